@@ -0,0 +1,18 @@
+package gomavlib
+
+// BackpressurePolicy is the policy applied to writes when the write queue of
+// a channel is full, that is when the channel cannot keep up with them.
+type BackpressurePolicy int
+
+const (
+	// BackpressurePolicyBlock waits for the channel to accept the write.
+	BackpressurePolicyBlock BackpressurePolicy = iota
+
+	// BackpressurePolicyDropOldest discards the oldest queued write in order
+	// to make room for the new one. If the write queue is not enabled, the
+	// new write is discarded.
+	BackpressurePolicyDropOldest
+
+	// BackpressurePolicyDropNewest discards the new write.
+	BackpressurePolicyDropNewest
+)
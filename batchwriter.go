@@ -0,0 +1,57 @@
+package gomavlib
+
+import (
+	"io"
+)
+
+// batchMaxSize is the maximum size of a write performed by a batchWriter.
+// Batches that are bigger are split into multiple writes.
+const batchMaxSize = 8192
+
+// writeBatch is a group of messages or frames that are written together.
+type writeBatch []interface{}
+
+// batchWriter is a writer that is able to collect several writes and flush
+// them with a single call to the underlying writer.
+type batchWriter struct {
+	w        io.Writer
+	batching bool
+	buf      []byte
+}
+
+func (w *batchWriter) Write(p []byte) (int, error) {
+	if !w.batching {
+		return w.w.Write(p)
+	}
+
+	if len(w.buf)+len(p) > batchMaxSize {
+		err := w.flush()
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	w.buf = append(w.buf, p...)
+	return len(p), nil
+}
+
+// begin starts collecting writes.
+func (w *batchWriter) begin() {
+	w.batching = true
+}
+
+// end writes collected data and stops collecting writes.
+func (w *batchWriter) end() error {
+	w.batching = false
+	return w.flush()
+}
+
+func (w *batchWriter) flush() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+
+	_, err := w.w.Write(w.buf)
+	w.buf = w.buf[:0]
+	return err
+}
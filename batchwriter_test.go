@@ -0,0 +1,97 @@
+package gomavlib
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+	"github.com/aler9/gomavlib/pkg/frame"
+	"github.com/aler9/gomavlib/pkg/msg"
+	"github.com/aler9/gomavlib/pkg/transceiver"
+)
+
+func TestNodeWriteMessagesAll(t *testing.T) {
+	l1 := newTestPipe()
+	l2 := newTestPipe()
+
+	node, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 10,
+		Endpoints: []EndpointConf{
+			EndpointCustom{ReadWriteCloser: &testEndpoint{l1, l2}},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node.Close()
+
+	go func() {
+		for range node.Events() {
+		}
+	}()
+
+	var ms []msg.Message
+	for i := 0; i < 5; i++ {
+		ms = append(ms, &common.MessageParamValue{
+			ParamId:    "PARAM",
+			ParamIndex: uint16(i),
+			ParamCount: 5,
+		})
+	}
+	node.WriteMessagesAll(ms)
+
+	// all frames are received with a single write
+	buf := <-l2.ch
+
+	dialectDE, err := dialect.NewDecEncoder(common.Dialect)
+	require.NoError(t, err)
+
+	tr, err := transceiver.New(transceiver.Conf{
+		Reader:      bytes.NewReader(buf),
+		Writer:      &bytes.Buffer{},
+		DialectDE:   dialectDE,
+		OutVersion:  transceiver.V2,
+		OutSystemID: 1,
+	})
+	require.NoError(t, err)
+
+	for i := 0; i < 5; i++ {
+		fr, err := tr.Read()
+		require.NoError(t, err)
+		require.Equal(t, ms[i], fr.GetMessage())
+		require.Equal(t, byte(i), fr.(*frame.V2Frame).SequenceID)
+	}
+}
+
+func TestBatchWriterSplit(t *testing.T) {
+	var writes [][]byte
+	w := &batchWriter{w: writerFunc(func(p []byte) (int, error) {
+		writes = append(writes, append([]byte(nil), p...))
+		return len(p), nil
+	})}
+
+	w.begin()
+	for i := 0; i < 3; i++ {
+		w.Write(bytes.Repeat([]byte{byte(i)}, batchMaxSize/2)) //nolint:errcheck
+	}
+	err := w.end()
+	require.NoError(t, err)
+
+	require.Equal(t, 2, len(writes))
+	require.Equal(t, batchMaxSize, len(writes[0]))
+	require.Equal(t, batchMaxSize/2, len(writes[1]))
+
+	// writes are not collected outside batches
+	w.Write([]byte{1, 2, 3}) //nolint:errcheck
+	require.Equal(t, 3, len(writes))
+}
+
+type writerFunc func([]byte) (int, error)
+
+func (f writerFunc) Write(p []byte) (int, error) {
+	return f(p)
+}
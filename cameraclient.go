@@ -0,0 +1,310 @@
+package gomavlib
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// CameraMode is the mode of a camera (CAMERA_MODE).
+type CameraMode int
+
+// camera modes.
+const (
+	CameraModeImage       CameraMode = 0
+	CameraModeVideo       CameraMode = 1
+	CameraModeImageSurvey CameraMode = 2
+)
+
+// String implements fmt.Stringer.
+func (m CameraMode) String() string {
+	switch m {
+	case CameraModeImage:
+		return "image"
+	case CameraModeVideo:
+		return "video"
+	case CameraModeImageSurvey:
+		return "image survey"
+	}
+	return "unknown"
+}
+
+// CameraInformation contains the capabilities of a camera (CAMERA_INFORMATION).
+type CameraInformation struct {
+	VendorName        string
+	ModelName         string
+	FirmwareVersion   uint32
+	FocalLength       float32 // mm
+	SensorSizeH       float32 // mm
+	SensorSizeV       float32 // mm
+	ResolutionH       int     // pixels
+	ResolutionV       int     // pixels
+	LensID            int
+	Flags             uint32 // CAMERA_CAP_FLAGS
+	DefinitionVersion int
+	DefinitionURI     string
+}
+
+// CameraSettings contains the settings of a camera (CAMERA_SETTINGS).
+type CameraSettings struct {
+	Mode       CameraMode
+	ZoomLevel  float32
+	FocusLevel float32
+}
+
+// CameraImage is an image captured by a camera (CAMERA_IMAGE_CAPTURED).
+type CameraImage struct {
+	// capture time. It is zero if unknown.
+	Time        time.Time
+	CameraID    int
+	Index       int
+	Lat         int32 // degE7
+	Lon         int32 // degE7
+	Alt         int32 // mm, MSL
+	RelativeAlt int32 // mm, above ground
+	Q           [4]float32
+	Success     bool
+	FileURL     string
+}
+
+// CameraClientConf allows to configure a CameraClient.
+type CameraClientConf struct {
+	// the node used to communicate.
+	// Its dialect must contain the COMMAND_* and CAMERA_* messages.
+	Node *Node
+	// the camera.
+	Target Target
+
+	// (optional) callback invoked when the camera captures an image.
+	// Images that are reported as missing are requested again.
+	OnImageCaptured func(*CameraImage)
+	// (optional) the time to wait for a requested message.
+	// It defaults to 1 second.
+	Timeout time.Duration
+}
+
+// CameraClient implements the client side of the camera protocol, that
+// allows to read the capabilities and settings of a camera and to capture
+// images and videos.
+type CameraClient struct {
+	conf       CameraClientConf
+	captureSeq int32
+	frameService
+}
+
+// NewCameraClient allocates a CameraClient. See CameraClientConf for the options.
+func NewCameraClient(conf CameraClientConf) (*CameraClient, error) {
+	if conf.Node == nil {
+		return nil, fmt.Errorf("Node not provided")
+	}
+	if conf.Timeout == 0 {
+		conf.Timeout = 1 * time.Second
+	}
+
+	d := conf.Node.conf.Dialect
+	if dialectMessage(d, 76, 152) == nil ||
+		dialectMessage(d, 77, 143) == nil ||
+		dialectMessage(d, 259, 92) == nil ||
+		dialectMessage(d, 260, 146) == nil ||
+		dialectMessage(d, 263, 133) == nil {
+		return nil, fmt.Errorf("dialect does not contain the camera protocol messages")
+	}
+
+	c := &CameraClient{
+		conf: conf,
+	}
+
+	c.start(conf.Node, func(evt *EventFrame) bool {
+		return evt.Message().GetID() == 263 && conf.Target.matches(evt)
+	}, 256, c.run)
+
+	return c, nil
+}
+
+// Close closes the client.
+func (c *CameraClient) Close() {
+	c.close()
+}
+
+// cameraClientWindow is the number of image indexes, preceding the last
+// one, that are tracked in order to detect lost images.
+const cameraClientWindow = 64
+
+func (c *CameraClient) run() {
+	// lost images are requested by a dedicated routine, in order not to
+	// block the reception of new images.
+	missing := make(chan int, cameraClientWindow)
+	requesterDone := make(chan struct{})
+	go c.runRequester(missing, requesterDone)
+	defer func() { <-requesterDone }()
+
+	received := make(map[int]struct{})
+	next := -1
+
+	for {
+		select {
+		case evt := <-c.fw.frames:
+			img := cameraImageDecode(evt)
+
+			// indexes restarted: a new capture session has begun
+			if next >= 0 && img.Index < next-cameraClientWindow {
+				received = make(map[int]struct{})
+				next = -1
+			}
+
+			if _, ok := received[img.Index]; ok {
+				continue
+			}
+			received[img.Index] = struct{}{}
+
+			if c.conf.OnImageCaptured != nil {
+				c.conf.OnImageCaptured(img)
+			}
+
+			// request images that have been lost
+			if next >= 0 {
+				first := next
+				if first < img.Index-cameraClientWindow {
+					first = img.Index - cameraClientWindow
+				}
+				for i := first; i < img.Index; i++ {
+					if _, ok := received[i]; !ok {
+						select {
+						case missing <- i:
+						default:
+						}
+					}
+				}
+			}
+			if img.Index >= next {
+				next = img.Index + 1
+			}
+
+			for i := range received {
+				if i < next-cameraClientWindow {
+					delete(received, i)
+				}
+			}
+
+		case <-c.ctx.Done():
+			return
+		}
+	}
+}
+
+func (c *CameraClient) runRequester(missing chan int, done chan struct{}) {
+	defer close(done)
+
+	for {
+		select {
+		case i := <-missing:
+			c.conf.Node.runCommand(c.ctx, c.conf.Target, 512, 263, float32(i))
+
+		case <-c.ctx.Done():
+			return
+		}
+	}
+}
+
+func cameraImageDecode(evt *EventFrame) *CameraImage {
+	m := evt.Message()
+
+	img := &CameraImage{
+		CameraID:    int(messageGetInt(m, "CameraId")),
+		Index:       int(messageGetInt(m, "ImageIndex")),
+		Lat:         int32(messageGetInt(m, "Lat")),
+		Lon:         int32(messageGetInt(m, "Lon")),
+		Alt:         int32(messageGetInt(m, "Alt")),
+		RelativeAlt: int32(messageGetInt(m, "RelativeAlt")),
+		Success:     messageGetInt(m, "CaptureResult") == 1,
+		FileURL:     messageGetString(m, "FileUrl"),
+	}
+
+	if t := messageGetInt(m, "TimeUtc"); t != 0 {
+		img.Time = time.Unix(0, t*int64(time.Microsecond))
+	}
+
+	q := messageGet(m, "Q")
+	for i := range img.Q {
+		img.Q[i] = float32(q.Index(i).Float())
+	}
+
+	return img
+}
+
+func cameraString(buf []byte) string {
+	if i := bytes.IndexByte(buf, 0); i >= 0 {
+		buf = buf[:i]
+	}
+	return string(buf)
+}
+
+// Information returns the capabilities of the camera.
+func (c *CameraClient) Information(ctx context.Context) (*CameraInformation, error) {
+	evt, err := c.conf.Node.requestMessage(ctx, c.conf.Target, 259, nil, c.conf.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	m := evt.Message()
+
+	return &CameraInformation{
+		VendorName:        cameraString(messageGetBytes(m, "VendorName", 32)),
+		ModelName:         cameraString(messageGetBytes(m, "ModelName", 32)),
+		FirmwareVersion:   uint32(messageGetInt(m, "FirmwareVersion")),
+		FocalLength:       float32(messageGetFloat(m, "FocalLength")),
+		SensorSizeH:       float32(messageGetFloat(m, "SensorSizeH")),
+		SensorSizeV:       float32(messageGetFloat(m, "SensorSizeV")),
+		ResolutionH:       int(messageGetInt(m, "ResolutionH")),
+		ResolutionV:       int(messageGetInt(m, "ResolutionV")),
+		LensID:            int(messageGetInt(m, "LensId")),
+		Flags:             uint32(messageGetInt(m, "Flags")),
+		DefinitionVersion: int(messageGetInt(m, "CamDefinitionVersion")),
+		DefinitionURI:     messageGetString(m, "CamDefinitionUri"),
+	}, nil
+}
+
+// Settings returns the settings of the camera.
+func (c *CameraClient) Settings(ctx context.Context) (*CameraSettings, error) {
+	evt, err := c.conf.Node.requestMessage(ctx, c.conf.Target, 260, nil, c.conf.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	m := evt.Message()
+
+	return &CameraSettings{
+		Mode:       CameraMode(messageGetInt(m, "ModeId")),
+		ZoomLevel:  float32(messageGetFloat(m, "Zoomlevel")),
+		FocusLevel: float32(messageGetFloat(m, "Focuslevel")),
+	}, nil
+}
+
+// StartImageCapture starts capturing count images (0 means unlimited),
+// separated by the given interval.
+func (c *CameraClient) StartImageCapture(ctx context.Context, interval time.Duration, count int) error {
+	// single captures carry a sequence number, that prevents double
+	// captures in case the command is sent again.
+	seq := int32(0)
+	if count == 1 {
+		seq = atomic.AddInt32(&c.captureSeq, 1)
+	}
+
+	return c.conf.Node.runCommand(ctx, c.conf.Target, 2000,
+		0, float32(interval.Seconds()), float32(count), float32(seq))
+}
+
+// StopImageCapture stops capturing images.
+func (c *CameraClient) StopImageCapture(ctx context.Context) error {
+	return c.conf.Node.runCommand(ctx, c.conf.Target, 2001, 0)
+}
+
+// StartVideoCapture starts recording a video stream (0 means all streams).
+func (c *CameraClient) StartVideoCapture(ctx context.Context, streamID int) error {
+	return c.conf.Node.runCommand(ctx, c.conf.Target, 2500, float32(streamID))
+}
+
+// StopVideoCapture stops recording a video stream (0 means all streams).
+func (c *CameraClient) StopVideoCapture(ctx context.Context, streamID int) error {
+	return c.conf.Node.runCommand(ctx, c.conf.Target, 2501, float32(streamID))
+}
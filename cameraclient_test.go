@@ -0,0 +1,121 @@
+package gomavlib
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+)
+
+func TestCameraClient(t *testing.T) {
+	node1, node2 := newTestNodePair(t, common.Dialect)
+	defer node1.Close()
+	defer node2.Close()
+
+	go func() {
+		for range node1.Events() {
+		}
+	}()
+
+	image := func(i int) *common.MessageCameraImageCaptured {
+		return &common.MessageCameraImageCaptured{
+			ImageIndex:    int32(i),
+			CaptureResult: 1,
+			FileUrl:       "img" + string(rune('0'+i)) + ".jpg",
+		}
+	}
+
+	// a camera that loses the second image
+	go func() {
+		for evt := range node2.Events() {
+			fr, ok := evt.(*EventFrame)
+			if !ok {
+				continue
+			}
+
+			m, ok := fr.Message().(*common.MessageCommandLong)
+			if !ok {
+				continue
+			}
+
+			node2.WriteMessageAll(&common.MessageCommandAck{
+				Command: m.Command,
+				Result:  0,
+			})
+
+			switch {
+			case m.Command == 512 && m.Param1 == 259:
+				res := &common.MessageCameraInformation{
+					ResolutionH:      1920,
+					ResolutionV:      1080,
+					CamDefinitionUri: "http://camera/def.xml",
+				}
+				copy(res.VendorName[:], "vendor")
+				copy(res.ModelName[:], "model")
+				node2.WriteMessageAll(res)
+
+			case m.Command == 512 && m.Param1 == 260:
+				node2.WriteMessageAll(&common.MessageCameraSettings{
+					ModeId:    1,
+					Zoomlevel: 50,
+				})
+
+			case m.Command == 512 && m.Param1 == 263:
+				node2.WriteMessageAll(image(int(m.Param2)))
+
+			case m.Command == 2000:
+				node2.WriteMessageAll(image(0))
+				node2.WriteMessageAll(image(2))
+			}
+		}
+	}()
+
+	var mutex sync.Mutex
+	var images []string
+	imagesDone := make(chan struct{})
+
+	c, err := NewCameraClient(CameraClientConf{
+		Node: node1,
+		Target: Target{
+			SystemID:    11,
+			ComponentID: 1,
+		},
+		OnImageCaptured: func(img *CameraImage) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			images = append(images, img.FileURL)
+			if len(images) == 3 {
+				close(imagesDone)
+			}
+		},
+	})
+	require.NoError(t, err)
+	defer c.Close()
+
+	info, err := c.Information(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, &CameraInformation{
+		VendorName:    "vendor",
+		ModelName:     "model",
+		ResolutionH:   1920,
+		ResolutionV:   1080,
+		DefinitionURI: "http://camera/def.xml",
+	}, info)
+
+	settings, err := c.Settings(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, &CameraSettings{
+		Mode:      CameraModeVideo,
+		ZoomLevel: 50,
+	}, settings)
+
+	err = c.StartImageCapture(context.Background(), time.Second, 3)
+	require.NoError(t, err)
+
+	<-imagesDone
+	require.Equal(t, []string{"img0.jpg", "img2.jpg", "img1.jpg"}, images)
+}
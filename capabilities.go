@@ -0,0 +1,194 @@
+package gomavlib
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// FirmwareVersion is the version of a software running on a component,
+// encoded as in AUTOPILOT_VERSION.
+type FirmwareVersion struct {
+	Major int
+	Minor int
+	Patch int
+	// FIRMWARE_VERSION_TYPE, for instance 255 for official releases.
+	Type int
+}
+
+func decodeFirmwareVersion(v uint32) FirmwareVersion {
+	return FirmwareVersion{
+		Major: int(v >> 24),
+		Minor: int((v >> 16) & 0xFF),
+		Patch: int((v >> 8) & 0xFF),
+		Type:  int(v & 0xFF),
+	}
+}
+
+// String implements fmt.Stringer.
+func (v FirmwareVersion) String() string {
+	return strconv.FormatInt(int64(v.Major), 10) + "." +
+		strconv.FormatInt(int64(v.Minor), 10) + "." +
+		strconv.FormatInt(int64(v.Patch), 10)
+}
+
+// AutopilotVersion contains the capabilities and the software versions of a
+// component (AUTOPILOT_VERSION).
+type AutopilotVersion struct {
+	// bitmask of MAV_PROTOCOL_CAPABILITY
+	Capabilities      uint64
+	FlightVersion     FirmwareVersion
+	MiddlewareVersion FirmwareVersion
+	OSVersion         FirmwareVersion
+	BoardVersion      uint32
+	VendorID          uint16
+	ProductID         uint16
+	UID               uint64
+}
+
+// HasCapability checks whether the component supports a capability, that
+// is a MAV_PROTOCOL_CAPABILITY flag.
+func (v *AutopilotVersion) HasCapability(capability uint64) bool {
+	return (v.Capabilities & capability) == capability
+}
+
+// CapabilitiesClientConf allows to configure a CapabilitiesClient.
+type CapabilitiesClientConf struct {
+	// the node used to communicate.
+	// Its dialect must contain the COMMAND_* and AUTOPILOT_VERSION messages.
+	Node *Node
+
+	// (optional) the time to wait for AUTOPILOT_VERSION after the request
+	// has been accepted. It defaults to 1 second.
+	Timeout time.Duration
+}
+
+// CapabilitiesClient discovers the capabilities and the software versions
+// of components, through MAV_CMD_REQUEST_AUTOPILOT_CAPABILITIES, falling back
+// to MAV_CMD_REQUEST_MESSAGE when the command is not supported.
+// Results are cached for each component.
+type CapabilitiesClient struct {
+	conf     CapabilitiesClientConf
+	mutex    sync.Mutex
+	versions map[systemKey]*AutopilotVersion
+}
+
+// NewCapabilitiesClient allocates a CapabilitiesClient.
+// See CapabilitiesClientConf for the options.
+func NewCapabilitiesClient(conf CapabilitiesClientConf) (*CapabilitiesClient, error) {
+	if conf.Node == nil {
+		return nil, fmt.Errorf("Node not provided")
+	}
+	if conf.Timeout == 0 {
+		conf.Timeout = 1 * time.Second
+	}
+
+	d := conf.Node.conf.Dialect
+	if dialectMessage(d, 76, 152) == nil ||
+		dialectMessage(d, 77, 143) == nil ||
+		dialectMessage(d, 148, 178) == nil {
+		return nil, fmt.Errorf("dialect does not contain the command protocol and AUTOPILOT_VERSION messages")
+	}
+
+	return &CapabilitiesClient{
+		conf:     conf,
+		versions: make(map[systemKey]*AutopilotVersion),
+	}, nil
+}
+
+func capabilitiesKey(t Target) systemKey {
+	return systemKey{
+		SystemID:    t.SystemID,
+		ComponentID: t.ComponentID,
+	}
+}
+
+// Get returns the capabilities and the software versions of a component.
+// They are requested to the component the first time, then they are
+// returned from the cache.
+func (c *CapabilitiesClient) Get(ctx context.Context, t Target) (*AutopilotVersion, error) {
+	c.mutex.Lock()
+	v, ok := c.versions[capabilitiesKey(t)]
+	c.mutex.Unlock()
+
+	if ok {
+		return v, nil
+	}
+
+	return c.Refresh(ctx, t)
+}
+
+// Refresh requests the capabilities and the software versions of a
+// component and updates the cache.
+func (c *CapabilitiesClient) Refresh(ctx context.Context, t Target) (*AutopilotVersion, error) {
+	evt, err := c.request(ctx, t)
+	if err != nil {
+		return nil, err
+	}
+	m := evt.Message()
+
+	v := &AutopilotVersion{
+		Capabilities:      uint64(messageGetInt(m, "Capabilities")),
+		FlightVersion:     decodeFirmwareVersion(uint32(messageGetInt(m, "FlightSwVersion"))),
+		MiddlewareVersion: decodeFirmwareVersion(uint32(messageGetInt(m, "MiddlewareSwVersion"))),
+		OSVersion:         decodeFirmwareVersion(uint32(messageGetInt(m, "OsSwVersion"))),
+		BoardVersion:      uint32(messageGetInt(m, "BoardVersion")),
+		VendorID:          uint16(messageGetInt(m, "VendorId")),
+		ProductID:         uint16(messageGetInt(m, "ProductId")),
+		UID:               uint64(messageGetInt(m, "Uid")),
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.versions[capabilitiesKey(t)] = v
+
+	return v, nil
+}
+
+// Invalidate removes a component from the cache, for instance after it
+// has been rebooted or updated.
+func (c *CapabilitiesClient) Invalidate(t Target) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	delete(c.versions, capabilitiesKey(t))
+}
+
+func (c *CapabilitiesClient) request(ctx context.Context, t Target) (*EventFrame, error) {
+	// the message can be sent before the acknowledgement
+	fw := c.conf.Node.nodeWaiters.add(func(evt *EventFrame) bool {
+		return evt.Message().GetID() == 148 && t.matches(evt)
+	}, 1)
+	defer c.conf.Node.nodeWaiters.remove(fw)
+
+	res, err := c.conf.Node.SendCommand(ctx, t, 520, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	switch res {
+	case CommandResultAccepted:
+
+	// the command is deprecated and can be missing in recent flight stacks
+	case CommandResultUnsupported:
+		return c.conf.Node.requestMessage(ctx, t, 148, nil, c.conf.Timeout)
+
+	default:
+		return nil, fmt.Errorf("command 520: %s", res)
+	}
+
+	timerC, stopTimer := c.conf.Node.conf.Clock.NewTimer(c.conf.Timeout)
+	defer stopTimer()
+
+	select {
+	case evt := <-fw.frames:
+		return evt, nil
+
+	case <-timerC:
+		return nil, fmt.Errorf("timeout")
+
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
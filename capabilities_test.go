@@ -0,0 +1,101 @@
+package gomavlib
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+)
+
+func TestCapabilitiesClient(t *testing.T) {
+	for _, ca := range []string{"capabilities", "request message"} {
+		t.Run(ca, func(t *testing.T) {
+			node1, node2 := newTestNodePair(t, common.Dialect)
+			defer node1.Close()
+			defer node2.Close()
+
+			go func() {
+				for range node1.Events() {
+				}
+			}()
+
+			var requests int32
+
+			go func() {
+				for evt := range node2.Events() {
+					fr, ok := evt.(*EventFrame)
+					if !ok {
+						continue
+					}
+
+					m, ok := fr.Message().(*common.MessageCommandLong)
+					if !ok {
+						continue
+					}
+
+					version := &common.MessageAutopilotVersion{
+						Capabilities:    common.MAV_PROTOCOL_CAPABILITY_MISSION_INT | common.MAV_PROTOCOL_CAPABILITY_FTP,
+						FlightSwVersion: 4<<24 | 3<<16 | 2<<8 | 255,
+						VendorId:        10,
+						ProductId:       20,
+						Uid:             1234,
+					}
+
+					switch {
+					case m.Command == 520 && ca == "capabilities":
+						atomic.AddInt32(&requests, 1)
+						// Ardupilot sends the message before the acknowledgement
+						node2.WriteMessageAll(version)
+						node2.WriteMessageAll(&common.MessageCommandAck{Command: m.Command, Result: 0})
+
+					case m.Command == 520:
+						node2.WriteMessageAll(&common.MessageCommandAck{Command: m.Command, Result: 3})
+
+					case m.Command == 512 && m.Param1 == 148:
+						atomic.AddInt32(&requests, 1)
+						node2.WriteMessageAll(&common.MessageCommandAck{Command: m.Command, Result: 0})
+						node2.WriteMessageAll(version)
+					}
+				}
+			}()
+
+			c, err := NewCapabilitiesClient(CapabilitiesClientConf{
+				Node: node1,
+			})
+			require.NoError(t, err)
+
+			target := Target{SystemID: 11, ComponentID: 1}
+
+			v, err := c.Get(context.Background(), target)
+			require.NoError(t, err)
+			require.Equal(t, &AutopilotVersion{
+				Capabilities: uint64(common.MAV_PROTOCOL_CAPABILITY_MISSION_INT | common.MAV_PROTOCOL_CAPABILITY_FTP),
+				FlightVersion: FirmwareVersion{
+					Major: 4,
+					Minor: 3,
+					Patch: 2,
+					Type:  255,
+				},
+				VendorID:  10,
+				ProductID: 20,
+				UID:       1234,
+			}, v)
+			require.Equal(t, "4.3.2", v.FlightVersion.String())
+			require.True(t, v.HasCapability(uint64(common.MAV_PROTOCOL_CAPABILITY_FTP)))
+			require.False(t, v.HasCapability(uint64(common.MAV_PROTOCOL_CAPABILITY_MAVLINK2)))
+
+			// cached
+			_, err = c.Get(context.Background(), target)
+			require.NoError(t, err)
+			require.Equal(t, int32(1), atomic.LoadInt32(&requests))
+
+			c.Invalidate(target)
+			_, err = c.Get(context.Background(), target)
+			require.NoError(t, err)
+			require.Equal(t, int32(2), atomic.LoadInt32(&requests))
+		})
+	}
+}
@@ -2,6 +2,11 @@ package gomavlib
 
 import (
 	"io"
+	"math/rand"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/aler9/gomavlib/pkg/frame"
 	"github.com/aler9/gomavlib/pkg/msg"
@@ -16,57 +21,261 @@ type Channel struct {
 	e           Endpoint
 	label       string
 	rwc         io.ReadWriteCloser
+	rwcClose    sync.Once
 	n           *Node
 	transceiver *transceiver.Transceiver
+	highLatency bool
+	noHeartbeat bool
+	activePeer  uint32 // atomic, set when a heartbeat is received
+	policy      BackpressurePolicy
+	limiter     *rateLimiter
+	downsampler *downsampler
+	batch       *batchWriter
+	transforms  []func(*Channel, frame.Frame) frame.Frame
+	translation []IDTranslation
+	streamFreq  int
+	inFaults    *faultInjector
+	outFaults   *faultInjector
+	stats       *channelStats
 	running     bool
+	created     time.Time
+	reconnected bool
 
 	// in
-	write     chan interface{}
-	terminate chan struct{}
+	write           chan interface{}
+	writeHigh       chan interface{}
+	writerTerminate chan struct{}
+	terminate       chan struct{}
+
+	// out
+	writerDone chan struct{}
 }
 
 func newChannel(n *Node, e Endpoint, label string, rwc io.ReadWriteCloser) (*Channel, error) {
+	opts := endpointChannelOptions(e)
+	stats := &channelStats{}
+
+	var writer io.Writer = &countingWriter{w: rwc, n: &stats.bytesOut}
+	var reader io.Reader = &countingReader{r: rwc, n: &stats.bytesIn}
+
+	faultSeed := opts.faultSeed
+	if faultSeed == 0 {
+		faultSeed = time.Now().UnixNano()
+	}
+
+	var outFaults *faultInjector
+	if opts.outFaults != nil {
+		out := writer
+		outFaults = newFaultInjector(*opts.outFaults, n.conf.Clock, faultSeed,
+			func(what interface{}) {
+				out.Write(what.([]byte)) //nolint:errcheck
+			},
+			func(what interface{}) interface{} {
+				return append([]byte(nil), what.([]byte)...)
+			},
+			nil)
+		writer = &faultWriter{fi: outFaults}
+	}
+
+	if opts.inFaults != nil && opts.inFaults.CorruptProbability > 0 {
+		reader = &faultReader{
+			r:           reader,
+			rand:        rand.New(rand.NewSource(faultSeed + 1)),
+			probability: opts.inFaults.CorruptProbability,
+		}
+	}
+
+	var downsampler *downsampler
+	if len(opts.outRates) != 0 {
+		downsampler = newDownsampler(opts.outRates)
+	}
+
+	var limiter *rateLimiter
+	if opts.outBytesPerSecond > 0 || opts.outFramesPerSecond > 0 {
+		limiter = newRateLimiter(opts.outBytesPerSecond, opts.outFramesPerSecond, n.conf.Clock.Now())
+		writer = &rateLimitedWriter{w: writer, l: limiter}
+	}
+
+	batch := &batchWriter{w: writer}
+
+	outVersion := n.conf.OutVersion
+	inKey := n.conf.InKey
+	outKey := n.conf.OutKey
+
+	if opts.signature {
+		inKey = opts.inKey
+		outKey = opts.outKey
+	}
+
+	if opts.outVersion != 0 {
+		outVersion = opts.outVersion
+	}
+
+	// signing is possible only with V2 frames
+	if (opts.outVersion != 0 || opts.signature) && outVersion != V2 {
+		inKey = nil
+		outKey = nil
+	}
+
+	acceptUnknownFlags := n.conf.InIncompatibilityFlagPolicy == IncompatibilityFlagPolicyEvent
+
+	ch := &Channel{}
+
+	// outgoing frames pass through the interceptors of the node, then
+	// through the transforms and the translation of the endpoint
+	var outStages []func(frame.Frame) frame.Frame
+	if len(n.conf.FrameInterceptors) != 0 {
+		outStages = append(outStages, func(fr frame.Frame) frame.Frame {
+			return ch.intercept(FrameDirectionOut, fr)
+		})
+	}
+	if len(opts.outTransforms) != 0 {
+		outStages = append(outStages, ch.outTransform)
+	}
+	if len(opts.idTranslations) != 0 {
+		outStages = append(outStages, ch.translateOut)
+	}
+
+	var outFrameHook func(frame.Frame) frame.Frame
+	switch len(outStages) {
+	case 0:
+
+	case 1:
+		outFrameHook = outStages[0]
+
+	default:
+		outFrameHook = func(fr frame.Frame) frame.Frame {
+			for _, stage := range outStages {
+				fr = stage(fr)
+				if fr == nil {
+					return nil
+				}
+			}
+			return fr
+		}
+	}
+
+	var inFaults *faultInjector
+	if opts.inFaults != nil {
+		inFaults = newFaultInjector(*opts.inFaults, n.conf.Clock, faultSeed+2,
+			func(what interface{}) {
+				ch.onFrame(what.(frame.Frame))
+			},
+			func(what interface{}) interface{} {
+				return what.(frame.Frame).Clone()
+			},
+			func(what interface{}) {
+				if n.conf.EventFramePool {
+					transceiver.ReleaseFrame(what.(frame.Frame))
+				}
+			})
+	}
+
 	transceiver, err := transceiver.New(transceiver.Conf{
-		Reader:      rwc,
-		Writer:      rwc,
-		DialectDE:   n.dialectDE,
-		InKey:       n.conf.InKey,
-		OutSystemID: n.conf.OutSystemID,
+		Reader:                            reader,
+		Writer:                            batch,
+		DialectDE:                         n.dialectDE,
+		CRCExtras:                         n.conf.CRCExtras,
+		InKey:                             inKey,
+		InSignatureWindow:                 n.conf.InSignatureWindow,
+		DiscardUnknown:                    n.conf.DiscardUnknownMessages,
+		AcceptUnknownIncompatibilityFlags: acceptUnknownFlags,
+		DecodeDisable:                     n.conf.DecodeWorkers > 0,
+		FramePool:                         n.conf.EventFramePool,
+		OutSystemID:                       n.conf.OutSystemID,
 		OutVersion: func() transceiver.Version {
-			if n.conf.OutVersion == V2 {
+			switch outVersion {
+			case V2:
 				return transceiver.V2
+			case VAuto:
+				return transceiver.VAuto
 			}
 			return transceiver.V1
 		}(),
-		OutComponentID:     n.conf.OutComponentID,
-		OutSignatureLinkID: randomByte(),
-		OutKey:             n.conf.OutKey,
+		OutComponentID:       n.conf.OutComponentID,
+		OutSignatureLinkID:   randomByte(),
+		OutKey:               outKey,
+		OutCompatibilityFlag: n.conf.OutCompatibilityFlag,
+		OutTruncationDisable: opts.outTruncationDisable,
+		OutExtensionsDisable: opts.outExtensionsDisable,
+		OutFrameHook:         outFrameHook,
+		OutResign:            n.conf.OutResign,
+		OutResequence:        n.conf.OutResequence,
+		TimeNow:              n.conf.Clock.Now,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	return &Channel{
-		e:           e,
-		label:       label,
-		rwc:         rwc,
-		n:           n,
-		transceiver: transceiver,
-		write:       make(chan interface{}),
-		terminate:   make(chan struct{}),
-	}, nil
+	// streams are requested by the endpoint even if the node does not
+	// request them
+	streamFreq := 0
+	if opts.streamRequest {
+		streamFreq = opts.streamFrequency
+		if streamFreq == 0 {
+			streamFreq = n.conf.StreamRequestFrequency
+		}
+	}
+
+	writeQueueSize := n.conf.WriteQueueSize
+	if opts.writeQueueSize != 0 {
+		writeQueueSize = opts.writeQueueSize
+	}
+
+	*ch = Channel{
+		e:               e,
+		label:           label,
+		rwc:             rwc,
+		n:               n,
+		transceiver:     transceiver,
+		highLatency:     opts.highLatency,
+		noHeartbeat:     opts.heartbeatDisable,
+		policy:          opts.backpressurePolicy,
+		limiter:         limiter,
+		downsampler:     downsampler,
+		transforms:      opts.outTransforms,
+		translation:     opts.idTranslations,
+		streamFreq:      streamFreq,
+		inFaults:        inFaults,
+		outFaults:       outFaults,
+		batch:           batch,
+		stats:           stats,
+		created:         n.conf.Clock.Now(),
+		write:           make(chan interface{}, writeQueueSize),
+		writeHigh:       make(chan interface{}, writeQueueSize),
+		writerTerminate: make(chan struct{}),
+		terminate:       make(chan struct{}),
+		writerDone:      make(chan struct{}),
+	}
+	return ch, nil
+}
+
+// closeRWC closes the underlying ReadWriteCloser, that can be closed by
+// Close() too.
+func (ch *Channel) closeRWC() {
+	ch.rwcClose.Do(func() {
+		ch.rwc.Close()
+	})
+}
+
+// Close closes the channel. The channel is removed from the node and an
+// EventChannelClose is emitted. Channels of endpoints that provide a single
+// channel, like clients, serial ports and custom endpoints, are not reopened.
+func (ch *Channel) Close() {
+	ch.closeRWC()
 }
 
 func (ch *Channel) close() {
 	if ch.running {
 		close(ch.terminate)
 	} else {
-		ch.rwc.Close()
+		ch.closeRWC()
 	}
 }
 
 func (ch *Channel) start() {
 	ch.running = true
+	ch.n.nodeChannelStats.onChannelOpen(ch)
 	ch.n.channelsWg.Add(1)
 	go ch.run()
 }
@@ -74,73 +283,357 @@ func (ch *Channel) start() {
 func (ch *Channel) run() {
 	defer ch.n.channelsWg.Done()
 
+	if ch.outFaults != nil {
+		ch.outFaults.start()
+	}
+
 	readerDone := make(chan struct{})
 	go func() {
 		defer close(readerDone)
 
+		if ch.inFaults != nil {
+			ch.inFaults.start()
+			defer ch.inFaults.close()
+		}
+
 		// wait client here, in order to allow the writer goroutine to start
 		// and allow clients to write messages before starting listening to events
-		ch.n.events <- &EventChannelOpen{ch}
+		ch.n.events <- &EventChannelOpen{
+			Channel:      ch,
+			RemoteAddr:   ch.RemoteAddr(),
+			EndpointConf: ch.e.Conf(),
+			Reconnection: ch.reconnected,
+		}
+
+		if ch.n.nodeDecoder != nil {
+			ch.readParallel()
+			return
+		}
 
 		for {
 			frame, err := ch.transceiver.Read()
 			if err != nil {
 				// continue in case of parse errors
 				if _, ok := err.(*transceiver.Error); ok {
-					ch.n.events <- &EventParseError{err, ch}
+					ch.onParseError(err)
 					continue
 				}
 				return
 			}
 
-			evt := &EventFrame{frame, ch}
-
-			if ch.n.nodeStreamRequest != nil {
-				ch.n.nodeStreamRequest.onEventFrame(evt)
-			}
-
-			ch.n.events <- evt
+			ch.receiveFrame(frame)
 		}
 	}()
 
-	writerDone := make(chan struct{})
 	go func() {
-		defer close(writerDone)
+		defer close(ch.writerDone)
 
-		for what := range ch.write {
-			switch wh := what.(type) {
-			case msg.Message:
-				ch.transceiver.WriteMessage(wh)
+		for {
+			var what interface{}
 
-			case frame.Frame:
-				ch.transceiver.WriteFrame(wh)
+			// high priority writes go first
+			select {
+			case what = <-ch.writeHigh:
+			default:
+				select {
+				case what = <-ch.writeHigh:
+				case what = <-ch.write:
+				case <-ch.writerTerminate:
+					return
+				}
+			}
+
+			if batch, ok := what.(writeBatch); ok {
+				ch.batch.begin()
+				for _, what := range batch {
+					ch.writeItem(what)
+				}
+				ch.batch.end() //nolint:errcheck
+				continue
 			}
+
+			ch.writeItem(what)
 		}
 	}()
 
 	select {
 	case <-readerDone:
+		ch.n.nodeChannelStats.onChannelClose(ch)
+		ch.n.nodeSystemStats.onChannelClose(ch)
+		if ch.n.nodeTimesync != nil {
+			ch.n.nodeTimesync.onChannelClose(ch)
+		}
 		ch.n.events <- &EventChannelClose{ch}
 
 		ch.n.channelClose <- ch
 		<-ch.terminate
 
-		close(ch.write)
-		<-writerDone
+		close(ch.writerTerminate)
+		<-ch.writerDone
 
-		ch.rwc.Close()
+		ch.closeRWC()
+		if ch.outFaults != nil {
+			ch.outFaults.close()
+		}
 
 	case <-ch.terminate:
+		ch.n.nodeChannelStats.onChannelClose(ch)
+		ch.n.nodeSystemStats.onChannelClose(ch)
+		if ch.n.nodeTimesync != nil {
+			ch.n.nodeTimesync.onChannelClose(ch)
+		}
 		ch.n.events <- &EventChannelClose{ch}
 
-		close(ch.write)
-		<-writerDone
+		close(ch.writerTerminate)
+		<-ch.writerDone
 
-		ch.rwc.Close()
+		ch.closeRWC()
+		if ch.outFaults != nil {
+			ch.outFaults.close()
+		}
 		<-readerDone
 	}
 }
 
+// readParallel reads frames and submits them to the node decoder, then
+// processes decoded frames in the same order they were read.
+func (ch *Channel) readParallel() {
+	pending := make(chan *decodeJob, decodeQueueSize)
+
+	processorDone := make(chan struct{})
+	go func() {
+		defer close(processorDone)
+
+		for job := range pending {
+			<-job.done
+			if job.err != nil {
+				ch.onParseError(job.err)
+				continue
+			}
+			ch.receiveFrame(job.fr)
+		}
+	}()
+
+	defer func() {
+		close(pending)
+		<-processorDone
+	}()
+
+	for {
+		frame, err := ch.transceiver.Read()
+		if err != nil {
+			// continue in case of parse errors
+			if _, ok := err.(*transceiver.Error); ok {
+				job := &decodeJob{err: err, done: make(chan struct{})}
+				close(job.done)
+				pending <- job
+				continue
+			}
+			return
+		}
+
+		pending <- ch.n.nodeDecoder.submit(frame)
+	}
+}
+
+func (ch *Channel) onParseError(err error) {
+	atomic.AddUint64(&ch.stats.parseErrors, 1)
+	switch err.(*transceiver.Error).Kind {
+	case transceiver.ErrBadSignature:
+		atomic.AddUint64(&ch.stats.signatureErrors, 1)
+		ch.n.events <- newEventSignatureRejected(ch, err.(*transceiver.Error))
+		return
+
+	case transceiver.ErrBadChecksum:
+		atomic.AddUint64(&ch.stats.checksumErrors, 1)
+
+	case transceiver.ErrUnknownMessage:
+		atomic.AddUint64(&ch.stats.unknownMessages, 1)
+	}
+	ch.n.events <- &EventParseError{err, ch}
+}
+
+// receiveFrame processes a frame, after passing it through the fault
+// injector, if any.
+func (ch *Channel) receiveFrame(fr frame.Frame) {
+	if ch.inFaults != nil {
+		ch.inFaults.push(fr)
+		return
+	}
+	ch.onFrame(fr)
+}
+
+func (ch *Channel) onFrame(fr frame.Frame) {
+	atomic.AddUint64(&ch.stats.framesIn, 1)
+
+	if len(ch.translation) != 0 {
+		ch.translateIn(fr)
+	}
+
+	if len(ch.n.conf.FrameInterceptors) != 0 {
+		fr = ch.interceptIn(fr)
+		if fr == nil {
+			return
+		}
+	}
+
+	if ff, ok := fr.(*frame.V2Frame); ok && ff.HasUnknownIncompatibilityFlags() {
+		ch.onUnknownIncompatibilityFlag(ff)
+		return
+	}
+
+	evt := newEventFrame(fr, ch, ch.n.conf.EventFramePool)
+
+	if lost := ch.n.nodeSystemStats.onEventFrame(evt); lost > 0 {
+		ch.n.events <- &EventFrameLoss{
+			Channel:     ch,
+			SystemID:    evt.SystemID(),
+			ComponentID: evt.ComponentID(),
+			Count:       lost,
+		}
+	}
+
+	if ch.n.nodeHeartbeat != nil {
+		ch.n.nodeHeartbeat.onEventFrame(evt)
+	}
+
+	if ch.n.nodeSystems != nil {
+		ch.n.nodeSystems.onEventFrame(evt)
+	}
+
+	if ch.n.nodeMission != nil {
+		ch.n.nodeMission.onEventFrame(evt)
+	}
+
+	if ch.n.nodeStreamRequest != nil {
+		ch.n.nodeStreamRequest.onEventFrame(evt)
+	}
+
+	if ch.n.nodeTimesync != nil {
+		ch.n.nodeTimesync.onEventFrame(evt)
+	}
+
+	if ch.n.nodePing != nil {
+		ch.n.nodePing.onEventFrame(evt)
+	}
+
+	ch.n.nodeWaiters.onEventFrame(evt)
+
+	ch.n.events <- evt
+}
+
+// onUnknownIncompatibilityFlag emits a frame with unknown incompatibility
+// flags. Since its layout may differ from the standard one, the frame is not
+// processed further.
+func (ch *Channel) onUnknownIncompatibilityFlag(ff *frame.V2Frame) {
+	if ch.n.conf.EventFramePool {
+		pooled := ff
+		ff = ff.Clone().(*frame.V2Frame)
+		transceiver.ReleaseFrame(pooled)
+	}
+
+	ch.n.events <- &EventUnknownIncompatibilityFlag{
+		Frame:   ff,
+		Channel: ch,
+	}
+}
+
+// enqueue sends a message or frame to the writer routine.
+// It can be called by multiple routines in parallel, and returns immediately
+// if the channel is closed.
+func (ch *Channel) enqueue(what interface{}) {
+	queue := ch.write
+	if pw, ok := what.(priorityWrite); ok {
+		what = pw.what
+		queue = ch.writeHigh
+	} else if ch.n.isHighPriority(what) {
+		queue = ch.writeHigh
+	}
+
+	switch {
+	case ch.policy == BackpressurePolicyDropNewest,
+		ch.policy == BackpressurePolicyDropOldest && cap(queue) == 0:
+		select {
+		case queue <- what:
+		case <-ch.writerDone:
+		default:
+			atomic.AddUint64(&ch.stats.writeDrops, 1)
+		}
+
+	case ch.policy == BackpressurePolicyDropOldest:
+		for {
+			select {
+			case queue <- what:
+				return
+			case <-ch.writerDone:
+				return
+			default:
+			}
+
+			// the queue is full, discard its oldest entry
+			select {
+			case <-queue:
+				atomic.AddUint64(&ch.stats.writeDrops, 1)
+			default:
+			}
+		}
+
+	default:
+		select {
+		case queue <- what:
+		case <-ch.writerDone:
+		}
+	}
+}
+
+func (ch *Channel) writeItem(what interface{}) {
+	// high latency channels only receive high latency messages,
+	// heartbeats and command acknowledgements
+	if hl, ok := what.(highLatencyWrite); ok {
+		if !ch.highLatency {
+			return
+		}
+		what = hl.m
+	} else if ch.highLatency && !highLatencyAllowed(what) {
+		return
+	}
+
+	// frames discarded by the downsampler must not consume the tokens of
+	// the rate limiter
+	if ch.downsampler != nil && !ch.downsample(what) {
+		return
+	}
+
+	if ch.limiter != nil && !ch.limiter.allow(ch.n.conf.Clock.Now()) {
+		return
+	}
+
+	var err error
+	switch wh := what.(type) {
+	case msg.Message:
+		err = ch.transceiver.WriteMessage(wh)
+
+	case frame.Frame:
+		err = ch.transceiver.WriteFrame(wh)
+	}
+	if err == nil {
+		atomic.AddUint64(&ch.stats.framesOut, 1)
+	}
+}
+
+// downsample checks whether a message or frame can be written, according to
+// the rates of the endpoint.
+func (ch *Channel) downsample(what interface{}) bool {
+	switch wh := what.(type) {
+	case msg.Message:
+		return ch.downsampler.allow(wh.GetID(), ch.n.conf.OutSystemID, ch.n.conf.OutComponentID, ch.n.conf.Clock.Now())
+
+	case frame.Frame:
+		return ch.downsampler.allow(wh.GetMessage().GetID(), wh.GetSystemID(), wh.GetComponentID(), ch.n.conf.Clock.Now())
+	}
+	return true
+}
+
 // String implements fmt.Stringer.
 func (ch *Channel) String() string {
 	return ch.label
@@ -150,3 +643,39 @@ func (ch *Channel) String() string {
 func (ch *Channel) Endpoint() Endpoint {
 	return ch.e
 }
+
+// EndpointKind returns the kind of the channel Endpoint.
+func (ch *Channel) EndpointKind() EndpointKind {
+	return endpointKind(ch.e.Conf())
+}
+
+// Label returns the channel label, that contains the endpoint kind and, if
+// available, the remote address. It is the same value returned by String().
+func (ch *Channel) Label() string {
+	return ch.label
+}
+
+// RemoteAddr returns the address of the remote peer, or nil if it is not
+// available, like in case of serial ports and custom endpoints.
+// The address of UDP broadcast channels is the broadcast address.
+func (ch *Channel) RemoteAddr() net.Addr {
+	if p, ok := ch.rwc.(remoteAddrProvider); ok {
+		return p.RemoteAddr()
+	}
+	return nil
+}
+
+// Version returns the Mavlink version currently used to encode messages
+// written to the channel, that is the result of the negotiation in case of
+// VAuto.
+func (ch *Channel) Version() Version {
+	if ch.transceiver.OutVersion() == transceiver.V2 {
+		return V2
+	}
+	return V1
+}
+
+// CreationTime returns the time at which the channel was created.
+func (ch *Channel) CreationTime() time.Time {
+	return ch.created
+}
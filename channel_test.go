@@ -0,0 +1,190 @@
+package gomavlib
+
+import (
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+func TestChannelInfo(t *testing.T) {
+	start := time.Now()
+
+	node1, err := NewNode(NodeConf{
+		Dialect:     &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:  V2,
+		OutSystemID: 10,
+		Endpoints: []EndpointConf{
+			EndpointTCPServer{Address: "127.0.0.1:5601"},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node1.Close()
+
+	node2, err := NewNode(NodeConf{
+		Dialect:     &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:  V2,
+		OutSystemID: 11,
+		Endpoints: []EndpointConf{
+			EndpointVersion{
+				Endpoint:   EndpointTCPClient{Address: "127.0.0.1:5601"},
+				OutVersion: VAuto,
+			},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node2.Close()
+
+	evt := <-node2.Events()
+	e, ok := evt.(*EventChannelOpen)
+	require.True(t, ok)
+	ch2 := e.Channel
+
+	evt = <-node1.Events()
+	e, ok = evt.(*EventChannelOpen)
+	require.True(t, ok)
+	ch1 := e.Channel
+
+	require.Equal(t, EndpointKindTCPServer, ch1.EndpointKind())
+	require.Equal(t, "tcp:"+ch1.RemoteAddr().String(), ch1.Label())
+	require.Equal(t, V2, ch1.Version())
+	require.True(t, !ch1.CreationTime().Before(start))
+
+	require.Equal(t, EndpointKindTCPClient, ch2.EndpointKind())
+	require.Equal(t, "tcp:127.0.0.1:5601", ch2.Label())
+	require.Equal(t, "127.0.0.1:5601", ch2.RemoteAddr().String())
+	require.Equal(t, V1, ch2.Version())
+	require.True(t, !ch2.CreationTime().Before(start))
+
+	// the version is negotiated once a V2 frame is received
+	node1.WriteMessageTo(ch1, &MessageHeartbeat{})
+
+	for evt := range node2.Events() {
+		if _, ok := evt.(*EventFrame); ok {
+			break
+		}
+	}
+
+	require.Equal(t, V2, ch2.Version())
+}
+
+func TestChannelInfoCustom(t *testing.T) {
+	node1, node2 := newTestNodePair(t, &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}) //nolint:govet
+	defer node1.Close()
+	defer node2.Close()
+
+	go func() {
+		for range node2.Events() {
+		}
+	}()
+
+	evt := <-node1.Events()
+	e, ok := evt.(*EventChannelOpen)
+	require.True(t, ok)
+
+	require.Equal(t, EndpointKindCustom, e.Channel.EndpointKind())
+	require.Equal(t, "custom", e.Channel.Label())
+	require.Nil(t, e.Channel.RemoteAddr())
+}
+
+func TestChannelClose(t *testing.T) {
+	node, err := NewNode(NodeConf{
+		Dialect:     &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:  V2,
+		OutSystemID: 10,
+		Endpoints: []EndpointConf{
+			EndpointTCPServer{Address: "127.0.0.1:5601"},
+			EndpointTCPClient{Address: "127.0.0.1:5601"},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node.Close()
+
+	conn, err := net.Dial("tcp", "127.0.0.1:5601")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	channels := make(map[EndpointKind]*Channel)
+	for len(channels) != 3 {
+		evt := <-node.Events()
+		e, ok := evt.(*EventChannelOpen)
+		require.True(t, ok)
+		if e.Channel.EndpointKind() == EndpointKindTCPServer &&
+			e.RemoteAddr.String() != conn.LocalAddr().String() {
+			channels["peer"] = e.Channel
+		} else {
+			channels[e.Channel.EndpointKind()] = e.Channel
+		}
+	}
+
+	// channels that do not belong to the node are ignored
+	node.CloseChannel(&Channel{})
+
+	node.CloseChannel(channels[EndpointKindTCPServer])
+
+	evt := <-node.Events()
+	e, ok := evt.(*EventChannelClose)
+	require.True(t, ok)
+	require.Equal(t, channels[EndpointKindTCPServer], e.Channel)
+
+	_, err = conn.Read(make([]byte, 1))
+	require.Equal(t, io.EOF, err)
+
+	channels[EndpointKindTCPClient].Close()
+
+	closed := make(map[*Channel]struct{})
+	for len(closed) != 2 {
+		evt := <-node.Events()
+		e, ok := evt.(*EventChannelClose)
+		require.True(t, ok)
+		closed[e.Channel] = struct{}{}
+	}
+	require.Contains(t, closed, channels[EndpointKindTCPClient])
+	require.Contains(t, closed, channels["peer"])
+	require.Len(t, node.ChannelStats(), 0)
+}
+
+func TestNodeChannels(t *testing.T) {
+	node, err := NewNode(NodeConf{
+		Dialect:     &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:  V2,
+		OutSystemID: 10,
+		Endpoints: []EndpointConf{
+			EndpointTCPServer{Address: "127.0.0.1:5601"},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node.Close()
+
+	require.Len(t, node.Channels(), 0)
+
+	conn, err := net.Dial("tcp", "127.0.0.1:5601")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	evt := <-node.Events()
+	e, ok := evt.(*EventChannelOpen)
+	require.True(t, ok)
+	require.Equal(t, []*Channel{e.Channel}, node.Channels())
+
+	conn.Close()
+
+	evt = <-node.Events()
+	_, ok = evt.(*EventChannelClose)
+	require.True(t, ok)
+
+	// wait for the node to remove the channel
+	for i := 0; i < 100 && len(node.Channels()) != 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.Len(t, node.Channels(), 0)
+}
@@ -2,20 +2,49 @@ package gomavlib
 
 import (
 	"fmt"
+	"net"
 )
 
+// maxAcceptedHosts is the maximum number of remote hosts that are remembered
+// by a channelAccepter in order to detect reconnections.
+const maxAcceptedHosts = 1024
+
 type channelAccepter struct {
-	n   *Node
-	eca endpointChannelAccepter
+	n     *Node
+	eca   endpointChannelAccepter
+	hosts map[string]struct{}
 }
 
 func newChannelAccepter(n *Node, eca endpointChannelAccepter) (*channelAccepter, error) {
 	return &channelAccepter{
-		n:   n,
-		eca: eca,
+		n:     n,
+		eca:   eca,
+		hosts: make(map[string]struct{}),
 	}, nil
 }
 
+// isReconnection checks whether a host has already opened a channel.
+func (ca *channelAccepter) isReconnection(rwc interface{}) bool {
+	p, ok := rwc.(remoteAddrProvider)
+	if !ok || p.RemoteAddr() == nil {
+		return false
+	}
+
+	host, _, err := net.SplitHostPort(p.RemoteAddr().String())
+	if err != nil {
+		return false
+	}
+
+	if _, ok := ca.hosts[host]; ok {
+		return true
+	}
+
+	if len(ca.hosts) < maxAcceptedHosts {
+		ca.hosts[host] = struct{}{}
+	}
+	return false
+}
+
 func (ca *channelAccepter) close() {
 	ca.eca.Close()
 }
@@ -41,6 +70,7 @@ func (ca *channelAccepter) run1() {
 		if err != nil {
 			panic(fmt.Errorf("newChannel unexpected error: %s", err))
 		}
+		ch.reconnected = ca.isReconnection(rwc)
 
 		ca.n.channelNew <- ch
 	}
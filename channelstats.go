@@ -0,0 +1,174 @@
+package gomavlib
+
+import (
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ChannelStats contains statistics about a channel.
+type ChannelStats struct {
+	// the channel
+	Channel *Channel
+
+	// number of frames received
+	FramesIn uint64
+	// number of frames sent
+	FramesOut uint64
+	// number of bytes received
+	BytesIn uint64
+	// number of bytes sent
+	BytesOut uint64
+	// number of frames that could not be parsed
+	ParseErrors uint64
+	// number of frames discarded because of an invalid signature
+	SignatureErrors uint64
+	// number of frames discarded because of a wrong checksum
+	ChecksumErrors uint64
+	// number of frames discarded because their message is not in the dialect.
+	// See NodeConf.DiscardUnknownMessages.
+	UnknownMessages uint64
+	// number of writes discarded because the channel could not keep up.
+	// See EndpointBackpressure.
+	WriteDrops uint64
+	// round-trip time, averaged over TIMESYNC exchanges, or zero if
+	// unknown. See NodeConf.TimesyncEnable.
+	RTT time.Duration
+}
+
+// add adds the counters of other statistics, except the round-trip time.
+func (s *ChannelStats) add(o ChannelStats) {
+	s.FramesIn += o.FramesIn
+	s.FramesOut += o.FramesOut
+	s.BytesIn += o.BytesIn
+	s.BytesOut += o.BytesOut
+	s.ParseErrors += o.ParseErrors
+	s.SignatureErrors += o.SignatureErrors
+	s.ChecksumErrors += o.ChecksumErrors
+	s.UnknownMessages += o.UnknownMessages
+	s.WriteDrops += o.WriteDrops
+}
+
+// channelStats contains the counters of a channel.
+// It is allocated separately in order to guarantee the 64-bit alignment
+// required by atomic operations.
+type channelStats struct {
+	framesIn        uint64
+	framesOut       uint64
+	bytesIn         uint64
+	bytesOut        uint64
+	parseErrors     uint64
+	signatureErrors uint64
+	checksumErrors  uint64
+	unknownMessages uint64
+	writeDrops      uint64
+	rtt             uint64
+}
+
+func (s *channelStats) get(ch *Channel) ChannelStats {
+	return ChannelStats{
+		Channel:         ch,
+		FramesIn:        atomic.LoadUint64(&s.framesIn),
+		FramesOut:       atomic.LoadUint64(&s.framesOut),
+		BytesIn:         atomic.LoadUint64(&s.bytesIn),
+		BytesOut:        atomic.LoadUint64(&s.bytesOut),
+		ParseErrors:     atomic.LoadUint64(&s.parseErrors),
+		SignatureErrors: atomic.LoadUint64(&s.signatureErrors),
+		ChecksumErrors:  atomic.LoadUint64(&s.checksumErrors),
+		UnknownMessages: atomic.LoadUint64(&s.unknownMessages),
+		WriteDrops:      atomic.LoadUint64(&s.writeDrops),
+		RTT:             time.Duration(atomic.LoadUint64(&s.rtt)),
+	}
+}
+
+// updateRTT adds a round-trip time sample to the average.
+// It is called by the reader routine of the channel only.
+func (s *channelStats) updateRTT(rtt time.Duration) {
+	cur := time.Duration(atomic.LoadUint64(&s.rtt))
+	if cur == 0 {
+		cur = rtt
+	} else {
+		cur += time.Duration(float64(rtt-cur) * timesyncFilterAlpha)
+	}
+	atomic.StoreUint64(&s.rtt, uint64(cur))
+}
+
+type countingReader struct {
+	r io.Reader
+	n *uint64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	atomic.AddUint64(c.n, uint64(n))
+	return n, err
+}
+
+type countingWriter struct {
+	w io.Writer
+	n *uint64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	atomic.AddUint64(c.n, uint64(n))
+	return n, err
+}
+
+// nodeChannelStats keeps track of open channels, in order to allow reading
+// their statistics from any routine.
+type nodeChannelStats struct {
+	mutex    sync.Mutex
+	channels map[*Channel]struct{}
+	closed   ChannelStats // counters of closed channels
+}
+
+func newNodeChannelStats() *nodeChannelStats {
+	return &nodeChannelStats{
+		channels: make(map[*Channel]struct{}),
+	}
+}
+
+func (s *nodeChannelStats) onChannelOpen(ch *Channel) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.channels[ch] = struct{}{}
+}
+
+func (s *nodeChannelStats) onChannelClose(ch *Channel) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.channels[ch]; ok {
+		delete(s.channels, ch)
+		s.closed.add(ch.stats.get(ch))
+	}
+}
+
+func (s *nodeChannelStats) get() []ChannelStats {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	ret := make([]ChannelStats, 0, len(s.channels))
+	for ch := range s.channels {
+		ret = append(ret, ch.stats.get(ch))
+	}
+	return ret
+}
+
+// getTotal returns the statistics of open channels and the sum of the
+// counters of all channels, including closed ones.
+func (s *nodeChannelStats) getTotal() ([]ChannelStats, ChannelStats) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	ret := make([]ChannelStats, 0, len(s.channels))
+	total := s.closed
+	for ch := range s.channels {
+		st := ch.stats.get(ch)
+		ret = append(ret, st)
+		total.add(st)
+	}
+	return ret, total
+}
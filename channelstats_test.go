@@ -0,0 +1,125 @@
+package gomavlib
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+	"github.com/aler9/gomavlib/pkg/msg"
+	"github.com/aler9/gomavlib/pkg/transceiver"
+)
+
+func TestChannelStatsErrors(t *testing.T) {
+	var buf bytes.Buffer
+	tr, err := transceiver.New(transceiver.Conf{
+		Reader:      bytes.NewReader(nil),
+		Writer:      &buf,
+		OutVersion:  transceiver.V2,
+		OutSystemID: 11,
+	})
+	require.NoError(t, err)
+
+	// message not in the dialect
+	err = tr.WriteMessage(&msg.MessageRaw{ID: 60000, Content: []byte{1, 2, 3}})
+	require.NoError(t, err)
+
+	// heartbeat whose checksum is computed without CRC extra
+	err = tr.WriteMessage(&msg.MessageRaw{ID: 0, Content: []byte{1, 2, 3, 4, 5, 6, 7, 8, 9}})
+	require.NoError(t, err)
+
+	l1 := newTestPipe()
+	l2 := newTestPipe()
+
+	node, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 10,
+		Endpoints: []EndpointConf{
+			EndpointCustom{ReadWriteCloser: &testEndpoint{l1, l2}},
+		},
+		HeartbeatDisable:       true,
+		DiscardUnknownMessages: true,
+	})
+	require.NoError(t, err)
+	defer node.Close()
+
+	go l1.Write(buf.Bytes()) //nolint:errcheck
+
+	// frames with errors are not consumed, therefore their remaining bytes
+	// may produce additional errors
+	kinds := make(map[error]struct{})
+	for evt := range node.Events() {
+		if ee, ok := evt.(*EventParseError); ok {
+			kinds[ee.Error.(*transceiver.Error).Kind] = struct{}{}
+			_, ok1 := kinds[transceiver.ErrUnknownMessage]
+			_, ok2 := kinds[transceiver.ErrBadChecksum]
+			if ok1 && ok2 {
+				break
+			}
+		}
+	}
+
+	stats := node.ChannelStats()
+	require.Len(t, stats, 1)
+	require.True(t, stats[0].ParseErrors >= 2)
+	require.Equal(t, uint64(1), stats[0].ChecksumErrors)
+	require.Equal(t, uint64(1), stats[0].UnknownMessages)
+	require.Equal(t, uint64(0), stats[0].SignatureErrors)
+}
+
+func TestNodeEventStats(t *testing.T) {
+	l1, l2 := newTestPipe(), newTestPipe()
+
+	node1, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 10,
+		Endpoints: []EndpointConf{
+			EndpointCustom{ReadWriteCloser: &testEndpoint{l1, l2}},
+		},
+		HeartbeatDisable: true,
+		StatsPeriod:      50 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	defer node1.Close()
+
+	node2, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 11,
+		Endpoints: []EndpointConf{
+			EndpointCustom{ReadWriteCloser: &testEndpoint{l2, l1}},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node2.Close()
+
+	defer l1.Close()
+	defer l2.Close()
+
+	go func() {
+		for range node2.Events() {
+		}
+	}()
+
+	<-node1.Events()
+
+	node1.WriteMessageAll(&common.MessageParamValue{ParamId: "test"})
+
+	for evt := range node1.Events() {
+		st, ok := evt.(*EventStats)
+		if !ok || st.Total.FramesOut == 0 {
+			continue
+		}
+
+		require.Len(t, st.Channels, 1)
+		require.Equal(t, uint64(1), st.Channels[0].FramesOut)
+		require.Equal(t, uint64(1), st.Total.FramesOut)
+		require.Equal(t, st.Channels[0].BytesOut, st.Total.BytesOut)
+		break
+	}
+}
@@ -0,0 +1,163 @@
+package gomavlib
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock is the time source of a Node. It is used by periodic messages
+// (heartbeats, SYS_STATUS, TIMESYNC, etc), by the timeouts of protocols and
+// by signature timestamps, and can be replaced in order to advance time
+// synthetically in tests. See ManualClock.
+// Network deadlines and reconnection delays always use the system clock.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// NewTimer returns a channel that receives the current time after the
+	// given duration, and a function that stops the timer.
+	NewTimer(d time.Duration) (<-chan time.Time, func())
+
+	// NewTicker returns a channel that receives the current time
+	// periodically, and a function that stops the ticker.
+	NewTicker(d time.Duration) (<-chan time.Time, func())
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) NewTimer(d time.Duration) (<-chan time.Time, func()) {
+	t := time.NewTimer(d)
+	return t.C, func() { t.Stop() }
+}
+
+func (realClock) NewTicker(d time.Duration) (<-chan time.Time, func()) {
+	t := time.NewTicker(d)
+	return t.C, t.Stop
+}
+
+type manualClockTimer struct {
+	at     time.Time
+	period time.Duration // zero for timers
+	c      chan time.Time
+}
+
+// ManualClock is a Clock whose time advances only when Advance is called.
+// Like the system clock, timers and tickers drop ticks that are not received
+// in time.
+type ManualClock struct {
+	mutex  sync.Mutex
+	now    time.Time
+	timers []*manualClockTimer
+}
+
+// NewManualClock allocates a ManualClock that starts at the given time.
+func NewManualClock(now time.Time) *ManualClock {
+	return &ManualClock{
+		now: now,
+	}
+}
+
+// Now implements Clock.
+func (c *ManualClock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.now
+}
+
+// NewTimer implements Clock.
+func (c *ManualClock) NewTimer(d time.Duration) (<-chan time.Time, func()) {
+	return c.add(d, 0)
+}
+
+// NewTicker implements Clock.
+func (c *ManualClock) NewTicker(d time.Duration) (<-chan time.Time, func()) {
+	if d <= 0 {
+		panic("non-positive interval for NewTicker")
+	}
+	return c.add(d, d)
+}
+
+func (c *ManualClock) add(d time.Duration, period time.Duration) (<-chan time.Time, func()) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	t := &manualClockTimer{
+		at:     c.now.Add(d),
+		period: period,
+		c:      make(chan time.Time, 1),
+	}
+	c.timers = append(c.timers, t)
+
+	if d <= 0 && period == 0 {
+		c.fire(t)
+	}
+
+	return t.c, func() {
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+		c.remove(t)
+	}
+}
+
+func (c *ManualClock) remove(t *manualClockTimer) {
+	for i, t2 := range c.timers {
+		if t2 == t {
+			c.timers = append(c.timers[:i], c.timers[i+1:]...)
+			return
+		}
+	}
+}
+
+// fire sends the current time to a timer, and schedules its next tick.
+func (c *ManualClock) fire(t *manualClockTimer) {
+	select {
+	case t.c <- c.now:
+	default:
+	}
+
+	if t.period > 0 {
+		t.at = t.at.Add(t.period)
+	} else {
+		c.remove(t)
+	}
+}
+
+// Timers returns the number of active timers and tickers. It allows tests to
+// wait for a routine to start waiting, before advancing time.
+func (c *ManualClock) Timers() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return len(c.timers)
+}
+
+// Advance moves the time forward by the given duration, firing expired
+// timers and tickers in order.
+func (c *ManualClock) Advance(d time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	target := c.now.Add(d)
+
+	for {
+		sort.SliceStable(c.timers, func(i, j int) bool {
+			return c.timers[i].at.Before(c.timers[j].at)
+		})
+
+		if len(c.timers) == 0 || c.timers[0].at.After(target) {
+			break
+		}
+
+		t := c.timers[0]
+		if t.at.After(c.now) {
+			c.now = t.at
+		}
+		c.fire(t)
+	}
+
+	c.now = target
+}
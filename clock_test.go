@@ -0,0 +1,93 @@
+package gomavlib
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+)
+
+func TestManualClock(t *testing.T) {
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewManualClock(start)
+	require.Equal(t, start, c.Now())
+
+	timerC, _ := c.NewTimer(2 * time.Second)
+	tickerC, stopTicker := c.NewTicker(1 * time.Second)
+	require.Equal(t, 2, c.Timers())
+
+	c.Advance(1 * time.Second)
+	require.Equal(t, start.Add(1*time.Second), <-tickerC)
+	require.Equal(t, 0, len(timerC))
+
+	// ticks that are not received are dropped
+	c.Advance(2 * time.Second)
+	require.Equal(t, start.Add(2*time.Second), <-timerC)
+	require.Equal(t, start.Add(2*time.Second), <-tickerC)
+	require.Equal(t, 0, len(tickerC))
+	require.Equal(t, start.Add(3*time.Second), c.Now())
+	require.Equal(t, 1, c.Timers())
+
+	stopTicker()
+	require.Equal(t, 0, c.Timers())
+
+	c.Advance(1 * time.Second)
+	require.Equal(t, 0, len(tickerC))
+
+	timerC, _ = c.NewTimer(0)
+	require.Equal(t, start.Add(4*time.Second), <-timerC)
+}
+
+func TestNodeClock(t *testing.T) {
+	l1 := newTestPipe()
+	l2 := newTestPipe()
+
+	clock := NewManualClock(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	node1, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 10,
+		Endpoints: []EndpointConf{
+			EndpointCustom{ReadWriteCloser: &testEndpoint{l1, l2}},
+		},
+		HeartbeatPeriod: time.Hour,
+		Clock:           clock,
+	})
+	require.NoError(t, err)
+	defer node1.Close()
+
+	node2, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 11,
+		Endpoints: []EndpointConf{
+			EndpointCustom{ReadWriteCloser: &testEndpoint{l2, l1}},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node2.Close()
+
+	go func() {
+		for range node1.Events() {
+		}
+	}()
+
+	evt := <-node2.Events()
+	require.IsType(t, &EventChannelOpen{}, evt)
+
+	// wait for the heartbeat routine
+	for clock.Timers() == 0 {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	clock.Advance(time.Hour)
+
+	evt = <-node2.Events()
+	fr, ok := evt.(*EventFrame)
+	require.Equal(t, true, ok)
+	require.IsType(t, &common.MessageHeartbeat{}, fr.Message())
+}
@@ -0,0 +1,312 @@
+package main
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"os/signal"
+	"reflect"
+	"strings"
+	"time"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+
+	"github.com/aler9/gomavlib"
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/dialects/ardupilotmega"
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+	"github.com/aler9/gomavlib/pkg/frame"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+var dialects = map[string]*dialect.Dialect{
+	"ardupilotmega": ardupilotmega.Dialect,
+	"common":        common.Dialect,
+	"none":          nil,
+}
+
+var versions = map[string]gomavlib.Version{
+	"1":    gomavlib.V1,
+	"2":    gomavlib.V2,
+	"auto": gomavlib.VAuto,
+}
+
+var endpointTypes = map[string]func(string) gomavlib.EndpointConf{
+	"serial": func(addr string) gomavlib.EndpointConf {
+		return gomavlib.EndpointSerial{Address: addr}
+	},
+	"udps": func(addr string) gomavlib.EndpointConf {
+		return gomavlib.EndpointUDPServer{Address: addr}
+	},
+	"udpc": func(addr string) gomavlib.EndpointConf {
+		return gomavlib.EndpointUDPClient{Address: addr}
+	},
+	"udpb": func(addr string) gomavlib.EndpointConf {
+		return gomavlib.EndpointUDPBroadcast{BroadcastAddress: addr}
+	},
+	"tcps": func(addr string) gomavlib.EndpointConf {
+		return gomavlib.EndpointTCPServer{Address: addr}
+	},
+	"tcpc": func(addr string) gomavlib.EndpointConf {
+		return gomavlib.EndpointTCPClient{Address: addr}
+	},
+}
+
+func parseEndpoint(desc string) (gomavlib.EndpointConf, error) {
+	parts := strings.SplitN(desc, ":", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid endpoint: %s", desc)
+	}
+
+	et, ok := endpointTypes[parts[0]]
+	if !ok {
+		return nil, fmt.Errorf("invalid endpoint type: %s", parts[0])
+	}
+
+	return et(parts[1]), nil
+}
+
+// filter selects frames by system id and message id. An empty list matches
+// everything.
+type filter struct {
+	systemIDs  map[byte]struct{}
+	messageIDs map[uint32]struct{}
+}
+
+func newFilter(systemIDs []uint8, messageIDs []uint32) *filter {
+	f := &filter{
+		systemIDs:  make(map[byte]struct{}),
+		messageIDs: make(map[uint32]struct{}),
+	}
+	for _, id := range systemIDs {
+		f.systemIDs[id] = struct{}{}
+	}
+	for _, id := range messageIDs {
+		f.messageIDs[id] = struct{}{}
+	}
+	return f
+}
+
+func (f *filter) match(evt *gomavlib.EventFrame) bool {
+	if len(f.systemIDs) != 0 {
+		if _, ok := f.systemIDs[evt.SystemID()]; !ok {
+			return false
+		}
+	}
+
+	if len(f.messageIDs) != 0 {
+		if _, ok := f.messageIDs[evt.Message().GetID()]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+func printText(evt *gomavlib.EventFrame) {
+	m := evt.Message()
+
+	var content string
+	if raw, ok := m.(*msg.MessageRaw); ok {
+		content = fmt.Sprintf("%x", raw.Content)
+	} else {
+		content = fmt.Sprintf("%+v", reflect.ValueOf(m).Elem().Interface())
+	}
+
+	fmt.Printf("%s [%s] sys=%d comp=%d %s %s\n",
+		time.Now().Format("15:04:05.000"), evt.Channel,
+		evt.SystemID(), evt.ComponentID(), msg.Name(m), content)
+}
+
+type jsonHeader struct {
+	SystemID    byte `json:"system_id"`
+	ComponentID byte `json:"component_id"`
+	Sequence    byte `json:"sequence"`
+}
+
+type jsonEntry struct {
+	Time    time.Time              `json:"time"`
+	Channel string                 `json:"channel"`
+	Header  jsonHeader             `json:"header"`
+	Name    string                 `json:"name"`
+	ID      uint32                 `json:"id"`
+	Message map[string]interface{} `json:"message"`
+}
+
+var textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+// fieldEncode converts enums into their name, when available, and NaN or
+// infinite values, that are not supported by JSON, into null.
+func fieldEncode(v reflect.Value) interface{} {
+	switch {
+	case v.Kind() == reflect.Float32 || v.Kind() == reflect.Float64:
+		f := v.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil
+		}
+		return f
+
+	case v.Type().Implements(textMarshalerType):
+		if byts, err := v.Interface().(encoding.TextMarshaler).MarshalText(); err == nil {
+			return string(byts)
+		}
+
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return v.Int()
+		default:
+			return v.Uint()
+		}
+
+	case v.Kind() == reflect.Array && v.Type().Elem().Kind() != reflect.Uint8:
+		ret := make([]interface{}, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			ret[i] = fieldEncode(v.Index(i))
+		}
+		return ret
+	}
+
+	return v.Interface()
+}
+
+func messageEncode(m msg.Message) map[string]interface{} {
+	if raw, ok := m.(*msg.MessageRaw); ok {
+		return map[string]interface{}{
+			"payload": raw.Content,
+		}
+	}
+
+	rv := reflect.ValueOf(m).Elem()
+	rt := rv.Type()
+
+	ret := make(map[string]interface{}, rt.NumField())
+	for i := 0; i < rt.NumField(); i++ {
+		ret[msg.FieldName(rt.Field(i))] = fieldEncode(rv.Field(i))
+	}
+	return ret
+}
+
+func frameSequenceID(fr frame.Frame) byte {
+	switch ff := fr.(type) {
+	case *frame.V1Frame:
+		return ff.SequenceID
+
+	case *frame.V2Frame:
+		return ff.SequenceID
+	}
+	return 0
+}
+
+func printJSON(evt *gomavlib.EventFrame) error {
+	m := evt.Message()
+
+	byts, err := json.Marshal(jsonEntry{
+		Time:    time.Now(),
+		Channel: evt.Channel.String(),
+		Header: jsonHeader{
+			SystemID:    evt.SystemID(),
+			ComponentID: evt.ComponentID(),
+			Sequence:    frameSequenceID(evt.Frame),
+		},
+		Name:    msg.Name(m),
+		ID:      m.GetID(),
+		Message: messageEncode(m),
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(byts))
+	return nil
+}
+
+func run() error {
+	kingpin.CommandLine.Help = "Print Mavlink frames received from an endpoint.\n\n" +
+		"The endpoint is in the format type:address, where type is one of:\n" +
+		"  serial  serial port, example: serial:/dev/ttyUSB0:57600\n" +
+		"  udps    UDP server, example: udps:0.0.0.0:14550\n" +
+		"  udpc    UDP client, example: udpc:1.2.3.4:14550\n" +
+		"  udpb    UDP broadcast, example: udpb:192.168.7.255:14550\n" +
+		"  tcps    TCP server, example: tcps:0.0.0.0:5760\n" +
+		"  tcpc    TCP client, example: tcpc:1.2.3.4:5760"
+
+	argDialect := kingpin.Flag("dialect", "Dialect used to decode messages").
+		Default("ardupilotmega").Enum("ardupilotmega", "common", "none")
+	argVersion := kingpin.Flag("version", "Mavlink version used to write heartbeats").
+		Default("2").Enum("1", "2", "auto")
+	argSystemID := kingpin.Flag("sysid", "System id of the dumper").Default("125").Uint8()
+	argHeartbeatDisable := kingpin.Flag("hb-disable", "Disable heartbeats").Bool()
+	argFormat := kingpin.Flag("format", "Output format").Default("text").Enum("text", "json")
+	argFilterSystemIDs := kingpin.Flag("filter-sysid", "Print only frames sent by this system id, "+
+		"can be repeated").Uint8List()
+	argFilterMessageIDs := kingpin.Flag("filter-msgid", "Print only messages with this id, "+
+		"can be repeated").Uint32List()
+	argEndpoint := kingpin.Arg("endpoint", "Endpoint").Required().String()
+
+	kingpin.Parse()
+
+	endpoint, err := parseEndpoint(*argEndpoint)
+	if err != nil {
+		return err
+	}
+
+	node, err := gomavlib.NewNode(gomavlib.NodeConf{
+		Endpoints:        []gomavlib.EndpointConf{endpoint},
+		Dialect:          dialects[*argDialect],
+		OutVersion:       versions[*argVersion],
+		OutSystemID:      *argSystemID,
+		HeartbeatDisable: *argHeartbeatDisable,
+	})
+	if err != nil {
+		return err
+	}
+	defer node.Close()
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+
+	f := newFilter(*argFilterSystemIDs, *argFilterMessageIDs)
+
+	for {
+		select {
+		case evt := <-node.Events():
+			switch ee := evt.(type) {
+			case *gomavlib.EventChannelOpen:
+				fmt.Fprintf(os.Stderr, "channel opened: %s\n", ee.Channel)
+
+			case *gomavlib.EventChannelClose:
+				fmt.Fprintf(os.Stderr, "channel closed: %s\n", ee.Channel)
+
+			case *gomavlib.EventParseError:
+				fmt.Fprintf(os.Stderr, "parse error: %s\n", ee.Error)
+
+			case *gomavlib.EventFrame:
+				if !f.match(ee) {
+					continue
+				}
+
+				if *argFormat == "json" {
+					err := printJSON(ee)
+					if err != nil {
+						fmt.Fprintf(os.Stderr, "unable to encode %s: %s\n", msg.Name(ee.Message()), err)
+					}
+				} else {
+					printText(ee)
+				}
+			}
+
+		case <-interrupt:
+			return nil
+		}
+	}
+}
+
+func main() {
+	err := run()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ERR: %s\n", err)
+		os.Exit(1)
+	}
+}
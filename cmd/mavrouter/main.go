@@ -0,0 +1,279 @@
+package main
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"os/signal"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+
+	"github.com/aler9/gomavlib"
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/dialects/ardupilotmega"
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+	"github.com/aler9/gomavlib/pkg/frame"
+)
+
+var dialects = map[string]*dialect.Dialect{
+	"ardupilotmega": ardupilotmega.Dialect,
+	"common":        common.Dialect,
+	"none":          nil,
+}
+
+var versions = map[string]gomavlib.Version{
+	"1":    gomavlib.V1,
+	"2":    gomavlib.V2,
+	"auto": gomavlib.VAuto,
+}
+
+var endpointTypes = map[string]func(string) gomavlib.EndpointConf{
+	"serial": func(addr string) gomavlib.EndpointConf {
+		return gomavlib.EndpointSerial{Address: addr}
+	},
+	"udps": func(addr string) gomavlib.EndpointConf {
+		return gomavlib.EndpointUDPServer{Address: addr}
+	},
+	"udpc": func(addr string) gomavlib.EndpointConf {
+		return gomavlib.EndpointUDPClient{Address: addr}
+	},
+	"udpb": func(addr string) gomavlib.EndpointConf {
+		return gomavlib.EndpointUDPBroadcast{BroadcastAddress: addr}
+	},
+	"tcps": func(addr string) gomavlib.EndpointConf {
+		return gomavlib.EndpointTCPServer{Address: addr}
+	},
+	"tcpc": func(addr string) gomavlib.EndpointConf {
+		return gomavlib.EndpointTCPClient{Address: addr}
+	},
+}
+
+func parseEndpoint(desc string) (gomavlib.EndpointConf, error) {
+	parts := strings.SplitN(desc, ":", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid endpoint: %s", desc)
+	}
+
+	et, ok := endpointTypes[parts[0]]
+	if !ok {
+		return nil, fmt.Errorf("invalid endpoint type: %s", parts[0])
+	}
+
+	return et(parts[1]), nil
+}
+
+// readConfig reads a configuration file, that contains an endpoint per line.
+// Empty lines and lines starting with # are ignored.
+func readConfig(fpath string) ([]string, error) {
+	f, err := os.Open(fpath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var ret []string
+	scanner := bufio.NewScanner(f)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		ret = append(ret, line)
+	}
+
+	return ret, scanner.Err()
+}
+
+// router routes frames to the channels through which their target system
+// has been seen, or to all channels if the target is unknown.
+type router struct {
+	node    *gomavlib.Node
+	systems map[byte]map[*gomavlib.Channel]struct{}
+}
+
+func newRouter(node *gomavlib.Node) *router {
+	return &router{
+		node:    node,
+		systems: make(map[byte]map[*gomavlib.Channel]struct{}),
+	}
+}
+
+func (r *router) onChannelClose(ch *gomavlib.Channel) {
+	for sysID, chans := range r.systems {
+		delete(chans, ch)
+		if len(chans) == 0 {
+			delete(r.systems, sysID)
+		}
+	}
+}
+
+func (r *router) onEventFrame(evt *gomavlib.EventFrame) {
+	// learn the channel of the sender
+	chans, ok := r.systems[evt.SystemID()]
+	if !ok {
+		chans = make(map[*gomavlib.Channel]struct{})
+		r.systems[evt.SystemID()] = chans
+	}
+	chans[evt.Channel] = struct{}{}
+
+	target := messageTargetSystem(evt)
+	if target != 0 {
+		if chans, ok := r.systems[target]; ok {
+			for ch := range chans {
+				if ch != evt.Channel {
+					r.node.WriteFrameTo(ch, evt.Frame)
+				}
+			}
+			return
+		}
+	}
+
+	r.node.WriteFrameExcept(evt.Channel, evt.Frame)
+}
+
+// messageTargetSystem returns the target system of a message, or zero if the
+// message is a broadcast or cannot be decoded.
+func messageTargetSystem(evt *gomavlib.EventFrame) byte {
+	rv := reflect.ValueOf(evt.Message())
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return 0
+	}
+
+	f := rv.Elem().FieldByName("TargetSystem")
+	if !f.IsValid() || f.Kind() != reflect.Uint8 {
+		return 0
+	}
+
+	return byte(f.Uint())
+}
+
+func printStats(node *gomavlib.Node) {
+	stats := node.ChannelStats()
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Channel.String() < stats[j].Channel.String()
+	})
+
+	for _, s := range stats {
+		fmt.Fprintf(os.Stderr, "%s: frames in=%d out=%d, bytes in=%d out=%d, "+
+			"errors parse=%d signature=%d checksum=%d, write drops=%d\n",
+			s.Channel, s.FramesIn, s.FramesOut, s.BytesIn, s.BytesOut,
+			s.ParseErrors, s.SignatureErrors, s.ChecksumErrors, s.WriteDrops)
+	}
+}
+
+func run() error {
+	kingpin.CommandLine.Help = "Route Mavlink frames between multiple endpoints.\n\n" +
+		"Endpoints are in the format type:address, where type is one of:\n" +
+		"  serial  serial port, example: serial:/dev/ttyUSB0:57600\n" +
+		"  udps    UDP server, example: udps:0.0.0.0:14550\n" +
+		"  udpc    UDP client, example: udpc:1.2.3.4:14550\n" +
+		"  udpb    UDP broadcast, example: udpb:192.168.7.255:14550\n" +
+		"  tcps    TCP server, example: tcps:0.0.0.0:5760\n" +
+		"  tcpc    TCP client, example: tcpc:1.2.3.4:5760"
+
+	argConfig := kingpin.Flag("config", "Path to a file that contains an endpoint per line").String()
+	argDialect := kingpin.Flag("dialect", "Dialect used to decode messages and find their target").
+		Default("ardupilotmega").Enum("ardupilotmega", "common", "none")
+	argVersion := kingpin.Flag("version", "Mavlink version used to write messages").
+		Default("2").Enum("1", "2", "auto")
+	argSystemID := kingpin.Flag("sysid", "System id of the router").Default("125").Uint8()
+	argHeartbeatDisable := kingpin.Flag("hb-disable", "Disable heartbeats").Bool()
+	argKey := kingpin.Flag("key", "Passphrase used to sign and verify frames, "+
+		"hashed with SHA-256").String()
+	argStatsPeriod := kingpin.Flag("stats-period", "Period between stats reports, "+
+		"0 to disable").Default("0s").Duration()
+	argEndpoints := kingpin.Arg("endpoints", "Endpoints").Strings()
+
+	kingpin.Parse()
+
+	descs := *argEndpoints
+	if *argConfig != "" {
+		fromConfig, err := readConfig(*argConfig)
+		if err != nil {
+			return err
+		}
+		descs = append(descs, fromConfig...)
+	}
+
+	if len(descs) == 0 {
+		return fmt.Errorf("at least one endpoint must be provided")
+	}
+
+	endpoints := make([]gomavlib.EndpointConf, len(descs))
+	for i, desc := range descs {
+		var err error
+		endpoints[i], err = parseEndpoint(desc)
+		if err != nil {
+			return err
+		}
+	}
+
+	conf := gomavlib.NodeConf{
+		Endpoints:        endpoints,
+		Dialect:          dialects[*argDialect],
+		OutVersion:       versions[*argVersion],
+		OutSystemID:      *argSystemID,
+		HeartbeatDisable: *argHeartbeatDisable,
+	}
+
+	if *argKey != "" {
+		sum := sha256.Sum256([]byte(*argKey))
+		conf.InKey = frame.NewV2Key(sum[:])
+		conf.OutKey = conf.InKey
+	}
+
+	node, err := gomavlib.NewNode(conf)
+	if err != nil {
+		return err
+	}
+	defer node.Close()
+
+	var statsC <-chan time.Time
+	if *argStatsPeriod > 0 {
+		ticker := time.NewTicker(*argStatsPeriod)
+		defer ticker.Stop()
+		statsC = ticker.C
+	}
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+
+	r := newRouter(node)
+
+	for {
+		select {
+		case evt := <-node.Events():
+			switch ee := evt.(type) {
+			case *gomavlib.EventChannelOpen:
+				fmt.Fprintf(os.Stderr, "channel opened: %s\n", ee.Channel)
+
+			case *gomavlib.EventChannelClose:
+				fmt.Fprintf(os.Stderr, "channel closed: %s\n", ee.Channel)
+				r.onChannelClose(ee.Channel)
+
+			case *gomavlib.EventFrame:
+				r.onEventFrame(ee)
+			}
+
+		case <-statsC:
+			printStats(node)
+
+		case <-interrupt:
+			return nil
+		}
+	}
+}
+
+func main() {
+	err := run()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ERR: %s\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,270 @@
+package gomavlib
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+// CommandResult is the result of a command (MAV_RESULT).
+type CommandResult int
+
+// command results.
+const (
+	CommandResultAccepted            CommandResult = 0
+	CommandResultTemporarilyRejected CommandResult = 1
+	CommandResultDenied              CommandResult = 2
+	CommandResultUnsupported         CommandResult = 3
+	CommandResultFailed              CommandResult = 4
+	CommandResultInProgress          CommandResult = 5
+	CommandResultCancelled           CommandResult = 6
+)
+
+// String implements fmt.Stringer.
+func (r CommandResult) String() string {
+	switch r {
+	case CommandResultAccepted:
+		return "accepted"
+	case CommandResultTemporarilyRejected:
+		return "temporarily rejected"
+	case CommandResultDenied:
+		return "denied"
+	case CommandResultUnsupported:
+		return "unsupported"
+	case CommandResultFailed:
+		return "failed"
+	case CommandResultInProgress:
+		return "in progress"
+	case CommandResultCancelled:
+		return "cancelled"
+	}
+	return "unknown"
+}
+
+// CommandIntParams are the parameters of a command sent with COMMAND_INT.
+type CommandIntParams struct {
+	Frame        int
+	Current      bool
+	Autocontinue bool
+	Param1       float32
+	Param2       float32
+	Param3       float32
+	Param4       float32
+	X            int32
+	Y            int32
+	Z            float32
+}
+
+// SendCommand sends a command to a component with COMMAND_LONG and waits
+// for its COMMAND_ACK. command is a MAV_CMD value; params are the command
+// parameters, up to 7.
+// The command is sent again, with an increased confirmation field, in case
+// no acknowledgement is received; in case the command is in progress,
+// the final acknowledgement is awaited without sending the command again.
+// Timeouts and retries are set with NodeConf.CommandTimeout,
+// NodeConf.CommandRetries and NodeConf.CommandInProgressTimeout.
+func (n *Node) SendCommand(ctx context.Context, t Target, command int, params ...float32) (CommandResult, error) {
+	if len(params) > 7 {
+		return 0, fmt.Errorf("too many parameters")
+	}
+
+	tpl := dialectMessage(n.conf.Dialect, 76, 152)
+	if tpl == nil {
+		return 0, fmt.Errorf("dialect does not contain COMMAND_LONG")
+	}
+
+	return n.sendCommand(ctx, t, command, func(attempt int) msg.Message {
+		m := newMessage(tpl)
+		messageSet(m, "TargetSystem", t.SystemID)
+		messageSet(m, "TargetComponent", t.ComponentID)
+		messageSet(m, "Command", command)
+		messageSet(m, "Confirmation", attempt)
+		for i, p := range params {
+			messageSet(m, fmt.Sprintf("Param%d", i+1), p)
+		}
+		return m
+	})
+}
+
+// SendCommandInt sends a command to a component with COMMAND_INT and waits
+// for its COMMAND_ACK. command is a MAV_CMD value.
+// It behaves like SendCommand.
+func (n *Node) SendCommandInt(ctx context.Context, t Target, command int, p CommandIntParams) (CommandResult, error) {
+	tpl := dialectMessage(n.conf.Dialect, 75, 158)
+	if tpl == nil {
+		return 0, fmt.Errorf("dialect does not contain COMMAND_INT")
+	}
+
+	return n.sendCommand(ctx, t, command, func(attempt int) msg.Message {
+		m := newMessage(tpl)
+		messageSet(m, "TargetSystem", t.SystemID)
+		messageSet(m, "TargetComponent", t.ComponentID)
+		messageSet(m, "Frame", p.Frame)
+		messageSet(m, "Command", command)
+		messageSet(m, "Current", boolToUint8(p.Current))
+		messageSet(m, "Autocontinue", boolToUint8(p.Autocontinue))
+		messageSet(m, "Param1", p.Param1)
+		messageSet(m, "Param2", p.Param2)
+		messageSet(m, "Param3", p.Param3)
+		messageSet(m, "Param4", p.Param4)
+		messageSet(m, "X", p.X)
+		messageSet(m, "Y", p.Y)
+		messageSet(m, "Z", p.Z)
+		return m
+	})
+}
+
+func (n *Node) sendCommand(ctx context.Context, t Target, command int,
+	build func(attempt int) msg.Message) (CommandResult, error) {
+	if dialectMessage(n.conf.Dialect, 77, 143) == nil {
+		return 0, fmt.Errorf("dialect does not contain COMMAND_ACK")
+	}
+
+	fw := n.nodeWaiters.add(func(evt *EventFrame) bool {
+		m := evt.Message()
+		if m.GetID() != 77 || !t.matches(evt) ||
+			int(messageGetInt(m, "Command")) != command {
+			return false
+		}
+
+		// discard acknowledgements that are explicitly directed to other systems
+		if f := messageGet(m, "TargetSystem"); f.IsValid() && f.Uint() != 0 &&
+			byte(f.Uint()) != n.conf.OutSystemID {
+			return false
+		}
+
+		return true
+	}, 8)
+	defer n.nodeWaiters.remove(fw)
+
+	attempt := 0
+	inProgress := false
+
+	for {
+		timeout := n.conf.CommandInProgressTimeout
+
+		if !inProgress {
+			if attempt > n.conf.CommandRetries {
+				return 0, fmt.Errorf("timeout")
+			}
+
+			n.writeMessageToTarget(t, build(attempt))
+			attempt++
+			timeout = n.conf.CommandTimeout
+		}
+
+		timerC, stopTimer := n.conf.Clock.NewTimer(timeout)
+
+		select {
+		case evt := <-fw.frames:
+			stopTimer()
+
+			res := CommandResult(messageGetInt(evt.Message(), "Result"))
+			if res == CommandResultInProgress {
+				inProgress = true
+				continue
+			}
+			return res, nil
+
+		case <-timerC:
+			if inProgress {
+				return 0, fmt.Errorf("timeout")
+			}
+
+		case <-ctx.Done():
+			stopTimer()
+			return 0, ctx.Err()
+		}
+	}
+}
+
+// runCommand sends a command and returns an error if it is not accepted.
+func (n *Node) runCommand(ctx context.Context, t Target, command int, params ...float32) error {
+	res, err := n.SendCommand(ctx, t, command, params...)
+	if err != nil {
+		return err
+	}
+	if res != CommandResultAccepted {
+		return fmt.Errorf("command %d: %s", command, res)
+	}
+	return nil
+}
+
+// RequestMessage requests a single message to a component through
+// MAV_CMD_REQUEST_MESSAGE and waits for it. The requested message is
+// identified by the type of m, that is filled with the received message:
+//
+//	var info common.MessageCameraInformation
+//	err := node.RequestMessage(ctx, target, &info)
+//
+// params are the parameters of the command that follow the message ID,
+// for instance the index of the requested item, up to 6.
+// The request is sent again if the message is not received within
+// NodeConf.CommandTimeout, up to NodeConf.CommandRetries times.
+func (n *Node) RequestMessage(ctx context.Context, t Target, m msg.Message, params ...float32) error {
+	if len(params) > 6 {
+		return fmt.Errorf("too many parameters")
+	}
+
+	rv := reflect.ValueOf(m)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("message must be a non-nil pointer")
+	}
+
+	if !dialectContainsType(n.conf.Dialect, m) {
+		return fmt.Errorf("message %T is not in the dialect", m)
+	}
+
+	evt, err := n.requestMessage(ctx, t, m.GetID(), nil, n.conf.CommandTimeout, params...)
+	if err != nil {
+		return err
+	}
+
+	res := reflect.ValueOf(evt.Message())
+	if res.Type() != rv.Type() {
+		return fmt.Errorf("unexpected message type %T", evt.Message())
+	}
+
+	rv.Elem().Set(res.Elem())
+	return nil
+}
+
+// requestMessage requests a message through MAV_CMD_REQUEST_MESSAGE and
+// waits for it. params are the parameters of the command that follow the
+// message id. The request is sent again if the message is not received
+// within timeout, up to NodeConf.CommandRetries times.
+func (n *Node) requestMessage(ctx context.Context, t Target, id uint32,
+	match func(*EventFrame) bool, timeout time.Duration, params ...float32) (*EventFrame, error) {
+	fw := n.nodeWaiters.add(func(evt *EventFrame) bool {
+		return evt.Message().GetID() == id && t.matches(evt) &&
+			(match == nil || match(evt))
+	}, 1)
+	defer n.nodeWaiters.remove(fw)
+
+	for attempt := 0; ; attempt++ {
+		err := n.runCommand(ctx, t, 512, append([]float32{float32(id)}, params...)...)
+		if err != nil {
+			return nil, err
+		}
+
+		timerC, stopTimer := n.conf.Clock.NewTimer(timeout)
+
+		select {
+		case evt := <-fw.frames:
+			stopTimer()
+			return evt, nil
+
+		case <-timerC:
+			if attempt >= n.conf.CommandRetries {
+				return nil, fmt.Errorf("timeout")
+			}
+
+		case <-ctx.Done():
+			stopTimer()
+			return nil, ctx.Err()
+		}
+	}
+}
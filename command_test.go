@@ -0,0 +1,228 @@
+package gomavlib
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+)
+
+func TestNodeSendCommand(t *testing.T) {
+	node1, node2 := newTestNodePair(t, common.Dialect)
+	defer node1.Close()
+	defer node2.Close()
+
+	go func() {
+		for range node1.Events() {
+		}
+	}()
+
+	// a vehicle that ignores the first attempt and then reports progress
+	received := make(chan *common.MessageCommandLong, 10)
+	go func() {
+		for evt := range node2.Events() {
+			fr, ok := evt.(*EventFrame)
+			if !ok {
+				continue
+			}
+
+			m, ok := fr.Message().(*common.MessageCommandLong)
+			if !ok {
+				continue
+			}
+			received <- m
+
+			if m.Confirmation == 0 {
+				continue
+			}
+
+			node2.WriteMessageAll(&common.MessageCommandAck{
+				Command:      m.Command,
+				Result:       5,
+				Progress:     50,
+				TargetSystem: 10,
+			})
+			node2.WriteMessageAll(&common.MessageCommandAck{
+				Command:      m.Command,
+				Result:       0,
+				TargetSystem: 10,
+			})
+		}
+	}()
+
+	res, err := node1.SendCommand(context.Background(), Target{
+		SystemID:    11,
+		ComponentID: 1,
+	}, 400, 1, 21196)
+	require.NoError(t, err)
+	require.Equal(t, CommandResultAccepted, res)
+
+	m := <-received
+	require.Equal(t, &common.MessageCommandLong{
+		TargetSystem:    11,
+		TargetComponent: 1,
+		Command:         400,
+		Param1:          1,
+		Param2:          21196,
+	}, m)
+
+	m = <-received
+	require.Equal(t, uint8(1), m.Confirmation)
+
+	_, err = node1.SendCommand(context.Background(), Target{
+		SystemID:    11,
+		ComponentID: 1,
+	}, 400, 1, 2, 3, 4, 5, 6, 7, 8)
+	require.EqualError(t, err, "too many parameters")
+}
+
+func TestNodeSendCommandRetries(t *testing.T) {
+	l1 := newTestPipe()
+	l2 := newTestPipe()
+
+	node1, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 10,
+		Endpoints: []EndpointConf{
+			EndpointCustom{&testEndpoint{l1, l2}},
+		},
+		HeartbeatDisable: true,
+		CommandTimeout:   50 * time.Millisecond,
+		CommandRetries:   1,
+	})
+	require.NoError(t, err)
+	defer node1.Close()
+
+	node2, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 11,
+		Endpoints: []EndpointConf{
+			EndpointCustom{&testEndpoint{l2, l1}},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node2.Close()
+
+	go func() {
+		for range node1.Events() {
+		}
+	}()
+
+	// a vehicle that never answers
+	received := make(chan *common.MessageCommandLong, 10)
+	go func() {
+		for evt := range node2.Events() {
+			if fr, ok := evt.(*EventFrame); ok {
+				if m, ok := fr.Message().(*common.MessageCommandLong); ok {
+					received <- m
+				}
+			}
+		}
+	}()
+
+	_, err = node1.SendCommand(context.Background(), Target{
+		SystemID:    11,
+		ComponentID: 1,
+	}, 400, 1)
+	require.EqualError(t, err, "timeout")
+
+	require.Equal(t, uint8(0), (<-received).Confirmation)
+	require.Equal(t, uint8(1), (<-received).Confirmation)
+	require.Equal(t, 0, len(received))
+}
+
+func TestNodeRequestMessage(t *testing.T) {
+	l1 := newTestPipe()
+	l2 := newTestPipe()
+
+	node1, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 10,
+		Endpoints: []EndpointConf{
+			EndpointCustom{ReadWriteCloser: &testEndpoint{l1, l2}},
+		},
+		HeartbeatDisable: true,
+		CommandTimeout:   50 * time.Millisecond,
+		CommandRetries:   1,
+	})
+	require.NoError(t, err)
+	defer node1.Close()
+
+	node2, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 11,
+		Endpoints: []EndpointConf{
+			EndpointCustom{ReadWriteCloser: &testEndpoint{l2, l1}},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node2.Close()
+
+	go func() {
+		for range node1.Events() {
+		}
+	}()
+
+	// a vehicle that loses the first home position
+	go func() {
+		count := 0
+		for evt := range node2.Events() {
+			fr, ok := evt.(*EventFrame)
+			if !ok {
+				continue
+			}
+
+			m, ok := fr.Message().(*common.MessageCommandLong)
+			if !ok || m.Command != 512 {
+				continue
+			}
+
+			node2.WriteMessageAll(&common.MessageCommandAck{
+				Command: m.Command,
+				Result:  0,
+			})
+
+			if m.Param1 == 242 {
+				count++
+				if count == 1 {
+					continue
+				}
+				node2.WriteMessageAll(&common.MessageHomePosition{
+					Latitude:  1,
+					Longitude: 2,
+					Altitude:  3,
+				})
+			}
+		}
+	}()
+
+	target := Target{
+		SystemID:    11,
+		ComponentID: 1,
+	}
+
+	var home common.MessageHomePosition
+	err = node1.RequestMessage(context.Background(), target, &home)
+	require.NoError(t, err)
+	require.Equal(t, common.MessageHomePosition{
+		Latitude:  1,
+		Longitude: 2,
+		Altitude:  3,
+	}, home)
+
+	// a message that is never sent
+	err = node1.RequestMessage(context.Background(), target, &common.MessageCameraInformation{})
+	require.EqualError(t, err, "timeout")
+
+	err = node1.RequestMessage(context.Background(), target, &MessageHeartbeat{})
+	require.EqualError(t, err, "message *gomavlib.MessageHeartbeat is not in the dialect")
+}
@@ -0,0 +1,333 @@
+package gomavlib
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+// ComponentMetadataType is the type of a component metadata (COMP_METADATA_TYPE).
+type ComponentMetadataType int
+
+// component metadata types.
+const (
+	ComponentMetadataTypeGeneral     ComponentMetadataType = 0
+	ComponentMetadataTypeParameter   ComponentMetadataType = 1
+	ComponentMetadataTypeCommands    ComponentMetadataType = 2
+	ComponentMetadataTypePeripherals ComponentMetadataType = 3
+	ComponentMetadataTypeEvents      ComponentMetadataType = 4
+)
+
+// ComponentInformation contains the references to the metadata of a
+// component (COMPONENT_INFORMATION).
+type ComponentInformation struct {
+	GeneralMetadataURI     string
+	GeneralMetadataCRC     uint32
+	PeripheralsMetadataURI string
+	PeripheralsMetadataCRC uint32
+}
+
+// componentGeneralMetadata is the content of the general metadata file.
+type componentGeneralMetadata struct {
+	MetadataTypes []struct {
+		Type ComponentMetadataType `json:"type"`
+		URI  string                `json:"uri"`
+	} `json:"metadataTypes"`
+}
+
+// ComponentInformationClientConf allows to configure a ComponentInformationClient.
+type ComponentInformationClientConf struct {
+	// the node used to communicate.
+	// Its dialect must contain the COMMAND_*, COMPONENT_INFORMATION and
+	// FILE_TRANSFER_PROTOCOL messages.
+	Node *Node
+	// the component whose metadata are fetched.
+	Target Target
+
+	// (optional) the time to wait for a response before retrying.
+	// It defaults to 1 second.
+	Timeout time.Duration
+}
+
+// ComponentInformationClient implements the client side of the component
+// information protocol, that allows to fetch the metadata of a component,
+// like the parameter metadata.
+// Files are downloaded through MAVLink FTP or HTTP; compressed files are
+// returned as they are.
+type ComponentInformationClient struct {
+	conf ComponentInformationClientConf
+}
+
+// NewComponentInformationClient allocates a ComponentInformationClient.
+// See ComponentInformationClientConf for the options.
+func NewComponentInformationClient(conf ComponentInformationClientConf) (*ComponentInformationClient, error) {
+	if conf.Node == nil {
+		return nil, fmt.Errorf("Node not provided")
+	}
+	if conf.Timeout == 0 {
+		conf.Timeout = 1 * time.Second
+	}
+
+	d := conf.Node.conf.Dialect
+	if dialectMessage(d, 76, 152) == nil ||
+		dialectMessage(d, 77, 143) == nil ||
+		dialectMessage(d, 395, 0) == nil ||
+		dialectMessage(d, 110, 84) == nil {
+		return nil, fmt.Errorf("dialect does not contain the component information protocol messages")
+	}
+
+	return &ComponentInformationClient{
+		conf: conf,
+	}, nil
+}
+
+// Information returns the references to the metadata of the component.
+func (c *ComponentInformationClient) Information(ctx context.Context) (*ComponentInformation, error) {
+	evt, err := c.conf.Node.requestMessage(ctx, c.conf.Target, 395, nil, c.conf.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	m := evt.Message()
+
+	return &ComponentInformation{
+		GeneralMetadataURI:     messageGetString(m, "GeneralMetadataUri"),
+		GeneralMetadataCRC:     uint32(messageGetInt(m, "GeneralMetadataFileCrc")),
+		PeripheralsMetadataURI: messageGetString(m, "PeripheralsMetadataUri"),
+		PeripheralsMetadataCRC: uint32(messageGetInt(m, "PeripheralsMetadataFileCrc")),
+	}, nil
+}
+
+// Metadata fetches a metadata file of the component.
+func (c *ComponentInformationClient) Metadata(ctx context.Context, typ ComponentMetadataType) ([]byte, error) {
+	info, err := c.Information(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case typ == ComponentMetadataTypeGeneral:
+		return c.download(ctx, info.GeneralMetadataURI)
+
+	case typ == ComponentMetadataTypePeripherals && info.PeripheralsMetadataURI != "":
+		return c.download(ctx, info.PeripheralsMetadataURI)
+	}
+
+	// other metadata files are referenced by the general metadata file
+	buf, err := c.download(ctx, info.GeneralMetadataURI)
+	if err != nil {
+		return nil, err
+	}
+
+	var general componentGeneralMetadata
+	err = json.Unmarshal(buf, &general)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode general metadata: %s", err)
+	}
+
+	for _, entry := range general.MetadataTypes {
+		if entry.Type == typ {
+			return c.download(ctx, entry.URI)
+		}
+	}
+
+	return nil, fmt.Errorf("metadata type %d not provided by the component", typ)
+}
+
+func (c *ComponentInformationClient) download(ctx context.Context, uri string) ([]byte, error) {
+	switch {
+	case strings.HasPrefix(uri, "mftp://"):
+		path := strings.TrimPrefix(uri, "mftp://")
+		target := c.conf.Target
+
+		// files can be provided by another component of the same system
+		if strings.HasPrefix(path, "[;comp=") {
+			i := strings.IndexByte(path, ']')
+			if i < 0 {
+				return nil, fmt.Errorf("invalid URI: %s", uri)
+			}
+
+			compID, err := strconv.ParseUint(path[len("[;comp="):i], 10, 8)
+			if err != nil {
+				return nil, fmt.Errorf("invalid URI: %s", uri)
+			}
+
+			target.ComponentID = byte(compID)
+			path = path[i+1:]
+		}
+
+		fc, err := newFTPClient(ftpClientConf{
+			Node:    c.conf.Node,
+			Target:  target,
+			Timeout: c.conf.Timeout,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return fc.download(ctx, path)
+
+	case strings.HasPrefix(uri, "http://"), strings.HasPrefix(uri, "https://"):
+		req, err := http.NewRequest(http.MethodGet, uri, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		res, err := http.DefaultClient.Do(req.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		defer res.Body.Close()
+
+		if res.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("bad status code: %d", res.StatusCode)
+		}
+
+		return ioutil.ReadAll(res.Body)
+	}
+
+	return nil, fmt.Errorf("unsupported URI: %s", uri)
+}
+
+// ComponentInformationServerConf allows to configure a ComponentInformationServer.
+type ComponentInformationServerConf struct {
+	// the node used to communicate.
+	// Its dialect must contain the COMMAND_* and COMPONENT_INFORMATION messages.
+	Node *Node
+	// the file system that contains the metadata files.
+	// It must be exposed by a FTPServer.
+	FileSystem FTPFileSystem
+	// the path of the general metadata file.
+	GeneralMetadataPath string
+
+	// (optional) the path of the peripherals metadata file.
+	PeripheralsMetadataPath string
+}
+
+// ComponentInformationServer implements the server side of the component
+// information protocol, that allows remote components to fetch the metadata
+// of the node.
+type ComponentInformationServer struct {
+	conf          ComponentInformationServerConf
+	message       msg.Message
+	msgCommandAck msg.Message
+	frameService
+}
+
+// NewComponentInformationServer allocates a ComponentInformationServer.
+// See ComponentInformationServerConf for the options.
+func NewComponentInformationServer(conf ComponentInformationServerConf) (*ComponentInformationServer, error) {
+	if conf.Node == nil {
+		return nil, fmt.Errorf("Node not provided")
+	}
+	if conf.FileSystem == nil {
+		return nil, fmt.Errorf("FileSystem not provided")
+	}
+	if conf.GeneralMetadataPath == "" {
+		return nil, fmt.Errorf("GeneralMetadataPath not provided")
+	}
+
+	d := conf.Node.conf.Dialect
+	tpl := dialectMessage(d, 395, 0)
+	if tpl == nil || dialectMessage(d, 76, 152) == nil ||
+		dialectMessage(d, 77, 143) == nil {
+		return nil, fmt.Errorf("dialect does not contain the component information protocol messages")
+	}
+
+	m := newMessage(tpl)
+
+	crc, err := componentMetadataCRC(conf.FileSystem, conf.GeneralMetadataPath)
+	if err != nil {
+		return nil, err
+	}
+	messageSet(m, "GeneralMetadataUri", componentMetadataURI(conf.GeneralMetadataPath))
+	messageSet(m, "GeneralMetadataFileCrc", crc)
+
+	if conf.PeripheralsMetadataPath != "" {
+		crc, err := componentMetadataCRC(conf.FileSystem, conf.PeripheralsMetadataPath)
+		if err != nil {
+			return nil, err
+		}
+		messageSet(m, "PeripheralsMetadataUri", componentMetadataURI(conf.PeripheralsMetadataPath))
+		messageSet(m, "PeripheralsMetadataFileCrc", crc)
+	}
+
+	s := &ComponentInformationServer{
+		conf:          conf,
+		message:       m,
+		msgCommandAck: dialectMessage(d, 77, 143),
+	}
+
+	s.start(conf.Node, s.isRequest, 16, s.run)
+
+	return s, nil
+}
+
+func componentMetadataURI(path string) string {
+	return "mftp:///" + strings.TrimPrefix(path, "/")
+}
+
+func componentMetadataCRC(fs FTPFileSystem, path string) (uint32, error) {
+	f, err := fs.Open(ftpPath([]byte(path)))
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	buf, err := ioutil.ReadAll(f)
+	if err != nil {
+		return 0, err
+	}
+
+	return ftpCRC32(0, buf), nil
+}
+
+// Close stops the server.
+func (s *ComponentInformationServer) Close() {
+	s.close()
+}
+
+func (s *ComponentInformationServer) isRequest(evt *EventFrame) bool {
+	m := evt.Message()
+	if m.GetID() != 76 ||
+		messageGetInt(m, "Command") != 512 ||
+		messageGetFloat(m, "Param1") != 395 {
+		return false
+	}
+
+	sysID := byte(messageGetInt(m, "TargetSystem"))
+	compID := byte(messageGetInt(m, "TargetComponent"))
+	return sysID == s.conf.Node.conf.OutSystemID &&
+		(compID == s.conf.Node.conf.OutComponentID || compID == 0)
+}
+
+func (s *ComponentInformationServer) run() {
+	for {
+		select {
+		case evt := <-s.fw.frames:
+			source := Target{
+				Channel:     evt.Channel,
+				SystemID:    evt.SystemID(),
+				ComponentID: evt.ComponentID(),
+			}
+
+			ack := newMessage(s.msgCommandAck)
+			messageSet(ack, "Command", 512)
+			messageSet(ack, "Result", int(CommandResultAccepted))
+			messageSet(ack, "TargetSystem", source.SystemID)
+			messageSet(ack, "TargetComponent", source.ComponentID)
+			s.conf.Node.writeMessageToTarget(source, ack)
+
+			s.conf.Node.writeMessageToTarget(source, s.message)
+
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package gomavlib
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+)
+
+func TestComponentInformation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gomavlib")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	general := []byte(`{"version":1,"metadataTypes":[` +
+		`{"type":1,"uri":"mftp:///metadata/parameters.json"}]}`)
+	err = ioutil.WriteFile(filepath.Join(dir, "general.json"), general, 0o644)
+	require.NoError(t, err)
+
+	// bigger than a single FTP message
+	parameters := make([]byte, 1000)
+	for i := range parameters {
+		parameters[i] = 'a' + byte(i%26)
+	}
+	err = os.Mkdir(filepath.Join(dir, "metadata"), 0o755)
+	require.NoError(t, err)
+	err = ioutil.WriteFile(filepath.Join(dir, "metadata", "parameters.json"), parameters, 0o644)
+	require.NoError(t, err)
+
+	node1, node2 := newTestNodePair(t, common.Dialect)
+	defer node1.Close()
+	defer node2.Close()
+
+	go func() {
+		for range node1.Events() {
+		}
+	}()
+	go func() {
+		for range node2.Events() {
+		}
+	}()
+
+	fs, err := NewFTPServer(FTPServerConf{
+		Node:       node2,
+		FileSystem: FTPDir(dir),
+	})
+	require.NoError(t, err)
+	defer fs.Close()
+
+	s, err := NewComponentInformationServer(ComponentInformationServerConf{
+		Node:                node2,
+		FileSystem:          FTPDir(dir),
+		GeneralMetadataPath: "general.json",
+	})
+	require.NoError(t, err)
+	defer s.Close()
+
+	c, err := NewComponentInformationClient(ComponentInformationClientConf{
+		Node: node1,
+		Target: Target{
+			SystemID:    11,
+			ComponentID: 1,
+		},
+	})
+	require.NoError(t, err)
+
+	info, err := c.Information(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, &ComponentInformation{
+		GeneralMetadataURI: "mftp:///general.json",
+		GeneralMetadataCRC: ftpCRC32(0, general),
+	}, info)
+
+	buf, err := c.Metadata(context.Background(), ComponentMetadataTypeGeneral)
+	require.NoError(t, err)
+	require.Equal(t, general, buf)
+
+	buf, err = c.Metadata(context.Background(), ComponentMetadataTypeParameter)
+	require.NoError(t, err)
+	require.Equal(t, parameters, buf)
+
+	_, err = c.Metadata(context.Background(), ComponentMetadataTypeEvents)
+	require.EqualError(t, err, "metadata type 4 not provided by the component")
+}
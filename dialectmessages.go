@@ -0,0 +1,97 @@
+package gomavlib
+
+import (
+	"reflect"
+
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+// dialectMessage returns the message of the dialect with the given id,
+// if it exists and its CRC extra corresponds to the expected one, that
+// ensures that the message corresponds to the standard.
+func dialectMessage(d *dialect.Dialect, id uint32, crcExtra byte) msg.Message {
+	if d == nil {
+		return nil
+	}
+
+	for _, m := range d.Messages {
+		if m.GetID() == id {
+			mde, err := msg.NewDecEncoder(m)
+			if err != nil || mde.CRCExtra() != crcExtra {
+				return nil
+			}
+			return m
+		}
+	}
+	return nil
+}
+
+// dialectContainsType checks whether the dialect contains a message with the
+// same type of the given one.
+func dialectContainsType(d *dialect.Dialect, m msg.Message) bool {
+	if d == nil {
+		return false
+	}
+
+	t := reflect.TypeOf(m)
+	for _, dm := range d.Messages {
+		if reflect.TypeOf(dm) == t {
+			return true
+		}
+	}
+	return false
+}
+
+// newMessage allocates a message with the same type of the given one.
+func newMessage(tpl msg.Message) msg.Message {
+	return reflect.New(reflect.TypeOf(tpl).Elem()).Interface().(msg.Message)
+}
+
+// messageCopy returns a shallow copy of a message.
+func messageCopy(m msg.Message) msg.Message {
+	cpy := newMessage(m)
+	reflect.ValueOf(cpy).Elem().Set(reflect.ValueOf(m).Elem())
+	return cpy
+}
+
+// messageSet sets a message field, converting the value to the field type.
+func messageSet(m msg.Message, name string, v interface{}) {
+	f := reflect.ValueOf(m).Elem().FieldByName(name)
+	f.Set(reflect.ValueOf(v).Convert(f.Type()))
+}
+
+// messageGet returns a message field.
+func messageGet(m msg.Message, name string) reflect.Value {
+	return reflect.ValueOf(m).Elem().FieldByName(name)
+}
+
+// messageGetInt returns a message field that contains an integer.
+func messageGetInt(m msg.Message, name string) int64 {
+	f := messageGet(m, name)
+	switch f.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(f.Uint())
+	}
+	return f.Int()
+}
+
+// messageGetFloat returns a message field that contains a floating point number.
+func messageGetFloat(m msg.Message, name string) float64 {
+	return messageGet(m, name).Float()
+}
+
+// messageGetString returns a message field that contains a string.
+func messageGetString(m msg.Message, name string) string {
+	return messageGet(m, name).String()
+}
+
+// messageGetBytes returns the first n elements of a message field that
+// contains a byte array.
+func messageGetBytes(m msg.Message, name string, n int) []byte {
+	f := messageGet(m, name)
+	if n > f.Len() {
+		n = f.Len()
+	}
+	return append([]byte(nil), f.Slice(0, n).Bytes()...)
+}
@@ -0,0 +1,58 @@
+package gomavlib
+
+import (
+	"time"
+)
+
+type downsamplerKey struct {
+	messageID   uint32
+	systemID    byte
+	componentID byte
+}
+
+// downsampler limits the rate of selected messages. The rate is limited
+// separately for each system and component, in order not to discard the
+// messages of a system because of the messages of another.
+type downsampler struct {
+	periods map[uint32]time.Duration
+	next    map[downsamplerKey]time.Time
+}
+
+func newDownsampler(rates map[uint32]float64) *downsampler {
+	periods := make(map[uint32]time.Duration, len(rates))
+	for id, rate := range rates {
+		periods[id] = time.Duration(float64(time.Second) / rate)
+	}
+
+	return &downsampler{
+		periods: periods,
+		next:    make(map[downsamplerKey]time.Time),
+	}
+}
+
+// allow checks whether a message of a system and component can be written.
+// It must be called before the message is encoded, in order not to waste
+// sequence numbers with discarded frames.
+func (d *downsampler) allow(messageID uint32, systemID byte, componentID byte, now time.Time) bool {
+	period, ok := d.periods[messageID]
+	if !ok {
+		return true
+	}
+
+	key := downsamplerKey{messageID, systemID, componentID}
+
+	next, ok := d.next[key]
+	if ok && now.Before(next) {
+		return false
+	}
+
+	// the deadline of the next frame is computed from the previous deadline,
+	// in order to reach the configured rate even when the incoming rate is
+	// not a multiple of it. After a pause, it is computed from now.
+	if ok && now.Sub(next) < period {
+		d.next[key] = next.Add(period)
+	} else {
+		d.next[key] = now.Add(period)
+	}
+	return true
+}
@@ -0,0 +1,50 @@
+package gomavlib
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDownsampler(t *testing.T) {
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	d := newDownsampler(map[uint32]float64{30: 10})
+
+	// a 1kHz stream is limited to 10Hz
+	count := 0
+	for i := 0; i < 1000; i++ {
+		if d.allow(30, 1, 1, now.Add(time.Duration(i)*time.Millisecond)) {
+			count++
+		}
+	}
+	require.Equal(t, 10, count)
+
+	// systems are limited separately
+	require.True(t, d.allow(30, 2, 1, now))
+	require.False(t, d.allow(30, 2, 1, now.Add(50*time.Millisecond)))
+
+	// messages that are not listed are not limited
+	require.True(t, d.allow(0, 1, 1, now))
+	require.True(t, d.allow(0, 1, 1, now))
+}
+
+func TestDownsamplerJitter(t *testing.T) {
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	d := newDownsampler(map[uint32]float64{30: 10})
+
+	// a 30Hz stream is limited to 10Hz, even if 100ms is not a multiple of
+	// the incoming period
+	count := 0
+	for i := 0; i < 300; i++ {
+		if d.allow(30, 1, 1, now.Add(time.Duration(i)*time.Second/30)) {
+			count++
+		}
+	}
+	require.Equal(t, 100, count)
+
+	// after a pause, the rate is not exceeded
+	now = now.Add(20 * time.Second)
+	require.True(t, d.allow(30, 1, 1, now))
+	require.False(t, d.allow(30, 1, 1, now.Add(50*time.Millisecond)))
+}
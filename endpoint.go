@@ -2,6 +2,7 @@ package gomavlib
 
 import (
 	"io"
+	"net"
 )
 
 // EndpointConf is the interface implemented by all endpoint configurations.
@@ -17,6 +18,105 @@ type Endpoint interface {
 	isEndpoint()
 }
 
+// EndpointKind is the kind of an endpoint.
+type EndpointKind string
+
+// endpoint kinds.
+const (
+	EndpointKindSerial       EndpointKind = "serial"
+	EndpointKindUDPServer    EndpointKind = "udp-server"
+	EndpointKindUDPClient    EndpointKind = "udp-client"
+	EndpointKindUDPBroadcast EndpointKind = "udp-broadcast"
+	EndpointKindTCPServer    EndpointKind = "tcp-server"
+	EndpointKindTCPClient    EndpointKind = "tcp-client"
+	EndpointKindWebSocket    EndpointKind = "websocket"
+	EndpointKindCustom       EndpointKind = "custom"
+)
+
+// endpointKind returns the kind of an endpoint configuration.
+// Wrapper configurations have the kind of the endpoint they wrap.
+func endpointKind(conf EndpointConf) EndpointKind {
+	for {
+		switch tconf := conf.(type) {
+		case EndpointRateLimit:
+			conf = tconf.Endpoint
+
+		case EndpointHighLatency:
+			conf = tconf.Endpoint
+
+		case EndpointVersion:
+			conf = tconf.Endpoint
+
+		case EndpointBackpressure:
+			conf = tconf.Endpoint
+
+		case EndpointHeartbeatDisable:
+			conf = tconf.Endpoint
+
+		case EndpointOutTransform:
+			conf = tconf.Endpoint
+
+		case EndpointSignature:
+			conf = tconf.Endpoint
+
+		case EndpointIDTranslation:
+			conf = tconf.Endpoint
+
+		case EndpointDownsample:
+			conf = tconf.Endpoint
+
+		case EndpointFaultInjection:
+			conf = tconf.Endpoint
+
+		case EndpointSITL:
+			inner, err := tconf.inner()
+			if err != nil {
+				return EndpointKindCustom
+			}
+			conf = inner
+
+		case EndpointShared:
+			if tconf.Share == nil {
+				return EndpointKindCustom
+			}
+			conf = tconf.Share.conf.Endpoint
+
+		case EndpointSerial:
+			return EndpointKindSerial
+
+		case EndpointUDPServer:
+			return EndpointKindUDPServer
+
+		case EndpointUDPClient:
+			return EndpointKindUDPClient
+
+		case EndpointUDPBroadcast:
+			return EndpointKindUDPBroadcast
+
+		case EndpointTCPServer:
+			return EndpointKindTCPServer
+
+		case EndpointTCPClient:
+			return EndpointKindTCPClient
+
+		case EndpointWebSocket:
+			return EndpointKindWebSocket
+
+		case EndpointExternal:
+			return tconf.kind()
+
+		default:
+			return EndpointKindCustom
+		}
+	}
+}
+
+// remoteAddrProvider is implemented by channels that know the address of the
+// remote peer.
+type remoteAddrProvider interface {
+	RemoteAddr() net.Addr
+}
+
 // a endpoint must also implement one of the following:
 // - endpointChannelSingle
 // - endpointChannelAccepter
@@ -0,0 +1,34 @@
+package gomavlib
+
+import (
+	"fmt"
+)
+
+// EndpointBackpressure wraps an endpoint and sets the policy applied to each
+// of its channels when it cannot keep up with writes, in order to prevent a
+// slow channel from stalling the writers of all the others.
+// Discarded writes are counted in ChannelStats.WriteDrops.
+type EndpointBackpressure struct {
+	// the wrapped endpoint
+	Endpoint EndpointConf
+
+	// the policy applied when the write queue of a channel is full.
+	Policy BackpressurePolicy
+
+	// (optional) the size of the write queue of each channel.
+	// It defaults to NodeConf.WriteQueueSize.
+	WriteQueueSize int
+}
+
+func (conf EndpointBackpressure) init() (Endpoint, error) {
+	if conf.WriteQueueSize < 0 {
+		return nil, fmt.Errorf("WriteQueueSize must be >= 0")
+	}
+
+	return wrapEndpoint(conf, conf.Endpoint, func(opts *channelOptions) {
+		opts.backpressurePolicy = conf.Policy
+		if conf.WriteQueueSize != 0 {
+			opts.writeQueueSize = conf.WriteQueueSize
+		}
+	})
+}
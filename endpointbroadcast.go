@@ -115,6 +115,10 @@ func (t *endpointUDPBroadcast) Label() string {
 	return fmt.Sprintf("udp:%s", t.broadcastAddr)
 }
 
+func (t *endpointUDPBroadcast) RemoteAddr() net.Addr {
+	return t.broadcastAddr
+}
+
 func (t *endpointUDPBroadcast) Close() error {
 	close(t.terminate)
 	t.pc.Close()
@@ -13,7 +13,8 @@ import (
 type endpointClientConf interface {
 	isUDP() bool
 	getAddress() string
-	init() (Endpoint, error)
+	getLocalAddress() string
+	EndpointConf
 }
 
 // EndpointTCPClient sets up a endpoint that works with a TCP client.
@@ -23,6 +24,11 @@ type endpointClientConf interface {
 type EndpointTCPClient struct {
 	// domain name or IP of the server to connect to, example: 1.2.3.4:5600
 	Address string
+
+	// (optional) local IP and port to bind to before connecting,
+	// example: 192.168.1.2:14550. The IP or the port can be omitted,
+	// example: :14550
+	LocalAddress string
 }
 
 func (EndpointTCPClient) isUDP() bool {
@@ -33,6 +39,10 @@ func (conf EndpointTCPClient) getAddress() string {
 	return conf.Address
 }
 
+func (conf EndpointTCPClient) getLocalAddress() string {
+	return conf.LocalAddress
+}
+
 func (conf EndpointTCPClient) init() (Endpoint, error) {
 	return initEndpointClient(conf)
 }
@@ -41,6 +51,11 @@ func (conf EndpointTCPClient) init() (Endpoint, error) {
 type EndpointUDPClient struct {
 	// domain name or IP of the server to connect to, example: 1.2.3.4:5600
 	Address string
+
+	// (optional) local IP and port to bind to before connecting,
+	// example: 192.168.1.2:14550. The IP or the port can be omitted,
+	// example: :14550
+	LocalAddress string
 }
 
 func (EndpointUDPClient) isUDP() bool {
@@ -51,14 +66,20 @@ func (conf EndpointUDPClient) getAddress() string {
 	return conf.Address
 }
 
+func (conf EndpointUDPClient) getLocalAddress() string {
+	return conf.LocalAddress
+}
+
 func (conf EndpointUDPClient) init() (Endpoint, error) {
 	return initEndpointClient(conf)
 }
 
 type endpointClient struct {
 	conf        endpointClientConf
+	localAddr   net.Addr
 	writerMutex sync.Mutex
 	writer      io.Writer
+	remoteAddr  net.Addr
 
 	// in
 	terminate chan struct{}
@@ -71,8 +92,21 @@ func initEndpointClient(conf endpointClientConf) (Endpoint, error) {
 		return nil, fmt.Errorf("invalid address")
 	}
 
+	var localAddr net.Addr
+	if conf.getLocalAddress() != "" {
+		if conf.isUDP() {
+			localAddr, err = net.ResolveUDPAddr("udp4", conf.getLocalAddress())
+		} else {
+			localAddr, err = net.ResolveTCPAddr("tcp4", conf.getLocalAddress())
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid local address")
+		}
+	}
+
 	t := &endpointClient{
 		conf:      conf,
+		localAddr: localAddr,
 		terminate: make(chan struct{}),
 		read:      make(chan []byte),
 	}
@@ -122,8 +156,13 @@ func (t *endpointClient) do() {
 				return "tcp4"
 			}()
 
+			dialer := &net.Dialer{
+				Timeout:   netConnectTimeout,
+				LocalAddr: t.localAddr,
+			}
+
 			var err error
-			rawConn, err = net.DialTimeout(network, t.conf.getAddress(), netConnectTimeout)
+			rawConn, err = dialer.Dial(network, t.conf.getAddress())
 			if err != nil {
 				rawConn = nil // ensure rawConn is nil in case of error
 			}
@@ -164,11 +203,12 @@ func (t *endpointClient) do() {
 			continue
 		}
 
-		conn := &netTimedConn{rawConn}
+		conn := &netTimedConn{conn: rawConn}
 		func() {
 			t.writerMutex.Lock()
 			defer t.writerMutex.Unlock()
 			t.writer = conn
+			t.remoteAddr = rawConn.RemoteAddr()
 		}()
 
 		readerDone := make(chan struct{})
@@ -205,10 +245,17 @@ func (t *endpointClient) do() {
 			t.writerMutex.Lock()
 			defer t.writerMutex.Unlock()
 			t.writer = nil
+			t.remoteAddr = nil
 		}()
 	}
 }
 
+func (t *endpointClient) RemoteAddr() net.Addr {
+	t.writerMutex.Lock()
+	defer t.writerMutex.Unlock()
+	return t.remoteAddr
+}
+
 func (t *endpointClient) Read(buf []byte) (int, error) {
 	src, ok := <-t.read
 	if !ok {
@@ -0,0 +1,46 @@
+package gomavlib
+
+import (
+	"fmt"
+)
+
+// EndpointDownsample wraps an endpoint and limits the rate at which selected
+// messages are written to its channels, in order to forward high-frequency
+// streams to slow links, like a telemetry radio. Frames that exceed the rate
+// are discarded before being encoded, therefore they do not consume sequence
+// numbers. The rate is limited separately for each system and component.
+// Messages that are not listed are not limited.
+type EndpointDownsample struct {
+	// the wrapped endpoint
+	Endpoint EndpointConf
+
+	// the maximum rate (in Hz) of each message, indexed by message id,
+	// for instance {30: 5} limits ATTITUDE to 5Hz.
+	Rates map[uint32]float64
+}
+
+func (conf EndpointDownsample) init() (Endpoint, error) {
+	if len(conf.Rates) == 0 {
+		return nil, fmt.Errorf("Rates not provided")
+	}
+
+	for id, rate := range conf.Rates {
+		if rate <= 0 {
+			return nil, fmt.Errorf("rate of message %d must be greater than zero", id)
+		}
+	}
+
+	return wrapEndpoint(conf, conf.Endpoint, func(opts *channelOptions) {
+		// when wrappers are nested, the lowest rate is used
+		rates := make(map[uint32]float64, len(opts.outRates)+len(conf.Rates))
+		for id, rate := range opts.outRates {
+			rates[id] = rate
+		}
+		for id, rate := range conf.Rates {
+			if cur, ok := rates[id]; !ok || rate < cur {
+				rates[id] = rate
+			}
+		}
+		opts.outRates = rates
+	})
+}
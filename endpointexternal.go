@@ -0,0 +1,128 @@
+package gomavlib
+
+import (
+	"fmt"
+	"io"
+	"net"
+)
+
+// EndpointProvider is the interface that transports implemented outside of
+// this library must implement in order to be used as endpoints, through
+// EndpointExternal.
+type EndpointProvider interface {
+	// Kind returns the kind of the endpoint, that is reported by
+	// Channel.EndpointKind(). If empty, EndpointKindCustom is used.
+	Kind() EndpointKind
+
+	// Open is called once by NewNode and opens the endpoint.
+	// The returned value must implement either EndpointChannelSingle
+	// or EndpointChannelAccepter.
+	Open() (EndpointChannelProvider, error)
+}
+
+// EndpointChannelProvider is an opened endpoint, that provides channels.
+// Close() is called when the node is closed.
+type EndpointChannelProvider interface {
+	Close() error
+}
+
+// EndpointChannelSingle is an opened endpoint that provides a single channel,
+// like a serial port.
+// Read() must not return any error unless Close() is called.
+// If the channel also implements RemoteAddr() net.Addr, the address is
+// reported by Channel.RemoteAddr().
+type EndpointChannelSingle interface {
+	EndpointChannelProvider
+	io.ReadWriter
+
+	// Label returns the label of the channel, that is used in logs and
+	// statistics.
+	Label() string
+}
+
+// EndpointChannelAccepter is an opened endpoint that provides a channel for
+// each connected peer, like a TCP server.
+// Accept() must block until a new peer is connected. Any error returned by
+// Accept() stops the endpoint, therefore it must return an error only when
+// Close() is called.
+// If the returned channels also implement RemoteAddr() net.Addr, the address
+// is reported by Channel.RemoteAddr() and is used to detect reconnections.
+type EndpointChannelAccepter interface {
+	EndpointChannelProvider
+
+	// Accept returns the label and the content of a new channel.
+	Accept() (string, io.ReadWriteCloser, error)
+}
+
+// EndpointExternal sets up an endpoint implemented outside of this library.
+// See EndpointProvider for the requirements.
+type EndpointExternal struct {
+	// the provider of the endpoint
+	Provider EndpointProvider
+}
+
+func (conf EndpointExternal) init() (Endpoint, error) {
+	if conf.Provider == nil {
+		return nil, fmt.Errorf("Provider not provided")
+	}
+
+	p, err := conf.Provider.Open()
+	if err != nil {
+		return nil, err
+	}
+
+	switch tp := p.(type) {
+	case EndpointChannelAccepter:
+		return &endpointExternalAccepter{conf, tp}, nil
+
+	case EndpointChannelSingle:
+		return &endpointExternalSingle{conf, tp}, nil
+	}
+
+	p.Close()
+	return nil, fmt.Errorf("endpoint %T does not implement any interface", p)
+}
+
+func (conf EndpointExternal) kind() EndpointKind {
+	if k := conf.Provider.Kind(); k != "" {
+		return k
+	}
+	return EndpointKindCustom
+}
+
+type endpointExternalSingle struct {
+	conf EndpointExternal
+	EndpointChannelSingle
+}
+
+func (t *endpointExternalSingle) isEndpoint() {}
+
+func (t *endpointExternalSingle) Conf() EndpointConf {
+	return t.conf
+}
+
+func (t *endpointExternalSingle) RemoteAddr() net.Addr {
+	if p, ok := t.EndpointChannelSingle.(remoteAddrProvider); ok {
+		return p.RemoteAddr()
+	}
+	return nil
+}
+
+type endpointExternalAccepter struct {
+	conf EndpointExternal
+	EndpointChannelAccepter
+}
+
+func (t *endpointExternalAccepter) isEndpoint() {}
+
+func (t *endpointExternalAccepter) Conf() EndpointConf {
+	return t.conf
+}
+
+func (t *endpointExternalAccepter) Accept() (string, io.ReadWriteCloser, error) {
+	label, rwc, err := t.EndpointChannelAccepter.Accept()
+	if err != nil {
+		return "", nil, errorTerminated
+	}
+	return label, rwc, nil
+}
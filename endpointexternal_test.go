@@ -0,0 +1,198 @@
+package gomavlib
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+)
+
+type testExternalSingle struct {
+	net.Conn
+}
+
+func (t *testExternalSingle) Label() string {
+	return "external"
+}
+
+type testExternalAccepter struct {
+	conns chan net.Conn
+	done  chan struct{}
+}
+
+func (t *testExternalAccepter) Accept() (string, io.ReadWriteCloser, error) {
+	select {
+	case conn := <-t.conns:
+		return "external-peer", conn, nil
+	case <-t.done:
+		return "", nil, fmt.Errorf("terminated")
+	}
+}
+
+func (t *testExternalAccepter) Close() error {
+	close(t.done)
+	return nil
+}
+
+type testExternalNone struct{}
+
+func (testExternalNone) Close() error {
+	return nil
+}
+
+type testExternalProvider struct {
+	kind EndpointKind
+	open func() (EndpointChannelProvider, error)
+}
+
+func (p *testExternalProvider) Kind() EndpointKind {
+	return p.kind
+}
+
+func (p *testExternalProvider) Open() (EndpointChannelProvider, error) {
+	return p.open()
+}
+
+func TestEndpointExternalSingle(t *testing.T) {
+	c1, c2 := net.Pipe()
+
+	node1, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 10,
+		Endpoints: []EndpointConf{EndpointExternal{&testExternalProvider{
+			kind: "lora",
+			open: func() (EndpointChannelProvider, error) {
+				return &testExternalSingle{c1}, nil
+			},
+		}}},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node1.Close()
+
+	node2, err := NewNode(NodeConf{
+		Dialect:          common.Dialect,
+		OutVersion:       V2,
+		OutSystemID:      11,
+		Endpoints:        []EndpointConf{EndpointCustom{c2}},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node2.Close()
+
+	evt := <-node1.Events()
+	ch := evt.(*EventChannelOpen).Channel
+	require.Equal(t, "external", ch.String())
+	require.Equal(t, EndpointKind("lora"), ch.EndpointKind())
+	<-node2.Events()
+
+	msg := &common.MessageParamValue{
+		ParamId:    "testparam",
+		ParamValue: 123,
+		ParamType:  common.MAV_PARAM_TYPE_UINT32,
+	}
+	node1.WriteMessageAll(msg)
+
+	evt = <-node2.Events()
+	fr, ok := evt.(*EventFrame)
+	require.Equal(t, true, ok)
+	require.Equal(t, msg, fr.Message())
+}
+
+func TestEndpointExternalAccepter(t *testing.T) {
+	acc := &testExternalAccepter{
+		conns: make(chan net.Conn),
+		done:  make(chan struct{}),
+	}
+
+	node1, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 10,
+		Endpoints: []EndpointConf{EndpointExternal{&testExternalProvider{
+			open: func() (EndpointChannelProvider, error) {
+				return acc, nil
+			},
+		}}},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node1.Close()
+
+	c1, c2 := net.Pipe()
+	acc.conns <- c1
+
+	node2, err := NewNode(NodeConf{
+		Dialect:          common.Dialect,
+		OutVersion:       V2,
+		OutSystemID:      11,
+		Endpoints:        []EndpointConf{EndpointCustom{c2}},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node2.Close()
+
+	evt := <-node1.Events()
+	ch := evt.(*EventChannelOpen).Channel
+	require.Equal(t, "external-peer", ch.String())
+	require.Equal(t, EndpointKindCustom, ch.EndpointKind())
+	<-node2.Events()
+
+	msg := &common.MessageParamValue{
+		ParamId:    "testparam",
+		ParamValue: 123,
+		ParamType:  common.MAV_PARAM_TYPE_UINT32,
+	}
+	node2.WriteMessageAll(msg)
+
+	evt = <-node1.Events()
+	fr, ok := evt.(*EventFrame)
+	require.Equal(t, true, ok)
+	require.Equal(t, msg, fr.Message())
+}
+
+func TestEndpointExternalErrors(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		conf EndpointExternal
+		err  string
+	}{
+		{
+			"no provider",
+			EndpointExternal{},
+			"Provider not provided",
+		},
+		{
+			"open error",
+			EndpointExternal{&testExternalProvider{
+				open: func() (EndpointChannelProvider, error) {
+					return nil, fmt.Errorf("unavailable")
+				},
+			}},
+			"unavailable",
+		},
+		{
+			"no interface",
+			EndpointExternal{&testExternalProvider{
+				open: func() (EndpointChannelProvider, error) {
+					return testExternalNone{}, nil
+				},
+			}},
+			"endpoint gomavlib.testExternalNone does not implement any interface",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			_, err := NewNode(NodeConf{
+				OutVersion:  V2,
+				OutSystemID: 10,
+				Endpoints:   []EndpointConf{ca.conf},
+			})
+			require.EqualError(t, err, ca.err)
+		})
+	}
+}
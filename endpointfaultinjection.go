@@ -0,0 +1,105 @@
+package gomavlib
+
+import (
+	"fmt"
+	"time"
+)
+
+// Faults are the faults injected into a direction of the channels of an
+// EndpointFaultInjection. Probabilities are between 0 and 1.
+type Faults struct {
+	// (optional) the probability that a frame is discarded.
+	DropProbability float64
+
+	// (optional) the probability that a frame is delivered twice.
+	DuplicateProbability float64
+
+	// (optional) the probability that a frame is delivered after the
+	// following one.
+	ReorderProbability float64
+
+	// (optional) the probability that a frame is delayed by a random amount
+	// of time, up to MaxDelay. Since other frames are not delayed, delayed
+	// frames are reordered too.
+	DelayProbability float64
+	// (optional) the maximum delay of delayed frames.
+	MaxDelay time.Duration
+
+	// (optional) the probability that a bit is flipped. Outgoing frames are
+	// corrupted after being encoded, while incoming data is corrupted before
+	// being decoded, therefore corrupted frames are usually discarded by the
+	// receiver because of their checksum.
+	CorruptProbability float64
+}
+
+func (f Faults) validate() error {
+	for _, p := range []float64{
+		f.DropProbability,
+		f.DuplicateProbability,
+		f.ReorderProbability,
+		f.DelayProbability,
+		f.CorruptProbability,
+	} {
+		if p < 0 || p > 1 {
+			return fmt.Errorf("probabilities must be between 0 and 1")
+		}
+	}
+
+	if f.DelayProbability > 0 && f.MaxDelay <= 0 {
+		return fmt.Errorf("DelayProbability requires MaxDelay")
+	}
+
+	return nil
+}
+
+func (f Faults) enabled() bool {
+	return f.DropProbability > 0 ||
+		f.DuplicateProbability > 0 ||
+		f.ReorderProbability > 0 ||
+		f.DelayProbability > 0 ||
+		f.CorruptProbability > 0
+}
+
+// EndpointFaultInjection wraps an endpoint and injects faults into the frames
+// that are received from and written to its channels, in order to test the
+// robustness of applications against bad links.
+//
+// Outgoing frames that are written together (Node.WriteBatch*) are handled
+// as a single unit. Incoming frames are dropped, duplicated, reordered and
+// delayed after being decoded, while corruption is applied to received data.
+type EndpointFaultInjection struct {
+	// the wrapped endpoint
+	Endpoint EndpointConf
+
+	// (optional) faults of incoming frames
+	In Faults
+
+	// (optional) faults of outgoing frames
+	Out Faults
+
+	// (optional) the seed of the random number generator, in order to
+	// reproduce a sequence of faults. If zero, a random seed is used.
+	Seed int64
+}
+
+func (conf EndpointFaultInjection) init() (Endpoint, error) {
+	err := conf.In.validate()
+	if err != nil {
+		return nil, fmt.Errorf("In: %s", err)
+	}
+
+	err = conf.Out.validate()
+	if err != nil {
+		return nil, fmt.Errorf("Out: %s", err)
+	}
+
+	return wrapEndpoint(conf, conf.Endpoint, func(opts *channelOptions) {
+		if conf.In.enabled() {
+			opts.inFaults = &conf.In
+		}
+		if conf.Out.enabled() {
+			opts.outFaults = &conf.Out
+		}
+		opts.faultSeed = conf.Seed
+	})
+}
@@ -0,0 +1,110 @@
+package gomavlib
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+)
+
+func TestEndpointFaultInjection(t *testing.T) {
+	l1, l2 := newTestPipe(), newTestPipe()
+
+	node1, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 10,
+		Endpoints: []EndpointConf{
+			EndpointFaultInjection{
+				Endpoint: EndpointCustom{ReadWriteCloser: &testEndpoint{l1, l2}},
+				In:       Faults{DuplicateProbability: 1},
+				Out:      Faults{DropProbability: 1},
+				Seed:     1,
+			},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node1.Close()
+
+	node2, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 1,
+		Endpoints: []EndpointConf{
+			EndpointCustom{ReadWriteCloser: &testEndpoint{l2, l1}},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node2.Close()
+
+	defer l1.Close()
+	defer l2.Close()
+
+	evt := <-node1.Events()
+	require.Equal(t, EndpointKindCustom, evt.(*EventChannelOpen).Channel.EndpointKind())
+	<-node2.Events()
+
+	// incoming frames are duplicated
+	m := &common.MessageParamValue{ParamId: "test", ParamValue: 100}
+	go node2.WriteMessageAll(m)
+
+	for i := 0; i < 2; i++ {
+		evt = <-node1.Events()
+		fr, ok := evt.(*EventFrame)
+		require.Equal(t, true, ok)
+		require.Equal(t, m, fr.Message())
+	}
+
+	// outgoing frames are dropped
+	node1.WriteMessageAll(m)
+
+	select {
+	case evt := <-node2.Events():
+		t.Errorf("unexpected event: %#v", evt)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestEndpointFaultInjectionErrors(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		conf EndpointFaultInjection
+		err  string
+	}{
+		{
+			"probability",
+			EndpointFaultInjection{
+				Endpoint: EndpointCustom{ReadWriteCloser: &testEndpoint{}},
+				In:       Faults{DropProbability: 2},
+			},
+			"In: probabilities must be between 0 and 1",
+		},
+		{
+			"max delay",
+			EndpointFaultInjection{
+				Endpoint: EndpointCustom{ReadWriteCloser: &testEndpoint{}},
+				Out:      Faults{DelayProbability: 0.5},
+			},
+			"Out: DelayProbability requires MaxDelay",
+		},
+		{
+			"endpoint",
+			EndpointFaultInjection{},
+			"wrapped endpoint not provided",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			_, err := NewNode(NodeConf{
+				Dialect:     common.Dialect,
+				OutVersion:  V2,
+				OutSystemID: 10,
+				Endpoints:   []EndpointConf{ca.conf},
+			})
+			require.EqualError(t, err, ca.err)
+		})
+	}
+}
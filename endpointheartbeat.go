@@ -0,0 +1,15 @@
+package gomavlib
+
+// EndpointHeartbeatDisable wraps an endpoint and disables the periodic
+// sending of heartbeats to its channels, while keeping it on the channels of
+// the other endpoints. See NodeConf.HeartbeatDisable.
+type EndpointHeartbeatDisable struct {
+	// the wrapped endpoint
+	Endpoint EndpointConf
+}
+
+func (conf EndpointHeartbeatDisable) init() (Endpoint, error) {
+	return wrapEndpoint(conf, conf.Endpoint, func(opts *channelOptions) {
+		opts.heartbeatDisable = true
+	})
+}
@@ -0,0 +1,16 @@
+package gomavlib
+
+// EndpointHighLatency wraps an endpoint and treats its channels as high
+// latency links, like satellite links: outgoing messages are suppressed,
+// except heartbeats, command acknowledgements and the HIGH_LATENCY2 messages
+// produced by the node. See NodeConf.HighLatencyProvider.
+type EndpointHighLatency struct {
+	// the wrapped endpoint
+	Endpoint EndpointConf
+}
+
+func (conf EndpointHighLatency) init() (Endpoint, error) {
+	return wrapEndpoint(conf, conf.Endpoint, func(opts *channelOptions) {
+		opts.highLatency = true
+	})
+}
@@ -0,0 +1,225 @@
+package gomavlib
+
+import (
+	"fmt"
+
+	"github.com/aler9/gomavlib/pkg/frame"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+// IDTranslation is an entry of the table of an EndpointIDTranslation.
+type IDTranslation struct {
+	// the system id used on the link
+	RemoteSystemID byte
+	// the system id used by the node and by the other endpoints
+	LocalSystemID byte
+
+	// (optional) the component id used on the link. If zero, all the
+	// components of the system are translated and keep their component id.
+	RemoteComponentID byte
+	// (optional) the component id used by the node and by the other
+	// endpoints. It must be provided together with RemoteComponentID.
+	LocalComponentID byte
+}
+
+// EndpointIDTranslation wraps an endpoint and translates the system ids and
+// component ids used on its channels (MAVLink NAT), in order to solve
+// conflicts between remote systems that use the same ids, like two vehicles
+// that use system id 1 and are connected to the same router.
+//
+// Ids of incoming frames are translated from remote to local, ids of
+// outgoing frames from local to remote. The target of messages
+// (TargetSystem and TargetComponent fields) is translated too.
+// Only frames whose message is in the dialect are translated, since their
+// checksum must be computed again. Translated incoming frames lose their
+// signature, since it does not cover the new ids; in the same way, the
+// signature of routed frames (Node.WriteFrame*) is not valid anymore after
+// the translation, unless NodeConf.OutResign is enabled.
+type EndpointIDTranslation struct {
+	// the wrapped endpoint
+	Endpoint EndpointConf
+
+	// the translation table
+	Table []IDTranslation
+}
+
+func (conf EndpointIDTranslation) init() (Endpoint, error) {
+	remote := make(map[systemKey]struct{})
+	local := make(map[systemKey]struct{})
+
+	for i, e := range conf.Table {
+		if e.RemoteSystemID == 0 || e.LocalSystemID == 0 {
+			return nil, fmt.Errorf("translation %d: system ids must be >= 1", i)
+		}
+		if (e.RemoteComponentID == 0) != (e.LocalComponentID == 0) {
+			return nil, fmt.Errorf("translation %d: both component ids must be provided", i)
+		}
+
+		rk := systemKey{SystemID: e.RemoteSystemID, ComponentID: e.RemoteComponentID}
+		if _, ok := remote[rk]; ok {
+			return nil, fmt.Errorf("translation %d: duplicate remote ids", i)
+		}
+		remote[rk] = struct{}{}
+
+		lk := systemKey{SystemID: e.LocalSystemID, ComponentID: e.LocalComponentID}
+		if _, ok := local[lk]; ok {
+			return nil, fmt.Errorf("translation %d: duplicate local ids", i)
+		}
+		local[lk] = struct{}{}
+	}
+
+	return wrapEndpoint(conf, conf.Endpoint, func(opts *channelOptions) {
+		opts.idTranslations = append(append([]IDTranslation(nil), opts.idTranslations...), conf.Table...)
+	})
+}
+
+// translateID translates a system id and a component id. Entries with a
+// component id have priority over entries without.
+func translateID(table []IDTranslation, out bool, systemID byte, componentID byte) (byte, byte) {
+	var match *IDTranslation
+
+	for i := range table {
+		e := &table[i]
+
+		fromSystem, fromComponent := e.RemoteSystemID, e.RemoteComponentID
+		if out {
+			fromSystem, fromComponent = e.LocalSystemID, e.LocalComponentID
+		}
+
+		if fromSystem != systemID {
+			continue
+		}
+
+		if fromComponent == componentID {
+			match = e
+			break
+		}
+
+		if fromComponent == 0 && match == nil {
+			match = e
+		}
+	}
+
+	if match == nil {
+		return systemID, componentID
+	}
+
+	toSystem, toComponent := match.LocalSystemID, match.LocalComponentID
+	if out {
+		toSystem, toComponent = match.RemoteSystemID, match.RemoteComponentID
+	}
+	if toComponent == 0 {
+		toComponent = componentID
+	}
+	return toSystem, toComponent
+}
+
+// translateTarget translates the target of a message. It returns nil if the
+// message does not have a target or if the target is not translated.
+func translateTarget(table []IDTranslation, out bool, m msg.Message) msg.Message {
+	if !messageGet(m, "TargetSystem").IsValid() {
+		return nil
+	}
+
+	systemID := byte(messageGetInt(m, "TargetSystem"))
+	var componentID byte
+	hasComponent := messageGet(m, "TargetComponent").IsValid()
+	if hasComponent {
+		componentID = byte(messageGetInt(m, "TargetComponent"))
+	}
+
+	newSystemID, newComponentID := translateID(table, out, systemID, componentID)
+	if newSystemID == systemID && newComponentID == componentID {
+		return nil
+	}
+
+	m = messageCopy(m)
+	messageSet(m, "TargetSystem", newSystemID)
+	if hasComponent {
+		messageSet(m, "TargetComponent", newComponentID)
+	}
+	return m
+}
+
+func frameSetIDs(fr frame.Frame, systemID byte, componentID byte, m msg.Message) {
+	switch ff := fr.(type) {
+	case *frame.V1Frame:
+		ff.SystemID = systemID
+		ff.ComponentID = componentID
+		ff.Message = m
+
+	case *frame.V2Frame:
+		ff.SystemID = systemID
+		ff.ComponentID = componentID
+		ff.Message = m
+	}
+}
+
+// translateIn translates the ids of an incoming frame. Since the frame has
+// just been decoded, it is modified in place.
+func (ch *Channel) translateIn(fr frame.Frame) {
+	m := fr.GetMessage()
+	if _, ok := m.(*msg.MessageRaw); ok {
+		return
+	}
+
+	systemID, componentID := translateID(ch.translation, false, fr.GetSystemID(), fr.GetComponentID())
+
+	newMsg := translateTarget(ch.translation, false, m)
+	if newMsg == nil {
+		if systemID == fr.GetSystemID() && componentID == fr.GetComponentID() {
+			return
+		}
+		newMsg = m
+	}
+
+	frameSetIDs(fr, systemID, componentID, newMsg)
+
+	if ff, ok := fr.(*frame.V2Frame); ok && ff.IsSigned() {
+		ff.IncompatibilityFlag &^= frame.V2FlagSigned
+		ff.SignatureLinkID = 0
+		ff.SignatureTimestamp = 0
+		ff.Signature = nil
+	}
+
+	// compute the checksum of the translated frame
+	mp := ch.n.dialectDE.MessageDEs[newMsg.GetID()]
+	_, isV2 := fr.(*frame.V2Frame)
+	byt, err := mp.Encode(newMsg, isV2)
+	if err != nil {
+		return
+	}
+
+	tmp := fr.Clone()
+	frameSetIDs(tmp, systemID, componentID, &msg.MessageRaw{ID: newMsg.GetID(), Content: byt})
+
+	switch ff := fr.(type) {
+	case *frame.V1Frame:
+		ff.Checksum = tmp.(*frame.V1Frame).GenChecksum(mp.CRCExtra())
+	case *frame.V2Frame:
+		ff.Checksum = tmp.(*frame.V2Frame).GenChecksum(mp.CRCExtra())
+	}
+}
+
+// translateOut translates the ids of an outgoing frame. The checksum is
+// computed by the transceiver.
+func (ch *Channel) translateOut(fr frame.Frame) frame.Frame {
+	m := fr.GetMessage()
+	if _, ok := m.(*msg.MessageRaw); ok {
+		return fr
+	}
+
+	systemID, componentID := translateID(ch.translation, true, fr.GetSystemID(), fr.GetComponentID())
+
+	newMsg := translateTarget(ch.translation, true, m)
+	if newMsg == nil {
+		if systemID == fr.GetSystemID() && componentID == fr.GetComponentID() {
+			return fr
+		}
+		newMsg = m
+	}
+
+	fr = fr.Clone()
+	frameSetIDs(fr, systemID, componentID, newMsg)
+	return fr
+}
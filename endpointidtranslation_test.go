@@ -0,0 +1,153 @@
+package gomavlib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+)
+
+func TestEndpointIDTranslation(t *testing.T) {
+	l1, l2 := newTestPipe(), newTestPipe()
+
+	node1, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 10,
+		Endpoints: []EndpointConf{
+			EndpointIDTranslation{
+				Endpoint: EndpointCustom{ReadWriteCloser: &testEndpoint{l1, l2}},
+				Table: []IDTranslation{{
+					RemoteSystemID: 1,
+					LocalSystemID:  21,
+				}},
+			},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node1.Close()
+
+	node2, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 1,
+		Endpoints: []EndpointConf{
+			EndpointCustom{ReadWriteCloser: &testEndpoint{l2, l1}},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node2.Close()
+
+	defer l1.Close()
+	defer l2.Close()
+
+	evt := <-node1.Events()
+	require.Equal(t, EndpointKindCustom, evt.(*EventChannelOpen).Channel.EndpointKind())
+	<-node2.Events()
+
+	m1 := &common.MessageParamValue{ParamId: "test", ParamValue: 100}
+	go node2.WriteMessageAll(m1)
+
+	evt = <-node1.Events()
+	fr, ok := evt.(*EventFrame)
+	require.Equal(t, true, ok)
+	require.Equal(t, byte(21), fr.SystemID())
+	require.Equal(t, byte(1), fr.ComponentID())
+	require.Equal(t, m1, fr.Message())
+
+	m2 := &common.MessageCommandLong{
+		TargetSystem:    21,
+		TargetComponent: 1,
+		Command:         common.MAV_CMD_COMPONENT_ARM_DISARM,
+	}
+	go node1.WriteMessageAll(m2)
+
+	evt = <-node2.Events()
+	fr, ok = evt.(*EventFrame)
+	require.Equal(t, true, ok)
+	require.Equal(t, byte(10), fr.SystemID())
+	require.Equal(t, &common.MessageCommandLong{
+		TargetSystem:    1,
+		TargetComponent: 1,
+		Command:         common.MAV_CMD_COMPONENT_ARM_DISARM,
+	}, fr.Message())
+
+	// the original message is not modified
+	require.Equal(t, uint8(21), m2.TargetSystem)
+}
+
+func TestTranslateID(t *testing.T) {
+	table := []IDTranslation{
+		{RemoteSystemID: 1, LocalSystemID: 21},
+		{RemoteSystemID: 1, LocalSystemID: 22, RemoteComponentID: 100, LocalComponentID: 1},
+	}
+
+	for _, ca := range []struct {
+		name     string
+		out      bool
+		in       [2]byte
+		expected [2]byte
+	}{
+		{"system in", false, [2]byte{1, 5}, [2]byte{21, 5}},
+		{"component in", false, [2]byte{1, 100}, [2]byte{22, 1}},
+		{"unknown in", false, [2]byte{2, 1}, [2]byte{2, 1}},
+		{"system out", true, [2]byte{21, 5}, [2]byte{1, 5}},
+		{"component out", true, [2]byte{22, 1}, [2]byte{1, 100}},
+		{"unknown out", true, [2]byte{22, 2}, [2]byte{22, 2}},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			systemID, componentID := translateID(table, ca.out, ca.in[0], ca.in[1])
+			require.Equal(t, ca.expected, [2]byte{systemID, componentID})
+		})
+	}
+}
+
+func TestEndpointIDTranslationErrors(t *testing.T) {
+	for _, ca := range []struct {
+		name  string
+		table []IDTranslation
+		err   string
+	}{
+		{
+			"system id",
+			[]IDTranslation{{RemoteSystemID: 1}},
+			"translation 0: system ids must be >= 1",
+		},
+		{
+			"component id",
+			[]IDTranslation{{RemoteSystemID: 1, LocalSystemID: 2, RemoteComponentID: 1}},
+			"translation 0: both component ids must be provided",
+		},
+		{
+			"duplicate remote",
+			[]IDTranslation{
+				{RemoteSystemID: 1, LocalSystemID: 2},
+				{RemoteSystemID: 1, LocalSystemID: 3},
+			},
+			"translation 1: duplicate remote ids",
+		},
+		{
+			"duplicate local",
+			[]IDTranslation{
+				{RemoteSystemID: 1, LocalSystemID: 2},
+				{RemoteSystemID: 3, LocalSystemID: 2},
+			},
+			"translation 1: duplicate local ids",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			_, err := NewNode(NodeConf{
+				OutVersion:  V2,
+				OutSystemID: 10,
+				Endpoints: []EndpointConf{EndpointIDTranslation{
+					Endpoint: EndpointCustom{ReadWriteCloser: &testEndpoint{newTestPipe(), newTestPipe()}},
+					Table:    ca.table,
+				}},
+			})
+			require.EqualError(t, err, ca.err)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package gomavlib
+
+import (
+	"fmt"
+
+	"github.com/aler9/gomavlib/pkg/frame"
+)
+
+// EndpointOutTransform wraps an endpoint and transforms the frames written
+// to its channels just before they are encoded, in order to adjust them for
+// specific links, for instance by zeroing sensitive fields or by remapping
+// component ids. Transforms are applied after NodeConf.FrameInterceptors.
+// When wrappers are nested, the transform of the inner wrapper is applied
+// first.
+type EndpointOutTransform struct {
+	// the wrapped endpoint
+	Endpoint EndpointConf
+
+	// the function that is called with every frame written to a channel of
+	// the endpoint. It returns the frame to write, that is the same frame or
+	// a modified copy, or nil in order to discard the frame. The same rules
+	// of FrameInterceptor apply.
+	Transform func(ch *Channel, fr frame.Frame) frame.Frame
+}
+
+func (conf EndpointOutTransform) init() (Endpoint, error) {
+	if conf.Transform == nil {
+		return nil, fmt.Errorf("Transform not provided")
+	}
+
+	return wrapEndpoint(conf, conf.Endpoint, func(opts *channelOptions) {
+		transforms := make([]func(*Channel, frame.Frame) frame.Frame, 0, len(opts.outTransforms)+1)
+		transforms = append(transforms, opts.outTransforms...)
+		opts.outTransforms = append(transforms, conf.Transform)
+	})
+}
+
+// outTransform passes an outgoing frame through the transforms of the
+// endpoint.
+func (ch *Channel) outTransform(fr frame.Frame) frame.Frame {
+	for _, t := range ch.transforms {
+		fr = t(ch, fr)
+		if fr == nil {
+			return nil
+		}
+	}
+	return fr
+}
@@ -0,0 +1,98 @@
+package gomavlib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+	"github.com/aler9/gomavlib/pkg/frame"
+)
+
+func TestEndpointOutTransform(t *testing.T) {
+	la1, la2 := newTestPipe(), newTestPipe()
+	lb1, lb2 := newTestPipe(), newTestPipe()
+
+	node1, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 10,
+		Endpoints: []EndpointConf{
+			EndpointOutTransform{
+				Endpoint: EndpointOutTransform{
+					Endpoint: EndpointCustom{ReadWriteCloser: &testEndpoint{la1, la2}},
+					// zero the value of parameters
+					Transform: func(ch *Channel, fr frame.Frame) frame.Frame {
+						m, ok := fr.GetMessage().(*common.MessageParamValue)
+						if !ok {
+							return fr
+						}
+
+						cpy := *m
+						cpy.ParamValue = 0
+						fr = fr.Clone()
+						fr.(*frame.V2Frame).Message = &cpy
+						return fr
+					},
+				},
+				// remap the component id
+				Transform: func(ch *Channel, fr frame.Frame) frame.Frame {
+					fr = fr.Clone()
+					fr.(*frame.V2Frame).ComponentID = 5
+					return fr
+				},
+			},
+			EndpointCustom{ReadWriteCloser: &testEndpoint{lb1, lb2}},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node1.Close()
+
+	newReceiver := func(r *testPipe, w *testPipe) *Node {
+		node, err := NewNode(NodeConf{
+			Dialect:     common.Dialect,
+			OutVersion:  V2,
+			OutSystemID: 11,
+			Endpoints: []EndpointConf{
+				EndpointCustom{ReadWriteCloser: &testEndpoint{r, w}},
+			},
+			HeartbeatDisable: true,
+		})
+		require.NoError(t, err)
+		return node
+	}
+
+	node2 := newReceiver(la2, la1)
+	defer node2.Close()
+
+	node3 := newReceiver(lb2, lb1)
+	defer node3.Close()
+
+	defer la1.Close()
+	defer la2.Close()
+	defer lb1.Close()
+	defer lb2.Close()
+
+	for i := 0; i < 2; i++ {
+		evt := <-node1.Events()
+		require.Equal(t, EndpointKindCustom, evt.(*EventChannelOpen).Channel.EndpointKind())
+	}
+	<-node2.Events()
+	<-node3.Events()
+
+	m := &common.MessageParamValue{ParamId: "test", ParamValue: 100}
+	node1.WriteMessageAll(m)
+
+	evt := <-node2.Events()
+	fr, ok := evt.(*EventFrame)
+	require.Equal(t, true, ok)
+	require.Equal(t, byte(5), fr.ComponentID())
+	require.Equal(t, &common.MessageParamValue{ParamId: "test", ParamValue: 0}, fr.Message())
+
+	evt = <-node3.Events()
+	fr, ok = evt.(*EventFrame)
+	require.Equal(t, true, ok)
+	require.Equal(t, byte(1), fr.ComponentID())
+	require.Equal(t, m, fr.Message())
+}
@@ -0,0 +1,24 @@
+package gomavlib
+
+// EndpointRateLimit wraps an endpoint and limits the outgoing traffic of each
+// of its channels. Frames exceeding the limit are discarded before being
+// encoded, therefore they do not consume sequence numbers.
+type EndpointRateLimit struct {
+	// the wrapped endpoint
+	Endpoint EndpointConf
+
+	// (optional) the maximum number of bytes per second that can be written
+	// to each channel.
+	OutBytesPerSecond int
+
+	// (optional) the maximum number of frames per second that can be written
+	// to each channel.
+	OutFramesPerSecond int
+}
+
+func (conf EndpointRateLimit) init() (Endpoint, error) {
+	return wrapEndpoint(conf, conf.Endpoint, func(opts *channelOptions) {
+		opts.outBytesPerSecond = conf.OutBytesPerSecond
+		opts.outFramesPerSecond = conf.OutFramesPerSecond
+	})
+}
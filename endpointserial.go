@@ -5,16 +5,15 @@ import (
 	"io"
 	"regexp"
 	"strconv"
-
-	"github.com/tarm/serial"
 )
 
 var reSerial = regexp.MustCompile("^(.+?):([0-9]+)$")
 
 // EndpointSerial sets up a endpoint that works with a serial port.
+// It is supported on Linux and Windows without cgo; other systems need cgo.
 type EndpointSerial struct {
 	// the address of the serial port in format name:baudrate
-	// example: /dev/ttyUSB0:57600
+	// example: /dev/ttyUSB0:57600 (Linux), COM3:57600 (Windows)
 	Address string
 }
 
@@ -32,10 +31,7 @@ func (conf EndpointSerial) init() (Endpoint, error) {
 	name := matches[1]
 	baud, _ := strconv.Atoi(matches[2])
 
-	rwc, err := serial.OpenPort(&serial.Config{
-		Name: name,
-		Baud: baud,
-	})
+	rwc, err := serialOpen(name, baud)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,13 @@
+//go:build js
+// +build js
+
+package gomavlib
+
+import (
+	"fmt"
+	"io"
+)
+
+func serialOpen(name string, baud int) (io.ReadWriteCloser, error) {
+	return nil, fmt.Errorf("serial ports are not supported by this platform")
+}
@@ -0,0 +1,17 @@
+//go:build !js
+// +build !js
+
+package gomavlib
+
+import (
+	"io"
+
+	"github.com/tarm/serial"
+)
+
+func serialOpen(name string, baud int) (io.ReadWriteCloser, error) {
+	return serial.OpenPort(&serial.Config{
+		Name: name,
+		Baud: baud,
+	})
+}
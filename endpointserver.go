@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"sync"
+	"time"
 
 	"github.com/aler9/gomavlib/pkg/udplistener"
 )
@@ -11,7 +13,10 @@ import (
 type endpointServerConf interface {
 	isUDP() bool
 	getAddress() string
-	init() (Endpoint, error)
+	getIdleTimeout() time.Duration
+	getMaxConnections() int
+	getAcceptFilter() func(net.Addr) bool
+	EndpointConf
 }
 
 // EndpointTCPServer sets up a endpoint that works with a TCP server.
@@ -21,6 +26,15 @@ type endpointServerConf interface {
 type EndpointTCPServer struct {
 	// listen address, example: 0.0.0.0:5600
 	Address string
+
+	// (optional) the maximum number of concurrent connections. Connections
+	// received when the limit is reached are closed immediately.
+	// It defaults to unlimited.
+	MaxConnections int
+
+	// (optional) a function that is called with the remote address of every
+	// incoming connection, and returns whether the connection is accepted.
+	AcceptFilter func(net.Addr) bool
 }
 
 func (EndpointTCPServer) isUDP() bool {
@@ -31,12 +45,29 @@ func (conf EndpointTCPServer) getAddress() string {
 	return conf.Address
 }
 
+func (EndpointTCPServer) getIdleTimeout() time.Duration {
+	return 0
+}
+
+func (conf EndpointTCPServer) getMaxConnections() int {
+	return conf.MaxConnections
+}
+
+func (conf EndpointTCPServer) getAcceptFilter() func(net.Addr) bool {
+	return conf.AcceptFilter
+}
+
 // EndpointUDPServer sets up a endpoint that works with an UDP server.
 // This is the most appropriate way for transferring frames from a UAV to a GCS
 // if they are connected to the same network.
 type EndpointUDPServer struct {
 	// listen address, example: 0.0.0.0:5600
 	Address string
+
+	// (optional) the time after which the channel of a remote address that
+	// is not sending data anymore is closed, emitting an EventChannelClose.
+	// It defaults to 60 seconds.
+	IdleTimeout time.Duration
 }
 
 func (EndpointUDPServer) isUDP() bool {
@@ -47,10 +78,25 @@ func (conf EndpointUDPServer) getAddress() string {
 	return conf.Address
 }
 
+func (conf EndpointUDPServer) getIdleTimeout() time.Duration {
+	return conf.IdleTimeout
+}
+
+func (EndpointUDPServer) getMaxConnections() int {
+	return 0
+}
+
+func (EndpointUDPServer) getAcceptFilter() func(net.Addr) bool {
+	return nil
+}
+
 type endpointServer struct {
 	conf     endpointServerConf
 	listener net.Listener
 
+	connsMutex sync.Mutex
+	conns      int
+
 	// in
 	terminate chan struct{}
 }
@@ -99,12 +145,48 @@ func (t *endpointServer) Close() error {
 	return nil
 }
 
+// allow checks whether a connection can be accepted and, in case, counts it.
+func (t *endpointServer) allow(addr net.Addr) bool {
+	filter := t.conf.getAcceptFilter()
+	if filter != nil && !filter(addr) {
+		return false
+	}
+
+	t.connsMutex.Lock()
+	defer t.connsMutex.Unlock()
+
+	maxConns := t.conf.getMaxConnections()
+	if maxConns > 0 && t.conns >= maxConns {
+		return false
+	}
+
+	t.conns++
+	return true
+}
+
+func (t *endpointServer) onConnClose() {
+	t.connsMutex.Lock()
+	defer t.connsMutex.Unlock()
+	t.conns--
+}
+
 func (t *endpointServer) Accept() (string, io.ReadWriteCloser, error) {
-	rawConn, err := t.listener.Accept()
-	// wait termination, do not report errors
-	if err != nil {
-		<-t.terminate
-		return "", nil, errorTerminated
+	var rawConn net.Conn
+
+	for {
+		var err error
+		rawConn, err = t.listener.Accept()
+		// wait termination, do not report errors
+		if err != nil {
+			<-t.terminate
+			return "", nil, errorTerminated
+		}
+
+		if t.allow(rawConn.RemoteAddr()) {
+			break
+		}
+
+		rawConn.Close()
 	}
 
 	label := fmt.Sprintf("%s:%s", func() string {
@@ -114,7 +196,27 @@ func (t *endpointServer) Accept() (string, io.ReadWriteCloser, error) {
 		return "tcp"
 	}(), rawConn.RemoteAddr())
 
-	conn := &netTimedConn{rawConn}
+	conn := &endpointServerConn{
+		netTimedConn: netTimedConn{
+			conn:        rawConn,
+			readTimeout: t.conf.getIdleTimeout(),
+		},
+		t: t,
+	}
 
 	return label, conn, nil
 }
+
+// endpointServerConn is a connection accepted by a server, that is
+// uncounted when closed.
+type endpointServerConn struct {
+	netTimedConn
+	t         *endpointServer
+	closeOnce sync.Once
+}
+
+func (c *endpointServerConn) Close() error {
+	err := c.netTimedConn.Close()
+	c.closeOnce.Do(c.t.onConnClose)
+	return err
+}
@@ -0,0 +1,194 @@
+package gomavlib
+
+import (
+	"fmt"
+	"sync"
+)
+
+// EndpointShareConf allows to configure an EndpointShare.
+type EndpointShareConf struct {
+	// the endpoint to share. It must provide a single channel, like
+	// EndpointSerial or EndpointUDPClient.
+	Endpoint EndpointConf
+
+	// (optional) the number of reads that are buffered for each node.
+	// When the buffer of a node is full, incoming data of that node is
+	// discarded, in order not to block the other nodes.
+	// It defaults to 256.
+	ReadQueueSize int
+}
+
+// EndpointShare is an endpoint that is shared between multiple nodes, like a
+// serial port that is used by a vehicle emulator and by a logger.
+// Incoming data is delivered to all the nodes that use the endpoint, while
+// outgoing frames of the nodes are written to the endpoint without being
+// interleaved. Nodes do not receive frames written by other nodes.
+//
+// Each node uses the endpoint by adding EndpointShared{Share} to its endpoints.
+type EndpointShare struct {
+	conf  EndpointShareConf
+	e     endpointChannelSingle
+	opts  channelOptions
+	mutex sync.Mutex
+	users map[*endpointShared]struct{}
+
+	writeMutex sync.Mutex
+
+	// out
+	done chan struct{}
+}
+
+// NewEndpointShare opens an endpoint that can be shared between multiple
+// nodes. See EndpointShareConf for the options.
+func NewEndpointShare(conf EndpointShareConf) (*EndpointShare, error) {
+	if conf.Endpoint == nil {
+		return nil, fmt.Errorf("Endpoint not provided")
+	}
+
+	if conf.ReadQueueSize == 0 {
+		conf.ReadQueueSize = 256
+	}
+
+	e, err := conf.Endpoint.init()
+	if err != nil {
+		return nil, err
+	}
+
+	te, ok := e.(endpointChannelSingle)
+	if !ok {
+		if ca, ok := e.(endpointChannelAccepter); ok {
+			ca.Close()
+		}
+		return nil, fmt.Errorf("endpoint %T does not provide a single channel", e)
+	}
+
+	s := &EndpointShare{
+		conf:  conf,
+		e:     te,
+		opts:  endpointChannelOptions(e),
+		users: make(map[*endpointShared]struct{}),
+		done:  make(chan struct{}),
+	}
+
+	go s.run()
+
+	return s, nil
+}
+
+// Close closes the endpoint. Nodes that use the endpoint stop receiving data,
+// but must be closed separately.
+func (s *EndpointShare) Close() {
+	s.e.Close()
+	<-s.done
+}
+
+func (s *EndpointShare) run() {
+	defer close(s.done)
+
+	buf := make([]byte, bufferSize)
+
+	for {
+		n, err := s.e.Read(buf)
+		if err != nil {
+			return
+		}
+
+		// the same copy is shared between nodes, that only read it
+		cpy := make([]byte, n)
+		copy(cpy, buf[:n])
+
+		s.mutex.Lock()
+		for u := range s.users {
+			select {
+			case u.read <- cpy:
+			default:
+			}
+		}
+		s.mutex.Unlock()
+	}
+}
+
+func (s *EndpointShare) write(buf []byte) (int, error) {
+	s.writeMutex.Lock()
+	defer s.writeMutex.Unlock()
+	return s.e.Write(buf)
+}
+
+func (s *EndpointShare) removeUser(u *endpointShared) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.users, u)
+}
+
+// EndpointShared sets up an endpoint that uses an EndpointShare.
+type EndpointShared struct {
+	// the shared endpoint
+	Share *EndpointShare
+}
+
+type endpointShared struct {
+	conf      EndpointShared
+	read      chan []byte
+	buf       []byte
+	closeOnce sync.Once
+
+	// in
+	terminate chan struct{}
+}
+
+func (conf EndpointShared) init() (Endpoint, error) {
+	if conf.Share == nil {
+		return nil, fmt.Errorf("Share not provided")
+	}
+
+	s := conf.Share
+
+	t := &endpointShared{
+		conf:      conf,
+		read:      make(chan []byte, s.conf.ReadQueueSize),
+		terminate: make(chan struct{}),
+	}
+
+	s.mutex.Lock()
+	s.users[t] = struct{}{}
+	s.mutex.Unlock()
+
+	// channel options of the shared endpoint are applied to every node
+	return &endpointWrapperSingle{t, conf, s.opts}, nil
+}
+
+func (t *endpointShared) isEndpoint() {}
+
+func (t *endpointShared) Conf() EndpointConf {
+	return t.conf
+}
+
+func (t *endpointShared) Label() string {
+	return t.conf.Share.e.Label()
+}
+
+func (t *endpointShared) Close() error {
+	t.closeOnce.Do(func() {
+		t.conf.Share.removeUser(t)
+		close(t.terminate)
+	})
+	return nil
+}
+
+func (t *endpointShared) Read(buf []byte) (int, error) {
+	if len(t.buf) == 0 {
+		select {
+		case t.buf = <-t.read:
+		case <-t.terminate:
+			return 0, errorTerminated
+		}
+	}
+
+	n := copy(buf, t.buf)
+	t.buf = t.buf[n:]
+	return n, nil
+}
+
+func (t *endpointShared) Write(buf []byte) (int, error) {
+	return t.conf.Share.write(buf)
+}
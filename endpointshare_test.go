@@ -0,0 +1,96 @@
+package gomavlib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+)
+
+func TestEndpointShare(t *testing.T) {
+	l1, l2 := newTestPipe(), newTestPipe()
+
+	share, err := NewEndpointShare(EndpointShareConf{
+		Endpoint: EndpointCustom{ReadWriteCloser: &testEndpoint{l1, l2}},
+	})
+	require.NoError(t, err)
+	defer share.Close()
+
+	newNode := func(systemID byte, e EndpointConf) *Node {
+		node, err := NewNode(NodeConf{
+			Dialect:          common.Dialect,
+			OutVersion:       V2,
+			OutSystemID:      systemID,
+			Endpoints:        []EndpointConf{e},
+			HeartbeatDisable: true,
+		})
+		require.NoError(t, err)
+		return node
+	}
+
+	node1 := newNode(10, EndpointShared{share})
+	defer node1.Close()
+
+	node2 := newNode(11, EndpointShared{share})
+	defer node2.Close()
+
+	remote := newNode(1, EndpointCustom{ReadWriteCloser: &testEndpoint{l2, l1}})
+	defer remote.Close()
+
+	defer l1.Close()
+	defer l2.Close()
+
+	for _, node := range []*Node{node1, node2} {
+		evt := <-node.Events()
+		ch := evt.(*EventChannelOpen).Channel
+		require.Equal(t, "custom", ch.String())
+		require.Equal(t, EndpointKindCustom, ch.EndpointKind())
+	}
+	<-remote.Events()
+
+	// incoming frames are delivered to all nodes
+	m := &common.MessageParamValue{ParamId: "test", ParamValue: 100}
+	go remote.WriteMessageAll(m)
+
+	for _, node := range []*Node{node1, node2} {
+		evt := <-node.Events()
+		fr, ok := evt.(*EventFrame)
+		require.Equal(t, true, ok)
+		require.Equal(t, byte(1), fr.SystemID())
+		require.Equal(t, m, fr.Message())
+	}
+
+	// outgoing frames of all nodes are written to the endpoint
+	go node1.WriteMessageAll(m)
+	go node2.WriteMessageAll(m)
+
+	systemIDs := make(map[byte]struct{})
+	for len(systemIDs) < 2 {
+		evt := <-remote.Events()
+		fr, ok := evt.(*EventFrame)
+		if !ok {
+			continue
+		}
+		require.Equal(t, m, fr.Message())
+		systemIDs[fr.SystemID()] = struct{}{}
+	}
+	require.Equal(t, map[byte]struct{}{10: {}, 11: {}}, systemIDs)
+}
+
+func TestEndpointShareErrors(t *testing.T) {
+	_, err := NewEndpointShare(EndpointShareConf{})
+	require.EqualError(t, err, "Endpoint not provided")
+
+	_, err = NewEndpointShare(EndpointShareConf{
+		Endpoint: EndpointTCPServer{Address: "127.0.0.1:5601"},
+	})
+	require.EqualError(t, err, "endpoint *gomavlib.endpointServer does not provide a single channel")
+
+	_, err = NewNode(NodeConf{
+		OutVersion:  V2,
+		OutSystemID: 10,
+		Endpoints:   []EndpointConf{EndpointShared{}},
+	})
+	require.EqualError(t, err, "Share not provided")
+}
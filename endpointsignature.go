@@ -0,0 +1,32 @@
+package gomavlib
+
+import (
+	"github.com/aler9/gomavlib/pkg/frame"
+)
+
+// EndpointSignature wraps an endpoint and overrides the keys used to
+// validate and sign the frames of its channels (NodeConf.InKey and
+// NodeConf.OutKey), in order to communicate with links that use different
+// keys. A nil key disables validation or signing on the endpoint.
+// Keys are used only on channels that use V2 (NodeConf.OutVersion or
+// EndpointVersion).
+// Routed frames (Node.WriteFrame*) keep their original signature, unless
+// NodeConf.OutResign is enabled.
+type EndpointSignature struct {
+	// the wrapped endpoint
+	Endpoint EndpointConf
+
+	// (optional) the secret key used to validate incoming frames.
+	InKey *frame.V2Key
+
+	// (optional) the secret key used to sign outgoing frames.
+	OutKey *frame.V2Key
+}
+
+func (conf EndpointSignature) init() (Endpoint, error) {
+	return wrapEndpoint(conf, conf.Endpoint, func(opts *channelOptions) {
+		opts.signature = true
+		opts.inKey = conf.InKey
+		opts.outKey = conf.OutKey
+	})
+}
@@ -0,0 +1,93 @@
+package gomavlib
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+	"github.com/aler9/gomavlib/pkg/frame"
+)
+
+func TestEndpointSignatureResign(t *testing.T) {
+	keyA := frame.NewV2Key(bytes.Repeat([]byte("\x4F"), 32))
+	keyB := frame.NewV2Key(bytes.Repeat([]byte("\x5A"), 32))
+
+	la1, la2 := newTestPipe(), newTestPipe()
+	lb1, lb2 := newTestPipe(), newTestPipe()
+	lc1, lc2 := newTestPipe(), newTestPipe()
+
+	newNode := func(systemID byte, key *frame.V2Key, endpoints ...EndpointConf) *Node {
+		node, err := NewNode(NodeConf{
+			Dialect:          common.Dialect,
+			OutVersion:       V2,
+			OutSystemID:      systemID,
+			InKey:            key,
+			OutKey:           key,
+			Endpoints:        endpoints,
+			HeartbeatDisable: true,
+			OutResign:        true,
+		})
+		require.NoError(t, err)
+		return node
+	}
+
+	// a router that uses a different key on each link
+	router := newNode(1, keyA,
+		EndpointCustom{ReadWriteCloser: &testEndpoint{la1, la2}},
+		EndpointSignature{
+			Endpoint: EndpointCustom{ReadWriteCloser: &testEndpoint{lb1, lb2}},
+			InKey:    keyB,
+			OutKey:   keyB,
+		},
+		EndpointSignature{
+			Endpoint: EndpointCustom{ReadWriteCloser: &testEndpoint{lc1, lc2}},
+		})
+	defer router.Close()
+
+	nodeA := newNode(10, keyA, EndpointCustom{ReadWriteCloser: &testEndpoint{la2, la1}})
+	defer nodeA.Close()
+
+	nodeB := newNode(11, keyB, EndpointCustom{ReadWriteCloser: &testEndpoint{lb2, lb1}})
+	defer nodeB.Close()
+
+	nodeC := newNode(12, nil, EndpointCustom{ReadWriteCloser: &testEndpoint{lc2, lc1}})
+	defer nodeC.Close()
+
+	defer la1.Close()
+	defer la2.Close()
+	defer lb1.Close()
+	defer lb2.Close()
+	defer lc1.Close()
+	defer lc2.Close()
+
+	for i := 0; i < 3; i++ {
+		<-router.Events()
+	}
+	<-nodeA.Events()
+	<-nodeB.Events()
+	<-nodeC.Events()
+
+	m := &common.MessageParamValue{ParamId: "test", ParamValue: 100}
+	nodeA.WriteMessageAll(m)
+
+	evt := <-router.Events()
+	fr, ok := evt.(*EventFrame)
+	require.Equal(t, true, ok)
+	router.WriteFrameExcept(fr.Channel, fr.Frame)
+
+	evt = <-nodeB.Events()
+	fr, ok = evt.(*EventFrame)
+	require.Equal(t, true, ok)
+	require.Equal(t, byte(10), fr.SystemID())
+	require.Equal(t, true, fr.Frame.(*frame.V2Frame).IsSigned())
+	require.Equal(t, m, fr.Message())
+
+	evt = <-nodeC.Events()
+	fr, ok = evt.(*EventFrame)
+	require.Equal(t, true, ok)
+	require.Equal(t, byte(10), fr.SystemID())
+	require.Equal(t, false, fr.Frame.(*frame.V2Frame).IsSigned())
+	require.Equal(t, m, fr.Message())
+}
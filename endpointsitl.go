@@ -0,0 +1,80 @@
+package gomavlib
+
+import (
+	"fmt"
+)
+
+// SITLFlavor is the flight stack of a SITL (software in the loop) instance.
+type SITLFlavor int
+
+// SITL flavors.
+const (
+	// Ardupilot SITL, that exposes its first serial port as a TCP server on
+	// port 5760 and emits telemetry streams only when requested.
+	SITLFlavorArdupilot SITLFlavor = iota
+
+	// PX4 SITL, that sends telemetry to ground stations on UDP port 14550.
+	SITLFlavorPX4
+)
+
+// EndpointSITL sets up an endpoint that connects to a SITL instance running
+// on the same machine, by following the conventions of its flight stack:
+//
+//	gomavlib.EndpointSITL{Flavor: gomavlib.SITLFlavorArdupilot}
+//
+// With Ardupilot, the endpoint is a TCP client connected to 127.0.0.1:5760
+// and streams are requested automatically to the vehicle, even when
+// NodeConf.StreamRequestEnable is false.
+// With PX4, the endpoint is a UDP server listening on :14550.
+type EndpointSITL struct {
+	// the flight stack of the SITL instance
+	Flavor SITLFlavor
+
+	// (optional) the address of the SITL instance (Ardupilot) or the address
+	// to listen on (PX4), that replaces the default one.
+	Address string
+
+	// (optional) the requested stream frequency in Hz (Ardupilot). It
+	// defaults to NodeConf.StreamRequestFrequency.
+	StreamFrequency int
+}
+
+// inner returns the configuration of the endpoint that is used to connect to
+// the SITL instance.
+func (conf EndpointSITL) inner() (EndpointConf, error) {
+	switch conf.Flavor {
+	case SITLFlavorArdupilot:
+		address := conf.Address
+		if address == "" {
+			address = "127.0.0.1:5760"
+		}
+		return EndpointTCPClient{Address: address}, nil
+
+	case SITLFlavorPX4:
+		address := conf.Address
+		if address == "" {
+			address = ":14550"
+		}
+		return EndpointUDPServer{Address: address}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported flavor (%d)", conf.Flavor)
+}
+
+func (conf EndpointSITL) init() (Endpoint, error) {
+	if conf.StreamFrequency < 0 {
+		return nil, fmt.Errorf("StreamFrequency must be >= 0")
+	}
+
+	inner, err := conf.inner()
+	if err != nil {
+		return nil, err
+	}
+
+	return wrapEndpoint(conf, inner, func(opts *channelOptions) {
+		if conf.Flavor == SITLFlavorArdupilot {
+			opts.streamRequest = true
+			opts.streamFrequency = conf.StreamFrequency
+		}
+	})
+}
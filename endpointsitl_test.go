@@ -0,0 +1,73 @@
+package gomavlib
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+)
+
+func TestEndpointSITLArdupilot(t *testing.T) {
+	// emulate an Ardupilot SITL instance
+	sitl, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 1,
+		Endpoints: []EndpointConf{
+			EndpointTCPServer{Address: "127.0.0.1:5762"},
+		},
+		HeartbeatPeriod:        100 * time.Millisecond,
+		HeartbeatAutopilotType: 3, // MAV_AUTOPILOT_ARDUPILOTMEGA
+	})
+	require.NoError(t, err)
+	defer sitl.Close()
+
+	// streams are requested even if StreamRequestEnable is false
+	node, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 255,
+		Endpoints: []EndpointConf{
+			EndpointSITL{
+				Flavor:          SITLFlavorArdupilot,
+				Address:         "127.0.0.1:5762",
+				StreamFrequency: 10,
+			},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node.Close()
+
+	go func() {
+		for range node.Events() {
+		}
+	}()
+
+	for evt := range sitl.Events() {
+		if ee, ok := evt.(*EventFrame); ok {
+			if m, ok := ee.Message().(*common.MessageRequestDataStream); ok {
+				require.Equal(t, uint16(10), m.ReqMessageRate)
+				require.Equal(t, uint8(1), m.StartStop)
+				return
+			}
+		}
+	}
+}
+
+func TestEndpointSITLErrors(t *testing.T) {
+	_, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 255,
+		Endpoints: []EndpointConf{
+			EndpointSITL{Flavor: 5},
+		},
+	})
+	require.EqualError(t, err, "unsupported flavor (5)")
+
+	require.Equal(t, EndpointKindTCPClient, endpointKind(EndpointSITL{Flavor: SITLFlavorArdupilot}))
+	require.Equal(t, EndpointKindUDPServer, endpointKind(EndpointSITL{Flavor: SITLFlavorPX4}))
+}
@@ -0,0 +1,38 @@
+package gomavlib
+
+import (
+	"fmt"
+)
+
+// EndpointVersion wraps an endpoint and overrides the Mavlink version used
+// to encode messages written to its channels (NodeConf.OutVersion), and
+// optionally the encoding of V2 frames.
+// Encoding options do not apply to routed frames (Node.WriteFrame*).
+// Frames are signed (NodeConf.OutKey) and signatures are required
+// (NodeConf.InKey) only on channels that use V2.
+type EndpointVersion struct {
+	// the wrapped endpoint
+	Endpoint EndpointConf
+
+	// Mavlink version used to encode messages.
+	OutVersion Version
+
+	// (optional) disables the empty-byte truncation of outgoing V2 frames.
+	OutTruncationDisable bool
+
+	// (optional) disables the encoding of extension fields of outgoing V2
+	// frames, in order to target receivers that do not know them.
+	OutExtensionsDisable bool
+}
+
+func (conf EndpointVersion) init() (Endpoint, error) {
+	if conf.OutVersion == 0 {
+		return nil, fmt.Errorf("OutVersion not provided")
+	}
+
+	return wrapEndpoint(conf, conf.Endpoint, func(opts *channelOptions) {
+		opts.outVersion = conf.OutVersion
+		opts.outTruncationDisable = conf.OutTruncationDisable
+		opts.outExtensionsDisable = conf.OutExtensionsDisable
+	})
+}
@@ -0,0 +1,42 @@
+package gomavlib
+
+import (
+	"io"
+)
+
+// EndpointWebSocket sets up a endpoint that works with a WebSocket client.
+// It is supported only when running in a browser (GOOS=js GOARCH=wasm), and
+// allows to communicate with a server that exchanges frames through binary
+// WebSocket messages.
+type EndpointWebSocket struct {
+	// the URL of the WebSocket server, example: ws://1.2.3.4:8080/mavlink
+	URL string
+}
+
+type endpointWebSocket struct {
+	conf EndpointWebSocket
+	io.ReadWriteCloser
+}
+
+func (conf EndpointWebSocket) init() (Endpoint, error) {
+	rwc, err := webSocketDial(conf.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &endpointWebSocket{
+		conf:            conf,
+		ReadWriteCloser: rwc,
+	}
+	return t, nil
+}
+
+func (t *endpointWebSocket) isEndpoint() {}
+
+func (t *endpointWebSocket) Conf() EndpointConf {
+	return t.conf
+}
+
+func (t *endpointWebSocket) Label() string {
+	return "websocket:" + t.conf.URL
+}
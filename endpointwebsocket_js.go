@@ -0,0 +1,141 @@
+//go:build js
+// +build js
+
+package gomavlib
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"syscall/js"
+)
+
+// webSocketConn is a io.ReadWriteCloser that uses the WebSocket API of the
+// browser. Browser callbacks must not block, therefore received messages are
+// queued and consumed by Read().
+type webSocketConn struct {
+	ws    js.Value
+	funcs []js.Func
+
+	mutex  sync.Mutex
+	cond   *sync.Cond
+	queue  [][]byte
+	buf    []byte
+	closed bool
+}
+
+func webSocketDial(url string) (io.ReadWriteCloser, error) {
+	c := &webSocketConn{}
+	c.cond = sync.NewCond(&c.mutex)
+
+	ws := js.Global().Get("WebSocket").New(url)
+	ws.Set("binaryType", "arraybuffer")
+	c.ws = ws
+
+	opened := make(chan error, 1)
+
+	c.addListener("open", func(js.Value) {
+		select {
+		case opened <- nil:
+		default:
+		}
+	})
+
+	c.addListener("error", func(js.Value) {
+		select {
+		case opened <- fmt.Errorf("unable to connect to %s", url):
+		default:
+		}
+	})
+
+	// the close event is the last one, therefore callbacks can be released
+	c.addListener("close", func(js.Value) {
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+		c.closed = true
+		c.cond.Broadcast()
+
+		for _, f := range c.funcs {
+			f.Release()
+		}
+	})
+
+	c.addListener("message", func(evt js.Value) {
+		arr := js.Global().Get("Uint8Array").New(evt.Get("data"))
+		buf := make([]byte, arr.Get("length").Int())
+		js.CopyBytesToGo(buf, arr)
+
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+		c.queue = append(c.queue, buf)
+		c.cond.Signal()
+	})
+
+	err := <-opened
+	if err != nil {
+		c.Close()
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (c *webSocketConn) addListener(event string, cb func(js.Value)) {
+	f := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		cb(args[0])
+		return nil
+	})
+	c.funcs = append(c.funcs, f)
+	c.ws.Call("addEventListener", event, f)
+}
+
+// Close implements io.Closer.
+func (c *webSocketConn) Close() error {
+	c.ws.Call("close")
+
+	c.mutex.Lock()
+	c.closed = true
+	c.cond.Broadcast()
+	c.mutex.Unlock()
+
+	return nil
+}
+
+// Read implements io.Reader.
+func (c *webSocketConn) Read(p []byte) (int, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for len(c.buf) == 0 {
+		if c.closed {
+			return 0, errorTerminated
+		}
+
+		if len(c.queue) > 0 {
+			c.buf = c.queue[0]
+			c.queue = c.queue[1:]
+			continue
+		}
+
+		c.cond.Wait()
+	}
+
+	n := copy(p, c.buf)
+	c.buf = c.buf[n:]
+	return n, nil
+}
+
+// Write implements io.Writer.
+func (c *webSocketConn) Write(p []byte) (int, error) {
+	c.mutex.Lock()
+	closed := c.closed
+	c.mutex.Unlock()
+	if closed {
+		return 0, errorTerminated
+	}
+
+	arr := js.Global().Get("Uint8Array").New(len(p))
+	js.CopyBytesToJS(arr, p)
+	c.ws.Call("send", arr)
+	return len(p), nil
+}
@@ -0,0 +1,13 @@
+//go:build !js
+// +build !js
+
+package gomavlib
+
+import (
+	"fmt"
+	"io"
+)
+
+func webSocketDial(url string) (io.ReadWriteCloser, error) {
+	return nil, fmt.Errorf("WebSocket endpoints are supported only in browsers (GOOS=js)")
+}
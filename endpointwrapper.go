@@ -0,0 +1,109 @@
+package gomavlib
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/aler9/gomavlib/pkg/frame"
+)
+
+// channelOptions are options that wrapper endpoints apply to the channels
+// of the endpoint they wrap.
+type channelOptions struct {
+	outBytesPerSecond    int
+	outFramesPerSecond   int
+	highLatency          bool
+	outVersion           Version
+	outTruncationDisable bool
+	outExtensionsDisable bool
+	backpressurePolicy   BackpressurePolicy
+	writeQueueSize       int
+	heartbeatDisable     bool
+	outTransforms        []func(*Channel, frame.Frame) frame.Frame
+	signature            bool
+	inKey                *frame.V2Key
+	outKey               *frame.V2Key
+	idTranslations       []IDTranslation
+	outRates             map[uint32]float64
+	streamRequest        bool
+	streamFrequency      int
+	inFaults             *Faults
+	outFaults            *Faults
+	faultSeed            int64
+}
+
+// endpointWrapper is implemented by wrapper endpoints.
+type endpointWrapper interface {
+	channelOptions() channelOptions
+}
+
+type endpointWrapperSingle struct {
+	endpointChannelSingle
+	conf EndpointConf
+	opts channelOptions
+}
+
+func (t *endpointWrapperSingle) Conf() EndpointConf {
+	return t.conf
+}
+
+func (t *endpointWrapperSingle) channelOptions() channelOptions {
+	return t.opts
+}
+
+func (t *endpointWrapperSingle) RemoteAddr() net.Addr {
+	if p, ok := t.endpointChannelSingle.(remoteAddrProvider); ok {
+		return p.RemoteAddr()
+	}
+	return nil
+}
+
+type endpointWrapperAccepter struct {
+	endpointChannelAccepter
+	conf EndpointConf
+	opts channelOptions
+}
+
+func (t *endpointWrapperAccepter) Conf() EndpointConf {
+	return t.conf
+}
+
+func (t *endpointWrapperAccepter) channelOptions() channelOptions {
+	return t.opts
+}
+
+// wrapEndpoint initializes an endpoint and wraps it in order to apply
+// additional options to its channels. Wrappers can be nested.
+func wrapEndpoint(conf EndpointConf, inner EndpointConf, apply func(*channelOptions)) (Endpoint, error) {
+	if inner == nil {
+		return nil, fmt.Errorf("wrapped endpoint not provided")
+	}
+
+	e, err := inner.init()
+	if err != nil {
+		return nil, err
+	}
+
+	var opts channelOptions
+	if w, ok := e.(endpointWrapper); ok {
+		opts = w.channelOptions()
+	}
+	apply(&opts)
+
+	switch te := e.(type) {
+	case endpointChannelAccepter:
+		return &endpointWrapperAccepter{te, conf, opts}, nil
+
+	case endpointChannelSingle:
+		return &endpointWrapperSingle{te, conf, opts}, nil
+	}
+
+	return nil, fmt.Errorf("endpoint %T does not implement any interface", e)
+}
+
+func endpointChannelOptions(e Endpoint) channelOptions {
+	if w, ok := e.(endpointWrapper); ok {
+		return w.channelOptions()
+	}
+	return channelOptions{}
+}
@@ -1,8 +1,13 @@
 package gomavlib
 
 import (
+	"net"
+	"sync"
+	"time"
+
 	"github.com/aler9/gomavlib/pkg/frame"
 	"github.com/aler9/gomavlib/pkg/msg"
+	"github.com/aler9/gomavlib/pkg/transceiver"
 )
 
 // Event is the interface implemented by all events received with node.Events().
@@ -13,6 +18,19 @@ type Event interface {
 // EventChannelOpen is the event fired when a channel gets opened.
 type EventChannelOpen struct {
 	Channel *Channel
+
+	// the address of the remote peer, or nil if it is not available.
+	// See Channel.RemoteAddr.
+	RemoteAddr net.Addr
+
+	// the configuration of the endpoint that produced the channel.
+	EndpointConf EndpointConf
+
+	// whether a previous channel of the same endpoint was opened by the same
+	// remote host. Channels of client endpoints are kept open when the
+	// underlying connection is reestablished, therefore they are never
+	// reconnections.
+	Reconnection bool
 }
 
 func (*EventChannelOpen) isEventOut() {}
@@ -25,16 +43,80 @@ type EventChannelClose struct {
 func (*EventChannelClose) isEventOut() {}
 
 // EventFrame is the event fired when a frame is received.
+// The frame and its message are owned by the receiver of the event: the node
+// never reuses or modifies them after the event is emitted, therefore they can
+// be retained or passed to other routines. When EventFramePool is enabled,
+// this holds until Release() is called.
 type EventFrame struct {
 	// the frame
 	Frame frame.Frame
 
 	// the channel from which the frame was received
 	Channel *Channel
+
+	pooled bool
 }
 
 func (*EventFrame) isEventOut() {}
 
+// IsTruncated checks whether the frame is a V2 frame whose payload is shorter
+// than its message, because trailing zero bytes have been truncated or because
+// the sender does not know the extension fields of the message.
+// It returns false when the message is not in the dialect.
+func (res *EventFrame) IsTruncated() bool {
+	ff, ok := res.Frame.(*frame.V2Frame)
+	if !ok || res.Channel == nil || res.Channel.n.dialectDE == nil {
+		return false
+	}
+
+	mp, ok := res.Channel.n.dialectDE.MessageDEs[ff.GetMessage().GetID()]
+	if !ok {
+		return false
+	}
+
+	return int(ff.PayloadLength) < mp.SizeExtended()
+}
+
+var eventFramePool = sync.Pool{
+	New: func() interface{} {
+		return &EventFrame{}
+	},
+}
+
+func newEventFrame(fr frame.Frame, ch *Channel, pooled bool) *EventFrame {
+	if !pooled {
+		return &EventFrame{Frame: fr, Channel: ch}
+	}
+
+	evt := eventFramePool.Get().(*EventFrame)
+	evt.Frame = fr
+	evt.Channel = ch
+	evt.pooled = true
+	return evt
+}
+
+// Release gives back the event and its frame to the node, that reuses them
+// to emit following events. It must be called when EventFramePool is enabled,
+// and neither the event nor its frame can be used afterwards. The message
+// inside the frame is not reused and can be retained.
+// It can be called as soon as the frame has been passed to Node.WriteFrame*(),
+// since routed frames are cloned when EventFramePool is enabled, for
+// instance:
+//
+//	node.WriteFrameExcept(evt.Channel, evt.Frame)
+//	evt.Release()
+//
+// It has no effect when EventFramePool is disabled.
+func (res *EventFrame) Release() {
+	if !res.pooled {
+		return
+	}
+
+	transceiver.ReleaseFrame(res.Frame)
+	*res = EventFrame{}
+	eventFramePool.Put(res)
+}
+
 // SystemID returns the frame system id.
 func (res *EventFrame) SystemID() byte {
 	return res.Frame.GetSystemID()
@@ -50,9 +132,102 @@ func (res *EventFrame) Message() msg.Message {
 	return res.Frame.GetMessage()
 }
 
+// EventFrameLoss is the event fired when a gap is detected in the sequence
+// numbers of frames received from a remote system.
+type EventFrameLoss struct {
+	// the channel from which frames are received
+	Channel *Channel
+	// the system id of the remote system
+	SystemID byte
+	// the component id of the remote system
+	ComponentID byte
+	// the number of missing frames
+	Count int
+}
+
+func (*EventFrameLoss) isEventOut() {}
+
+// EventChannelLatency is the event fired when the round-trip time of a
+// channel is measured, that happens when a remote component answers a
+// TIMESYNC request. It requires TimesyncEnable.
+type EventChannelLatency struct {
+	// the channel whose round-trip time has been measured
+	Channel *Channel
+	// the system id of the component that answered
+	SystemID byte
+	// the component id of the component that answered
+	ComponentID byte
+	// the round-trip time of the exchange
+	RTT time.Duration
+}
+
+func (*EventChannelLatency) isEventOut() {}
+
+// EventStats is the event fired periodically with the statistics of the node.
+// It requires StatsPeriod.
+type EventStats struct {
+	// the statistics of open channels
+	Channels []ChannelStats
+	// the sum of the counters of all channels, including closed ones.
+	// Channel and RTT are not filled.
+	Total ChannelStats
+}
+
+func (*EventStats) isEventOut() {}
+
+// EventMissionCurrent is the event fired when the current mission item of a
+// remote system changes. It requires MissionProgressEnable.
+type EventMissionCurrent struct {
+	// the channel from which the MISSION_CURRENT message was received
+	Channel *Channel
+	// the system id of the remote system
+	SystemID byte
+	// the component id of the remote system
+	ComponentID byte
+	// the index of the current item
+	Seq int
+}
+
+func (*EventMissionCurrent) isEventOut() {}
+
+// EventMissionItemReached is the event fired when a remote system reaches
+// a mission item. It requires MissionProgressEnable.
+type EventMissionItemReached struct {
+	// the channel from which the MISSION_ITEM_REACHED message was received
+	Channel *Channel
+	// the system id of the remote system
+	SystemID byte
+	// the component id of the remote system
+	ComponentID byte
+	// the index of the reached item
+	Seq int
+}
+
+func (*EventMissionItemReached) isEventOut() {}
+
+// EventMissionComplete is the event fired when a remote system completes its
+// mission, that is when it reaches the last item or reports the completion
+// through MISSION_CURRENT. The last item is known only if the number of items
+// has been received or the mission has been uploaded by the node.
+// It requires MissionProgressEnable.
+type EventMissionComplete struct {
+	// the channel from which the last progress message was received
+	Channel *Channel
+	// the system id of the remote system
+	SystemID byte
+	// the component id of the remote system
+	ComponentID byte
+}
+
+func (*EventMissionComplete) isEventOut() {}
+
 // EventParseError is the event fired when a parse error occurs.
+// Frames rejected because of their signature are notified with
+// EventSignatureRejected instead.
 type EventParseError struct {
-	// the error
+	// the error, that is a *transceiver.Error. Its kind can be checked with
+	// errors.Is(), for instance errors.Is(err, transceiver.ErrBadChecksum),
+	// and its Bytes field contains the offending bytes.
 	Error error
 
 	// the channel used to send the frame
@@ -72,3 +247,94 @@ type EventStreamRequested struct {
 }
 
 func (*EventStreamRequested) isEventOut() {}
+
+// EventSystemOnline is the event fired when a heartbeat is received from a
+// remote system that was unknown or offline. It requires SystemTrackingEnable.
+type EventSystemOnline struct {
+	// the channel from which the heartbeat was received
+	Channel *Channel
+	// the system id of the remote system
+	SystemID byte
+	// the component id of the remote system
+	ComponentID byte
+}
+
+func (*EventSystemOnline) isEventOut() {}
+
+// EventSystemOffline is the event fired when a remote system has not sent
+// heartbeats for SystemTimeout. It requires SystemTrackingEnable.
+type EventSystemOffline struct {
+	// the channel from which the last heartbeat was received
+	Channel *Channel
+	// the system id of the remote system
+	SystemID byte
+	// the component id of the remote system
+	ComponentID byte
+}
+
+func (*EventSystemOffline) isEventOut() {}
+
+// EventSignatureRejected is the event fired when a frame is discarded because
+// its signature is missing, wrong or too old. It requires NodeConf.InKey.
+type EventSignatureRejected struct {
+	// the channel from which the frame was received
+	Channel *Channel
+	// the system id of the sender
+	SystemID byte
+	// the component id of the sender
+	ComponentID byte
+	// the signature link id, if the frame is signed
+	LinkID byte
+	// the signature timestamp, in 10 microsecond units since 1st January
+	// 2015, if the frame is signed
+	Timestamp uint64
+	// the difference between the signature timestamp and the local clock,
+	// if the frame is signed
+	TimestampDelta time.Duration
+	// the reason why the frame was rejected
+	Reason string
+	// the error, that is a *transceiver.Error of kind
+	// transceiver.ErrBadSignature
+	Error error
+}
+
+func (*EventSignatureRejected) isEventOut() {}
+
+// EventUnknownIncompatibilityFlag is the event fired when a V2 frame with
+// incompatibility flags that are not understood is received. It requires
+// NodeConf.InIncompatibilityFlagPolicy = IncompatibilityFlagPolicyEvent.
+// The message of the frame is not decoded.
+type EventUnknownIncompatibilityFlag struct {
+	// the frame
+	Frame *frame.V2Frame
+
+	// the channel from which the frame was received
+	Channel *Channel
+}
+
+func (*EventUnknownIncompatibilityFlag) isEventOut() {}
+
+// 1st January 2015 GMT, the reference date of signature timestamps
+var signatureReferenceDate = time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func newEventSignatureRejected(ch *Channel, err *transceiver.Error) *EventSignatureRejected {
+	evt := &EventSignatureRejected{
+		Channel: ch,
+		Reason:  err.Error(),
+		Error:   err,
+	}
+
+	if err.Frame != nil {
+		evt.SystemID = err.Frame.GetSystemID()
+		evt.ComponentID = err.Frame.GetComponentID()
+
+		if ff, ok := err.Frame.(*frame.V2Frame); ok && ff.IsSigned() {
+			evt.LinkID = ff.SignatureLinkID
+			evt.Timestamp = ff.SignatureTimestamp
+			ts := signatureReferenceDate.Add(time.Duration(ff.SignatureTimestamp) * 10 * time.Microsecond)
+			evt.TimestampDelta = ts.Sub(ch.n.conf.Clock.Now())
+		}
+	}
+
+	return evt
+}
@@ -0,0 +1,466 @@
+package gomavlib
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+	"github.com/aler9/gomavlib/pkg/frame"
+	"github.com/aler9/gomavlib/pkg/msg"
+	"github.com/aler9/gomavlib/pkg/transceiver"
+)
+
+func TestEventChannelOpen(t *testing.T) {
+	conf := EndpointTCPServer{Address: "127.0.0.1:5601"}
+
+	node, err := NewNode(NodeConf{
+		Dialect:          common.Dialect,
+		OutVersion:       V2,
+		OutSystemID:      10,
+		Endpoints:        []EndpointConf{conf},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node.Close()
+
+	for i, reconnection := range []bool{false, true} {
+		conn, err := net.Dial("tcp", "127.0.0.1:5601")
+		require.NoError(t, err)
+
+		evt := <-node.Events()
+		e, ok := evt.(*EventChannelOpen)
+		require.True(t, ok)
+		require.Equal(t, conn.LocalAddr().String(), e.RemoteAddr.String())
+		require.Equal(t, conf.Address, e.EndpointConf.(EndpointTCPServer).Address)
+		require.Equal(t, reconnection, e.Reconnection, "connection %d", i)
+
+		conn.Close()
+
+		evt = <-node.Events()
+		_, ok = evt.(*EventChannelClose)
+		require.True(t, ok)
+	}
+}
+
+func TestEventFramePool(t *testing.T) {
+	l1 := newTestPipe()
+	l2 := newTestPipe()
+
+	node1, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 10,
+		Endpoints: []EndpointConf{
+			EndpointCustom{ReadWriteCloser: &testEndpoint{l1, l2}},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node1.Close()
+
+	node2, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 11,
+		Endpoints: []EndpointConf{
+			EndpointCustom{ReadWriteCloser: &testEndpoint{l2, l1}},
+		},
+		HeartbeatDisable: true,
+		EventFramePool:   true,
+	})
+	require.NoError(t, err)
+	defer node2.Close()
+
+	go func() {
+		for range node1.Events() {
+		}
+	}()
+
+	go func() {
+		for i := 0; i < 20; i++ {
+			node1.WriteMessageAll(&common.MessageParamValue{ParamIndex: uint16(i)})
+		}
+	}()
+
+	var msgs []*common.MessageParamValue
+	for evt := range node2.Events() {
+		if fr, ok := evt.(*EventFrame); ok {
+			require.Equal(t, byte(10), fr.SystemID())
+
+			// messages are not recycled and can be retained
+			msgs = append(msgs, fr.Message().(*common.MessageParamValue))
+			fr.Release()
+
+			if len(msgs) == 20 {
+				break
+			}
+		}
+	}
+
+	for i, m := range msgs {
+		require.Equal(t, uint16(i), m.ParamIndex)
+	}
+}
+
+func TestEventFramePoolRouting(t *testing.T) {
+	l1 := newTestPipe()
+	l2 := newTestPipe()
+	l3 := newTestPipe()
+	l4 := newTestPipe()
+
+	source, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 10,
+		Endpoints: []EndpointConf{
+			EndpointCustom{ReadWriteCloser: &testEndpoint{l1, l2}},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer source.Close()
+
+	router, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 11,
+		Endpoints: []EndpointConf{
+			EndpointCustom{ReadWriteCloser: &testEndpoint{l2, l1}},
+			EndpointCustom{ReadWriteCloser: &testEndpoint{l3, l4}},
+		},
+		HeartbeatDisable: true,
+		EventFramePool:   true,
+	})
+	require.NoError(t, err)
+	defer router.Close()
+
+	dest, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 12,
+		Endpoints: []EndpointConf{
+			EndpointCustom{ReadWriteCloser: &testEndpoint{l4, l3}},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer dest.Close()
+
+	go func() {
+		for range source.Events() {
+		}
+	}()
+
+	go func() {
+		for evt := range router.Events() {
+			if fr, ok := evt.(*EventFrame); ok {
+				// events can be released as soon as they are routed
+				router.WriteFrameExcept(fr.Channel, fr.Frame)
+				fr.Release()
+			}
+		}
+	}()
+
+	go func() {
+		for i := 0; i < 50; i++ {
+			source.WriteMessageAll(&common.MessageParamValue{ParamIndex: uint16(i)})
+		}
+	}()
+
+	i := 0
+	for evt := range dest.Events() {
+		if fr, ok := evt.(*EventFrame); ok {
+			require.Equal(t, byte(10), fr.SystemID())
+			require.Equal(t, &common.MessageParamValue{ParamIndex: uint16(i)}, fr.Message())
+			i++
+
+			if i == 50 {
+				break
+			}
+		}
+	}
+}
+
+func TestEventFramePoolWaiters(t *testing.T) {
+	n := &Node{
+		nodeWaiters: newNodeWaiters(),
+	}
+
+	fw := n.nodeWaiters.add(func(*EventFrame) bool { return true }, 1)
+	defer n.nodeWaiters.remove(fw)
+
+	evt := newEventFrame(nil, nil, true)
+	n.nodeWaiters.onEventFrame(evt)
+
+	// events delivered to waiters are not recycled
+	evt.Release()
+	require.Equal(t, evt, <-fw.frames)
+}
+
+func TestEventParseErrorUnknownMessage(t *testing.T) {
+	l1 := newTestPipe()
+	l2 := newTestPipe()
+
+	node1, err := NewNode(NodeConf{
+		OutVersion:  V2,
+		OutSystemID: 10,
+		Endpoints: []EndpointConf{
+			EndpointCustom{ReadWriteCloser: &testEndpoint{l1, l2}},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node1.Close()
+
+	node2, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 11,
+		Endpoints: []EndpointConf{
+			EndpointCustom{ReadWriteCloser: &testEndpoint{l2, l1}},
+		},
+		HeartbeatDisable:       true,
+		DiscardUnknownMessages: true,
+	})
+	require.NoError(t, err)
+	defer node2.Close()
+
+	go func() {
+		for range node1.Events() {
+		}
+	}()
+
+	node1.WriteMessageAll(&msg.MessageRaw{ID: 60000, Content: []byte{1, 2, 3}})
+
+	for evt := range node2.Events() {
+		if ee, ok := evt.(*EventParseError); ok {
+			require.True(t, errors.Is(ee.Error, transceiver.ErrUnknownMessage))
+			require.Equal(t, 10+3+2, len(ee.Error.(*transceiver.Error).Bytes))
+			break
+		}
+	}
+}
+
+func TestEventSignatureRejected(t *testing.T) {
+	l1 := newTestPipe()
+	l2 := newTestPipe()
+
+	node1, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 10,
+		OutKey:      frame.NewV2Key([]byte("key1")),
+		Endpoints: []EndpointConf{
+			EndpointCustom{ReadWriteCloser: &testEndpoint{l1, l2}},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node1.Close()
+
+	node2, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 11,
+		InKey:       frame.NewV2Key([]byte("key2")),
+		Endpoints: []EndpointConf{
+			EndpointCustom{ReadWriteCloser: &testEndpoint{l2, l1}},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node2.Close()
+
+	go func() {
+		for range node1.Events() {
+		}
+	}()
+
+	node1.WriteMessageAll(&common.MessageParamValue{ParamIndex: 1})
+
+	for evt := range node2.Events() {
+		if ee, ok := evt.(*EventSignatureRejected); ok {
+			require.Equal(t, byte(10), ee.SystemID)
+			require.Equal(t, byte(1), ee.ComponentID)
+			require.NotEqual(t, uint64(0), ee.Timestamp)
+			require.True(t, ee.TimestampDelta > -5*time.Second && ee.TimestampDelta < 5*time.Second)
+			require.Equal(t, "wrong signature", ee.Reason)
+			require.True(t, errors.Is(ee.Error, transceiver.ErrBadSignature))
+			break
+		}
+
+		_, ok := evt.(*EventParseError)
+		require.False(t, ok)
+	}
+
+	require.Equal(t, uint64(1), node2.ChannelStats()[0].SignatureErrors)
+}
+
+func TestEventFrameIsTruncated(t *testing.T) {
+	l1 := newTestPipe()
+	l2 := newTestPipe()
+
+	node1, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 10,
+		Endpoints: []EndpointConf{
+			EndpointVersion{
+				Endpoint:             EndpointCustom{ReadWriteCloser: &testEndpoint{l1, l2}},
+				OutVersion:           V2,
+				OutExtensionsDisable: true,
+			},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node1.Close()
+
+	node2, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 11,
+		Endpoints: []EndpointConf{
+			EndpointCustom{ReadWriteCloser: &testEndpoint{l2, l1}},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node2.Close()
+
+	nextFrame := func(n *Node) *EventFrame {
+		for evt := range n.Events() {
+			if fr, ok := evt.(*EventFrame); ok {
+				return fr
+			}
+		}
+		return nil
+	}
+
+	// extension fields are not sent
+	node1.WriteMessageAll(&common.MessageGpsRawInt{
+		SatellitesVisible: 10,
+		Yaw:               5,
+	})
+	fr := nextFrame(node2)
+	require.Equal(t, &common.MessageGpsRawInt{
+		SatellitesVisible: 10,
+	}, fr.Message())
+	require.True(t, fr.IsTruncated())
+
+	node2.WriteMessageAll(&common.MessageParamValue{
+		ParamId:   "PARAM",
+		ParamType: common.MAV_PARAM_TYPE_REAL32,
+	})
+	require.False(t, nextFrame(node1).IsTruncated())
+
+	node2.WriteMessageAll(&common.MessageParamValue{
+		ParamId: "PARAM",
+	})
+	require.True(t, nextFrame(node1).IsTruncated())
+
+	go func() {
+		for range node1.Events() {
+		}
+	}()
+	go func() {
+		for range node2.Events() {
+		}
+	}()
+}
+
+func TestEventUnknownIncompatibilityFlag(t *testing.T) {
+	mde, err := msg.NewDecEncoder(&common.MessageHeartbeat{})
+	require.NoError(t, err)
+
+	content, err := mde.Encode(&common.MessageHeartbeat{Type: 1}, true)
+	require.NoError(t, err)
+
+	fr := &frame.V2Frame{
+		IncompatibilityFlag: 0x02,
+		SystemID:            10,
+		ComponentID:         1,
+		Message:             &msg.MessageRaw{ID: 0, Content: content},
+	}
+	fr.Checksum = fr.GenChecksum(mde.CRCExtra())
+
+	for _, ca := range []string{"reject", "event"} {
+		t.Run(ca, func(t *testing.T) {
+			l1 := newTestPipe()
+			l2 := newTestPipe()
+
+			node1, err := NewNode(NodeConf{
+				Dialect:     common.Dialect,
+				OutVersion:  V2,
+				OutSystemID: 10,
+				Endpoints: []EndpointConf{
+					EndpointCustom{ReadWriteCloser: &testEndpoint{l1, l2}},
+				},
+				HeartbeatDisable:     true,
+				OutCompatibilityFlag: 0x80,
+			})
+			require.NoError(t, err)
+			defer node1.Close()
+
+			policy := IncompatibilityFlagPolicyReject
+			if ca == "event" {
+				policy = IncompatibilityFlagPolicyEvent
+			}
+
+			node2, err := NewNode(NodeConf{
+				Dialect:     common.Dialect,
+				OutVersion:  V2,
+				OutSystemID: 11,
+				Endpoints: []EndpointConf{
+					EndpointCustom{ReadWriteCloser: &testEndpoint{l2, l1}},
+				},
+				HeartbeatDisable:            true,
+				InIncompatibilityFlagPolicy: policy,
+			})
+			require.NoError(t, err)
+			defer node2.Close()
+
+			go func() {
+				for range node1.Events() {
+				}
+			}()
+
+			node1.WriteFrameAll(fr)
+
+			for evt := range node2.Events() {
+				if ca == "reject" {
+					if ee, ok := evt.(*EventParseError); ok {
+						require.True(t, errors.Is(ee.Error, transceiver.ErrUnknownIncompatibilityFlag))
+						break
+					}
+				} else {
+					if ee, ok := evt.(*EventUnknownIncompatibilityFlag); ok {
+						require.Equal(t, byte(0x02), ee.Frame.IncompatibilityFlag)
+						require.Equal(t, &msg.MessageRaw{ID: 0, Content: content}, ee.Frame.Message)
+						break
+					}
+				}
+				require.IsType(t, &EventChannelOpen{}, evt)
+			}
+
+			// compatibility flags are added to outgoing frames
+			node1.WriteMessageAll(&common.MessageHeartbeat{})
+
+			for evt := range node2.Events() {
+				if ee, ok := evt.(*EventFrame); ok {
+					require.Equal(t, byte(0x80), ee.Frame.(*frame.V2Frame).CompatibilityFlag)
+					break
+				}
+			}
+
+			go func() {
+				for range node2.Events() {
+				}
+			}()
+		})
+	}
+}
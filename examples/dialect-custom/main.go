@@ -8,18 +8,32 @@ import (
 	"github.com/aler9/gomavlib/pkg/msg"
 )
 
-// this is a custom message.
+// this is a custom message, defined without XML.
 // It must be prefixed with "Message" and implement the msg.Message interface.
+// Field types are the Mavlink ones (uint8, int16, float32, ...); the following
+// tags are available:
+// - mavlen:"N" turns a string into a char[N] field
+// - mavenum:"uint8" encodes an int enum with the given type
+// - mavext:"true" marks an extension field
+// - mavname:"name" sets the Mavlink name of a field, used by the CRC extra
 type MessageCustom struct {
 	Param1 uint8
 	Param2 uint8
 	Param3 uint32
+	Label  string `mavlen:"16"`
 }
 
 func (*MessageCustom) GetID() uint32 {
 	return 304
 }
 
+// the CRC extra is computed from the message name and fields. It can be
+// specified explicitly, in order to match the definition used by other
+// Mavlink implementations.
+func (*MessageCustom) CRCExtra() byte {
+	return 82
+}
+
 func main() {
 	// create a custom dialect from messages
 	dialect := &dialect.Dialect{3, []msg.Message{
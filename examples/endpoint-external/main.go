@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net"
+
+	"github.com/aler9/gomavlib"
+	"github.com/aler9/gomavlib/pkg/dialects/ardupilotmega"
+)
+
+// this is an example transport, implemented outside of gomavlib, that
+// accepts connections on a Unix socket.
+type UnixProvider struct {
+	Path string
+}
+
+func (p *UnixProvider) Kind() gomavlib.EndpointKind {
+	return "unix"
+}
+
+// Open returns an object that implements gomavlib.EndpointChannelAccepter.
+// Transports that provide a single channel must implement
+// gomavlib.EndpointChannelSingle instead.
+func (p *UnixProvider) Open() (gomavlib.EndpointChannelProvider, error) {
+	ln, err := net.Listen("unix", p.Path)
+	if err != nil {
+		return nil, err
+	}
+	return &unixAccepter{ln}, nil
+}
+
+type unixAccepter struct {
+	ln net.Listener
+}
+
+func (a *unixAccepter) Close() error {
+	return a.ln.Close()
+}
+
+// Accept returns a channel for each connected peer.
+// Since net.UnixConn implements RemoteAddr(), the address of the peer is
+// available in Channel.RemoteAddr().
+func (a *unixAccepter) Accept() (string, io.ReadWriteCloser, error) {
+	conn, err := a.ln.Accept()
+	if err != nil {
+		return "", nil, err
+	}
+	return "unix:" + a.ln.Addr().String(), conn, nil
+}
+
+func main() {
+	// create a node which
+	// - communicates with a transport implemented outside of gomavlib
+	// - understands ardupilotmega dialect
+	// - writes messages with given system id
+	node, err := gomavlib.NewNode(gomavlib.NodeConf{
+		Endpoints: []gomavlib.EndpointConf{
+			gomavlib.EndpointExternal{Provider: &UnixProvider{Path: "/tmp/mavlink.sock"}},
+		},
+		Dialect:     ardupilotmega.Dialect,
+		OutVersion:  gomavlib.V2, // change to V1 if you're unable to communicate with the target
+		OutSystemID: 10,
+	})
+	if err != nil {
+		panic(err)
+	}
+	defer node.Close()
+
+	// print every message we receive
+	for evt := range node.Events() {
+		if frm, ok := evt.(*gomavlib.EventFrame); ok {
+			fmt.Printf("received: id=%d, %+v\n", frm.Message().GetID(), frm.Message())
+		}
+	}
+}
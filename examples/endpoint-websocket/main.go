@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/aler9/gomavlib"
+	"github.com/aler9/gomavlib/pkg/dialects/ardupilotmega"
+)
+
+// this example runs in a browser. It can be compiled with:
+//   GOOS=js GOARCH=wasm go build -o main.wasm
+// and loaded with wasm_exec.js, that is provided by the Go distribution.
+
+func main() {
+	// create a node which
+	// - communicates with a WebSocket server
+	// - understands ardupilotmega dialect
+	// - writes messages with given system id
+	node, err := gomavlib.NewNode(gomavlib.NodeConf{
+		Endpoints: []gomavlib.EndpointConf{
+			gomavlib.EndpointWebSocket{"ws://1.2.3.4:8080/mavlink"},
+		},
+		Dialect:     ardupilotmega.Dialect,
+		OutVersion:  gomavlib.V2, // change to V1 if you're unable to communicate with the target
+		OutSystemID: 10,
+	})
+	if err != nil {
+		panic(err)
+	}
+	defer node.Close()
+
+	// print every message we receive
+	for evt := range node.Events() {
+		if frm, ok := evt.(*gomavlib.EventFrame); ok {
+			fmt.Printf("received: id=%d, %+v\n", frm.Message().GetID(), frm.Message())
+		}
+	}
+}
@@ -31,14 +31,27 @@ func main() {
 		case *gomavlib.EventFrame:
 			fmt.Printf("frame received: %v\n", ee)
 
+		case *gomavlib.EventFrameLoss:
+			fmt.Printf("frames lost: %v\n", ee)
+
 		case *gomavlib.EventParseError:
 			fmt.Printf("parse error: %v\n", ee)
 
+		case *gomavlib.EventSignatureRejected:
+			fmt.Printf("signature rejected: %v\n", ee)
+
+		case *gomavlib.EventUnknownIncompatibilityFlag:
+			fmt.Printf("unknown incompatibility flag: %v\n", ee)
+
 		case *gomavlib.EventChannelOpen:
-			fmt.Printf("channel opened: %v\n", ee)
+			fmt.Printf("channel opened: %v (remote address: %v, reconnection: %v)\n",
+				ee.Channel, ee.RemoteAddr, ee.Reconnection)
 
 		case *gomavlib.EventChannelClose:
 			fmt.Printf("channel closed: %v\n", ee)
+
+		case *gomavlib.EventChannelLatency:
+			fmt.Printf("channel latency: %v (round-trip time: %v)\n", ee.Channel, ee.RTT)
 		}
 	}
 }
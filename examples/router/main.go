@@ -14,7 +14,7 @@ func main() {
 	node, err := gomavlib.NewNode(gomavlib.NodeConf{
 		Endpoints: []gomavlib.EndpointConf{
 			gomavlib.EndpointSerial{"/dev/ttyUSB0:57600"},
-			gomavlib.EndpointUDPClient{"1.2.3.4:5900"},
+			gomavlib.EndpointUDPClient{Address: "1.2.3.4:5900"},
 		},
 		Dialect:     nil,
 		OutVersion:  gomavlib.V2, // change to V1 if you're unable to communicate with the target
@@ -0,0 +1,230 @@
+package gomavlib
+
+import (
+	"io"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+type faultItem struct {
+	what interface{}
+	at   time.Time
+}
+
+// faultInjector drops, duplicates, reorders and delays items (encoded frames
+// or decoded frames) before passing them to an emit function.
+// push() must be called by a single routine. Items that are not delayed are
+// emitted by the calling routine, while delayed items are emitted by a
+// dedicated routine.
+type faultInjector struct {
+	faults  Faults
+	clock   Clock
+	rand    *rand.Rand
+	emit    func(interface{})
+	clone   func(interface{}) interface{}
+	discard func(interface{})
+
+	held    interface{} // item that is emitted after the following one
+	hasHeld bool
+
+	emitMutex sync.Mutex
+
+	mutex sync.Mutex
+	queue []faultItem // delayed items, sorted by release time
+
+	// in
+	wake      chan struct{}
+	terminate chan struct{}
+
+	// out
+	done chan struct{}
+}
+
+func newFaultInjector(
+	faults Faults,
+	clock Clock,
+	seed int64,
+	emit func(interface{}),
+	clone func(interface{}) interface{},
+	discard func(interface{}),
+) *faultInjector {
+	return &faultInjector{
+		faults:    faults,
+		clock:     clock,
+		rand:      rand.New(rand.NewSource(seed)),
+		emit:      emit,
+		clone:     clone,
+		discard:   discard,
+		wake:      make(chan struct{}, 1),
+		terminate: make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+}
+
+func (fi *faultInjector) start() {
+	go fi.run()
+}
+
+// close stops the injector. Items that are still delayed or held are
+// discarded.
+func (fi *faultInjector) close() {
+	close(fi.terminate)
+	<-fi.done
+
+	if fi.discard != nil {
+		for _, item := range fi.queue {
+			fi.discard(item.what)
+		}
+		if fi.hasHeld {
+			fi.discard(fi.held)
+		}
+	}
+	fi.queue = nil
+	fi.held = nil
+	fi.hasHeld = false
+}
+
+func (fi *faultInjector) happens(probability float64) bool {
+	return probability > 0 && fi.rand.Float64() < probability
+}
+
+func (fi *faultInjector) push(what interface{}) {
+	if fi.happens(fi.faults.DropProbability) {
+		if fi.discard != nil {
+			fi.discard(what)
+		}
+		return
+	}
+
+	if !fi.hasHeld && fi.happens(fi.faults.ReorderProbability) {
+		fi.held = what
+		fi.hasHeld = true
+		return
+	}
+
+	fi.deliver(what)
+	if fi.happens(fi.faults.DuplicateProbability) {
+		fi.deliver(fi.clone(what))
+	}
+
+	if fi.hasHeld {
+		held := fi.held
+		fi.held = nil
+		fi.hasHeld = false
+		fi.deliver(held)
+	}
+}
+
+// deliver emits an item, or queues it in case it is delayed.
+func (fi *faultInjector) deliver(what interface{}) {
+	if !fi.happens(fi.faults.DelayProbability) {
+		fi.emitMutex.Lock()
+		fi.emit(what)
+		fi.emitMutex.Unlock()
+		return
+	}
+
+	delay := time.Duration(fi.rand.Int63n(int64(fi.faults.MaxDelay) + 1))
+	item := faultItem{what: what, at: fi.clock.Now().Add(delay)}
+
+	fi.mutex.Lock()
+	i := len(fi.queue)
+	for i > 0 && fi.queue[i-1].at.After(item.at) {
+		i--
+	}
+	fi.queue = append(fi.queue, faultItem{})
+	copy(fi.queue[i+1:], fi.queue[i:])
+	fi.queue[i] = item
+	fi.mutex.Unlock()
+
+	select {
+	case fi.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (fi *faultInjector) run() {
+	defer close(fi.done)
+
+	stopTimer := func() {}
+	defer func() { stopTimer() }()
+
+	for {
+		now := fi.clock.Now()
+		var ready []interface{}
+		wait := time.Duration(-1)
+
+		fi.mutex.Lock()
+		for len(fi.queue) > 0 && !fi.queue[0].at.After(now) {
+			ready = append(ready, fi.queue[0].what)
+			fi.queue = fi.queue[1:]
+		}
+		if len(fi.queue) > 0 {
+			wait = fi.queue[0].at.Sub(now)
+		}
+		fi.mutex.Unlock()
+
+		if len(ready) != 0 {
+			fi.emitMutex.Lock()
+			for _, what := range ready {
+				fi.emit(what)
+			}
+			fi.emitMutex.Unlock()
+		}
+
+		stopTimer()
+		stopTimer = func() {}
+
+		var timerC <-chan time.Time
+		if wait >= 0 {
+			timerC, stopTimer = fi.clock.NewTimer(wait)
+		}
+
+		select {
+		case <-timerC:
+		case <-fi.wake:
+		case <-fi.terminate:
+			return
+		}
+	}
+}
+
+// corrupt flips a random bit of a buffer.
+func corrupt(r *rand.Rand, buf []byte) {
+	if len(buf) == 0 {
+		return
+	}
+	i := r.Intn(len(buf) * 8)
+	buf[i/8] ^= 1 << uint(i%8)
+}
+
+// faultReader is a reader that corrupts read data.
+type faultReader struct {
+	r           io.Reader
+	rand        *rand.Rand
+	probability float64
+}
+
+func (r *faultReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if n > 0 && r.rand.Float64() < r.probability {
+		corrupt(r.rand, p[:n])
+	}
+	return n, err
+}
+
+// faultWriter is a writer that passes written data to a fault injector,
+// corrupting it.
+type faultWriter struct {
+	fi *faultInjector
+}
+
+func (w *faultWriter) Write(buf []byte) (int, error) {
+	buf2 := append([]byte(nil), buf...)
+	if w.fi.happens(w.fi.faults.CorruptProbability) {
+		corrupt(w.fi.rand, buf2)
+	}
+	w.fi.push(buf2)
+	return len(buf), nil
+}
@@ -0,0 +1,113 @@
+package gomavlib
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestFaultInjector(faults Faults) (*faultInjector, *[]interface{}, *[]interface{}) {
+	var emitted []interface{}
+	var discarded []interface{}
+
+	fi := newFaultInjector(faults, realClock{}, 1,
+		func(what interface{}) {
+			emitted = append(emitted, what)
+		},
+		func(what interface{}) interface{} {
+			return what
+		},
+		func(what interface{}) {
+			discarded = append(discarded, what)
+		})
+
+	return fi, &emitted, &discarded
+}
+
+func TestFaultInjectorNone(t *testing.T) {
+	fi, emitted, _ := newTestFaultInjector(Faults{})
+	fi.start()
+
+	for i := 0; i < 5; i++ {
+		fi.push(i)
+	}
+	fi.close()
+
+	require.Equal(t, []interface{}{0, 1, 2, 3, 4}, *emitted)
+}
+
+func TestFaultInjectorDrop(t *testing.T) {
+	fi, emitted, discarded := newTestFaultInjector(Faults{DropProbability: 1})
+	fi.start()
+
+	fi.push(1)
+	fi.push(2)
+	fi.close()
+
+	require.Equal(t, 0, len(*emitted))
+	require.Equal(t, []interface{}{1, 2}, *discarded)
+}
+
+func TestFaultInjectorDuplicate(t *testing.T) {
+	fi, emitted, _ := newTestFaultInjector(Faults{DuplicateProbability: 1})
+	fi.start()
+
+	fi.push(1)
+	fi.push(2)
+	fi.close()
+
+	require.Equal(t, []interface{}{1, 1, 2, 2}, *emitted)
+}
+
+func TestFaultInjectorReorder(t *testing.T) {
+	fi, emitted, discarded := newTestFaultInjector(Faults{ReorderProbability: 1})
+	fi.start()
+
+	fi.push(1)
+	fi.push(2)
+	fi.push(3)
+	fi.close()
+
+	require.Equal(t, []interface{}{2, 1}, *emitted)
+
+	// the last item is held until the injector is closed
+	require.Equal(t, []interface{}{3}, *discarded)
+}
+
+func TestFaultInjectorDelay(t *testing.T) {
+	emitted := make(chan interface{}, 10)
+
+	fi := newFaultInjector(Faults{
+		DelayProbability: 1,
+		MaxDelay:         50 * time.Millisecond,
+	}, realClock{}, 1,
+		func(what interface{}) {
+			emitted <- what
+		},
+		nil,
+		nil)
+	fi.start()
+	defer fi.close()
+
+	start := time.Now()
+	fi.push(1)
+	require.Equal(t, 0, len(emitted))
+
+	require.Equal(t, 1, <-emitted)
+	require.True(t, time.Since(start) <= 1*time.Second)
+}
+
+func TestFaultCorrupt(t *testing.T) {
+	buf := []byte{0, 0, 0, 0}
+	corrupt(rand.New(rand.NewSource(1)), buf)
+
+	flipped := 0
+	for _, b := range buf {
+		for ; b != 0; b &= b - 1 {
+			flipped++
+		}
+	}
+	require.Equal(t, 1, flipped)
+}
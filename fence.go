@@ -0,0 +1,183 @@
+package gomavlib
+
+import (
+	"context"
+	"fmt"
+)
+
+// command used by fences to define the point where the vehicle returns after
+// a breach (MAV_CMD_NAV_FENCE_RETURN_POINT).
+const planCmdFenceReturnPoint = 5000
+
+// FencePoint is a point of a fence, in degrees.
+type FencePoint struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// FencePolygon is a polygonal area of a fence.
+type FencePolygon struct {
+	// whether the vehicle must stay inside (true) or outside (false) the area
+	Inclusion bool
+	// the vertices of the polygon, at least 3
+	Vertices []FencePoint
+}
+
+// FenceCircle is a circular area of a fence.
+type FenceCircle struct {
+	// whether the vehicle must stay inside (true) or outside (false) the area
+	Inclusion bool
+	// the center of the circle
+	Center FencePoint
+	// the radius of the circle, in meters
+	Radius float32
+}
+
+// Fence is a geofence, that is transferred with the mission protocol as a
+// plan of type MissionTypeFence.
+type Fence struct {
+	Polygons []FencePolygon
+	Circles  []FenceCircle
+	// (optional) the point where the vehicle returns after a breach
+	ReturnPoint *FencePoint
+}
+
+// FenceEncode converts a fence into the items of a fence plan.
+func FenceEncode(f *Fence) ([]*MissionItem, error) {
+	var items []*MissionItem
+
+	for i, poly := range f.Polygons {
+		if len(poly.Vertices) < 3 {
+			return nil, fmt.Errorf("polygon %d: a polygon must have at least 3 vertices", i)
+		}
+
+		cmd := planCmdFenceVertexExclusion
+		if poly.Inclusion {
+			cmd = planCmdFenceVertexInclusion
+		}
+
+		for _, v := range poly.Vertices {
+			items = append(items, &MissionItem{
+				Frame:        planFrameGlobal,
+				Command:      cmd,
+				Autocontinue: true,
+				Param1:       float32(len(poly.Vertices)),
+				X:            planDegToInt(v.Latitude),
+				Y:            planDegToInt(v.Longitude),
+			})
+		}
+	}
+
+	for i, c := range f.Circles {
+		if c.Radius <= 0 {
+			return nil, fmt.Errorf("circle %d: invalid radius (%v)", i, c.Radius)
+		}
+
+		cmd := planCmdFenceCircleExclusion
+		if c.Inclusion {
+			cmd = planCmdFenceCircleInclusion
+		}
+
+		items = append(items, &MissionItem{
+			Frame:        planFrameGlobal,
+			Command:      cmd,
+			Autocontinue: true,
+			Param1:       c.Radius,
+			X:            planDegToInt(c.Center.Latitude),
+			Y:            planDegToInt(c.Center.Longitude),
+		})
+	}
+
+	if f.ReturnPoint != nil {
+		items = append(items, &MissionItem{
+			Frame:        planFrameGlobalRelativeAlt,
+			Command:      planCmdFenceReturnPoint,
+			Autocontinue: true,
+			X:            planDegToInt(f.ReturnPoint.Latitude),
+			Y:            planDegToInt(f.ReturnPoint.Longitude),
+		})
+	}
+
+	return items, nil
+}
+
+// FenceDecode converts the items of a fence plan into a fence.
+// It returns an error if the sequence of items is not valid, for instance
+// if the vertices of a polygon are not consecutive.
+func FenceDecode(items []*MissionItem) (*Fence, error) {
+	f := &Fence{}
+
+	for i := 0; i < len(items); i++ {
+		item := items[i]
+
+		switch item.Command {
+		case planCmdFenceVertexInclusion, planCmdFenceVertexExclusion:
+			count := int(item.Param1)
+			if count < 3 || i+count > len(items) {
+				return nil, fmt.Errorf("fence item %d: invalid vertex count (%d)", i, count)
+			}
+
+			poly := FencePolygon{
+				Inclusion: item.Command == planCmdFenceVertexInclusion,
+			}
+			for _, v := range items[i : i+count] {
+				if v.Command != item.Command || int(v.Param1) != count {
+					return nil, fmt.Errorf("fence item %d: polygon is not complete", i)
+				}
+				poly.Vertices = append(poly.Vertices, FencePoint{planIntToDeg(v.X), planIntToDeg(v.Y)})
+			}
+			f.Polygons = append(f.Polygons, poly)
+			i += count - 1
+
+		case planCmdFenceCircleInclusion, planCmdFenceCircleExclusion:
+			if item.Param1 <= 0 {
+				return nil, fmt.Errorf("fence item %d: invalid radius (%v)", i, item.Param1)
+			}
+
+			f.Circles = append(f.Circles, FenceCircle{
+				Inclusion: item.Command == planCmdFenceCircleInclusion,
+				Center:    FencePoint{planIntToDeg(item.X), planIntToDeg(item.Y)},
+				Radius:    item.Param1,
+			})
+
+		case planCmdFenceReturnPoint:
+			if f.ReturnPoint != nil {
+				return nil, fmt.Errorf("fence item %d: duplicate return point", i)
+			}
+
+			f.ReturnPoint = &FencePoint{planIntToDeg(item.X), planIntToDeg(item.Y)}
+
+		default:
+			return nil, fmt.Errorf("fence item %d: unsupported command (%d)", i, item.Command)
+		}
+	}
+
+	return f, nil
+}
+
+// FenceValidate checks whether a sequence of items is a valid fence plan.
+// It can be used by the OnUpload callback of a MissionServer.
+func FenceValidate(items []*MissionItem) error {
+	_, err := FenceDecode(items)
+	return err
+}
+
+// UploadFence uploads a fence, replacing the existing one.
+func (c *MissionClient) UploadFence(ctx context.Context, f *Fence) error {
+	items, err := FenceEncode(f)
+	if err != nil {
+		return err
+	}
+
+	return c.Upload(ctx, MissionTypeFence, items)
+}
+
+// DownloadFence downloads the fence.
+func (c *MissionClient) DownloadFence(ctx context.Context) (*Fence, error) {
+	items, err := c.Download(ctx, MissionTypeFence)
+	if err != nil {
+		return nil, err
+	}
+
+	return FenceDecode(items)
+}
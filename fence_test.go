@@ -0,0 +1,173 @@
+package gomavlib
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+)
+
+var testFence = &Fence{
+	Polygons: []FencePolygon{{
+		Inclusion: true,
+		Vertices: []FencePoint{
+			{45.1, 9.1},
+			{45.2, 9.1},
+			{45.2, 9.2},
+			{45.1, 9.2},
+		},
+	}},
+	Circles: []FenceCircle{{
+		Center: FencePoint{45.15, 9.15},
+		Radius: 50,
+	}},
+	ReturnPoint: &FencePoint{45.12, 9.12},
+}
+
+func TestFenceEncodeDecode(t *testing.T) {
+	items, err := FenceEncode(testFence)
+	require.NoError(t, err)
+	require.Equal(t, 6, len(items))
+	require.Equal(t, planCmdFenceVertexInclusion, items[0].Command)
+	require.Equal(t, float32(4), items[3].Param1)
+	require.Equal(t, planCmdFenceCircleExclusion, items[4].Command)
+	require.Equal(t, planCmdFenceReturnPoint, items[5].Command)
+
+	f, err := FenceDecode(items)
+	require.NoError(t, err)
+	require.Equal(t, testFence, f)
+}
+
+func TestFenceEncodeErrors(t *testing.T) {
+	for _, ca := range []struct {
+		name  string
+		fence *Fence
+		err   string
+	}{
+		{
+			"vertex count",
+			&Fence{Polygons: []FencePolygon{{Vertices: []FencePoint{{1, 1}, {2, 2}}}}},
+			"polygon 0: a polygon must have at least 3 vertices",
+		},
+		{
+			"radius",
+			&Fence{Circles: []FenceCircle{{Center: FencePoint{1, 1}}}},
+			"circle 0: invalid radius (0)",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			_, err := FenceEncode(ca.fence)
+			require.EqualError(t, err, ca.err)
+		})
+	}
+}
+
+func TestFenceValidate(t *testing.T) {
+	vertex := func(cmd int, count float32) *MissionItem {
+		return &MissionItem{Command: cmd, Param1: count}
+	}
+
+	for _, ca := range []struct {
+		name  string
+		items []*MissionItem
+		err   string
+	}{
+		{
+			"vertex count",
+			[]*MissionItem{
+				vertex(planCmdFenceVertexInclusion, 3),
+				vertex(planCmdFenceVertexInclusion, 3),
+			},
+			"fence item 0: invalid vertex count (3)",
+		},
+		{
+			"mixed polygons",
+			[]*MissionItem{
+				vertex(planCmdFenceVertexInclusion, 3),
+				vertex(planCmdFenceVertexInclusion, 3),
+				vertex(planCmdFenceVertexExclusion, 3),
+			},
+			"fence item 0: polygon is not complete",
+		},
+		{
+			"inconsistent count",
+			[]*MissionItem{
+				vertex(planCmdFenceVertexInclusion, 3),
+				vertex(planCmdFenceVertexInclusion, 4),
+				vertex(planCmdFenceVertexInclusion, 3),
+			},
+			"fence item 0: polygon is not complete",
+		},
+		{
+			"radius",
+			[]*MissionItem{
+				{Command: planCmdFenceCircleInclusion},
+			},
+			"fence item 0: invalid radius (0)",
+		},
+		{
+			"return point",
+			[]*MissionItem{
+				{Command: planCmdFenceReturnPoint},
+				{Command: planCmdFenceReturnPoint},
+			},
+			"fence item 1: duplicate return point",
+		},
+		{
+			"command",
+			[]*MissionItem{
+				{Command: 16},
+			},
+			"fence item 0: unsupported command (16)",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			require.EqualError(t, FenceValidate(ca.items), ca.err)
+		})
+	}
+}
+
+func TestMissionClientFence(t *testing.T) {
+	node1, node2 := newTestNodePair(t, common.Dialect)
+	defer node1.Close()
+	defer node2.Close()
+
+	go func() {
+		for range node1.Events() {
+		}
+	}()
+	go func() {
+		for range node2.Events() {
+		}
+	}()
+
+	s, err := NewMissionServer(MissionServerConf{
+		Node: node2,
+		OnUpload: func(typ MissionType, items []*MissionItem) MissionResult {
+			if FenceValidate(items) != nil {
+				return MissionResultInvalidSequence
+			}
+			return MissionResultAccepted
+		},
+	})
+	require.NoError(t, err)
+	defer s.Close()
+
+	c, err := NewMissionClient(MissionClientConf{
+		Node: node1,
+		Target: Target{
+			SystemID:    11,
+			ComponentID: 1,
+		},
+	})
+	require.NoError(t, err)
+
+	err = c.UploadFence(context.Background(), testFence)
+	require.NoError(t, err)
+
+	f, err := c.DownloadFence(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, testFence, f)
+}
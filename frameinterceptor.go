@@ -0,0 +1,66 @@
+package gomavlib
+
+import (
+	"github.com/aler9/gomavlib/pkg/frame"
+	"github.com/aler9/gomavlib/pkg/transceiver"
+)
+
+// FrameDirection is the direction of a frame.
+type FrameDirection int
+
+// frame directions.
+const (
+	// the frame has been received from a channel
+	FrameDirectionIn FrameDirection = iota
+	// the frame is going to be written to a channel
+	FrameDirectionOut
+)
+
+// String implements fmt.Stringer.
+func (d FrameDirection) String() string {
+	if d == FrameDirectionIn {
+		return "in"
+	}
+	return "out"
+}
+
+// FrameInterceptor is a function that is called with every frame received
+// from or written to a channel. It returns the frame to pass to the next
+// interceptor, that is the same frame or a modified copy, or nil in order to
+// drop the frame.
+//
+// Frames and their messages are shared between channels and with the
+// application, therefore they must not be modified in place: a modified
+// frame must be a copy (see frame.Frame.Clone), and a modified message must
+// be a copy too. Frames must not be retained after the function returns.
+//
+// Incoming frames are intercepted before they are processed by the node and
+// emitted as EventFrame. Outgoing frames are intercepted before they are
+// encoded: the checksum and the signature of frames written with
+// WriteMessage*() are computed afterwards, while the checksum of routed
+// frames, written with WriteFrame*(), is computed again if they are modified.
+//
+// Interceptors are called by the routines of each channel, therefore they
+// can be called by multiple routines in parallel.
+type FrameInterceptor func(ch *Channel, dir FrameDirection, fr frame.Frame) frame.Frame
+
+// intercept passes a frame through the interceptors of the node.
+func (ch *Channel) intercept(dir FrameDirection, fr frame.Frame) frame.Frame {
+	for _, fi := range ch.n.conf.FrameInterceptors {
+		fr = fi(ch, dir, fr)
+		if fr == nil {
+			return nil
+		}
+	}
+	return fr
+}
+
+// interceptIn passes a received frame through the interceptors.
+// Dropped frames are given back to the frame pool.
+func (ch *Channel) interceptIn(fr frame.Frame) frame.Frame {
+	out := ch.intercept(FrameDirectionIn, fr)
+	if out == nil && ch.n.conf.EventFramePool {
+		transceiver.ReleaseFrame(fr)
+	}
+	return out
+}
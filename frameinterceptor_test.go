@@ -0,0 +1,111 @@
+package gomavlib
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+	"github.com/aler9/gomavlib/pkg/frame"
+)
+
+func TestNodeFrameInterceptors(t *testing.T) {
+	l1 := newTestPipe()
+	l2 := newTestPipe()
+
+	var mutex sync.Mutex
+	var calls []string
+
+	record := func(name string) FrameInterceptor {
+		return func(ch *Channel, dir FrameDirection, fr frame.Frame) frame.Frame {
+			mutex.Lock()
+			defer mutex.Unlock()
+			calls = append(calls, name+"-"+dir.String())
+			return fr
+		}
+	}
+
+	node1, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 10,
+		Endpoints: []EndpointConf{
+			EndpointCustom{ReadWriteCloser: &testEndpoint{l1, l2}},
+		},
+		HeartbeatDisable: true,
+		FrameInterceptors: []FrameInterceptor{
+			record("a"),
+			func(ch *Channel, dir FrameDirection, fr frame.Frame) frame.Frame {
+				m, ok := fr.GetMessage().(*common.MessageParamValue)
+				if !ok {
+					return fr
+				}
+
+				switch {
+				// drop
+				case m.ParamIndex == 1:
+					return nil
+
+				// modify outgoing messages
+				case dir == FrameDirectionOut && m.ParamIndex == 2:
+					cpy := *m
+					cpy.ParamValue = 200
+					fr = fr.Clone()
+					fr.(*frame.V2Frame).Message = &cpy
+				}
+				return fr
+			},
+			record("b"),
+		},
+	})
+	require.NoError(t, err)
+	defer node1.Close()
+
+	node2, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 11,
+		Endpoints: []EndpointConf{
+			EndpointCustom{ReadWriteCloser: &testEndpoint{l2, l1}},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node2.Close()
+
+	defer l1.Close()
+	defer l2.Close()
+
+	<-node1.Events()
+	<-node2.Events()
+
+	// outgoing
+	original := &common.MessageParamValue{ParamId: "test", ParamValue: 100, ParamIndex: 2}
+	node1.WriteMessageAll(&common.MessageParamValue{ParamId: "test", ParamValue: 100, ParamIndex: 1})
+	node1.WriteMessageAll(original)
+
+	evt := <-node2.Events()
+	fr, ok := evt.(*EventFrame)
+	require.Equal(t, true, ok)
+	require.Equal(t, &common.MessageParamValue{ParamId: "test", ParamValue: 200, ParamIndex: 2}, fr.Message())
+	require.Equal(t, float32(100), original.ParamValue)
+
+	// incoming
+	node2.WriteMessageAll(&common.MessageParamValue{ParamId: "test", ParamValue: 100, ParamIndex: 1})
+	node2.WriteMessageAll(&common.MessageParamValue{ParamId: "test", ParamValue: 100, ParamIndex: 3})
+
+	evt = <-node1.Events()
+	fr, ok = evt.(*EventFrame)
+	require.Equal(t, true, ok)
+	require.Equal(t, &common.MessageParamValue{ParamId: "test", ParamValue: 100, ParamIndex: 3}, fr.Message())
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	require.Equal(t, []string{
+		"a-out",
+		"a-out", "b-out",
+		"a-in",
+		"a-in", "b-in",
+	}, calls)
+}
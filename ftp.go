@@ -0,0 +1,90 @@
+package gomavlib
+
+import (
+	"encoding/binary"
+	"hash/crc32"
+)
+
+const (
+	ftpPayloadSize = 251
+	ftpHeaderSize  = 12
+	ftpMaxDataSize = ftpPayloadSize - ftpHeaderSize
+)
+
+// MAVFTP opcodes.
+const (
+	ftpOpTerminateSession = 1
+	ftpOpResetSessions    = 2
+	ftpOpListDirectory    = 3
+	ftpOpOpenFileRO       = 4
+	ftpOpReadFile         = 5
+	ftpOpCreateFile       = 6
+	ftpOpWriteFile        = 7
+	ftpOpRemoveFile       = 8
+	ftpOpCreateDirectory  = 9
+	ftpOpRemoveDirectory  = 10
+	ftpOpOpenFileWO       = 11
+	ftpOpTruncateFile     = 12
+	ftpOpRename           = 13
+	ftpOpCalcFileCRC32    = 14
+	ftpOpBurstReadFile    = 15
+	ftpOpAck              = 128
+	ftpOpNak              = 129
+)
+
+// MAVFTP error codes, sent in NAK responses.
+const (
+	ftpErrFail                = 1
+	ftpErrInvalidSession      = 4
+	ftpErrNoSessionsAvailable = 5
+	ftpErrEOF                 = 6
+	ftpErrUnknownCommand      = 7
+	ftpErrFileProtected       = 9
+	ftpErrFileNotFound        = 10
+)
+
+// ftpPayload is the content of a FILE_TRANSFER_PROTOCOL message.
+type ftpPayload struct {
+	Seq           uint16
+	Session       uint8
+	Opcode        uint8
+	ReqOpcode     uint8
+	BurstComplete bool
+	Offset        uint32
+	Data          []byte
+}
+
+func (p *ftpPayload) unmarshal(buf [ftpPayloadSize]byte) {
+	p.Seq = binary.LittleEndian.Uint16(buf[0:2])
+	p.Session = buf[2]
+	p.Opcode = buf[3]
+	size := int(buf[4])
+	if size > ftpMaxDataSize {
+		size = ftpMaxDataSize
+	}
+	p.ReqOpcode = buf[5]
+	p.BurstComplete = buf[6] != 0
+	p.Offset = binary.LittleEndian.Uint32(buf[8:12])
+	p.Data = append([]byte(nil), buf[ftpHeaderSize:ftpHeaderSize+size]...)
+}
+
+func (p *ftpPayload) marshal() [ftpPayloadSize]byte {
+	var buf [ftpPayloadSize]byte
+	binary.LittleEndian.PutUint16(buf[0:2], p.Seq)
+	buf[2] = p.Session
+	buf[3] = p.Opcode
+	buf[4] = uint8(len(p.Data))
+	buf[5] = p.ReqOpcode
+	if p.BurstComplete {
+		buf[6] = 1
+	}
+	binary.LittleEndian.PutUint32(buf[8:12], p.Offset)
+	copy(buf[ftpHeaderSize:], p.Data)
+	return buf
+}
+
+// ftpCRC32 computes the checksum used by MAVFTP, that is a CRC-32 with the
+// IEEE polynomial, without the initial and final inversions.
+func ftpCRC32(crc uint32, p []byte) uint32 {
+	return ^crc32.Update(^crc, crc32.IEEETable, p)
+}
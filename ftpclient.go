@@ -0,0 +1,179 @@
+package gomavlib
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+const (
+	ftpClientMaxPrealloc = 64 * 1024
+)
+
+// ftpClientConf allows to configure a ftpClient.
+type ftpClientConf struct {
+	// the node used to communicate.
+	// Its dialect must contain the FILE_TRANSFER_PROTOCOL message.
+	Node *Node
+	// the component that exposes the files.
+	Target Target
+
+	// (optional) the time to wait for a response before retrying.
+	// It defaults to 1 second.
+	Timeout time.Duration
+	// (optional) the maximum number of retries. It defaults to 3.
+	Retries int
+}
+
+// ftpClient implements the client side of the MAVLink FTP protocol, that
+// allows to download files from a remote component. It is used to fetch
+// the files referenced by COMPONENT_INFORMATION.
+type ftpClient struct {
+	conf   ftpClientConf
+	msgFTP msg.Message
+	mutex  sync.Mutex
+	seq    uint16
+}
+
+func newFTPClient(conf ftpClientConf) (*ftpClient, error) {
+	if conf.Node == nil {
+		return nil, fmt.Errorf("Node not provided")
+	}
+	if conf.Timeout == 0 {
+		conf.Timeout = 1 * time.Second
+	}
+	if conf.Retries == 0 {
+		conf.Retries = 3
+	}
+
+	msgFTP := dialectMessage(conf.Node.conf.Dialect, 110, 84)
+	if msgFTP == nil {
+		return nil, fmt.Errorf("dialect does not contain FILE_TRANSFER_PROTOCOL")
+	}
+
+	return &ftpClient{
+		conf:   conf,
+		msgFTP: msgFTP,
+	}, nil
+}
+
+func (c *ftpClient) request(ctx context.Context, req *ftpPayload) (*ftpPayload, error) {
+	req.Seq = c.seq
+	c.seq += 2
+
+	m := newMessage(c.msgFTP)
+	messageSet(m, "TargetSystem", c.conf.Target.SystemID)
+	messageSet(m, "TargetComponent", c.conf.Target.ComponentID)
+	messageSet(m, "Payload", req.marshal())
+
+	evt, err := c.conf.Node.request(ctx, func(evt *EventFrame) bool {
+		if evt.Message().GetID() != 110 || !c.conf.Target.matches(evt) ||
+			byte(messageGetInt(evt.Message(), "TargetSystem")) != c.conf.Node.conf.OutSystemID {
+			return false
+		}
+
+		var p ftpPayload
+		p.unmarshal(messageGet(evt.Message(), "Payload").Interface().([ftpPayloadSize]byte))
+		return p.Seq == req.Seq+1 && p.ReqOpcode == req.Opcode
+	}, func() {
+		c.conf.Node.writeMessageToTarget(c.conf.Target, m)
+	}, c.conf.Timeout, c.conf.Retries)
+	if err != nil {
+		return nil, err
+	}
+
+	var res ftpPayload
+	res.unmarshal(messageGet(evt.Message(), "Payload").Interface().([ftpPayloadSize]byte))
+
+	if res.Opcode == ftpOpNak {
+		code := byte(ftpErrFail)
+		if len(res.Data) > 0 {
+			code = res.Data[0]
+		}
+		return &res, ftpError(code)
+	}
+
+	return &res, nil
+}
+
+// ftpError is an error returned by a MAVFTP server.
+type ftpError byte
+
+func (e ftpError) Error() string {
+	switch e {
+	case ftpErrInvalidSession:
+		return "invalid session"
+	case ftpErrNoSessionsAvailable:
+		return "no sessions available"
+	case ftpErrEOF:
+		return "end of file"
+	case ftpErrUnknownCommand:
+		return "unknown command"
+	case ftpErrFileProtected:
+		return "file protected"
+	case ftpErrFileNotFound:
+		return "file not found"
+	}
+	return "failure"
+}
+
+// download downloads a file.
+func (c *ftpClient) download(ctx context.Context, path string) ([]byte, error) {
+	// requests must be serialized since sequence numbers are shared
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	res, err := c.request(ctx, &ftpPayload{
+		Opcode: ftpOpOpenFileRO,
+		Data:   []byte(path),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(res.Data) < 4 {
+		return nil, fmt.Errorf("invalid response")
+	}
+
+	session := res.Session
+	size := int(binary.LittleEndian.Uint32(res.Data))
+
+	defer c.request(ctx, &ftpPayload{ //nolint:errcheck
+		Session: session,
+		Opcode:  ftpOpTerminateSession,
+	})
+
+	// the size is reported by the remote component: do not trust it when
+	// allocating memory.
+	prealloc := size
+	if prealloc > ftpClientMaxPrealloc {
+		prealloc = ftpClientMaxPrealloc
+	}
+	buf := make([]byte, 0, prealloc)
+
+	for len(buf) < size {
+		res, err := c.request(ctx, &ftpPayload{
+			Session: session,
+			Opcode:  ftpOpReadFile,
+			Offset:  uint32(len(buf)),
+			Data:    make([]byte, ftpMaxDataSize),
+		})
+		if err != nil {
+			if err == ftpError(ftpErrEOF) {
+				break
+			}
+			return nil, err
+		}
+
+		if res.Offset != uint32(len(buf)) || len(res.Data) == 0 {
+			return nil, fmt.Errorf("invalid response")
+		}
+
+		buf = append(buf, res.Data...)
+	}
+
+	return buf, nil
+}
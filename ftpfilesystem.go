@@ -0,0 +1,39 @@
+package gomavlib
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// FTPFileSystem is a read-only file system that is exposed by a FTPServer.
+// Names are slash-separated paths relative to the root, that is ".".
+type FTPFileSystem interface {
+	Open(name string) (io.ReadCloser, error)
+	Stat(name string) (os.FileInfo, error)
+	ReadDir(name string) ([]os.FileInfo, error)
+}
+
+type ftpDir string
+
+// FTPDir returns a FTPFileSystem that exposes the content of a directory.
+func FTPDir(dir string) FTPFileSystem {
+	return ftpDir(dir)
+}
+
+func (d ftpDir) path(name string) string {
+	return filepath.Join(string(d), filepath.FromSlash(name))
+}
+
+func (d ftpDir) Open(name string) (io.ReadCloser, error) {
+	return os.Open(d.path(name))
+}
+
+func (d ftpDir) Stat(name string) (os.FileInfo, error) {
+	return os.Stat(d.path(name))
+}
+
+func (d ftpDir) ReadDir(name string) ([]os.FileInfo, error) {
+	return ioutil.ReadDir(d.path(name))
+}
@@ -0,0 +1,44 @@
+//go:build go1.16
+// +build go1.16
+
+package gomavlib
+
+import (
+	"io"
+	"io/fs"
+	"os"
+)
+
+type ftpFS struct {
+	fsys fs.FS
+}
+
+// FTPFS returns a FTPFileSystem that exposes the content of a fs.FS.
+func FTPFS(fsys fs.FS) FTPFileSystem {
+	return ftpFS{fsys}
+}
+
+func (f ftpFS) Open(name string) (io.ReadCloser, error) {
+	return f.fsys.Open(name)
+}
+
+func (f ftpFS) Stat(name string) (os.FileInfo, error) {
+	return fs.Stat(f.fsys, name)
+}
+
+func (f ftpFS) ReadDir(name string) ([]os.FileInfo, error) {
+	entries, err := fs.ReadDir(f.fsys, name)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]os.FileInfo, 0, len(entries))
+	for _, e := range entries {
+		info, err := e.Info()
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, info)
+	}
+	return ret, nil
+}
@@ -0,0 +1,37 @@
+//go:build go1.16
+// +build go1.16
+
+package gomavlib
+
+import (
+	"io/ioutil"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFTPFS(t *testing.T) {
+	fsys := FTPFS(fstest.MapFS{
+		"logs/a.bin": &fstest.MapFile{Data: []byte("abc")},
+		"logs/b.bin": &fstest.MapFile{Data: []byte("defg")},
+	})
+
+	entries, err := fsys.ReadDir("logs")
+	require.NoError(t, err)
+	require.Equal(t, 2, len(entries))
+	require.Equal(t, "b.bin", entries[1].Name())
+	require.Equal(t, int64(4), entries[1].Size())
+
+	info, err := fsys.Stat("logs/a.bin")
+	require.NoError(t, err)
+	require.Equal(t, int64(3), info.Size())
+
+	f, err := fsys.Open("logs/a.bin")
+	require.NoError(t, err)
+	defer f.Close()
+
+	buf, err := ioutil.ReadAll(f)
+	require.NoError(t, err)
+	require.Equal(t, []byte("abc"), buf)
+}
@@ -0,0 +1,448 @@
+package gomavlib
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+// FTPServerConf allows to configure a FTPServer.
+type FTPServerConf struct {
+	// the node used to communicate.
+	// Its dialect must contain the FILE_TRANSFER_PROTOCOL message.
+	Node *Node
+	// the file system exposed by the server. See FTPDir and FTPFS.
+	FileSystem FTPFileSystem
+
+	// (optional) the maximum number of files that can be open at once.
+	// It defaults to 4.
+	MaxSessions int
+
+	// (optional) the maximum number of packets sent in response to a
+	// burst read request. Clients request the rest of the file with
+	// another burst.
+	// It defaults to 32.
+	BurstSize int
+}
+
+type ftpServerSession struct {
+	file io.ReadCloser
+	size int64
+}
+
+func (ss *ftpServerSession) readAt(buf []byte, off int64) (int, error) {
+	switch f := ss.file.(type) {
+	case io.ReaderAt:
+		return f.ReadAt(buf, off)
+
+	case io.Seeker:
+		_, err := f.Seek(off, io.SeekStart)
+		if err != nil {
+			return 0, err
+		}
+		return io.ReadFull(ss.file, buf)
+	}
+
+	return 0, fmt.Errorf("file does not support random access")
+}
+
+// ftpServerBurst is a burst read in progress. Its packets are sent one at a
+// time by the server routine, in order to keep serving other requests.
+type ftpServerBurst struct {
+	source Target
+	req    *ftpPayload
+	seq    uint16
+	offset int64
+	sent   int
+}
+
+// FTPServer implements the server side of the MAVLink FTP protocol, that
+// allows remote components to list, download and checksum files exposed
+// by the node. The file system is read-only: requests that modify it are
+// refused.
+type FTPServer struct {
+	conf     FTPServerConf
+	msgFTP   msg.Message
+	sessions map[uint8]*ftpServerSession
+
+	// last response, sent again when a request is repeated
+	lastSource Target
+	lastReq    *ftpPayload
+	lastRes    *ftpPayload
+
+	burst *ftpServerBurst
+
+	frameService
+}
+
+// NewFTPServer allocates a FTPServer. See FTPServerConf for the options.
+func NewFTPServer(conf FTPServerConf) (*FTPServer, error) {
+	if conf.Node == nil {
+		return nil, fmt.Errorf("Node not provided")
+	}
+	if conf.FileSystem == nil {
+		return nil, fmt.Errorf("FileSystem not provided")
+	}
+	if conf.MaxSessions == 0 {
+		conf.MaxSessions = 4
+	}
+	if conf.BurstSize == 0 {
+		conf.BurstSize = 32
+	}
+
+	msgFTP := dialectMessage(conf.Node.conf.Dialect, 110, 84)
+	if msgFTP == nil {
+		return nil, fmt.Errorf("dialect does not contain FILE_TRANSFER_PROTOCOL")
+	}
+
+	s := &FTPServer{
+		conf:     conf,
+		msgFTP:   msgFTP,
+		sessions: make(map[uint8]*ftpServerSession),
+	}
+
+	s.start(conf.Node, s.isRequest, 256, s.run)
+
+	return s, nil
+}
+
+// Close stops the server.
+func (s *FTPServer) Close() {
+	s.close()
+}
+
+func (s *FTPServer) isRequest(evt *EventFrame) bool {
+	if evt.Message().GetID() != 110 {
+		return false
+	}
+
+	sysID := byte(messageGetInt(evt.Message(), "TargetSystem"))
+	compID := byte(messageGetInt(evt.Message(), "TargetComponent"))
+	return sysID == s.conf.Node.conf.OutSystemID &&
+		(compID == s.conf.Node.conf.OutComponentID || compID == 0)
+}
+
+func (s *FTPServer) run() {
+	defer s.resetSessions()
+
+	for {
+		if s.burst != nil {
+			select {
+			case evt := <-s.fw.frames:
+				s.onRequest(evt)
+
+			case <-s.ctx.Done():
+				return
+
+			default:
+				s.burstNext()
+			}
+			continue
+		}
+
+		select {
+		case evt := <-s.fw.frames:
+			s.onRequest(evt)
+
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *FTPServer) write(t Target, p *ftpPayload) {
+	m := newMessage(s.msgFTP)
+	messageSet(m, "TargetSystem", t.SystemID)
+	messageSet(m, "TargetComponent", t.ComponentID)
+	messageSet(m, "Payload", p.marshal())
+	s.conf.Node.writeMessageToTarget(t, m)
+}
+
+func (s *FTPServer) resetSessions() {
+	for id, ss := range s.sessions {
+		ss.file.Close()
+		delete(s.sessions, id)
+	}
+}
+
+func (s *FTPServer) onRequest(evt *EventFrame) {
+	var req ftpPayload
+	req.unmarshal(messageGet(evt.Message(), "Payload").Interface().([ftpPayloadSize]byte))
+
+	source := Target{
+		Channel:     evt.Channel,
+		SystemID:    evt.SystemID(),
+		ComponentID: evt.ComponentID(),
+	}
+
+	// the response to the previous request was lost: send it again
+	if s.lastReq != nil && source == s.lastSource &&
+		req.Seq == s.lastReq.Seq && req.Opcode == s.lastReq.Opcode {
+		// the burst is still in progress
+		if s.lastRes != nil {
+			s.write(source, s.lastRes)
+		}
+		return
+	}
+
+	s.lastSource = source
+	s.lastReq = &req
+	s.lastRes = nil
+
+	if req.Opcode == ftpOpBurstReadFile {
+		s.burst = &ftpServerBurst{
+			source: source,
+			req:    &req,
+			seq:    req.Seq,
+			offset: int64(req.Offset),
+		}
+		return
+	}
+
+	res := s.process(&req)
+	res.Seq = req.Seq + 1
+	res.Session = req.Session
+	res.ReqOpcode = req.Opcode
+	s.write(source, res)
+	s.lastRes = res
+}
+
+func ftpAck(data []byte) *ftpPayload {
+	return &ftpPayload{
+		Opcode: ftpOpAck,
+		Data:   data,
+	}
+}
+
+func ftpNak(code byte) *ftpPayload {
+	return &ftpPayload{
+		Opcode: ftpOpNak,
+		Data:   []byte{code},
+	}
+}
+
+func ftpNakFromError(err error) *ftpPayload {
+	if os.IsNotExist(err) {
+		return ftpNak(ftpErrFileNotFound)
+	}
+	return ftpNak(ftpErrFail)
+}
+
+// ftpPath converts a path received from a remote component into a path
+// of the file system, preventing access outside of its root.
+func ftpPath(data []byte) string {
+	p := string(data)
+	if i := strings.IndexByte(p, 0); i >= 0 {
+		p = p[:i]
+	}
+
+	p = strings.TrimPrefix(path.Clean("/"+p), "/")
+	if p == "" {
+		return "."
+	}
+	return p
+}
+
+func (s *FTPServer) process(req *ftpPayload) *ftpPayload {
+	switch req.Opcode {
+	case ftpOpTerminateSession:
+		ss, ok := s.sessions[req.Session]
+		if !ok {
+			return ftpNak(ftpErrInvalidSession)
+		}
+		ss.file.Close()
+		delete(s.sessions, req.Session)
+		return ftpAck(nil)
+
+	case ftpOpResetSessions:
+		s.resetSessions()
+		return ftpAck(nil)
+
+	case ftpOpListDirectory:
+		return s.listDirectory(req)
+
+	case ftpOpOpenFileRO:
+		return s.openFile(req)
+
+	case ftpOpReadFile:
+		ss, ok := s.sessions[req.Session]
+		if !ok {
+			return ftpNak(ftpErrInvalidSession)
+		}
+		return s.readFile(ss, int64(req.Offset), len(req.Data))
+
+	case ftpOpCalcFileCRC32:
+		return s.calcFileCRC32(req)
+
+	case ftpOpCreateFile, ftpOpWriteFile, ftpOpRemoveFile, ftpOpCreateDirectory,
+		ftpOpRemoveDirectory, ftpOpOpenFileWO, ftpOpTruncateFile, ftpOpRename:
+		return ftpNak(ftpErrFileProtected)
+	}
+
+	return ftpNak(ftpErrUnknownCommand)
+}
+
+func (s *FTPServer) listDirectory(req *ftpPayload) *ftpPayload {
+	entries, err := s.conf.FileSystem.ReadDir(ftpPath(req.Data))
+	if err != nil {
+		return ftpNakFromError(err)
+	}
+
+	if int(req.Offset) >= len(entries) {
+		return ftpNak(ftpErrEOF)
+	}
+
+	var data []byte
+	for _, e := range entries[req.Offset:] {
+		var entry string
+		switch {
+		case e.IsDir():
+			entry = "D" + e.Name()
+		case e.Mode().IsRegular():
+			entry = "F" + e.Name() + "\t" + strconv.FormatInt(e.Size(), 10)
+		default:
+			entry = "S"
+		}
+		entry += "\x00"
+
+		if (len(data) + len(entry)) > ftpMaxDataSize {
+			break
+		}
+		data = append(data, entry...)
+	}
+
+	return ftpAck(data)
+}
+
+func (s *FTPServer) openFile(req *ftpPayload) *ftpPayload {
+	name := ftpPath(req.Data)
+
+	info, err := s.conf.FileSystem.Stat(name)
+	if err != nil {
+		return ftpNakFromError(err)
+	}
+	if !info.Mode().IsRegular() {
+		return ftpNak(ftpErrFail)
+	}
+
+	id, ok := s.freeSessionID()
+	if !ok {
+		return ftpNak(ftpErrNoSessionsAvailable)
+	}
+
+	f, err := s.conf.FileSystem.Open(name)
+	if err != nil {
+		return ftpNakFromError(err)
+	}
+
+	s.sessions[id] = &ftpServerSession{
+		file: f,
+		size: info.Size(),
+	}
+
+	res := ftpAck(make([]byte, 4))
+	binary.LittleEndian.PutUint32(res.Data, uint32(info.Size()))
+	res.Session = id
+	return res
+}
+
+func (s *FTPServer) freeSessionID() (uint8, bool) {
+	for i := 0; i < s.conf.MaxSessions && i < 256; i++ {
+		if _, ok := s.sessions[uint8(i)]; !ok {
+			return uint8(i), true
+		}
+	}
+	return 0, false
+}
+
+func (s *FTPServer) readFile(ss *ftpServerSession, offset int64, size int) *ftpPayload {
+	if offset >= ss.size {
+		return ftpNak(ftpErrEOF)
+	}
+
+	if size == 0 || size > ftpMaxDataSize {
+		size = ftpMaxDataSize
+	}
+
+	buf := make([]byte, size)
+	n, err := ss.readAt(buf, offset)
+	if n == 0 {
+		if err == io.EOF {
+			return ftpNak(ftpErrEOF)
+		}
+		return ftpNak(ftpErrFail)
+	}
+
+	res := ftpAck(buf[:n])
+	res.Offset = uint32(offset)
+	return res
+}
+
+// burstNext sends the next packet of the burst in progress. The burst ends
+// when the file is over, when an error occurs or when BurstSize packets
+// have been sent.
+func (s *FTPServer) burstNext() {
+	b := s.burst
+
+	var res *ftpPayload
+	ss, ok := s.sessions[b.req.Session]
+	if !ok {
+		res = ftpNak(ftpErrInvalidSession)
+	} else {
+		res = s.readFile(ss, b.offset, ftpMaxDataSize)
+	}
+
+	b.seq++
+	b.sent++
+	res.Seq = b.seq
+	res.Session = b.req.Session
+	res.ReqOpcode = b.req.Opcode
+
+	if res.Opcode == ftpOpAck {
+		b.offset += int64(len(res.Data))
+		res.BurstComplete = b.offset >= ss.size || b.sent >= s.conf.BurstSize
+	}
+
+	s.write(b.source, res)
+
+	if b.req == s.lastReq {
+		s.lastRes = res
+	}
+
+	if res.Opcode != ftpOpAck || res.BurstComplete {
+		s.burst = nil
+	}
+}
+
+func (s *FTPServer) calcFileCRC32(req *ftpPayload) *ftpPayload {
+	f, err := s.conf.FileSystem.Open(ftpPath(req.Data))
+	if err != nil {
+		return ftpNakFromError(err)
+	}
+	defer f.Close()
+
+	var crc uint32
+	buf := make([]byte, 4096)
+
+	for {
+		n, err := f.Read(buf)
+		crc = ftpCRC32(crc, buf[:n])
+
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return ftpNak(ftpErrFail)
+		}
+	}
+
+	res := ftpAck(make([]byte, 4))
+	binary.LittleEndian.PutUint32(res.Data, crc)
+	return res
+}
@@ -0,0 +1,142 @@
+package gomavlib
+
+import (
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+)
+
+func TestFTPCRC32(t *testing.T) {
+	// check value of CRC-32 with zero initial value and no final inversion
+	require.Equal(t, uint32(0x2dfd2d88), ftpCRC32(0, []byte("123456789")))
+}
+
+func TestFTPServer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gomavlib")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	content := make([]byte, 1000)
+	for i := range content {
+		content[i] = byte(i)
+	}
+
+	err = ioutil.WriteFile(filepath.Join(dir, "log.bin"), content, 0o644)
+	require.NoError(t, err)
+	err = os.Mkdir(filepath.Join(dir, "media"), 0o755)
+	require.NoError(t, err)
+
+	node1, node2 := newTestNodePair(t, common.Dialect)
+	defer node1.Close()
+	defer node2.Close()
+
+	go func() {
+		for range node2.Events() {
+		}
+	}()
+
+	responses := make(chan *ftpPayload, 100)
+	go func() {
+		for evt := range node1.Events() {
+			if fr, ok := evt.(*EventFrame); ok {
+				if m, ok := fr.Message().(*common.MessageFileTransferProtocol); ok {
+					var p ftpPayload
+					p.unmarshal(m.Payload)
+					responses <- &p
+				}
+			}
+		}
+	}()
+
+	s, err := NewFTPServer(FTPServerConf{
+		Node:       node2,
+		FileSystem: FTPDir(dir),
+		BurstSize:  2,
+	})
+	require.NoError(t, err)
+	defer s.Close()
+
+	seq := uint16(0)
+	request := func(p *ftpPayload) *ftpPayload {
+		p.Seq = seq
+		seq += 2
+		node1.WriteMessageAll(&common.MessageFileTransferProtocol{
+			TargetSystem:    11,
+			TargetComponent: 1,
+			Payload:         p.marshal(),
+		})
+		return <-responses
+	}
+
+	res := request(&ftpPayload{Opcode: ftpOpListDirectory, Data: []byte("/")})
+	require.Equal(t, uint8(ftpOpAck), res.Opcode)
+	require.Equal(t, []byte("Flog.bin\t1000\x00Dmedia\x00"), res.Data)
+
+	res = request(&ftpPayload{Opcode: ftpOpListDirectory, Data: []byte("/"), Offset: 2})
+	require.Equal(t, &ftpPayload{
+		Seq:       3,
+		Opcode:    ftpOpNak,
+		ReqOpcode: ftpOpListDirectory,
+		Data:      []byte{ftpErrEOF},
+	}, res)
+
+	res = request(&ftpPayload{Opcode: ftpOpOpenFileRO, Data: []byte("/missing")})
+	require.Equal(t, []byte{ftpErrFileNotFound}, res.Data)
+
+	res = request(&ftpPayload{Opcode: ftpOpOpenFileRO, Data: []byte("/../log.bin")})
+	require.Equal(t, uint8(ftpOpAck), res.Opcode)
+	require.Equal(t, uint32(1000), binary.LittleEndian.Uint32(res.Data))
+	session := res.Session
+
+	res = request(&ftpPayload{
+		Session: session,
+		Opcode:  ftpOpReadFile,
+		Offset:  100,
+		Data:    make([]byte, 50),
+	})
+	require.Equal(t, uint8(ftpOpAck), res.Opcode)
+	require.Equal(t, content[100:150], res.Data)
+
+	// bursts are limited to BurstSize packets
+	var received []byte
+	bursts := 0
+	for len(received) < len(content) {
+		res = request(&ftpPayload{
+			Session: session,
+			Opcode:  ftpOpBurstReadFile,
+			Offset:  uint32(len(received)),
+		})
+		packets := 0
+		for {
+			require.Equal(t, uint8(ftpOpAck), res.Opcode)
+			require.Equal(t, uint32(len(received)), res.Offset)
+			received = append(received, res.Data...)
+			packets++
+			if res.BurstComplete {
+				break
+			}
+			res = <-responses
+		}
+		require.True(t, packets <= 2)
+		seq = res.Seq + 1
+		bursts++
+	}
+	require.Equal(t, content, received)
+	require.Equal(t, 3, bursts)
+
+	res = request(&ftpPayload{Session: session, Opcode: ftpOpTerminateSession})
+	require.Equal(t, uint8(ftpOpAck), res.Opcode)
+
+	res = request(&ftpPayload{Opcode: ftpOpCalcFileCRC32, Data: []byte("log.bin")})
+	require.Equal(t, uint8(ftpOpAck), res.Opcode)
+	require.Equal(t, ftpCRC32(0, content), binary.LittleEndian.Uint32(res.Data))
+
+	res = request(&ftpPayload{Opcode: ftpOpRemoveFile, Data: []byte("log.bin")})
+	require.Equal(t, []byte{ftpErrFileProtected}, res.Data)
+}
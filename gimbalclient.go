@@ -0,0 +1,272 @@
+package gomavlib
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+// GimbalManagerFlags are the flags that control a gimbal (GIMBAL_MANAGER_FLAGS).
+type GimbalManagerFlags uint32
+
+// gimbal manager flags.
+const (
+	GimbalManagerFlagRetract   GimbalManagerFlags = 1
+	GimbalManagerFlagNeutral   GimbalManagerFlags = 2
+	GimbalManagerFlagRollLock  GimbalManagerFlags = 4
+	GimbalManagerFlagPitchLock GimbalManagerFlags = 8
+	GimbalManagerFlagYawLock   GimbalManagerFlags = 16
+)
+
+// GimbalManagerInformation contains the capabilities of a gimbal manager
+// (GIMBAL_MANAGER_INFORMATION). Angles are in radians.
+type GimbalManagerInformation struct {
+	CapFlags       uint32 // GIMBAL_MANAGER_CAP_FLAGS
+	GimbalDeviceID int
+	RollMin        float32
+	RollMax        float32
+	PitchMin       float32
+	PitchMax       float32
+	YawMin         float32
+	YawMax         float32
+}
+
+// GimbalManagerStatus is the status of a gimbal manager (GIMBAL_MANAGER_STATUS).
+type GimbalManagerStatus struct {
+	Flags                       GimbalManagerFlags
+	GimbalDeviceID              int
+	PrimaryControlSystemID      byte
+	PrimaryControlComponentID   byte
+	SecondaryControlSystemID    byte
+	SecondaryControlComponentID byte
+}
+
+// GimbalAttitude is the attitude of a gimbal device (GIMBAL_DEVICE_ATTITUDE_STATUS).
+type GimbalAttitude struct {
+	// quaternion components, w, x, y, z
+	Q [4]float32
+	// angular velocity, in rad/s
+	AngularVelocity [3]float32
+}
+
+// GimbalClientConf allows to configure a GimbalClient.
+type GimbalClientConf struct {
+	// the node used to communicate.
+	// Its dialect must contain the COMMAND_* and GIMBAL_* messages.
+	Node *Node
+	// the gimbal manager.
+	Target Target
+
+	// (optional) the id of the gimbal device controlled by the manager.
+	// It defaults to zero, that means all devices.
+	GimbalDeviceID int
+	// (optional) the time to wait for a requested message.
+	// It defaults to 1 second.
+	Timeout time.Duration
+}
+
+// GimbalClient implements the client side of the gimbal protocol v2, that
+// allows to discover a gimbal manager, take control of it, set attitude or
+// rates and track its status.
+type GimbalClient struct {
+	conf           GimbalClientConf
+	msgSetAttitude msg.Message
+	msgSetPitchYaw msg.Message
+	mutex          sync.Mutex
+	status         *GimbalManagerStatus
+	attitude       *GimbalAttitude
+	frameService
+}
+
+// NewGimbalClient allocates a GimbalClient. See GimbalClientConf for the options.
+func NewGimbalClient(conf GimbalClientConf) (*GimbalClient, error) {
+	if conf.Node == nil {
+		return nil, fmt.Errorf("Node not provided")
+	}
+	if conf.Timeout == 0 {
+		conf.Timeout = 1 * time.Second
+	}
+
+	d := conf.Node.conf.Dialect
+
+	c := &GimbalClient{
+		conf:           conf,
+		msgSetAttitude: dialectMessage(d, 282, 123),
+		msgSetPitchYaw: dialectMessage(d, 287, 1),
+	}
+
+	if dialectMessage(d, 76, 152) == nil ||
+		dialectMessage(d, 77, 143) == nil ||
+		dialectMessage(d, 280, 70) == nil ||
+		dialectMessage(d, 281, 48) == nil ||
+		dialectMessage(d, 285, 137) == nil ||
+		c.msgSetAttitude == nil || c.msgSetPitchYaw == nil {
+		return nil, fmt.Errorf("dialect does not contain the gimbal protocol messages")
+	}
+
+	c.start(conf.Node, c.isStatus, 64, c.run)
+
+	return c, nil
+}
+
+// Close closes the client.
+func (c *GimbalClient) Close() {
+	c.close()
+}
+
+func (c *GimbalClient) matchesDevice(m msg.Message) bool {
+	return c.conf.GimbalDeviceID == 0 ||
+		int(messageGetInt(m, "GimbalDeviceId")) == c.conf.GimbalDeviceID
+}
+
+func (c *GimbalClient) isStatus(evt *EventFrame) bool {
+	switch evt.Message().GetID() {
+	case 281:
+		return c.conf.Target.matches(evt) && c.matchesDevice(evt.Message())
+
+	case 285:
+		// the attitude is sent by the gimbal device, that can be a different
+		// component than the manager.
+		return (c.conf.Target.Channel == nil || c.conf.Target.Channel == evt.Channel) &&
+			c.conf.Target.SystemID == evt.SystemID()
+	}
+	return false
+}
+
+func (c *GimbalClient) run() {
+	for {
+		select {
+		case evt := <-c.fw.frames:
+			m := evt.Message()
+
+			c.mutex.Lock()
+
+			if m.GetID() == 281 {
+				c.status = &GimbalManagerStatus{
+					Flags:                       GimbalManagerFlags(messageGetInt(m, "Flags")),
+					GimbalDeviceID:              int(messageGetInt(m, "GimbalDeviceId")),
+					PrimaryControlSystemID:      byte(messageGetInt(m, "PrimaryControlSysid")),
+					PrimaryControlComponentID:   byte(messageGetInt(m, "PrimaryControlCompid")),
+					SecondaryControlSystemID:    byte(messageGetInt(m, "SecondaryControlSysid")),
+					SecondaryControlComponentID: byte(messageGetInt(m, "SecondaryControlCompid")),
+				}
+			} else {
+				a := &GimbalAttitude{
+					AngularVelocity: [3]float32{
+						float32(messageGetFloat(m, "AngularVelocityX")),
+						float32(messageGetFloat(m, "AngularVelocityY")),
+						float32(messageGetFloat(m, "AngularVelocityZ")),
+					},
+				}
+				q := messageGet(m, "Q")
+				for i := range a.Q {
+					a.Q[i] = float32(q.Index(i).Float())
+				}
+				c.attitude = a
+			}
+
+			c.mutex.Unlock()
+
+		case <-c.ctx.Done():
+			return
+		}
+	}
+}
+
+// Information returns the capabilities of the gimbal manager.
+func (c *GimbalClient) Information(ctx context.Context) (*GimbalManagerInformation, error) {
+	evt, err := c.conf.Node.requestMessage(ctx, c.conf.Target, 280, func(evt *EventFrame) bool {
+		return c.matchesDevice(evt.Message())
+	}, c.conf.Timeout, float32(c.conf.GimbalDeviceID))
+	if err != nil {
+		return nil, err
+	}
+	m := evt.Message()
+
+	return &GimbalManagerInformation{
+		CapFlags:       uint32(messageGetInt(m, "CapFlags")),
+		GimbalDeviceID: int(messageGetInt(m, "GimbalDeviceId")),
+		RollMin:        float32(messageGetFloat(m, "RollMin")),
+		RollMax:        float32(messageGetFloat(m, "RollMax")),
+		PitchMin:       float32(messageGetFloat(m, "PitchMin")),
+		PitchMax:       float32(messageGetFloat(m, "PitchMax")),
+		YawMin:         float32(messageGetFloat(m, "YawMin")),
+		YawMax:         float32(messageGetFloat(m, "YawMax")),
+	}, nil
+}
+
+// Status returns the last status received from the gimbal manager,
+// or nil if no status has been received yet.
+func (c *GimbalClient) Status() *GimbalManagerStatus {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.status
+}
+
+// Attitude returns the last attitude received from the gimbal device,
+// or nil if no attitude has been received yet.
+func (c *GimbalClient) Attitude() *GimbalAttitude {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.attitude
+}
+
+// InControl returns whether the node is the primary controller of the gimbal,
+// according to the last status.
+func (c *GimbalClient) InControl() bool {
+	s := c.Status()
+	return s != nil &&
+		s.PrimaryControlSystemID == c.conf.Node.conf.OutSystemID &&
+		s.PrimaryControlComponentID == c.conf.Node.conf.OutComponentID
+}
+
+// TakeControl sets the node as the primary controller of the gimbal.
+func (c *GimbalClient) TakeControl(ctx context.Context) error {
+	return c.conf.Node.runCommand(ctx, c.conf.Target, 1001,
+		float32(c.conf.Node.conf.OutSystemID), float32(c.conf.Node.conf.OutComponentID),
+		-1, -1, 0, 0, float32(c.conf.GimbalDeviceID))
+}
+
+// ReleaseControl releases the control of the gimbal.
+func (c *GimbalClient) ReleaseControl(ctx context.Context) error {
+	return c.conf.Node.runCommand(ctx, c.conf.Target, 1001,
+		-3, -3, -1, -1, 0, 0, float32(c.conf.GimbalDeviceID))
+}
+
+func (c *GimbalClient) newSet(tpl msg.Message, flags GimbalManagerFlags) msg.Message {
+	m := newMessage(tpl)
+	messageSet(m, "TargetSystem", c.conf.Target.SystemID)
+	messageSet(m, "TargetComponent", c.conf.Target.ComponentID)
+	messageSet(m, "Flags", flags)
+	messageSet(m, "GimbalDeviceId", c.conf.GimbalDeviceID)
+	return m
+}
+
+// SetAttitude sets the attitude and the angular velocity of the gimbal.
+// Unused values can be set to NaN.
+func (c *GimbalClient) SetAttitude(q [4]float32, angularVelocity [3]float32, flags GimbalManagerFlags) {
+	m := c.newSet(c.msgSetAttitude, flags)
+	mq := messageGet(m, "Q")
+	for i, v := range q {
+		mq.Index(i).SetFloat(float64(v))
+	}
+	messageSet(m, "AngularVelocityX", angularVelocity[0])
+	messageSet(m, "AngularVelocityY", angularVelocity[1])
+	messageSet(m, "AngularVelocityZ", angularVelocity[2])
+	c.conf.Node.writeMessageToTarget(c.conf.Target, m)
+}
+
+// SetPitchYaw sets pitch and yaw angles (in radians) and rates (in rad/s)
+// of the gimbal. Unused values can be set to NaN.
+func (c *GimbalClient) SetPitchYaw(pitch float32, yaw float32, pitchRate float32,
+	yawRate float32, flags GimbalManagerFlags) {
+	m := c.newSet(c.msgSetPitchYaw, flags)
+	messageSet(m, "Pitch", pitch)
+	messageSet(m, "Yaw", yaw)
+	messageSet(m, "PitchRate", pitchRate)
+	messageSet(m, "YawRate", yawRate)
+	c.conf.Node.writeMessageToTarget(c.conf.Target, m)
+}
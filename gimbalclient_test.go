@@ -0,0 +1,106 @@
+package gomavlib
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+)
+
+func TestGimbalClient(t *testing.T) {
+	node1, node2 := newTestNodePair(t, common.Dialect)
+	defer node1.Close()
+	defer node2.Close()
+
+	go func() {
+		for range node1.Events() {
+		}
+	}()
+
+	setpoints := make(chan *common.MessageGimbalManagerSetPitchyaw, 1)
+
+	// a gimbal manager
+	go func() {
+		for evt := range node2.Events() {
+			fr, ok := evt.(*EventFrame)
+			if !ok {
+				continue
+			}
+
+			switch m := fr.Message().(type) {
+			case *common.MessageCommandLong:
+				node2.WriteMessageAll(&common.MessageCommandAck{
+					Command: m.Command,
+					Result:  0,
+				})
+
+				switch m.Command {
+				case 512:
+					node2.WriteMessageAll(&common.MessageGimbalManagerInformation{
+						CapFlags:       32 | 256,
+						GimbalDeviceId: 1,
+						PitchMin:       -1.5,
+						PitchMax:       0.5,
+					})
+
+				case 1001:
+					node2.WriteMessageAll(&common.MessageGimbalManagerStatus{
+						GimbalDeviceId:       1,
+						PrimaryControlSysid:  uint8(m.Param1),
+						PrimaryControlCompid: uint8(m.Param2),
+					})
+					node2.WriteMessageAll(&common.MessageGimbalDeviceAttitudeStatus{
+						Q: [4]float32{1, 0, 0, 0},
+					})
+				}
+
+			case *common.MessageGimbalManagerSetPitchyaw:
+				setpoints <- m
+			}
+		}
+	}()
+
+	c, err := NewGimbalClient(GimbalClientConf{
+		Node: node1,
+		Target: Target{
+			SystemID:    11,
+			ComponentID: 1,
+		},
+		GimbalDeviceID: 1,
+	})
+	require.NoError(t, err)
+	defer c.Close()
+
+	info, err := c.Information(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, &GimbalManagerInformation{
+		CapFlags:       32 | 256,
+		GimbalDeviceID: 1,
+		PitchMin:       -1.5,
+		PitchMax:       0.5,
+	}, info)
+
+	require.Equal(t, false, c.InControl())
+
+	err = c.TakeControl(context.Background())
+	require.NoError(t, err)
+
+	for c.Status() == nil || c.Attitude() == nil {
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.Equal(t, true, c.InControl())
+	require.Equal(t, [4]float32{1, 0, 0, 0}, c.Attitude().Q)
+
+	nan := float32(math.NaN())
+	c.SetPitchYaw(-0.5, 0.2, nan, nan, GimbalManagerFlagYawLock)
+
+	sp := <-setpoints
+	require.Equal(t, uint8(1), sp.GimbalDeviceId)
+	require.Equal(t, common.GIMBAL_MANAGER_FLAGS(16), sp.Flags)
+	require.Equal(t, float32(-0.5), sp.Pitch)
+	require.Equal(t, float32(0.2), sp.Yaw)
+}
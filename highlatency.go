@@ -0,0 +1,146 @@
+package gomavlib
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+// HighLatencyState is the state of a vehicle that is condensed into a
+// HIGH_LATENCY2 message, in order to be sent through high latency links,
+// like satellite links.
+type HighLatencyState struct {
+	// milliseconds since boot or Unix epoch
+	Timestamp uint32
+	// MAV_TYPE
+	Type int
+	// MAV_AUTOPILOT
+	Autopilot int
+	// autopilot-specific flags
+	CustomMode uint16
+	// degrees
+	Latitude float64
+	// degrees
+	Longitude float64
+	// meters above mean sea level
+	Altitude float64
+	// meters
+	TargetAltitude float64
+	// degrees
+	Heading float64
+	// degrees
+	TargetHeading float64
+	// meters
+	TargetDistance float64
+	// percent
+	Throttle int
+	// m/s
+	Airspeed float64
+	// m/s
+	AirspeedSetpoint float64
+	// m/s
+	Groundspeed float64
+	// m/s
+	Windspeed float64
+	// degrees
+	WindHeading float64
+	// meters
+	Eph float64
+	// meters
+	Epv float64
+	// celsius
+	TemperatureAir int
+	// m/s
+	ClimbRate float64
+	// percent, -1 if not provided
+	Battery int
+	// current waypoint
+	WaypointNumber int
+	// HL_FAILURE_FLAG
+	FailureFlags uint16
+	// custom payload
+	Custom [3]int8
+}
+
+func highLatencyClamp(v float64, min float64, max float64) float64 {
+	v = math.Round(v)
+	if v < min {
+		return min
+	}
+	if v > max {
+		return max
+	}
+	return v
+}
+
+func highLatencyEncode(tpl msg.Message, s *HighLatencyState) msg.Message {
+	m := newMessage(tpl)
+	messageSet(m, "Timestamp", s.Timestamp)
+	messageSet(m, "Type", s.Type)
+	messageSet(m, "Autopilot", s.Autopilot)
+	messageSet(m, "CustomMode", s.CustomMode)
+	messageSet(m, "Latitude", highLatencyClamp(s.Latitude*1e7, math.MinInt32, math.MaxInt32))
+	messageSet(m, "Longitude", highLatencyClamp(s.Longitude*1e7, math.MinInt32, math.MaxInt32))
+	messageSet(m, "Altitude", highLatencyClamp(s.Altitude, math.MinInt16, math.MaxInt16))
+	messageSet(m, "TargetAltitude", highLatencyClamp(s.TargetAltitude, math.MinInt16, math.MaxInt16))
+	messageSet(m, "Heading", highLatencyClamp(math.Mod(s.Heading+360, 360)/2, 0, 179))
+	messageSet(m, "TargetHeading", highLatencyClamp(math.Mod(s.TargetHeading+360, 360)/2, 0, 179))
+	messageSet(m, "TargetDistance", highLatencyClamp(s.TargetDistance/10, 0, math.MaxUint16))
+	messageSet(m, "Throttle", highLatencyClamp(float64(s.Throttle), 0, math.MaxUint8))
+	messageSet(m, "Airspeed", highLatencyClamp(s.Airspeed*5, 0, math.MaxUint8))
+	messageSet(m, "AirspeedSp", highLatencyClamp(s.AirspeedSetpoint*5, 0, math.MaxUint8))
+	messageSet(m, "Groundspeed", highLatencyClamp(s.Groundspeed*5, 0, math.MaxUint8))
+	messageSet(m, "Windspeed", highLatencyClamp(s.Windspeed*5, 0, math.MaxUint8))
+	messageSet(m, "WindHeading", highLatencyClamp(math.Mod(s.WindHeading+360, 360)/2, 0, 179))
+	messageSet(m, "Eph", highLatencyClamp(s.Eph*10, 0, math.MaxUint8))
+	messageSet(m, "Epv", highLatencyClamp(s.Epv*10, 0, math.MaxUint8))
+	messageSet(m, "TemperatureAir", highLatencyClamp(float64(s.TemperatureAir), math.MinInt8, math.MaxInt8))
+	messageSet(m, "ClimbRate", highLatencyClamp(s.ClimbRate*10, math.MinInt8, math.MaxInt8))
+	messageSet(m, "Battery", highLatencyClamp(float64(s.Battery), -1, 100))
+	messageSet(m, "WpNum", highLatencyClamp(float64(s.WaypointNumber), 0, math.MaxUint16))
+	messageSet(m, "FailureFlags", s.FailureFlags)
+	messageSet(m, "Custom0", s.Custom[0])
+	messageSet(m, "Custom1", s.Custom[1])
+	messageSet(m, "Custom2", s.Custom[2])
+	return m
+}
+
+// HighLatencyDecode expands a HIGH_LATENCY2 message into the vehicle state.
+func HighLatencyDecode(m msg.Message) (*HighLatencyState, error) {
+	if m.GetID() != 235 || !messageGet(m, "WpNum").IsValid() {
+		return nil, fmt.Errorf("message is not a HIGH_LATENCY2 message")
+	}
+
+	return &HighLatencyState{
+		Timestamp:        uint32(messageGetInt(m, "Timestamp")),
+		Type:             int(messageGetInt(m, "Type")),
+		Autopilot:        int(messageGetInt(m, "Autopilot")),
+		CustomMode:       uint16(messageGetInt(m, "CustomMode")),
+		Latitude:         float64(messageGetInt(m, "Latitude")) / 1e7,
+		Longitude:        float64(messageGetInt(m, "Longitude")) / 1e7,
+		Altitude:         float64(messageGetInt(m, "Altitude")),
+		TargetAltitude:   float64(messageGetInt(m, "TargetAltitude")),
+		Heading:          float64(messageGetInt(m, "Heading")) * 2,
+		TargetHeading:    float64(messageGetInt(m, "TargetHeading")) * 2,
+		TargetDistance:   float64(messageGetInt(m, "TargetDistance")) * 10,
+		Throttle:         int(messageGetInt(m, "Throttle")),
+		Airspeed:         float64(messageGetInt(m, "Airspeed")) / 5,
+		AirspeedSetpoint: float64(messageGetInt(m, "AirspeedSp")) / 5,
+		Groundspeed:      float64(messageGetInt(m, "Groundspeed")) / 5,
+		Windspeed:        float64(messageGetInt(m, "Windspeed")) / 5,
+		WindHeading:      float64(messageGetInt(m, "WindHeading")) * 2,
+		Eph:              float64(messageGetInt(m, "Eph")) / 10,
+		Epv:              float64(messageGetInt(m, "Epv")) / 10,
+		TemperatureAir:   int(messageGetInt(m, "TemperatureAir")),
+		ClimbRate:        float64(messageGetInt(m, "ClimbRate")) / 10,
+		Battery:          int(messageGetInt(m, "Battery")),
+		WaypointNumber:   int(messageGetInt(m, "WpNum")),
+		FailureFlags:     uint16(messageGetInt(m, "FailureFlags")),
+		Custom: [3]int8{
+			int8(messageGetInt(m, "Custom0")),
+			int8(messageGetInt(m, "Custom1")),
+			int8(messageGetInt(m, "Custom2")),
+		},
+	}, nil
+}
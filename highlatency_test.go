@@ -0,0 +1,130 @@
+package gomavlib
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+)
+
+func TestNodeHighLatency(t *testing.T) {
+	state := &HighLatencyState{
+		Timestamp:        1234,
+		Type:             2,
+		Autopilot:        12,
+		CustomMode:       3,
+		Latitude:         45.1234567,
+		Longitude:        -7.7654321,
+		Altitude:         120,
+		TargetAltitude:   150,
+		Heading:          90,
+		TargetHeading:    180,
+		TargetDistance:   2500,
+		Throttle:         60,
+		Airspeed:         15.2,
+		AirspeedSetpoint: 16,
+		Groundspeed:      14.4,
+		Windspeed:        3.2,
+		WindHeading:      270,
+		Eph:              1.5,
+		Epv:              2.5,
+		TemperatureAir:   21,
+		ClimbRate:        -1.2,
+		Battery:          80,
+		WaypointNumber:   4,
+		FailureFlags:     8,
+		Custom:           [3]int8{1, -2, 3},
+	}
+
+	l1 := newTestPipe()
+	l2 := newTestPipe()
+
+	vehicle, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 10,
+		Endpoints: []EndpointConf{
+			EndpointHighLatency{EndpointCustom{&testEndpoint{l1, l2}}},
+		},
+		HeartbeatPeriod:     10 * time.Millisecond,
+		HighLatencyProvider: func() *HighLatencyState { return state },
+		HighLatencyPeriod:   100 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	defer vehicle.Close()
+
+	gcs, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 11,
+		Endpoints: []EndpointConf{
+			EndpointCustom{ReadWriteCloser: &testEndpoint{l2, l1}},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer gcs.Close()
+
+	go func() {
+		for range vehicle.Events() {
+		}
+	}()
+
+	evt := <-gcs.Events()
+	require.IsType(t, &EventChannelOpen{}, evt)
+
+	// other messages are suppressed.
+	// writes block until the frame is written into the pipe, therefore they
+	// must be performed while the events of the GCS are read.
+	go vehicle.WriteMessageAll(&common.MessageRequestDataStream{})
+
+	for evt := range gcs.Events() {
+		fr, ok := evt.(*EventFrame)
+		if !ok {
+			continue
+		}
+
+		// heartbeats are let through
+		if fr.Message().GetID() == 0 {
+			continue
+		}
+
+		require.Equal(t, uint32(235), fr.Message().GetID())
+
+		dec, err := HighLatencyDecode(fr.Message())
+		require.NoError(t, err)
+		require.Equal(t, &HighLatencyState{
+			Timestamp:        1234,
+			Type:             2,
+			Autopilot:        12,
+			CustomMode:       3,
+			Latitude:         45.1234567,
+			Longitude:        -7.7654321,
+			Altitude:         120,
+			TargetAltitude:   150,
+			Heading:          90,
+			TargetHeading:    180,
+			TargetDistance:   2500,
+			Throttle:         60,
+			Airspeed:         15.2,
+			AirspeedSetpoint: 16,
+			Groundspeed:      14.4,
+			Windspeed:        3.2,
+			WindHeading:      270,
+			Eph:              1.5,
+			Epv:              2.5,
+			TemperatureAir:   21,
+			ClimbRate:        -1.2,
+			Battery:          80,
+			WaypointNumber:   4,
+			FailureFlags:     8,
+			Custom:           [3]int8{1, -2, 3},
+		}, dec)
+		break
+	}
+
+	_, err = HighLatencyDecode(&common.MessageHeartbeat{})
+	require.Error(t, err)
+}
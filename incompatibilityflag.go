@@ -0,0 +1,16 @@
+package gomavlib
+
+// IncompatibilityFlagPolicy is the policy applied to received V2 frames with
+// incompatibility flags that are not understood.
+type IncompatibilityFlagPolicy int
+
+const (
+	// IncompatibilityFlagPolicyReject discards frames, as recommended by the
+	// Mavlink specification, and notifies them with an EventParseError.
+	IncompatibilityFlagPolicyReject IncompatibilityFlagPolicy = iota
+
+	// IncompatibilityFlagPolicyEvent emits frames with an
+	// EventUnknownIncompatibilityFlag, assuming that their layout is the
+	// standard one, in order to allow applications to handle them.
+	IncompatibilityFlagPolicyEvent
+)
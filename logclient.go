@@ -0,0 +1,254 @@
+package gomavlib
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+const (
+	logDataSize = 90
+)
+
+// LogEntry is a log stored by a remote component.
+type LogEntry struct {
+	// id of the log
+	ID int
+	// size of the log, in bytes
+	Size int64
+	// creation time of the log. It is zero if unknown.
+	Time time.Time
+}
+
+// LogClientConf allows to configure a LogClient.
+type LogClientConf struct {
+	// the node used to communicate.
+	// Its dialect must contain the LOG_* messages.
+	Node *Node
+	// the component whose logs are listed and downloaded.
+	Target Target
+
+	// (optional) the time to wait for a response before retrying.
+	// It defaults to 1 second.
+	Timeout time.Duration
+	// (optional) the maximum number of consecutive retries. It defaults to 5.
+	Retries int
+}
+
+// LogClient implements the client side of the log transfer protocol, that
+// allows to list, download and erase the logs of a remote component.
+type LogClient struct {
+	conf           LogClientConf
+	msgRequestList msg.Message
+	msgRequestData msg.Message
+	msgErase       msg.Message
+	msgRequestEnd  msg.Message
+}
+
+// NewLogClient allocates a LogClient. See LogClientConf for the options.
+func NewLogClient(conf LogClientConf) (*LogClient, error) {
+	if conf.Node == nil {
+		return nil, fmt.Errorf("Node not provided")
+	}
+	if conf.Timeout == 0 {
+		conf.Timeout = 1 * time.Second
+	}
+	if conf.Retries == 0 {
+		conf.Retries = 5
+	}
+
+	d := conf.Node.conf.Dialect
+
+	c := &LogClient{
+		conf:           conf,
+		msgRequestList: dialectMessage(d, 117, 128),
+		msgRequestData: dialectMessage(d, 119, 116),
+		msgErase:       dialectMessage(d, 121, 237),
+		msgRequestEnd:  dialectMessage(d, 122, 203),
+	}
+
+	if c.msgRequestList == nil || dialectMessage(d, 118, 56) == nil ||
+		c.msgRequestData == nil || dialectMessage(d, 120, 134) == nil ||
+		c.msgErase == nil || c.msgRequestEnd == nil {
+		return nil, fmt.Errorf("dialect does not contain the log protocol messages")
+	}
+
+	return c, nil
+}
+
+func (c *LogClient) new(tpl msg.Message) msg.Message {
+	m := newMessage(tpl)
+	messageSet(m, "TargetSystem", c.conf.Target.SystemID)
+	messageSet(m, "TargetComponent", c.conf.Target.ComponentID)
+	return m
+}
+
+func (c *LogClient) writeRequestList() {
+	m := c.new(c.msgRequestList)
+	messageSet(m, "Start", 0)
+	messageSet(m, "End", 0xFFFF)
+	c.conf.Node.writeMessageToTarget(c.conf.Target, m)
+}
+
+func (c *LogClient) writeRequestData(id int, offset int64, count int64) {
+	m := c.new(c.msgRequestData)
+	messageSet(m, "Id", id)
+	messageSet(m, "Ofs", offset)
+	messageSet(m, "Count", count)
+	c.conf.Node.writeMessageToTarget(c.conf.Target, m)
+}
+
+// List returns the logs stored by the component, sorted by id.
+func (c *LogClient) List(ctx context.Context) ([]*LogEntry, error) {
+	fw := c.conf.Node.nodeWaiters.add(func(evt *EventFrame) bool {
+		return evt.Message().GetID() == 118 && c.conf.Target.matches(evt)
+	}, 256)
+	defer c.conf.Node.nodeWaiters.remove(fw)
+
+	entries := make(map[int]*LogEntry)
+	retries := 0
+
+	c.writeRequestList()
+
+	for {
+		timerC, stopTimer := c.conf.Node.conf.Clock.NewTimer(c.conf.Timeout)
+
+		select {
+		case evt := <-fw.frames:
+			stopTimer()
+			m := evt.Message()
+
+			num := int(messageGetInt(m, "NumLogs"))
+			if num == 0 {
+				return []*LogEntry{}, nil
+			}
+
+			e := &LogEntry{
+				ID:   int(messageGetInt(m, "Id")),
+				Size: messageGetInt(m, "Size"),
+			}
+			if t := messageGetInt(m, "TimeUtc"); t != 0 {
+				e.Time = time.Unix(t, 0)
+			}
+			entries[e.ID] = e
+			retries = 0
+
+			if len(entries) >= num {
+				ret := make([]*LogEntry, 0, len(entries))
+				for _, e := range entries {
+					ret = append(ret, e)
+				}
+				sort.Slice(ret, func(i, j int) bool {
+					return ret[i].ID < ret[j].ID
+				})
+				return ret, nil
+			}
+
+		case <-timerC:
+			if retries >= c.conf.Retries {
+				return nil, fmt.Errorf("timeout")
+			}
+			retries++
+			c.writeRequestList()
+
+		case <-ctx.Done():
+			stopTimer()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// Download downloads a log and writes its content into w, starting from
+// the given offset. Missing parts of the log are requested again.
+// An interrupted download can be resumed by setting offset to the number of
+// bytes already written.
+func (c *LogClient) Download(ctx context.Context, e *LogEntry, offset int64, w io.WriterAt) error {
+	if offset >= e.Size {
+		return nil
+	}
+
+	fw := c.conf.Node.nodeWaiters.add(func(evt *EventFrame) bool {
+		return evt.Message().GetID() == 120 && c.conf.Target.matches(evt) &&
+			int(messageGetInt(evt.Message(), "Id")) == e.ID
+	}, 1024)
+	defer c.conf.Node.nodeWaiters.remove(fw)
+
+	// the log is split into chunks that are delivered by LOG_DATA messages
+	chunkCount := int((e.Size - offset + logDataSize - 1) / logDataSize)
+	received := make([]bool, chunkCount)
+	missing := chunkCount
+	retries := 0
+
+	c.writeRequestData(e.ID, offset, e.Size-offset)
+
+	for missing > 0 {
+		timerC, stopTimer := c.conf.Node.conf.Clock.NewTimer(c.conf.Timeout)
+
+		select {
+		case evt := <-fw.frames:
+			stopTimer()
+			m := evt.Message()
+
+			ofs := messageGetInt(m, "Ofs")
+			count := int(messageGetInt(m, "Count"))
+			if count == 0 || ofs < offset || ((ofs-offset)%logDataSize) != 0 {
+				continue
+			}
+
+			i := int((ofs - offset) / logDataSize)
+			if i >= chunkCount || received[i] {
+				continue
+			}
+
+			_, err := w.WriteAt(messageGetBytes(m, "Data", count), ofs)
+			if err != nil {
+				return err
+			}
+
+			received[i] = true
+			missing--
+			retries = 0
+
+		case <-timerC:
+			if retries >= c.conf.Retries {
+				return fmt.Errorf("timeout")
+			}
+			retries++
+
+			// request the first gap
+			start := 0
+			for received[start] {
+				start++
+			}
+			end := start
+			for end < chunkCount && !received[end] {
+				end++
+			}
+
+			gapOffset := offset + int64(start)*logDataSize
+			gapEnd := offset + int64(end)*logDataSize
+			if gapEnd > e.Size {
+				gapEnd = e.Size
+			}
+			c.writeRequestData(e.ID, gapOffset, gapEnd-gapOffset)
+
+		case <-ctx.Done():
+			stopTimer()
+			return ctx.Err()
+		}
+	}
+
+	c.conf.Node.writeMessageToTarget(c.conf.Target, c.new(c.msgRequestEnd))
+
+	return nil
+}
+
+// Erase erases all the logs of the component.
+// The protocol does not provide any acknowledgement.
+func (c *LogClient) Erase() {
+	c.conf.Node.writeMessageToTarget(c.conf.Target, c.new(c.msgErase))
+}
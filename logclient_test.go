@@ -0,0 +1,107 @@
+package gomavlib
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+)
+
+type testWriterAt []byte
+
+func (w testWriterAt) WriteAt(p []byte, off int64) (int, error) {
+	return copy(w[off:], p), nil
+}
+
+func TestLogClient(t *testing.T) {
+	node1, node2 := newTestNodePair(t, common.Dialect)
+	defer node1.Close()
+	defer node2.Close()
+
+	go func() {
+		for range node1.Events() {
+		}
+	}()
+
+	content := make([]byte, 1000)
+	for i := range content {
+		content[i] = byte(i)
+	}
+
+	ended := make(chan struct{}, 2)
+
+	// an autopilot that loses the second chunk of the first transfer
+	go func() {
+		first := true
+
+		for evt := range node2.Events() {
+			fr, ok := evt.(*EventFrame)
+			if !ok {
+				continue
+			}
+
+			switch m := fr.Message().(type) {
+			case *common.MessageLogRequestList:
+				node2.WriteMessageAll(&common.MessageLogEntry{
+					Id: 1, NumLogs: 2, LastLogNum: 2, TimeUtc: 1600000000, Size: 1000,
+				})
+				node2.WriteMessageAll(&common.MessageLogEntry{
+					Id: 2, NumLogs: 2, LastLogNum: 2, Size: 10,
+				})
+
+			case *common.MessageLogRequestData:
+				end := int(m.Ofs + m.Count)
+				if end > len(content) {
+					end = len(content)
+				}
+
+				for ofs := int(m.Ofs); ofs < end; ofs += 90 {
+					if first && ofs == 90 {
+						first = false
+						continue
+					}
+
+					res := &common.MessageLogData{Id: m.Id, Ofs: uint32(ofs)}
+					res.Count = uint8(copy(res.Data[:], content[ofs:end]))
+					node2.WriteMessageAll(res)
+				}
+
+			case *common.MessageLogRequestEnd:
+				ended <- struct{}{}
+			}
+		}
+	}()
+
+	c, err := NewLogClient(LogClientConf{
+		Node: node1,
+		Target: Target{
+			SystemID:    11,
+			ComponentID: 1,
+		},
+		Timeout: 100 * time.Millisecond,
+	})
+	require.NoError(t, err)
+
+	entries, err := c.List(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []*LogEntry{
+		{ID: 1, Size: 1000, Time: time.Unix(1600000000, 0)},
+		{ID: 2, Size: 10},
+	}, entries)
+
+	buf := make(testWriterAt, 1000)
+	err = c.Download(context.Background(), entries[0], 0, buf)
+	require.NoError(t, err)
+	require.Equal(t, content, []byte(buf))
+	<-ended
+
+	// resume
+	buf = make(testWriterAt, 1000)
+	err = c.Download(context.Background(), entries[0], 500, buf)
+	require.NoError(t, err)
+	require.Equal(t, make([]byte, 500), []byte(buf[:500]))
+	require.Equal(t, content[500:], []byte(buf[500:]))
+}
@@ -0,0 +1,240 @@
+package gomavlib
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+// special message intervals.
+const (
+	// MessageIntervalDefault restores the default interval of a message.
+	MessageIntervalDefault time.Duration = 0
+	// MessageIntervalDisabled stops the emission of a message.
+	MessageIntervalDisabled time.Duration = -1
+)
+
+// data streams (MAV_DATA_STREAM) of old Ardupilot versions, that contain
+// the given message.
+// https://github.com/ArduPilot/ardupilot/blob/master/ArduCopter/GCS_Mavlink.cpp
+var messageIntervalDataStreams = map[uint32]int{
+	27:  1,  // RAW_IMU
+	29:  1,  // SCALED_PRESSURE
+	116: 1,  // SCALED_IMU2
+	1:   2,  // SYS_STATUS
+	24:  2,  // GPS_RAW_INT
+	42:  2,  // MISSION_CURRENT
+	62:  2,  // NAV_CONTROLLER_OUTPUT
+	125: 2,  // POWER_STATUS
+	35:  3,  // RC_CHANNELS_RAW
+	36:  3,  // SERVO_OUTPUT_RAW
+	65:  3,  // RC_CHANNELS
+	32:  6,  // LOCAL_POSITION_NED
+	33:  6,  // GLOBAL_POSITION_INT
+	30:  10, // ATTITUDE
+	74:  11, // VFR_HUD
+	2:   12, // SYSTEM_TIME
+	136: 12, // TERRAIN_REPORT
+	147: 12, // BATTERY_STATUS
+	241: 12, // VIBRATION
+}
+
+// MessageInterval is the interval of a message that has been granted by a
+// component.
+type MessageInterval struct {
+	MessageID uint32
+	// the interval between two messages, as reported by the component.
+	// A negative value means that the message is disabled.
+	Interval time.Duration
+	// whether the interval has been set through REQUEST_DATA_STREAM. In this
+	// case, the interval applies to all messages of the same data stream
+	// and, since the component does not report it, it is the requested one.
+	DataStream bool
+}
+
+// MessageIntervalClientConf allows to configure a MessageIntervalClient.
+type MessageIntervalClientConf struct {
+	// the node used to communicate.
+	// Its dialect must contain the COMMAND_* messages.
+	Node *Node
+	// the component whose message intervals are set.
+	Target Target
+
+	// (optional) the time to wait for a requested message.
+	// It defaults to 1 second.
+	Timeout time.Duration
+}
+
+// MessageIntervalClient allows to set and get the interval of the messages
+// emitted by a component, through MAV_CMD_SET_MESSAGE_INTERVAL, falling back
+// to REQUEST_DATA_STREAM in case of old Ardupilot versions.
+// It keeps track of the granted intervals.
+type MessageIntervalClient struct {
+	conf                 MessageIntervalClientConf
+	msgRequestDataStream msg.Message
+	mutex                sync.Mutex
+	intervals            map[uint32]MessageInterval
+}
+
+// NewMessageIntervalClient allocates a MessageIntervalClient.
+// See MessageIntervalClientConf for the options.
+func NewMessageIntervalClient(conf MessageIntervalClientConf) (*MessageIntervalClient, error) {
+	if conf.Node == nil {
+		return nil, fmt.Errorf("Node not provided")
+	}
+	if conf.Timeout == 0 {
+		conf.Timeout = 1 * time.Second
+	}
+
+	d := conf.Node.conf.Dialect
+	if dialectMessage(d, 76, 152) == nil ||
+		dialectMessage(d, 77, 143) == nil {
+		return nil, fmt.Errorf("dialect does not contain the command protocol messages")
+	}
+
+	return &MessageIntervalClient{
+		conf:                 conf,
+		msgRequestDataStream: dialectMessage(d, 66, 148),
+		intervals:            make(map[uint32]MessageInterval),
+	}, nil
+}
+
+func (c *MessageIntervalClient) store(mi MessageInterval) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.intervals[mi.MessageID] = mi
+}
+
+// Intervals returns the intervals that have been granted by the component.
+func (c *MessageIntervalClient) Intervals() []MessageInterval {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	ret := make([]MessageInterval, 0, len(c.intervals))
+	for _, mi := range c.intervals {
+		ret = append(ret, mi)
+	}
+	return ret
+}
+
+// Set sets the interval of a message. interval can also be
+// MessageIntervalDefault or MessageIntervalDisabled.
+// The interval granted by the component, that can differ from the requested
+// one, is then read with Get.
+func (c *MessageIntervalClient) Set(ctx context.Context, messageID uint32, interval time.Duration) error {
+	var param float32
+	switch {
+	case interval < 0:
+		param = -1
+	default:
+		param = float32(interval / time.Microsecond)
+	}
+
+	res, err := c.conf.Node.SendCommand(ctx, c.conf.Target, 511, float32(messageID), param)
+	if err != nil {
+		return err
+	}
+
+	switch res {
+	case CommandResultAccepted:
+		// the granted interval is stored by Get. Components that do not
+		// report intervals are still considered successful.
+		c.Get(ctx, messageID) //nolint:errcheck
+		return nil
+
+	// old Ardupilot versions do not support the command
+	case CommandResultUnsupported:
+		if ok := c.setDataStream(messageID, interval); ok {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("command 511: %s", res)
+}
+
+func (c *MessageIntervalClient) setDataStream(messageID uint32, interval time.Duration) bool {
+	stream, ok := messageIntervalDataStreams[messageID]
+	if !ok || c.msgRequestDataStream == nil {
+		return false
+	}
+
+	// the default interval is not supported
+	if interval == MessageIntervalDefault {
+		return false
+	}
+
+	rate := 0
+	startStop := 0
+	if interval > 0 {
+		rate = int(math.Round(float64(time.Second) / float64(interval)))
+		if rate < 1 {
+			rate = 1
+		}
+		startStop = 1
+	}
+
+	m := newMessage(c.msgRequestDataStream)
+	messageSet(m, "TargetSystem", c.conf.Target.SystemID)
+	messageSet(m, "TargetComponent", c.conf.Target.ComponentID)
+	messageSet(m, "ReqStreamId", stream)
+	messageSet(m, "ReqMessageRate", rate)
+	messageSet(m, "StartStop", startStop)
+	c.conf.Node.writeMessageToTarget(c.conf.Target, m)
+
+	c.store(MessageInterval{
+		MessageID:  messageID,
+		Interval:   interval,
+		DataStream: true,
+	})
+	return true
+}
+
+// Get returns the current interval of a message, through
+// MAV_CMD_GET_MESSAGE_INTERVAL. A negative value means that the message is
+// disabled, while zero means that the message is not available.
+func (c *MessageIntervalClient) Get(ctx context.Context, messageID uint32) (time.Duration, error) {
+	if dialectMessage(c.conf.Node.conf.Dialect, 244, 95) == nil {
+		return 0, fmt.Errorf("dialect does not contain MESSAGE_INTERVAL")
+	}
+
+	fw := c.conf.Node.nodeWaiters.add(func(evt *EventFrame) bool {
+		return evt.Message().GetID() == 244 && c.conf.Target.matches(evt) &&
+			uint32(messageGetInt(evt.Message(), "MessageId")) == messageID
+	}, 1)
+	defer c.conf.Node.nodeWaiters.remove(fw)
+
+	err := c.conf.Node.runCommand(ctx, c.conf.Target, 510, float32(messageID))
+	if err != nil {
+		return 0, err
+	}
+
+	timerC, stopTimer := c.conf.Node.conf.Clock.NewTimer(c.conf.Timeout)
+	defer stopTimer()
+
+	select {
+	case evt := <-fw.frames:
+		us := messageGetInt(evt.Message(), "IntervalUs")
+		interval := time.Duration(us) * time.Microsecond
+		if us < 0 {
+			interval = MessageIntervalDisabled
+		}
+
+		if us != 0 {
+			c.store(MessageInterval{
+				MessageID: messageID,
+				Interval:  interval,
+			})
+		}
+		return interval, nil
+
+	case <-timerC:
+		return 0, fmt.Errorf("timeout")
+
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	}
+}
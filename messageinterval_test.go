@@ -0,0 +1,113 @@
+package gomavlib
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+)
+
+func TestMessageIntervalClient(t *testing.T) {
+	node1, node2 := newTestNodePair(t, common.Dialect)
+	defer node1.Close()
+	defer node2.Close()
+
+	go func() {
+		for range node1.Events() {
+		}
+	}()
+
+	dataStreams := make(chan *common.MessageRequestDataStream, 1)
+
+	// a device that supports SET_MESSAGE_INTERVAL for ATTITUDE only,
+	// with a minimum interval of 200ms, and that denies GLOBAL_POSITION_INT
+	go func() {
+		intervals := make(map[uint16]int32)
+
+		for evt := range node2.Events() {
+			fr, ok := evt.(*EventFrame)
+			if !ok {
+				continue
+			}
+
+			switch m := fr.Message().(type) {
+			case *common.MessageCommandLong:
+				id := uint16(m.Param1)
+				res := common.MAV_RESULT(0)
+
+				switch {
+				case m.Command == 511 && id == 30:
+					intervals[id] = int32(m.Param2)
+					if intervals[id] < 200000 {
+						intervals[id] = 200000
+					}
+
+				case m.Command == 511 && id == 33:
+					res = 2 // MAV_RESULT_DENIED
+
+				case m.Command == 510:
+
+				default:
+					res = 3 // MAV_RESULT_UNSUPPORTED
+				}
+
+				node2.WriteMessageAll(&common.MessageCommandAck{
+					Command: m.Command,
+					Result:  res,
+				})
+
+				if m.Command == 510 {
+					node2.WriteMessageAll(&common.MessageMessageInterval{
+						MessageId:  id,
+						IntervalUs: intervals[id],
+					})
+				}
+
+			case *common.MessageRequestDataStream:
+				dataStreams <- m
+			}
+		}
+	}()
+
+	c, err := NewMessageIntervalClient(MessageIntervalClientConf{
+		Node: node1,
+		Target: Target{
+			SystemID:    11,
+			ComponentID: 1,
+		},
+	})
+	require.NoError(t, err)
+
+	err = c.Set(context.Background(), 30, 100*time.Millisecond)
+	require.NoError(t, err)
+
+	interval, err := c.Get(context.Background(), 30)
+	require.NoError(t, err)
+	require.Equal(t, 200*time.Millisecond, interval)
+
+	// only unsupported commands fall back to REQUEST_DATA_STREAM
+	err = c.Set(context.Background(), 33, 250*time.Millisecond)
+	require.EqualError(t, err, "command 511: denied")
+
+	err = c.Set(context.Background(), 74, 250*time.Millisecond)
+	require.NoError(t, err)
+	require.Equal(t, &common.MessageRequestDataStream{
+		TargetSystem:    11,
+		TargetComponent: 1,
+		ReqStreamId:     11,
+		ReqMessageRate:  4,
+		StartStop:       1,
+	}, <-dataStreams)
+
+	err = c.Set(context.Background(), 12345, 250*time.Millisecond)
+	require.EqualError(t, err, "command 511: unsupported")
+
+	intervals := c.Intervals()
+	require.ElementsMatch(t, []MessageInterval{
+		{MessageID: 30, Interval: 200 * time.Millisecond},
+		{MessageID: 74, Interval: 250 * time.Millisecond, DataStream: true},
+	}, intervals)
+}
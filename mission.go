@@ -0,0 +1,170 @@
+package gomavlib
+
+import (
+	"fmt"
+
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+// MissionType is the type of a mission (MAV_MISSION_TYPE).
+type MissionType int
+
+// mission types.
+const (
+	MissionTypeMission MissionType = 0
+	MissionTypeFence   MissionType = 1
+	MissionTypeRally   MissionType = 2
+)
+
+// String implements fmt.Stringer.
+func (t MissionType) String() string {
+	switch t {
+	case MissionTypeMission:
+		return "mission"
+	case MissionTypeFence:
+		return "fence"
+	case MissionTypeRally:
+		return "rally"
+	}
+	return "unknown"
+}
+
+// MissionResult is the result of a mission operation (MAV_MISSION_RESULT).
+type MissionResult int
+
+// mission results.
+const (
+	MissionResultAccepted         MissionResult = 0
+	MissionResultError            MissionResult = 1
+	MissionResultUnsupportedFrame MissionResult = 2
+	MissionResultUnsupported      MissionResult = 3
+	MissionResultNoSpace          MissionResult = 4
+	MissionResultInvalid          MissionResult = 5
+	MissionResultInvalidSequence  MissionResult = 13
+	MissionResultDenied           MissionResult = 14
+	MissionResultCancelled        MissionResult = 15
+)
+
+// String implements fmt.Stringer.
+func (r MissionResult) String() string {
+	switch r {
+	case MissionResultAccepted:
+		return "accepted"
+	case MissionResultError:
+		return "error"
+	case MissionResultUnsupportedFrame:
+		return "unsupported frame"
+	case MissionResultUnsupported:
+		return "unsupported"
+	case MissionResultNoSpace:
+		return "no space"
+	case MissionResultInvalid:
+		return "invalid"
+	case MissionResultInvalidSequence:
+		return "invalid sequence"
+	case MissionResultDenied:
+		return "denied"
+	case MissionResultCancelled:
+		return "cancelled"
+	}
+	return fmt.Sprintf("unknown (%d)", int(r))
+}
+
+// MissionItem is an item of a mission, fence or rally plan (MISSION_ITEM_INT).
+type MissionItem struct {
+	// the coordinate frame (MAV_FRAME)
+	Frame int
+	// the command (MAV_CMD)
+	Command int
+	// whether this is the current item
+	Current bool
+	// whether to continue to the next item automatically
+	Autocontinue bool
+	// command-dependent parameters
+	Param1 float32
+	Param2 float32
+	Param3 float32
+	Param4 float32
+	// latitude in degrees * 1e7, or a command-dependent parameter
+	X int32
+	// longitude in degrees * 1e7, or a command-dependent parameter
+	Y int32
+	// altitude in meters, or a command-dependent parameter
+	Z float32
+}
+
+// missionMessages contains the messages of the mission protocol.
+type missionMessages struct {
+	msgRequestList msg.Message
+	msgCount       msg.Message
+	msgRequestInt  msg.Message
+	msgItemInt     msg.Message
+	msgAck         msg.Message
+	msgClearAll    msg.Message
+}
+
+func newMissionMessages(n *Node) (*missionMessages, error) {
+	mm := &missionMessages{
+		msgRequestList: dialectMessage(n.conf.Dialect, 43, 132),
+		msgCount:       dialectMessage(n.conf.Dialect, 44, 221),
+		msgRequestInt:  dialectMessage(n.conf.Dialect, 51, 196),
+		msgItemInt:     dialectMessage(n.conf.Dialect, 73, 38),
+		msgAck:         dialectMessage(n.conf.Dialect, 47, 153),
+		msgClearAll:    dialectMessage(n.conf.Dialect, 45, 232),
+	}
+
+	if mm.msgRequestList == nil || mm.msgCount == nil || mm.msgRequestInt == nil ||
+		mm.msgItemInt == nil || mm.msgAck == nil || mm.msgClearAll == nil {
+		return nil, fmt.Errorf("dialect does not contain the mission protocol messages")
+	}
+
+	return mm, nil
+}
+
+func (mm *missionMessages) new(tpl msg.Message, t Target, typ MissionType) msg.Message {
+	m := newMessage(tpl)
+	messageSet(m, "TargetSystem", t.SystemID)
+	messageSet(m, "TargetComponent", t.ComponentID)
+	messageSet(m, "MissionType", int(typ))
+	return m
+}
+
+func (mm *missionMessages) encodeItem(t Target, typ MissionType, seq int, item *MissionItem) msg.Message {
+	m := mm.new(mm.msgItemInt, t, typ)
+	messageSet(m, "Seq", seq)
+	messageSet(m, "Frame", item.Frame)
+	messageSet(m, "Command", item.Command)
+	messageSet(m, "Current", boolToUint8(item.Current))
+	messageSet(m, "Autocontinue", boolToUint8(item.Autocontinue))
+	messageSet(m, "Param1", item.Param1)
+	messageSet(m, "Param2", item.Param2)
+	messageSet(m, "Param3", item.Param3)
+	messageSet(m, "Param4", item.Param4)
+	messageSet(m, "X", item.X)
+	messageSet(m, "Y", item.Y)
+	messageSet(m, "Z", item.Z)
+	return m
+}
+
+func (mm *missionMessages) decodeItem(m msg.Message) *MissionItem {
+	return &MissionItem{
+		Frame:        int(messageGetInt(m, "Frame")),
+		Command:      int(messageGetInt(m, "Command")),
+		Current:      messageGetInt(m, "Current") != 0,
+		Autocontinue: messageGetInt(m, "Autocontinue") != 0,
+		Param1:       float32(messageGetFloat(m, "Param1")),
+		Param2:       float32(messageGetFloat(m, "Param2")),
+		Param3:       float32(messageGetFloat(m, "Param3")),
+		Param4:       float32(messageGetFloat(m, "Param4")),
+		X:            int32(messageGetInt(m, "X")),
+		Y:            int32(messageGetInt(m, "Y")),
+		Z:            float32(messageGetFloat(m, "Z")),
+	}
+}
+
+func boolToUint8(v bool) uint8 {
+	if v {
+		return 1
+	}
+	return 0
+}
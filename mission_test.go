@@ -0,0 +1,158 @@
+package gomavlib
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+)
+
+var testMissionItems = []*MissionItem{
+	{Frame: 6, Command: 22, Current: true, Autocontinue: true, Z: 10},
+	{Frame: 6, Command: 16, Autocontinue: true, X: 450000000, Y: 90000000, Z: 20},
+	{Frame: 6, Command: 21, Autocontinue: true, X: 450000100, Y: 90000100},
+}
+
+func TestMissionClient(t *testing.T) {
+	node1, node2 := newTestNodePair(t, common.Dialect)
+	defer node1.Close()
+	defer node2.Close()
+
+	go func() {
+		for range node1.Events() {
+		}
+	}()
+
+	// a minimal vehicle that stores a single mission
+	go func() {
+		var stored []*common.MessageMissionItemInt
+		var count int
+
+		for evt := range node2.Events() {
+			fr, ok := evt.(*EventFrame)
+			if !ok {
+				continue
+			}
+
+			switch m := fr.Message().(type) {
+			case *common.MessageMissionCount:
+				stored = nil
+				count = int(m.Count)
+				// a stale acknowledgement of a previous transaction
+				node2.WriteMessageAll(&common.MessageMissionAck{
+					TargetSystem: 10, TargetComponent: 1,
+				})
+				node2.WriteMessageAll(&common.MessageMissionRequestInt{
+					TargetSystem: 10, TargetComponent: 1, Seq: 0,
+				})
+
+			case *common.MessageMissionItemInt:
+				stored = append(stored, m)
+				if len(stored) == count {
+					node2.WriteMessageAll(&common.MessageMissionAck{
+						TargetSystem: 10, TargetComponent: 1,
+					})
+				} else {
+					node2.WriteMessageAll(&common.MessageMissionRequestInt{
+						TargetSystem: 10, TargetComponent: 1, Seq: uint16(len(stored)),
+					})
+				}
+
+			case *common.MessageMissionRequestList:
+				node2.WriteMessageAll(&common.MessageMissionCount{
+					TargetSystem: 10, TargetComponent: 1, Count: uint16(len(stored)),
+				})
+
+			case *common.MessageMissionRequestInt:
+				node2.WriteMessageAll(stored[m.Seq])
+
+			case *common.MessageMissionClearAll:
+				stored = nil
+				node2.WriteMessageAll(&common.MessageMissionAck{
+					TargetSystem: 10, TargetComponent: 1,
+				})
+			}
+		}
+	}()
+
+	c, err := NewMissionClient(MissionClientConf{
+		Node: node1,
+		Target: Target{
+			SystemID:    11,
+			ComponentID: 1,
+		},
+	})
+	require.NoError(t, err)
+
+	err = c.Upload(context.Background(), MissionTypeMission, testMissionItems)
+	require.NoError(t, err)
+
+	items, err := c.Download(context.Background(), MissionTypeMission)
+	require.NoError(t, err)
+	require.Equal(t, testMissionItems, items)
+
+	err = c.Clear(context.Background(), MissionTypeMission)
+	require.NoError(t, err)
+
+	items, err = c.Download(context.Background(), MissionTypeMission)
+	require.NoError(t, err)
+	require.Equal(t, []*MissionItem{}, items)
+}
+
+func TestMissionServer(t *testing.T) {
+	node1, node2 := newTestNodePair(t, common.Dialect)
+	defer node1.Close()
+	defer node2.Close()
+
+	go func() {
+		for range node1.Events() {
+		}
+	}()
+	go func() {
+		for range node2.Events() {
+		}
+	}()
+
+	var uploaded []*MissionItem
+
+	s, err := NewMissionServer(MissionServerConf{
+		Node: node2,
+		OnUpload: func(typ MissionType, items []*MissionItem) MissionResult {
+			require.Equal(t, MissionTypeFence, typ)
+			uploaded = items
+			return MissionResultAccepted
+		},
+	})
+	require.NoError(t, err)
+	defer s.Close()
+
+	s.Set(MissionTypeMission, testMissionItems)
+
+	c, err := NewMissionClient(MissionClientConf{
+		Node: node1,
+		Target: Target{
+			SystemID:    11,
+			ComponentID: 1,
+		},
+	})
+	require.NoError(t, err)
+
+	items, err := c.Download(context.Background(), MissionTypeMission)
+	require.NoError(t, err)
+	require.Equal(t, testMissionItems, items)
+
+	err = c.Upload(context.Background(), MissionTypeFence, testMissionItems)
+	require.NoError(t, err)
+	require.Equal(t, testMissionItems, uploaded)
+	require.Equal(t, testMissionItems, s.Get(MissionTypeFence))
+
+	// the returned plan is a copy
+	s.Get(MissionTypeFence)[0].Command = 0
+	require.Equal(t, testMissionItems, s.Get(MissionTypeFence))
+
+	err = c.Clear(context.Background(), MissionTypeMission)
+	require.NoError(t, err)
+	require.Empty(t, s.Get(MissionTypeMission))
+}
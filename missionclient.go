@@ -0,0 +1,205 @@
+package gomavlib
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// MissionClientConf allows to configure a MissionClient.
+type MissionClientConf struct {
+	// the node used to communicate.
+	// Its dialect must contain the MISSION_* messages.
+	Node *Node
+	// the component that stores the plans.
+	Target Target
+
+	// (optional) the time to wait for a response before retrying.
+	// It defaults to 1.5 seconds.
+	Timeout time.Duration
+	// (optional) the maximum number of retries. It defaults to 5.
+	Retries int
+}
+
+// MissionClient implements the client side of the mission protocol, that
+// allows to upload and download mission, fence and rally plans.
+type MissionClient struct {
+	conf MissionClientConf
+	mm   *missionMessages
+}
+
+// NewMissionClient allocates a MissionClient. See MissionClientConf for the options.
+func NewMissionClient(conf MissionClientConf) (*MissionClient, error) {
+	if conf.Node == nil {
+		return nil, fmt.Errorf("Node not provided")
+	}
+	if conf.Timeout == 0 {
+		conf.Timeout = 1500 * time.Millisecond
+	}
+	if conf.Retries == 0 {
+		conf.Retries = 5
+	}
+
+	mm, err := newMissionMessages(conf.Node)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MissionClient{
+		conf: conf,
+		mm:   mm,
+	}, nil
+}
+
+func (c *MissionClient) isResponse(evt *EventFrame, id uint32, typ MissionType) bool {
+	return evt.Message().GetID() == id &&
+		c.conf.Target.matches(evt) &&
+		MissionType(messageGetInt(evt.Message(), "MissionType")) == typ
+}
+
+// Download downloads the plan of the given type.
+func (c *MissionClient) Download(ctx context.Context, typ MissionType) ([]*MissionItem, error) {
+	evt, err := c.conf.Node.request(ctx,
+		func(evt *EventFrame) bool {
+			return c.isResponse(evt, 44, typ)
+		},
+		func() {
+			c.conf.Node.writeMessageToTarget(c.conf.Target,
+				c.mm.new(c.mm.msgRequestList, c.conf.Target, typ))
+		},
+		c.conf.Timeout, c.conf.Retries)
+	if err != nil {
+		return nil, err
+	}
+
+	count := int(messageGetInt(evt.Message(), "Count"))
+	items := make([]*MissionItem, count)
+
+	for seq := 0; seq < count; seq++ {
+		evt, err := c.conf.Node.request(ctx,
+			func(evt *EventFrame) bool {
+				return c.isResponse(evt, 73, typ) &&
+					int(messageGetInt(evt.Message(), "Seq")) == seq
+			},
+			func() {
+				m := c.mm.new(c.mm.msgRequestInt, c.conf.Target, typ)
+				messageSet(m, "Seq", seq)
+				c.conf.Node.writeMessageToTarget(c.conf.Target, m)
+			},
+			c.conf.Timeout, c.conf.Retries)
+		if err != nil {
+			return nil, err
+		}
+
+		items[seq] = c.mm.decodeItem(evt.Message())
+	}
+
+	m := c.mm.new(c.mm.msgAck, c.conf.Target, typ)
+	messageSet(m, "Type", int(MissionResultAccepted))
+	c.conf.Node.writeMessageToTarget(c.conf.Target, m)
+
+	return items, nil
+}
+
+// Upload uploads a plan of the given type, replacing the existing one.
+func (c *MissionClient) Upload(ctx context.Context, typ MissionType, items []*MissionItem) error {
+	fw := c.conf.Node.nodeWaiters.add(func(evt *EventFrame) bool {
+		return c.isResponse(evt, 51, typ) || c.isResponse(evt, 47, typ)
+	}, 16)
+	defer c.conf.Node.nodeWaiters.remove(fw)
+
+	writeCount := func() {
+		m := c.mm.new(c.mm.msgCount, c.conf.Target, typ)
+		messageSet(m, "Count", len(items))
+		c.conf.Node.writeMessageToTarget(c.conf.Target, m)
+	}
+
+	writeItem := func(seq int) {
+		c.conf.Node.writeMessageToTarget(c.conf.Target,
+			c.mm.encodeItem(c.conf.Target, typ, seq, items[seq]))
+	}
+
+	writeCount()
+	lastSent := -1
+	retries := 0
+
+	for {
+		timerC, stopTimer := c.conf.Node.conf.Clock.NewTimer(c.conf.Timeout)
+
+		select {
+		case evt := <-fw.frames:
+			stopTimer()
+
+			m := evt.Message()
+			if m.GetID() == 47 { // MISSION_ACK
+				res := MissionResult(messageGetInt(m, "Type"))
+				switch {
+				case res == MissionResultAccepted:
+					// an acceptance received before all items have been sent
+					// belongs to a previous transaction
+					if lastSent != len(items)-1 {
+						continue
+					}
+
+					if typ == MissionTypeMission && c.conf.Node.nodeMission != nil {
+						c.conf.Node.nodeMission.setTotal(c.conf.Target, len(items))
+					}
+					return nil
+
+				case res == MissionResultInvalidSequence:
+					// the component is still waiting for an item
+					continue
+				}
+				return fmt.Errorf("upload rejected: %s", res)
+			}
+
+			// MISSION_REQUEST_INT
+			seq := int(messageGetInt(m, "Seq"))
+			if seq >= len(items) {
+				continue
+			}
+
+			writeItem(seq)
+			lastSent = seq
+			retries = 0
+
+		case <-timerC:
+			if retries >= c.conf.Retries {
+				return fmt.Errorf("timeout")
+			}
+			retries++
+
+			if lastSent < 0 {
+				writeCount()
+			} else {
+				writeItem(lastSent)
+			}
+
+		case <-ctx.Done():
+			stopTimer()
+			return ctx.Err()
+		}
+	}
+}
+
+// Clear removes the plan of the given type.
+func (c *MissionClient) Clear(ctx context.Context, typ MissionType) error {
+	evt, err := c.conf.Node.request(ctx,
+		func(evt *EventFrame) bool {
+			return c.isResponse(evt, 47, typ)
+		},
+		func() {
+			c.conf.Node.writeMessageToTarget(c.conf.Target,
+				c.mm.new(c.mm.msgClearAll, c.conf.Target, typ))
+		},
+		c.conf.Timeout, c.conf.Retries)
+	if err != nil {
+		return err
+	}
+
+	res := MissionResult(messageGetInt(evt.Message(), "Type"))
+	if res != MissionResultAccepted {
+		return fmt.Errorf("clear rejected: %s", res)
+	}
+	return nil
+}
@@ -0,0 +1,272 @@
+package gomavlib
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// MissionUploadFunc is the callback invoked when a remote component uploads
+// a plan. It returns the result that is sent to the component; if the result
+// is MissionResultAccepted, the plan is stored.
+type MissionUploadFunc func(typ MissionType, items []*MissionItem) MissionResult
+
+// MissionServerConf allows to configure a MissionServer.
+type MissionServerConf struct {
+	// the node used to communicate.
+	// Its dialect must contain the MISSION_* messages.
+	Node *Node
+
+	// (optional) callback invoked when a plan is uploaded.
+	// If nil, plans are always accepted.
+	OnUpload MissionUploadFunc
+	// (optional) the time to wait for an item before requesting it again.
+	// It defaults to 1.5 seconds.
+	Timeout time.Duration
+	// (optional) the maximum number of retries. It defaults to 5.
+	Retries int
+}
+
+type missionServerUpload struct {
+	source  Target
+	typ     MissionType
+	items   []*MissionItem
+	retries int
+}
+
+// MissionServer implements the server side of the mission protocol, that
+// allows remote components to upload and download mission, fence and rally
+// plans stored by the node.
+type MissionServer struct {
+	conf   MissionServerConf
+	mm     *missionMessages
+	mutex  sync.Mutex
+	plans  map[MissionType][]*MissionItem
+	upload *missionServerUpload
+	frameService
+}
+
+// NewMissionServer allocates a MissionServer. See MissionServerConf for the options.
+func NewMissionServer(conf MissionServerConf) (*MissionServer, error) {
+	if conf.Node == nil {
+		return nil, fmt.Errorf("Node not provided")
+	}
+	if conf.Timeout == 0 {
+		conf.Timeout = 1500 * time.Millisecond
+	}
+	if conf.Retries == 0 {
+		conf.Retries = 5
+	}
+
+	mm, err := newMissionMessages(conf.Node)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &MissionServer{
+		conf:  conf,
+		mm:    mm,
+		plans: make(map[MissionType][]*MissionItem),
+	}
+
+	s.start(conf.Node, s.isRequest, 256, s.run)
+
+	return s, nil
+}
+
+// Close stops the server.
+func (s *MissionServer) Close() {
+	s.close()
+}
+
+// Get returns a copy of the stored plan of the given type.
+func (s *MissionServer) Get(typ MissionType) []*MissionItem {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return missionItemsCopy(s.plans[typ])
+}
+
+// Set replaces the stored plan of the given type with a copy of items.
+func (s *MissionServer) Set(typ MissionType, items []*MissionItem) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.plans[typ] = missionItemsCopy(items)
+}
+
+func missionItemsCopy(items []*MissionItem) []*MissionItem {
+	if items == nil {
+		return nil
+	}
+
+	ret := make([]*MissionItem, len(items))
+	for i, it := range items {
+		cpy := *it
+		ret[i] = &cpy
+	}
+	return ret
+}
+
+func (s *MissionServer) isRequest(evt *EventFrame) bool {
+	switch evt.Message().GetID() {
+	case 43, 44, 45, 51, 73:
+	default:
+		return false
+	}
+
+	sysID := byte(messageGetInt(evt.Message(), "TargetSystem"))
+	compID := byte(messageGetInt(evt.Message(), "TargetComponent"))
+	return sysID == s.conf.Node.conf.OutSystemID &&
+		(compID == s.conf.Node.conf.OutComponentID || compID == 0)
+}
+
+func (s *MissionServer) writeAck(t Target, typ MissionType, res MissionResult) {
+	m := s.mm.new(s.mm.msgAck, t, typ)
+	messageSet(m, "Type", int(res))
+	s.conf.Node.writeMessageToTarget(t, m)
+}
+
+func (s *MissionServer) writeRequest(u *missionServerUpload) {
+	m := s.mm.new(s.mm.msgRequestInt, u.source, u.typ)
+	messageSet(m, "Seq", len(u.items))
+	s.conf.Node.writeMessageToTarget(u.source, m)
+}
+
+func (s *MissionServer) run() {
+	var timerStop func()
+	var timerC <-chan time.Time
+
+	stopTimer := func() {
+		if timerStop != nil {
+			timerStop()
+			timerStop = nil
+			timerC = nil
+		}
+	}
+	defer stopTimer()
+
+	startTimer := func() {
+		stopTimer()
+		timerC, timerStop = s.conf.Node.conf.Clock.NewTimer(s.conf.Timeout)
+	}
+
+	for {
+		select {
+		case evt := <-s.fw.frames:
+			if s.onRequest(evt) {
+				startTimer()
+			} else if s.upload == nil {
+				stopTimer()
+			}
+
+		case <-timerC:
+			timerStop = nil
+			timerC = nil
+
+			u := s.upload
+			if u == nil {
+				continue
+			}
+
+			if u.retries >= s.conf.Retries {
+				s.writeAck(u.source, u.typ, MissionResultCancelled)
+				s.upload = nil
+				continue
+			}
+			u.retries++
+
+			s.writeRequest(u)
+			startTimer()
+
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// onRequest processes a request and returns whether an item has been requested.
+func (s *MissionServer) onRequest(evt *EventFrame) bool {
+	m := evt.Message()
+	typ := MissionType(messageGetInt(m, "MissionType"))
+	source := Target{
+		Channel:     evt.Channel,
+		SystemID:    evt.SystemID(),
+		ComponentID: evt.ComponentID(),
+	}
+
+	switch m.GetID() {
+	case 43: // MISSION_REQUEST_LIST
+		res := s.mm.new(s.mm.msgCount, source, typ)
+		messageSet(res, "Count", len(s.Get(typ)))
+		s.conf.Node.writeMessageToTarget(source, res)
+
+	case 51: // MISSION_REQUEST_INT
+		seq := int(messageGetInt(m, "Seq"))
+		items := s.Get(typ)
+		if seq >= len(items) {
+			s.writeAck(source, typ, MissionResultInvalidSequence)
+			return false
+		}
+		s.conf.Node.writeMessageToTarget(source, s.mm.encodeItem(source, typ, seq, items[seq]))
+
+	case 45: // MISSION_CLEAR_ALL
+		s.Set(typ, nil)
+		s.writeAck(source, typ, MissionResultAccepted)
+
+	case 44: // MISSION_COUNT
+		count := int(messageGetInt(m, "Count"))
+		s.upload = &missionServerUpload{
+			source: source,
+			typ:    typ,
+			items:  make([]*MissionItem, 0, count),
+		}
+
+		if count == 0 {
+			s.completeUpload()
+			return false
+		}
+
+		s.writeRequest(s.upload)
+		return true
+
+	case 73: // MISSION_ITEM_INT
+		u := s.upload
+		if u == nil || u.typ != typ || u.source != source {
+			return false
+		}
+
+		// discard duplicates
+		if int(messageGetInt(m, "Seq")) != len(u.items) {
+			return false
+		}
+
+		u.items = append(u.items, s.mm.decodeItem(m))
+		u.retries = 0
+
+		if len(u.items) == cap(u.items) {
+			s.completeUpload()
+			return false
+		}
+
+		s.writeRequest(u)
+		return true
+	}
+
+	return false
+}
+
+func (s *MissionServer) completeUpload() {
+	u := s.upload
+	s.upload = nil
+
+	res := MissionResultAccepted
+	if s.conf.OnUpload != nil {
+		res = s.conf.OnUpload(u.typ, u.items)
+	}
+
+	if res == MissionResultAccepted {
+		s.Set(u.typ, u.items)
+	}
+
+	s.writeAck(u.source, u.typ, res)
+}
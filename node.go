@@ -45,6 +45,7 @@ package gomavlib
 import (
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/aler9/gomavlib/pkg/dialect"
@@ -60,16 +61,6 @@ const (
 	netWriteTimeout    = 10 * time.Second
 )
 
-type writeToReq struct {
-	ch   *Channel
-	what interface{}
-}
-
-type writeExceptReq struct {
-	except *Channel
-	what   interface{}
-}
-
 // NodeConf allows to configure a Node.
 type NodeConf struct {
 	// the endpoints with which this node will
@@ -79,13 +70,35 @@ type NodeConf struct {
 	// (optional) the dialect which contains the messages that will be encoded and decoded.
 	// If not provided, messages are decoded in the MessageRaw struct.
 	Dialect *dialect.Dialect
+	// (optional) the crc-extra of messages that are not in the dialect,
+	// indexed by message id. It allows to validate the checksum of frames
+	// whose message is emitted as MessageRaw, and to re-sign and re-sequence
+	// them, without the need of a full dialect. This is useful for routers,
+	// that validate frames but do not decode them.
+	CRCExtras map[uint32]byte
 
 	// (optional) the secret key used to validate incoming frames.
 	// Non signed frames are discarded, as well as frames with a version < 2.0.
 	InKey *frame.V2Key
+	// (optional) the maximum age of the signature timestamp of a frame with
+	// respect to the most recent frame of the same stream (link id, system id,
+	// component id). Older frames are rejected, since they may be replayed.
+	// It defaults to 10 seconds.
+	InSignatureWindow time.Duration
+
+	// (optional) discards frames whose message is not in the dialect and not
+	// in CRCExtras, instead of emitting them with a MessageRaw. Discarded frames are notified with
+	// an EventParseError.
+	DiscardUnknownMessages bool
+
+	// (optional) the policy applied to received V2 frames with incompatibility
+	// flags that are not understood. It defaults to
+	// IncompatibilityFlagPolicyReject.
+	InIncompatibilityFlagPolicy IncompatibilityFlagPolicy
 
 	// Mavlink version used to encode messages. See Version
-	// for the available options.
+	// for the available options. It can be overridden on single endpoints
+	// with EndpointVersion.
 	OutVersion Version
 	// the system id, added to every outgoing frame and used to identify this
 	// node in the network.
@@ -95,9 +108,62 @@ type NodeConf struct {
 	// (optional) the secret key used to sign outgoing frames.
 	// This feature requires a version >= 2.0.
 	OutKey *frame.V2Key
+	// (optional) the compatibility flags added to every outgoing V2 frame.
+	OutCompatibilityFlag byte
+	// (optional) signs again routed frames (WriteFrame*) with the key of each
+	// destination channel (OutKey or EndpointSignature), or removes their
+	// signature on channels without key, in order to route frames between
+	// links that use different keys. Adding or removing a signature requires
+	// the message to be in the dialect or in CRCExtras.
+	OutResign bool
+	// (optional) replaces the sequence id of routed frames (WriteFrame*) with
+	// the sequence id of each destination channel, in order to merge multiple
+	// sources into a single link without confusing receivers that track
+	// sequence ids per link. Since sequence ids are shared by all sources,
+	// receivers that track them per system, like Node with EventFrameLoss,
+	// detect gaps. Routed frames are not modified, therefore the EventFrame
+	// of a routed frame still contains its original sequence id. Only frames
+	// whose message is in the dialect or in CRCExtras are re-sequenced, and
+	// signed frames are re-sequenced only when OutResign is enabled.
+	OutResequence bool
+
+	// (optional) recycles EventFrames and their frames once they have been
+	// processed, in order to reduce allocations. When enabled,
+	// EventFrame.Release() must be called once an EventFrame is not needed
+	// anymore. Frames passed to WriteFrame*() are cloned, such that events can
+	// be released right after being routed. See EventFrame.Release.
+	EventFramePool bool
+
+	// (optional) the number of routines that validate and decode received
+	// frames in parallel. Frames of each channel are still emitted in the
+	// order they were received. By default, frames are decoded by the routine
+	// that reads each channel.
+	DecodeWorkers int
+
+	// (optional) the number of messages and frames that can be queued for
+	// each channel, in order to absorb bursts of writes. When the queue of a
+	// channel is full, writes wait for the channel. By default, writes wait
+	// for the channel to be ready.
+	WriteQueueSize int
+	// (optional) the IDs of messages that are written before the messages
+	// and frames queued with normal priority, including routed frames.
+	// For instance, HEARTBEAT (0) and COMMAND_ACK (77) messages can be
+	// prioritized over bulk traffic. Messages can be prioritized on single
+	// writes with WriteMessageHighPriorityTo and WriteMessageHighPriorityAll.
+	HighPriorityMessages []uint32
+
+	// (optional) functions that are called in order with every frame
+	// received from or written to a channel, and can pass, modify or drop it.
+	// See FrameInterceptor.
+	FrameInterceptors []FrameInterceptor
 
 	// (optional) disables the periodic sending of heartbeats to open channels.
+	// It can be disabled on single endpoints with EndpointHeartbeatDisable.
 	HeartbeatDisable bool
+	// (optional) sends heartbeats to a channel only after a heartbeat has
+	// been received from it, in order to avoid writing to passive listeners
+	// and broadcast sockets.
+	HeartbeatActivePeersOnly bool
 	// (optional) the period between heartbeats. It defaults to 5 seconds.
 	HeartbeatPeriod time.Duration
 	// (optional) the system type advertised by heartbeats.
@@ -106,31 +172,111 @@ type NodeConf struct {
 	// (optional) the autopilot type advertised by heartbeats.
 	// It defaults to MAV_AUTOPILOT_GENERIC
 	HeartbeatAutopilotType int
+	// (optional) the base mode advertised by heartbeats, that is a bitmask of
+	// MAV_MODE_FLAG. It can be changed at runtime with SetMode.
+	HeartbeatBaseMode int
+	// (optional) the autopilot-specific custom mode advertised by heartbeats.
+	// It can be changed at runtime with SetMode.
+	HeartbeatCustomMode uint32
+	// (optional) the system status advertised by heartbeats.
+	// It defaults to MAV_STATE_ACTIVE. It can be changed at runtime with
+	// SetSystemStatus.
+	HeartbeatSystemStatus int
+	// (optional) a function that is called before each heartbeat and returns
+	// the base mode, custom mode and system status to advertise. If it returns
+	// nil, the values set with SetMode and SetSystemStatus are advertised.
+	HeartbeatProvider func() *HeartbeatState
+
+	// (optional) a function that returns the general status of the system,
+	// that is periodically sent with SYS_STATUS messages and, if provided,
+	// EXTENDED_SYS_STATE messages. See SysStatus.
+	SysStatusProvider func() *SysStatus
+	// (optional) the period between SYS_STATUS messages. It defaults to 1 second.
+	SysStatusPeriod time.Duration
+
+	// (optional) track heartbeats of remote systems and emit
+	// EventSystemOnline and EventSystemOffline when they appear or disappear.
+	SystemTrackingEnable bool
+	// (optional) the time after which a remote system that has not sent
+	// heartbeats is considered offline. It defaults to 15 seconds.
+	SystemTimeout time.Duration
+
+	// (optional) track the mission progress of remote systems and emit
+	// EventMissionCurrent, EventMissionItemReached and EventMissionComplete.
+	// See MissionProgress.
+	MissionProgressEnable bool
 
 	// (optional) automatically request streams to detected Ardupilot devices,
 	// that need an explicit request in order to emit telemetry stream.
 	StreamRequestEnable bool
 	// (optional) the requested stream frequency in Hz. It defaults to 4.
 	StreamRequestFrequency int
+
+	// (optional) automatically answer TIMESYNC requests and periodically
+	// measure the clock offset of remote components and the round-trip time
+	// of channels. See TimesyncEstimates, ChannelStats and EventChannelLatency.
+	TimesyncEnable bool
+	// (optional) the period between TIMESYNC requests. It defaults to 10 seconds.
+	TimesyncPeriod time.Duration
+
+	// (optional) disables the automatic response to PING requests, that are
+	// answered by default as required by the ping protocol.
+	PingResponseDisable bool
+
+	// (optional) a function that returns the state of the vehicle, that is
+	// periodically sent to high latency endpoints with HIGH_LATENCY2 messages.
+	// See EndpointHighLatency.
+	HighLatencyProvider func() *HighLatencyState
+	// (optional) the period between HIGH_LATENCY2 messages. It defaults to 5 seconds.
+	HighLatencyPeriod time.Duration
+
+	// (optional) the period between EventStats, that contain the statistics
+	// of channels. If not provided, EventStats are not emitted.
+	StatsPeriod time.Duration
+
+	// (optional) the time to wait for a COMMAND_ACK before sending a command
+	// again. It defaults to 1 second.
+	CommandTimeout time.Duration
+	// (optional) the maximum number of times a command is sent again.
+	// It defaults to 3.
+	CommandRetries int
+	// (optional) the time to wait for the final COMMAND_ACK of a command that
+	// is in progress. It defaults to 10 seconds.
+	CommandInProgressTimeout time.Duration
+
+	// (optional) the time source of the node, that is used by periodic
+	// messages, timeouts and signature timestamps. It defaults to the system
+	// clock. See Clock and ManualClock.
+	Clock Clock
 }
 
 // Node is a high-level Mavlink encoder and decoder that works with endpoints.
 type Node struct {
 	conf               NodeConf
 	dialectDE          *dialect.DecEncoder
+	highPriority       map[uint32]struct{}
 	channelAccepters   map[*channelAccepter]struct{}
 	channelAcceptersWg sync.WaitGroup
 	channels           map[*Channel]struct{}
+	channelList        atomic.Value // []*Channel, replaced when channels change
 	channelsWg         sync.WaitGroup
 	nodeHeartbeat      *nodeHeartbeat
+	nodeSysStatus      *nodeSysStatus
+	nodeSystems        *nodeSystems
+	nodeMission        *nodeMissionProgress
 	nodeStreamRequest  *nodeStreamRequest
+	nodeTimesync       *nodeTimesync
+	nodePing           *nodePing
+	nodeHighLatency    *nodeHighLatency
+	nodeStats          *nodeStats
+	nodeChannelStats   *nodeChannelStats
+	nodeSystemStats    *nodeSystemStats
+	nodeWaiters        *nodeWaiters
+	nodeDecoder        *nodeDecoder
 
 	// in
 	channelNew   chan *Channel
 	channelClose chan *Channel
-	writeTo      chan writeToReq
-	writeAll     chan interface{}
-	writeExcept  chan writeExceptReq
 	terminate    chan struct{}
 
 	// out
@@ -152,9 +298,36 @@ func NewNode(conf NodeConf) (*Node, error) {
 	if conf.HeartbeatAutopilotType == 0 {
 		conf.HeartbeatAutopilotType = 0 // MAV_AUTOPILOT_GENERIC
 	}
+	if conf.HeartbeatSystemStatus == 0 {
+		conf.HeartbeatSystemStatus = 4 // MAV_STATE_ACTIVE
+	}
+	if conf.SysStatusPeriod == 0 {
+		conf.SysStatusPeriod = 1 * time.Second
+	}
+	if conf.SystemTimeout == 0 {
+		conf.SystemTimeout = 15 * time.Second
+	}
 	if conf.StreamRequestFrequency == 0 {
 		conf.StreamRequestFrequency = 4
 	}
+	if conf.TimesyncPeriod == 0 {
+		conf.TimesyncPeriod = 10 * time.Second
+	}
+	if conf.HighLatencyPeriod == 0 {
+		conf.HighLatencyPeriod = 5 * time.Second
+	}
+	if conf.CommandTimeout == 0 {
+		conf.CommandTimeout = 1 * time.Second
+	}
+	if conf.CommandRetries == 0 {
+		conf.CommandRetries = 3
+	}
+	if conf.CommandInProgressTimeout == 0 {
+		conf.CommandInProgressTimeout = 10 * time.Second
+	}
+	if conf.Clock == nil {
+		conf.Clock = realClock{}
+	}
 
 	// check Transceiver configuration here, since Transceiver is created dynamically
 	if conf.OutVersion == 0 {
@@ -169,6 +342,9 @@ func NewNode(conf NodeConf) (*Node, error) {
 	if conf.OutKey != nil && conf.OutVersion != V2 {
 		return nil, fmt.Errorf("OutKey requires V2 frames")
 	}
+	if conf.WriteQueueSize < 0 {
+		return nil, fmt.Errorf("WriteQueueSize must be >= 0")
+	}
 
 	dialectDE, err := func() (*dialect.DecEncoder, error) {
 		if conf.Dialect == nil {
@@ -180,16 +356,19 @@ func NewNode(conf NodeConf) (*Node, error) {
 		return nil, err
 	}
 
+	highPriority := make(map[uint32]struct{})
+	for _, id := range conf.HighPriorityMessages {
+		highPriority[id] = struct{}{}
+	}
+
 	n := &Node{
 		conf:             conf,
 		dialectDE:        dialectDE,
+		highPriority:     highPriority,
 		channelAccepters: make(map[*channelAccepter]struct{}),
 		channels:         make(map[*Channel]struct{}),
 		channelNew:       make(chan *Channel),
 		channelClose:     make(chan *Channel),
-		writeTo:          make(chan writeToReq),
-		writeAll:         make(chan interface{}),
-		writeExcept:      make(chan writeExceptReq),
 		terminate:        make(chan struct{}),
 		events:           make(chan Event),
 		done:             make(chan struct{}),
@@ -236,17 +415,54 @@ func NewNode(conf NodeConf) (*Node, error) {
 		}
 	}
 
+	n.updateChannelList()
+
+	n.nodeChannelStats = newNodeChannelStats()
+	n.nodeSystemStats = newNodeSystemStats(conf.Clock)
+	n.nodeWaiters = newNodeWaiters()
+	n.nodeDecoder = newNodeDecoder(n)
 	n.nodeHeartbeat = newNodeHeartbeat(n)
+	n.nodeSysStatus = newNodeSysStatus(n)
+	n.nodeSystems = newNodeSystems(n)
+	n.nodeMission = newNodeMissionProgress(n)
 	n.nodeStreamRequest = newNodeStreamRequest(n)
+	n.nodeTimesync = newNodeTimesync(n)
+	n.nodePing = newNodePing(n)
+	n.nodeHighLatency = newNodeHighLatency(n)
+	n.nodeStats = newNodeStats(n)
+
+	if n.nodeDecoder != nil {
+		go n.nodeDecoder.run()
+	}
 
 	if n.nodeHeartbeat != nil {
 		go n.nodeHeartbeat.run()
 	}
 
+	if n.nodeSysStatus != nil {
+		go n.nodeSysStatus.run()
+	}
+
+	if n.nodeSystems != nil {
+		go n.nodeSystems.run()
+	}
+
 	if n.nodeStreamRequest != nil {
 		go n.nodeStreamRequest.run()
 	}
 
+	if n.nodeTimesync != nil {
+		go n.nodeTimesync.run()
+	}
+
+	if n.nodeHighLatency != nil {
+		go n.nodeHighLatency.run()
+	}
+
+	if n.nodeStats != nil {
+		go n.nodeStats.run()
+	}
+
 	for ch := range n.channels {
 		ch.start()
 	}
@@ -268,30 +484,14 @@ outer:
 		select {
 		case ch := <-n.channelNew:
 			n.channels[ch] = struct{}{}
+			n.updateChannelList()
 			ch.start()
 
 		case ch := <-n.channelClose:
 			delete(n.channels, ch)
+			n.updateChannelList()
 			ch.close()
 
-		case req := <-n.writeTo:
-			if _, ok := n.channels[req.ch]; !ok {
-				return
-			}
-			req.ch.write <- req.what
-
-		case what := <-n.writeAll:
-			for ch := range n.channels {
-				ch.write <- what
-			}
-
-		case req := <-n.writeExcept:
-			for ch := range n.channels {
-				if ch != req.except {
-					ch.write <- req.what
-				}
-			}
-
 		case <-n.terminate:
 			break outer
 		}
@@ -306,9 +506,6 @@ outer:
 				}
 
 			case <-n.channelClose:
-			case <-n.writeTo:
-			case <-n.writeAll:
-			case <-n.writeExcept:
 			}
 		}
 	}()
@@ -317,10 +514,30 @@ outer:
 		n.nodeHeartbeat.close()
 	}
 
+	if n.nodeSysStatus != nil {
+		n.nodeSysStatus.close()
+	}
+
+	if n.nodeSystems != nil {
+		n.nodeSystems.close()
+	}
+
 	if n.nodeStreamRequest != nil {
 		n.nodeStreamRequest.close()
 	}
 
+	if n.nodeTimesync != nil {
+		n.nodeTimesync.close()
+	}
+
+	if n.nodeHighLatency != nil {
+		n.nodeHighLatency.close()
+	}
+
+	if n.nodeStats != nil {
+		n.nodeStats.close()
+	}
+
 	for ca := range n.channelAccepters {
 		ca.close()
 	}
@@ -330,6 +547,44 @@ outer:
 		ch.close()
 	}
 	n.channelsWg.Wait()
+
+	if n.nodeDecoder != nil {
+		n.nodeDecoder.close()
+	}
+}
+
+// updateChannelList replaces the list of channels used by writers.
+// The list is never modified in place, in order to allow writers to use it
+// without locking.
+func (n *Node) updateChannelList() {
+	list := make([]*Channel, 0, len(n.channels))
+	for ch := range n.channels {
+		list = append(list, ch)
+	}
+	n.channelList.Store(list)
+}
+
+func (n *Node) writeTo(channel *Channel, what interface{}) {
+	for _, ch := range n.channelList.Load().([]*Channel) {
+		if ch == channel {
+			ch.enqueue(what)
+			return
+		}
+	}
+}
+
+func (n *Node) writeAll(what interface{}) {
+	for _, ch := range n.channelList.Load().([]*Channel) {
+		ch.enqueue(what)
+	}
+}
+
+func (n *Node) writeExcept(except *Channel, what interface{}) {
+	for _, ch := range n.channelList.Load().([]*Channel) {
+		if ch != except {
+			ch.enqueue(what)
+		}
+	}
 }
 
 // Close halts node operations and waits for all routines to return.
@@ -348,46 +603,202 @@ func (n *Node) Close() {
 // Events returns a channel from which receiving events. Possible events are:
 //   *EventChannelOpen
 //   *EventChannelClose
+//   *EventChannelLatency
 //   *EventFrame
+//   *EventFrameLoss
+//   *EventMissionComplete
+//   *EventMissionCurrent
+//   *EventMissionItemReached
 //   *EventParseError
+//   *EventSignatureRejected
+//   *EventStats
 //   *EventStreamRequested
+//   *EventSystemOnline
+//   *EventSystemOffline
+//   *EventUnknownIncompatibilityFlag
 // See individual events for meaning and content.
+//
+// Protocol helpers, like SendCommand and the protocol clients, receive their
+// responses from the routines that emit events; therefore their methods must
+// not be called by the routine that reads events, otherwise responses stall.
 func (n *Node) Events() chan Event {
 	return n.events
 }
 
+// SystemStats returns statistics about remote systems, including the
+// number of received and lost frames and the receive rate of each message.
+func (n *Node) SystemStats() []SystemStats {
+	return n.nodeSystemStats.get()
+}
+
+// MessageRate returns the observed receive rate, in Hz, of a message sent by
+// a remote component, or zero if the rate is unknown. It can be used to
+// check that the intervals requested with a MessageIntervalClient are honored.
+// If the target channel is nil, the highest rate among channels is returned.
+func (n *Node) MessageRate(t Target, messageID uint32) float64 {
+	return n.nodeSystemStats.messageRate(t, messageID)
+}
+
+// Channels returns a snapshot of the open channels.
+func (n *Node) Channels() []*Channel {
+	list := n.channelList.Load().([]*Channel)
+	ret := make([]*Channel, len(list))
+	copy(ret, list)
+	return ret
+}
+
+// ChannelStats returns statistics about open channels, including the
+// number of frames and bytes exchanged and the number of parse errors.
+func (n *Node) ChannelStats() []ChannelStats {
+	return n.nodeChannelStats.get()
+}
+
+// TimesyncEstimates returns the clock offset and round-trip time of remote
+// components that answered TIMESYNC requests. It requires TimesyncEnable.
+func (n *Node) TimesyncEstimates() []TimesyncEstimate {
+	if n.nodeTimesync == nil {
+		return nil
+	}
+	return n.nodeTimesync.get()
+}
+
+// Systems returns the remote systems that are sending heartbeats, with their
+// type, autopilot, capabilities and the channel from which they were last
+// heard. Systems that go offline are removed. It requires SystemTrackingEnable.
+//
+// Capabilities are filled when an AUTOPILOT_VERSION message is received, that
+// can be requested with MAV_CMD_REQUEST_MESSAGE.
+func (n *Node) Systems() []RemoteSystem {
+	if n.nodeSystems == nil {
+		return nil
+	}
+	return n.nodeSystems.get()
+}
+
+// MissionProgress returns the mission progress of remote systems, that is
+// the current item, the last reached item and whether the mission has been
+// completed. It requires MissionProgressEnable.
+func (n *Node) MissionProgress() []MissionProgress {
+	if n.nodeMission == nil {
+		return nil
+	}
+	return n.nodeMission.get()
+}
+
+// CloseChannel closes given channel. See Channel.Close.
+// Channels that do not belong to the node are ignored.
+func (n *Node) CloseChannel(channel *Channel) {
+	for _, ch := range n.channelList.Load().([]*Channel) {
+		if ch == channel {
+			ch.Close()
+			return
+		}
+	}
+}
+
 // WriteMessageTo writes a message to given channel.
 func (n *Node) WriteMessageTo(channel *Channel, m msg.Message) {
-	n.writeTo <- writeToReq{channel, m}
+	n.writeTo(channel, m)
 }
 
 // WriteMessageAll writes a message to all channels.
 func (n *Node) WriteMessageAll(m msg.Message) {
-	n.writeAll <- m
+	n.writeAll(m)
 }
 
 // WriteMessageExcept writes a message to all channels except specified channel.
 func (n *Node) WriteMessageExcept(exceptChannel *Channel, m msg.Message) {
-	n.writeExcept <- writeExceptReq{exceptChannel, m}
+	n.writeExcept(exceptChannel, m)
+}
+
+// WriteMessageHighPriorityTo writes a message to given channel, before the
+// messages and frames queued with normal priority.
+func (n *Node) WriteMessageHighPriorityTo(channel *Channel, m msg.Message) {
+	n.writeTo(channel, priorityWrite{m})
+}
+
+// WriteMessageHighPriorityAll writes a message to all channels, before the
+// messages and frames queued with normal priority.
+func (n *Node) WriteMessageHighPriorityAll(m msg.Message) {
+	n.writeAll(priorityWrite{m})
+}
+
+// routedFrame returns a frame that can be queued for writing.
+// Frames are written by channel routines after WriteFrame*() returns, while
+// frames of pooled EventFrames can be reused as soon as Release() is called,
+// therefore they are cloned when EventFramePool is enabled.
+func (n *Node) routedFrame(fr frame.Frame) frame.Frame {
+	if n.conf.EventFramePool {
+		return fr.Clone()
+	}
+	return fr
 }
 
 // WriteFrameTo writes a frame to given channel.
 // This function is intended only for routing pre-existing frames to other nodes,
 // since all frame fields must be filled manually.
 func (n *Node) WriteFrameTo(channel *Channel, fr frame.Frame) {
-	n.writeTo <- writeToReq{channel, fr}
+	n.writeTo(channel, n.routedFrame(fr))
 }
 
 // WriteFrameAll writes a frame to all channels.
 // This function is intended only for routing pre-existing frames to other nodes,
 // since all frame fields must be filled manually.
 func (n *Node) WriteFrameAll(fr frame.Frame) {
-	n.writeAll <- fr
+	n.writeAll(n.routedFrame(fr))
 }
 
 // WriteFrameExcept writes a frame to all channels except specified channel.
 // This function is intended only for routing pre-existing frames to other nodes,
 // since all frame fields must be filled manually.
 func (n *Node) WriteFrameExcept(exceptChannel *Channel, fr frame.Frame) {
-	n.writeExcept <- writeExceptReq{exceptChannel, fr}
+	n.writeExcept(exceptChannel, n.routedFrame(fr))
+}
+
+// WriteMessagesTo writes several messages to given channel.
+// Messages are written with as few calls to the underlying transport as
+// possible.
+func (n *Node) WriteMessagesTo(channel *Channel, ms []msg.Message) {
+	batch := make(writeBatch, len(ms))
+	for i, m := range ms {
+		batch[i] = m
+	}
+	n.writeTo(channel, batch)
+}
+
+// WriteMessagesAll writes several messages to all channels.
+// Messages are written with as few calls to the underlying transport as
+// possible.
+func (n *Node) WriteMessagesAll(ms []msg.Message) {
+	batch := make(writeBatch, len(ms))
+	for i, m := range ms {
+		batch[i] = m
+	}
+	n.writeAll(batch)
+}
+
+// WriteFramesTo writes several frames to given channel.
+// Frames are written with as few calls to the underlying transport as
+// possible.
+// This function is intended only for routing pre-existing frames to other nodes,
+// since all frame fields must be filled manually.
+func (n *Node) WriteFramesTo(channel *Channel, frs []frame.Frame) {
+	batch := make(writeBatch, len(frs))
+	for i, fr := range frs {
+		batch[i] = n.routedFrame(fr)
+	}
+	n.writeTo(channel, batch)
+}
+
+// WriteFramesAll writes several frames to all channels.
+// Frames are written with as few calls to the underlying transport as
+// possible.
+// This function is intended only for routing pre-existing frames to other nodes,
+// since all frame fields must be filled manually.
+func (n *Node) WriteFramesAll(frs []frame.Frame) {
+	batch := make(writeBatch, len(frs))
+	for i, fr := range frs {
+		batch[i] = n.routedFrame(fr)
+	}
+	n.writeAll(batch)
 }
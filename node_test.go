@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"net"
 	"reflect"
 	"sync"
 	"testing"
@@ -12,6 +13,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/dialects/common"
 	"github.com/aler9/gomavlib/pkg/frame"
 	"github.com/aler9/gomavlib/pkg/msg"
 )
@@ -181,11 +183,215 @@ func doTest(t *testing.T, t1 EndpointConf, t2 EndpointConf) {
 }
 
 func TestNodeTcpServerClient(t *testing.T) {
-	doTest(t, EndpointTCPServer{"127.0.0.1:5601"}, EndpointTCPClient{"127.0.0.1:5601"})
+	doTest(t, EndpointTCPServer{Address: "127.0.0.1:5601"}, EndpointTCPClient{Address: "127.0.0.1:5601"})
+}
+
+func TestNodeTcpServerMaxConnections(t *testing.T) {
+	node, err := NewNode(NodeConf{
+		Dialect:     &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:  V2,
+		OutSystemID: 10,
+		Endpoints: []EndpointConf{
+			EndpointTCPServer{
+				Address:        "127.0.0.1:5601",
+				MaxConnections: 1,
+			},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node.Close()
+
+	conn1, err := net.Dial("tcp", "127.0.0.1:5601")
+	require.NoError(t, err)
+	defer conn1.Close()
+
+	evt := <-node.Events()
+	_, ok := evt.(*EventChannelOpen)
+	require.True(t, ok)
+
+	conn2, err := net.Dial("tcp", "127.0.0.1:5601")
+	require.NoError(t, err)
+	defer conn2.Close()
+
+	_, err = conn2.Read(make([]byte, 1))
+	require.Equal(t, io.EOF, err)
+
+	conn1.Close()
+
+	evt = <-node.Events()
+	_, ok = evt.(*EventChannelClose)
+	require.True(t, ok)
+
+	conn3, err := net.Dial("tcp", "127.0.0.1:5601")
+	require.NoError(t, err)
+	defer conn3.Close()
+
+	evt = <-node.Events()
+	_, ok = evt.(*EventChannelOpen)
+	require.True(t, ok)
+}
+
+func TestNodeTcpServerAcceptFilter(t *testing.T) {
+	addrs := make(chan net.Addr, 1)
+
+	node, err := NewNode(NodeConf{
+		Dialect:     &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:  V2,
+		OutSystemID: 10,
+		Endpoints: []EndpointConf{
+			EndpointTCPServer{
+				Address: "127.0.0.1:5601",
+				AcceptFilter: func(addr net.Addr) bool {
+					addrs <- addr
+					return false
+				},
+			},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node.Close()
+
+	conn, err := net.Dial("tcp", "127.0.0.1:5601")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = conn.Read(make([]byte, 1))
+	require.Equal(t, io.EOF, err)
+	require.Equal(t, conn.LocalAddr().String(), (<-addrs).String())
 }
 
 func TestNodeUdpServerClient(t *testing.T) {
-	doTest(t, EndpointUDPServer{"127.0.0.1:5601"}, EndpointUDPClient{"127.0.0.1:5601"})
+	doTest(t, EndpointUDPServer{Address: "127.0.0.1:5601"}, EndpointUDPClient{Address: "127.0.0.1:5601"})
+}
+
+func TestNodeUdpServerIdleTimeout(t *testing.T) {
+	node1, err := NewNode(NodeConf{
+		Dialect:     &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:  V2,
+		OutSystemID: 10,
+		Endpoints: []EndpointConf{
+			EndpointUDPServer{
+				Address:     "127.0.0.1:5601",
+				IdleTimeout: 500 * time.Millisecond,
+			},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node1.Close()
+
+	node2, err := NewNode(NodeConf{
+		Dialect:     &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:  V2,
+		OutSystemID: 11,
+		Endpoints: []EndpointConf{
+			EndpointUDPClient{Address: "127.0.0.1:5601"},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node2.Close()
+
+	go func() {
+		for range node2.Events() {
+		}
+	}()
+
+	// wait client initialization
+	time.Sleep(100 * time.Millisecond)
+
+	node2.WriteMessageAll(&MessageHeartbeat{
+		Type:           1,
+		Autopilot:      2,
+		BaseMode:       3,
+		CustomMode:     6,
+		SystemStatus:   4,
+		MavlinkVersion: 5,
+	})
+
+	evt := <-node1.Events()
+	_, ok := evt.(*EventChannelOpen)
+	require.True(t, ok)
+
+	evt = <-node1.Events()
+	_, ok = evt.(*EventFrame)
+	require.True(t, ok)
+
+	start := time.Now()
+
+	evt = <-node1.Events()
+	_, ok = evt.(*EventChannelClose)
+	require.True(t, ok)
+	require.True(t, time.Since(start) < 2*time.Second)
+}
+
+func TestNodeClientLocalAddress(t *testing.T) {
+	for _, ca := range []string{"tcp", "udp"} {
+		t.Run(ca, func(t *testing.T) {
+			var serverConf EndpointConf
+			var clientConf EndpointConf
+			if ca == "tcp" {
+				serverConf = EndpointTCPServer{Address: "127.0.0.1:5601"}
+				clientConf = EndpointTCPClient{
+					Address:      "127.0.0.1:5601",
+					LocalAddress: "127.0.0.1:5603",
+				}
+			} else {
+				serverConf = EndpointUDPServer{Address: "127.0.0.1:5601"}
+				clientConf = EndpointUDPClient{
+					Address:      "127.0.0.1:5601",
+					LocalAddress: "127.0.0.1:5603",
+				}
+			}
+
+			node1, err := NewNode(NodeConf{
+				Dialect:     &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+				OutVersion:  V2,
+				OutSystemID: 10,
+				Endpoints:   []EndpointConf{serverConf},
+			})
+			require.NoError(t, err)
+			defer node1.Close()
+
+			node2, err := NewNode(NodeConf{
+				Dialect:         &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+				OutVersion:      V2,
+				OutSystemID:     11,
+				Endpoints:       []EndpointConf{clientConf},
+				HeartbeatPeriod: 100 * time.Millisecond,
+			})
+			require.NoError(t, err)
+			defer node2.Close()
+
+			go func() {
+				for range node2.Events() {
+				}
+			}()
+
+			evt := <-node1.Events()
+			e, ok := evt.(*EventChannelOpen)
+			require.True(t, ok)
+			require.Equal(t, ca+":127.0.0.1:5603", e.Channel.String())
+		})
+	}
+}
+
+func TestNodeClientLocalAddressError(t *testing.T) {
+	_, err := NewNode(NodeConf{
+		Dialect:     &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:  V2,
+		OutSystemID: 11,
+		Endpoints: []EndpointConf{
+			EndpointUDPClient{
+				Address:      "127.0.0.1:5601",
+				LocalAddress: "invalid",
+			},
+		},
+		HeartbeatDisable: true,
+	})
+	require.EqualError(t, err, "invalid local address")
 }
 
 func TestNodeUdpBroadcastBroadcast(t *testing.T) {
@@ -210,7 +416,10 @@ func (ch testLoopback) Read(buf []byte) (int, error) {
 }
 
 func (ch testLoopback) Write(buf []byte) (int, error) {
-	ch <- buf
+	// copy buffer since it is reused by the writer
+	cpy := make([]byte, len(buf))
+	copy(cpy, buf)
+	ch <- cpy
 	return len(buf), nil
 }
 
@@ -219,6 +428,79 @@ type testEndpoint struct {
 	io.Writer
 }
 
+// testPipe is a loopback that can be safely closed by one side while the
+// other side is writing.
+type testPipe struct {
+	ch        chan []byte
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func newTestPipe() *testPipe {
+	return &testPipe{
+		ch:   make(chan []byte),
+		done: make(chan struct{}),
+	}
+}
+
+func (p *testPipe) Close() error {
+	p.closeOnce.Do(func() {
+		close(p.done)
+	})
+	return nil
+}
+
+func (p *testPipe) Read(buf []byte) (int, error) {
+	select {
+	case ret := <-p.ch:
+		return copy(buf, ret), nil
+	case <-p.done:
+		return 0, errorTerminated
+	}
+}
+
+func (p *testPipe) Write(buf []byte) (int, error) {
+	cpy := make([]byte, len(buf))
+	copy(cpy, buf)
+
+	select {
+	case p.ch <- cpy:
+		return len(buf), nil
+	case <-p.done:
+		return 0, errorTerminated
+	}
+}
+
+// newTestNodePair allocates two nodes connected together.
+func newTestNodePair(t *testing.T, d *dialect.Dialect) (*Node, *Node) {
+	l1 := newTestPipe()
+	l2 := newTestPipe()
+
+	node1, err := NewNode(NodeConf{
+		Dialect:     d,
+		OutVersion:  V2,
+		OutSystemID: 10,
+		Endpoints: []EndpointConf{
+			EndpointCustom{ReadWriteCloser: &testEndpoint{l1, l2}},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+
+	node2, err := NewNode(NodeConf{
+		Dialect:     d,
+		OutVersion:  V2,
+		OutSystemID: 11,
+		Endpoints: []EndpointConf{
+			EndpointCustom{ReadWriteCloser: &testEndpoint{l2, l1}},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+
+	return node1, node2
+}
+
 func TestNodeCustomCustom(t *testing.T) {
 	l1 := make(testLoopback)
 	l2 := make(testLoopback)
@@ -232,8 +514,8 @@ func TestNodeError(t *testing.T) {
 		OutVersion:  V2,
 		OutSystemID: 11,
 		Endpoints: []EndpointConf{
-			EndpointUDPServer{"127.0.0.1:5600"},
-			EndpointUDPServer{"127.0.0.1:5600"},
+			EndpointUDPServer{Address: "127.0.0.1:5600"},
+			EndpointUDPServer{Address: "127.0.0.1:5600"},
 		},
 		HeartbeatDisable: true,
 	})
@@ -246,7 +528,7 @@ func TestNodeCloseInLoop(t *testing.T) {
 		OutVersion:  V2,
 		OutSystemID: 11,
 		Endpoints: []EndpointConf{
-			EndpointUDPServer{"127.0.0.1:5600"},
+			EndpointUDPServer{Address: "127.0.0.1:5600"},
 		},
 		HeartbeatDisable: true,
 	})
@@ -257,7 +539,7 @@ func TestNodeCloseInLoop(t *testing.T) {
 		OutVersion:  V2,
 		OutSystemID: 11,
 		Endpoints: []EndpointConf{
-			EndpointUDPClient{"127.0.0.1:5600"},
+			EndpointUDPClient{Address: "127.0.0.1:5600"},
 		},
 		HeartbeatDisable: true,
 	})
@@ -292,7 +574,7 @@ func TestNodeWriteMultipleInLoop(t *testing.T) {
 		OutVersion:  V2,
 		OutSystemID: 11,
 		Endpoints: []EndpointConf{
-			EndpointUDPServer{"127.0.0.1:5600"},
+			EndpointUDPServer{Address: "127.0.0.1:5600"},
 		},
 		HeartbeatDisable: true,
 	})
@@ -303,7 +585,7 @@ func TestNodeWriteMultipleInLoop(t *testing.T) {
 		OutVersion:  V2,
 		OutSystemID: 11,
 		Endpoints: []EndpointConf{
-			EndpointUDPClient{"127.0.0.1:5600"},
+			EndpointUDPClient{Address: "127.0.0.1:5600"},
 		},
 		HeartbeatDisable: true,
 	})
@@ -358,7 +640,7 @@ func TestNodeSignature(t *testing.T) {
 	node1, err := NewNode(NodeConf{
 		Dialect: &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
 		Endpoints: []EndpointConf{
-			EndpointUDPServer{"127.0.0.1:5600"},
+			EndpointUDPServer{Address: "127.0.0.1:5600"},
 		},
 		HeartbeatDisable: true,
 		InKey:            key2,
@@ -371,7 +653,7 @@ func TestNodeSignature(t *testing.T) {
 	node2, err := NewNode(NodeConf{
 		Dialect: &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
 		Endpoints: []EndpointConf{
-			EndpointUDPClient{"127.0.0.1:5600"},
+			EndpointUDPClient{Address: "127.0.0.1:5600"},
 		},
 		HeartbeatDisable: true,
 		InKey:            key1,
@@ -419,7 +701,7 @@ func TestNodeRouting(t *testing.T) {
 		OutVersion:  V2,
 		OutSystemID: 10,
 		Endpoints: []EndpointConf{
-			EndpointUDPClient{"127.0.0.1:5600"},
+			EndpointUDPClient{Address: "127.0.0.1:5600"},
 		},
 		HeartbeatDisable: true,
 	})
@@ -430,8 +712,8 @@ func TestNodeRouting(t *testing.T) {
 		OutVersion:  V2,
 		OutSystemID: 11,
 		Endpoints: []EndpointConf{
-			EndpointUDPServer{"127.0.0.1:5600"},
-			EndpointUDPClient{"127.0.0.1:5601"},
+			EndpointUDPServer{Address: "127.0.0.1:5600"},
+			EndpointUDPClient{Address: "127.0.0.1:5601"},
 		},
 		HeartbeatDisable: true,
 	})
@@ -442,7 +724,7 @@ func TestNodeRouting(t *testing.T) {
 		OutVersion:  V2,
 		OutSystemID: 12,
 		Endpoints: []EndpointConf{
-			EndpointUDPServer{"127.0.0.1:5601"},
+			EndpointUDPServer{Address: "127.0.0.1:5601"},
 		},
 		HeartbeatDisable: true,
 	})
@@ -499,7 +781,7 @@ func TestNodeHeartbeat(t *testing.T) {
 			OutVersion:  V2,
 			OutSystemID: 10,
 			Endpoints: []EndpointConf{
-				EndpointUDPServer{"127.0.0.1:5600"},
+				EndpointUDPServer{Address: "127.0.0.1:5600"},
 			},
 			HeartbeatDisable: true,
 		})
@@ -511,7 +793,7 @@ func TestNodeHeartbeat(t *testing.T) {
 			OutVersion:  V2,
 			OutSystemID: 11,
 			Endpoints: []EndpointConf{
-				EndpointUDPClient{"127.0.0.1:5600"},
+				EndpointUDPClient{Address: "127.0.0.1:5600"},
 			},
 			HeartbeatDisable: false,
 			HeartbeatPeriod:  500 * time.Millisecond,
@@ -539,7 +821,7 @@ func TestNodeStreamRequest(t *testing.T) {
 			OutVersion:  V2,
 			OutSystemID: 10,
 			Endpoints: []EndpointConf{
-				EndpointUDPServer{"127.0.0.1:5600"},
+				EndpointUDPServer{Address: "127.0.0.1:5600"},
 			},
 			HeartbeatDisable:    true,
 			StreamRequestEnable: true,
@@ -555,7 +837,7 @@ func TestNodeStreamRequest(t *testing.T) {
 			OutVersion:  V2,
 			OutSystemID: 10,
 			Endpoints: []EndpointConf{
-				EndpointUDPClient{"127.0.0.1:5600"},
+				EndpointUDPClient{Address: "127.0.0.1:5600"},
 			},
 			HeartbeatDisable:       false,
 			HeartbeatPeriod:        500 * time.Millisecond,
@@ -578,3 +860,376 @@ func TestNodeStreamRequest(t *testing.T) {
 		}
 	}()
 }
+
+func TestNodeTimesync(t *testing.T) {
+	l1 := newTestPipe()
+	l2 := newTestPipe()
+
+	node1, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 10,
+		Endpoints: []EndpointConf{
+			EndpointCustom{ReadWriteCloser: &testEndpoint{l1, l2}},
+		},
+		HeartbeatDisable: true,
+		TimesyncEnable:   true,
+		TimesyncPeriod:   50 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	defer node1.Close()
+
+	node2, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 11,
+		Endpoints: []EndpointConf{
+			EndpointCustom{ReadWriteCloser: &testEndpoint{l2, l1}},
+		},
+		HeartbeatDisable: true,
+		TimesyncEnable:   true,
+		TimesyncPeriod:   50 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	defer node2.Close()
+
+	go func() {
+		for range node2.Events() {
+		}
+	}()
+
+	for evt := range node1.Events() {
+		if _, ok := evt.(*EventFrame); !ok {
+			continue
+		}
+
+		estimates := node1.TimesyncEstimates()
+		if len(estimates) == 1 && estimates[0].Samples >= 3 {
+			e := estimates[0]
+			require.Equal(t, byte(11), e.SystemID)
+			require.True(t, e.RTT < time.Second)
+			// nodes share the same clock
+			require.True(t, e.Offset < 100*time.Millisecond && e.Offset > -100*time.Millisecond)
+			break
+		}
+	}
+}
+
+func TestNodeChannelLatency(t *testing.T) {
+	l1 := newTestPipe()
+	l2 := newTestPipe()
+
+	node1, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 10,
+		Endpoints: []EndpointConf{
+			EndpointCustom{ReadWriteCloser: &testEndpoint{l1, l2}},
+		},
+		HeartbeatDisable: true,
+		TimesyncEnable:   true,
+		TimesyncPeriod:   50 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	defer node1.Close()
+
+	node2, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 11,
+		Endpoints: []EndpointConf{
+			EndpointCustom{ReadWriteCloser: &testEndpoint{l2, l1}},
+		},
+		HeartbeatDisable: true,
+		TimesyncEnable:   true,
+	})
+	require.NoError(t, err)
+	defer node2.Close()
+
+	// unblock writers before closing nodes
+	defer l1.Close()
+	defer l2.Close()
+
+	go func() {
+		for range node2.Events() {
+		}
+	}()
+
+	for evt := range node1.Events() {
+		if ee, ok := evt.(*EventChannelLatency); ok {
+			require.Equal(t, byte(11), ee.SystemID)
+			require.Equal(t, byte(1), ee.ComponentID)
+			require.True(t, ee.RTT > 0 && ee.RTT < time.Second)
+
+			stats := node1.ChannelStats()
+			require.Len(t, stats, 1)
+			require.Equal(t, ee.Channel, stats[0].Channel)
+			require.True(t, stats[0].RTT > 0 && stats[0].RTT < time.Second)
+			break
+		}
+	}
+}
+
+func TestNodePingResponse(t *testing.T) {
+	node1, node2 := newTestNodePair(t, common.Dialect)
+	defer node1.Close()
+	defer node2.Close()
+
+	go func() {
+		for range node2.Events() {
+		}
+	}()
+
+	evt := <-node1.Events()
+	require.IsType(t, &EventChannelOpen{}, evt)
+
+	node1.WriteMessageAll(&common.MessagePing{
+		TimeUsec: 1234,
+		Seq:      5,
+	})
+
+	for evt := range node1.Events() {
+		if ee, ok := evt.(*EventFrame); ok {
+			require.Equal(t, byte(11), ee.SystemID())
+			require.Equal(t, &common.MessagePing{
+				TimeUsec:        1234,
+				Seq:             5,
+				TargetSystem:    10,
+				TargetComponent: 1,
+			}, ee.Message())
+			break
+		}
+	}
+}
+
+func TestNodeRateLimit(t *testing.T) {
+	l1 := make(testLoopback)
+	l2 := make(testLoopback)
+
+	node1, err := NewNode(NodeConf{
+		Dialect:     &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:  V2,
+		OutSystemID: 10,
+		Endpoints: []EndpointConf{
+			EndpointCustom{&testEndpoint{l1, l2}},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node1.Close()
+
+	node2, err := NewNode(NodeConf{
+		Dialect:     &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:  V2,
+		OutSystemID: 11,
+		Endpoints: []EndpointConf{
+			EndpointRateLimit{
+				Endpoint:           EndpointCustom{&testEndpoint{l2, l1}},
+				OutFramesPerSecond: 10,
+			},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node2.Close()
+
+	go func() {
+		for range node2.Events() {
+		}
+	}()
+
+	write := func() {
+		node2.WriteMessageAll(&MessageHeartbeat{
+			Type:           1,
+			Autopilot:      2,
+			BaseMode:       3,
+			CustomMode:     6,
+			SystemStatus:   4,
+			MavlinkVersion: 5,
+		})
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		// exceed the limit, then keep writing until a token is available again
+		for i := 0; i < 15; i++ {
+			write()
+		}
+
+		ticker := time.NewTicker(10 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				write()
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	// discarded frames do not consume sequence numbers
+	seq := byte(0)
+	for evt := range node1.Events() {
+		fr, ok := evt.(*EventFrame)
+		if !ok {
+			continue
+		}
+
+		require.Equal(t, seq, fr.Frame.(*frame.V2Frame).SequenceID)
+		seq++
+		if seq == 11 {
+			break
+		}
+	}
+}
+
+func TestNodeSystemStats(t *testing.T) {
+	node1, node2 := newTestNodePair(t, nil)
+	defer node1.Close()
+	defer node2.Close()
+
+	go func() {
+		for range node2.Events() {
+		}
+	}()
+
+	go func() {
+		// 3 is reordered, 5 is duplicated
+		for _, seq := range []byte{0, 1, 4, 5, 3, 5, 6} {
+			node2.WriteFrameAll(&frame.V2Frame{
+				SequenceID:  seq,
+				SystemID:    11,
+				ComponentID: 1,
+				Message:     &msg.MessageRaw{ID: 0, Content: []byte{1, 2, 3}},
+			})
+		}
+	}()
+
+	count := 0
+	var loss *EventFrameLoss
+	for evt := range node1.Events() {
+		switch e := evt.(type) {
+		case *EventFrameLoss:
+			loss = e
+		case *EventFrame:
+			count++
+		}
+		if count == 7 {
+			break
+		}
+	}
+
+	require.NotNil(t, loss)
+	require.Equal(t, byte(11), loss.SystemID)
+	require.Equal(t, byte(1), loss.ComponentID)
+	require.Equal(t, 2, loss.Count)
+
+	stats := node1.SystemStats()
+	require.Len(t, stats, 1)
+	require.Equal(t, byte(11), stats[0].SystemID)
+	require.Equal(t, uint64(7), stats[0].FramesReceived)
+	require.Equal(t, uint64(2), stats[0].FramesLost)
+	require.Equal(t, uint64(1), stats[0].Gaps)
+	require.Equal(t, float64(2)*100/9, stats[0].LossPercentage())
+}
+
+func TestNodeMessageRate(t *testing.T) {
+	node1, node2 := newTestNodePair(t, nil)
+	defer node1.Close()
+	defer node2.Close()
+
+	go func() {
+		for range node2.Events() {
+		}
+	}()
+
+	go func() {
+		for seq := byte(0); seq < 10; seq++ {
+			node2.WriteFrameAll(&frame.V2Frame{
+				SequenceID:  seq,
+				SystemID:    11,
+				ComponentID: 1,
+				Message:     &msg.MessageRaw{ID: 0, Content: []byte{1, 2, 3}},
+			})
+			time.Sleep(20 * time.Millisecond)
+		}
+	}()
+
+	var ch *Channel
+	count := 0
+	for evt := range node1.Events() {
+		if e, ok := evt.(*EventFrame); ok {
+			ch = e.Channel
+			count++
+		}
+		if count == 10 {
+			break
+		}
+	}
+
+	stats := node1.SystemStats()
+	require.Len(t, stats, 1)
+	rate := stats[0].MessageRates[0]
+	require.True(t, rate > 20 && rate < 60)
+
+	rate = node1.MessageRate(Target{SystemID: 11, ComponentID: 1}, 0)
+	require.True(t, rate > 20 && rate < 60)
+	rate = node1.MessageRate(Target{Channel: ch, SystemID: 11, ComponentID: 1}, 0)
+	require.True(t, rate > 20 && rate < 60)
+	require.Equal(t, float64(0), node1.MessageRate(Target{SystemID: 11, ComponentID: 1}, 1))
+	require.Equal(t, float64(0), node1.MessageRate(Target{SystemID: 12, ComponentID: 1}, 0))
+
+	// the rate decays when messages are not received anymore
+	time.Sleep(200 * time.Millisecond)
+	rate = node1.MessageRate(Target{SystemID: 11, ComponentID: 1}, 0)
+	require.True(t, rate < 6)
+}
+
+func TestNodeChannelStats(t *testing.T) {
+	node1, node2 := newTestNodePair(t, nil)
+	defer node1.Close()
+	defer node2.Close()
+
+	go func() {
+		for range node2.Events() {
+		}
+	}()
+
+	go func() {
+		for seq := byte(0); seq < 2; seq++ {
+			node2.WriteFrameAll(&frame.V2Frame{
+				SequenceID:  seq,
+				SystemID:    11,
+				ComponentID: 1,
+				Message:     &msg.MessageRaw{ID: 0, Content: []byte{1, 2, 3}},
+			})
+		}
+	}()
+
+	count := 0
+	for evt := range node1.Events() {
+		if _, ok := evt.(*EventFrame); ok {
+			count++
+			if count == 2 {
+				break
+			}
+		}
+	}
+
+	// wait for the writer routine of node2 to update its counters
+	time.Sleep(100 * time.Millisecond)
+
+	stats1 := node1.ChannelStats()
+	require.Len(t, stats1, 1)
+	require.Equal(t, uint64(2), stats1[0].FramesIn)
+	require.Equal(t, uint64(0), stats1[0].FramesOut)
+	require.Equal(t, uint64(0), stats1[0].ParseErrors)
+
+	stats2 := node2.ChannelStats()
+	require.Len(t, stats2, 1)
+	require.Equal(t, uint64(2), stats2[0].FramesOut)
+	require.True(t, stats2[0].BytesOut > 0)
+	require.Equal(t, stats2[0].BytesOut, stats1[0].BytesIn)
+}
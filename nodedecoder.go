@@ -0,0 +1,73 @@
+package gomavlib
+
+import (
+	"github.com/aler9/gomavlib/pkg/frame"
+	"github.com/aler9/gomavlib/pkg/transceiver"
+)
+
+// decodeQueueSize is the maximum number of frames of a channel that can be
+// decoded in parallel.
+const decodeQueueSize = 64
+
+// decodeJob is a frame that is waiting to be decoded, or a read error.
+type decodeJob struct {
+	fr   frame.Frame
+	err  error
+	done chan struct{}
+}
+
+// nodeDecoder is a pool of routines that validate and decode frames in
+// parallel. Channels wait for jobs in the order they are submitted, in order
+// to preserve the order of frames.
+type nodeDecoder struct {
+	n    *Node
+	jobs chan *decodeJob
+	done chan struct{}
+}
+
+func newNodeDecoder(n *Node) *nodeDecoder {
+	if n.conf.DecodeWorkers <= 0 || n.dialectDE == nil {
+		return nil
+	}
+
+	return &nodeDecoder{
+		n:    n,
+		jobs: make(chan *decodeJob),
+		done: make(chan struct{}),
+	}
+}
+
+func (d *nodeDecoder) close() {
+	close(d.jobs)
+	<-d.done
+}
+
+func (d *nodeDecoder) run() {
+	defer close(d.done)
+
+	workersDone := make(chan struct{})
+	for i := 0; i < d.n.conf.DecodeWorkers; i++ {
+		go func() {
+			defer func() { workersDone <- struct{}{} }()
+
+			for job := range d.jobs {
+				job.err = transceiver.DecodeMessage(d.n.dialectDE, job.fr)
+				close(job.done)
+			}
+		}()
+	}
+
+	for i := 0; i < d.n.conf.DecodeWorkers; i++ {
+		<-workersDone
+	}
+}
+
+// submit starts decoding a frame.
+func (d *nodeDecoder) submit(fr frame.Frame) *decodeJob {
+	job := &decodeJob{
+		fr:   fr,
+		done: make(chan struct{}),
+	}
+	d.jobs <- job
+	return job
+}
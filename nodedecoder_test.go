@@ -0,0 +1,62 @@
+package gomavlib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+)
+
+func TestNodeDecodeWorkers(t *testing.T) {
+	l1 := newTestPipe()
+	l2 := newTestPipe()
+
+	node1, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 10,
+		Endpoints: []EndpointConf{
+			EndpointCustom{ReadWriteCloser: &testEndpoint{l1, l2}},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node1.Close()
+
+	node2, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 11,
+		Endpoints: []EndpointConf{
+			EndpointCustom{ReadWriteCloser: &testEndpoint{l2, l1}},
+		},
+		HeartbeatDisable: true,
+		DecodeWorkers:    4,
+	})
+	require.NoError(t, err)
+	defer node2.Close()
+
+	go func() {
+		for range node1.Events() {
+		}
+	}()
+
+	go func() {
+		for i := 0; i < 100; i++ {
+			node1.WriteMessageAll(&common.MessageParamValue{ParamIndex: uint16(i)})
+		}
+	}()
+
+	// frames are decoded and emitted in order
+	i := 0
+	for evt := range node2.Events() {
+		if fr, ok := evt.(*EventFrame); ok {
+			require.Equal(t, &common.MessageParamValue{ParamIndex: uint16(i)}, fr.Message())
+			i++
+			if i == 100 {
+				break
+			}
+		}
+	}
+}
@@ -1,17 +1,33 @@
 package gomavlib
 
 import (
-	"reflect"
-	"time"
+	"sync"
+	"sync/atomic"
 
 	"github.com/aler9/gomavlib/pkg/msg"
 )
 
+// HeartbeatState is the state advertised by heartbeats.
+// See NodeConf.HeartbeatProvider.
+type HeartbeatState struct {
+	// bitmask of MAV_MODE_FLAG
+	BaseMode int
+	// autopilot-specific custom mode
+	CustomMode uint32
+	// MAV_STATE
+	SystemStatus int
+}
+
 type nodeHeartbeat struct {
 	n            *Node
 	msgHeartbeat msg.Message
+	mutex        sync.Mutex
+	baseMode     int
+	customMode   uint32
+	systemStatus int
 
 	// in
+	update    chan struct{}
 	terminate chan struct{}
 
 	// out
@@ -24,31 +40,19 @@ func newNodeHeartbeat(n *Node) *nodeHeartbeat {
 		return nil
 	}
 
-	// dialect must be enabled
-	if n.conf.Dialect == nil {
-		return nil
-	}
-
 	// heartbeat message must exist in dialect and correspond to standard
-	msgHeartbeat := func() msg.Message {
-		for _, m := range n.conf.Dialect.Messages {
-			if m.GetID() == 0 {
-				return m
-			}
-		}
-		return nil
-	}()
+	msgHeartbeat := dialectMessage(n.conf.Dialect, 0, 50)
 	if msgHeartbeat == nil {
 		return nil
 	}
-	mde, err := msg.NewDecEncoder(msgHeartbeat)
-	if err != nil || mde.CRCExtra() != 50 {
-		return nil
-	}
 
 	h := &nodeHeartbeat{
 		n:            n,
 		msgHeartbeat: msgHeartbeat,
+		baseMode:     n.conf.HeartbeatBaseMode,
+		customMode:   n.conf.HeartbeatCustomMode,
+		systemStatus: n.conf.HeartbeatSystemStatus,
+		update:       make(chan struct{}, 1),
 		terminate:    make(chan struct{}),
 		done:         make(chan struct{}),
 	}
@@ -64,23 +68,114 @@ func (h *nodeHeartbeat) close() {
 func (h *nodeHeartbeat) run() {
 	defer close(h.done)
 
-	ticker := time.NewTicker(h.n.conf.HeartbeatPeriod)
-	defer ticker.Stop()
+	tickerC, stopTicker := h.n.conf.Clock.NewTicker(h.n.conf.HeartbeatPeriod)
+	defer func() { stopTicker() }()
 
 	for {
 		select {
-		case <-ticker.C:
-			m := reflect.New(reflect.TypeOf(h.msgHeartbeat).Elem())
-			m.Elem().FieldByName("Type").SetInt(int64(h.n.conf.HeartbeatSystemType))
-			m.Elem().FieldByName("Autopilot").SetInt(int64(h.n.conf.HeartbeatAutopilotType))
-			m.Elem().FieldByName("BaseMode").SetInt(0)
-			m.Elem().FieldByName("CustomMode").SetUint(0)
-			m.Elem().FieldByName("SystemStatus").SetInt(4) // MAV_STATE_ACTIVE
-			m.Elem().FieldByName("MavlinkVersion").SetUint(uint64(h.n.conf.Dialect.Version))
-			h.n.WriteMessageAll(m.Interface().(msg.Message))
+		case <-tickerC:
+			h.write()
+
+		case <-h.update:
+			// advertise changes immediately, then restart the period
+			h.write()
+			stopTicker()
+			tickerC, stopTicker = h.n.conf.Clock.NewTicker(h.n.conf.HeartbeatPeriod)
 
 		case <-h.terminate:
 			return
 		}
 	}
 }
+
+func (h *nodeHeartbeat) state() HeartbeatState {
+	if h.n.conf.HeartbeatProvider != nil {
+		st := h.n.conf.HeartbeatProvider()
+		if st != nil {
+			return *st
+		}
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	return HeartbeatState{
+		BaseMode:     h.baseMode,
+		CustomMode:   h.customMode,
+		SystemStatus: h.systemStatus,
+	}
+}
+
+func (h *nodeHeartbeat) message() msg.Message {
+	st := h.state()
+
+	m := newMessage(h.msgHeartbeat)
+	messageSet(m, "Type", h.n.conf.HeartbeatSystemType)
+	messageSet(m, "Autopilot", h.n.conf.HeartbeatAutopilotType)
+	messageSet(m, "BaseMode", st.BaseMode)
+	messageSet(m, "CustomMode", st.CustomMode)
+	messageSet(m, "SystemStatus", st.SystemStatus)
+	messageSet(m, "MavlinkVersion", h.n.conf.Dialect.Version)
+	return m
+}
+
+// write writes a heartbeat to the channels that are not excluded by
+// EndpointHeartbeatDisable and NodeConf.HeartbeatActivePeersOnly.
+func (h *nodeHeartbeat) write() {
+	m := h.message()
+
+	for _, ch := range h.n.channelList.Load().([]*Channel) {
+		if ch.noHeartbeat {
+			continue
+		}
+
+		if h.n.conf.HeartbeatActivePeersOnly && atomic.LoadUint32(&ch.activePeer) == 0 {
+			continue
+		}
+
+		ch.enqueue(m)
+	}
+}
+
+func (h *nodeHeartbeat) onEventFrame(evt *EventFrame) {
+	if evt.Message().GetID() == 0 {
+		atomic.StoreUint32(&evt.Channel.activePeer, 1)
+	}
+}
+
+func (h *nodeHeartbeat) set(cb func()) {
+	h.mutex.Lock()
+	cb()
+	h.mutex.Unlock()
+
+	select {
+	case h.update <- struct{}{}:
+	default:
+	}
+}
+
+// SetMode changes the base mode (a bitmask of MAV_MODE_FLAG) and the
+// autopilot-specific custom mode advertised by heartbeats.
+// The change is advertised immediately.
+// It has no effect if heartbeats are disabled.
+func (n *Node) SetMode(baseMode int, customMode uint32) {
+	if n.nodeHeartbeat == nil {
+		return
+	}
+	n.nodeHeartbeat.set(func() {
+		n.nodeHeartbeat.baseMode = baseMode
+		n.nodeHeartbeat.customMode = customMode
+	})
+}
+
+// SetSystemStatus changes the system status (MAV_STATE) advertised by
+// heartbeats. The change is advertised immediately.
+// It has no effect if heartbeats are disabled.
+func (n *Node) SetSystemStatus(systemStatus int) {
+	if n.nodeHeartbeat == nil {
+		return
+	}
+	n.nodeHeartbeat.set(func() {
+		n.nodeHeartbeat.systemStatus = systemStatus
+	})
+}
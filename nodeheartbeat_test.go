@@ -0,0 +1,246 @@
+package gomavlib
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+)
+
+func TestNodeHeartbeatSetMode(t *testing.T) {
+	l1 := newTestPipe()
+	l2 := newTestPipe()
+
+	node1, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 10,
+		Endpoints: []EndpointConf{
+			EndpointCustom{ReadWriteCloser: &testEndpoint{l1, l2}},
+		},
+		HeartbeatPeriod:        time.Hour,
+		HeartbeatSystemType:    int(common.MAV_TYPE_ONBOARD_CONTROLLER),
+		HeartbeatAutopilotType: int(common.MAV_AUTOPILOT_INVALID),
+		HeartbeatBaseMode:      int(common.MAV_MODE_FLAG_CUSTOM_MODE_ENABLED),
+		HeartbeatCustomMode:    3,
+		HeartbeatSystemStatus:  int(common.MAV_STATE_STANDBY),
+	})
+	require.NoError(t, err)
+	defer node1.Close()
+
+	node2, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 11,
+		Endpoints: []EndpointConf{
+			EndpointCustom{ReadWriteCloser: &testEndpoint{l2, l1}},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node2.Close()
+
+	evt := <-node1.Events()
+	require.IsType(t, &EventChannelOpen{}, evt)
+
+	go func() {
+		for range node1.Events() {
+		}
+	}()
+
+	nextHeartbeat := func() *common.MessageHeartbeat {
+		for evt := range node2.Events() {
+			if fr, ok := evt.(*EventFrame); ok {
+				if m, ok := fr.Message().(*common.MessageHeartbeat); ok {
+					return m
+				}
+			}
+		}
+		return nil
+	}
+
+	node1.SetMode(int(common.MAV_MODE_FLAG_CUSTOM_MODE_ENABLED|common.MAV_MODE_FLAG_SAFETY_ARMED), 4)
+
+	require.Equal(t, &common.MessageHeartbeat{
+		Type:           common.MAV_TYPE_ONBOARD_CONTROLLER,
+		Autopilot:      common.MAV_AUTOPILOT_INVALID,
+		BaseMode:       common.MAV_MODE_FLAG_CUSTOM_MODE_ENABLED | common.MAV_MODE_FLAG_SAFETY_ARMED,
+		CustomMode:     4,
+		SystemStatus:   common.MAV_STATE_STANDBY,
+		MavlinkVersion: 3,
+	}, nextHeartbeat())
+
+	node1.SetSystemStatus(int(common.MAV_STATE_CRITICAL))
+
+	require.Equal(t, common.MAV_STATE_CRITICAL, nextHeartbeat().SystemStatus)
+}
+
+func TestNodeHeartbeatProvider(t *testing.T) {
+	l1 := newTestPipe()
+	l2 := newTestPipe()
+
+	var provided uint32
+
+	node1, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 10,
+		Endpoints: []EndpointConf{
+			EndpointCustom{ReadWriteCloser: &testEndpoint{l1, l2}},
+		},
+		HeartbeatPeriod: 100 * time.Millisecond,
+		HeartbeatProvider: func() *HeartbeatState {
+			return &HeartbeatState{
+				BaseMode:     int(common.MAV_MODE_FLAG_SAFETY_ARMED),
+				CustomMode:   atomic.AddUint32(&provided, 1),
+				SystemStatus: int(common.MAV_STATE_ACTIVE),
+			}
+		},
+	})
+	require.NoError(t, err)
+	defer node1.Close()
+
+	node2, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 11,
+		Endpoints: []EndpointConf{
+			EndpointCustom{ReadWriteCloser: &testEndpoint{l2, l1}},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node2.Close()
+
+	go func() {
+		for range node1.Events() {
+		}
+	}()
+
+	var modes []uint32
+	for evt := range node2.Events() {
+		if fr, ok := evt.(*EventFrame); ok {
+			m := fr.Message().(*common.MessageHeartbeat)
+			require.Equal(t, common.MAV_MODE_FLAG_SAFETY_ARMED, m.BaseMode)
+			require.Equal(t, common.MAV_STATE_ACTIVE, m.SystemStatus)
+			modes = append(modes, m.CustomMode)
+			if len(modes) == 2 {
+				break
+			}
+		}
+	}
+
+	// the provider is called before each heartbeat
+	require.True(t, modes[1] > modes[0])
+}
+
+func TestNodeHeartbeatEndpointDisable(t *testing.T) {
+	l1 := newTestPipe()
+	l2 := newTestPipe()
+	l3 := newTestPipe()
+	l4 := newTestPipe()
+
+	node, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 10,
+		Endpoints: []EndpointConf{
+			EndpointCustom{ReadWriteCloser: &testEndpoint{l1, l2}},
+			EndpointHeartbeatDisable{
+				Endpoint: EndpointCustom{ReadWriteCloser: &testEndpoint{l3, l4}},
+			},
+		},
+		HeartbeatPeriod: 100 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	defer l2.Close()
+	defer node.Close()
+
+	go func() {
+		for range node.Events() {
+		}
+	}()
+
+	// heartbeats are written to the first endpoint only
+	for i := 0; i < 3; i++ {
+		buf := <-l2.ch
+		require.Equal(t, byte(0), buf[7])
+	}
+
+	go func() {
+		for {
+			select {
+			case <-l2.ch:
+			case <-l2.done:
+				return
+			}
+		}
+	}()
+
+	select {
+	case <-l4.ch:
+		t.Fatal("unexpected write")
+	default:
+	}
+
+	node.WriteMessageAll(&common.MessageParamValue{ParamType: common.MAV_PARAM_TYPE_REAL32})
+
+	buf := <-l4.ch
+	require.Equal(t, byte(22), buf[7])
+
+}
+
+func TestNodeHeartbeatActivePeersOnly(t *testing.T) {
+	l1 := newTestPipe()
+	l2 := newTestPipe()
+
+	node1, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 10,
+		Endpoints: []EndpointConf{
+			EndpointCustom{ReadWriteCloser: &testEndpoint{l1, l2}},
+		},
+		HeartbeatPeriod:          100 * time.Millisecond,
+		HeartbeatActivePeersOnly: true,
+	})
+	require.NoError(t, err)
+	defer node1.Close()
+
+	node2, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 11,
+		Endpoints: []EndpointConf{
+			EndpointCustom{ReadWriteCloser: &testEndpoint{l2, l1}},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node2.Close()
+
+	go func() {
+		for range node1.Events() {
+		}
+	}()
+
+	evt := <-node2.Events()
+	require.IsType(t, &EventChannelOpen{}, evt)
+
+	// the remote node is passive
+	select {
+	case evt := <-node2.Events():
+		t.Fatalf("unexpected event %v", evt)
+	case <-time.After(300 * time.Millisecond):
+	}
+
+	node2.WriteMessageAll(&common.MessageHeartbeat{})
+
+	evt = <-node2.Events()
+	fr, ok := evt.(*EventFrame)
+	require.True(t, ok)
+	require.IsType(t, &common.MessageHeartbeat{}, fr.Message())
+}
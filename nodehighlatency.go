@@ -0,0 +1,93 @@
+package gomavlib
+
+import (
+	"github.com/aler9/gomavlib/pkg/frame"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+// highLatencyWrite is a message that is written to high latency channels only.
+type highLatencyWrite struct {
+	m msg.Message
+}
+
+// highLatencyAllowed checks whether a message can be written to high latency
+// channels, besides HIGH_LATENCY2 messages.
+func highLatencyAllowed(what interface{}) bool {
+	var m msg.Message
+	switch wh := what.(type) {
+	case msg.Message:
+		m = wh
+
+	case frame.Frame:
+		m = wh.GetMessage()
+	}
+
+	if m == nil {
+		return false
+	}
+
+	switch m.GetID() {
+	case 0, // HEARTBEAT
+		77: // COMMAND_ACK
+		return true
+	}
+	return false
+}
+
+type nodeHighLatency struct {
+	n               *Node
+	msgHighLatency2 msg.Message
+
+	// in
+	terminate chan struct{}
+
+	// out
+	done chan struct{}
+}
+
+func newNodeHighLatency(n *Node) *nodeHighLatency {
+	// module is disabled
+	if n.conf.HighLatencyProvider == nil {
+		return nil
+	}
+
+	// high latency message must exist in dialect and correspond to standard
+	msgHighLatency2 := dialectMessage(n.conf.Dialect, 235, 179)
+	if msgHighLatency2 == nil {
+		return nil
+	}
+
+	return &nodeHighLatency{
+		n:               n,
+		msgHighLatency2: msgHighLatency2,
+		terminate:       make(chan struct{}),
+		done:            make(chan struct{}),
+	}
+}
+
+func (h *nodeHighLatency) close() {
+	close(h.terminate)
+	<-h.done
+}
+
+func (h *nodeHighLatency) run() {
+	defer close(h.done)
+
+	tickerC, stopTicker := h.n.conf.Clock.NewTicker(h.n.conf.HighLatencyPeriod)
+	defer stopTicker()
+
+	for {
+		select {
+		case <-tickerC:
+			s := h.n.conf.HighLatencyProvider()
+			if s == nil {
+				continue
+			}
+
+			h.n.writeAll(highLatencyWrite{highLatencyEncode(h.msgHighLatency2, s)})
+
+		case <-h.terminate:
+			return
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package gomavlib
+
+import (
+	"sort"
+	"sync"
+)
+
+// MissionProgress is the progress of the mission of a remote system.
+type MissionProgress struct {
+	// the channel from which the last progress message was received
+	Channel *Channel
+	// the system id of the remote system
+	SystemID byte
+	// the component id of the remote system
+	ComponentID byte
+
+	// the index of the current item
+	Current int
+	// the index of the last reached item, or -1 if no item has been reached
+	Reached int
+	// the number of items of the mission, or zero if unknown. It is read
+	// from MISSION_CURRENT, when available, or from MISSION_COUNT.
+	Total int
+	// whether the mission has been completed
+	Complete bool
+}
+
+type nodeMissionProgress struct {
+	n       *Node
+	mutex   sync.Mutex
+	entries map[systemKey]*MissionProgress
+}
+
+func newNodeMissionProgress(n *Node) *nodeMissionProgress {
+	// module is disabled
+	if !n.conf.MissionProgressEnable {
+		return nil
+	}
+
+	// mission messages must exist in dialect and correspond to standard
+	if dialectMessage(n.conf.Dialect, 42, 28) == nil ||
+		dialectMessage(n.conf.Dialect, 46, 11) == nil {
+		return nil
+	}
+
+	return &nodeMissionProgress{
+		n:       n,
+		entries: make(map[systemKey]*MissionProgress),
+	}
+}
+
+func (p *nodeMissionProgress) entry(evt *EventFrame) *MissionProgress {
+	key := systemKey{
+		SystemID:    evt.SystemID(),
+		ComponentID: evt.ComponentID(),
+	}
+
+	e, ok := p.entries[key]
+	if !ok {
+		e = &MissionProgress{
+			SystemID:    key.SystemID,
+			ComponentID: key.ComponentID,
+			Current:     -1,
+			Reached:     -1,
+		}
+		p.entries[key] = e
+	}
+	e.Channel = evt.Channel
+	return e
+}
+
+// setTotal sets the number of items of a mission that has been uploaded by
+// the node.
+func (p *nodeMissionProgress) setTotal(t Target, total int) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	key := systemKey{
+		SystemID:    t.SystemID,
+		ComponentID: t.ComponentID,
+	}
+
+	e, ok := p.entries[key]
+	if !ok {
+		e = &MissionProgress{
+			Channel:     t.Channel,
+			SystemID:    key.SystemID,
+			ComponentID: key.ComponentID,
+			Current:     -1,
+			Reached:     -1,
+		}
+		p.entries[key] = e
+	}
+
+	e.Total = total
+	e.Complete = false
+}
+
+func (p *nodeMissionProgress) onEventFrame(evt *EventFrame) {
+	m := evt.Message()
+
+	var events []Event
+
+	func() {
+		switch m.GetID() {
+		case 42: // MISSION_CURRENT
+			p.mutex.Lock()
+			defer p.mutex.Unlock()
+
+			e := p.entry(evt)
+			seq := int(messageGetInt(m, "Seq"))
+
+			// newer versions of the message contain the total and the state
+			if messageGet(m, "Total").IsValid() {
+				if total := messageGetInt(m, "Total"); total != 0 && total != 0xFFFF {
+					e.Total = int(total)
+				}
+			}
+
+			if seq != e.Current {
+				e.Current = seq
+				e.Complete = false
+				events = append(events, &EventMissionCurrent{
+					Channel:     evt.Channel,
+					SystemID:    e.SystemID,
+					ComponentID: e.ComponentID,
+					Seq:         seq,
+				})
+			}
+
+			if messageGet(m, "MissionState").IsValid() &&
+				messageGetInt(m, "MissionState") == 5 && // MISSION_STATE_COMPLETE
+				!e.Complete {
+				e.Complete = true
+				events = append(events, &EventMissionComplete{
+					Channel:     evt.Channel,
+					SystemID:    e.SystemID,
+					ComponentID: e.ComponentID,
+				})
+			}
+
+		case 46: // MISSION_ITEM_REACHED
+			p.mutex.Lock()
+			defer p.mutex.Unlock()
+
+			e := p.entry(evt)
+			seq := int(messageGetInt(m, "Seq"))
+			e.Reached = seq
+
+			events = append(events, &EventMissionItemReached{
+				Channel:     evt.Channel,
+				SystemID:    e.SystemID,
+				ComponentID: e.ComponentID,
+				Seq:         seq,
+			})
+
+			if e.Total != 0 && seq == e.Total-1 && !e.Complete {
+				e.Complete = true
+				events = append(events, &EventMissionComplete{
+					Channel:     evt.Channel,
+					SystemID:    e.SystemID,
+					ComponentID: e.ComponentID,
+				})
+			}
+
+		case 44: // MISSION_COUNT
+			// the message is sent by the remote system when its mission
+			// is downloaded
+			if messageGet(m, "MissionType").IsValid() &&
+				MissionType(messageGetInt(m, "MissionType")) != MissionTypeMission {
+				return
+			}
+
+			p.mutex.Lock()
+			defer p.mutex.Unlock()
+
+			e := p.entry(evt)
+			e.Total = int(messageGetInt(m, "Count"))
+		}
+	}()
+
+	for _, evt := range events {
+		p.n.events <- evt
+	}
+}
+
+func (p *nodeMissionProgress) get() []MissionProgress {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	ret := make([]MissionProgress, 0, len(p.entries))
+	for _, e := range p.entries {
+		ret = append(ret, *e)
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].SystemID != ret[j].SystemID {
+			return ret[i].SystemID < ret[j].SystemID
+		}
+		return ret[i].ComponentID < ret[j].ComponentID
+	})
+
+	return ret
+}
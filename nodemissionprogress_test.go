@@ -0,0 +1,86 @@
+package gomavlib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+)
+
+func TestNodeMissionProgress(t *testing.T) {
+	l1 := newTestPipe()
+	l2 := newTestPipe()
+
+	gcs, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 10,
+		Endpoints: []EndpointConf{
+			EndpointCustom{ReadWriteCloser: &testEndpoint{l1, l2}},
+		},
+		HeartbeatDisable:      true,
+		MissionProgressEnable: true,
+	})
+	require.NoError(t, err)
+	defer gcs.Close()
+
+	vehicle, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 11,
+		Endpoints: []EndpointConf{
+			EndpointCustom{ReadWriteCloser: &testEndpoint{l2, l1}},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer vehicle.Close()
+
+	go func() {
+		for range vehicle.Events() {
+		}
+	}()
+
+	evt := <-gcs.Events()
+	require.IsType(t, &EventChannelOpen{}, evt)
+	ch := evt.(*EventChannelOpen).Channel
+
+	go func() {
+		vehicle.WriteMessageAll(&common.MessageMissionCount{Count: 2})
+		vehicle.WriteMessageAll(&common.MessageMissionCurrent{Seq: 0})
+		vehicle.WriteMessageAll(&common.MessageMissionCurrent{Seq: 0})
+		vehicle.WriteMessageAll(&common.MessageMissionItemReached{Seq: 0})
+		vehicle.WriteMessageAll(&common.MessageMissionCurrent{Seq: 1})
+		vehicle.WriteMessageAll(&common.MessageMissionItemReached{Seq: 1})
+	}()
+
+	var events []Event
+	for evt := range gcs.Events() {
+		if _, ok := evt.(*EventFrame); ok {
+			continue
+		}
+		events = append(events, evt)
+		if _, ok := evt.(*EventMissionComplete); ok {
+			break
+		}
+	}
+
+	require.Equal(t, []Event{
+		&EventMissionCurrent{Channel: ch, SystemID: 11, ComponentID: 1, Seq: 0},
+		&EventMissionItemReached{Channel: ch, SystemID: 11, ComponentID: 1, Seq: 0},
+		&EventMissionCurrent{Channel: ch, SystemID: 11, ComponentID: 1, Seq: 1},
+		&EventMissionItemReached{Channel: ch, SystemID: 11, ComponentID: 1, Seq: 1},
+		&EventMissionComplete{Channel: ch, SystemID: 11, ComponentID: 1},
+	}, events)
+
+	require.Equal(t, []MissionProgress{{
+		Channel:     ch,
+		SystemID:    11,
+		ComponentID: 1,
+		Current:     1,
+		Reached:     1,
+		Total:       2,
+		Complete:    true,
+	}}, gcs.MissionProgress())
+}
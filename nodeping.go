@@ -0,0 +1,50 @@
+package gomavlib
+
+import (
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+// nodePing answers PING requests, as required by the ping protocol.
+// https://mavlink.io/en/services/ping.html
+type nodePing struct {
+	n       *Node
+	msgPing msg.Message
+}
+
+func newNodePing(n *Node) *nodePing {
+	// module is disabled
+	if n.conf.PingResponseDisable {
+		return nil
+	}
+
+	// ping message must exist in dialect and correspond to standard
+	msgPing := dialectMessage(n.conf.Dialect, 4, 237)
+	if msgPing == nil {
+		return nil
+	}
+
+	return &nodePing{
+		n:       n,
+		msgPing: msgPing,
+	}
+}
+
+func (p *nodePing) onEventFrame(evt *EventFrame) {
+	m := evt.Message()
+	if m.GetID() != 4 {
+		return
+	}
+
+	// requests are addressed to all systems, while responses are addressed
+	// to the requester
+	if messageGetInt(m, "TargetSystem") != 0 || messageGetInt(m, "TargetComponent") != 0 {
+		return
+	}
+
+	res := newMessage(p.msgPing)
+	messageSet(res, "TimeUsec", messageGetInt(m, "TimeUsec"))
+	messageSet(res, "Seq", messageGetInt(m, "Seq"))
+	messageSet(res, "TargetSystem", evt.SystemID())
+	messageSet(res, "TargetComponent", evt.ComponentID())
+	p.n.WriteMessageTo(evt.Channel, res)
+}
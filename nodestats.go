@@ -0,0 +1,50 @@
+package gomavlib
+
+type nodeStats struct {
+	n *Node
+
+	// in
+	terminate chan struct{}
+
+	// out
+	done chan struct{}
+}
+
+func newNodeStats(n *Node) *nodeStats {
+	// module is disabled
+	if n.conf.StatsPeriod <= 0 {
+		return nil
+	}
+
+	return &nodeStats{
+		n:         n,
+		terminate: make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+}
+
+func (s *nodeStats) close() {
+	close(s.terminate)
+	<-s.done
+}
+
+func (s *nodeStats) run() {
+	defer close(s.done)
+
+	tickerC, stopTicker := s.n.conf.Clock.NewTicker(s.n.conf.StatsPeriod)
+	defer stopTicker()
+
+	for {
+		select {
+		case <-tickerC:
+			channels, total := s.n.nodeChannelStats.getTotal()
+			s.n.events <- &EventStats{
+				Channels: channels,
+				Total:    total,
+			}
+
+		case <-s.terminate:
+			return
+		}
+	}
+}
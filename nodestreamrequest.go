@@ -34,7 +34,7 @@ type nodeStreamRequest struct {
 
 func newNodeStreamRequest(n *Node) *nodeStreamRequest {
 	// module is disabled
-	if !n.conf.StreamRequestEnable {
+	if !n.conf.StreamRequestEnable && !endpointsRequestStreams(n) {
 		return nil
 	}
 
@@ -89,6 +89,22 @@ func newNodeStreamRequest(n *Node) *nodeStreamRequest {
 	return sr
 }
 
+// endpointsRequestStreams checks whether any endpoint requests streams
+// regardless of the node configuration, like EndpointSITL.
+func endpointsRequestStreams(n *Node) bool {
+	for ch := range n.channels {
+		if ch.streamFreq != 0 {
+			return true
+		}
+	}
+	for ca := range n.channelAccepters {
+		if endpointChannelOptions(ca.eca).streamRequest {
+			return true
+		}
+	}
+	return false
+}
+
 func (sr *nodeStreamRequest) close() {
 	close(sr.terminate)
 	<-sr.done
@@ -97,13 +113,13 @@ func (sr *nodeStreamRequest) close() {
 func (sr *nodeStreamRequest) run() {
 	defer close(sr.done)
 
-	ticker := time.NewTicker(30 * time.Second)
-	defer ticker.Stop()
+	tickerC, stopTicker := sr.n.conf.Clock.NewTicker(30 * time.Second)
+	defer stopTicker()
 
 	for {
 		select {
 		// periodic cleanup
-		case now := <-ticker.C:
+		case now := <-tickerC:
 			func() {
 				sr.lastRequestsMutex.Lock()
 				defer sr.lastRequestsMutex.Unlock()
@@ -128,6 +144,14 @@ func (sr *nodeStreamRequest) onEventFrame(evt *EventFrame) {
 		return
 	}
 
+	frequency := evt.Channel.streamFreq
+	if frequency == 0 {
+		if !sr.n.conf.StreamRequestEnable {
+			return
+		}
+		frequency = sr.n.conf.StreamRequestFrequency
+	}
+
 	rnode := streamNode{
 		Channel:     evt.Channel,
 		SystemID:    evt.SystemID(),
@@ -140,10 +164,10 @@ func (sr *nodeStreamRequest) onEventFrame(evt *EventFrame) {
 		sr.lastRequestsMutex.Lock()
 		defer sr.lastRequestsMutex.Unlock()
 
-		now := time.Now()
+		now := sr.n.conf.Clock.Now()
 
 		if _, ok := sr.lastRequests[rnode]; !ok {
-			sr.lastRequests[rnode] = time.Now()
+			sr.lastRequests[rnode] = now
 			request = true
 
 		} else if now.Sub(sr.lastRequests[rnode]) >= streamRequestPeriod {
@@ -169,7 +193,7 @@ func (sr *nodeStreamRequest) onEventFrame(evt *EventFrame) {
 			m.Elem().FieldByName("TargetSystem").SetUint(uint64(evt.SystemID()))
 			m.Elem().FieldByName("TargetComponent").SetUint(uint64(evt.ComponentID()))
 			m.Elem().FieldByName("ReqStreamId").SetUint(uint64(stream))
-			m.Elem().FieldByName("ReqMessageRate").SetUint(uint64(sr.n.conf.StreamRequestFrequency))
+			m.Elem().FieldByName("ReqMessageRate").SetUint(uint64(frequency))
 			m.Elem().FieldByName("StartStop").SetUint(uint64(1))
 			sr.n.WriteMessageTo(evt.Channel, m.Interface().(msg.Message))
 		}
@@ -0,0 +1,69 @@
+package gomavlib
+
+import (
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+type nodeSysStatus struct {
+	n                   *Node
+	msgSysStatus        msg.Message
+	msgExtendedSysState msg.Message
+
+	// in
+	terminate chan struct{}
+
+	// out
+	done chan struct{}
+}
+
+func newNodeSysStatus(n *Node) *nodeSysStatus {
+	// module is disabled
+	if n.conf.SysStatusProvider == nil {
+		return nil
+	}
+
+	// sys status message must exist in dialect and correspond to standard
+	msgSysStatus := dialectMessage(n.conf.Dialect, 1, 124)
+	if msgSysStatus == nil {
+		return nil
+	}
+
+	return &nodeSysStatus{
+		n:                   n,
+		msgSysStatus:        msgSysStatus,
+		msgExtendedSysState: dialectMessage(n.conf.Dialect, 245, 130),
+		terminate:           make(chan struct{}),
+		done:                make(chan struct{}),
+	}
+}
+
+func (s *nodeSysStatus) close() {
+	close(s.terminate)
+	<-s.done
+}
+
+func (s *nodeSysStatus) run() {
+	defer close(s.done)
+
+	tickerC, stopTicker := s.n.conf.Clock.NewTicker(s.n.conf.SysStatusPeriod)
+	defer stopTicker()
+
+	for {
+		select {
+		case <-tickerC:
+			st := s.n.conf.SysStatusProvider()
+			if st == nil {
+				continue
+			}
+
+			s.n.WriteMessageAll(sysStatusEncode(s.msgSysStatus, st))
+
+			if st.Extended != nil && s.msgExtendedSysState != nil {
+				s.n.WriteMessageAll(extendedSysStateEncode(s.msgExtendedSysState, st.Extended))
+			}
+
+		case <-s.terminate:
+			return
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package gomavlib
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+// RemoteSystem describes a remote system that is sending heartbeats.
+type RemoteSystem struct {
+	// the channel from which the last frame was received
+	Channel *Channel
+	// the system id of the remote system
+	SystemID byte
+	// the component id of the remote system
+	ComponentID byte
+
+	// MAV_TYPE, from the last heartbeat
+	Type int
+	// MAV_AUTOPILOT, from the last heartbeat
+	Autopilot int
+	// bitmask of MAV_PROTOCOL_CAPABILITY, from the last AUTOPILOT_VERSION
+	// message. It is valid only if CapabilitiesKnown is true.
+	Capabilities uint64
+	// whether an AUTOPILOT_VERSION message has been received
+	CapabilitiesKnown bool
+
+	// the time at which the last frame was received
+	LastSeen time.Time
+}
+
+type systemKey struct {
+	SystemID    byte
+	ComponentID byte
+}
+
+type systemEntry struct {
+	system        RemoteSystem
+	lastHeartbeat time.Time
+}
+
+type nodeSystems struct {
+	n                   *Node
+	msgAutopilotVersion msg.Message
+	mutex               sync.Mutex
+	entries             map[systemKey]*systemEntry
+
+	// in
+	terminate chan struct{}
+
+	// out
+	done chan struct{}
+}
+
+func newNodeSystems(n *Node) *nodeSystems {
+	// module is disabled
+	if !n.conf.SystemTrackingEnable {
+		return nil
+	}
+
+	// heartbeat message must exist in dialect and correspond to standard
+	if dialectMessage(n.conf.Dialect, 0, 50) == nil {
+		return nil
+	}
+
+	return &nodeSystems{
+		n:                   n,
+		msgAutopilotVersion: dialectMessage(n.conf.Dialect, 148, 178),
+		entries:             make(map[systemKey]*systemEntry),
+		terminate:           make(chan struct{}),
+		done:                make(chan struct{}),
+	}
+}
+
+func (s *nodeSystems) close() {
+	close(s.terminate)
+	<-s.done
+}
+
+func (s *nodeSystems) run() {
+	defer close(s.done)
+
+	tickerC, stopTicker := s.n.conf.Clock.NewTicker(s.n.conf.SystemTimeout / 4)
+	defer stopTicker()
+
+	for {
+		select {
+		case now := <-tickerC:
+			for _, evt := range s.expire(now) {
+				s.n.events <- evt
+			}
+
+		case <-s.terminate:
+			return
+		}
+	}
+}
+
+// expire removes systems that have not sent a heartbeat in time.
+func (s *nodeSystems) expire(now time.Time) []*EventSystemOffline {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var ret []*EventSystemOffline
+
+	for key, entry := range s.entries {
+		if now.Sub(entry.lastHeartbeat) >= s.n.conf.SystemTimeout {
+			delete(s.entries, key)
+			ret = append(ret, &EventSystemOffline{
+				Channel:     entry.system.Channel,
+				SystemID:    key.SystemID,
+				ComponentID: key.ComponentID,
+			})
+		}
+	}
+
+	return ret
+}
+
+func (s *nodeSystems) get() []RemoteSystem {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	ret := make([]RemoteSystem, 0, len(s.entries))
+	for _, entry := range s.entries {
+		ret = append(ret, entry.system)
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].SystemID != ret[j].SystemID {
+			return ret[i].SystemID < ret[j].SystemID
+		}
+		return ret[i].ComponentID < ret[j].ComponentID
+	})
+
+	return ret
+}
+
+func (s *nodeSystems) onEventFrame(evt *EventFrame) {
+	key := systemKey{
+		SystemID:    evt.SystemID(),
+		ComponentID: evt.ComponentID(),
+	}
+	m := evt.Message()
+	now := s.n.conf.Clock.Now()
+
+	online := func() bool {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+
+		entry, ok := s.entries[key]
+
+		// systems are added when they send a heartbeat
+		if m.GetID() != 0 {
+			if ok {
+				entry.system.Channel = evt.Channel
+				entry.system.LastSeen = now
+
+				if s.msgAutopilotVersion != nil &&
+					m.GetID() == s.msgAutopilotVersion.GetID() {
+					entry.system.Capabilities = uint64(messageGetInt(m, "Capabilities"))
+					entry.system.CapabilitiesKnown = true
+				}
+			}
+			return false
+		}
+
+		if !ok {
+			entry = &systemEntry{
+				system: RemoteSystem{
+					SystemID:    key.SystemID,
+					ComponentID: key.ComponentID,
+				},
+			}
+			s.entries[key] = entry
+		}
+
+		entry.system.Channel = evt.Channel
+		entry.system.Type = int(messageGetInt(m, "Type"))
+		entry.system.Autopilot = int(messageGetInt(m, "Autopilot"))
+		entry.system.LastSeen = now
+		entry.lastHeartbeat = now
+		return !ok
+	}()
+
+	if online {
+		s.n.events <- &EventSystemOnline{
+			Channel:     evt.Channel,
+			SystemID:    key.SystemID,
+			ComponentID: key.ComponentID,
+		}
+	}
+}
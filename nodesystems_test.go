@@ -0,0 +1,154 @@
+package gomavlib
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+)
+
+func TestNodeSystemOnlineOffline(t *testing.T) {
+	l1 := newTestPipe()
+	l2 := newTestPipe()
+
+	gcs, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 10,
+		Endpoints: []EndpointConf{
+			EndpointCustom{ReadWriteCloser: &testEndpoint{l1, l2}},
+		},
+		HeartbeatDisable:     true,
+		SystemTrackingEnable: true,
+		SystemTimeout:        200 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	defer gcs.Close()
+
+	vehicle, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 11,
+		Endpoints: []EndpointConf{
+			EndpointCustom{ReadWriteCloser: &testEndpoint{l2, l1}},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer vehicle.Close()
+
+	go func() {
+		for range vehicle.Events() {
+		}
+	}()
+
+	evt := <-gcs.Events()
+	require.IsType(t, &EventChannelOpen{}, evt)
+	ch := evt.(*EventChannelOpen).Channel
+
+	for i := 0; i < 3; i++ {
+		vehicle.WriteMessageAll(&common.MessageHeartbeat{
+			Type:      common.MAV_TYPE_QUADROTOR,
+			Autopilot: common.MAV_AUTOPILOT_PX4,
+		})
+
+		evt = <-gcs.Events()
+		if i == 0 {
+			require.Equal(t, &EventSystemOnline{
+				Channel:     ch,
+				SystemID:    11,
+				ComponentID: 1,
+			}, evt)
+			evt = <-gcs.Events()
+		}
+		require.IsType(t, &EventFrame{}, evt)
+
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	start := time.Now()
+	evt = <-gcs.Events()
+	require.Equal(t, &EventSystemOffline{
+		Channel:     ch,
+		SystemID:    11,
+		ComponentID: 1,
+	}, evt)
+	require.True(t, time.Since(start) >= 100*time.Millisecond)
+}
+
+func TestNodeSystems(t *testing.T) {
+	l1 := newTestPipe()
+	l2 := newTestPipe()
+
+	gcs, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 10,
+		Endpoints: []EndpointConf{
+			EndpointCustom{ReadWriteCloser: &testEndpoint{l1, l2}},
+		},
+		HeartbeatDisable:     true,
+		SystemTrackingEnable: true,
+	})
+	require.NoError(t, err)
+	defer gcs.Close()
+
+	vehicle, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 11,
+		Endpoints: []EndpointConf{
+			EndpointCustom{ReadWriteCloser: &testEndpoint{l2, l1}},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer vehicle.Close()
+
+	go func() {
+		for range vehicle.Events() {
+		}
+	}()
+
+	evt := <-gcs.Events()
+	require.IsType(t, &EventChannelOpen{}, evt)
+	ch := evt.(*EventChannelOpen).Channel
+
+	require.Equal(t, []RemoteSystem{}, gcs.Systems())
+
+	vehicle.WriteMessageAll(&common.MessageHeartbeat{
+		Type:      common.MAV_TYPE_QUADROTOR,
+		Autopilot: common.MAV_AUTOPILOT_PX4,
+	})
+	vehicle.WriteMessageAll(&common.MessageAutopilotVersion{
+		Capabilities: common.MAV_PROTOCOL_CAPABILITY_MISSION_INT |
+			common.MAV_PROTOCOL_CAPABILITY_MAVLINK2,
+	})
+
+	frames := 0
+	for evt := range gcs.Events() {
+		if _, ok := evt.(*EventFrame); ok {
+			frames++
+			if frames == 2 {
+				break
+			}
+		}
+	}
+
+	systems := gcs.Systems()
+	require.Equal(t, 1, len(systems))
+	require.True(t, time.Since(systems[0].LastSeen) < 5*time.Second)
+	systems[0].LastSeen = time.Time{}
+	require.Equal(t, RemoteSystem{
+		Channel:     ch,
+		SystemID:    11,
+		ComponentID: 1,
+		Type:        int(common.MAV_TYPE_QUADROTOR),
+		Autopilot:   int(common.MAV_AUTOPILOT_PX4),
+		Capabilities: uint64(common.MAV_PROTOCOL_CAPABILITY_MISSION_INT |
+			common.MAV_PROTOCOL_CAPABILITY_MAVLINK2),
+		CapabilitiesKnown: true,
+	}, systems[0])
+}
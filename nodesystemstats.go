@@ -0,0 +1,234 @@
+package gomavlib
+
+import (
+	"sync"
+	"time"
+
+	"github.com/aler9/gomavlib/pkg/frame"
+)
+
+// frames whose sequence id is behind the last one by less than this amount
+// are considered reordered or duplicated, instead of lost.
+const systemStatsReorderWindow = 16
+
+// weight of the last interval in the average interval between messages,
+// that is used to estimate message rates.
+const systemStatsRateWeight = 0.2
+
+// SystemStats contains statistics about a remote system, identified by the
+// channel from which its frames are received, its system id and its
+// component id.
+type SystemStats struct {
+	// the channel from which frames are received
+	Channel *Channel
+	// the system id of the remote system
+	SystemID byte
+	// the component id of the remote system
+	ComponentID byte
+
+	// number of frames received
+	FramesReceived uint64
+	// number of frames lost, computed by analyzing sequence numbers
+	FramesLost uint64
+	// number of gaps detected in sequence numbers
+	Gaps uint64
+	// observed receive rate, in Hz, of each message ID. Rates are estimated
+	// from the average interval between messages, therefore at least two
+	// messages are needed. The rate of a message that stops being received
+	// decays over time.
+	MessageRates map[uint32]float64
+}
+
+// LossPercentage returns the percentage of lost frames with respect to the
+// expected ones.
+func (s SystemStats) LossPercentage() float64 {
+	expected := s.FramesReceived + s.FramesLost
+	if expected == 0 {
+		return 0
+	}
+	return float64(s.FramesLost) * 100 / float64(expected)
+}
+
+func frameSequenceID(fr frame.Frame) byte {
+	switch ff := fr.(type) {
+	case *frame.V1Frame:
+		return ff.SequenceID
+
+	case *frame.V2Frame:
+		return ff.SequenceID
+	}
+	return 0
+}
+
+type messageRate struct {
+	last     time.Time
+	interval float64 // average interval in seconds, zero if unknown
+}
+
+func (r *messageRate) update(now time.Time) {
+	if !r.last.IsZero() {
+		cur := now.Sub(r.last).Seconds()
+		if r.interval == 0 {
+			r.interval = cur
+		} else {
+			r.interval += (cur - r.interval) * systemStatsRateWeight
+		}
+	}
+	r.last = now
+}
+
+func (r *messageRate) get(now time.Time) float64 {
+	if r.interval <= 0 {
+		return 0
+	}
+
+	// when a message is late, the rate is computed from the time elapsed
+	// since the last one, in order to detect streams that stopped
+	interval := r.interval
+	if since := now.Sub(r.last).Seconds(); since > 2*interval {
+		interval = since
+	}
+
+	return 1 / interval
+}
+
+type systemStatsKey struct {
+	Channel     *Channel
+	SystemID    byte
+	ComponentID byte
+}
+
+type systemStatsEntry struct {
+	stats          SystemStats
+	lastSequenceID byte
+	rates          map[uint32]*messageRate
+}
+
+func (e *systemStatsEntry) updateRate(id uint32, now time.Time) {
+	r, ok := e.rates[id]
+	if !ok {
+		r = &messageRate{}
+		e.rates[id] = r
+	}
+	r.update(now)
+}
+
+type nodeSystemStats struct {
+	clock   Clock
+	mutex   sync.Mutex
+	entries map[systemStatsKey]*systemStatsEntry
+}
+
+func newNodeSystemStats(clock Clock) *nodeSystemStats {
+	return &nodeSystemStats{
+		clock:   clock,
+		entries: make(map[systemStatsKey]*systemStatsEntry),
+	}
+}
+
+// onEventFrame updates statistics and returns the number of frames that
+// have been lost before the given one.
+func (s *nodeSystemStats) onEventFrame(evt *EventFrame) int {
+	seq := frameSequenceID(evt.Frame)
+	now := s.clock.Now()
+
+	key := systemStatsKey{
+		Channel:     evt.Channel,
+		SystemID:    evt.SystemID(),
+		ComponentID: evt.ComponentID(),
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	entry, ok := s.entries[key]
+	if !ok {
+		entry = &systemStatsEntry{
+			stats: SystemStats{
+				Channel:     evt.Channel,
+				SystemID:    evt.SystemID(),
+				ComponentID: evt.ComponentID(),
+			},
+			rates: make(map[uint32]*messageRate),
+		}
+		s.entries[key] = entry
+		entry.stats.FramesReceived++
+		entry.lastSequenceID = seq
+		entry.updateRate(evt.Message().GetID(), now)
+		return 0
+	}
+
+	entry.stats.FramesReceived++
+	entry.updateRate(evt.Message().GetID(), now)
+
+	// a sequence id that is equal or slightly behind the last one belongs
+	// to a duplicated or reordered frame, not to a loss
+	delta := seq - entry.lastSequenceID
+	if delta == 0 || delta > 255-systemStatsReorderWindow {
+		return 0
+	}
+
+	lost := int(delta) - 1
+	entry.lastSequenceID = seq
+
+	if lost > 0 {
+		entry.stats.FramesLost += uint64(lost)
+		entry.stats.Gaps++
+	}
+
+	return lost
+}
+
+func (s *nodeSystemStats) onChannelClose(ch *Channel) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for key := range s.entries {
+		if key.Channel == ch {
+			delete(s.entries, key)
+		}
+	}
+}
+
+func (s *nodeSystemStats) get() []SystemStats {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := s.clock.Now()
+
+	ret := make([]SystemStats, 0, len(s.entries))
+	for _, entry := range s.entries {
+		stats := entry.stats
+		stats.MessageRates = make(map[uint32]float64, len(entry.rates))
+		for id, r := range entry.rates {
+			stats.MessageRates[id] = r.get(now)
+		}
+		ret = append(ret, stats)
+	}
+	return ret
+}
+
+// messageRate returns the highest receive rate of a message among the
+// channels that match the target.
+func (s *nodeSystemStats) messageRate(t Target, messageID uint32) float64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := s.clock.Now()
+	var ret float64
+
+	for key, entry := range s.entries {
+		if (t.Channel != nil && t.Channel != key.Channel) ||
+			t.SystemID != key.SystemID || t.ComponentID != key.ComponentID {
+			continue
+		}
+
+		if r, ok := entry.rates[messageID]; ok {
+			if v := r.get(now); v > ret {
+				ret = v
+			}
+		}
+	}
+
+	return ret
+}
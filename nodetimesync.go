@@ -0,0 +1,190 @@
+package gomavlib
+
+import (
+	"sync"
+	"time"
+
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+const (
+	// responses that arrive later than this are discarded.
+	timesyncMaxRTT = 10 * time.Second
+
+	// weight of new samples in the offset average.
+	timesyncFilterAlpha = 0.2
+)
+
+// TimesyncEstimate is an estimate of the clock of a remote component,
+// computed with the TIMESYNC protocol.
+type TimesyncEstimate struct {
+	Channel     *Channel
+	SystemID    byte
+	ComponentID byte
+
+	// the remote clock minus the local clock, averaged over samples.
+	Offset time.Duration
+	// the round-trip time of the last sample.
+	RTT time.Duration
+	// the number of samples.
+	Samples int
+}
+
+type timesyncKey struct {
+	Channel     *Channel
+	SystemID    byte
+	ComponentID byte
+}
+
+type nodeTimesync struct {
+	n           *Node
+	msgTimesync msg.Message
+	mutex       sync.Mutex
+	estimates   map[timesyncKey]*TimesyncEstimate
+
+	// in
+	terminate chan struct{}
+
+	// out
+	done chan struct{}
+}
+
+func newNodeTimesync(n *Node) *nodeTimesync {
+	// module is disabled
+	if !n.conf.TimesyncEnable {
+		return nil
+	}
+
+	// timesync message must exist in dialect and correspond to standard
+	msgTimesync := dialectMessage(n.conf.Dialect, 111, 34)
+	if msgTimesync == nil {
+		return nil
+	}
+
+	return &nodeTimesync{
+		n:           n,
+		msgTimesync: msgTimesync,
+		estimates:   make(map[timesyncKey]*TimesyncEstimate),
+		terminate:   make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+}
+
+func (t *nodeTimesync) close() {
+	close(t.terminate)
+	<-t.done
+}
+
+func (t *nodeTimesync) run() {
+	defer close(t.done)
+
+	tickerC, stopTicker := t.n.conf.Clock.NewTicker(t.n.conf.TimesyncPeriod)
+	defer stopTicker()
+
+	for {
+		select {
+		case <-tickerC:
+			m := newMessage(t.msgTimesync)
+			messageSet(m, "Tc1", 0)
+			messageSet(m, "Ts1", t.n.conf.Clock.Now().UnixNano())
+			t.n.WriteMessageAll(m)
+
+		case <-t.terminate:
+			return
+		}
+	}
+}
+
+func (t *nodeTimesync) onEventFrame(evt *EventFrame) {
+	m := evt.Message()
+	if m.GetID() != 111 {
+		return
+	}
+
+	// newer versions of the message contain the target
+	if f := messageGet(m, "TargetSystem"); f.IsValid() && f.Uint() != 0 &&
+		byte(f.Uint()) != t.n.conf.OutSystemID {
+		return
+	}
+
+	tc1 := messageGetInt(m, "Tc1")
+	ts1 := messageGetInt(m, "Ts1")
+
+	// request: reply with the local time
+	if tc1 == 0 {
+		res := newMessage(t.msgTimesync)
+		messageSet(res, "Tc1", t.n.conf.Clock.Now().UnixNano())
+		messageSet(res, "Ts1", ts1)
+		if f := messageGet(res, "TargetSystem"); f.IsValid() {
+			messageSet(res, "TargetSystem", evt.SystemID())
+			messageSet(res, "TargetComponent", evt.ComponentID())
+		}
+		t.n.WriteMessageTo(evt.Channel, res)
+		return
+	}
+
+	// response to a request sent by the node
+	now := t.n.conf.Clock.Now().UnixNano()
+	rtt := time.Duration(now - ts1)
+	if rtt < 0 || rtt > timesyncMaxRTT {
+		return
+	}
+	offset := time.Duration(tc1 - (ts1 + int64(rtt)/2))
+
+	key := timesyncKey{
+		Channel:     evt.Channel,
+		SystemID:    evt.SystemID(),
+		ComponentID: evt.ComponentID(),
+	}
+
+	func() {
+		t.mutex.Lock()
+		defer t.mutex.Unlock()
+
+		e, ok := t.estimates[key]
+		if !ok {
+			e = &TimesyncEstimate{
+				Channel:     evt.Channel,
+				SystemID:    evt.SystemID(),
+				ComponentID: evt.ComponentID(),
+				Offset:      offset,
+			}
+			t.estimates[key] = e
+		} else {
+			e.Offset += time.Duration(float64(offset-e.Offset) * timesyncFilterAlpha)
+		}
+		e.RTT = rtt
+		e.Samples++
+	}()
+
+	evt.Channel.stats.updateRTT(rtt)
+
+	t.n.events <- &EventChannelLatency{
+		Channel:     evt.Channel,
+		SystemID:    evt.SystemID(),
+		ComponentID: evt.ComponentID(),
+		RTT:         rtt,
+	}
+}
+
+func (t *nodeTimesync) onChannelClose(ch *Channel) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	for key := range t.estimates {
+		if key.Channel == ch {
+			delete(t.estimates, key)
+		}
+	}
+}
+
+func (t *nodeTimesync) get() []TimesyncEstimate {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	ret := make([]TimesyncEstimate, 0, len(t.estimates))
+	for _, e := range t.estimates {
+		ret = append(ret, *e)
+	}
+	return ret
+}
@@ -0,0 +1,127 @@
+package gomavlib
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// frameWaiter receives frames that satisfy a condition.
+type frameWaiter struct {
+	match  func(*EventFrame) bool
+	frames chan *EventFrame
+}
+
+// nodeWaiters routes incoming frames to routines that are waiting for them,
+// like protocol clients and servers.
+type nodeWaiters struct {
+	mutex   sync.Mutex
+	waiters map[*frameWaiter]struct{}
+}
+
+func newNodeWaiters() *nodeWaiters {
+	return &nodeWaiters{
+		waiters: make(map[*frameWaiter]struct{}),
+	}
+}
+
+// add registers a waiter. Frames are delivered through a buffered channel
+// with the given size; frames are discarded when the buffer is full, in order
+// not to block channels.
+func (w *nodeWaiters) add(match func(*EventFrame) bool, size int) *frameWaiter {
+	fw := &frameWaiter{
+		match:  match,
+		frames: make(chan *EventFrame, size),
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.waiters[fw] = struct{}{}
+
+	return fw
+}
+
+func (w *nodeWaiters) remove(fw *frameWaiter) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	delete(w.waiters, fw)
+}
+
+func (w *nodeWaiters) onEventFrame(evt *EventFrame) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	for fw := range w.waiters {
+		if fw.match(evt) {
+			select {
+			case fw.frames <- evt:
+				// the event is retained by the waiter, therefore it can't
+				// be recycled.
+				evt.pooled = false
+			default:
+			}
+		}
+	}
+}
+
+// request writes a request and waits for a response, that is a frame that
+// satisfies match. The request is written again in case no response is
+// received within timeout, up to the given number of retries.
+func (n *Node) request(ctx context.Context, match func(*EventFrame) bool,
+	send func(), timeout time.Duration, retries int) (*EventFrame, error) {
+	fw := n.nodeWaiters.add(match, 1)
+	defer n.nodeWaiters.remove(fw)
+
+	for i := 0; i <= retries; i++ {
+		send()
+
+		timerC, stopTimer := n.conf.Clock.NewTimer(timeout)
+
+		select {
+		case evt := <-fw.frames:
+			stopTimer()
+			return evt, nil
+
+		case <-timerC:
+
+		case <-ctx.Done():
+			stopTimer()
+			return nil, ctx.Err()
+		}
+	}
+
+	return nil, fmt.Errorf("timeout")
+}
+
+// frameService is the lifecycle shared by protocol helpers that receive
+// frames in a dedicated routine. It registers a frame waiter and runs the
+// routine until the helper is closed; the routine must return when ctx is
+// done.
+type frameService struct {
+	node      *Node
+	fw        *frameWaiter
+	ctx       context.Context
+	ctxCancel func()
+	done      chan struct{}
+}
+
+func (s *frameService) start(n *Node, match func(*EventFrame) bool, size int, run func()) {
+	s.node = n
+	s.ctx, s.ctxCancel = context.WithCancel(context.Background())
+	s.done = make(chan struct{})
+	s.fw = n.nodeWaiters.add(match, size)
+
+	go func() {
+		defer close(s.done)
+		if run != nil {
+			run()
+		}
+	}()
+}
+
+func (s *frameService) close() {
+	s.node.nodeWaiters.remove(s.fw)
+	s.ctxCancel()
+	<-s.done
+}
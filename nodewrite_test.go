@@ -0,0 +1,462 @@
+package gomavlib
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+	"github.com/aler9/gomavlib/pkg/frame"
+)
+
+func TestNodeWriteParallel(t *testing.T) {
+	node1, node2 := newTestNodePair(t, common.Dialect)
+	defer node1.Close()
+	defer node2.Close()
+
+	go func() {
+		for range node1.Events() {
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				node1.WriteMessageAll(&common.MessageParamValue{
+					ParamIndex: uint16(i*10 + j),
+				})
+			}
+		}(i)
+	}
+
+	received := make(map[uint16]struct{})
+	for evt := range node2.Events() {
+		if fr, ok := evt.(*EventFrame); ok {
+			received[fr.Message().(*common.MessageParamValue).ParamIndex] = struct{}{}
+			if len(received) == 100 {
+				break
+			}
+		}
+	}
+
+	wg.Wait()
+}
+
+func TestNodeWriteUnknownChannel(t *testing.T) {
+	node1, node2 := newTestNodePair(t, common.Dialect)
+	defer node1.Close()
+	defer node2.Close()
+
+	go func() {
+		for range node1.Events() {
+		}
+	}()
+
+	// writes to channels that do not belong to the node are discarded
+	node1.WriteMessageTo(&Channel{}, &common.MessageParamValue{ParamIndex: 1})
+	node1.WriteMessageAll(&common.MessageParamValue{ParamIndex: 2})
+
+	for evt := range node2.Events() {
+		if fr, ok := evt.(*EventFrame); ok {
+			require.Equal(t, uint16(2), fr.Message().(*common.MessageParamValue).ParamIndex)
+			break
+		}
+	}
+}
+
+func TestNodeWriteQueueSize(t *testing.T) {
+	l1 := newTestPipe()
+	l2 := newTestPipe()
+
+	node, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 10,
+		Endpoints: []EndpointConf{
+			EndpointCustom{ReadWriteCloser: &testEndpoint{l1, l2}},
+		},
+		HeartbeatDisable: true,
+		WriteQueueSize:   10,
+	})
+	require.NoError(t, err)
+	defer node.Close()
+
+	evt := <-node.Events()
+	_, ok := evt.(*EventChannelOpen)
+	require.True(t, ok)
+
+	// the remote side is not reading, therefore writes are queued:
+	// one is pending in the writer, the others are in the queue.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 11; i++ {
+			node.WriteMessageAll(&common.MessageParamValue{ParamIndex: uint16(i)})
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("writes are blocked")
+	}
+
+	for i := 0; i < 11; i++ {
+		// frames are written in order, with increasing sequence ids
+		buf := <-l2.ch
+		require.Equal(t, byte(frame.V2MagicByte), buf[0])
+		require.Equal(t, byte(i), buf[4])
+	}
+}
+
+func TestNodeWriteBackpressure(t *testing.T) {
+	for _, ca := range []struct {
+		name     string
+		policy   BackpressurePolicy
+		received []uint16
+	}{
+		{"drop oldest", BackpressurePolicyDropOldest, []uint16{0, 8, 9}},
+		{"drop newest", BackpressurePolicyDropNewest, []uint16{0, 1, 2}},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			l1 := newTestPipe()
+			l2 := newTestPipe()
+
+			node, err := NewNode(NodeConf{
+				Dialect:     common.Dialect,
+				OutVersion:  V2,
+				OutSystemID: 10,
+				Endpoints: []EndpointConf{
+					EndpointBackpressure{
+						Endpoint:       EndpointCustom{ReadWriteCloser: &testEndpoint{l1, l2}},
+						Policy:         ca.policy,
+						WriteQueueSize: 2,
+					},
+				},
+				HeartbeatDisable: true,
+			})
+			require.NoError(t, err)
+			defer node.Close()
+
+			evt := <-node.Events()
+			_, ok := evt.(*EventChannelOpen)
+			require.True(t, ok)
+
+			write := func(i int) {
+				node.WriteMessageAll(&common.MessageParamValue{
+					ParamIndex: uint16(i),
+					ParamType:  common.MAV_PARAM_TYPE_REAL32,
+				})
+			}
+
+			// wait for the writer to be stuck on the first message
+			write(0)
+			time.Sleep(100 * time.Millisecond)
+
+			// the remote side is not reading, therefore writes never block
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				for i := 1; i < 10; i++ {
+					write(i)
+				}
+			}()
+
+			select {
+			case <-done:
+			case <-time.After(2 * time.Second):
+				t.Fatal("writes are blocked")
+			}
+
+			require.Equal(t, uint64(7), node.ChannelStats()[0].WriteDrops)
+
+			for _, index := range ca.received {
+				buf := <-l2.ch
+				require.Equal(t, index, uint16(buf[16])|uint16(buf[17])<<8)
+			}
+		})
+	}
+}
+
+func TestNodeWriteHighPriority(t *testing.T) {
+	l1 := newTestPipe()
+	l2 := newTestPipe()
+
+	node, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 10,
+		Endpoints: []EndpointConf{
+			EndpointCustom{ReadWriteCloser: &testEndpoint{l1, l2}},
+		},
+		HeartbeatDisable:     true,
+		WriteQueueSize:       10,
+		HighPriorityMessages: []uint32{0},
+	})
+	require.NoError(t, err)
+	defer node.Close()
+
+	evt := <-node.Events()
+	_, ok := evt.(*EventChannelOpen)
+	require.True(t, ok)
+
+	paramValue := func(i int) *common.MessageParamValue {
+		return &common.MessageParamValue{
+			ParamIndex: uint16(i),
+			ParamType:  common.MAV_PARAM_TYPE_REAL32,
+		}
+	}
+
+	// wait for the writer to be stuck on the first message
+	node.WriteMessageAll(paramValue(0))
+	time.Sleep(100 * time.Millisecond)
+
+	node.WriteMessageAll(paramValue(1))
+	node.WriteMessageAll(paramValue(2))
+	node.WriteMessageAll(&common.MessageHeartbeat{})
+	node.WriteMessageHighPriorityAll(paramValue(3))
+
+	for _, ca := range []struct {
+		id    byte
+		index uint16
+	}{
+		{22, 0},
+		{0, 0},
+		{22, 3},
+		{22, 1},
+		{22, 2},
+	} {
+		buf := <-l2.ch
+		require.Equal(t, ca.id, buf[7])
+		if ca.id == 22 {
+			require.Equal(t, ca.index, uint16(buf[16])|uint16(buf[17])<<8)
+		}
+	}
+}
+
+func TestNodeWriteVersionAuto(t *testing.T) {
+	l1 := newTestPipe()
+	l2 := newTestPipe()
+
+	node1, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  VAuto,
+		OutSystemID: 10,
+		Endpoints: []EndpointConf{
+			EndpointCustom{ReadWriteCloser: &testEndpoint{l1, l2}},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node1.Close()
+
+	node2, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 11,
+		Endpoints: []EndpointConf{
+			EndpointCustom{ReadWriteCloser: &testEndpoint{l2, l1}},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node2.Close()
+
+	nextFrame := func(n *Node) *EventFrame {
+		for evt := range n.Events() {
+			if fr, ok := evt.(*EventFrame); ok {
+				return fr
+			}
+		}
+		return nil
+	}
+
+	// V1 is used until a V2 frame is received
+	node1.WriteMessageAll(&common.MessageParamValue{ParamIndex: 1})
+	require.IsType(t, &frame.V1Frame{}, nextFrame(node2).Frame)
+
+	node2.WriteMessageAll(&common.MessageParamValue{ParamIndex: 2})
+	require.IsType(t, &frame.V2Frame{}, nextFrame(node1).Frame)
+
+	node1.WriteMessageAll(&common.MessageParamValue{ParamIndex: 3})
+	require.IsType(t, &frame.V2Frame{}, nextFrame(node2).Frame)
+
+	go func() {
+		for range node1.Events() {
+		}
+	}()
+}
+
+func TestNodeWriteEndpointVersion(t *testing.T) {
+	key := frame.NewV2Key(bytes.Repeat([]byte("\x4F"), 32))
+
+	l1 := newTestPipe()
+	l2 := newTestPipe()
+	l3 := newTestPipe()
+	l4 := newTestPipe()
+
+	router, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 10,
+		OutKey:      key,
+		InKey:       key,
+		Endpoints: []EndpointConf{
+			EndpointVersion{
+				Endpoint:   EndpointCustom{ReadWriteCloser: &testEndpoint{l1, l2}},
+				OutVersion: V1,
+			},
+			EndpointCustom{ReadWriteCloser: &testEndpoint{l3, l4}},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer router.Close()
+
+	legacy, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V1,
+		OutSystemID: 11,
+		Endpoints: []EndpointConf{
+			EndpointCustom{ReadWriteCloser: &testEndpoint{l2, l1}},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer legacy.Close()
+
+	gcs, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 12,
+		InKey:       key,
+		Endpoints: []EndpointConf{
+			EndpointCustom{ReadWriteCloser: &testEndpoint{l4, l3}},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer gcs.Close()
+
+	nextFrame := func(n *Node) *EventFrame {
+		for evt := range n.Events() {
+			if fr, ok := evt.(*EventFrame); ok {
+				return fr
+			}
+		}
+		return nil
+	}
+
+	router.WriteMessageAll(&common.MessageParamValue{ParamIndex: 1})
+	require.IsType(t, &frame.V1Frame{}, nextFrame(legacy).Frame)
+	fr := nextFrame(gcs).Frame
+	require.IsType(t, &frame.V2Frame{}, fr)
+	require.True(t, fr.(*frame.V2Frame).IsSigned())
+
+	// signatures are not required on V1 channels
+	legacy.WriteMessageAll(&common.MessageParamValue{ParamIndex: 2})
+	require.Equal(t, uint16(2), nextFrame(router).Message().(*common.MessageParamValue).ParamIndex)
+
+	go func() {
+		for range router.Events() {
+		}
+	}()
+	go func() {
+		for range legacy.Events() {
+		}
+	}()
+	go func() {
+		for range gcs.Events() {
+		}
+	}()
+}
+
+func TestNodeWriteEndpointVersionError(t *testing.T) {
+	_, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 10,
+		Endpoints: []EndpointConf{
+			EndpointVersion{
+				Endpoint: EndpointCustom{ReadWriteCloser: &testEndpoint{newTestPipe(), newTestPipe()}},
+			},
+		},
+	})
+	require.EqualError(t, err, "OutVersion not provided")
+}
+
+func TestNodeWriteFrameResequence(t *testing.T) {
+	l1 := newTestPipe()
+	l2 := newTestPipe()
+
+	node1, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 10,
+		Endpoints: []EndpointConf{
+			EndpointCustom{ReadWriteCloser: &testEndpoint{l1, l2}},
+		},
+		HeartbeatDisable: true,
+		OutResequence:    true,
+	})
+	require.NoError(t, err)
+	defer node1.Close()
+
+	node2, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 11,
+		Endpoints: []EndpointConf{
+			EndpointCustom{ReadWriteCloser: &testEndpoint{l2, l1}},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node2.Close()
+
+	defer l1.Close()
+	defer l2.Close()
+
+	<-node1.Events()
+	<-node2.Events()
+
+	m := &common.MessageParamValue{ParamId: "test", ParamValue: 100}
+	routed := &frame.V2Frame{
+		SequenceID:  35,
+		SystemID:    3,
+		ComponentID: 1,
+		Message:     m,
+	}
+
+	go func() {
+		node1.WriteMessageAll(m)
+		node1.WriteFrameAll(routed)
+		node1.WriteMessageAll(m)
+	}()
+
+	for _, exp := range []struct {
+		systemID   byte
+		sequenceID byte
+	}{
+		{10, 0},
+		{3, 1},
+		{10, 2},
+	} {
+		var fr *EventFrame
+		for fr == nil {
+			// skip EventFrameLoss, since losses are detected per system
+			fr, _ = (<-node2.Events()).(*EventFrame)
+		}
+		require.Equal(t, exp.systemID, fr.SystemID())
+		require.Equal(t, exp.sequenceID, fr.Frame.(*frame.V2Frame).SequenceID)
+		require.Equal(t, m, fr.Message())
+	}
+
+	require.Equal(t, byte(35), routed.SequenceID)
+}
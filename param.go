@@ -0,0 +1,124 @@
+package gomavlib
+
+import (
+	"math"
+)
+
+// ParamType is the type of a parameter value (MAV_PARAM_TYPE).
+type ParamType int
+
+// parameter types.
+const (
+	ParamTypeUint8  ParamType = 1
+	ParamTypeInt8   ParamType = 2
+	ParamTypeUint16 ParamType = 3
+	ParamTypeInt16  ParamType = 4
+	ParamTypeUint32 ParamType = 5
+	ParamTypeInt32  ParamType = 6
+	ParamTypeUint64 ParamType = 7
+	ParamTypeInt64  ParamType = 8
+	ParamTypeReal32 ParamType = 9
+	ParamTypeReal64 ParamType = 10
+)
+
+// String implements fmt.Stringer.
+func (t ParamType) String() string {
+	switch t {
+	case ParamTypeUint8:
+		return "uint8"
+	case ParamTypeInt8:
+		return "int8"
+	case ParamTypeUint16:
+		return "uint16"
+	case ParamTypeInt16:
+		return "int16"
+	case ParamTypeUint32:
+		return "uint32"
+	case ParamTypeInt32:
+		return "int32"
+	case ParamTypeUint64:
+		return "uint64"
+	case ParamTypeInt64:
+		return "int64"
+	case ParamTypeReal32:
+		return "real32"
+	case ParamTypeReal64:
+		return "real64"
+	}
+	return "unknown"
+}
+
+// IsInteger checks whether the type is an integer type.
+func (t ParamType) IsInteger() bool {
+	return t >= ParamTypeUint8 && t <= ParamTypeInt64
+}
+
+// Param is a parameter of a component.
+type Param struct {
+	// the parameter name, up to 16 characters
+	Name string
+	// the parameter type
+	Type ParamType
+	// the parameter value
+	Value float64
+	// the parameter index
+	Index int
+}
+
+// Int returns the parameter value as an integer.
+func (p *Param) Int() int64 {
+	return int64(p.Value)
+}
+
+// paramDecode converts a value received in PARAM_VALUE or PARAM_SET into a
+// typed value.
+// With the C-cast encoding (Ardupilot), the value is casted to float.
+// With the bytewise encoding (PX4), the bytes of the value are copied into the float.
+func paramDecode(raw float32, typ ParamType, bytewise bool) float64 {
+	if !bytewise || !typ.IsInteger() {
+		return float64(raw)
+	}
+
+	bits := math.Float32bits(raw)
+
+	switch typ {
+	case ParamTypeUint8:
+		return float64(uint8(bits))
+	case ParamTypeInt8:
+		return float64(int8(bits))
+	case ParamTypeUint16:
+		return float64(uint16(bits))
+	case ParamTypeInt16:
+		return float64(int16(bits))
+	case ParamTypeUint32:
+		return float64(bits)
+	default:
+		return float64(int32(bits))
+	}
+}
+
+// paramEncode converts a typed value into a value that can be inserted
+// into PARAM_VALUE or PARAM_SET.
+func paramEncode(value float64, typ ParamType, bytewise bool) float32 {
+	if !bytewise || !typ.IsInteger() {
+		return float32(value)
+	}
+
+	var bits uint32
+	switch typ {
+	case ParamTypeUint8:
+		bits = uint32(uint8(value))
+	case ParamTypeInt8:
+		bits = uint32(uint8(int8(value)))
+	case ParamTypeUint16:
+		bits = uint32(uint16(value))
+	case ParamTypeInt16:
+		bits = uint32(uint16(int16(value)))
+	case ParamTypeUint32:
+		bits = uint32(value)
+	default:
+		bits = uint32(int32(value))
+	}
+
+	return math.Float32frombits(bits)
+}
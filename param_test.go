@@ -0,0 +1,203 @@
+package gomavlib
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+)
+
+func TestParamEncoding(t *testing.T) {
+	for _, ca := range []struct {
+		typ   ParamType
+		value float64
+	}{
+		{ParamTypeUint8, 200},
+		{ParamTypeInt8, -100},
+		{ParamTypeUint16, 60000},
+		{ParamTypeInt16, -30000},
+		{ParamTypeUint32, 4000000000},
+		{ParamTypeInt32, -2000000000},
+		{ParamTypeReal32, 1.5},
+	} {
+		for _, bytewise := range []bool{false, true} {
+			raw := paramEncode(ca.value, ca.typ, bytewise)
+			dec := paramDecode(raw, ca.typ, bytewise)
+			if bytewise || ca.typ == ParamTypeReal32 {
+				require.Equal(t, ca.value, dec)
+			} else {
+				require.InDelta(t, ca.value, dec, 1000)
+			}
+		}
+	}
+}
+
+func TestParamClient(t *testing.T) {
+	node1, node2 := newTestNodePair(t, common.Dialect)
+	defer node1.Close()
+	defer node2.Close()
+
+	values := []*common.MessageParamValue{
+		{ParamId: "PARAM_A", ParamValue: 1, ParamType: 6, ParamCount: 3, ParamIndex: 0},
+		{ParamId: "PARAM_B", ParamValue: 2.5, ParamType: 9, ParamCount: 3, ParamIndex: 1},
+		{ParamId: "PARAM_C", ParamValue: 3, ParamType: 2, ParamCount: 3, ParamIndex: 2},
+	}
+
+	go func() {
+		for range node1.Events() {
+		}
+	}()
+
+	go func() {
+		for evt := range node2.Events() {
+			fr, ok := evt.(*EventFrame)
+			if !ok {
+				continue
+			}
+
+			switch m := fr.Message().(type) {
+			case *common.MessageParamRequestList:
+				// skip a parameter in order to trigger a retransmission
+				node2.WriteMessageAll(values[0])
+				node2.WriteMessageAll(values[2])
+
+			case *common.MessageParamRequestRead:
+				for _, v := range values {
+					if (m.ParamIndex >= 0 && int(v.ParamIndex) == int(m.ParamIndex)) ||
+						(m.ParamIndex < 0 && v.ParamId == m.ParamId) {
+						node2.WriteMessageAll(v)
+					}
+				}
+
+			case *common.MessageParamSet:
+				for _, v := range values {
+					if v.ParamId == m.ParamId {
+						v.ParamValue = m.ParamValue
+						node2.WriteMessageAll(v)
+					}
+				}
+			}
+		}
+	}()
+
+	c, err := NewParamClient(ParamClientConf{
+		Node: node1,
+		Target: Target{
+			SystemID:    11,
+			ComponentID: 1,
+		},
+	})
+	require.NoError(t, err)
+
+	params, err := c.List(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []*Param{
+		{Name: "PARAM_A", Type: ParamTypeInt32, Value: 1, Index: 0},
+		{Name: "PARAM_B", Type: ParamTypeReal32, Value: 2.5, Index: 1},
+		{Name: "PARAM_C", Type: ParamTypeInt8, Value: 3, Index: 2},
+	}, params)
+
+	p, err := c.Read(context.Background(), "PARAM_B")
+	require.NoError(t, err)
+	require.Equal(t, 2.5, p.Value)
+
+	p, err = c.Write(context.Background(), &Param{Name: "PARAM_C", Type: ParamTypeInt8, Value: 7})
+	require.NoError(t, err)
+	require.Equal(t, float64(7), p.Value)
+}
+
+func TestParamServer(t *testing.T) {
+	node1, node2 := newTestNodePair(t, common.Dialect)
+	defer node1.Close()
+	defer node2.Close()
+
+	go func() {
+		for range node1.Events() {
+		}
+	}()
+
+	go func() {
+		for range node2.Events() {
+		}
+	}()
+
+	s, err := NewParamServer(ParamServerConf{
+		Node: node2,
+	})
+	require.NoError(t, err)
+	defer s.Close()
+
+	require.NoError(t, s.Register("PARAM_A", ParamTypeInt32, 1, nil))
+	require.NoError(t, s.Register("PARAM_B", ParamTypeReal32, 2.5, func(name string, value float64) bool {
+		return value < 10
+	}))
+	require.Error(t, s.Register("PARAM_A", ParamTypeInt32, 1, nil))
+
+	c, err := NewParamClient(ParamClientConf{
+		Node: node1,
+		Target: Target{
+			SystemID:    11,
+			ComponentID: 1,
+		},
+	})
+	require.NoError(t, err)
+
+	params, err := c.List(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []*Param{
+		{Name: "PARAM_A", Type: ParamTypeInt32, Value: 1, Index: 0},
+		{Name: "PARAM_B", Type: ParamTypeReal32, Value: 2.5, Index: 1},
+	}, params)
+
+	p, err := c.Write(context.Background(), &Param{Name: "PARAM_B", Type: ParamTypeReal32, Value: 5})
+	require.NoError(t, err)
+	require.Equal(t, float64(5), p.Value)
+
+	p, err = c.Write(context.Background(), &Param{Name: "PARAM_B", Type: ParamTypeReal32, Value: 20})
+	require.NoError(t, err)
+	require.Equal(t, float64(5), p.Value)
+
+	v, ok := s.Get("PARAM_B")
+	require.Equal(t, true, ok)
+	require.Equal(t, float64(5), v)
+}
+
+func TestParamServerBroadcast(t *testing.T) {
+	node1, node2 := newTestNodePair(t, common.Dialect)
+	defer node1.Close()
+	defer node2.Close()
+
+	go func() {
+		for range node2.Events() {
+		}
+	}()
+
+	s, err := NewParamServer(ParamServerConf{
+		Node: node2,
+	})
+	require.NoError(t, err)
+	defer s.Close()
+
+	require.NoError(t, s.Register("PARAM_A", ParamTypeInt32, 1, nil))
+
+	for evt := range node1.Events() {
+		switch ee := evt.(type) {
+		case *EventChannelOpen:
+			node1.WriteMessageAll(&common.MessageParamRequestRead{
+				TargetSystem:    0,
+				TargetComponent: 0,
+				ParamIndex:      -1,
+				ParamId:         "PARAM_A",
+			})
+
+		case *EventFrame:
+			if m, ok := ee.Message().(*common.MessageParamValue); ok {
+				require.Equal(t, "PARAM_A", m.ParamId)
+				require.Equal(t, float32(1), m.ParamValue)
+				return
+			}
+		}
+	}
+}
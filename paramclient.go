@@ -0,0 +1,207 @@
+package gomavlib
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+const (
+	paramIndexNone = 65535
+)
+
+// ParamClientConf allows to configure a ParamClient.
+type ParamClientConf struct {
+	// the node used to communicate.
+	// Its dialect must contain the PARAM_* messages.
+	Node *Node
+	// the component whose parameters are read and written.
+	Target Target
+
+	// (optional) whether integer values are encoded bytewise (PX4) instead of
+	// being casted to float (Ardupilot).
+	BytewiseEncoding bool
+	// (optional) the time to wait for a response before retrying.
+	// It defaults to 1 second.
+	Timeout time.Duration
+	// (optional) the maximum number of retries. It defaults to 3.
+	Retries int
+}
+
+// ParamClient implements the client side of the parameter protocol, that
+// allows to list, read and write the parameters of a remote component.
+type ParamClient struct {
+	conf           ParamClientConf
+	msgRequestRead msg.Message
+	msgRequestList msg.Message
+	msgParamValue  msg.Message
+	msgParamSet    msg.Message
+}
+
+// NewParamClient allocates a ParamClient. See ParamClientConf for the options.
+func NewParamClient(conf ParamClientConf) (*ParamClient, error) {
+	if conf.Node == nil {
+		return nil, fmt.Errorf("Node not provided")
+	}
+	if conf.Timeout == 0 {
+		conf.Timeout = 1 * time.Second
+	}
+	if conf.Retries == 0 {
+		conf.Retries = 3
+	}
+
+	c := &ParamClient{
+		conf:           conf,
+		msgRequestRead: dialectMessage(conf.Node.conf.Dialect, 20, 214),
+		msgRequestList: dialectMessage(conf.Node.conf.Dialect, 21, 159),
+		msgParamValue:  dialectMessage(conf.Node.conf.Dialect, 22, 220),
+		msgParamSet:    dialectMessage(conf.Node.conf.Dialect, 23, 168),
+	}
+
+	if c.msgRequestRead == nil || c.msgRequestList == nil ||
+		c.msgParamValue == nil || c.msgParamSet == nil {
+		return nil, fmt.Errorf("dialect does not contain the parameter protocol messages")
+	}
+
+	return c, nil
+}
+
+func (c *ParamClient) isParamValue(evt *EventFrame) bool {
+	return evt.Message().GetID() == 22 && c.conf.Target.matches(evt)
+}
+
+func (c *ParamClient) decodeParamValue(m msg.Message) *Param {
+	typ := ParamType(messageGetInt(m, "ParamType"))
+	return &Param{
+		Name:  messageGetString(m, "ParamId"),
+		Type:  typ,
+		Value: paramDecode(float32(messageGetFloat(m, "ParamValue")), typ, c.conf.BytewiseEncoding),
+		Index: int(messageGetInt(m, "ParamIndex")),
+	}
+}
+
+func (c *ParamClient) writeRequestRead(name string, index int) {
+	m := newMessage(c.msgRequestRead)
+	messageSet(m, "TargetSystem", c.conf.Target.SystemID)
+	messageSet(m, "TargetComponent", c.conf.Target.ComponentID)
+	messageSet(m, "ParamId", name)
+	messageSet(m, "ParamIndex", index)
+	c.conf.Node.writeMessageToTarget(c.conf.Target, m)
+}
+
+// List fetches all the parameters of the component.
+// Missing parameters are requested again individually.
+func (c *ParamClient) List(ctx context.Context) ([]*Param, error) {
+	fw := c.conf.Node.nodeWaiters.add(c.isParamValue, 1024)
+	defer c.conf.Node.nodeWaiters.remove(fw)
+
+	writeRequestList := func() {
+		m := newMessage(c.msgRequestList)
+		messageSet(m, "TargetSystem", c.conf.Target.SystemID)
+		messageSet(m, "TargetComponent", c.conf.Target.ComponentID)
+		c.conf.Node.writeMessageToTarget(c.conf.Target, m)
+	}
+
+	writeRequestList()
+
+	params := make(map[int]*Param)
+	count := -1
+	retries := 0
+
+	for {
+		timerC, stopTimer := c.conf.Node.conf.Clock.NewTimer(c.conf.Timeout)
+
+		select {
+		case evt := <-fw.frames:
+			stopTimer()
+
+			m := evt.Message()
+			if count < 0 {
+				count = int(messageGetInt(m, "ParamCount"))
+			}
+
+			p := c.decodeParamValue(m)
+			if p.Index != paramIndexNone && p.Index < count {
+				if _, ok := params[p.Index]; !ok {
+					params[p.Index] = p
+					retries = 0
+				}
+			}
+
+			if len(params) == count {
+				ret := make([]*Param, 0, count)
+				for _, p := range params {
+					ret = append(ret, p)
+				}
+				sort.Slice(ret, func(i, j int) bool {
+					return ret[i].Index < ret[j].Index
+				})
+				return ret, nil
+			}
+
+		case <-timerC:
+			if retries >= c.conf.Retries {
+				return nil, fmt.Errorf("timeout")
+			}
+			retries++
+
+			if count < 0 {
+				writeRequestList()
+			} else {
+				for i := 0; i < count; i++ {
+					if _, ok := params[i]; !ok {
+						c.writeRequestRead("", i)
+					}
+				}
+			}
+
+		case <-ctx.Done():
+			stopTimer()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// Read reads a parameter of the component.
+func (c *ParamClient) Read(ctx context.Context, name string) (*Param, error) {
+	evt, err := c.conf.Node.request(ctx,
+		func(evt *EventFrame) bool {
+			return c.isParamValue(evt) && messageGetString(evt.Message(), "ParamId") == name
+		},
+		func() {
+			c.writeRequestRead(name, -1)
+		},
+		c.conf.Timeout, c.conf.Retries)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.decodeParamValue(evt.Message()), nil
+}
+
+// Write writes a parameter of the component, and returns the parameter
+// as stored by the component.
+func (c *ParamClient) Write(ctx context.Context, p *Param) (*Param, error) {
+	evt, err := c.conf.Node.request(ctx,
+		func(evt *EventFrame) bool {
+			return c.isParamValue(evt) && messageGetString(evt.Message(), "ParamId") == p.Name
+		},
+		func() {
+			m := newMessage(c.msgParamSet)
+			messageSet(m, "TargetSystem", c.conf.Target.SystemID)
+			messageSet(m, "TargetComponent", c.conf.Target.ComponentID)
+			messageSet(m, "ParamId", p.Name)
+			messageSet(m, "ParamValue", paramEncode(p.Value, p.Type, c.conf.BytewiseEncoding))
+			messageSet(m, "ParamType", int(p.Type))
+			c.conf.Node.writeMessageToTarget(c.conf.Target, m)
+		},
+		c.conf.Timeout, c.conf.Retries)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.decodeParamValue(evt.Message()), nil
+}
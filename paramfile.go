@@ -0,0 +1,241 @@
+package gomavlib
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ParamFileFormat is the format of a parameter file.
+type ParamFileFormat int
+
+// parameter file formats.
+const (
+	// the format used by Ardupilot (.param, .parm), with a NAME,VALUE pair
+	// per line. Pairs separated by spaces or tabs are accepted too.
+	ParamFileFormatArdupilot ParamFileFormat = iota
+
+	// the format used by QGroundControl and PX4 (.params), with a
+	// SYSID COMPID NAME VALUE TYPE tuple per line, separated by tabs.
+	ParamFileFormatQGC
+)
+
+// ParamFile is a parameter file.
+type ParamFile struct {
+	// the file format
+	Format ParamFileFormat
+	// (QGC format only) the system and component ids
+	SystemID    int
+	ComponentID int
+	// the parameters. Parameters read from files in Ardupilot format do not
+	// have a type.
+	Params []*Param
+}
+
+// ParamFileDecode reads a parameter file. The format is detected
+// automatically.
+func ParamFileDecode(r io.Reader) (*ParamFile, error) {
+	f := &ParamFile{}
+	formatDetected := false
+
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+
+	for scanner.Scan() {
+		lineNum++
+
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+
+		fields := strings.FieldsFunc(line, func(c rune) bool {
+			return c == ',' || c == ' ' || c == '\t'
+		})
+
+		format := ParamFileFormatArdupilot
+		if len(fields) == 5 {
+			format = ParamFileFormatQGC
+		} else if len(fields) != 2 {
+			return nil, fmt.Errorf("line %d: invalid line", lineNum)
+		}
+
+		if !formatDetected {
+			f.Format = format
+			formatDetected = true
+		} else if format != f.Format {
+			return nil, fmt.Errorf("line %d: mixed formats", lineNum)
+		}
+
+		p := &Param{
+			Index: len(f.Params),
+		}
+
+		var value string
+
+		if format == ParamFileFormatQGC {
+			sysID, err := strconv.ParseUint(fields[0], 10, 8)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid system id", lineNum)
+			}
+
+			compID, err := strconv.ParseUint(fields[1], 10, 8)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid component id", lineNum)
+			}
+
+			typ, err := strconv.ParseUint(fields[4], 10, 8)
+			if err != nil || typ < uint64(ParamTypeUint8) || typ > uint64(ParamTypeReal64) {
+				return nil, fmt.Errorf("line %d: invalid type", lineNum)
+			}
+
+			f.SystemID = int(sysID)
+			f.ComponentID = int(compID)
+			p.Name = fields[2]
+			p.Type = ParamType(typ)
+			value = fields[3]
+		} else {
+			p.Name = fields[0]
+			value = fields[1]
+		}
+
+		if len(p.Name) > 16 {
+			return nil, fmt.Errorf("line %d: name is too long", lineNum)
+		}
+
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid value", lineNum)
+		}
+		p.Value = v
+
+		f.Params = append(f.Params, p)
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+func paramFileFormatValue(p *Param) string {
+	if p.Type.IsInteger() {
+		return strconv.FormatInt(p.Int(), 10)
+	}
+	if p.Type == ParamTypeReal64 {
+		return strconv.FormatFloat(p.Value, 'g', -1, 64)
+	}
+	return strconv.FormatFloat(p.Value, 'g', -1, 32)
+}
+
+// ParamFileEncode writes a parameter file.
+// Parameters are sorted by name.
+func ParamFileEncode(w io.Writer, f *ParamFile) error {
+	params := make([]*Param, len(f.Params))
+	copy(params, f.Params)
+	sort.Slice(params, func(i, j int) bool {
+		return params[i].Name < params[j].Name
+	})
+
+	bw := bufio.NewWriter(w)
+
+	switch f.Format {
+	case ParamFileFormatArdupilot:
+		for _, p := range params {
+			fmt.Fprintf(bw, "%s,%s\n", p.Name, paramFileFormatValue(p))
+		}
+
+	case ParamFileFormatQGC:
+		fmt.Fprintf(bw, "# Onboard parameters for Vehicle %d\n", f.SystemID)
+		fmt.Fprintf(bw, "#\n")
+		fmt.Fprintf(bw, "# Vehicle-Id Component-Id Name Value Type\n")
+		for _, p := range params {
+			if p.Type < ParamTypeUint8 || p.Type > ParamTypeReal64 {
+				return fmt.Errorf("parameter %s: type not provided", p.Name)
+			}
+			fmt.Fprintf(bw, "%d\t%d\t%s\t%s\t%d\n", f.SystemID, f.ComponentID,
+				p.Name, paramFileFormatValue(p), p.Type)
+		}
+
+	default:
+		return fmt.Errorf("unsupported format")
+	}
+
+	return bw.Flush()
+}
+
+// ParamDiffEntry is a parameter that differs between two parameter sets.
+type ParamDiffEntry struct {
+	// the parameter name
+	Name string
+	// the parameter in the first set, or nil if it is missing
+	A *Param
+	// the parameter in the second set, or nil if it is missing
+	B *Param
+}
+
+// paramEqual checks whether two parameter values are equal.
+// Since values are transmitted as 32-bit floats, they are compared with the
+// same precision.
+func paramEqual(a *Param, b *Param) bool {
+	if a.Type == ParamTypeReal64 && b.Type == ParamTypeReal64 {
+		return a.Value == b.Value
+	}
+	va := float32(a.Value)
+	vb := float32(b.Value)
+	return va == vb || (math.IsNaN(float64(va)) && math.IsNaN(float64(vb)))
+}
+
+// ParamDiff compares two parameter sets, for instance a set read from a file
+// and a set fetched with ParamClient.List(), and returns the parameters that
+// are missing from one of them or that have different values, sorted by name.
+func ParamDiff(a []*Param, b []*Param) []*ParamDiffEntry {
+	entries := make(map[string]*ParamDiffEntry)
+
+	for _, p := range a {
+		entries[p.Name] = &ParamDiffEntry{Name: p.Name, A: p}
+	}
+
+	for _, p := range b {
+		e, ok := entries[p.Name]
+		if !ok {
+			entries[p.Name] = &ParamDiffEntry{Name: p.Name, B: p}
+			continue
+		}
+
+		if paramEqual(e.A, p) {
+			delete(entries, p.Name)
+		} else {
+			e.B = p
+		}
+	}
+
+	ret := make([]*ParamDiffEntry, 0, len(entries))
+	for _, e := range entries {
+		ret = append(ret, e)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Name < ret[j].Name
+	})
+
+	return ret
+}
+
+// Diff fetches all the parameters of the component and compares them with
+// the given ones, that are usually read from a file. In the returned
+// entries, A is the given parameter and B is the parameter of the component.
+func (c *ParamClient) Diff(ctx context.Context, params []*Param) ([]*ParamDiffEntry, error) {
+	live, err := c.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParamDiff(params, live), nil
+}
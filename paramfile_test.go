@@ -0,0 +1,201 @@
+package gomavlib
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+)
+
+func TestParamFileDecode(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		file string
+		dec  *ParamFile
+	}{
+		{
+			"ardupilot",
+			"# comment\n" +
+				"PARAM_A,1\n" +
+				"PARAM_B 2.5\n" +
+				"\n" +
+				"PARAM_C\t-3\n",
+			&ParamFile{
+				Format: ParamFileFormatArdupilot,
+				Params: []*Param{
+					{Name: "PARAM_A", Value: 1, Index: 0},
+					{Name: "PARAM_B", Value: 2.5, Index: 1},
+					{Name: "PARAM_C", Value: -3, Index: 2},
+				},
+			},
+		},
+		{
+			"qgc",
+			"# Onboard parameters for Vehicle 1\n" +
+				"#\n" +
+				"# Vehicle-Id Component-Id Name Value Type\n" +
+				"1\t1\tPARAM_A\t1\t6\n" +
+				"1\t1\tPARAM_B\t2.5\t9\n",
+			&ParamFile{
+				Format:      ParamFileFormatQGC,
+				SystemID:    1,
+				ComponentID: 1,
+				Params: []*Param{
+					{Name: "PARAM_A", Type: ParamTypeInt32, Value: 1, Index: 0},
+					{Name: "PARAM_B", Type: ParamTypeReal32, Value: 2.5, Index: 1},
+				},
+			},
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			f, err := ParamFileDecode(strings.NewReader(ca.file))
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, f)
+		})
+	}
+}
+
+func TestParamFileDecodeErrors(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		file string
+		err  string
+	}{
+		{
+			"invalid line",
+			"PARAM_A\n",
+			"line 1: invalid line",
+		},
+		{
+			"invalid value",
+			"PARAM_A,abc\n",
+			"line 1: invalid value",
+		},
+		{
+			"mixed formats",
+			"PARAM_A,1\n1\t1\tPARAM_B\t2\t6\n",
+			"line 2: mixed formats",
+		},
+		{
+			"invalid type",
+			"1\t1\tPARAM_A\t1\t15\n",
+			"line 1: invalid type",
+		},
+		{
+			"name too long",
+			"PARAM_WITH_A_LONG_NAME,1\n",
+			"line 1: name is too long",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			_, err := ParamFileDecode(strings.NewReader(ca.file))
+			require.EqualError(t, err, ca.err)
+		})
+	}
+}
+
+func TestParamFileEncode(t *testing.T) {
+	params := []*Param{
+		{Name: "PARAM_B", Type: ParamTypeReal32, Value: float64(float32(0.1))},
+		{Name: "PARAM_A", Type: ParamTypeInt32, Value: -4},
+	}
+
+	var buf bytes.Buffer
+	err := ParamFileEncode(&buf, &ParamFile{
+		Format: ParamFileFormatArdupilot,
+		Params: params,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "PARAM_A,-4\n"+
+		"PARAM_B,0.1\n", buf.String())
+
+	buf.Reset()
+	err = ParamFileEncode(&buf, &ParamFile{
+		Format:      ParamFileFormatQGC,
+		SystemID:    1,
+		ComponentID: 1,
+		Params:      params,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "# Onboard parameters for Vehicle 1\n"+
+		"#\n"+
+		"# Vehicle-Id Component-Id Name Value Type\n"+
+		"1\t1\tPARAM_A\t-4\t6\n"+
+		"1\t1\tPARAM_B\t0.1\t9\n", buf.String())
+
+	err = ParamFileEncode(&buf, &ParamFile{
+		Format: ParamFileFormatQGC,
+		Params: []*Param{{Name: "PARAM_A", Value: 1}},
+	})
+	require.EqualError(t, err, "parameter PARAM_A: type not provided")
+}
+
+func TestParamDiff(t *testing.T) {
+	a := []*Param{
+		{Name: "PARAM_A", Value: 1},
+		{Name: "PARAM_B", Value: 0.1},
+		{Name: "PARAM_C", Value: 3},
+	}
+	b := []*Param{
+		{Name: "PARAM_A", Type: ParamTypeInt32, Value: 2},
+		{Name: "PARAM_B", Type: ParamTypeReal32, Value: float64(float32(0.1))},
+		{Name: "PARAM_D", Type: ParamTypeInt8, Value: 4},
+	}
+
+	require.Equal(t, []*ParamDiffEntry{
+		{Name: "PARAM_A", A: a[0], B: b[0]},
+		{Name: "PARAM_C", A: a[2]},
+		{Name: "PARAM_D", B: b[2]},
+	}, ParamDiff(a, b))
+}
+
+func TestParamClientDiff(t *testing.T) {
+	node1, node2 := newTestNodePair(t, common.Dialect)
+	defer node1.Close()
+	defer node2.Close()
+
+	go func() {
+		for range node1.Events() {
+		}
+	}()
+
+	go func() {
+		for range node2.Events() {
+		}
+	}()
+
+	s, err := NewParamServer(ParamServerConf{
+		Node: node2,
+	})
+	require.NoError(t, err)
+	defer s.Close()
+
+	require.NoError(t, s.Register("PARAM_A", ParamTypeInt32, 1, nil))
+	require.NoError(t, s.Register("PARAM_B", ParamTypeReal32, 2.5, nil))
+
+	c, err := NewParamClient(ParamClientConf{
+		Node: node1,
+		Target: Target{
+			SystemID:    11,
+			ComponentID: 1,
+		},
+	})
+	require.NoError(t, err)
+
+	f, err := ParamFileDecode(strings.NewReader("PARAM_A,1\nPARAM_B,3\n"))
+	require.NoError(t, err)
+
+	diff, err := c.Diff(context.Background(), f.Params)
+	require.NoError(t, err)
+	require.Equal(t, []*ParamDiffEntry{
+		{
+			Name: "PARAM_B",
+			A:    f.Params[1],
+			B:    &Param{Name: "PARAM_B", Type: ParamTypeReal32, Value: 2.5, Index: 1},
+		},
+	}, diff)
+}
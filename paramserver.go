@@ -0,0 +1,283 @@
+package gomavlib
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+// ParamSetFunc is the callback invoked when a remote component changes a
+// parameter. It returns whether the change is accepted.
+type ParamSetFunc func(name string, value float64) bool
+
+// ParamServerConf allows to configure a ParamServer.
+type ParamServerConf struct {
+	// the node used to communicate.
+	// Its dialect must contain the PARAM_* messages.
+	Node *Node
+
+	// (optional) whether integer values are encoded bytewise (PX4) instead of
+	// being casted to float (Ardupilot).
+	BytewiseEncoding bool
+	// (optional) the period between the PARAM_VALUE messages that are sent
+	// in response to a PARAM_REQUEST_LIST, in order not to saturate the link.
+	// It defaults to 10 milliseconds.
+	ListPeriod time.Duration
+}
+
+type paramServerEntry struct {
+	param *Param
+	onSet ParamSetFunc
+}
+
+// paramServerListing is a PARAM_REQUEST_LIST that is being answered.
+type paramServerListing struct {
+	channel *Channel
+	next    int
+}
+
+// ParamServer implements the server side of the parameter protocol, that
+// allows remote components to list, read and write parameters of the node.
+type ParamServer struct {
+	conf          ParamServerConf
+	msgParamValue msg.Message
+	mutex         sync.Mutex
+	entries       []*paramServerEntry
+	frameService
+}
+
+// NewParamServer allocates a ParamServer. See ParamServerConf for the options.
+func NewParamServer(conf ParamServerConf) (*ParamServer, error) {
+	if conf.Node == nil {
+		return nil, fmt.Errorf("Node not provided")
+	}
+	if conf.ListPeriod == 0 {
+		conf.ListPeriod = 10 * time.Millisecond
+	}
+
+	d := conf.Node.conf.Dialect
+	if dialectMessage(d, 20, 214) == nil ||
+		dialectMessage(d, 21, 159) == nil ||
+		dialectMessage(d, 22, 220) == nil ||
+		dialectMessage(d, 23, 168) == nil {
+		return nil, fmt.Errorf("dialect does not contain the parameter protocol messages")
+	}
+
+	s := &ParamServer{
+		conf:          conf,
+		msgParamValue: dialectMessage(d, 22, 220),
+	}
+
+	s.start(conf.Node, s.isRequest, 256, s.run)
+
+	return s, nil
+}
+
+// Close stops the server.
+func (s *ParamServer) Close() {
+	s.close()
+}
+
+// Register adds a parameter. The optional callback is invoked when a remote
+// component changes the parameter; if nil, changes are always accepted.
+func (s *ParamServer) Register(name string, typ ParamType, value float64, onSet ParamSetFunc) error {
+	if len(name) > 16 {
+		return fmt.Errorf("parameter name is longer than 16 characters")
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.find(name) != nil {
+		return fmt.Errorf("parameter '%s' already registered", name)
+	}
+
+	s.entries = append(s.entries, &paramServerEntry{
+		param: &Param{
+			Name:  name,
+			Type:  typ,
+			Value: value,
+			Index: len(s.entries),
+		},
+		onSet: onSet,
+	})
+	return nil
+}
+
+// Get returns the current value of a parameter.
+func (s *ParamServer) Get(name string) (float64, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	e := s.find(name)
+	if e == nil {
+		return 0, false
+	}
+	return e.param.Value, true
+}
+
+// Set changes the value of a parameter and notifies all channels.
+func (s *ParamServer) Set(name string, value float64) error {
+	s.mutex.Lock()
+	e := s.find(name)
+	if e == nil {
+		s.mutex.Unlock()
+		return fmt.Errorf("parameter '%s' not found", name)
+	}
+	e.param.Value = value
+	m := s.paramValue(e.param)
+	s.mutex.Unlock()
+
+	s.conf.Node.WriteMessageAll(m)
+	return nil
+}
+
+func (s *ParamServer) find(name string) *paramServerEntry {
+	for _, e := range s.entries {
+		if e.param.Name == name {
+			return e
+		}
+	}
+	return nil
+}
+
+func (s *ParamServer) isRequest(evt *EventFrame) bool {
+	switch evt.Message().GetID() {
+	case 20, 21, 23:
+	default:
+		return false
+	}
+
+	sysID := byte(messageGetInt(evt.Message(), "TargetSystem"))
+	compID := byte(messageGetInt(evt.Message(), "TargetComponent"))
+	return (sysID == s.conf.Node.conf.OutSystemID || sysID == 0) &&
+		(compID == s.conf.Node.conf.OutComponentID || compID == 0)
+}
+
+// paramValue must be called with the mutex locked.
+func (s *ParamServer) paramValue(p *Param) msg.Message {
+	m := newMessage(s.msgParamValue)
+	messageSet(m, "ParamId", p.Name)
+	messageSet(m, "ParamValue", paramEncode(p.Value, p.Type, s.conf.BytewiseEncoding))
+	messageSet(m, "ParamType", int(p.Type))
+	messageSet(m, "ParamCount", len(s.entries))
+	messageSet(m, "ParamIndex", p.Index)
+	return m
+}
+
+func (s *ParamServer) run() {
+	// lists are answered one parameter at a time, in order to pace the
+	// PARAM_VALUE messages and to keep serving other requests meanwhile.
+	listings := make(map[*Channel]*paramServerListing)
+	var tickerStop func()
+	var tickerC <-chan time.Time
+
+	defer func() {
+		if tickerStop != nil {
+			tickerStop()
+		}
+	}()
+
+	for {
+		select {
+		case evt := <-s.fw.frames:
+			if evt.Message().GetID() == 21 { // PARAM_REQUEST_LIST
+				listings[evt.Channel] = &paramServerListing{channel: evt.Channel}
+				if tickerStop == nil {
+					tickerC, tickerStop = s.conf.Node.conf.Clock.NewTicker(s.conf.ListPeriod)
+				}
+			} else {
+				s.onRequest(evt)
+			}
+
+		case <-tickerC:
+			for ch, l := range listings {
+				if !s.writeListNext(l) {
+					delete(listings, ch)
+				}
+			}
+
+			if len(listings) == 0 {
+				tickerStop()
+				tickerStop = nil
+				tickerC = nil
+			}
+
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// writeListNext writes the next parameter of a listing, and returns false
+// when the listing is complete.
+func (s *ParamServer) writeListNext(l *paramServerListing) bool {
+	s.mutex.Lock()
+	if l.next >= len(s.entries) {
+		s.mutex.Unlock()
+		return false
+	}
+	res := s.paramValue(s.entries[l.next].param)
+	s.mutex.Unlock()
+
+	s.conf.Node.WriteMessageTo(l.channel, res)
+	l.next++
+	return true
+}
+
+func (s *ParamServer) onRequest(evt *EventFrame) {
+	m := evt.Message()
+
+	switch m.GetID() {
+
+	case 20: // PARAM_REQUEST_READ
+		index := int(messageGetInt(m, "ParamIndex"))
+		name := messageGetString(m, "ParamId")
+
+		s.mutex.Lock()
+		var res msg.Message
+		if index >= 0 {
+			if index < len(s.entries) {
+				res = s.paramValue(s.entries[index].param)
+			}
+		} else if e := s.find(name); e != nil {
+			res = s.paramValue(e.param)
+		}
+		s.mutex.Unlock()
+
+		if res != nil {
+			s.conf.Node.WriteMessageTo(evt.Channel, res)
+		}
+
+	case 23: // PARAM_SET
+		name := messageGetString(m, "ParamId")
+
+		s.mutex.Lock()
+		e := s.find(name)
+		if e == nil {
+			s.mutex.Unlock()
+			return
+		}
+		value := paramDecode(float32(messageGetFloat(m, "ParamValue")), e.param.Type,
+			s.conf.BytewiseEncoding)
+		onSet := e.onSet
+		s.mutex.Unlock()
+
+		// invoke the callback without holding the mutex, in order to allow
+		// the callback to call Get() and Set().
+		accepted := onSet == nil || onSet(name, value)
+
+		s.mutex.Lock()
+		if accepted {
+			e.param.Value = value
+		}
+		res := s.paramValue(e.param)
+		s.mutex.Unlock()
+
+		// the current value is always sent back, in order to notify the
+		// requester whether the change has been accepted.
+		s.conf.Node.WriteMessageAll(res)
+	}
+}
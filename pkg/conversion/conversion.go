@@ -0,0 +1,747 @@
+// Package conversion converts Mavlink dialects from XML format into Go format.
+//
+// It can be used to generate custom dialects as part of the build of a
+// project, for instance with go:generate:
+//
+//	//go:generate go run github.com/aler9/gomavlib/cmd/dialect-import --output=dialect.go my_dialect.xml
+package conversion
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+var (
+	reMsgName     = regexp.MustCompile("^[A-Z0-9_]+$")
+	reTypeIsArray = regexp.MustCompile(`^(.+?)\[([0-9]+)\]$`)
+)
+
+var tplDialect = template.Must(template.New("").Funcs(template.FuncMap{
+	"quote": strconv.Quote,
+}).Parse(
+	`//nolint:golint,misspell,govet
+{{- if .Comment -}}
+// {{ .Comment }}
+{{- end }}
+package {{ .PkgName }}
+
+import (
+{{- if .Binary }}
+	"encoding/binary"
+{{- end }}
+{{- if .Enums }}
+	"errors"
+{{- end }}
+{{- if .Math }}
+	"math"
+{{- end }}
+{{- if .Enums }}
+	"strconv"
+{{- end }}
+
+	"github.com/aler9/gomavlib/pkg/msg"
+	"github.com/aler9/gomavlib/pkg/dialect"
+)
+
+// Dialect contains the dialect object that can be passed to the library.
+var Dialect = dial
+
+// dialect is not exposed directly such that it is not displayed in godoc.
+var dial = &dialect.Dialect{ {{.Version}}, []msg.Message{
+{{- range .Defs }}
+    // {{ .Name }}
+{{- range .Messages }}
+    &Message{{ .Name }}{},
+{{- end }}
+{{- end }}
+} }
+
+{{ range .Enums }}
+// {{ .Description }}
+type {{ .Name }} int
+
+const (
+{{- $pn := .Name }}
+{{- range .Values }}
+	// {{ .Description }}
+	{{ .Name }} {{ $pn }} = {{ .Value }}
+{{- end }}
+)
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (e {{ .Name }}) MarshalText() ([]byte, error) {
+	switch e { //nolint:gocritic
+{{- range .Values }}
+	case {{ .Name }}:
+		return []byte("{{ .Name }}"), nil
+{{- end }}
+	}
+	return nil, errors.New("invalid value")
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (e *{{ .Name }}) UnmarshalText(text []byte) error {
+	switch string(text) { //nolint:gocritic
+{{- range .Values }}
+	case "{{ .Name }}":
+		*e = {{ .Name }}
+		return nil
+{{- end }}
+	}
+	return errors.New("invalid value")
+}
+
+// String implements the fmt.Stringer interface.
+func (e {{ .Name }}) String() string {
+	byts, err := e.MarshalText()
+	if err == nil {
+		return string(byts)
+	}
+	return strconv.FormatInt(int64(e), 10)
+}
+
+{{ end }}
+
+{{ range .Defs }}
+// {{ .Name }}
+
+{{ range .Messages }}
+// {{ .Description }}
+type Message{{ .Name }} struct {
+{{- range .Fields }}
+	// {{ .Description }}
+    {{ .Line }}
+{{- end }}
+}
+
+// GetID implements the msg.Message interface.
+func (*Message{{ .Name }}) GetID() uint32 {
+    return {{ .ID }}
+}
+
+// MessageDescription implements the msg.DescriptionProvider interface.
+func (*Message{{ .Name }}) MessageDescription() string {
+    return {{ quote .Description }}
+}
+
+// FieldDescription implements the msg.DescriptionProvider interface.
+func (*Message{{ .Name }}) FieldDescription(name string) string {
+    switch name {
+{{- range .Fields }}
+    case {{ quote .Name }}:
+        return {{ quote .Description }}
+{{- end }}
+    }
+    return ""
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *Message{{ .Name }}) MarshalPayload(buf []byte) {
+{{- range .Marshalers.Encode }}
+	{{ . }}
+{{- end }}
+{{- if .Marshalers.Extensions }}
+	if len(buf) < {{ .Marshalers.SizeExtended }} {
+		return
+	}
+{{- range .Marshalers.EncodeExtensions }}
+	{{ . }}
+{{- end }}
+{{- end }}
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *Message{{ .Name }}) UnmarshalPayload(buf []byte) {
+{{- range .Marshalers.Decode }}
+	{{ . }}
+{{- end }}
+{{- if .Marshalers.Extensions }}
+	if len(buf) < {{ .Marshalers.SizeExtended }} {
+		return
+	}
+{{- range .Marshalers.DecodeExtensions }}
+	{{ . }}
+{{- end }}
+{{- end }}
+}
+{{ end }}
+{{- end }}
+{{- if .Strings }}
+func decodeString(buf []byte) string {
+	end := 0
+	for end < len(buf) && buf[end] != 0 {
+		end++
+	}
+	return string(buf[:end])
+}
+{{- end }}
+`))
+
+var dialectTypeToGo = map[string]string{
+	"double":   "float64",
+	"uint64_t": "uint64",
+	"int64_t":  "int64",
+	"float":    "float32",
+	"uint32_t": "uint32",
+	"int32_t":  "int32",
+	"uint16_t": "uint16",
+	"int16_t":  "int16",
+	"uint8_t":  "uint8",
+	"int8_t":   "int8",
+	"char":     "string",
+}
+
+func dialectFieldGoToDef(in string) string {
+	re := regexp.MustCompile("([A-Z])")
+	in = re.ReplaceAllString(in, "_${1}")
+	return strings.ToLower(in[1:])
+}
+
+func dialectFieldDefToGo(in string) string {
+	return dialectMsgDefToGo(in)
+}
+
+func dialectMsgDefToGo(in string) string {
+	re := regexp.MustCompile("_[a-z]")
+	in = strings.ToLower(in)
+	in = re.ReplaceAllStringFunc(in, func(match string) string {
+		return strings.ToUpper(match[1:2])
+	})
+	return strings.ToUpper(in[:1]) + in[1:]
+}
+
+func filterDesc(in string) string {
+	return strings.ReplaceAll(in, "\n", "")
+}
+
+type outEnumValue struct {
+	Value       string
+	Name        string
+	Description string
+}
+
+type outEnum struct {
+	Name        string
+	Description string
+	Values      []*outEnumValue
+}
+
+type outField struct {
+	Name        string
+	Description string
+	Line        string
+	marshal     *marshalField
+}
+
+type outMessage struct {
+	Name        string
+	Description string
+	ID          int
+	Fields      []*outField
+	Marshalers  *outMarshalers
+}
+
+type outDefinition struct {
+	Name     string
+	Enums    []*outEnum
+	Messages []*outMessage
+}
+
+// UpstreamURL is the URL of the definitions of the official Mavlink
+// repository, that can be used as IncludeURL.
+const UpstreamURL = "https://raw.githubusercontent.com/mavlink/mavlink/master/message_definitions/v1.0/"
+
+// Conf allows to configure Convert.
+type Conf struct {
+	// the path or the URL of the XML definition of the dialect.
+	Definition string
+
+	// (optional) additional directories in which included definitions are
+	// searched, after the directory of the including definition.
+	IncludeDirs []string
+
+	// (optional) the URL from which included definitions are downloaded when
+	// they are not found locally, for instance UpstreamURL. It allows to
+	// convert custom dialects that include standard dialects (i.e.
+	// common.xml) without downloading them manually.
+	IncludeURL string
+
+	// (optional) the name of the package of the generated code.
+	// It defaults to "main".
+	PackageName string
+
+	// (optional) a comment that is added before the package name.
+	Comment string
+
+	// (optional) a writer that receives the list of processed definitions.
+	Log io.Writer
+}
+
+// isRemote returns whether a definition address is a URL.
+func isRemote(addr string) bool {
+	u, err := url.Parse(addr)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https")
+}
+
+// definitionName returns the file name of a definition address.
+func definitionName(addr string) string {
+	if isRemote(addr) {
+		u, _ := url.Parse(addr)
+		return path.Base(u.Path)
+	}
+	return filepath.Base(addr)
+}
+
+type converter struct {
+	conf          Conf
+	version       string
+	defsProcessed map[string]struct{}
+	messageNames  map[int]string // name of processed messages, by id
+	messageIDs    map[string]int // id of processed messages, by name
+}
+
+// Convert converts a dialect from XML format into Go format, and writes the
+// formatted Go code into w.
+func Convert(w io.Writer, conf Conf) error {
+	if conf.Definition == "" {
+		return fmt.Errorf("Definition not provided")
+	}
+	if conf.PackageName == "" {
+		conf.PackageName = "main"
+	}
+
+	if conf.IncludeURL != "" && !isRemote(conf.IncludeURL) {
+		return fmt.Errorf("IncludeURL must be a HTTP or HTTPS URL")
+	}
+
+	c := &converter{
+		conf:          conf,
+		defsProcessed: make(map[string]struct{}),
+		messageNames:  make(map[int]string),
+		messageIDs:    make(map[string]int),
+	}
+
+	mainDef := conf.Definition
+	if !isRemote(mainDef) {
+		mainDef = filepath.Clean(mainDef)
+	}
+
+	// parse all definitions recursively
+	outDefs, err := c.definitionProcess(mainDef)
+	if err != nil {
+		return err
+	}
+
+	// merge enums together
+	enums := make(map[string]*outEnum)
+	for _, def := range outDefs {
+		for _, defEnum := range def.Enums {
+			if _, ok := enums[defEnum.Name]; !ok {
+				enums[defEnum.Name] = &outEnum{
+					Name:        defEnum.Name,
+					Description: defEnum.Description,
+				}
+			}
+			enum := enums[defEnum.Name]
+
+			if enum.Description == "" {
+				enum.Description = defEnum.Description
+			}
+
+			for _, v := range defEnum.Values {
+				dup, err := enumValueDuplicate(enum, v)
+				if err != nil {
+					return err
+				}
+				if !dup {
+					enum.Values = append(enum.Values, v)
+				}
+			}
+		}
+	}
+
+	// fill enum missing values
+	for _, enum := range enums {
+		nextVal := 0
+		for _, v := range enum.Values {
+			if v.Value != "" {
+				nextVal, _ = strconv.Atoi(v.Value)
+				nextVal++
+			} else {
+				v.Value = strconv.Itoa(nextVal)
+				nextVal++
+			}
+		}
+	}
+
+	// imports and helpers needed by marshalers
+	needsBinary := false
+	needsMath := false
+	needsStrings := false
+	for _, def := range outDefs {
+		for _, msg := range def.Messages {
+			for _, f := range msg.Fields {
+				switch {
+				case f.marshal.isString:
+					needsStrings = true
+				case f.marshal.wireType == "float32" || f.marshal.wireType == "float64":
+					needsBinary = true
+					needsMath = true
+				case typeSizes[f.marshal.wireType] > 1:
+					needsBinary = true
+				}
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	err = tplDialect.Execute(&buf, map[string]interface{}{
+		"PkgName": conf.PackageName,
+		"Comment": conf.Comment,
+		"Version": func() int {
+			ret, _ := strconv.Atoi(c.version)
+			return ret
+		}(),
+		"Defs":    outDefs,
+		"Enums":   enums,
+		"Binary":  needsBinary,
+		"Math":    needsMath,
+		"Strings": needsStrings,
+	})
+	if err != nil {
+		return err
+	}
+
+	byts, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("unable to format: %s", err)
+	}
+
+	_, err = w.Write(byts)
+	return err
+}
+
+// enumValueDuplicate checks whether an enum value has already been defined
+// by another definition.
+func enumValueDuplicate(enum *outEnum, v *outEnumValue) (bool, error) {
+	for _, existing := range enum.Values {
+		if existing.Name == v.Name {
+			if existing.Value != "" && v.Value != "" && existing.Value != v.Value {
+				return false, fmt.Errorf("enum value %s defined twice with different values (%s and %s)",
+					v.Name, existing.Value, v.Value)
+			}
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// resolveInclude returns the address of a definition included by another
+// one.
+func (c *converter) resolveInclude(parentAddr string, inc string) (string, error) {
+	if isRemote(inc) {
+		return inc, nil
+	}
+
+	if isRemote(parentAddr) {
+		return resolveURL(parentAddr, inc)
+	}
+
+	candidates := []string{filepath.Join(filepath.Dir(parentAddr), inc)}
+	for _, dir := range c.conf.IncludeDirs {
+		candidates = append(candidates, filepath.Join(dir, inc))
+	}
+
+	for _, candidate := range candidates {
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate, nil
+		}
+	}
+
+	if c.conf.IncludeURL != "" {
+		base := c.conf.IncludeURL
+		if !strings.HasSuffix(base, "/") {
+			base += "/"
+		}
+		return resolveURL(base, inc)
+	}
+
+	// return the first candidate, in order to report a meaningful error
+	return candidates[0], nil
+}
+
+func resolveURL(base string, ref string) (string, error) {
+	u, err := url.Parse(base)
+	if err != nil {
+		return "", err
+	}
+
+	r, err := url.Parse(filepath.ToSlash(ref))
+	if err != nil {
+		return "", err
+	}
+
+	return u.ResolveReference(r).String(), nil
+}
+
+func (c *converter) definitionProcess(defAddr string) ([]*outDefinition, error) {
+	// skip already processed
+	if _, ok := c.defsProcessed[defAddr]; ok {
+		return nil, nil
+	}
+	c.defsProcessed[defAddr] = struct{}{}
+
+	if c.conf.Log != nil {
+		fmt.Fprintf(c.conf.Log, "definition %s\n", defAddr)
+	}
+
+	content, err := definitionGet(defAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	def, err := definitionDecode(content)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode %s: %s", defAddr, err)
+	}
+
+	var outDefs []*outDefinition
+
+	// version
+	if def.Version != "" {
+		if c.version != "" && c.version != def.Version {
+			return nil, fmt.Errorf("version defined twice (%s and %s)", def.Version, c.version)
+		}
+		c.version = def.Version
+	}
+
+	// includes
+	for _, inc := range def.Includes {
+		incAddr, err := c.resolveInclude(defAddr, strings.TrimSpace(inc))
+		if err != nil {
+			return nil, err
+		}
+
+		subDefs, err := c.definitionProcess(incAddr)
+		if err != nil {
+			return nil, err
+		}
+		outDefs = append(outDefs, subDefs...)
+	}
+
+	outDef := &outDefinition{
+		Name: definitionName(defAddr),
+	}
+
+	// enums
+	for _, enum := range def.Enums {
+		oute := &outEnum{
+			Name:        enum.Name,
+			Description: filterDesc(enum.Description),
+		}
+		for _, val := range enum.Values {
+			oute.Values = append(oute.Values, &outEnumValue{
+				Value:       val.Value,
+				Name:        val.Name,
+				Description: filterDesc(val.Description),
+			})
+		}
+		outDef.Enums = append(outDef.Enums, oute)
+	}
+
+	// messages
+	for _, msg := range def.Messages {
+		// messages defined by multiple definitions are generated once
+		if name, ok := c.messageNames[msg.ID]; ok {
+			if name != msg.Name {
+				return nil, fmt.Errorf("message id %d defined twice (%s and %s)", msg.ID, name, msg.Name)
+			}
+			continue
+		}
+		if id, ok := c.messageIDs[msg.Name]; ok {
+			return nil, fmt.Errorf("message %s defined twice (id %d and %d)", msg.Name, id, msg.ID)
+		}
+		c.messageNames[msg.ID] = msg.Name
+		c.messageIDs[msg.Name] = msg.ID
+
+		outMsg, err := messageProcess(msg)
+		if err != nil {
+			return nil, err
+		}
+		outDef.Messages = append(outDef.Messages, outMsg)
+	}
+
+	outDefs = append(outDefs, outDef)
+	return outDefs, nil
+}
+
+func definitionGet(defAddr string) ([]byte, error) {
+	if isRemote(defAddr) {
+		byt, err := download(defAddr)
+		if err != nil {
+			return nil, fmt.Errorf("unable to download: %s", err)
+		}
+		return byt, nil
+	}
+
+	byt, err := ioutil.ReadFile(defAddr)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open: %s", err)
+	}
+	return byt, nil
+}
+
+func download(desturl string) ([]byte, error) {
+	res, err := http.Get(desturl)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("bad return code: %v", res.StatusCode)
+	}
+
+	byt, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	return byt, nil
+}
+
+func messageProcess(msg *definitionMessage) (*outMessage, error) {
+	if m := reMsgName.FindStringSubmatch(msg.Name); m == nil {
+		return nil, fmt.Errorf("unsupported message name: %s", msg.Name)
+	}
+
+	outMsg := &outMessage{
+		Name:        dialectMsgDefToGo(msg.Name),
+		Description: filterDesc(msg.Description),
+		ID:          msg.ID,
+	}
+
+	marshalFields := make([]*marshalField, len(msg.Fields))
+
+	for i, f := range msg.Fields {
+		outField, err := fieldProcess(f)
+		if err != nil {
+			return nil, err
+		}
+		outField.marshal.index = i
+		outMsg.Fields = append(outMsg.Fields, outField)
+		marshalFields[i] = outField.marshal
+	}
+
+	outMsg.Marshalers = marshalersProcess(marshalFields)
+
+	return outMsg, nil
+}
+
+func fieldProcess(field *dialectField) (*outField, error) {
+	outF := &outField{
+		Description: filterDesc(field.Description),
+	}
+	tags := make(map[string]string)
+
+	newname := dialectFieldDefToGo(field.Name)
+	outF.Name = newname
+
+	// name conversion is not univoque: add tag
+	if dialectFieldGoToDef(newname) != field.Name {
+		tags["mavname"] = field.Name
+	}
+
+	outF.Line += newname
+
+	typ := field.Type
+	arrayLen := ""
+
+	if typ == "uint8_t_mavlink_version" {
+		typ = "uint8_t"
+	}
+
+	// string or array
+	if matches := reTypeIsArray.FindStringSubmatch(typ); matches != nil {
+		// string
+		if matches[1] == "char" {
+			tags["mavlen"] = matches[2]
+			typ = "char"
+			// array
+		} else {
+			arrayLen = matches[2]
+			typ = matches[1]
+		}
+	}
+
+	// extension
+	if field.Extension {
+		tags["mavext"] = "true"
+	}
+
+	// metadata
+	if field.Units != "" {
+		tags["mavunits"] = field.Units
+	}
+	if field.Display != "" {
+		tags["mavdisplay"] = field.Display
+	}
+
+	goTyp := dialectTypeToGo[typ]
+	if goTyp == "" {
+		return nil, fmt.Errorf("unknown type: %s", typ)
+	}
+	typ = goTyp
+
+	outF.marshal = &marshalField{
+		name:        newname,
+		goType:      typ,
+		wireType:    typ,
+		isExtension: field.Extension,
+	}
+	if arrayLen != "" {
+		outF.marshal.arrayLength, _ = strconv.Atoi(arrayLen)
+	}
+	if typ == "string" {
+		outF.marshal.isString = true
+		outF.marshal.arrayLength = 1
+		if l, ok := tags["mavlen"]; ok {
+			outF.marshal.arrayLength, _ = strconv.Atoi(l)
+		}
+	}
+
+	outF.Line += " "
+	if arrayLen != "" {
+		outF.Line += "[" + arrayLen + "]"
+	}
+	if field.Enum != "" {
+		outF.Line += field.Enum
+		tags["mavenum"] = typ
+		outF.marshal.goType = field.Enum
+		outF.marshal.isEnum = true
+	} else {
+		outF.Line += typ
+	}
+
+	if len(tags) > 0 {
+		var tmp []string
+		for k, v := range tags {
+			tmp = append(tmp, k+":"+strconv.Quote(v))
+		}
+		sort.Strings(tmp)
+		outF.Line += " `" + strings.Join(tmp, " ") + "`"
+	}
+	return outF, nil
+}
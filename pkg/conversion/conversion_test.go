@@ -0,0 +1,184 @@
+package conversion
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvert(t *testing.T) {
+	var buf bytes.Buffer
+	var log bytes.Buffer
+	err := Convert(&buf, Conf{
+		Definition:  "testdata/test.xml",
+		PackageName: "test",
+		Log:         &log,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "definition testdata/test.xml\n", log.String())
+
+	out := buf.String()
+
+	for _, line := range []string{
+		"package test\n",
+		"var dial = &dialect.Dialect{3, []msg.Message{",
+		"\tTEST_ENUM_A TEST_ENUM = 1\n",
+		"\tTEST_ENUM_B TEST_ENUM = 2\n",
+		"type MessageTestMessage struct {",
+		"\tTargetSystem uint8\n",
+		"\tMode TEST_ENUM `mavenum:\"uint8\"`\n",
+		"\tText string `mavlen:\"16\"`\n",
+		"\tQ [4]float32\n",
+		"\tLat int32 `mavunits:\"degE7\"`\n",
+		"\tFlags uint16 `mavdisplay:\"bitmask\"`\n",
+		"func (*MessageTestMessage) MessageDescription() string {\n\treturn \"A test message.\"\n}",
+		"\tcase \"Lat\":\n\t\treturn \"Latitude.\"\n",
+		"\tcase \"Flags\":\n\t\treturn \"Flags with \\\"quotes\\\".\"\n",
+		"\tExt uint16 `mavext:\"true\"`\n",
+		"func (*MessageTestMessage) GetID() uint32 {\n\treturn 5\n}",
+		"func (m *MessageTestMessage) MarshalPayload(buf []byte) {\n",
+		"\tbinary.LittleEndian.PutUint32(buf[16:], uint32(m.Lat))\n",
+		"\tcopy(buf[24:40], m.Text)\n",
+		"\tif len(buf) < 42 {\n\t\treturn\n\t}\n\tbinary.LittleEndian.PutUint16(buf[40:], m.Ext)\n",
+		"func (m *MessageTestMessage) UnmarshalPayload(buf []byte) {\n",
+		"\tm.Mode = TEST_ENUM(buf[23])\n",
+		"\tm.Text = decodeString(buf[24:40])\n",
+		"func decodeString(buf []byte) string {\n",
+	} {
+		require.True(t, strings.Contains(out, line), line)
+	}
+}
+
+func TestConvertErrors(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		conf Conf
+		err  string
+	}{
+		{
+			"no definition",
+			Conf{},
+			"Definition not provided",
+		},
+		{
+			"missing file",
+			Conf{Definition: "testdata/missing.xml"},
+			"unable to open: open testdata/missing.xml: no such file or directory",
+		},
+		{
+			"missing include",
+			Conf{Definition: "testdata/custom/custom.xml"},
+			"unable to open: open testdata/custom/base.xml: no such file or directory",
+		},
+		{
+			"include url",
+			Conf{Definition: "testdata/custom/custom.xml", IncludeURL: "testdata"},
+			"IncludeURL must be a HTTP or HTTPS URL",
+		},
+		{
+			"message id defined twice",
+			Conf{Definition: "testdata/errors/message_id.xml"},
+			"message id 1 defined twice (BASE_MESSAGE and OTHER_MESSAGE)",
+		},
+		{
+			"enum value defined twice",
+			Conf{Definition: "testdata/errors/enum_value.xml"},
+			"enum value BASE_ENUM_A defined twice with different values (0 and 5)",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			err := Convert(&bytes.Buffer{}, ca.conf)
+			require.EqualError(t, err, ca.err)
+		})
+	}
+}
+
+// requireIncludes checks the output of testdata/include/main.xml.
+func requireIncludes(t *testing.T, out string) {
+	for _, line := range []string{
+		"\t// base.xml\n\t&MessageBaseMessage{},\n" +
+			"\t// child.xml\n\t&MessageChildMessage{},\n" +
+			"\t// main.xml\n\t&MessageMainMessage{},\n",
+		"\tBASE_ENUM_A BASE_ENUM = 0\n",
+		"\tBASE_ENUM_B BASE_ENUM = 1\n",
+	} {
+		require.True(t, strings.Contains(out, line), line)
+	}
+
+	// definitions, messages and enum values are generated once
+	require.Equal(t, 1, strings.Count(out, "type MessageBaseMessage struct"))
+	require.Equal(t, 1, strings.Count(out, "\tBASE_ENUM_A BASE_ENUM = 0\n"))
+}
+
+func TestConvertIncludes(t *testing.T) {
+	var buf bytes.Buffer
+	var log bytes.Buffer
+	err := Convert(&buf, Conf{
+		Definition: "./testdata/include/main.xml",
+		Log:        &log,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "definition testdata/include/main.xml\n"+
+		"definition testdata/include/sub/child.xml\n"+
+		"definition testdata/include/base.xml\n", log.String())
+
+	requireIncludes(t, buf.String())
+}
+
+func TestConvertRemote(t *testing.T) {
+	srv := httptest.NewServer(http.FileServer(http.Dir("testdata/include")))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	var log bytes.Buffer
+	err := Convert(&buf, Conf{
+		Definition: srv.URL + "/main.xml",
+		Log:        &log,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "definition "+srv.URL+"/main.xml\n"+
+		"definition "+srv.URL+"/sub/child.xml\n"+
+		"definition "+srv.URL+"/base.xml\n", log.String())
+
+	requireIncludes(t, buf.String())
+}
+
+func TestConvertIncludeDirs(t *testing.T) {
+	var buf bytes.Buffer
+	err := Convert(&buf, Conf{
+		Definition:  "testdata/custom/custom.xml",
+		IncludeDirs: []string{"testdata/include"},
+	})
+	require.NoError(t, err)
+	require.True(t, strings.Contains(buf.String(), "type MessageBaseMessage struct"))
+	require.True(t, strings.Contains(buf.String(), "type MessageCustomMessage struct"))
+}
+
+func TestConvertIncludeURL(t *testing.T) {
+	srv := httptest.NewServer(http.FileServer(http.Dir("testdata/include")))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	var log bytes.Buffer
+	err := Convert(&buf, Conf{
+		Definition: "testdata/custom/custom.xml",
+		IncludeURL: srv.URL,
+		Log:        &log,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "definition testdata/custom/custom.xml\n"+
+		"definition "+srv.URL+"/base.xml\n", log.String())
+	require.True(t, strings.Contains(buf.String(), "type MessageBaseMessage struct"))
+	require.True(t, strings.Contains(buf.String(), "type MessageCustomMessage struct"))
+}
+
+func TestIsRemote(t *testing.T) {
+	require.Equal(t, true, isRemote("https://raw.githubusercontent.com/mavlink/mavlink/master/message_definitions/v1.0/common.xml"))
+	require.Equal(t, false, isRemote("my_dialect.xml"))
+	require.Equal(t, false, isRemote("testdata/test.xml"))
+	require.Equal(t, false, isRemote("/usr/share/mavlink/common.xml"))
+}
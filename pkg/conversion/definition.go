@@ -1,4 +1,4 @@
-package main
+package conversion
 
 import (
 	"encoding/xml"
@@ -22,6 +22,8 @@ type dialectField struct {
 	Type        string `xml:"type,attr"`
 	Name        string `xml:"name,attr"`
 	Enum        string `xml:"enum,attr"`
+	Units       string `xml:"units,attr"`
+	Display     string `xml:"display,attr"`
 	Description string `xml:",innerxml"`
 }
 
@@ -0,0 +1,183 @@
+package conversion
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var typeSizes = map[string]int{
+	"float64": 8,
+	"uint64":  8,
+	"int64":   8,
+	"float32": 4,
+	"uint32":  4,
+	"int32":   4,
+	"uint16":  2,
+	"int16":   2,
+	"uint8":   1,
+	"int8":    1,
+	"string":  1,
+}
+
+// marshalField contains the informations needed to generate the code that
+// encodes and decodes a field without reflection.
+type marshalField struct {
+	name        string
+	goType      string // type of the Go field, or of its elements if it's an array
+	wireType    string // Go type that corresponds to the wire type
+	arrayLength int
+	isString    bool
+	isEnum      bool
+	isExtension bool
+	index       int
+	offset      int
+}
+
+func (f *marshalField) size() int {
+	if f.arrayLength > 0 {
+		return typeSizes[f.wireType] * f.arrayLength
+	}
+	return typeSizes[f.wireType]
+}
+
+// outMarshalers contains the body of the MarshalPayload and UnmarshalPayload
+// methods of a message.
+type outMarshalers struct {
+	Encode           []string
+	Decode           []string
+	Extensions       bool
+	EncodeExtensions []string
+	DecodeExtensions []string
+	SizeExtended     int
+}
+
+// encodeValue returns the statement that writes v at buf[off:].
+func encodeValue(f *marshalField, off string, v string) string {
+	if f.isEnum {
+		v = f.wireType + "(" + v + ")"
+	}
+
+	switch f.wireType {
+	case "uint8":
+		return "buf[" + off + "] = " + v
+	case "int8":
+		return "buf[" + off + "] = uint8(" + v + ")"
+	case "uint16", "uint32", "uint64":
+		return "binary.LittleEndian.Put" + strings.Title(f.wireType) + "(buf[" + off + ":], " + v + ")"
+	case "int16", "int32", "int64":
+		return "binary.LittleEndian.PutU" + f.wireType + "(buf[" + off + ":], u" + f.wireType + "(" + v + "))"
+	case "float32":
+		return "binary.LittleEndian.PutUint32(buf[" + off + ":], math.Float32bits(" + v + "))"
+	}
+	// float64
+	return "binary.LittleEndian.PutUint64(buf[" + off + ":], math.Float64bits(" + v + "))"
+}
+
+// decodeValue returns the expression that reads a value from buf[off:].
+func decodeValue(f *marshalField, off string) string {
+	var v string
+	switch f.wireType {
+	case "uint8":
+		v = "buf[" + off + "]"
+	case "int8":
+		v = "int8(buf[" + off + "])"
+	case "uint16", "uint32", "uint64":
+		v = "binary.LittleEndian." + strings.Title(f.wireType) + "(buf[" + off + ":])"
+	case "int16", "int32", "int64":
+		v = f.wireType + "(binary.LittleEndian.Uint" + f.wireType[3:] + "(buf[" + off + ":]))"
+	case "float32":
+		v = "math.Float32frombits(binary.LittleEndian.Uint32(buf[" + off + ":]))"
+	default: // float64
+		v = "math.Float64frombits(binary.LittleEndian.Uint64(buf[" + off + ":]))"
+	}
+
+	if f.isEnum {
+		// enums are decoded as unsigned integers
+		switch f.wireType {
+		case "int8":
+			v = "buf[" + off + "]"
+		case "int32":
+			v = "binary.LittleEndian.Uint32(buf[" + off + ":])"
+		}
+		v = f.goType + "(" + v + ")"
+	}
+
+	return v
+}
+
+func encodeField(f *marshalField) string {
+	switch {
+	case f.isString:
+		return fmt.Sprintf("copy(buf[%d:%d], m.%s)", f.offset, f.offset+f.arrayLength, f.name)
+
+	case f.arrayLength > 0 && f.wireType == "uint8" && !f.isEnum:
+		return fmt.Sprintf("copy(buf[%d:%d], m.%s[:])", f.offset, f.offset+f.arrayLength, f.name)
+
+	case f.arrayLength > 0:
+		off := fmt.Sprintf("%d+i*%d", f.offset, typeSizes[f.wireType])
+		if typeSizes[f.wireType] == 1 {
+			off = fmt.Sprintf("%d+i", f.offset)
+		}
+		return fmt.Sprintf("for i, v := range m.%s {\n%s\n}", f.name, encodeValue(f, off, "v"))
+	}
+
+	return encodeValue(f, strconv.Itoa(f.offset), "m."+f.name)
+}
+
+func decodeField(f *marshalField) string {
+	switch {
+	case f.isString:
+		return fmt.Sprintf("m.%s = decodeString(buf[%d:%d])", f.name, f.offset, f.offset+f.arrayLength)
+
+	case f.arrayLength > 0 && f.wireType == "uint8" && !f.isEnum:
+		return fmt.Sprintf("copy(m.%s[:], buf[%d:%d])", f.name, f.offset, f.offset+f.arrayLength)
+
+	case f.arrayLength > 0:
+		off := fmt.Sprintf("%d+i*%d", f.offset, typeSizes[f.wireType])
+		if typeSizes[f.wireType] == 1 {
+			off = fmt.Sprintf("%d+i", f.offset)
+		}
+		return fmt.Sprintf("for i := range m.%s {\nm.%s[i] = %s\n}", f.name, f.name, decodeValue(f, off))
+	}
+
+	return fmt.Sprintf("m.%s = %s", f.name, decodeValue(f, strconv.Itoa(f.offset)))
+}
+
+// marshalersProcess generates the body of the MarshalPayload and
+// UnmarshalPayload methods of a message.
+func marshalersProcess(fields []*marshalField) *outMarshalers {
+	sorted := append([]*marshalField(nil), fields...)
+
+	// reorder fields as described in
+	// https://mavlink.io/en/guide/serialization.html#field_reordering
+	sort.Slice(sorted, func(i, j int) bool {
+		if !sorted[i].isExtension && !sorted[j].isExtension {
+			if w1, w2 := typeSizes[sorted[i].wireType], typeSizes[sorted[j].wireType]; w1 != w2 {
+				return w1 > w2
+			}
+		}
+		return sorted[i].index < sorted[j].index
+	})
+
+	out := &outMarshalers{}
+
+	offset := 0
+	for _, f := range sorted {
+		f.offset = offset
+		offset += f.size()
+
+		if f.isExtension {
+			out.Extensions = true
+			out.EncodeExtensions = append(out.EncodeExtensions, encodeField(f))
+			out.DecodeExtensions = append(out.DecodeExtensions, decodeField(f))
+		} else {
+			out.Encode = append(out.Encode, encodeField(f))
+			out.Decode = append(out.Decode, decodeField(f))
+		}
+	}
+	out.SizeExtended = offset
+
+	return out
+}
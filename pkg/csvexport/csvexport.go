@@ -0,0 +1,201 @@
+// Package csvexport converts decoded messages into CSV files, one for each
+// message type.
+package csvexport
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/aler9/gomavlib"
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/msg"
+	"github.com/aler9/gomavlib/pkg/tlog"
+)
+
+type file struct {
+	f *os.File
+	w *csv.Writer
+}
+
+// Exporter writes decoded messages into a directory, in CSV format.
+// Each message type is written into a file named after the message
+// (for instance ATTITUDE.csv), that contains a timestamp column (seconds
+// since the Unix epoch), the system id, the component id and a column for
+// each message field. Arrays are split into multiple columns,
+// named name_0, name_1, etc.
+type Exporter struct {
+	dir   string
+	files map[reflect.Type]*file
+}
+
+// NewExporter allocates an Exporter. The directory is created if it does
+// not exist.
+func NewExporter(dir string) (*Exporter, error) {
+	err := os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Exporter{
+		dir:   dir,
+		files: make(map[reflect.Type]*file),
+	}, nil
+}
+
+// Close flushes and closes all files.
+func (e *Exporter) Close() error {
+	var ret error
+	for _, f := range e.files {
+		f.w.Flush()
+		if err := f.w.Error(); err != nil && ret == nil {
+			ret = err
+		}
+		if err := f.f.Close(); err != nil && ret == nil {
+			ret = err
+		}
+	}
+	e.files = nil
+	return ret
+}
+
+// OnEventFrame writes a received frame, by using the current time as timestamp.
+// Messages that have not been decoded are skipped.
+func (e *Exporter) OnEventFrame(evt *gomavlib.EventFrame) error {
+	return e.Write(time.Now(), evt.SystemID(), evt.ComponentID(), evt.Message())
+}
+
+// Write writes a message.
+// Messages that have not been decoded are skipped.
+func (e *Exporter) Write(t time.Time, systemID byte, componentID byte, m msg.Message) error {
+	if _, ok := m.(*msg.MessageRaw); ok {
+		return nil
+	}
+
+	rv := reflect.ValueOf(m).Elem()
+	rt := rv.Type()
+
+	f, ok := e.files[rt]
+	if !ok {
+		var err error
+		f, err = e.createFile(m)
+		if err != nil {
+			return err
+		}
+		e.files[rt] = f
+	}
+
+	record := []string{
+		strconv.FormatFloat(float64(t.UnixNano())/float64(time.Second), 'f', 6, 64),
+		strconv.FormatUint(uint64(systemID), 10),
+		strconv.FormatUint(uint64(componentID), 10),
+	}
+
+	for i := 0; i < rt.NumField(); i++ {
+		v := rv.Field(i)
+		if v.Kind() == reflect.Array {
+			for j := 0; j < v.Len(); j++ {
+				record = append(record, formatValue(v.Index(j)))
+			}
+		} else {
+			record = append(record, formatValue(v))
+		}
+	}
+
+	return f.w.Write(record)
+}
+
+func (e *Exporter) createFile(m msg.Message) (*file, error) {
+	f, err := os.Create(filepath.Join(e.dir, msg.Name(m)+".csv"))
+	if err != nil {
+		return nil, err
+	}
+
+	w := csv.NewWriter(f)
+
+	header := []string{"timestamp", "system_id", "component_id"}
+	rt := reflect.TypeOf(m).Elem()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		name := msg.FieldName(field)
+		if field.Type.Kind() == reflect.Array {
+			for j := 0; j < field.Type.Len(); j++ {
+				header = append(header, name+"_"+strconv.FormatInt(int64(j), 10))
+			}
+		} else {
+			header = append(header, name)
+		}
+	}
+
+	err = w.Write(header)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return &file{f, w}, nil
+}
+
+// formatValue formats a field. Enums are written in numeric form.
+func formatValue(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.Float32:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 32)
+
+	case reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 64)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10)
+
+	case reflect.String:
+		return v.String()
+	}
+
+	return fmt.Sprintf("%v", v.Interface())
+}
+
+// ExportTlog converts a tlog into CSV files, written into a directory.
+// The dialect is used to decode messages; messages that are not in the
+// dialect are skipped.
+func ExportTlog(r io.Reader, d *dialect.Dialect, dir string) error {
+	if d == nil {
+		return fmt.Errorf("dialect not provided")
+	}
+
+	tr, err := tlog.NewReader(r, d)
+	if err != nil {
+		return err
+	}
+
+	e, err := NewExporter(dir)
+	if err != nil {
+		return err
+	}
+
+	for {
+		entry, err := tr.Read()
+		if err != nil {
+			if err == io.EOF {
+				return e.Close()
+			}
+			e.Close()
+			return err
+		}
+
+		err = e.Write(entry.Time, entry.Frame.GetSystemID(), entry.Frame.GetComponentID(),
+			entry.Frame.GetMessage())
+		if err != nil {
+			e.Close()
+			return err
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package csvexport
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+	"github.com/aler9/gomavlib/pkg/frame"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+func TestExporter(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gomavlib-csvexport")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	e, err := NewExporter(dir)
+	require.NoError(t, err)
+
+	err = e.Write(time.Unix(1, 500000000), 1, 2, &common.MessageAttitudeQuaternion{
+		TimeBootMs:  10,
+		Q1:          1,
+		ReprOffsetQ: [4]float32{0.5, 0, 0, 0},
+	})
+	require.NoError(t, err)
+
+	err = e.Write(time.Unix(2, 0), 1, 2, &common.MessageParamValue{
+		ParamId:    "A,B",
+		ParamValue: 1.5,
+		ParamType:  common.MAV_PARAM_TYPE_REAL32,
+	})
+	require.NoError(t, err)
+
+	err = e.Write(time.Unix(3, 0), 1, 2, &msg.MessageRaw{ID: 1})
+	require.NoError(t, err)
+
+	err = e.Close()
+	require.NoError(t, err)
+
+	byts, err := ioutil.ReadFile(filepath.Join(dir, "ATTITUDE_QUATERNION.csv"))
+	require.NoError(t, err)
+	require.Equal(t, "timestamp,system_id,component_id,time_boot_ms,q1,q2,q3,q4,"+
+		"rollspeed,pitchspeed,yawspeed,repr_offset_q_0,repr_offset_q_1,repr_offset_q_2,repr_offset_q_3\n"+
+		"1.500000,1,2,10,1,0,0,0,0,0,0,0.5,0,0,0\n", string(byts))
+
+	byts, err = ioutil.ReadFile(filepath.Join(dir, "PARAM_VALUE.csv"))
+	require.NoError(t, err)
+	require.Equal(t, "timestamp,system_id,component_id,param_id,param_value,param_type,param_count,param_index\n"+
+		"2.000000,1,2,\"A,B\",1.5,9,0,0\n", string(byts))
+}
+
+func testFrame(t *testing.T, m msg.Message) frame.Frame {
+	mde, err := msg.NewDecEncoder(m)
+	require.NoError(t, err)
+
+	content, err := mde.Encode(m, true)
+	require.NoError(t, err)
+
+	f := &frame.V2Frame{
+		SystemID:    1,
+		ComponentID: 1,
+		Message:     &msg.MessageRaw{ID: m.GetID(), Content: content},
+	}
+	f.Checksum = f.GenChecksum(mde.CRCExtra())
+	return f
+}
+
+func TestExportTlog(t *testing.T) {
+	var buf bytes.Buffer
+	for i := 0; i < 3; i++ {
+		f := testFrame(t, &common.MessageSystemTime{TimeUnixUsec: uint64(i)})
+
+		var ts [8]byte
+		binary.BigEndian.PutUint64(ts[:], uint64(i)*1000000)
+		buf.Write(ts[:])
+
+		byts, err := f.Encode(make([]byte, 512), f.GetMessage().(*msg.MessageRaw).Content)
+		require.NoError(t, err)
+		buf.Write(byts)
+	}
+
+	dir, err := ioutil.TempDir("", "gomavlib-csvexport")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	err = ExportTlog(bytes.NewReader(buf.Bytes()), common.Dialect, dir)
+	require.NoError(t, err)
+
+	byts, err := ioutil.ReadFile(filepath.Join(dir, "SYSTEM_TIME.csv"))
+	require.NoError(t, err)
+	require.Equal(t, "timestamp,system_id,component_id,time_unix_usec,time_boot_ms\n"+
+		"0.000000,1,1,0,0\n"+
+		"1.000000,1,1,1,0\n"+
+		"2.000000,1,1,2,0\n", string(byts))
+
+	err = ExportTlog(bytes.NewReader(buf.Bytes()), nil, dir)
+	require.Error(t, err)
+}
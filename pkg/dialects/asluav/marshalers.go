@@ -0,0 +1,6385 @@
+//nolint:golint,misspell,govet
+package asluav
+
+// Code generated by dialect-marshalers. DO NOT EDIT.
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageHeartbeat) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], m.CustomMode)
+	buf[4] = uint8(m.Type)
+	buf[5] = uint8(m.Autopilot)
+	buf[6] = uint8(m.BaseMode)
+	buf[7] = uint8(m.SystemStatus)
+	buf[8] = m.MavlinkVersion
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageHeartbeat) UnmarshalPayload(buf []byte) {
+	m.CustomMode = binary.LittleEndian.Uint32(buf[0:])
+	m.Type = MAV_TYPE(buf[4])
+	m.Autopilot = MAV_AUTOPILOT(buf[5])
+	m.BaseMode = MAV_MODE_FLAG(buf[6])
+	m.SystemStatus = MAV_STATE(buf[7])
+	m.MavlinkVersion = buf[8]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageProtocolVersion) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint16(buf[0:], m.Version)
+	binary.LittleEndian.PutUint16(buf[2:], m.MinVersion)
+	binary.LittleEndian.PutUint16(buf[4:], m.MaxVersion)
+	copy(buf[6:14], m.SpecVersionHash[:])
+	copy(buf[14:22], m.LibraryVersionHash[:])
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageProtocolVersion) UnmarshalPayload(buf []byte) {
+	m.Version = binary.LittleEndian.Uint16(buf[0:])
+	m.MinVersion = binary.LittleEndian.Uint16(buf[2:])
+	m.MaxVersion = binary.LittleEndian.Uint16(buf[4:])
+	copy(m.SpecVersionHash[:], buf[6:14])
+	copy(m.LibraryVersionHash[:], buf[14:22])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageSysStatus) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], uint32(m.OnboardControlSensorsPresent))
+	binary.LittleEndian.PutUint32(buf[4:], uint32(m.OnboardControlSensorsEnabled))
+	binary.LittleEndian.PutUint32(buf[8:], uint32(m.OnboardControlSensorsHealth))
+	binary.LittleEndian.PutUint16(buf[12:], m.Load)
+	binary.LittleEndian.PutUint16(buf[14:], m.VoltageBattery)
+	binary.LittleEndian.PutUint16(buf[16:], uint16(m.CurrentBattery))
+	binary.LittleEndian.PutUint16(buf[18:], m.DropRateComm)
+	binary.LittleEndian.PutUint16(buf[20:], m.ErrorsComm)
+	binary.LittleEndian.PutUint16(buf[22:], m.ErrorsCount1)
+	binary.LittleEndian.PutUint16(buf[24:], m.ErrorsCount2)
+	binary.LittleEndian.PutUint16(buf[26:], m.ErrorsCount3)
+	binary.LittleEndian.PutUint16(buf[28:], m.ErrorsCount4)
+	buf[30] = uint8(m.BatteryRemaining)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageSysStatus) UnmarshalPayload(buf []byte) {
+	m.OnboardControlSensorsPresent = MAV_SYS_STATUS_SENSOR(binary.LittleEndian.Uint32(buf[0:]))
+	m.OnboardControlSensorsEnabled = MAV_SYS_STATUS_SENSOR(binary.LittleEndian.Uint32(buf[4:]))
+	m.OnboardControlSensorsHealth = MAV_SYS_STATUS_SENSOR(binary.LittleEndian.Uint32(buf[8:]))
+	m.Load = binary.LittleEndian.Uint16(buf[12:])
+	m.VoltageBattery = binary.LittleEndian.Uint16(buf[14:])
+	m.CurrentBattery = int16(binary.LittleEndian.Uint16(buf[16:]))
+	m.DropRateComm = binary.LittleEndian.Uint16(buf[18:])
+	m.ErrorsComm = binary.LittleEndian.Uint16(buf[20:])
+	m.ErrorsCount1 = binary.LittleEndian.Uint16(buf[22:])
+	m.ErrorsCount2 = binary.LittleEndian.Uint16(buf[24:])
+	m.ErrorsCount3 = binary.LittleEndian.Uint16(buf[26:])
+	m.ErrorsCount4 = binary.LittleEndian.Uint16(buf[28:])
+	m.BatteryRemaining = int8(buf[30])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageSystemTime) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.TimeUnixUsec)
+	binary.LittleEndian.PutUint32(buf[8:], m.TimeBootMs)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageSystemTime) UnmarshalPayload(buf []byte) {
+	m.TimeUnixUsec = binary.LittleEndian.Uint64(buf[0:])
+	m.TimeBootMs = binary.LittleEndian.Uint32(buf[8:])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessagePing) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.TimeUsec)
+	binary.LittleEndian.PutUint32(buf[8:], m.Seq)
+	buf[12] = m.TargetSystem
+	buf[13] = m.TargetComponent
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessagePing) UnmarshalPayload(buf []byte) {
+	m.TimeUsec = binary.LittleEndian.Uint64(buf[0:])
+	m.Seq = binary.LittleEndian.Uint32(buf[8:])
+	m.TargetSystem = buf[12]
+	m.TargetComponent = buf[13]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageChangeOperatorControl) MarshalPayload(buf []byte) {
+	buf[0] = m.TargetSystem
+	buf[1] = m.ControlRequest
+	buf[2] = m.Version
+	copy(buf[3:28], m.Passkey)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageChangeOperatorControl) UnmarshalPayload(buf []byte) {
+	m.TargetSystem = buf[0]
+	m.ControlRequest = buf[1]
+	m.Version = buf[2]
+	m.Passkey = decodeString(buf[3:28])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageChangeOperatorControlAck) MarshalPayload(buf []byte) {
+	buf[0] = m.GcsSystemId
+	buf[1] = m.ControlRequest
+	buf[2] = m.Ack
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageChangeOperatorControlAck) UnmarshalPayload(buf []byte) {
+	m.GcsSystemId = buf[0]
+	m.ControlRequest = buf[1]
+	m.Ack = buf[2]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageAuthKey) MarshalPayload(buf []byte) {
+	copy(buf[0:32], m.Key)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageAuthKey) UnmarshalPayload(buf []byte) {
+	m.Key = decodeString(buf[0:32])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageLinkNodeStatus) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.Timestamp)
+	binary.LittleEndian.PutUint32(buf[8:], m.TxRate)
+	binary.LittleEndian.PutUint32(buf[12:], m.RxRate)
+	binary.LittleEndian.PutUint32(buf[16:], m.MessagesSent)
+	binary.LittleEndian.PutUint32(buf[20:], m.MessagesReceived)
+	binary.LittleEndian.PutUint32(buf[24:], m.MessagesLost)
+	binary.LittleEndian.PutUint16(buf[28:], m.RxParseErr)
+	binary.LittleEndian.PutUint16(buf[30:], m.TxOverflows)
+	binary.LittleEndian.PutUint16(buf[32:], m.RxOverflows)
+	buf[34] = m.TxBuf
+	buf[35] = m.RxBuf
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageLinkNodeStatus) UnmarshalPayload(buf []byte) {
+	m.Timestamp = binary.LittleEndian.Uint64(buf[0:])
+	m.TxRate = binary.LittleEndian.Uint32(buf[8:])
+	m.RxRate = binary.LittleEndian.Uint32(buf[12:])
+	m.MessagesSent = binary.LittleEndian.Uint32(buf[16:])
+	m.MessagesReceived = binary.LittleEndian.Uint32(buf[20:])
+	m.MessagesLost = binary.LittleEndian.Uint32(buf[24:])
+	m.RxParseErr = binary.LittleEndian.Uint16(buf[28:])
+	m.TxOverflows = binary.LittleEndian.Uint16(buf[30:])
+	m.RxOverflows = binary.LittleEndian.Uint16(buf[32:])
+	m.TxBuf = buf[34]
+	m.RxBuf = buf[35]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageSetMode) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], m.CustomMode)
+	buf[4] = m.TargetSystem
+	buf[5] = uint8(m.BaseMode)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageSetMode) UnmarshalPayload(buf []byte) {
+	m.CustomMode = binary.LittleEndian.Uint32(buf[0:])
+	m.TargetSystem = buf[4]
+	m.BaseMode = MAV_MODE(buf[5])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageParamAckTransaction) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], math.Float32bits(m.ParamValue))
+	buf[4] = m.TargetSystem
+	buf[5] = m.TargetComponent
+	copy(buf[6:22], m.ParamId)
+	buf[22] = uint8(m.ParamType)
+	buf[23] = uint8(m.ParamResult)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageParamAckTransaction) UnmarshalPayload(buf []byte) {
+	m.ParamValue = math.Float32frombits(binary.LittleEndian.Uint32(buf[0:]))
+	m.TargetSystem = buf[4]
+	m.TargetComponent = buf[5]
+	m.ParamId = decodeString(buf[6:22])
+	m.ParamType = MAV_PARAM_TYPE(buf[22])
+	m.ParamResult = PARAM_ACK(buf[23])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageParamRequestRead) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint16(buf[0:], uint16(m.ParamIndex))
+	buf[2] = m.TargetSystem
+	buf[3] = m.TargetComponent
+	copy(buf[4:20], m.ParamId)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageParamRequestRead) UnmarshalPayload(buf []byte) {
+	m.ParamIndex = int16(binary.LittleEndian.Uint16(buf[0:]))
+	m.TargetSystem = buf[2]
+	m.TargetComponent = buf[3]
+	m.ParamId = decodeString(buf[4:20])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageParamRequestList) MarshalPayload(buf []byte) {
+	buf[0] = m.TargetSystem
+	buf[1] = m.TargetComponent
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageParamRequestList) UnmarshalPayload(buf []byte) {
+	m.TargetSystem = buf[0]
+	m.TargetComponent = buf[1]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageParamValue) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], math.Float32bits(m.ParamValue))
+	binary.LittleEndian.PutUint16(buf[4:], m.ParamCount)
+	binary.LittleEndian.PutUint16(buf[6:], m.ParamIndex)
+	copy(buf[8:24], m.ParamId)
+	buf[24] = uint8(m.ParamType)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageParamValue) UnmarshalPayload(buf []byte) {
+	m.ParamValue = math.Float32frombits(binary.LittleEndian.Uint32(buf[0:]))
+	m.ParamCount = binary.LittleEndian.Uint16(buf[4:])
+	m.ParamIndex = binary.LittleEndian.Uint16(buf[6:])
+	m.ParamId = decodeString(buf[8:24])
+	m.ParamType = MAV_PARAM_TYPE(buf[24])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageParamSet) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], math.Float32bits(m.ParamValue))
+	buf[4] = m.TargetSystem
+	buf[5] = m.TargetComponent
+	copy(buf[6:22], m.ParamId)
+	buf[22] = uint8(m.ParamType)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageParamSet) UnmarshalPayload(buf []byte) {
+	m.ParamValue = math.Float32frombits(binary.LittleEndian.Uint32(buf[0:]))
+	m.TargetSystem = buf[4]
+	m.TargetComponent = buf[5]
+	m.ParamId = decodeString(buf[6:22])
+	m.ParamType = MAV_PARAM_TYPE(buf[22])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageGpsRawInt) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.TimeUsec)
+	binary.LittleEndian.PutUint32(buf[8:], uint32(m.Lat))
+	binary.LittleEndian.PutUint32(buf[12:], uint32(m.Lon))
+	binary.LittleEndian.PutUint32(buf[16:], uint32(m.Alt))
+	binary.LittleEndian.PutUint16(buf[20:], m.Eph)
+	binary.LittleEndian.PutUint16(buf[22:], m.Epv)
+	binary.LittleEndian.PutUint16(buf[24:], m.Vel)
+	binary.LittleEndian.PutUint16(buf[26:], m.Cog)
+	buf[28] = uint8(m.FixType)
+	buf[29] = m.SatellitesVisible
+	if len(buf) < 52 {
+		return
+	}
+	binary.LittleEndian.PutUint32(buf[30:], uint32(m.AltEllipsoid))
+	binary.LittleEndian.PutUint32(buf[34:], m.HAcc)
+	binary.LittleEndian.PutUint32(buf[38:], m.VAcc)
+	binary.LittleEndian.PutUint32(buf[42:], m.VelAcc)
+	binary.LittleEndian.PutUint32(buf[46:], m.HdgAcc)
+	binary.LittleEndian.PutUint16(buf[50:], m.Yaw)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageGpsRawInt) UnmarshalPayload(buf []byte) {
+	m.TimeUsec = binary.LittleEndian.Uint64(buf[0:])
+	m.Lat = int32(binary.LittleEndian.Uint32(buf[8:]))
+	m.Lon = int32(binary.LittleEndian.Uint32(buf[12:]))
+	m.Alt = int32(binary.LittleEndian.Uint32(buf[16:]))
+	m.Eph = binary.LittleEndian.Uint16(buf[20:])
+	m.Epv = binary.LittleEndian.Uint16(buf[22:])
+	m.Vel = binary.LittleEndian.Uint16(buf[24:])
+	m.Cog = binary.LittleEndian.Uint16(buf[26:])
+	m.FixType = GPS_FIX_TYPE(buf[28])
+	m.SatellitesVisible = buf[29]
+	if len(buf) < 52 {
+		return
+	}
+	m.AltEllipsoid = int32(binary.LittleEndian.Uint32(buf[30:]))
+	m.HAcc = binary.LittleEndian.Uint32(buf[34:])
+	m.VAcc = binary.LittleEndian.Uint32(buf[38:])
+	m.VelAcc = binary.LittleEndian.Uint32(buf[42:])
+	m.HdgAcc = binary.LittleEndian.Uint32(buf[46:])
+	m.Yaw = binary.LittleEndian.Uint16(buf[50:])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageGpsStatus) MarshalPayload(buf []byte) {
+	buf[0] = m.SatellitesVisible
+	copy(buf[1:21], m.SatellitePrn[:])
+	copy(buf[21:41], m.SatelliteUsed[:])
+	copy(buf[41:61], m.SatelliteElevation[:])
+	copy(buf[61:81], m.SatelliteAzimuth[:])
+	copy(buf[81:101], m.SatelliteSnr[:])
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageGpsStatus) UnmarshalPayload(buf []byte) {
+	m.SatellitesVisible = buf[0]
+	copy(m.SatellitePrn[:], buf[1:21])
+	copy(m.SatelliteUsed[:], buf[21:41])
+	copy(m.SatelliteElevation[:], buf[41:61])
+	copy(m.SatelliteAzimuth[:], buf[61:81])
+	copy(m.SatelliteSnr[:], buf[81:101])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageScaledImu) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], m.TimeBootMs)
+	binary.LittleEndian.PutUint16(buf[4:], uint16(m.Xacc))
+	binary.LittleEndian.PutUint16(buf[6:], uint16(m.Yacc))
+	binary.LittleEndian.PutUint16(buf[8:], uint16(m.Zacc))
+	binary.LittleEndian.PutUint16(buf[10:], uint16(m.Xgyro))
+	binary.LittleEndian.PutUint16(buf[12:], uint16(m.Ygyro))
+	binary.LittleEndian.PutUint16(buf[14:], uint16(m.Zgyro))
+	binary.LittleEndian.PutUint16(buf[16:], uint16(m.Xmag))
+	binary.LittleEndian.PutUint16(buf[18:], uint16(m.Ymag))
+	binary.LittleEndian.PutUint16(buf[20:], uint16(m.Zmag))
+	if len(buf) < 24 {
+		return
+	}
+	binary.LittleEndian.PutUint16(buf[22:], uint16(m.Temperature))
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageScaledImu) UnmarshalPayload(buf []byte) {
+	m.TimeBootMs = binary.LittleEndian.Uint32(buf[0:])
+	m.Xacc = int16(binary.LittleEndian.Uint16(buf[4:]))
+	m.Yacc = int16(binary.LittleEndian.Uint16(buf[6:]))
+	m.Zacc = int16(binary.LittleEndian.Uint16(buf[8:]))
+	m.Xgyro = int16(binary.LittleEndian.Uint16(buf[10:]))
+	m.Ygyro = int16(binary.LittleEndian.Uint16(buf[12:]))
+	m.Zgyro = int16(binary.LittleEndian.Uint16(buf[14:]))
+	m.Xmag = int16(binary.LittleEndian.Uint16(buf[16:]))
+	m.Ymag = int16(binary.LittleEndian.Uint16(buf[18:]))
+	m.Zmag = int16(binary.LittleEndian.Uint16(buf[20:]))
+	if len(buf) < 24 {
+		return
+	}
+	m.Temperature = int16(binary.LittleEndian.Uint16(buf[22:]))
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageRawImu) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.TimeUsec)
+	binary.LittleEndian.PutUint16(buf[8:], uint16(m.Xacc))
+	binary.LittleEndian.PutUint16(buf[10:], uint16(m.Yacc))
+	binary.LittleEndian.PutUint16(buf[12:], uint16(m.Zacc))
+	binary.LittleEndian.PutUint16(buf[14:], uint16(m.Xgyro))
+	binary.LittleEndian.PutUint16(buf[16:], uint16(m.Ygyro))
+	binary.LittleEndian.PutUint16(buf[18:], uint16(m.Zgyro))
+	binary.LittleEndian.PutUint16(buf[20:], uint16(m.Xmag))
+	binary.LittleEndian.PutUint16(buf[22:], uint16(m.Ymag))
+	binary.LittleEndian.PutUint16(buf[24:], uint16(m.Zmag))
+	if len(buf) < 29 {
+		return
+	}
+	buf[26] = m.Id
+	binary.LittleEndian.PutUint16(buf[27:], uint16(m.Temperature))
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageRawImu) UnmarshalPayload(buf []byte) {
+	m.TimeUsec = binary.LittleEndian.Uint64(buf[0:])
+	m.Xacc = int16(binary.LittleEndian.Uint16(buf[8:]))
+	m.Yacc = int16(binary.LittleEndian.Uint16(buf[10:]))
+	m.Zacc = int16(binary.LittleEndian.Uint16(buf[12:]))
+	m.Xgyro = int16(binary.LittleEndian.Uint16(buf[14:]))
+	m.Ygyro = int16(binary.LittleEndian.Uint16(buf[16:]))
+	m.Zgyro = int16(binary.LittleEndian.Uint16(buf[18:]))
+	m.Xmag = int16(binary.LittleEndian.Uint16(buf[20:]))
+	m.Ymag = int16(binary.LittleEndian.Uint16(buf[22:]))
+	m.Zmag = int16(binary.LittleEndian.Uint16(buf[24:]))
+	if len(buf) < 29 {
+		return
+	}
+	m.Id = buf[26]
+	m.Temperature = int16(binary.LittleEndian.Uint16(buf[27:]))
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageRawPressure) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.TimeUsec)
+	binary.LittleEndian.PutUint16(buf[8:], uint16(m.PressAbs))
+	binary.LittleEndian.PutUint16(buf[10:], uint16(m.PressDiff1))
+	binary.LittleEndian.PutUint16(buf[12:], uint16(m.PressDiff2))
+	binary.LittleEndian.PutUint16(buf[14:], uint16(m.Temperature))
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageRawPressure) UnmarshalPayload(buf []byte) {
+	m.TimeUsec = binary.LittleEndian.Uint64(buf[0:])
+	m.PressAbs = int16(binary.LittleEndian.Uint16(buf[8:]))
+	m.PressDiff1 = int16(binary.LittleEndian.Uint16(buf[10:]))
+	m.PressDiff2 = int16(binary.LittleEndian.Uint16(buf[12:]))
+	m.Temperature = int16(binary.LittleEndian.Uint16(buf[14:]))
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageScaledPressure) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], m.TimeBootMs)
+	binary.LittleEndian.PutUint32(buf[4:], math.Float32bits(m.PressAbs))
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.PressDiff))
+	binary.LittleEndian.PutUint16(buf[12:], uint16(m.Temperature))
+	if len(buf) < 16 {
+		return
+	}
+	binary.LittleEndian.PutUint16(buf[14:], uint16(m.TemperaturePressDiff))
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageScaledPressure) UnmarshalPayload(buf []byte) {
+	m.TimeBootMs = binary.LittleEndian.Uint32(buf[0:])
+	m.PressAbs = math.Float32frombits(binary.LittleEndian.Uint32(buf[4:]))
+	m.PressDiff = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.Temperature = int16(binary.LittleEndian.Uint16(buf[12:]))
+	if len(buf) < 16 {
+		return
+	}
+	m.TemperaturePressDiff = int16(binary.LittleEndian.Uint16(buf[14:]))
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageAttitude) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], m.TimeBootMs)
+	binary.LittleEndian.PutUint32(buf[4:], math.Float32bits(m.Roll))
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.Pitch))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.Yaw))
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.Rollspeed))
+	binary.LittleEndian.PutUint32(buf[20:], math.Float32bits(m.Pitchspeed))
+	binary.LittleEndian.PutUint32(buf[24:], math.Float32bits(m.Yawspeed))
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageAttitude) UnmarshalPayload(buf []byte) {
+	m.TimeBootMs = binary.LittleEndian.Uint32(buf[0:])
+	m.Roll = math.Float32frombits(binary.LittleEndian.Uint32(buf[4:]))
+	m.Pitch = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.Yaw = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.Rollspeed = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	m.Pitchspeed = math.Float32frombits(binary.LittleEndian.Uint32(buf[20:]))
+	m.Yawspeed = math.Float32frombits(binary.LittleEndian.Uint32(buf[24:]))
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageAttitudeQuaternion) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], m.TimeBootMs)
+	binary.LittleEndian.PutUint32(buf[4:], math.Float32bits(m.Q1))
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.Q2))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.Q3))
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.Q4))
+	binary.LittleEndian.PutUint32(buf[20:], math.Float32bits(m.Rollspeed))
+	binary.LittleEndian.PutUint32(buf[24:], math.Float32bits(m.Pitchspeed))
+	binary.LittleEndian.PutUint32(buf[28:], math.Float32bits(m.Yawspeed))
+	if len(buf) < 48 {
+		return
+	}
+	for i, v := range m.ReprOffsetQ {
+		binary.LittleEndian.PutUint32(buf[32+i*4:], math.Float32bits(v))
+	}
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageAttitudeQuaternion) UnmarshalPayload(buf []byte) {
+	m.TimeBootMs = binary.LittleEndian.Uint32(buf[0:])
+	m.Q1 = math.Float32frombits(binary.LittleEndian.Uint32(buf[4:]))
+	m.Q2 = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.Q3 = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.Q4 = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	m.Rollspeed = math.Float32frombits(binary.LittleEndian.Uint32(buf[20:]))
+	m.Pitchspeed = math.Float32frombits(binary.LittleEndian.Uint32(buf[24:]))
+	m.Yawspeed = math.Float32frombits(binary.LittleEndian.Uint32(buf[28:]))
+	if len(buf) < 48 {
+		return
+	}
+	for i := range m.ReprOffsetQ {
+		m.ReprOffsetQ[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[32+i*4:]))
+	}
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageLocalPositionNed) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], m.TimeBootMs)
+	binary.LittleEndian.PutUint32(buf[4:], math.Float32bits(m.X))
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.Y))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.Z))
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.Vx))
+	binary.LittleEndian.PutUint32(buf[20:], math.Float32bits(m.Vy))
+	binary.LittleEndian.PutUint32(buf[24:], math.Float32bits(m.Vz))
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageLocalPositionNed) UnmarshalPayload(buf []byte) {
+	m.TimeBootMs = binary.LittleEndian.Uint32(buf[0:])
+	m.X = math.Float32frombits(binary.LittleEndian.Uint32(buf[4:]))
+	m.Y = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.Z = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.Vx = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	m.Vy = math.Float32frombits(binary.LittleEndian.Uint32(buf[20:]))
+	m.Vz = math.Float32frombits(binary.LittleEndian.Uint32(buf[24:]))
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageGlobalPositionInt) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], m.TimeBootMs)
+	binary.LittleEndian.PutUint32(buf[4:], uint32(m.Lat))
+	binary.LittleEndian.PutUint32(buf[8:], uint32(m.Lon))
+	binary.LittleEndian.PutUint32(buf[12:], uint32(m.Alt))
+	binary.LittleEndian.PutUint32(buf[16:], uint32(m.RelativeAlt))
+	binary.LittleEndian.PutUint16(buf[20:], uint16(m.Vx))
+	binary.LittleEndian.PutUint16(buf[22:], uint16(m.Vy))
+	binary.LittleEndian.PutUint16(buf[24:], uint16(m.Vz))
+	binary.LittleEndian.PutUint16(buf[26:], m.Hdg)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageGlobalPositionInt) UnmarshalPayload(buf []byte) {
+	m.TimeBootMs = binary.LittleEndian.Uint32(buf[0:])
+	m.Lat = int32(binary.LittleEndian.Uint32(buf[4:]))
+	m.Lon = int32(binary.LittleEndian.Uint32(buf[8:]))
+	m.Alt = int32(binary.LittleEndian.Uint32(buf[12:]))
+	m.RelativeAlt = int32(binary.LittleEndian.Uint32(buf[16:]))
+	m.Vx = int16(binary.LittleEndian.Uint16(buf[20:]))
+	m.Vy = int16(binary.LittleEndian.Uint16(buf[22:]))
+	m.Vz = int16(binary.LittleEndian.Uint16(buf[24:]))
+	m.Hdg = binary.LittleEndian.Uint16(buf[26:])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageRcChannelsScaled) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], m.TimeBootMs)
+	binary.LittleEndian.PutUint16(buf[4:], uint16(m.Chan1Scaled))
+	binary.LittleEndian.PutUint16(buf[6:], uint16(m.Chan2Scaled))
+	binary.LittleEndian.PutUint16(buf[8:], uint16(m.Chan3Scaled))
+	binary.LittleEndian.PutUint16(buf[10:], uint16(m.Chan4Scaled))
+	binary.LittleEndian.PutUint16(buf[12:], uint16(m.Chan5Scaled))
+	binary.LittleEndian.PutUint16(buf[14:], uint16(m.Chan6Scaled))
+	binary.LittleEndian.PutUint16(buf[16:], uint16(m.Chan7Scaled))
+	binary.LittleEndian.PutUint16(buf[18:], uint16(m.Chan8Scaled))
+	buf[20] = m.Port
+	buf[21] = m.Rssi
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageRcChannelsScaled) UnmarshalPayload(buf []byte) {
+	m.TimeBootMs = binary.LittleEndian.Uint32(buf[0:])
+	m.Chan1Scaled = int16(binary.LittleEndian.Uint16(buf[4:]))
+	m.Chan2Scaled = int16(binary.LittleEndian.Uint16(buf[6:]))
+	m.Chan3Scaled = int16(binary.LittleEndian.Uint16(buf[8:]))
+	m.Chan4Scaled = int16(binary.LittleEndian.Uint16(buf[10:]))
+	m.Chan5Scaled = int16(binary.LittleEndian.Uint16(buf[12:]))
+	m.Chan6Scaled = int16(binary.LittleEndian.Uint16(buf[14:]))
+	m.Chan7Scaled = int16(binary.LittleEndian.Uint16(buf[16:]))
+	m.Chan8Scaled = int16(binary.LittleEndian.Uint16(buf[18:]))
+	m.Port = buf[20]
+	m.Rssi = buf[21]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageRcChannelsRaw) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], m.TimeBootMs)
+	binary.LittleEndian.PutUint16(buf[4:], m.Chan1Raw)
+	binary.LittleEndian.PutUint16(buf[6:], m.Chan2Raw)
+	binary.LittleEndian.PutUint16(buf[8:], m.Chan3Raw)
+	binary.LittleEndian.PutUint16(buf[10:], m.Chan4Raw)
+	binary.LittleEndian.PutUint16(buf[12:], m.Chan5Raw)
+	binary.LittleEndian.PutUint16(buf[14:], m.Chan6Raw)
+	binary.LittleEndian.PutUint16(buf[16:], m.Chan7Raw)
+	binary.LittleEndian.PutUint16(buf[18:], m.Chan8Raw)
+	buf[20] = m.Port
+	buf[21] = m.Rssi
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageRcChannelsRaw) UnmarshalPayload(buf []byte) {
+	m.TimeBootMs = binary.LittleEndian.Uint32(buf[0:])
+	m.Chan1Raw = binary.LittleEndian.Uint16(buf[4:])
+	m.Chan2Raw = binary.LittleEndian.Uint16(buf[6:])
+	m.Chan3Raw = binary.LittleEndian.Uint16(buf[8:])
+	m.Chan4Raw = binary.LittleEndian.Uint16(buf[10:])
+	m.Chan5Raw = binary.LittleEndian.Uint16(buf[12:])
+	m.Chan6Raw = binary.LittleEndian.Uint16(buf[14:])
+	m.Chan7Raw = binary.LittleEndian.Uint16(buf[16:])
+	m.Chan8Raw = binary.LittleEndian.Uint16(buf[18:])
+	m.Port = buf[20]
+	m.Rssi = buf[21]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageServoOutputRaw) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], m.TimeUsec)
+	binary.LittleEndian.PutUint16(buf[4:], m.Servo1Raw)
+	binary.LittleEndian.PutUint16(buf[6:], m.Servo2Raw)
+	binary.LittleEndian.PutUint16(buf[8:], m.Servo3Raw)
+	binary.LittleEndian.PutUint16(buf[10:], m.Servo4Raw)
+	binary.LittleEndian.PutUint16(buf[12:], m.Servo5Raw)
+	binary.LittleEndian.PutUint16(buf[14:], m.Servo6Raw)
+	binary.LittleEndian.PutUint16(buf[16:], m.Servo7Raw)
+	binary.LittleEndian.PutUint16(buf[18:], m.Servo8Raw)
+	buf[20] = m.Port
+	if len(buf) < 37 {
+		return
+	}
+	binary.LittleEndian.PutUint16(buf[21:], m.Servo9Raw)
+	binary.LittleEndian.PutUint16(buf[23:], m.Servo10Raw)
+	binary.LittleEndian.PutUint16(buf[25:], m.Servo11Raw)
+	binary.LittleEndian.PutUint16(buf[27:], m.Servo12Raw)
+	binary.LittleEndian.PutUint16(buf[29:], m.Servo13Raw)
+	binary.LittleEndian.PutUint16(buf[31:], m.Servo14Raw)
+	binary.LittleEndian.PutUint16(buf[33:], m.Servo15Raw)
+	binary.LittleEndian.PutUint16(buf[35:], m.Servo16Raw)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageServoOutputRaw) UnmarshalPayload(buf []byte) {
+	m.TimeUsec = binary.LittleEndian.Uint32(buf[0:])
+	m.Servo1Raw = binary.LittleEndian.Uint16(buf[4:])
+	m.Servo2Raw = binary.LittleEndian.Uint16(buf[6:])
+	m.Servo3Raw = binary.LittleEndian.Uint16(buf[8:])
+	m.Servo4Raw = binary.LittleEndian.Uint16(buf[10:])
+	m.Servo5Raw = binary.LittleEndian.Uint16(buf[12:])
+	m.Servo6Raw = binary.LittleEndian.Uint16(buf[14:])
+	m.Servo7Raw = binary.LittleEndian.Uint16(buf[16:])
+	m.Servo8Raw = binary.LittleEndian.Uint16(buf[18:])
+	m.Port = buf[20]
+	if len(buf) < 37 {
+		return
+	}
+	m.Servo9Raw = binary.LittleEndian.Uint16(buf[21:])
+	m.Servo10Raw = binary.LittleEndian.Uint16(buf[23:])
+	m.Servo11Raw = binary.LittleEndian.Uint16(buf[25:])
+	m.Servo12Raw = binary.LittleEndian.Uint16(buf[27:])
+	m.Servo13Raw = binary.LittleEndian.Uint16(buf[29:])
+	m.Servo14Raw = binary.LittleEndian.Uint16(buf[31:])
+	m.Servo15Raw = binary.LittleEndian.Uint16(buf[33:])
+	m.Servo16Raw = binary.LittleEndian.Uint16(buf[35:])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageMissionRequestPartialList) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint16(buf[0:], uint16(m.StartIndex))
+	binary.LittleEndian.PutUint16(buf[2:], uint16(m.EndIndex))
+	buf[4] = m.TargetSystem
+	buf[5] = m.TargetComponent
+	if len(buf) < 7 {
+		return
+	}
+	buf[6] = uint8(m.MissionType)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageMissionRequestPartialList) UnmarshalPayload(buf []byte) {
+	m.StartIndex = int16(binary.LittleEndian.Uint16(buf[0:]))
+	m.EndIndex = int16(binary.LittleEndian.Uint16(buf[2:]))
+	m.TargetSystem = buf[4]
+	m.TargetComponent = buf[5]
+	if len(buf) < 7 {
+		return
+	}
+	m.MissionType = MAV_MISSION_TYPE(buf[6])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageMissionWritePartialList) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint16(buf[0:], uint16(m.StartIndex))
+	binary.LittleEndian.PutUint16(buf[2:], uint16(m.EndIndex))
+	buf[4] = m.TargetSystem
+	buf[5] = m.TargetComponent
+	if len(buf) < 7 {
+		return
+	}
+	buf[6] = uint8(m.MissionType)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageMissionWritePartialList) UnmarshalPayload(buf []byte) {
+	m.StartIndex = int16(binary.LittleEndian.Uint16(buf[0:]))
+	m.EndIndex = int16(binary.LittleEndian.Uint16(buf[2:]))
+	m.TargetSystem = buf[4]
+	m.TargetComponent = buf[5]
+	if len(buf) < 7 {
+		return
+	}
+	m.MissionType = MAV_MISSION_TYPE(buf[6])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageMissionItem) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], math.Float32bits(m.Param1))
+	binary.LittleEndian.PutUint32(buf[4:], math.Float32bits(m.Param2))
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.Param3))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.Param4))
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.X))
+	binary.LittleEndian.PutUint32(buf[20:], math.Float32bits(m.Y))
+	binary.LittleEndian.PutUint32(buf[24:], math.Float32bits(m.Z))
+	binary.LittleEndian.PutUint16(buf[28:], m.Seq)
+	binary.LittleEndian.PutUint16(buf[30:], uint16(m.Command))
+	buf[32] = m.TargetSystem
+	buf[33] = m.TargetComponent
+	buf[34] = uint8(m.Frame)
+	buf[35] = m.Current
+	buf[36] = m.Autocontinue
+	if len(buf) < 38 {
+		return
+	}
+	buf[37] = uint8(m.MissionType)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageMissionItem) UnmarshalPayload(buf []byte) {
+	m.Param1 = math.Float32frombits(binary.LittleEndian.Uint32(buf[0:]))
+	m.Param2 = math.Float32frombits(binary.LittleEndian.Uint32(buf[4:]))
+	m.Param3 = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.Param4 = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.X = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	m.Y = math.Float32frombits(binary.LittleEndian.Uint32(buf[20:]))
+	m.Z = math.Float32frombits(binary.LittleEndian.Uint32(buf[24:]))
+	m.Seq = binary.LittleEndian.Uint16(buf[28:])
+	m.Command = MAV_CMD(binary.LittleEndian.Uint16(buf[30:]))
+	m.TargetSystem = buf[32]
+	m.TargetComponent = buf[33]
+	m.Frame = MAV_FRAME(buf[34])
+	m.Current = buf[35]
+	m.Autocontinue = buf[36]
+	if len(buf) < 38 {
+		return
+	}
+	m.MissionType = MAV_MISSION_TYPE(buf[37])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageMissionRequest) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint16(buf[0:], m.Seq)
+	buf[2] = m.TargetSystem
+	buf[3] = m.TargetComponent
+	if len(buf) < 5 {
+		return
+	}
+	buf[4] = uint8(m.MissionType)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageMissionRequest) UnmarshalPayload(buf []byte) {
+	m.Seq = binary.LittleEndian.Uint16(buf[0:])
+	m.TargetSystem = buf[2]
+	m.TargetComponent = buf[3]
+	if len(buf) < 5 {
+		return
+	}
+	m.MissionType = MAV_MISSION_TYPE(buf[4])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageMissionSetCurrent) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint16(buf[0:], m.Seq)
+	buf[2] = m.TargetSystem
+	buf[3] = m.TargetComponent
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageMissionSetCurrent) UnmarshalPayload(buf []byte) {
+	m.Seq = binary.LittleEndian.Uint16(buf[0:])
+	m.TargetSystem = buf[2]
+	m.TargetComponent = buf[3]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageMissionCurrent) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint16(buf[0:], m.Seq)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageMissionCurrent) UnmarshalPayload(buf []byte) {
+	m.Seq = binary.LittleEndian.Uint16(buf[0:])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageMissionRequestList) MarshalPayload(buf []byte) {
+	buf[0] = m.TargetSystem
+	buf[1] = m.TargetComponent
+	if len(buf) < 3 {
+		return
+	}
+	buf[2] = uint8(m.MissionType)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageMissionRequestList) UnmarshalPayload(buf []byte) {
+	m.TargetSystem = buf[0]
+	m.TargetComponent = buf[1]
+	if len(buf) < 3 {
+		return
+	}
+	m.MissionType = MAV_MISSION_TYPE(buf[2])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageMissionCount) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint16(buf[0:], m.Count)
+	buf[2] = m.TargetSystem
+	buf[3] = m.TargetComponent
+	if len(buf) < 5 {
+		return
+	}
+	buf[4] = uint8(m.MissionType)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageMissionCount) UnmarshalPayload(buf []byte) {
+	m.Count = binary.LittleEndian.Uint16(buf[0:])
+	m.TargetSystem = buf[2]
+	m.TargetComponent = buf[3]
+	if len(buf) < 5 {
+		return
+	}
+	m.MissionType = MAV_MISSION_TYPE(buf[4])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageMissionClearAll) MarshalPayload(buf []byte) {
+	buf[0] = m.TargetSystem
+	buf[1] = m.TargetComponent
+	if len(buf) < 3 {
+		return
+	}
+	buf[2] = uint8(m.MissionType)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageMissionClearAll) UnmarshalPayload(buf []byte) {
+	m.TargetSystem = buf[0]
+	m.TargetComponent = buf[1]
+	if len(buf) < 3 {
+		return
+	}
+	m.MissionType = MAV_MISSION_TYPE(buf[2])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageMissionItemReached) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint16(buf[0:], m.Seq)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageMissionItemReached) UnmarshalPayload(buf []byte) {
+	m.Seq = binary.LittleEndian.Uint16(buf[0:])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageMissionAck) MarshalPayload(buf []byte) {
+	buf[0] = m.TargetSystem
+	buf[1] = m.TargetComponent
+	buf[2] = uint8(m.Type)
+	if len(buf) < 4 {
+		return
+	}
+	buf[3] = uint8(m.MissionType)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageMissionAck) UnmarshalPayload(buf []byte) {
+	m.TargetSystem = buf[0]
+	m.TargetComponent = buf[1]
+	m.Type = MAV_MISSION_RESULT(buf[2])
+	if len(buf) < 4 {
+		return
+	}
+	m.MissionType = MAV_MISSION_TYPE(buf[3])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageSetGpsGlobalOrigin) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], uint32(m.Latitude))
+	binary.LittleEndian.PutUint32(buf[4:], uint32(m.Longitude))
+	binary.LittleEndian.PutUint32(buf[8:], uint32(m.Altitude))
+	buf[12] = m.TargetSystem
+	if len(buf) < 21 {
+		return
+	}
+	binary.LittleEndian.PutUint64(buf[13:], m.TimeUsec)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageSetGpsGlobalOrigin) UnmarshalPayload(buf []byte) {
+	m.Latitude = int32(binary.LittleEndian.Uint32(buf[0:]))
+	m.Longitude = int32(binary.LittleEndian.Uint32(buf[4:]))
+	m.Altitude = int32(binary.LittleEndian.Uint32(buf[8:]))
+	m.TargetSystem = buf[12]
+	if len(buf) < 21 {
+		return
+	}
+	m.TimeUsec = binary.LittleEndian.Uint64(buf[13:])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageGpsGlobalOrigin) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], uint32(m.Latitude))
+	binary.LittleEndian.PutUint32(buf[4:], uint32(m.Longitude))
+	binary.LittleEndian.PutUint32(buf[8:], uint32(m.Altitude))
+	if len(buf) < 20 {
+		return
+	}
+	binary.LittleEndian.PutUint64(buf[12:], m.TimeUsec)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageGpsGlobalOrigin) UnmarshalPayload(buf []byte) {
+	m.Latitude = int32(binary.LittleEndian.Uint32(buf[0:]))
+	m.Longitude = int32(binary.LittleEndian.Uint32(buf[4:]))
+	m.Altitude = int32(binary.LittleEndian.Uint32(buf[8:]))
+	if len(buf) < 20 {
+		return
+	}
+	m.TimeUsec = binary.LittleEndian.Uint64(buf[12:])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageParamMapRc) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], math.Float32bits(m.ParamValue0))
+	binary.LittleEndian.PutUint32(buf[4:], math.Float32bits(m.Scale))
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.ParamValueMin))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.ParamValueMax))
+	binary.LittleEndian.PutUint16(buf[16:], uint16(m.ParamIndex))
+	buf[18] = m.TargetSystem
+	buf[19] = m.TargetComponent
+	copy(buf[20:36], m.ParamId)
+	buf[36] = m.ParameterRcChannelIndex
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageParamMapRc) UnmarshalPayload(buf []byte) {
+	m.ParamValue0 = math.Float32frombits(binary.LittleEndian.Uint32(buf[0:]))
+	m.Scale = math.Float32frombits(binary.LittleEndian.Uint32(buf[4:]))
+	m.ParamValueMin = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.ParamValueMax = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.ParamIndex = int16(binary.LittleEndian.Uint16(buf[16:]))
+	m.TargetSystem = buf[18]
+	m.TargetComponent = buf[19]
+	m.ParamId = decodeString(buf[20:36])
+	m.ParameterRcChannelIndex = buf[36]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageMissionRequestInt) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint16(buf[0:], m.Seq)
+	buf[2] = m.TargetSystem
+	buf[3] = m.TargetComponent
+	if len(buf) < 5 {
+		return
+	}
+	buf[4] = uint8(m.MissionType)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageMissionRequestInt) UnmarshalPayload(buf []byte) {
+	m.Seq = binary.LittleEndian.Uint16(buf[0:])
+	m.TargetSystem = buf[2]
+	m.TargetComponent = buf[3]
+	if len(buf) < 5 {
+		return
+	}
+	m.MissionType = MAV_MISSION_TYPE(buf[4])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageMissionChanged) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint16(buf[0:], uint16(m.StartIndex))
+	binary.LittleEndian.PutUint16(buf[2:], uint16(m.EndIndex))
+	buf[4] = m.OriginSysid
+	buf[5] = uint8(m.OriginCompid)
+	buf[6] = uint8(m.MissionType)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageMissionChanged) UnmarshalPayload(buf []byte) {
+	m.StartIndex = int16(binary.LittleEndian.Uint16(buf[0:]))
+	m.EndIndex = int16(binary.LittleEndian.Uint16(buf[2:]))
+	m.OriginSysid = buf[4]
+	m.OriginCompid = MAV_COMPONENT(buf[5])
+	m.MissionType = MAV_MISSION_TYPE(buf[6])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageSafetySetAllowedArea) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], math.Float32bits(m.P1x))
+	binary.LittleEndian.PutUint32(buf[4:], math.Float32bits(m.P1y))
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.P1z))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.P2x))
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.P2y))
+	binary.LittleEndian.PutUint32(buf[20:], math.Float32bits(m.P2z))
+	buf[24] = m.TargetSystem
+	buf[25] = m.TargetComponent
+	buf[26] = uint8(m.Frame)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageSafetySetAllowedArea) UnmarshalPayload(buf []byte) {
+	m.P1x = math.Float32frombits(binary.LittleEndian.Uint32(buf[0:]))
+	m.P1y = math.Float32frombits(binary.LittleEndian.Uint32(buf[4:]))
+	m.P1z = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.P2x = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.P2y = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	m.P2z = math.Float32frombits(binary.LittleEndian.Uint32(buf[20:]))
+	m.TargetSystem = buf[24]
+	m.TargetComponent = buf[25]
+	m.Frame = MAV_FRAME(buf[26])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageSafetyAllowedArea) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], math.Float32bits(m.P1x))
+	binary.LittleEndian.PutUint32(buf[4:], math.Float32bits(m.P1y))
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.P1z))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.P2x))
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.P2y))
+	binary.LittleEndian.PutUint32(buf[20:], math.Float32bits(m.P2z))
+	buf[24] = uint8(m.Frame)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageSafetyAllowedArea) UnmarshalPayload(buf []byte) {
+	m.P1x = math.Float32frombits(binary.LittleEndian.Uint32(buf[0:]))
+	m.P1y = math.Float32frombits(binary.LittleEndian.Uint32(buf[4:]))
+	m.P1z = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.P2x = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.P2y = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	m.P2z = math.Float32frombits(binary.LittleEndian.Uint32(buf[20:]))
+	m.Frame = MAV_FRAME(buf[24])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageAttitudeQuaternionCov) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.TimeUsec)
+	for i, v := range m.Q {
+		binary.LittleEndian.PutUint32(buf[8+i*4:], math.Float32bits(v))
+	}
+	binary.LittleEndian.PutUint32(buf[24:], math.Float32bits(m.Rollspeed))
+	binary.LittleEndian.PutUint32(buf[28:], math.Float32bits(m.Pitchspeed))
+	binary.LittleEndian.PutUint32(buf[32:], math.Float32bits(m.Yawspeed))
+	for i, v := range m.Covariance {
+		binary.LittleEndian.PutUint32(buf[36+i*4:], math.Float32bits(v))
+	}
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageAttitudeQuaternionCov) UnmarshalPayload(buf []byte) {
+	m.TimeUsec = binary.LittleEndian.Uint64(buf[0:])
+	for i := range m.Q {
+		m.Q[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[8+i*4:]))
+	}
+	m.Rollspeed = math.Float32frombits(binary.LittleEndian.Uint32(buf[24:]))
+	m.Pitchspeed = math.Float32frombits(binary.LittleEndian.Uint32(buf[28:]))
+	m.Yawspeed = math.Float32frombits(binary.LittleEndian.Uint32(buf[32:]))
+	for i := range m.Covariance {
+		m.Covariance[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[36+i*4:]))
+	}
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageNavControllerOutput) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], math.Float32bits(m.NavRoll))
+	binary.LittleEndian.PutUint32(buf[4:], math.Float32bits(m.NavPitch))
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.AltError))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.AspdError))
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.XtrackError))
+	binary.LittleEndian.PutUint16(buf[20:], uint16(m.NavBearing))
+	binary.LittleEndian.PutUint16(buf[22:], uint16(m.TargetBearing))
+	binary.LittleEndian.PutUint16(buf[24:], m.WpDist)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageNavControllerOutput) UnmarshalPayload(buf []byte) {
+	m.NavRoll = math.Float32frombits(binary.LittleEndian.Uint32(buf[0:]))
+	m.NavPitch = math.Float32frombits(binary.LittleEndian.Uint32(buf[4:]))
+	m.AltError = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.AspdError = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.XtrackError = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	m.NavBearing = int16(binary.LittleEndian.Uint16(buf[20:]))
+	m.TargetBearing = int16(binary.LittleEndian.Uint16(buf[22:]))
+	m.WpDist = binary.LittleEndian.Uint16(buf[24:])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageGlobalPositionIntCov) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.TimeUsec)
+	binary.LittleEndian.PutUint32(buf[8:], uint32(m.Lat))
+	binary.LittleEndian.PutUint32(buf[12:], uint32(m.Lon))
+	binary.LittleEndian.PutUint32(buf[16:], uint32(m.Alt))
+	binary.LittleEndian.PutUint32(buf[20:], uint32(m.RelativeAlt))
+	binary.LittleEndian.PutUint32(buf[24:], math.Float32bits(m.Vx))
+	binary.LittleEndian.PutUint32(buf[28:], math.Float32bits(m.Vy))
+	binary.LittleEndian.PutUint32(buf[32:], math.Float32bits(m.Vz))
+	for i, v := range m.Covariance {
+		binary.LittleEndian.PutUint32(buf[36+i*4:], math.Float32bits(v))
+	}
+	buf[180] = uint8(m.EstimatorType)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageGlobalPositionIntCov) UnmarshalPayload(buf []byte) {
+	m.TimeUsec = binary.LittleEndian.Uint64(buf[0:])
+	m.Lat = int32(binary.LittleEndian.Uint32(buf[8:]))
+	m.Lon = int32(binary.LittleEndian.Uint32(buf[12:]))
+	m.Alt = int32(binary.LittleEndian.Uint32(buf[16:]))
+	m.RelativeAlt = int32(binary.LittleEndian.Uint32(buf[20:]))
+	m.Vx = math.Float32frombits(binary.LittleEndian.Uint32(buf[24:]))
+	m.Vy = math.Float32frombits(binary.LittleEndian.Uint32(buf[28:]))
+	m.Vz = math.Float32frombits(binary.LittleEndian.Uint32(buf[32:]))
+	for i := range m.Covariance {
+		m.Covariance[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[36+i*4:]))
+	}
+	m.EstimatorType = MAV_ESTIMATOR_TYPE(buf[180])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageLocalPositionNedCov) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.TimeUsec)
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.X))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.Y))
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.Z))
+	binary.LittleEndian.PutUint32(buf[20:], math.Float32bits(m.Vx))
+	binary.LittleEndian.PutUint32(buf[24:], math.Float32bits(m.Vy))
+	binary.LittleEndian.PutUint32(buf[28:], math.Float32bits(m.Vz))
+	binary.LittleEndian.PutUint32(buf[32:], math.Float32bits(m.Ax))
+	binary.LittleEndian.PutUint32(buf[36:], math.Float32bits(m.Ay))
+	binary.LittleEndian.PutUint32(buf[40:], math.Float32bits(m.Az))
+	for i, v := range m.Covariance {
+		binary.LittleEndian.PutUint32(buf[44+i*4:], math.Float32bits(v))
+	}
+	buf[224] = uint8(m.EstimatorType)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageLocalPositionNedCov) UnmarshalPayload(buf []byte) {
+	m.TimeUsec = binary.LittleEndian.Uint64(buf[0:])
+	m.X = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.Y = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.Z = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	m.Vx = math.Float32frombits(binary.LittleEndian.Uint32(buf[20:]))
+	m.Vy = math.Float32frombits(binary.LittleEndian.Uint32(buf[24:]))
+	m.Vz = math.Float32frombits(binary.LittleEndian.Uint32(buf[28:]))
+	m.Ax = math.Float32frombits(binary.LittleEndian.Uint32(buf[32:]))
+	m.Ay = math.Float32frombits(binary.LittleEndian.Uint32(buf[36:]))
+	m.Az = math.Float32frombits(binary.LittleEndian.Uint32(buf[40:]))
+	for i := range m.Covariance {
+		m.Covariance[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[44+i*4:]))
+	}
+	m.EstimatorType = MAV_ESTIMATOR_TYPE(buf[224])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageRcChannels) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], m.TimeBootMs)
+	binary.LittleEndian.PutUint16(buf[4:], m.Chan1Raw)
+	binary.LittleEndian.PutUint16(buf[6:], m.Chan2Raw)
+	binary.LittleEndian.PutUint16(buf[8:], m.Chan3Raw)
+	binary.LittleEndian.PutUint16(buf[10:], m.Chan4Raw)
+	binary.LittleEndian.PutUint16(buf[12:], m.Chan5Raw)
+	binary.LittleEndian.PutUint16(buf[14:], m.Chan6Raw)
+	binary.LittleEndian.PutUint16(buf[16:], m.Chan7Raw)
+	binary.LittleEndian.PutUint16(buf[18:], m.Chan8Raw)
+	binary.LittleEndian.PutUint16(buf[20:], m.Chan9Raw)
+	binary.LittleEndian.PutUint16(buf[22:], m.Chan10Raw)
+	binary.LittleEndian.PutUint16(buf[24:], m.Chan11Raw)
+	binary.LittleEndian.PutUint16(buf[26:], m.Chan12Raw)
+	binary.LittleEndian.PutUint16(buf[28:], m.Chan13Raw)
+	binary.LittleEndian.PutUint16(buf[30:], m.Chan14Raw)
+	binary.LittleEndian.PutUint16(buf[32:], m.Chan15Raw)
+	binary.LittleEndian.PutUint16(buf[34:], m.Chan16Raw)
+	binary.LittleEndian.PutUint16(buf[36:], m.Chan17Raw)
+	binary.LittleEndian.PutUint16(buf[38:], m.Chan18Raw)
+	buf[40] = m.Chancount
+	buf[41] = m.Rssi
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageRcChannels) UnmarshalPayload(buf []byte) {
+	m.TimeBootMs = binary.LittleEndian.Uint32(buf[0:])
+	m.Chan1Raw = binary.LittleEndian.Uint16(buf[4:])
+	m.Chan2Raw = binary.LittleEndian.Uint16(buf[6:])
+	m.Chan3Raw = binary.LittleEndian.Uint16(buf[8:])
+	m.Chan4Raw = binary.LittleEndian.Uint16(buf[10:])
+	m.Chan5Raw = binary.LittleEndian.Uint16(buf[12:])
+	m.Chan6Raw = binary.LittleEndian.Uint16(buf[14:])
+	m.Chan7Raw = binary.LittleEndian.Uint16(buf[16:])
+	m.Chan8Raw = binary.LittleEndian.Uint16(buf[18:])
+	m.Chan9Raw = binary.LittleEndian.Uint16(buf[20:])
+	m.Chan10Raw = binary.LittleEndian.Uint16(buf[22:])
+	m.Chan11Raw = binary.LittleEndian.Uint16(buf[24:])
+	m.Chan12Raw = binary.LittleEndian.Uint16(buf[26:])
+	m.Chan13Raw = binary.LittleEndian.Uint16(buf[28:])
+	m.Chan14Raw = binary.LittleEndian.Uint16(buf[30:])
+	m.Chan15Raw = binary.LittleEndian.Uint16(buf[32:])
+	m.Chan16Raw = binary.LittleEndian.Uint16(buf[34:])
+	m.Chan17Raw = binary.LittleEndian.Uint16(buf[36:])
+	m.Chan18Raw = binary.LittleEndian.Uint16(buf[38:])
+	m.Chancount = buf[40]
+	m.Rssi = buf[41]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageRequestDataStream) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint16(buf[0:], m.ReqMessageRate)
+	buf[2] = m.TargetSystem
+	buf[3] = m.TargetComponent
+	buf[4] = m.ReqStreamId
+	buf[5] = m.StartStop
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageRequestDataStream) UnmarshalPayload(buf []byte) {
+	m.ReqMessageRate = binary.LittleEndian.Uint16(buf[0:])
+	m.TargetSystem = buf[2]
+	m.TargetComponent = buf[3]
+	m.ReqStreamId = buf[4]
+	m.StartStop = buf[5]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageDataStream) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint16(buf[0:], m.MessageRate)
+	buf[2] = m.StreamId
+	buf[3] = m.OnOff
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageDataStream) UnmarshalPayload(buf []byte) {
+	m.MessageRate = binary.LittleEndian.Uint16(buf[0:])
+	m.StreamId = buf[2]
+	m.OnOff = buf[3]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageManualControl) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint16(buf[0:], uint16(m.X))
+	binary.LittleEndian.PutUint16(buf[2:], uint16(m.Y))
+	binary.LittleEndian.PutUint16(buf[4:], uint16(m.Z))
+	binary.LittleEndian.PutUint16(buf[6:], uint16(m.R))
+	binary.LittleEndian.PutUint16(buf[8:], m.Buttons)
+	buf[10] = m.Target
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageManualControl) UnmarshalPayload(buf []byte) {
+	m.X = int16(binary.LittleEndian.Uint16(buf[0:]))
+	m.Y = int16(binary.LittleEndian.Uint16(buf[2:]))
+	m.Z = int16(binary.LittleEndian.Uint16(buf[4:]))
+	m.R = int16(binary.LittleEndian.Uint16(buf[6:]))
+	m.Buttons = binary.LittleEndian.Uint16(buf[8:])
+	m.Target = buf[10]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageRcChannelsOverride) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint16(buf[0:], m.Chan1Raw)
+	binary.LittleEndian.PutUint16(buf[2:], m.Chan2Raw)
+	binary.LittleEndian.PutUint16(buf[4:], m.Chan3Raw)
+	binary.LittleEndian.PutUint16(buf[6:], m.Chan4Raw)
+	binary.LittleEndian.PutUint16(buf[8:], m.Chan5Raw)
+	binary.LittleEndian.PutUint16(buf[10:], m.Chan6Raw)
+	binary.LittleEndian.PutUint16(buf[12:], m.Chan7Raw)
+	binary.LittleEndian.PutUint16(buf[14:], m.Chan8Raw)
+	buf[16] = m.TargetSystem
+	buf[17] = m.TargetComponent
+	if len(buf) < 38 {
+		return
+	}
+	binary.LittleEndian.PutUint16(buf[18:], m.Chan9Raw)
+	binary.LittleEndian.PutUint16(buf[20:], m.Chan10Raw)
+	binary.LittleEndian.PutUint16(buf[22:], m.Chan11Raw)
+	binary.LittleEndian.PutUint16(buf[24:], m.Chan12Raw)
+	binary.LittleEndian.PutUint16(buf[26:], m.Chan13Raw)
+	binary.LittleEndian.PutUint16(buf[28:], m.Chan14Raw)
+	binary.LittleEndian.PutUint16(buf[30:], m.Chan15Raw)
+	binary.LittleEndian.PutUint16(buf[32:], m.Chan16Raw)
+	binary.LittleEndian.PutUint16(buf[34:], m.Chan17Raw)
+	binary.LittleEndian.PutUint16(buf[36:], m.Chan18Raw)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageRcChannelsOverride) UnmarshalPayload(buf []byte) {
+	m.Chan1Raw = binary.LittleEndian.Uint16(buf[0:])
+	m.Chan2Raw = binary.LittleEndian.Uint16(buf[2:])
+	m.Chan3Raw = binary.LittleEndian.Uint16(buf[4:])
+	m.Chan4Raw = binary.LittleEndian.Uint16(buf[6:])
+	m.Chan5Raw = binary.LittleEndian.Uint16(buf[8:])
+	m.Chan6Raw = binary.LittleEndian.Uint16(buf[10:])
+	m.Chan7Raw = binary.LittleEndian.Uint16(buf[12:])
+	m.Chan8Raw = binary.LittleEndian.Uint16(buf[14:])
+	m.TargetSystem = buf[16]
+	m.TargetComponent = buf[17]
+	if len(buf) < 38 {
+		return
+	}
+	m.Chan9Raw = binary.LittleEndian.Uint16(buf[18:])
+	m.Chan10Raw = binary.LittleEndian.Uint16(buf[20:])
+	m.Chan11Raw = binary.LittleEndian.Uint16(buf[22:])
+	m.Chan12Raw = binary.LittleEndian.Uint16(buf[24:])
+	m.Chan13Raw = binary.LittleEndian.Uint16(buf[26:])
+	m.Chan14Raw = binary.LittleEndian.Uint16(buf[28:])
+	m.Chan15Raw = binary.LittleEndian.Uint16(buf[30:])
+	m.Chan16Raw = binary.LittleEndian.Uint16(buf[32:])
+	m.Chan17Raw = binary.LittleEndian.Uint16(buf[34:])
+	m.Chan18Raw = binary.LittleEndian.Uint16(buf[36:])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageMissionItemInt) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], math.Float32bits(m.Param1))
+	binary.LittleEndian.PutUint32(buf[4:], math.Float32bits(m.Param2))
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.Param3))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.Param4))
+	binary.LittleEndian.PutUint32(buf[16:], uint32(m.X))
+	binary.LittleEndian.PutUint32(buf[20:], uint32(m.Y))
+	binary.LittleEndian.PutUint32(buf[24:], math.Float32bits(m.Z))
+	binary.LittleEndian.PutUint16(buf[28:], m.Seq)
+	binary.LittleEndian.PutUint16(buf[30:], uint16(m.Command))
+	buf[32] = m.TargetSystem
+	buf[33] = m.TargetComponent
+	buf[34] = uint8(m.Frame)
+	buf[35] = m.Current
+	buf[36] = m.Autocontinue
+	if len(buf) < 38 {
+		return
+	}
+	buf[37] = uint8(m.MissionType)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageMissionItemInt) UnmarshalPayload(buf []byte) {
+	m.Param1 = math.Float32frombits(binary.LittleEndian.Uint32(buf[0:]))
+	m.Param2 = math.Float32frombits(binary.LittleEndian.Uint32(buf[4:]))
+	m.Param3 = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.Param4 = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.X = int32(binary.LittleEndian.Uint32(buf[16:]))
+	m.Y = int32(binary.LittleEndian.Uint32(buf[20:]))
+	m.Z = math.Float32frombits(binary.LittleEndian.Uint32(buf[24:]))
+	m.Seq = binary.LittleEndian.Uint16(buf[28:])
+	m.Command = MAV_CMD(binary.LittleEndian.Uint16(buf[30:]))
+	m.TargetSystem = buf[32]
+	m.TargetComponent = buf[33]
+	m.Frame = MAV_FRAME(buf[34])
+	m.Current = buf[35]
+	m.Autocontinue = buf[36]
+	if len(buf) < 38 {
+		return
+	}
+	m.MissionType = MAV_MISSION_TYPE(buf[37])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageVfrHud) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], math.Float32bits(m.Airspeed))
+	binary.LittleEndian.PutUint32(buf[4:], math.Float32bits(m.Groundspeed))
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.Alt))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.Climb))
+	binary.LittleEndian.PutUint16(buf[16:], uint16(m.Heading))
+	binary.LittleEndian.PutUint16(buf[18:], m.Throttle)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageVfrHud) UnmarshalPayload(buf []byte) {
+	m.Airspeed = math.Float32frombits(binary.LittleEndian.Uint32(buf[0:]))
+	m.Groundspeed = math.Float32frombits(binary.LittleEndian.Uint32(buf[4:]))
+	m.Alt = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.Climb = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.Heading = int16(binary.LittleEndian.Uint16(buf[16:]))
+	m.Throttle = binary.LittleEndian.Uint16(buf[18:])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageCommandInt) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], math.Float32bits(m.Param1))
+	binary.LittleEndian.PutUint32(buf[4:], math.Float32bits(m.Param2))
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.Param3))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.Param4))
+	binary.LittleEndian.PutUint32(buf[16:], uint32(m.X))
+	binary.LittleEndian.PutUint32(buf[20:], uint32(m.Y))
+	binary.LittleEndian.PutUint32(buf[24:], math.Float32bits(m.Z))
+	binary.LittleEndian.PutUint16(buf[28:], uint16(m.Command))
+	buf[30] = m.TargetSystem
+	buf[31] = m.TargetComponent
+	buf[32] = uint8(m.Frame)
+	buf[33] = m.Current
+	buf[34] = m.Autocontinue
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageCommandInt) UnmarshalPayload(buf []byte) {
+	m.Param1 = math.Float32frombits(binary.LittleEndian.Uint32(buf[0:]))
+	m.Param2 = math.Float32frombits(binary.LittleEndian.Uint32(buf[4:]))
+	m.Param3 = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.Param4 = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.X = int32(binary.LittleEndian.Uint32(buf[16:]))
+	m.Y = int32(binary.LittleEndian.Uint32(buf[20:]))
+	m.Z = math.Float32frombits(binary.LittleEndian.Uint32(buf[24:]))
+	m.Command = MAV_CMD(binary.LittleEndian.Uint16(buf[28:]))
+	m.TargetSystem = buf[30]
+	m.TargetComponent = buf[31]
+	m.Frame = MAV_FRAME(buf[32])
+	m.Current = buf[33]
+	m.Autocontinue = buf[34]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageCommandLong) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], math.Float32bits(m.Param1))
+	binary.LittleEndian.PutUint32(buf[4:], math.Float32bits(m.Param2))
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.Param3))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.Param4))
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.Param5))
+	binary.LittleEndian.PutUint32(buf[20:], math.Float32bits(m.Param6))
+	binary.LittleEndian.PutUint32(buf[24:], math.Float32bits(m.Param7))
+	binary.LittleEndian.PutUint16(buf[28:], uint16(m.Command))
+	buf[30] = m.TargetSystem
+	buf[31] = m.TargetComponent
+	buf[32] = m.Confirmation
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageCommandLong) UnmarshalPayload(buf []byte) {
+	m.Param1 = math.Float32frombits(binary.LittleEndian.Uint32(buf[0:]))
+	m.Param2 = math.Float32frombits(binary.LittleEndian.Uint32(buf[4:]))
+	m.Param3 = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.Param4 = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.Param5 = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	m.Param6 = math.Float32frombits(binary.LittleEndian.Uint32(buf[20:]))
+	m.Param7 = math.Float32frombits(binary.LittleEndian.Uint32(buf[24:]))
+	m.Command = MAV_CMD(binary.LittleEndian.Uint16(buf[28:]))
+	m.TargetSystem = buf[30]
+	m.TargetComponent = buf[31]
+	m.Confirmation = buf[32]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageCommandAck) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint16(buf[0:], uint16(m.Command))
+	buf[2] = uint8(m.Result)
+	if len(buf) < 10 {
+		return
+	}
+	buf[3] = m.Progress
+	binary.LittleEndian.PutUint32(buf[4:], uint32(m.ResultParam2))
+	buf[8] = m.TargetSystem
+	buf[9] = m.TargetComponent
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageCommandAck) UnmarshalPayload(buf []byte) {
+	m.Command = MAV_CMD(binary.LittleEndian.Uint16(buf[0:]))
+	m.Result = MAV_RESULT(buf[2])
+	if len(buf) < 10 {
+		return
+	}
+	m.Progress = buf[3]
+	m.ResultParam2 = int32(binary.LittleEndian.Uint32(buf[4:]))
+	m.TargetSystem = buf[8]
+	m.TargetComponent = buf[9]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageCommandCancel) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint16(buf[0:], uint16(m.Command))
+	buf[2] = m.TargetSystem
+	buf[3] = m.TargetComponent
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageCommandCancel) UnmarshalPayload(buf []byte) {
+	m.Command = MAV_CMD(binary.LittleEndian.Uint16(buf[0:]))
+	m.TargetSystem = buf[2]
+	m.TargetComponent = buf[3]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageManualSetpoint) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], m.TimeBootMs)
+	binary.LittleEndian.PutUint32(buf[4:], math.Float32bits(m.Roll))
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.Pitch))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.Yaw))
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.Thrust))
+	buf[20] = m.ModeSwitch
+	buf[21] = m.ManualOverrideSwitch
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageManualSetpoint) UnmarshalPayload(buf []byte) {
+	m.TimeBootMs = binary.LittleEndian.Uint32(buf[0:])
+	m.Roll = math.Float32frombits(binary.LittleEndian.Uint32(buf[4:]))
+	m.Pitch = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.Yaw = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.Thrust = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	m.ModeSwitch = buf[20]
+	m.ManualOverrideSwitch = buf[21]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageSetAttitudeTarget) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], m.TimeBootMs)
+	for i, v := range m.Q {
+		binary.LittleEndian.PutUint32(buf[4+i*4:], math.Float32bits(v))
+	}
+	binary.LittleEndian.PutUint32(buf[20:], math.Float32bits(m.BodyRollRate))
+	binary.LittleEndian.PutUint32(buf[24:], math.Float32bits(m.BodyPitchRate))
+	binary.LittleEndian.PutUint32(buf[28:], math.Float32bits(m.BodyYawRate))
+	binary.LittleEndian.PutUint32(buf[32:], math.Float32bits(m.Thrust))
+	buf[36] = m.TargetSystem
+	buf[37] = m.TargetComponent
+	buf[38] = uint8(m.TypeMask)
+	if len(buf) < 51 {
+		return
+	}
+	for i, v := range m.ThrustBody {
+		binary.LittleEndian.PutUint32(buf[39+i*4:], math.Float32bits(v))
+	}
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageSetAttitudeTarget) UnmarshalPayload(buf []byte) {
+	m.TimeBootMs = binary.LittleEndian.Uint32(buf[0:])
+	for i := range m.Q {
+		m.Q[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[4+i*4:]))
+	}
+	m.BodyRollRate = math.Float32frombits(binary.LittleEndian.Uint32(buf[20:]))
+	m.BodyPitchRate = math.Float32frombits(binary.LittleEndian.Uint32(buf[24:]))
+	m.BodyYawRate = math.Float32frombits(binary.LittleEndian.Uint32(buf[28:]))
+	m.Thrust = math.Float32frombits(binary.LittleEndian.Uint32(buf[32:]))
+	m.TargetSystem = buf[36]
+	m.TargetComponent = buf[37]
+	m.TypeMask = ATTITUDE_TARGET_TYPEMASK(buf[38])
+	if len(buf) < 51 {
+		return
+	}
+	for i := range m.ThrustBody {
+		m.ThrustBody[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[39+i*4:]))
+	}
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageAttitudeTarget) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], m.TimeBootMs)
+	for i, v := range m.Q {
+		binary.LittleEndian.PutUint32(buf[4+i*4:], math.Float32bits(v))
+	}
+	binary.LittleEndian.PutUint32(buf[20:], math.Float32bits(m.BodyRollRate))
+	binary.LittleEndian.PutUint32(buf[24:], math.Float32bits(m.BodyPitchRate))
+	binary.LittleEndian.PutUint32(buf[28:], math.Float32bits(m.BodyYawRate))
+	binary.LittleEndian.PutUint32(buf[32:], math.Float32bits(m.Thrust))
+	buf[36] = uint8(m.TypeMask)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageAttitudeTarget) UnmarshalPayload(buf []byte) {
+	m.TimeBootMs = binary.LittleEndian.Uint32(buf[0:])
+	for i := range m.Q {
+		m.Q[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[4+i*4:]))
+	}
+	m.BodyRollRate = math.Float32frombits(binary.LittleEndian.Uint32(buf[20:]))
+	m.BodyPitchRate = math.Float32frombits(binary.LittleEndian.Uint32(buf[24:]))
+	m.BodyYawRate = math.Float32frombits(binary.LittleEndian.Uint32(buf[28:]))
+	m.Thrust = math.Float32frombits(binary.LittleEndian.Uint32(buf[32:]))
+	m.TypeMask = ATTITUDE_TARGET_TYPEMASK(buf[36])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageSetPositionTargetLocalNed) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], m.TimeBootMs)
+	binary.LittleEndian.PutUint32(buf[4:], math.Float32bits(m.X))
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.Y))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.Z))
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.Vx))
+	binary.LittleEndian.PutUint32(buf[20:], math.Float32bits(m.Vy))
+	binary.LittleEndian.PutUint32(buf[24:], math.Float32bits(m.Vz))
+	binary.LittleEndian.PutUint32(buf[28:], math.Float32bits(m.Afx))
+	binary.LittleEndian.PutUint32(buf[32:], math.Float32bits(m.Afy))
+	binary.LittleEndian.PutUint32(buf[36:], math.Float32bits(m.Afz))
+	binary.LittleEndian.PutUint32(buf[40:], math.Float32bits(m.Yaw))
+	binary.LittleEndian.PutUint32(buf[44:], math.Float32bits(m.YawRate))
+	binary.LittleEndian.PutUint16(buf[48:], uint16(m.TypeMask))
+	buf[50] = m.TargetSystem
+	buf[51] = m.TargetComponent
+	buf[52] = uint8(m.CoordinateFrame)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageSetPositionTargetLocalNed) UnmarshalPayload(buf []byte) {
+	m.TimeBootMs = binary.LittleEndian.Uint32(buf[0:])
+	m.X = math.Float32frombits(binary.LittleEndian.Uint32(buf[4:]))
+	m.Y = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.Z = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.Vx = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	m.Vy = math.Float32frombits(binary.LittleEndian.Uint32(buf[20:]))
+	m.Vz = math.Float32frombits(binary.LittleEndian.Uint32(buf[24:]))
+	m.Afx = math.Float32frombits(binary.LittleEndian.Uint32(buf[28:]))
+	m.Afy = math.Float32frombits(binary.LittleEndian.Uint32(buf[32:]))
+	m.Afz = math.Float32frombits(binary.LittleEndian.Uint32(buf[36:]))
+	m.Yaw = math.Float32frombits(binary.LittleEndian.Uint32(buf[40:]))
+	m.YawRate = math.Float32frombits(binary.LittleEndian.Uint32(buf[44:]))
+	m.TypeMask = POSITION_TARGET_TYPEMASK(binary.LittleEndian.Uint16(buf[48:]))
+	m.TargetSystem = buf[50]
+	m.TargetComponent = buf[51]
+	m.CoordinateFrame = MAV_FRAME(buf[52])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessagePositionTargetLocalNed) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], m.TimeBootMs)
+	binary.LittleEndian.PutUint32(buf[4:], math.Float32bits(m.X))
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.Y))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.Z))
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.Vx))
+	binary.LittleEndian.PutUint32(buf[20:], math.Float32bits(m.Vy))
+	binary.LittleEndian.PutUint32(buf[24:], math.Float32bits(m.Vz))
+	binary.LittleEndian.PutUint32(buf[28:], math.Float32bits(m.Afx))
+	binary.LittleEndian.PutUint32(buf[32:], math.Float32bits(m.Afy))
+	binary.LittleEndian.PutUint32(buf[36:], math.Float32bits(m.Afz))
+	binary.LittleEndian.PutUint32(buf[40:], math.Float32bits(m.Yaw))
+	binary.LittleEndian.PutUint32(buf[44:], math.Float32bits(m.YawRate))
+	binary.LittleEndian.PutUint16(buf[48:], uint16(m.TypeMask))
+	buf[50] = uint8(m.CoordinateFrame)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessagePositionTargetLocalNed) UnmarshalPayload(buf []byte) {
+	m.TimeBootMs = binary.LittleEndian.Uint32(buf[0:])
+	m.X = math.Float32frombits(binary.LittleEndian.Uint32(buf[4:]))
+	m.Y = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.Z = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.Vx = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	m.Vy = math.Float32frombits(binary.LittleEndian.Uint32(buf[20:]))
+	m.Vz = math.Float32frombits(binary.LittleEndian.Uint32(buf[24:]))
+	m.Afx = math.Float32frombits(binary.LittleEndian.Uint32(buf[28:]))
+	m.Afy = math.Float32frombits(binary.LittleEndian.Uint32(buf[32:]))
+	m.Afz = math.Float32frombits(binary.LittleEndian.Uint32(buf[36:]))
+	m.Yaw = math.Float32frombits(binary.LittleEndian.Uint32(buf[40:]))
+	m.YawRate = math.Float32frombits(binary.LittleEndian.Uint32(buf[44:]))
+	m.TypeMask = POSITION_TARGET_TYPEMASK(binary.LittleEndian.Uint16(buf[48:]))
+	m.CoordinateFrame = MAV_FRAME(buf[50])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageSetPositionTargetGlobalInt) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], m.TimeBootMs)
+	binary.LittleEndian.PutUint32(buf[4:], uint32(m.LatInt))
+	binary.LittleEndian.PutUint32(buf[8:], uint32(m.LonInt))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.Alt))
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.Vx))
+	binary.LittleEndian.PutUint32(buf[20:], math.Float32bits(m.Vy))
+	binary.LittleEndian.PutUint32(buf[24:], math.Float32bits(m.Vz))
+	binary.LittleEndian.PutUint32(buf[28:], math.Float32bits(m.Afx))
+	binary.LittleEndian.PutUint32(buf[32:], math.Float32bits(m.Afy))
+	binary.LittleEndian.PutUint32(buf[36:], math.Float32bits(m.Afz))
+	binary.LittleEndian.PutUint32(buf[40:], math.Float32bits(m.Yaw))
+	binary.LittleEndian.PutUint32(buf[44:], math.Float32bits(m.YawRate))
+	binary.LittleEndian.PutUint16(buf[48:], uint16(m.TypeMask))
+	buf[50] = m.TargetSystem
+	buf[51] = m.TargetComponent
+	buf[52] = uint8(m.CoordinateFrame)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageSetPositionTargetGlobalInt) UnmarshalPayload(buf []byte) {
+	m.TimeBootMs = binary.LittleEndian.Uint32(buf[0:])
+	m.LatInt = int32(binary.LittleEndian.Uint32(buf[4:]))
+	m.LonInt = int32(binary.LittleEndian.Uint32(buf[8:]))
+	m.Alt = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.Vx = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	m.Vy = math.Float32frombits(binary.LittleEndian.Uint32(buf[20:]))
+	m.Vz = math.Float32frombits(binary.LittleEndian.Uint32(buf[24:]))
+	m.Afx = math.Float32frombits(binary.LittleEndian.Uint32(buf[28:]))
+	m.Afy = math.Float32frombits(binary.LittleEndian.Uint32(buf[32:]))
+	m.Afz = math.Float32frombits(binary.LittleEndian.Uint32(buf[36:]))
+	m.Yaw = math.Float32frombits(binary.LittleEndian.Uint32(buf[40:]))
+	m.YawRate = math.Float32frombits(binary.LittleEndian.Uint32(buf[44:]))
+	m.TypeMask = POSITION_TARGET_TYPEMASK(binary.LittleEndian.Uint16(buf[48:]))
+	m.TargetSystem = buf[50]
+	m.TargetComponent = buf[51]
+	m.CoordinateFrame = MAV_FRAME(buf[52])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessagePositionTargetGlobalInt) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], m.TimeBootMs)
+	binary.LittleEndian.PutUint32(buf[4:], uint32(m.LatInt))
+	binary.LittleEndian.PutUint32(buf[8:], uint32(m.LonInt))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.Alt))
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.Vx))
+	binary.LittleEndian.PutUint32(buf[20:], math.Float32bits(m.Vy))
+	binary.LittleEndian.PutUint32(buf[24:], math.Float32bits(m.Vz))
+	binary.LittleEndian.PutUint32(buf[28:], math.Float32bits(m.Afx))
+	binary.LittleEndian.PutUint32(buf[32:], math.Float32bits(m.Afy))
+	binary.LittleEndian.PutUint32(buf[36:], math.Float32bits(m.Afz))
+	binary.LittleEndian.PutUint32(buf[40:], math.Float32bits(m.Yaw))
+	binary.LittleEndian.PutUint32(buf[44:], math.Float32bits(m.YawRate))
+	binary.LittleEndian.PutUint16(buf[48:], uint16(m.TypeMask))
+	buf[50] = uint8(m.CoordinateFrame)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessagePositionTargetGlobalInt) UnmarshalPayload(buf []byte) {
+	m.TimeBootMs = binary.LittleEndian.Uint32(buf[0:])
+	m.LatInt = int32(binary.LittleEndian.Uint32(buf[4:]))
+	m.LonInt = int32(binary.LittleEndian.Uint32(buf[8:]))
+	m.Alt = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.Vx = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	m.Vy = math.Float32frombits(binary.LittleEndian.Uint32(buf[20:]))
+	m.Vz = math.Float32frombits(binary.LittleEndian.Uint32(buf[24:]))
+	m.Afx = math.Float32frombits(binary.LittleEndian.Uint32(buf[28:]))
+	m.Afy = math.Float32frombits(binary.LittleEndian.Uint32(buf[32:]))
+	m.Afz = math.Float32frombits(binary.LittleEndian.Uint32(buf[36:]))
+	m.Yaw = math.Float32frombits(binary.LittleEndian.Uint32(buf[40:]))
+	m.YawRate = math.Float32frombits(binary.LittleEndian.Uint32(buf[44:]))
+	m.TypeMask = POSITION_TARGET_TYPEMASK(binary.LittleEndian.Uint16(buf[48:]))
+	m.CoordinateFrame = MAV_FRAME(buf[50])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageLocalPositionNedSystemGlobalOffset) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], m.TimeBootMs)
+	binary.LittleEndian.PutUint32(buf[4:], math.Float32bits(m.X))
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.Y))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.Z))
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.Roll))
+	binary.LittleEndian.PutUint32(buf[20:], math.Float32bits(m.Pitch))
+	binary.LittleEndian.PutUint32(buf[24:], math.Float32bits(m.Yaw))
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageLocalPositionNedSystemGlobalOffset) UnmarshalPayload(buf []byte) {
+	m.TimeBootMs = binary.LittleEndian.Uint32(buf[0:])
+	m.X = math.Float32frombits(binary.LittleEndian.Uint32(buf[4:]))
+	m.Y = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.Z = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.Roll = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	m.Pitch = math.Float32frombits(binary.LittleEndian.Uint32(buf[20:]))
+	m.Yaw = math.Float32frombits(binary.LittleEndian.Uint32(buf[24:]))
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageHilState) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.TimeUsec)
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.Roll))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.Pitch))
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.Yaw))
+	binary.LittleEndian.PutUint32(buf[20:], math.Float32bits(m.Rollspeed))
+	binary.LittleEndian.PutUint32(buf[24:], math.Float32bits(m.Pitchspeed))
+	binary.LittleEndian.PutUint32(buf[28:], math.Float32bits(m.Yawspeed))
+	binary.LittleEndian.PutUint32(buf[32:], uint32(m.Lat))
+	binary.LittleEndian.PutUint32(buf[36:], uint32(m.Lon))
+	binary.LittleEndian.PutUint32(buf[40:], uint32(m.Alt))
+	binary.LittleEndian.PutUint16(buf[44:], uint16(m.Vx))
+	binary.LittleEndian.PutUint16(buf[46:], uint16(m.Vy))
+	binary.LittleEndian.PutUint16(buf[48:], uint16(m.Vz))
+	binary.LittleEndian.PutUint16(buf[50:], uint16(m.Xacc))
+	binary.LittleEndian.PutUint16(buf[52:], uint16(m.Yacc))
+	binary.LittleEndian.PutUint16(buf[54:], uint16(m.Zacc))
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageHilState) UnmarshalPayload(buf []byte) {
+	m.TimeUsec = binary.LittleEndian.Uint64(buf[0:])
+	m.Roll = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.Pitch = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.Yaw = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	m.Rollspeed = math.Float32frombits(binary.LittleEndian.Uint32(buf[20:]))
+	m.Pitchspeed = math.Float32frombits(binary.LittleEndian.Uint32(buf[24:]))
+	m.Yawspeed = math.Float32frombits(binary.LittleEndian.Uint32(buf[28:]))
+	m.Lat = int32(binary.LittleEndian.Uint32(buf[32:]))
+	m.Lon = int32(binary.LittleEndian.Uint32(buf[36:]))
+	m.Alt = int32(binary.LittleEndian.Uint32(buf[40:]))
+	m.Vx = int16(binary.LittleEndian.Uint16(buf[44:]))
+	m.Vy = int16(binary.LittleEndian.Uint16(buf[46:]))
+	m.Vz = int16(binary.LittleEndian.Uint16(buf[48:]))
+	m.Xacc = int16(binary.LittleEndian.Uint16(buf[50:]))
+	m.Yacc = int16(binary.LittleEndian.Uint16(buf[52:]))
+	m.Zacc = int16(binary.LittleEndian.Uint16(buf[54:]))
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageHilControls) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.TimeUsec)
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.RollAilerons))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.PitchElevator))
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.YawRudder))
+	binary.LittleEndian.PutUint32(buf[20:], math.Float32bits(m.Throttle))
+	binary.LittleEndian.PutUint32(buf[24:], math.Float32bits(m.Aux1))
+	binary.LittleEndian.PutUint32(buf[28:], math.Float32bits(m.Aux2))
+	binary.LittleEndian.PutUint32(buf[32:], math.Float32bits(m.Aux3))
+	binary.LittleEndian.PutUint32(buf[36:], math.Float32bits(m.Aux4))
+	buf[40] = uint8(m.Mode)
+	buf[41] = m.NavMode
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageHilControls) UnmarshalPayload(buf []byte) {
+	m.TimeUsec = binary.LittleEndian.Uint64(buf[0:])
+	m.RollAilerons = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.PitchElevator = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.YawRudder = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	m.Throttle = math.Float32frombits(binary.LittleEndian.Uint32(buf[20:]))
+	m.Aux1 = math.Float32frombits(binary.LittleEndian.Uint32(buf[24:]))
+	m.Aux2 = math.Float32frombits(binary.LittleEndian.Uint32(buf[28:]))
+	m.Aux3 = math.Float32frombits(binary.LittleEndian.Uint32(buf[32:]))
+	m.Aux4 = math.Float32frombits(binary.LittleEndian.Uint32(buf[36:]))
+	m.Mode = MAV_MODE(buf[40])
+	m.NavMode = buf[41]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageHilRcInputsRaw) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.TimeUsec)
+	binary.LittleEndian.PutUint16(buf[8:], m.Chan1Raw)
+	binary.LittleEndian.PutUint16(buf[10:], m.Chan2Raw)
+	binary.LittleEndian.PutUint16(buf[12:], m.Chan3Raw)
+	binary.LittleEndian.PutUint16(buf[14:], m.Chan4Raw)
+	binary.LittleEndian.PutUint16(buf[16:], m.Chan5Raw)
+	binary.LittleEndian.PutUint16(buf[18:], m.Chan6Raw)
+	binary.LittleEndian.PutUint16(buf[20:], m.Chan7Raw)
+	binary.LittleEndian.PutUint16(buf[22:], m.Chan8Raw)
+	binary.LittleEndian.PutUint16(buf[24:], m.Chan9Raw)
+	binary.LittleEndian.PutUint16(buf[26:], m.Chan10Raw)
+	binary.LittleEndian.PutUint16(buf[28:], m.Chan11Raw)
+	binary.LittleEndian.PutUint16(buf[30:], m.Chan12Raw)
+	buf[32] = m.Rssi
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageHilRcInputsRaw) UnmarshalPayload(buf []byte) {
+	m.TimeUsec = binary.LittleEndian.Uint64(buf[0:])
+	m.Chan1Raw = binary.LittleEndian.Uint16(buf[8:])
+	m.Chan2Raw = binary.LittleEndian.Uint16(buf[10:])
+	m.Chan3Raw = binary.LittleEndian.Uint16(buf[12:])
+	m.Chan4Raw = binary.LittleEndian.Uint16(buf[14:])
+	m.Chan5Raw = binary.LittleEndian.Uint16(buf[16:])
+	m.Chan6Raw = binary.LittleEndian.Uint16(buf[18:])
+	m.Chan7Raw = binary.LittleEndian.Uint16(buf[20:])
+	m.Chan8Raw = binary.LittleEndian.Uint16(buf[22:])
+	m.Chan9Raw = binary.LittleEndian.Uint16(buf[24:])
+	m.Chan10Raw = binary.LittleEndian.Uint16(buf[26:])
+	m.Chan11Raw = binary.LittleEndian.Uint16(buf[28:])
+	m.Chan12Raw = binary.LittleEndian.Uint16(buf[30:])
+	m.Rssi = buf[32]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageHilActuatorControls) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.TimeUsec)
+	binary.LittleEndian.PutUint64(buf[8:], m.Flags)
+	for i, v := range m.Controls {
+		binary.LittleEndian.PutUint32(buf[16+i*4:], math.Float32bits(v))
+	}
+	buf[80] = uint8(m.Mode)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageHilActuatorControls) UnmarshalPayload(buf []byte) {
+	m.TimeUsec = binary.LittleEndian.Uint64(buf[0:])
+	m.Flags = binary.LittleEndian.Uint64(buf[8:])
+	for i := range m.Controls {
+		m.Controls[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[16+i*4:]))
+	}
+	m.Mode = MAV_MODE_FLAG(buf[80])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageOpticalFlow) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.TimeUsec)
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.FlowCompMX))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.FlowCompMY))
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.GroundDistance))
+	binary.LittleEndian.PutUint16(buf[20:], uint16(m.FlowX))
+	binary.LittleEndian.PutUint16(buf[22:], uint16(m.FlowY))
+	buf[24] = m.SensorId
+	buf[25] = m.Quality
+	if len(buf) < 34 {
+		return
+	}
+	binary.LittleEndian.PutUint32(buf[26:], math.Float32bits(m.FlowRateX))
+	binary.LittleEndian.PutUint32(buf[30:], math.Float32bits(m.FlowRateY))
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageOpticalFlow) UnmarshalPayload(buf []byte) {
+	m.TimeUsec = binary.LittleEndian.Uint64(buf[0:])
+	m.FlowCompMX = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.FlowCompMY = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.GroundDistance = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	m.FlowX = int16(binary.LittleEndian.Uint16(buf[20:]))
+	m.FlowY = int16(binary.LittleEndian.Uint16(buf[22:]))
+	m.SensorId = buf[24]
+	m.Quality = buf[25]
+	if len(buf) < 34 {
+		return
+	}
+	m.FlowRateX = math.Float32frombits(binary.LittleEndian.Uint32(buf[26:]))
+	m.FlowRateY = math.Float32frombits(binary.LittleEndian.Uint32(buf[30:]))
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageGlobalVisionPositionEstimate) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.Usec)
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.X))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.Y))
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.Z))
+	binary.LittleEndian.PutUint32(buf[20:], math.Float32bits(m.Roll))
+	binary.LittleEndian.PutUint32(buf[24:], math.Float32bits(m.Pitch))
+	binary.LittleEndian.PutUint32(buf[28:], math.Float32bits(m.Yaw))
+	if len(buf) < 117 {
+		return
+	}
+	for i, v := range m.Covariance {
+		binary.LittleEndian.PutUint32(buf[32+i*4:], math.Float32bits(v))
+	}
+	buf[116] = m.ResetCounter
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageGlobalVisionPositionEstimate) UnmarshalPayload(buf []byte) {
+	m.Usec = binary.LittleEndian.Uint64(buf[0:])
+	m.X = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.Y = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.Z = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	m.Roll = math.Float32frombits(binary.LittleEndian.Uint32(buf[20:]))
+	m.Pitch = math.Float32frombits(binary.LittleEndian.Uint32(buf[24:]))
+	m.Yaw = math.Float32frombits(binary.LittleEndian.Uint32(buf[28:]))
+	if len(buf) < 117 {
+		return
+	}
+	for i := range m.Covariance {
+		m.Covariance[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[32+i*4:]))
+	}
+	m.ResetCounter = buf[116]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageVisionPositionEstimate) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.Usec)
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.X))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.Y))
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.Z))
+	binary.LittleEndian.PutUint32(buf[20:], math.Float32bits(m.Roll))
+	binary.LittleEndian.PutUint32(buf[24:], math.Float32bits(m.Pitch))
+	binary.LittleEndian.PutUint32(buf[28:], math.Float32bits(m.Yaw))
+	if len(buf) < 117 {
+		return
+	}
+	for i, v := range m.Covariance {
+		binary.LittleEndian.PutUint32(buf[32+i*4:], math.Float32bits(v))
+	}
+	buf[116] = m.ResetCounter
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageVisionPositionEstimate) UnmarshalPayload(buf []byte) {
+	m.Usec = binary.LittleEndian.Uint64(buf[0:])
+	m.X = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.Y = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.Z = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	m.Roll = math.Float32frombits(binary.LittleEndian.Uint32(buf[20:]))
+	m.Pitch = math.Float32frombits(binary.LittleEndian.Uint32(buf[24:]))
+	m.Yaw = math.Float32frombits(binary.LittleEndian.Uint32(buf[28:]))
+	if len(buf) < 117 {
+		return
+	}
+	for i := range m.Covariance {
+		m.Covariance[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[32+i*4:]))
+	}
+	m.ResetCounter = buf[116]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageVisionSpeedEstimate) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.Usec)
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.X))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.Y))
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.Z))
+	if len(buf) < 57 {
+		return
+	}
+	for i, v := range m.Covariance {
+		binary.LittleEndian.PutUint32(buf[20+i*4:], math.Float32bits(v))
+	}
+	buf[56] = m.ResetCounter
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageVisionSpeedEstimate) UnmarshalPayload(buf []byte) {
+	m.Usec = binary.LittleEndian.Uint64(buf[0:])
+	m.X = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.Y = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.Z = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	if len(buf) < 57 {
+		return
+	}
+	for i := range m.Covariance {
+		m.Covariance[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[20+i*4:]))
+	}
+	m.ResetCounter = buf[56]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageViconPositionEstimate) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.Usec)
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.X))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.Y))
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.Z))
+	binary.LittleEndian.PutUint32(buf[20:], math.Float32bits(m.Roll))
+	binary.LittleEndian.PutUint32(buf[24:], math.Float32bits(m.Pitch))
+	binary.LittleEndian.PutUint32(buf[28:], math.Float32bits(m.Yaw))
+	if len(buf) < 116 {
+		return
+	}
+	for i, v := range m.Covariance {
+		binary.LittleEndian.PutUint32(buf[32+i*4:], math.Float32bits(v))
+	}
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageViconPositionEstimate) UnmarshalPayload(buf []byte) {
+	m.Usec = binary.LittleEndian.Uint64(buf[0:])
+	m.X = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.Y = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.Z = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	m.Roll = math.Float32frombits(binary.LittleEndian.Uint32(buf[20:]))
+	m.Pitch = math.Float32frombits(binary.LittleEndian.Uint32(buf[24:]))
+	m.Yaw = math.Float32frombits(binary.LittleEndian.Uint32(buf[28:]))
+	if len(buf) < 116 {
+		return
+	}
+	for i := range m.Covariance {
+		m.Covariance[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[32+i*4:]))
+	}
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageHighresImu) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.TimeUsec)
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.Xacc))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.Yacc))
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.Zacc))
+	binary.LittleEndian.PutUint32(buf[20:], math.Float32bits(m.Xgyro))
+	binary.LittleEndian.PutUint32(buf[24:], math.Float32bits(m.Ygyro))
+	binary.LittleEndian.PutUint32(buf[28:], math.Float32bits(m.Zgyro))
+	binary.LittleEndian.PutUint32(buf[32:], math.Float32bits(m.Xmag))
+	binary.LittleEndian.PutUint32(buf[36:], math.Float32bits(m.Ymag))
+	binary.LittleEndian.PutUint32(buf[40:], math.Float32bits(m.Zmag))
+	binary.LittleEndian.PutUint32(buf[44:], math.Float32bits(m.AbsPressure))
+	binary.LittleEndian.PutUint32(buf[48:], math.Float32bits(m.DiffPressure))
+	binary.LittleEndian.PutUint32(buf[52:], math.Float32bits(m.PressureAlt))
+	binary.LittleEndian.PutUint32(buf[56:], math.Float32bits(m.Temperature))
+	binary.LittleEndian.PutUint16(buf[60:], m.FieldsUpdated)
+	if len(buf) < 63 {
+		return
+	}
+	buf[62] = m.Id
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageHighresImu) UnmarshalPayload(buf []byte) {
+	m.TimeUsec = binary.LittleEndian.Uint64(buf[0:])
+	m.Xacc = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.Yacc = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.Zacc = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	m.Xgyro = math.Float32frombits(binary.LittleEndian.Uint32(buf[20:]))
+	m.Ygyro = math.Float32frombits(binary.LittleEndian.Uint32(buf[24:]))
+	m.Zgyro = math.Float32frombits(binary.LittleEndian.Uint32(buf[28:]))
+	m.Xmag = math.Float32frombits(binary.LittleEndian.Uint32(buf[32:]))
+	m.Ymag = math.Float32frombits(binary.LittleEndian.Uint32(buf[36:]))
+	m.Zmag = math.Float32frombits(binary.LittleEndian.Uint32(buf[40:]))
+	m.AbsPressure = math.Float32frombits(binary.LittleEndian.Uint32(buf[44:]))
+	m.DiffPressure = math.Float32frombits(binary.LittleEndian.Uint32(buf[48:]))
+	m.PressureAlt = math.Float32frombits(binary.LittleEndian.Uint32(buf[52:]))
+	m.Temperature = math.Float32frombits(binary.LittleEndian.Uint32(buf[56:]))
+	m.FieldsUpdated = binary.LittleEndian.Uint16(buf[60:])
+	if len(buf) < 63 {
+		return
+	}
+	m.Id = buf[62]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageOpticalFlowRad) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.TimeUsec)
+	binary.LittleEndian.PutUint32(buf[8:], m.IntegrationTimeUs)
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.IntegratedX))
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.IntegratedY))
+	binary.LittleEndian.PutUint32(buf[20:], math.Float32bits(m.IntegratedXgyro))
+	binary.LittleEndian.PutUint32(buf[24:], math.Float32bits(m.IntegratedYgyro))
+	binary.LittleEndian.PutUint32(buf[28:], math.Float32bits(m.IntegratedZgyro))
+	binary.LittleEndian.PutUint32(buf[32:], m.TimeDeltaDistanceUs)
+	binary.LittleEndian.PutUint32(buf[36:], math.Float32bits(m.Distance))
+	binary.LittleEndian.PutUint16(buf[40:], uint16(m.Temperature))
+	buf[42] = m.SensorId
+	buf[43] = m.Quality
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageOpticalFlowRad) UnmarshalPayload(buf []byte) {
+	m.TimeUsec = binary.LittleEndian.Uint64(buf[0:])
+	m.IntegrationTimeUs = binary.LittleEndian.Uint32(buf[8:])
+	m.IntegratedX = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.IntegratedY = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	m.IntegratedXgyro = math.Float32frombits(binary.LittleEndian.Uint32(buf[20:]))
+	m.IntegratedYgyro = math.Float32frombits(binary.LittleEndian.Uint32(buf[24:]))
+	m.IntegratedZgyro = math.Float32frombits(binary.LittleEndian.Uint32(buf[28:]))
+	m.TimeDeltaDistanceUs = binary.LittleEndian.Uint32(buf[32:])
+	m.Distance = math.Float32frombits(binary.LittleEndian.Uint32(buf[36:]))
+	m.Temperature = int16(binary.LittleEndian.Uint16(buf[40:]))
+	m.SensorId = buf[42]
+	m.Quality = buf[43]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageHilSensor) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.TimeUsec)
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.Xacc))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.Yacc))
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.Zacc))
+	binary.LittleEndian.PutUint32(buf[20:], math.Float32bits(m.Xgyro))
+	binary.LittleEndian.PutUint32(buf[24:], math.Float32bits(m.Ygyro))
+	binary.LittleEndian.PutUint32(buf[28:], math.Float32bits(m.Zgyro))
+	binary.LittleEndian.PutUint32(buf[32:], math.Float32bits(m.Xmag))
+	binary.LittleEndian.PutUint32(buf[36:], math.Float32bits(m.Ymag))
+	binary.LittleEndian.PutUint32(buf[40:], math.Float32bits(m.Zmag))
+	binary.LittleEndian.PutUint32(buf[44:], math.Float32bits(m.AbsPressure))
+	binary.LittleEndian.PutUint32(buf[48:], math.Float32bits(m.DiffPressure))
+	binary.LittleEndian.PutUint32(buf[52:], math.Float32bits(m.PressureAlt))
+	binary.LittleEndian.PutUint32(buf[56:], math.Float32bits(m.Temperature))
+	binary.LittleEndian.PutUint32(buf[60:], m.FieldsUpdated)
+	if len(buf) < 65 {
+		return
+	}
+	buf[64] = m.Id
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageHilSensor) UnmarshalPayload(buf []byte) {
+	m.TimeUsec = binary.LittleEndian.Uint64(buf[0:])
+	m.Xacc = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.Yacc = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.Zacc = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	m.Xgyro = math.Float32frombits(binary.LittleEndian.Uint32(buf[20:]))
+	m.Ygyro = math.Float32frombits(binary.LittleEndian.Uint32(buf[24:]))
+	m.Zgyro = math.Float32frombits(binary.LittleEndian.Uint32(buf[28:]))
+	m.Xmag = math.Float32frombits(binary.LittleEndian.Uint32(buf[32:]))
+	m.Ymag = math.Float32frombits(binary.LittleEndian.Uint32(buf[36:]))
+	m.Zmag = math.Float32frombits(binary.LittleEndian.Uint32(buf[40:]))
+	m.AbsPressure = math.Float32frombits(binary.LittleEndian.Uint32(buf[44:]))
+	m.DiffPressure = math.Float32frombits(binary.LittleEndian.Uint32(buf[48:]))
+	m.PressureAlt = math.Float32frombits(binary.LittleEndian.Uint32(buf[52:]))
+	m.Temperature = math.Float32frombits(binary.LittleEndian.Uint32(buf[56:]))
+	m.FieldsUpdated = binary.LittleEndian.Uint32(buf[60:])
+	if len(buf) < 65 {
+		return
+	}
+	m.Id = buf[64]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageSimState) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], math.Float32bits(m.Q1))
+	binary.LittleEndian.PutUint32(buf[4:], math.Float32bits(m.Q2))
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.Q3))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.Q4))
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.Roll))
+	binary.LittleEndian.PutUint32(buf[20:], math.Float32bits(m.Pitch))
+	binary.LittleEndian.PutUint32(buf[24:], math.Float32bits(m.Yaw))
+	binary.LittleEndian.PutUint32(buf[28:], math.Float32bits(m.Xacc))
+	binary.LittleEndian.PutUint32(buf[32:], math.Float32bits(m.Yacc))
+	binary.LittleEndian.PutUint32(buf[36:], math.Float32bits(m.Zacc))
+	binary.LittleEndian.PutUint32(buf[40:], math.Float32bits(m.Xgyro))
+	binary.LittleEndian.PutUint32(buf[44:], math.Float32bits(m.Ygyro))
+	binary.LittleEndian.PutUint32(buf[48:], math.Float32bits(m.Zgyro))
+	binary.LittleEndian.PutUint32(buf[52:], math.Float32bits(m.Lat))
+	binary.LittleEndian.PutUint32(buf[56:], math.Float32bits(m.Lon))
+	binary.LittleEndian.PutUint32(buf[60:], math.Float32bits(m.Alt))
+	binary.LittleEndian.PutUint32(buf[64:], math.Float32bits(m.StdDevHorz))
+	binary.LittleEndian.PutUint32(buf[68:], math.Float32bits(m.StdDevVert))
+	binary.LittleEndian.PutUint32(buf[72:], math.Float32bits(m.Vn))
+	binary.LittleEndian.PutUint32(buf[76:], math.Float32bits(m.Ve))
+	binary.LittleEndian.PutUint32(buf[80:], math.Float32bits(m.Vd))
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageSimState) UnmarshalPayload(buf []byte) {
+	m.Q1 = math.Float32frombits(binary.LittleEndian.Uint32(buf[0:]))
+	m.Q2 = math.Float32frombits(binary.LittleEndian.Uint32(buf[4:]))
+	m.Q3 = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.Q4 = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.Roll = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	m.Pitch = math.Float32frombits(binary.LittleEndian.Uint32(buf[20:]))
+	m.Yaw = math.Float32frombits(binary.LittleEndian.Uint32(buf[24:]))
+	m.Xacc = math.Float32frombits(binary.LittleEndian.Uint32(buf[28:]))
+	m.Yacc = math.Float32frombits(binary.LittleEndian.Uint32(buf[32:]))
+	m.Zacc = math.Float32frombits(binary.LittleEndian.Uint32(buf[36:]))
+	m.Xgyro = math.Float32frombits(binary.LittleEndian.Uint32(buf[40:]))
+	m.Ygyro = math.Float32frombits(binary.LittleEndian.Uint32(buf[44:]))
+	m.Zgyro = math.Float32frombits(binary.LittleEndian.Uint32(buf[48:]))
+	m.Lat = math.Float32frombits(binary.LittleEndian.Uint32(buf[52:]))
+	m.Lon = math.Float32frombits(binary.LittleEndian.Uint32(buf[56:]))
+	m.Alt = math.Float32frombits(binary.LittleEndian.Uint32(buf[60:]))
+	m.StdDevHorz = math.Float32frombits(binary.LittleEndian.Uint32(buf[64:]))
+	m.StdDevVert = math.Float32frombits(binary.LittleEndian.Uint32(buf[68:]))
+	m.Vn = math.Float32frombits(binary.LittleEndian.Uint32(buf[72:]))
+	m.Ve = math.Float32frombits(binary.LittleEndian.Uint32(buf[76:]))
+	m.Vd = math.Float32frombits(binary.LittleEndian.Uint32(buf[80:]))
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageRadioStatus) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint16(buf[0:], m.Rxerrors)
+	binary.LittleEndian.PutUint16(buf[2:], m.Fixed)
+	buf[4] = m.Rssi
+	buf[5] = m.Remrssi
+	buf[6] = m.Txbuf
+	buf[7] = m.Noise
+	buf[8] = m.Remnoise
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageRadioStatus) UnmarshalPayload(buf []byte) {
+	m.Rxerrors = binary.LittleEndian.Uint16(buf[0:])
+	m.Fixed = binary.LittleEndian.Uint16(buf[2:])
+	m.Rssi = buf[4]
+	m.Remrssi = buf[5]
+	m.Txbuf = buf[6]
+	m.Noise = buf[7]
+	m.Remnoise = buf[8]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageFileTransferProtocol) MarshalPayload(buf []byte) {
+	buf[0] = m.TargetNetwork
+	buf[1] = m.TargetSystem
+	buf[2] = m.TargetComponent
+	copy(buf[3:254], m.Payload[:])
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageFileTransferProtocol) UnmarshalPayload(buf []byte) {
+	m.TargetNetwork = buf[0]
+	m.TargetSystem = buf[1]
+	m.TargetComponent = buf[2]
+	copy(m.Payload[:], buf[3:254])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageTimesync) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], uint64(m.Tc1))
+	binary.LittleEndian.PutUint64(buf[8:], uint64(m.Ts1))
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageTimesync) UnmarshalPayload(buf []byte) {
+	m.Tc1 = int64(binary.LittleEndian.Uint64(buf[0:]))
+	m.Ts1 = int64(binary.LittleEndian.Uint64(buf[8:]))
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageCameraTrigger) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.TimeUsec)
+	binary.LittleEndian.PutUint32(buf[8:], m.Seq)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageCameraTrigger) UnmarshalPayload(buf []byte) {
+	m.TimeUsec = binary.LittleEndian.Uint64(buf[0:])
+	m.Seq = binary.LittleEndian.Uint32(buf[8:])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageHilGps) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.TimeUsec)
+	binary.LittleEndian.PutUint32(buf[8:], uint32(m.Lat))
+	binary.LittleEndian.PutUint32(buf[12:], uint32(m.Lon))
+	binary.LittleEndian.PutUint32(buf[16:], uint32(m.Alt))
+	binary.LittleEndian.PutUint16(buf[20:], m.Eph)
+	binary.LittleEndian.PutUint16(buf[22:], m.Epv)
+	binary.LittleEndian.PutUint16(buf[24:], m.Vel)
+	binary.LittleEndian.PutUint16(buf[26:], uint16(m.Vn))
+	binary.LittleEndian.PutUint16(buf[28:], uint16(m.Ve))
+	binary.LittleEndian.PutUint16(buf[30:], uint16(m.Vd))
+	binary.LittleEndian.PutUint16(buf[32:], m.Cog)
+	buf[34] = m.FixType
+	buf[35] = m.SatellitesVisible
+	if len(buf) < 39 {
+		return
+	}
+	buf[36] = m.Id
+	binary.LittleEndian.PutUint16(buf[37:], m.Yaw)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageHilGps) UnmarshalPayload(buf []byte) {
+	m.TimeUsec = binary.LittleEndian.Uint64(buf[0:])
+	m.Lat = int32(binary.LittleEndian.Uint32(buf[8:]))
+	m.Lon = int32(binary.LittleEndian.Uint32(buf[12:]))
+	m.Alt = int32(binary.LittleEndian.Uint32(buf[16:]))
+	m.Eph = binary.LittleEndian.Uint16(buf[20:])
+	m.Epv = binary.LittleEndian.Uint16(buf[22:])
+	m.Vel = binary.LittleEndian.Uint16(buf[24:])
+	m.Vn = int16(binary.LittleEndian.Uint16(buf[26:]))
+	m.Ve = int16(binary.LittleEndian.Uint16(buf[28:]))
+	m.Vd = int16(binary.LittleEndian.Uint16(buf[30:]))
+	m.Cog = binary.LittleEndian.Uint16(buf[32:])
+	m.FixType = buf[34]
+	m.SatellitesVisible = buf[35]
+	if len(buf) < 39 {
+		return
+	}
+	m.Id = buf[36]
+	m.Yaw = binary.LittleEndian.Uint16(buf[37:])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageHilOpticalFlow) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.TimeUsec)
+	binary.LittleEndian.PutUint32(buf[8:], m.IntegrationTimeUs)
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.IntegratedX))
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.IntegratedY))
+	binary.LittleEndian.PutUint32(buf[20:], math.Float32bits(m.IntegratedXgyro))
+	binary.LittleEndian.PutUint32(buf[24:], math.Float32bits(m.IntegratedYgyro))
+	binary.LittleEndian.PutUint32(buf[28:], math.Float32bits(m.IntegratedZgyro))
+	binary.LittleEndian.PutUint32(buf[32:], m.TimeDeltaDistanceUs)
+	binary.LittleEndian.PutUint32(buf[36:], math.Float32bits(m.Distance))
+	binary.LittleEndian.PutUint16(buf[40:], uint16(m.Temperature))
+	buf[42] = m.SensorId
+	buf[43] = m.Quality
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageHilOpticalFlow) UnmarshalPayload(buf []byte) {
+	m.TimeUsec = binary.LittleEndian.Uint64(buf[0:])
+	m.IntegrationTimeUs = binary.LittleEndian.Uint32(buf[8:])
+	m.IntegratedX = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.IntegratedY = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	m.IntegratedXgyro = math.Float32frombits(binary.LittleEndian.Uint32(buf[20:]))
+	m.IntegratedYgyro = math.Float32frombits(binary.LittleEndian.Uint32(buf[24:]))
+	m.IntegratedZgyro = math.Float32frombits(binary.LittleEndian.Uint32(buf[28:]))
+	m.TimeDeltaDistanceUs = binary.LittleEndian.Uint32(buf[32:])
+	m.Distance = math.Float32frombits(binary.LittleEndian.Uint32(buf[36:]))
+	m.Temperature = int16(binary.LittleEndian.Uint16(buf[40:]))
+	m.SensorId = buf[42]
+	m.Quality = buf[43]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageHilStateQuaternion) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.TimeUsec)
+	for i, v := range m.AttitudeQuaternion {
+		binary.LittleEndian.PutUint32(buf[8+i*4:], math.Float32bits(v))
+	}
+	binary.LittleEndian.PutUint32(buf[24:], math.Float32bits(m.Rollspeed))
+	binary.LittleEndian.PutUint32(buf[28:], math.Float32bits(m.Pitchspeed))
+	binary.LittleEndian.PutUint32(buf[32:], math.Float32bits(m.Yawspeed))
+	binary.LittleEndian.PutUint32(buf[36:], uint32(m.Lat))
+	binary.LittleEndian.PutUint32(buf[40:], uint32(m.Lon))
+	binary.LittleEndian.PutUint32(buf[44:], uint32(m.Alt))
+	binary.LittleEndian.PutUint16(buf[48:], uint16(m.Vx))
+	binary.LittleEndian.PutUint16(buf[50:], uint16(m.Vy))
+	binary.LittleEndian.PutUint16(buf[52:], uint16(m.Vz))
+	binary.LittleEndian.PutUint16(buf[54:], m.IndAirspeed)
+	binary.LittleEndian.PutUint16(buf[56:], m.TrueAirspeed)
+	binary.LittleEndian.PutUint16(buf[58:], uint16(m.Xacc))
+	binary.LittleEndian.PutUint16(buf[60:], uint16(m.Yacc))
+	binary.LittleEndian.PutUint16(buf[62:], uint16(m.Zacc))
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageHilStateQuaternion) UnmarshalPayload(buf []byte) {
+	m.TimeUsec = binary.LittleEndian.Uint64(buf[0:])
+	for i := range m.AttitudeQuaternion {
+		m.AttitudeQuaternion[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[8+i*4:]))
+	}
+	m.Rollspeed = math.Float32frombits(binary.LittleEndian.Uint32(buf[24:]))
+	m.Pitchspeed = math.Float32frombits(binary.LittleEndian.Uint32(buf[28:]))
+	m.Yawspeed = math.Float32frombits(binary.LittleEndian.Uint32(buf[32:]))
+	m.Lat = int32(binary.LittleEndian.Uint32(buf[36:]))
+	m.Lon = int32(binary.LittleEndian.Uint32(buf[40:]))
+	m.Alt = int32(binary.LittleEndian.Uint32(buf[44:]))
+	m.Vx = int16(binary.LittleEndian.Uint16(buf[48:]))
+	m.Vy = int16(binary.LittleEndian.Uint16(buf[50:]))
+	m.Vz = int16(binary.LittleEndian.Uint16(buf[52:]))
+	m.IndAirspeed = binary.LittleEndian.Uint16(buf[54:])
+	m.TrueAirspeed = binary.LittleEndian.Uint16(buf[56:])
+	m.Xacc = int16(binary.LittleEndian.Uint16(buf[58:]))
+	m.Yacc = int16(binary.LittleEndian.Uint16(buf[60:]))
+	m.Zacc = int16(binary.LittleEndian.Uint16(buf[62:]))
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageScaledImu2) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], m.TimeBootMs)
+	binary.LittleEndian.PutUint16(buf[4:], uint16(m.Xacc))
+	binary.LittleEndian.PutUint16(buf[6:], uint16(m.Yacc))
+	binary.LittleEndian.PutUint16(buf[8:], uint16(m.Zacc))
+	binary.LittleEndian.PutUint16(buf[10:], uint16(m.Xgyro))
+	binary.LittleEndian.PutUint16(buf[12:], uint16(m.Ygyro))
+	binary.LittleEndian.PutUint16(buf[14:], uint16(m.Zgyro))
+	binary.LittleEndian.PutUint16(buf[16:], uint16(m.Xmag))
+	binary.LittleEndian.PutUint16(buf[18:], uint16(m.Ymag))
+	binary.LittleEndian.PutUint16(buf[20:], uint16(m.Zmag))
+	if len(buf) < 24 {
+		return
+	}
+	binary.LittleEndian.PutUint16(buf[22:], uint16(m.Temperature))
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageScaledImu2) UnmarshalPayload(buf []byte) {
+	m.TimeBootMs = binary.LittleEndian.Uint32(buf[0:])
+	m.Xacc = int16(binary.LittleEndian.Uint16(buf[4:]))
+	m.Yacc = int16(binary.LittleEndian.Uint16(buf[6:]))
+	m.Zacc = int16(binary.LittleEndian.Uint16(buf[8:]))
+	m.Xgyro = int16(binary.LittleEndian.Uint16(buf[10:]))
+	m.Ygyro = int16(binary.LittleEndian.Uint16(buf[12:]))
+	m.Zgyro = int16(binary.LittleEndian.Uint16(buf[14:]))
+	m.Xmag = int16(binary.LittleEndian.Uint16(buf[16:]))
+	m.Ymag = int16(binary.LittleEndian.Uint16(buf[18:]))
+	m.Zmag = int16(binary.LittleEndian.Uint16(buf[20:]))
+	if len(buf) < 24 {
+		return
+	}
+	m.Temperature = int16(binary.LittleEndian.Uint16(buf[22:]))
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageLogRequestList) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint16(buf[0:], m.Start)
+	binary.LittleEndian.PutUint16(buf[2:], m.End)
+	buf[4] = m.TargetSystem
+	buf[5] = m.TargetComponent
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageLogRequestList) UnmarshalPayload(buf []byte) {
+	m.Start = binary.LittleEndian.Uint16(buf[0:])
+	m.End = binary.LittleEndian.Uint16(buf[2:])
+	m.TargetSystem = buf[4]
+	m.TargetComponent = buf[5]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageLogEntry) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], m.TimeUtc)
+	binary.LittleEndian.PutUint32(buf[4:], m.Size)
+	binary.LittleEndian.PutUint16(buf[8:], m.Id)
+	binary.LittleEndian.PutUint16(buf[10:], m.NumLogs)
+	binary.LittleEndian.PutUint16(buf[12:], m.LastLogNum)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageLogEntry) UnmarshalPayload(buf []byte) {
+	m.TimeUtc = binary.LittleEndian.Uint32(buf[0:])
+	m.Size = binary.LittleEndian.Uint32(buf[4:])
+	m.Id = binary.LittleEndian.Uint16(buf[8:])
+	m.NumLogs = binary.LittleEndian.Uint16(buf[10:])
+	m.LastLogNum = binary.LittleEndian.Uint16(buf[12:])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageLogRequestData) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], m.Ofs)
+	binary.LittleEndian.PutUint32(buf[4:], m.Count)
+	binary.LittleEndian.PutUint16(buf[8:], m.Id)
+	buf[10] = m.TargetSystem
+	buf[11] = m.TargetComponent
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageLogRequestData) UnmarshalPayload(buf []byte) {
+	m.Ofs = binary.LittleEndian.Uint32(buf[0:])
+	m.Count = binary.LittleEndian.Uint32(buf[4:])
+	m.Id = binary.LittleEndian.Uint16(buf[8:])
+	m.TargetSystem = buf[10]
+	m.TargetComponent = buf[11]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageLogData) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], m.Ofs)
+	binary.LittleEndian.PutUint16(buf[4:], m.Id)
+	buf[6] = m.Count
+	copy(buf[7:97], m.Data[:])
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageLogData) UnmarshalPayload(buf []byte) {
+	m.Ofs = binary.LittleEndian.Uint32(buf[0:])
+	m.Id = binary.LittleEndian.Uint16(buf[4:])
+	m.Count = buf[6]
+	copy(m.Data[:], buf[7:97])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageLogErase) MarshalPayload(buf []byte) {
+	buf[0] = m.TargetSystem
+	buf[1] = m.TargetComponent
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageLogErase) UnmarshalPayload(buf []byte) {
+	m.TargetSystem = buf[0]
+	m.TargetComponent = buf[1]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageLogRequestEnd) MarshalPayload(buf []byte) {
+	buf[0] = m.TargetSystem
+	buf[1] = m.TargetComponent
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageLogRequestEnd) UnmarshalPayload(buf []byte) {
+	m.TargetSystem = buf[0]
+	m.TargetComponent = buf[1]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageGpsInjectData) MarshalPayload(buf []byte) {
+	buf[0] = m.TargetSystem
+	buf[1] = m.TargetComponent
+	buf[2] = m.Len
+	copy(buf[3:113], m.Data[:])
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageGpsInjectData) UnmarshalPayload(buf []byte) {
+	m.TargetSystem = buf[0]
+	m.TargetComponent = buf[1]
+	m.Len = buf[2]
+	copy(m.Data[:], buf[3:113])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageGps2Raw) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.TimeUsec)
+	binary.LittleEndian.PutUint32(buf[8:], uint32(m.Lat))
+	binary.LittleEndian.PutUint32(buf[12:], uint32(m.Lon))
+	binary.LittleEndian.PutUint32(buf[16:], uint32(m.Alt))
+	binary.LittleEndian.PutUint32(buf[20:], m.DgpsAge)
+	binary.LittleEndian.PutUint16(buf[24:], m.Eph)
+	binary.LittleEndian.PutUint16(buf[26:], m.Epv)
+	binary.LittleEndian.PutUint16(buf[28:], m.Vel)
+	binary.LittleEndian.PutUint16(buf[30:], m.Cog)
+	buf[32] = uint8(m.FixType)
+	buf[33] = m.SatellitesVisible
+	buf[34] = m.DgpsNumch
+	if len(buf) < 57 {
+		return
+	}
+	binary.LittleEndian.PutUint16(buf[35:], m.Yaw)
+	binary.LittleEndian.PutUint32(buf[37:], uint32(m.AltEllipsoid))
+	binary.LittleEndian.PutUint32(buf[41:], m.HAcc)
+	binary.LittleEndian.PutUint32(buf[45:], m.VAcc)
+	binary.LittleEndian.PutUint32(buf[49:], m.VelAcc)
+	binary.LittleEndian.PutUint32(buf[53:], m.HdgAcc)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageGps2Raw) UnmarshalPayload(buf []byte) {
+	m.TimeUsec = binary.LittleEndian.Uint64(buf[0:])
+	m.Lat = int32(binary.LittleEndian.Uint32(buf[8:]))
+	m.Lon = int32(binary.LittleEndian.Uint32(buf[12:]))
+	m.Alt = int32(binary.LittleEndian.Uint32(buf[16:]))
+	m.DgpsAge = binary.LittleEndian.Uint32(buf[20:])
+	m.Eph = binary.LittleEndian.Uint16(buf[24:])
+	m.Epv = binary.LittleEndian.Uint16(buf[26:])
+	m.Vel = binary.LittleEndian.Uint16(buf[28:])
+	m.Cog = binary.LittleEndian.Uint16(buf[30:])
+	m.FixType = GPS_FIX_TYPE(buf[32])
+	m.SatellitesVisible = buf[33]
+	m.DgpsNumch = buf[34]
+	if len(buf) < 57 {
+		return
+	}
+	m.Yaw = binary.LittleEndian.Uint16(buf[35:])
+	m.AltEllipsoid = int32(binary.LittleEndian.Uint32(buf[37:]))
+	m.HAcc = binary.LittleEndian.Uint32(buf[41:])
+	m.VAcc = binary.LittleEndian.Uint32(buf[45:])
+	m.VelAcc = binary.LittleEndian.Uint32(buf[49:])
+	m.HdgAcc = binary.LittleEndian.Uint32(buf[53:])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessagePowerStatus) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint16(buf[0:], m.Vcc)
+	binary.LittleEndian.PutUint16(buf[2:], m.Vservo)
+	binary.LittleEndian.PutUint16(buf[4:], uint16(m.Flags))
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessagePowerStatus) UnmarshalPayload(buf []byte) {
+	m.Vcc = binary.LittleEndian.Uint16(buf[0:])
+	m.Vservo = binary.LittleEndian.Uint16(buf[2:])
+	m.Flags = MAV_POWER_STATUS(binary.LittleEndian.Uint16(buf[4:]))
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageSerialControl) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], m.Baudrate)
+	binary.LittleEndian.PutUint16(buf[4:], m.Timeout)
+	buf[6] = uint8(m.Device)
+	buf[7] = uint8(m.Flags)
+	buf[8] = m.Count
+	copy(buf[9:79], m.Data[:])
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageSerialControl) UnmarshalPayload(buf []byte) {
+	m.Baudrate = binary.LittleEndian.Uint32(buf[0:])
+	m.Timeout = binary.LittleEndian.Uint16(buf[4:])
+	m.Device = SERIAL_CONTROL_DEV(buf[6])
+	m.Flags = SERIAL_CONTROL_FLAG(buf[7])
+	m.Count = buf[8]
+	copy(m.Data[:], buf[9:79])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageGpsRtk) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], m.TimeLastBaselineMs)
+	binary.LittleEndian.PutUint32(buf[4:], m.Tow)
+	binary.LittleEndian.PutUint32(buf[8:], uint32(m.BaselineAMm))
+	binary.LittleEndian.PutUint32(buf[12:], uint32(m.BaselineBMm))
+	binary.LittleEndian.PutUint32(buf[16:], uint32(m.BaselineCMm))
+	binary.LittleEndian.PutUint32(buf[20:], m.Accuracy)
+	binary.LittleEndian.PutUint32(buf[24:], uint32(m.IarNumHypotheses))
+	binary.LittleEndian.PutUint16(buf[28:], m.Wn)
+	buf[30] = m.RtkReceiverId
+	buf[31] = m.RtkHealth
+	buf[32] = m.RtkRate
+	buf[33] = m.Nsats
+	buf[34] = uint8(m.BaselineCoordsType)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageGpsRtk) UnmarshalPayload(buf []byte) {
+	m.TimeLastBaselineMs = binary.LittleEndian.Uint32(buf[0:])
+	m.Tow = binary.LittleEndian.Uint32(buf[4:])
+	m.BaselineAMm = int32(binary.LittleEndian.Uint32(buf[8:]))
+	m.BaselineBMm = int32(binary.LittleEndian.Uint32(buf[12:]))
+	m.BaselineCMm = int32(binary.LittleEndian.Uint32(buf[16:]))
+	m.Accuracy = binary.LittleEndian.Uint32(buf[20:])
+	m.IarNumHypotheses = int32(binary.LittleEndian.Uint32(buf[24:]))
+	m.Wn = binary.LittleEndian.Uint16(buf[28:])
+	m.RtkReceiverId = buf[30]
+	m.RtkHealth = buf[31]
+	m.RtkRate = buf[32]
+	m.Nsats = buf[33]
+	m.BaselineCoordsType = RTK_BASELINE_COORDINATE_SYSTEM(buf[34])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageGps2Rtk) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], m.TimeLastBaselineMs)
+	binary.LittleEndian.PutUint32(buf[4:], m.Tow)
+	binary.LittleEndian.PutUint32(buf[8:], uint32(m.BaselineAMm))
+	binary.LittleEndian.PutUint32(buf[12:], uint32(m.BaselineBMm))
+	binary.LittleEndian.PutUint32(buf[16:], uint32(m.BaselineCMm))
+	binary.LittleEndian.PutUint32(buf[20:], m.Accuracy)
+	binary.LittleEndian.PutUint32(buf[24:], uint32(m.IarNumHypotheses))
+	binary.LittleEndian.PutUint16(buf[28:], m.Wn)
+	buf[30] = m.RtkReceiverId
+	buf[31] = m.RtkHealth
+	buf[32] = m.RtkRate
+	buf[33] = m.Nsats
+	buf[34] = uint8(m.BaselineCoordsType)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageGps2Rtk) UnmarshalPayload(buf []byte) {
+	m.TimeLastBaselineMs = binary.LittleEndian.Uint32(buf[0:])
+	m.Tow = binary.LittleEndian.Uint32(buf[4:])
+	m.BaselineAMm = int32(binary.LittleEndian.Uint32(buf[8:]))
+	m.BaselineBMm = int32(binary.LittleEndian.Uint32(buf[12:]))
+	m.BaselineCMm = int32(binary.LittleEndian.Uint32(buf[16:]))
+	m.Accuracy = binary.LittleEndian.Uint32(buf[20:])
+	m.IarNumHypotheses = int32(binary.LittleEndian.Uint32(buf[24:]))
+	m.Wn = binary.LittleEndian.Uint16(buf[28:])
+	m.RtkReceiverId = buf[30]
+	m.RtkHealth = buf[31]
+	m.RtkRate = buf[32]
+	m.Nsats = buf[33]
+	m.BaselineCoordsType = RTK_BASELINE_COORDINATE_SYSTEM(buf[34])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageScaledImu3) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], m.TimeBootMs)
+	binary.LittleEndian.PutUint16(buf[4:], uint16(m.Xacc))
+	binary.LittleEndian.PutUint16(buf[6:], uint16(m.Yacc))
+	binary.LittleEndian.PutUint16(buf[8:], uint16(m.Zacc))
+	binary.LittleEndian.PutUint16(buf[10:], uint16(m.Xgyro))
+	binary.LittleEndian.PutUint16(buf[12:], uint16(m.Ygyro))
+	binary.LittleEndian.PutUint16(buf[14:], uint16(m.Zgyro))
+	binary.LittleEndian.PutUint16(buf[16:], uint16(m.Xmag))
+	binary.LittleEndian.PutUint16(buf[18:], uint16(m.Ymag))
+	binary.LittleEndian.PutUint16(buf[20:], uint16(m.Zmag))
+	if len(buf) < 24 {
+		return
+	}
+	binary.LittleEndian.PutUint16(buf[22:], uint16(m.Temperature))
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageScaledImu3) UnmarshalPayload(buf []byte) {
+	m.TimeBootMs = binary.LittleEndian.Uint32(buf[0:])
+	m.Xacc = int16(binary.LittleEndian.Uint16(buf[4:]))
+	m.Yacc = int16(binary.LittleEndian.Uint16(buf[6:]))
+	m.Zacc = int16(binary.LittleEndian.Uint16(buf[8:]))
+	m.Xgyro = int16(binary.LittleEndian.Uint16(buf[10:]))
+	m.Ygyro = int16(binary.LittleEndian.Uint16(buf[12:]))
+	m.Zgyro = int16(binary.LittleEndian.Uint16(buf[14:]))
+	m.Xmag = int16(binary.LittleEndian.Uint16(buf[16:]))
+	m.Ymag = int16(binary.LittleEndian.Uint16(buf[18:]))
+	m.Zmag = int16(binary.LittleEndian.Uint16(buf[20:]))
+	if len(buf) < 24 {
+		return
+	}
+	m.Temperature = int16(binary.LittleEndian.Uint16(buf[22:]))
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageDataTransmissionHandshake) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], m.Size)
+	binary.LittleEndian.PutUint16(buf[4:], m.Width)
+	binary.LittleEndian.PutUint16(buf[6:], m.Height)
+	binary.LittleEndian.PutUint16(buf[8:], m.Packets)
+	buf[10] = uint8(m.Type)
+	buf[11] = m.Payload
+	buf[12] = m.JpgQuality
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageDataTransmissionHandshake) UnmarshalPayload(buf []byte) {
+	m.Size = binary.LittleEndian.Uint32(buf[0:])
+	m.Width = binary.LittleEndian.Uint16(buf[4:])
+	m.Height = binary.LittleEndian.Uint16(buf[6:])
+	m.Packets = binary.LittleEndian.Uint16(buf[8:])
+	m.Type = MAVLINK_DATA_STREAM_TYPE(buf[10])
+	m.Payload = buf[11]
+	m.JpgQuality = buf[12]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageEncapsulatedData) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint16(buf[0:], m.Seqnr)
+	copy(buf[2:255], m.Data[:])
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageEncapsulatedData) UnmarshalPayload(buf []byte) {
+	m.Seqnr = binary.LittleEndian.Uint16(buf[0:])
+	copy(m.Data[:], buf[2:255])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageDistanceSensor) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], m.TimeBootMs)
+	binary.LittleEndian.PutUint16(buf[4:], m.MinDistance)
+	binary.LittleEndian.PutUint16(buf[6:], m.MaxDistance)
+	binary.LittleEndian.PutUint16(buf[8:], m.CurrentDistance)
+	buf[10] = uint8(m.Type)
+	buf[11] = m.Id
+	buf[12] = uint8(m.Orientation)
+	buf[13] = m.Covariance
+	if len(buf) < 39 {
+		return
+	}
+	binary.LittleEndian.PutUint32(buf[14:], math.Float32bits(m.HorizontalFov))
+	binary.LittleEndian.PutUint32(buf[18:], math.Float32bits(m.VerticalFov))
+	for i, v := range m.Quaternion {
+		binary.LittleEndian.PutUint32(buf[22+i*4:], math.Float32bits(v))
+	}
+	buf[38] = m.SignalQuality
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageDistanceSensor) UnmarshalPayload(buf []byte) {
+	m.TimeBootMs = binary.LittleEndian.Uint32(buf[0:])
+	m.MinDistance = binary.LittleEndian.Uint16(buf[4:])
+	m.MaxDistance = binary.LittleEndian.Uint16(buf[6:])
+	m.CurrentDistance = binary.LittleEndian.Uint16(buf[8:])
+	m.Type = MAV_DISTANCE_SENSOR(buf[10])
+	m.Id = buf[11]
+	m.Orientation = MAV_SENSOR_ORIENTATION(buf[12])
+	m.Covariance = buf[13]
+	if len(buf) < 39 {
+		return
+	}
+	m.HorizontalFov = math.Float32frombits(binary.LittleEndian.Uint32(buf[14:]))
+	m.VerticalFov = math.Float32frombits(binary.LittleEndian.Uint32(buf[18:]))
+	for i := range m.Quaternion {
+		m.Quaternion[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[22+i*4:]))
+	}
+	m.SignalQuality = buf[38]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageTerrainRequest) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.Mask)
+	binary.LittleEndian.PutUint32(buf[8:], uint32(m.Lat))
+	binary.LittleEndian.PutUint32(buf[12:], uint32(m.Lon))
+	binary.LittleEndian.PutUint16(buf[16:], m.GridSpacing)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageTerrainRequest) UnmarshalPayload(buf []byte) {
+	m.Mask = binary.LittleEndian.Uint64(buf[0:])
+	m.Lat = int32(binary.LittleEndian.Uint32(buf[8:]))
+	m.Lon = int32(binary.LittleEndian.Uint32(buf[12:]))
+	m.GridSpacing = binary.LittleEndian.Uint16(buf[16:])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageTerrainData) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], uint32(m.Lat))
+	binary.LittleEndian.PutUint32(buf[4:], uint32(m.Lon))
+	binary.LittleEndian.PutUint16(buf[8:], m.GridSpacing)
+	for i, v := range m.Data {
+		binary.LittleEndian.PutUint16(buf[10+i*2:], uint16(v))
+	}
+	buf[42] = m.Gridbit
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageTerrainData) UnmarshalPayload(buf []byte) {
+	m.Lat = int32(binary.LittleEndian.Uint32(buf[0:]))
+	m.Lon = int32(binary.LittleEndian.Uint32(buf[4:]))
+	m.GridSpacing = binary.LittleEndian.Uint16(buf[8:])
+	for i := range m.Data {
+		m.Data[i] = int16(binary.LittleEndian.Uint16(buf[10+i*2:]))
+	}
+	m.Gridbit = buf[42]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageTerrainCheck) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], uint32(m.Lat))
+	binary.LittleEndian.PutUint32(buf[4:], uint32(m.Lon))
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageTerrainCheck) UnmarshalPayload(buf []byte) {
+	m.Lat = int32(binary.LittleEndian.Uint32(buf[0:]))
+	m.Lon = int32(binary.LittleEndian.Uint32(buf[4:]))
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageTerrainReport) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], uint32(m.Lat))
+	binary.LittleEndian.PutUint32(buf[4:], uint32(m.Lon))
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.TerrainHeight))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.CurrentHeight))
+	binary.LittleEndian.PutUint16(buf[16:], m.Spacing)
+	binary.LittleEndian.PutUint16(buf[18:], m.Pending)
+	binary.LittleEndian.PutUint16(buf[20:], m.Loaded)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageTerrainReport) UnmarshalPayload(buf []byte) {
+	m.Lat = int32(binary.LittleEndian.Uint32(buf[0:]))
+	m.Lon = int32(binary.LittleEndian.Uint32(buf[4:]))
+	m.TerrainHeight = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.CurrentHeight = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.Spacing = binary.LittleEndian.Uint16(buf[16:])
+	m.Pending = binary.LittleEndian.Uint16(buf[18:])
+	m.Loaded = binary.LittleEndian.Uint16(buf[20:])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageScaledPressure2) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], m.TimeBootMs)
+	binary.LittleEndian.PutUint32(buf[4:], math.Float32bits(m.PressAbs))
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.PressDiff))
+	binary.LittleEndian.PutUint16(buf[12:], uint16(m.Temperature))
+	if len(buf) < 16 {
+		return
+	}
+	binary.LittleEndian.PutUint16(buf[14:], uint16(m.TemperaturePressDiff))
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageScaledPressure2) UnmarshalPayload(buf []byte) {
+	m.TimeBootMs = binary.LittleEndian.Uint32(buf[0:])
+	m.PressAbs = math.Float32frombits(binary.LittleEndian.Uint32(buf[4:]))
+	m.PressDiff = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.Temperature = int16(binary.LittleEndian.Uint16(buf[12:]))
+	if len(buf) < 16 {
+		return
+	}
+	m.TemperaturePressDiff = int16(binary.LittleEndian.Uint16(buf[14:]))
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageAttPosMocap) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.TimeUsec)
+	for i, v := range m.Q {
+		binary.LittleEndian.PutUint32(buf[8+i*4:], math.Float32bits(v))
+	}
+	binary.LittleEndian.PutUint32(buf[24:], math.Float32bits(m.X))
+	binary.LittleEndian.PutUint32(buf[28:], math.Float32bits(m.Y))
+	binary.LittleEndian.PutUint32(buf[32:], math.Float32bits(m.Z))
+	if len(buf) < 120 {
+		return
+	}
+	for i, v := range m.Covariance {
+		binary.LittleEndian.PutUint32(buf[36+i*4:], math.Float32bits(v))
+	}
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageAttPosMocap) UnmarshalPayload(buf []byte) {
+	m.TimeUsec = binary.LittleEndian.Uint64(buf[0:])
+	for i := range m.Q {
+		m.Q[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[8+i*4:]))
+	}
+	m.X = math.Float32frombits(binary.LittleEndian.Uint32(buf[24:]))
+	m.Y = math.Float32frombits(binary.LittleEndian.Uint32(buf[28:]))
+	m.Z = math.Float32frombits(binary.LittleEndian.Uint32(buf[32:]))
+	if len(buf) < 120 {
+		return
+	}
+	for i := range m.Covariance {
+		m.Covariance[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[36+i*4:]))
+	}
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageSetActuatorControlTarget) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.TimeUsec)
+	for i, v := range m.Controls {
+		binary.LittleEndian.PutUint32(buf[8+i*4:], math.Float32bits(v))
+	}
+	buf[40] = m.GroupMlx
+	buf[41] = m.TargetSystem
+	buf[42] = m.TargetComponent
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageSetActuatorControlTarget) UnmarshalPayload(buf []byte) {
+	m.TimeUsec = binary.LittleEndian.Uint64(buf[0:])
+	for i := range m.Controls {
+		m.Controls[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[8+i*4:]))
+	}
+	m.GroupMlx = buf[40]
+	m.TargetSystem = buf[41]
+	m.TargetComponent = buf[42]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageActuatorControlTarget) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.TimeUsec)
+	for i, v := range m.Controls {
+		binary.LittleEndian.PutUint32(buf[8+i*4:], math.Float32bits(v))
+	}
+	buf[40] = m.GroupMlx
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageActuatorControlTarget) UnmarshalPayload(buf []byte) {
+	m.TimeUsec = binary.LittleEndian.Uint64(buf[0:])
+	for i := range m.Controls {
+		m.Controls[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[8+i*4:]))
+	}
+	m.GroupMlx = buf[40]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageAltitude) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.TimeUsec)
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.AltitudeMonotonic))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.AltitudeAmsl))
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.AltitudeLocal))
+	binary.LittleEndian.PutUint32(buf[20:], math.Float32bits(m.AltitudeRelative))
+	binary.LittleEndian.PutUint32(buf[24:], math.Float32bits(m.AltitudeTerrain))
+	binary.LittleEndian.PutUint32(buf[28:], math.Float32bits(m.BottomClearance))
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageAltitude) UnmarshalPayload(buf []byte) {
+	m.TimeUsec = binary.LittleEndian.Uint64(buf[0:])
+	m.AltitudeMonotonic = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.AltitudeAmsl = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.AltitudeLocal = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	m.AltitudeRelative = math.Float32frombits(binary.LittleEndian.Uint32(buf[20:]))
+	m.AltitudeTerrain = math.Float32frombits(binary.LittleEndian.Uint32(buf[24:]))
+	m.BottomClearance = math.Float32frombits(binary.LittleEndian.Uint32(buf[28:]))
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageResourceRequest) MarshalPayload(buf []byte) {
+	buf[0] = m.RequestId
+	buf[1] = m.UriType
+	copy(buf[2:122], m.Uri[:])
+	buf[122] = m.TransferType
+	copy(buf[123:243], m.Storage[:])
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageResourceRequest) UnmarshalPayload(buf []byte) {
+	m.RequestId = buf[0]
+	m.UriType = buf[1]
+	copy(m.Uri[:], buf[2:122])
+	m.TransferType = buf[122]
+	copy(m.Storage[:], buf[123:243])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageScaledPressure3) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], m.TimeBootMs)
+	binary.LittleEndian.PutUint32(buf[4:], math.Float32bits(m.PressAbs))
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.PressDiff))
+	binary.LittleEndian.PutUint16(buf[12:], uint16(m.Temperature))
+	if len(buf) < 16 {
+		return
+	}
+	binary.LittleEndian.PutUint16(buf[14:], uint16(m.TemperaturePressDiff))
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageScaledPressure3) UnmarshalPayload(buf []byte) {
+	m.TimeBootMs = binary.LittleEndian.Uint32(buf[0:])
+	m.PressAbs = math.Float32frombits(binary.LittleEndian.Uint32(buf[4:]))
+	m.PressDiff = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.Temperature = int16(binary.LittleEndian.Uint16(buf[12:]))
+	if len(buf) < 16 {
+		return
+	}
+	m.TemperaturePressDiff = int16(binary.LittleEndian.Uint16(buf[14:]))
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageFollowTarget) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.Timestamp)
+	binary.LittleEndian.PutUint64(buf[8:], m.CustomState)
+	binary.LittleEndian.PutUint32(buf[16:], uint32(m.Lat))
+	binary.LittleEndian.PutUint32(buf[20:], uint32(m.Lon))
+	binary.LittleEndian.PutUint32(buf[24:], math.Float32bits(m.Alt))
+	for i, v := range m.Vel {
+		binary.LittleEndian.PutUint32(buf[28+i*4:], math.Float32bits(v))
+	}
+	for i, v := range m.Acc {
+		binary.LittleEndian.PutUint32(buf[40+i*4:], math.Float32bits(v))
+	}
+	for i, v := range m.AttitudeQ {
+		binary.LittleEndian.PutUint32(buf[52+i*4:], math.Float32bits(v))
+	}
+	for i, v := range m.Rates {
+		binary.LittleEndian.PutUint32(buf[68+i*4:], math.Float32bits(v))
+	}
+	for i, v := range m.PositionCov {
+		binary.LittleEndian.PutUint32(buf[80+i*4:], math.Float32bits(v))
+	}
+	buf[92] = m.EstCapabilities
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageFollowTarget) UnmarshalPayload(buf []byte) {
+	m.Timestamp = binary.LittleEndian.Uint64(buf[0:])
+	m.CustomState = binary.LittleEndian.Uint64(buf[8:])
+	m.Lat = int32(binary.LittleEndian.Uint32(buf[16:]))
+	m.Lon = int32(binary.LittleEndian.Uint32(buf[20:]))
+	m.Alt = math.Float32frombits(binary.LittleEndian.Uint32(buf[24:]))
+	for i := range m.Vel {
+		m.Vel[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[28+i*4:]))
+	}
+	for i := range m.Acc {
+		m.Acc[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[40+i*4:]))
+	}
+	for i := range m.AttitudeQ {
+		m.AttitudeQ[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[52+i*4:]))
+	}
+	for i := range m.Rates {
+		m.Rates[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[68+i*4:]))
+	}
+	for i := range m.PositionCov {
+		m.PositionCov[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[80+i*4:]))
+	}
+	m.EstCapabilities = buf[92]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageControlSystemState) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.TimeUsec)
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.XAcc))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.YAcc))
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.ZAcc))
+	binary.LittleEndian.PutUint32(buf[20:], math.Float32bits(m.XVel))
+	binary.LittleEndian.PutUint32(buf[24:], math.Float32bits(m.YVel))
+	binary.LittleEndian.PutUint32(buf[28:], math.Float32bits(m.ZVel))
+	binary.LittleEndian.PutUint32(buf[32:], math.Float32bits(m.XPos))
+	binary.LittleEndian.PutUint32(buf[36:], math.Float32bits(m.YPos))
+	binary.LittleEndian.PutUint32(buf[40:], math.Float32bits(m.ZPos))
+	binary.LittleEndian.PutUint32(buf[44:], math.Float32bits(m.Airspeed))
+	for i, v := range m.VelVariance {
+		binary.LittleEndian.PutUint32(buf[48+i*4:], math.Float32bits(v))
+	}
+	for i, v := range m.PosVariance {
+		binary.LittleEndian.PutUint32(buf[60+i*4:], math.Float32bits(v))
+	}
+	for i, v := range m.Q {
+		binary.LittleEndian.PutUint32(buf[72+i*4:], math.Float32bits(v))
+	}
+	binary.LittleEndian.PutUint32(buf[88:], math.Float32bits(m.RollRate))
+	binary.LittleEndian.PutUint32(buf[92:], math.Float32bits(m.PitchRate))
+	binary.LittleEndian.PutUint32(buf[96:], math.Float32bits(m.YawRate))
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageControlSystemState) UnmarshalPayload(buf []byte) {
+	m.TimeUsec = binary.LittleEndian.Uint64(buf[0:])
+	m.XAcc = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.YAcc = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.ZAcc = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	m.XVel = math.Float32frombits(binary.LittleEndian.Uint32(buf[20:]))
+	m.YVel = math.Float32frombits(binary.LittleEndian.Uint32(buf[24:]))
+	m.ZVel = math.Float32frombits(binary.LittleEndian.Uint32(buf[28:]))
+	m.XPos = math.Float32frombits(binary.LittleEndian.Uint32(buf[32:]))
+	m.YPos = math.Float32frombits(binary.LittleEndian.Uint32(buf[36:]))
+	m.ZPos = math.Float32frombits(binary.LittleEndian.Uint32(buf[40:]))
+	m.Airspeed = math.Float32frombits(binary.LittleEndian.Uint32(buf[44:]))
+	for i := range m.VelVariance {
+		m.VelVariance[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[48+i*4:]))
+	}
+	for i := range m.PosVariance {
+		m.PosVariance[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[60+i*4:]))
+	}
+	for i := range m.Q {
+		m.Q[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[72+i*4:]))
+	}
+	m.RollRate = math.Float32frombits(binary.LittleEndian.Uint32(buf[88:]))
+	m.PitchRate = math.Float32frombits(binary.LittleEndian.Uint32(buf[92:]))
+	m.YawRate = math.Float32frombits(binary.LittleEndian.Uint32(buf[96:]))
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageBatteryStatus) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], uint32(m.CurrentConsumed))
+	binary.LittleEndian.PutUint32(buf[4:], uint32(m.EnergyConsumed))
+	binary.LittleEndian.PutUint16(buf[8:], uint16(m.Temperature))
+	for i, v := range m.Voltages {
+		binary.LittleEndian.PutUint16(buf[10+i*2:], v)
+	}
+	binary.LittleEndian.PutUint16(buf[30:], uint16(m.CurrentBattery))
+	buf[32] = m.Id
+	buf[33] = uint8(m.BatteryFunction)
+	buf[34] = uint8(m.Type)
+	buf[35] = uint8(m.BatteryRemaining)
+	if len(buf) < 54 {
+		return
+	}
+	binary.LittleEndian.PutUint32(buf[36:], uint32(m.TimeRemaining))
+	buf[40] = uint8(m.ChargeState)
+	for i, v := range m.VoltagesExt {
+		binary.LittleEndian.PutUint16(buf[41+i*2:], v)
+	}
+	buf[49] = uint8(m.Mode)
+	binary.LittleEndian.PutUint32(buf[50:], uint32(m.FaultBitmask))
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageBatteryStatus) UnmarshalPayload(buf []byte) {
+	m.CurrentConsumed = int32(binary.LittleEndian.Uint32(buf[0:]))
+	m.EnergyConsumed = int32(binary.LittleEndian.Uint32(buf[4:]))
+	m.Temperature = int16(binary.LittleEndian.Uint16(buf[8:]))
+	for i := range m.Voltages {
+		m.Voltages[i] = binary.LittleEndian.Uint16(buf[10+i*2:])
+	}
+	m.CurrentBattery = int16(binary.LittleEndian.Uint16(buf[30:]))
+	m.Id = buf[32]
+	m.BatteryFunction = MAV_BATTERY_FUNCTION(buf[33])
+	m.Type = MAV_BATTERY_TYPE(buf[34])
+	m.BatteryRemaining = int8(buf[35])
+	if len(buf) < 54 {
+		return
+	}
+	m.TimeRemaining = int32(binary.LittleEndian.Uint32(buf[36:]))
+	m.ChargeState = MAV_BATTERY_CHARGE_STATE(buf[40])
+	for i := range m.VoltagesExt {
+		m.VoltagesExt[i] = binary.LittleEndian.Uint16(buf[41+i*2:])
+	}
+	m.Mode = MAV_BATTERY_MODE(buf[49])
+	m.FaultBitmask = MAV_BATTERY_FAULT(binary.LittleEndian.Uint32(buf[50:]))
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageAutopilotVersion) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], uint64(m.Capabilities))
+	binary.LittleEndian.PutUint64(buf[8:], m.Uid)
+	binary.LittleEndian.PutUint32(buf[16:], m.FlightSwVersion)
+	binary.LittleEndian.PutUint32(buf[20:], m.MiddlewareSwVersion)
+	binary.LittleEndian.PutUint32(buf[24:], m.OsSwVersion)
+	binary.LittleEndian.PutUint32(buf[28:], m.BoardVersion)
+	binary.LittleEndian.PutUint16(buf[32:], m.VendorId)
+	binary.LittleEndian.PutUint16(buf[34:], m.ProductId)
+	copy(buf[36:44], m.FlightCustomVersion[:])
+	copy(buf[44:52], m.MiddlewareCustomVersion[:])
+	copy(buf[52:60], m.OsCustomVersion[:])
+	if len(buf) < 78 {
+		return
+	}
+	copy(buf[60:78], m.Uid2[:])
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageAutopilotVersion) UnmarshalPayload(buf []byte) {
+	m.Capabilities = MAV_PROTOCOL_CAPABILITY(binary.LittleEndian.Uint64(buf[0:]))
+	m.Uid = binary.LittleEndian.Uint64(buf[8:])
+	m.FlightSwVersion = binary.LittleEndian.Uint32(buf[16:])
+	m.MiddlewareSwVersion = binary.LittleEndian.Uint32(buf[20:])
+	m.OsSwVersion = binary.LittleEndian.Uint32(buf[24:])
+	m.BoardVersion = binary.LittleEndian.Uint32(buf[28:])
+	m.VendorId = binary.LittleEndian.Uint16(buf[32:])
+	m.ProductId = binary.LittleEndian.Uint16(buf[34:])
+	copy(m.FlightCustomVersion[:], buf[36:44])
+	copy(m.MiddlewareCustomVersion[:], buf[44:52])
+	copy(m.OsCustomVersion[:], buf[52:60])
+	if len(buf) < 78 {
+		return
+	}
+	copy(m.Uid2[:], buf[60:78])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageLandingTarget) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.TimeUsec)
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.AngleX))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.AngleY))
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.Distance))
+	binary.LittleEndian.PutUint32(buf[20:], math.Float32bits(m.SizeX))
+	binary.LittleEndian.PutUint32(buf[24:], math.Float32bits(m.SizeY))
+	buf[28] = m.TargetNum
+	buf[29] = uint8(m.Frame)
+	if len(buf) < 60 {
+		return
+	}
+	binary.LittleEndian.PutUint32(buf[30:], math.Float32bits(m.X))
+	binary.LittleEndian.PutUint32(buf[34:], math.Float32bits(m.Y))
+	binary.LittleEndian.PutUint32(buf[38:], math.Float32bits(m.Z))
+	for i, v := range m.Q {
+		binary.LittleEndian.PutUint32(buf[42+i*4:], math.Float32bits(v))
+	}
+	buf[58] = uint8(m.Type)
+	buf[59] = m.PositionValid
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageLandingTarget) UnmarshalPayload(buf []byte) {
+	m.TimeUsec = binary.LittleEndian.Uint64(buf[0:])
+	m.AngleX = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.AngleY = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.Distance = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	m.SizeX = math.Float32frombits(binary.LittleEndian.Uint32(buf[20:]))
+	m.SizeY = math.Float32frombits(binary.LittleEndian.Uint32(buf[24:]))
+	m.TargetNum = buf[28]
+	m.Frame = MAV_FRAME(buf[29])
+	if len(buf) < 60 {
+		return
+	}
+	m.X = math.Float32frombits(binary.LittleEndian.Uint32(buf[30:]))
+	m.Y = math.Float32frombits(binary.LittleEndian.Uint32(buf[34:]))
+	m.Z = math.Float32frombits(binary.LittleEndian.Uint32(buf[38:]))
+	for i := range m.Q {
+		m.Q[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[42+i*4:]))
+	}
+	m.Type = LANDING_TARGET_TYPE(buf[58])
+	m.PositionValid = buf[59]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageFenceStatus) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], m.BreachTime)
+	binary.LittleEndian.PutUint16(buf[4:], m.BreachCount)
+	buf[6] = m.BreachStatus
+	buf[7] = uint8(m.BreachType)
+	if len(buf) < 9 {
+		return
+	}
+	buf[8] = uint8(m.BreachMitigation)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageFenceStatus) UnmarshalPayload(buf []byte) {
+	m.BreachTime = binary.LittleEndian.Uint32(buf[0:])
+	m.BreachCount = binary.LittleEndian.Uint16(buf[4:])
+	m.BreachStatus = buf[6]
+	m.BreachType = FENCE_BREACH(buf[7])
+	if len(buf) < 9 {
+		return
+	}
+	m.BreachMitigation = FENCE_MITIGATE(buf[8])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageMagCalReport) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], math.Float32bits(m.Fitness))
+	binary.LittleEndian.PutUint32(buf[4:], math.Float32bits(m.OfsX))
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.OfsY))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.OfsZ))
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.DiagX))
+	binary.LittleEndian.PutUint32(buf[20:], math.Float32bits(m.DiagY))
+	binary.LittleEndian.PutUint32(buf[24:], math.Float32bits(m.DiagZ))
+	binary.LittleEndian.PutUint32(buf[28:], math.Float32bits(m.OffdiagX))
+	binary.LittleEndian.PutUint32(buf[32:], math.Float32bits(m.OffdiagY))
+	binary.LittleEndian.PutUint32(buf[36:], math.Float32bits(m.OffdiagZ))
+	buf[40] = m.CompassId
+	buf[41] = m.CalMask
+	buf[42] = uint8(m.CalStatus)
+	buf[43] = m.Autosaved
+	if len(buf) < 54 {
+		return
+	}
+	binary.LittleEndian.PutUint32(buf[44:], math.Float32bits(m.OrientationConfidence))
+	buf[48] = uint8(m.OldOrientation)
+	buf[49] = uint8(m.NewOrientation)
+	binary.LittleEndian.PutUint32(buf[50:], math.Float32bits(m.ScaleFactor))
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageMagCalReport) UnmarshalPayload(buf []byte) {
+	m.Fitness = math.Float32frombits(binary.LittleEndian.Uint32(buf[0:]))
+	m.OfsX = math.Float32frombits(binary.LittleEndian.Uint32(buf[4:]))
+	m.OfsY = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.OfsZ = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.DiagX = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	m.DiagY = math.Float32frombits(binary.LittleEndian.Uint32(buf[20:]))
+	m.DiagZ = math.Float32frombits(binary.LittleEndian.Uint32(buf[24:]))
+	m.OffdiagX = math.Float32frombits(binary.LittleEndian.Uint32(buf[28:]))
+	m.OffdiagY = math.Float32frombits(binary.LittleEndian.Uint32(buf[32:]))
+	m.OffdiagZ = math.Float32frombits(binary.LittleEndian.Uint32(buf[36:]))
+	m.CompassId = buf[40]
+	m.CalMask = buf[41]
+	m.CalStatus = MAG_CAL_STATUS(buf[42])
+	m.Autosaved = buf[43]
+	if len(buf) < 54 {
+		return
+	}
+	m.OrientationConfidence = math.Float32frombits(binary.LittleEndian.Uint32(buf[44:]))
+	m.OldOrientation = MAV_SENSOR_ORIENTATION(buf[48])
+	m.NewOrientation = MAV_SENSOR_ORIENTATION(buf[49])
+	m.ScaleFactor = math.Float32frombits(binary.LittleEndian.Uint32(buf[50:]))
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageEfiStatus) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], math.Float32bits(m.EcuIndex))
+	binary.LittleEndian.PutUint32(buf[4:], math.Float32bits(m.Rpm))
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.FuelConsumed))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.FuelFlow))
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.EngineLoad))
+	binary.LittleEndian.PutUint32(buf[20:], math.Float32bits(m.ThrottlePosition))
+	binary.LittleEndian.PutUint32(buf[24:], math.Float32bits(m.SparkDwellTime))
+	binary.LittleEndian.PutUint32(buf[28:], math.Float32bits(m.BarometricPressure))
+	binary.LittleEndian.PutUint32(buf[32:], math.Float32bits(m.IntakeManifoldPressure))
+	binary.LittleEndian.PutUint32(buf[36:], math.Float32bits(m.IntakeManifoldTemperature))
+	binary.LittleEndian.PutUint32(buf[40:], math.Float32bits(m.CylinderHeadTemperature))
+	binary.LittleEndian.PutUint32(buf[44:], math.Float32bits(m.IgnitionTiming))
+	binary.LittleEndian.PutUint32(buf[48:], math.Float32bits(m.InjectionTime))
+	binary.LittleEndian.PutUint32(buf[52:], math.Float32bits(m.ExhaustGasTemperature))
+	binary.LittleEndian.PutUint32(buf[56:], math.Float32bits(m.ThrottleOut))
+	binary.LittleEndian.PutUint32(buf[60:], math.Float32bits(m.PtCompensation))
+	buf[64] = m.Health
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageEfiStatus) UnmarshalPayload(buf []byte) {
+	m.EcuIndex = math.Float32frombits(binary.LittleEndian.Uint32(buf[0:]))
+	m.Rpm = math.Float32frombits(binary.LittleEndian.Uint32(buf[4:]))
+	m.FuelConsumed = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.FuelFlow = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.EngineLoad = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	m.ThrottlePosition = math.Float32frombits(binary.LittleEndian.Uint32(buf[20:]))
+	m.SparkDwellTime = math.Float32frombits(binary.LittleEndian.Uint32(buf[24:]))
+	m.BarometricPressure = math.Float32frombits(binary.LittleEndian.Uint32(buf[28:]))
+	m.IntakeManifoldPressure = math.Float32frombits(binary.LittleEndian.Uint32(buf[32:]))
+	m.IntakeManifoldTemperature = math.Float32frombits(binary.LittleEndian.Uint32(buf[36:]))
+	m.CylinderHeadTemperature = math.Float32frombits(binary.LittleEndian.Uint32(buf[40:]))
+	m.IgnitionTiming = math.Float32frombits(binary.LittleEndian.Uint32(buf[44:]))
+	m.InjectionTime = math.Float32frombits(binary.LittleEndian.Uint32(buf[48:]))
+	m.ExhaustGasTemperature = math.Float32frombits(binary.LittleEndian.Uint32(buf[52:]))
+	m.ThrottleOut = math.Float32frombits(binary.LittleEndian.Uint32(buf[56:]))
+	m.PtCompensation = math.Float32frombits(binary.LittleEndian.Uint32(buf[60:]))
+	m.Health = buf[64]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageEstimatorStatus) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.TimeUsec)
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.VelRatio))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.PosHorizRatio))
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.PosVertRatio))
+	binary.LittleEndian.PutUint32(buf[20:], math.Float32bits(m.MagRatio))
+	binary.LittleEndian.PutUint32(buf[24:], math.Float32bits(m.HaglRatio))
+	binary.LittleEndian.PutUint32(buf[28:], math.Float32bits(m.TasRatio))
+	binary.LittleEndian.PutUint32(buf[32:], math.Float32bits(m.PosHorizAccuracy))
+	binary.LittleEndian.PutUint32(buf[36:], math.Float32bits(m.PosVertAccuracy))
+	binary.LittleEndian.PutUint16(buf[40:], uint16(m.Flags))
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageEstimatorStatus) UnmarshalPayload(buf []byte) {
+	m.TimeUsec = binary.LittleEndian.Uint64(buf[0:])
+	m.VelRatio = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.PosHorizRatio = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.PosVertRatio = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	m.MagRatio = math.Float32frombits(binary.LittleEndian.Uint32(buf[20:]))
+	m.HaglRatio = math.Float32frombits(binary.LittleEndian.Uint32(buf[24:]))
+	m.TasRatio = math.Float32frombits(binary.LittleEndian.Uint32(buf[28:]))
+	m.PosHorizAccuracy = math.Float32frombits(binary.LittleEndian.Uint32(buf[32:]))
+	m.PosVertAccuracy = math.Float32frombits(binary.LittleEndian.Uint32(buf[36:]))
+	m.Flags = ESTIMATOR_STATUS_FLAGS(binary.LittleEndian.Uint16(buf[40:]))
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageWindCov) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.TimeUsec)
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.WindX))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.WindY))
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.WindZ))
+	binary.LittleEndian.PutUint32(buf[20:], math.Float32bits(m.VarHoriz))
+	binary.LittleEndian.PutUint32(buf[24:], math.Float32bits(m.VarVert))
+	binary.LittleEndian.PutUint32(buf[28:], math.Float32bits(m.WindAlt))
+	binary.LittleEndian.PutUint32(buf[32:], math.Float32bits(m.HorizAccuracy))
+	binary.LittleEndian.PutUint32(buf[36:], math.Float32bits(m.VertAccuracy))
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageWindCov) UnmarshalPayload(buf []byte) {
+	m.TimeUsec = binary.LittleEndian.Uint64(buf[0:])
+	m.WindX = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.WindY = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.WindZ = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	m.VarHoriz = math.Float32frombits(binary.LittleEndian.Uint32(buf[20:]))
+	m.VarVert = math.Float32frombits(binary.LittleEndian.Uint32(buf[24:]))
+	m.WindAlt = math.Float32frombits(binary.LittleEndian.Uint32(buf[28:]))
+	m.HorizAccuracy = math.Float32frombits(binary.LittleEndian.Uint32(buf[32:]))
+	m.VertAccuracy = math.Float32frombits(binary.LittleEndian.Uint32(buf[36:]))
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageGpsInput) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.TimeUsec)
+	binary.LittleEndian.PutUint32(buf[8:], m.TimeWeekMs)
+	binary.LittleEndian.PutUint32(buf[12:], uint32(m.Lat))
+	binary.LittleEndian.PutUint32(buf[16:], uint32(m.Lon))
+	binary.LittleEndian.PutUint32(buf[20:], math.Float32bits(m.Alt))
+	binary.LittleEndian.PutUint32(buf[24:], math.Float32bits(m.Hdop))
+	binary.LittleEndian.PutUint32(buf[28:], math.Float32bits(m.Vdop))
+	binary.LittleEndian.PutUint32(buf[32:], math.Float32bits(m.Vn))
+	binary.LittleEndian.PutUint32(buf[36:], math.Float32bits(m.Ve))
+	binary.LittleEndian.PutUint32(buf[40:], math.Float32bits(m.Vd))
+	binary.LittleEndian.PutUint32(buf[44:], math.Float32bits(m.SpeedAccuracy))
+	binary.LittleEndian.PutUint32(buf[48:], math.Float32bits(m.HorizAccuracy))
+	binary.LittleEndian.PutUint32(buf[52:], math.Float32bits(m.VertAccuracy))
+	binary.LittleEndian.PutUint16(buf[56:], uint16(m.IgnoreFlags))
+	binary.LittleEndian.PutUint16(buf[58:], m.TimeWeek)
+	buf[60] = m.GpsId
+	buf[61] = m.FixType
+	buf[62] = m.SatellitesVisible
+	if len(buf) < 65 {
+		return
+	}
+	binary.LittleEndian.PutUint16(buf[63:], m.Yaw)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageGpsInput) UnmarshalPayload(buf []byte) {
+	m.TimeUsec = binary.LittleEndian.Uint64(buf[0:])
+	m.TimeWeekMs = binary.LittleEndian.Uint32(buf[8:])
+	m.Lat = int32(binary.LittleEndian.Uint32(buf[12:]))
+	m.Lon = int32(binary.LittleEndian.Uint32(buf[16:]))
+	m.Alt = math.Float32frombits(binary.LittleEndian.Uint32(buf[20:]))
+	m.Hdop = math.Float32frombits(binary.LittleEndian.Uint32(buf[24:]))
+	m.Vdop = math.Float32frombits(binary.LittleEndian.Uint32(buf[28:]))
+	m.Vn = math.Float32frombits(binary.LittleEndian.Uint32(buf[32:]))
+	m.Ve = math.Float32frombits(binary.LittleEndian.Uint32(buf[36:]))
+	m.Vd = math.Float32frombits(binary.LittleEndian.Uint32(buf[40:]))
+	m.SpeedAccuracy = math.Float32frombits(binary.LittleEndian.Uint32(buf[44:]))
+	m.HorizAccuracy = math.Float32frombits(binary.LittleEndian.Uint32(buf[48:]))
+	m.VertAccuracy = math.Float32frombits(binary.LittleEndian.Uint32(buf[52:]))
+	m.IgnoreFlags = GPS_INPUT_IGNORE_FLAGS(binary.LittleEndian.Uint16(buf[56:]))
+	m.TimeWeek = binary.LittleEndian.Uint16(buf[58:])
+	m.GpsId = buf[60]
+	m.FixType = buf[61]
+	m.SatellitesVisible = buf[62]
+	if len(buf) < 65 {
+		return
+	}
+	m.Yaw = binary.LittleEndian.Uint16(buf[63:])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageGpsRtcmData) MarshalPayload(buf []byte) {
+	buf[0] = m.Flags
+	buf[1] = m.Len
+	copy(buf[2:182], m.Data[:])
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageGpsRtcmData) UnmarshalPayload(buf []byte) {
+	m.Flags = buf[0]
+	m.Len = buf[1]
+	copy(m.Data[:], buf[2:182])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageHighLatency) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], m.CustomMode)
+	binary.LittleEndian.PutUint32(buf[4:], uint32(m.Latitude))
+	binary.LittleEndian.PutUint32(buf[8:], uint32(m.Longitude))
+	binary.LittleEndian.PutUint16(buf[12:], uint16(m.Roll))
+	binary.LittleEndian.PutUint16(buf[14:], uint16(m.Pitch))
+	binary.LittleEndian.PutUint16(buf[16:], m.Heading)
+	binary.LittleEndian.PutUint16(buf[18:], uint16(m.HeadingSp))
+	binary.LittleEndian.PutUint16(buf[20:], uint16(m.AltitudeAmsl))
+	binary.LittleEndian.PutUint16(buf[22:], uint16(m.AltitudeSp))
+	binary.LittleEndian.PutUint16(buf[24:], m.WpDistance)
+	buf[26] = uint8(m.BaseMode)
+	buf[27] = uint8(m.LandedState)
+	buf[28] = uint8(m.Throttle)
+	buf[29] = m.Airspeed
+	buf[30] = m.AirspeedSp
+	buf[31] = m.Groundspeed
+	buf[32] = uint8(m.ClimbRate)
+	buf[33] = m.GpsNsat
+	buf[34] = uint8(m.GpsFixType)
+	buf[35] = m.BatteryRemaining
+	buf[36] = uint8(m.Temperature)
+	buf[37] = uint8(m.TemperatureAir)
+	buf[38] = m.Failsafe
+	buf[39] = m.WpNum
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageHighLatency) UnmarshalPayload(buf []byte) {
+	m.CustomMode = binary.LittleEndian.Uint32(buf[0:])
+	m.Latitude = int32(binary.LittleEndian.Uint32(buf[4:]))
+	m.Longitude = int32(binary.LittleEndian.Uint32(buf[8:]))
+	m.Roll = int16(binary.LittleEndian.Uint16(buf[12:]))
+	m.Pitch = int16(binary.LittleEndian.Uint16(buf[14:]))
+	m.Heading = binary.LittleEndian.Uint16(buf[16:])
+	m.HeadingSp = int16(binary.LittleEndian.Uint16(buf[18:]))
+	m.AltitudeAmsl = int16(binary.LittleEndian.Uint16(buf[20:]))
+	m.AltitudeSp = int16(binary.LittleEndian.Uint16(buf[22:]))
+	m.WpDistance = binary.LittleEndian.Uint16(buf[24:])
+	m.BaseMode = MAV_MODE_FLAG(buf[26])
+	m.LandedState = MAV_LANDED_STATE(buf[27])
+	m.Throttle = int8(buf[28])
+	m.Airspeed = buf[29]
+	m.AirspeedSp = buf[30]
+	m.Groundspeed = buf[31]
+	m.ClimbRate = int8(buf[32])
+	m.GpsNsat = buf[33]
+	m.GpsFixType = GPS_FIX_TYPE(buf[34])
+	m.BatteryRemaining = buf[35]
+	m.Temperature = int8(buf[36])
+	m.TemperatureAir = int8(buf[37])
+	m.Failsafe = buf[38]
+	m.WpNum = buf[39]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageHighLatency2) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], m.Timestamp)
+	binary.LittleEndian.PutUint32(buf[4:], uint32(m.Latitude))
+	binary.LittleEndian.PutUint32(buf[8:], uint32(m.Longitude))
+	binary.LittleEndian.PutUint16(buf[12:], m.CustomMode)
+	binary.LittleEndian.PutUint16(buf[14:], uint16(m.Altitude))
+	binary.LittleEndian.PutUint16(buf[16:], uint16(m.TargetAltitude))
+	binary.LittleEndian.PutUint16(buf[18:], m.TargetDistance)
+	binary.LittleEndian.PutUint16(buf[20:], m.WpNum)
+	binary.LittleEndian.PutUint16(buf[22:], uint16(m.FailureFlags))
+	buf[24] = uint8(m.Type)
+	buf[25] = uint8(m.Autopilot)
+	buf[26] = m.Heading
+	buf[27] = m.TargetHeading
+	buf[28] = m.Throttle
+	buf[29] = m.Airspeed
+	buf[30] = m.AirspeedSp
+	buf[31] = m.Groundspeed
+	buf[32] = m.Windspeed
+	buf[33] = m.WindHeading
+	buf[34] = m.Eph
+	buf[35] = m.Epv
+	buf[36] = uint8(m.TemperatureAir)
+	buf[37] = uint8(m.ClimbRate)
+	buf[38] = uint8(m.Battery)
+	buf[39] = uint8(m.Custom0)
+	buf[40] = uint8(m.Custom1)
+	buf[41] = uint8(m.Custom2)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageHighLatency2) UnmarshalPayload(buf []byte) {
+	m.Timestamp = binary.LittleEndian.Uint32(buf[0:])
+	m.Latitude = int32(binary.LittleEndian.Uint32(buf[4:]))
+	m.Longitude = int32(binary.LittleEndian.Uint32(buf[8:]))
+	m.CustomMode = binary.LittleEndian.Uint16(buf[12:])
+	m.Altitude = int16(binary.LittleEndian.Uint16(buf[14:]))
+	m.TargetAltitude = int16(binary.LittleEndian.Uint16(buf[16:]))
+	m.TargetDistance = binary.LittleEndian.Uint16(buf[18:])
+	m.WpNum = binary.LittleEndian.Uint16(buf[20:])
+	m.FailureFlags = HL_FAILURE_FLAG(binary.LittleEndian.Uint16(buf[22:]))
+	m.Type = MAV_TYPE(buf[24])
+	m.Autopilot = MAV_AUTOPILOT(buf[25])
+	m.Heading = buf[26]
+	m.TargetHeading = buf[27]
+	m.Throttle = buf[28]
+	m.Airspeed = buf[29]
+	m.AirspeedSp = buf[30]
+	m.Groundspeed = buf[31]
+	m.Windspeed = buf[32]
+	m.WindHeading = buf[33]
+	m.Eph = buf[34]
+	m.Epv = buf[35]
+	m.TemperatureAir = int8(buf[36])
+	m.ClimbRate = int8(buf[37])
+	m.Battery = int8(buf[38])
+	m.Custom0 = int8(buf[39])
+	m.Custom1 = int8(buf[40])
+	m.Custom2 = int8(buf[41])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageVibration) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.TimeUsec)
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.VibrationX))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.VibrationY))
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.VibrationZ))
+	binary.LittleEndian.PutUint32(buf[20:], m.Clipping_0)
+	binary.LittleEndian.PutUint32(buf[24:], m.Clipping_1)
+	binary.LittleEndian.PutUint32(buf[28:], m.Clipping_2)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageVibration) UnmarshalPayload(buf []byte) {
+	m.TimeUsec = binary.LittleEndian.Uint64(buf[0:])
+	m.VibrationX = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.VibrationY = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.VibrationZ = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	m.Clipping_0 = binary.LittleEndian.Uint32(buf[20:])
+	m.Clipping_1 = binary.LittleEndian.Uint32(buf[24:])
+	m.Clipping_2 = binary.LittleEndian.Uint32(buf[28:])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageHomePosition) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], uint32(m.Latitude))
+	binary.LittleEndian.PutUint32(buf[4:], uint32(m.Longitude))
+	binary.LittleEndian.PutUint32(buf[8:], uint32(m.Altitude))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.X))
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.Y))
+	binary.LittleEndian.PutUint32(buf[20:], math.Float32bits(m.Z))
+	for i, v := range m.Q {
+		binary.LittleEndian.PutUint32(buf[24+i*4:], math.Float32bits(v))
+	}
+	binary.LittleEndian.PutUint32(buf[40:], math.Float32bits(m.ApproachX))
+	binary.LittleEndian.PutUint32(buf[44:], math.Float32bits(m.ApproachY))
+	binary.LittleEndian.PutUint32(buf[48:], math.Float32bits(m.ApproachZ))
+	if len(buf) < 60 {
+		return
+	}
+	binary.LittleEndian.PutUint64(buf[52:], m.TimeUsec)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageHomePosition) UnmarshalPayload(buf []byte) {
+	m.Latitude = int32(binary.LittleEndian.Uint32(buf[0:]))
+	m.Longitude = int32(binary.LittleEndian.Uint32(buf[4:]))
+	m.Altitude = int32(binary.LittleEndian.Uint32(buf[8:]))
+	m.X = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.Y = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	m.Z = math.Float32frombits(binary.LittleEndian.Uint32(buf[20:]))
+	for i := range m.Q {
+		m.Q[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[24+i*4:]))
+	}
+	m.ApproachX = math.Float32frombits(binary.LittleEndian.Uint32(buf[40:]))
+	m.ApproachY = math.Float32frombits(binary.LittleEndian.Uint32(buf[44:]))
+	m.ApproachZ = math.Float32frombits(binary.LittleEndian.Uint32(buf[48:]))
+	if len(buf) < 60 {
+		return
+	}
+	m.TimeUsec = binary.LittleEndian.Uint64(buf[52:])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageSetHomePosition) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], uint32(m.Latitude))
+	binary.LittleEndian.PutUint32(buf[4:], uint32(m.Longitude))
+	binary.LittleEndian.PutUint32(buf[8:], uint32(m.Altitude))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.X))
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.Y))
+	binary.LittleEndian.PutUint32(buf[20:], math.Float32bits(m.Z))
+	for i, v := range m.Q {
+		binary.LittleEndian.PutUint32(buf[24+i*4:], math.Float32bits(v))
+	}
+	binary.LittleEndian.PutUint32(buf[40:], math.Float32bits(m.ApproachX))
+	binary.LittleEndian.PutUint32(buf[44:], math.Float32bits(m.ApproachY))
+	binary.LittleEndian.PutUint32(buf[48:], math.Float32bits(m.ApproachZ))
+	buf[52] = m.TargetSystem
+	if len(buf) < 61 {
+		return
+	}
+	binary.LittleEndian.PutUint64(buf[53:], m.TimeUsec)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageSetHomePosition) UnmarshalPayload(buf []byte) {
+	m.Latitude = int32(binary.LittleEndian.Uint32(buf[0:]))
+	m.Longitude = int32(binary.LittleEndian.Uint32(buf[4:]))
+	m.Altitude = int32(binary.LittleEndian.Uint32(buf[8:]))
+	m.X = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.Y = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	m.Z = math.Float32frombits(binary.LittleEndian.Uint32(buf[20:]))
+	for i := range m.Q {
+		m.Q[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[24+i*4:]))
+	}
+	m.ApproachX = math.Float32frombits(binary.LittleEndian.Uint32(buf[40:]))
+	m.ApproachY = math.Float32frombits(binary.LittleEndian.Uint32(buf[44:]))
+	m.ApproachZ = math.Float32frombits(binary.LittleEndian.Uint32(buf[48:]))
+	m.TargetSystem = buf[52]
+	if len(buf) < 61 {
+		return
+	}
+	m.TimeUsec = binary.LittleEndian.Uint64(buf[53:])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageMessageInterval) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], uint32(m.IntervalUs))
+	binary.LittleEndian.PutUint16(buf[4:], m.MessageId)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageMessageInterval) UnmarshalPayload(buf []byte) {
+	m.IntervalUs = int32(binary.LittleEndian.Uint32(buf[0:]))
+	m.MessageId = binary.LittleEndian.Uint16(buf[4:])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageExtendedSysState) MarshalPayload(buf []byte) {
+	buf[0] = uint8(m.VtolState)
+	buf[1] = uint8(m.LandedState)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageExtendedSysState) UnmarshalPayload(buf []byte) {
+	m.VtolState = MAV_VTOL_STATE(buf[0])
+	m.LandedState = MAV_LANDED_STATE(buf[1])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageAdsbVehicle) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], m.IcaoAddress)
+	binary.LittleEndian.PutUint32(buf[4:], uint32(m.Lat))
+	binary.LittleEndian.PutUint32(buf[8:], uint32(m.Lon))
+	binary.LittleEndian.PutUint32(buf[12:], uint32(m.Altitude))
+	binary.LittleEndian.PutUint16(buf[16:], m.Heading)
+	binary.LittleEndian.PutUint16(buf[18:], m.HorVelocity)
+	binary.LittleEndian.PutUint16(buf[20:], uint16(m.VerVelocity))
+	binary.LittleEndian.PutUint16(buf[22:], uint16(m.Flags))
+	binary.LittleEndian.PutUint16(buf[24:], m.Squawk)
+	buf[26] = uint8(m.AltitudeType)
+	copy(buf[27:36], m.Callsign)
+	buf[36] = uint8(m.EmitterType)
+	buf[37] = m.Tslc
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageAdsbVehicle) UnmarshalPayload(buf []byte) {
+	m.IcaoAddress = binary.LittleEndian.Uint32(buf[0:])
+	m.Lat = int32(binary.LittleEndian.Uint32(buf[4:]))
+	m.Lon = int32(binary.LittleEndian.Uint32(buf[8:]))
+	m.Altitude = int32(binary.LittleEndian.Uint32(buf[12:]))
+	m.Heading = binary.LittleEndian.Uint16(buf[16:])
+	m.HorVelocity = binary.LittleEndian.Uint16(buf[18:])
+	m.VerVelocity = int16(binary.LittleEndian.Uint16(buf[20:]))
+	m.Flags = ADSB_FLAGS(binary.LittleEndian.Uint16(buf[22:]))
+	m.Squawk = binary.LittleEndian.Uint16(buf[24:])
+	m.AltitudeType = ADSB_ALTITUDE_TYPE(buf[26])
+	m.Callsign = decodeString(buf[27:36])
+	m.EmitterType = ADSB_EMITTER_TYPE(buf[36])
+	m.Tslc = buf[37]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageCollision) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], m.Id)
+	binary.LittleEndian.PutUint32(buf[4:], math.Float32bits(m.TimeToMinimumDelta))
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.AltitudeMinimumDelta))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.HorizontalMinimumDelta))
+	buf[16] = uint8(m.Src)
+	buf[17] = uint8(m.Action)
+	buf[18] = uint8(m.ThreatLevel)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageCollision) UnmarshalPayload(buf []byte) {
+	m.Id = binary.LittleEndian.Uint32(buf[0:])
+	m.TimeToMinimumDelta = math.Float32frombits(binary.LittleEndian.Uint32(buf[4:]))
+	m.AltitudeMinimumDelta = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.HorizontalMinimumDelta = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.Src = MAV_COLLISION_SRC(buf[16])
+	m.Action = MAV_COLLISION_ACTION(buf[17])
+	m.ThreatLevel = MAV_COLLISION_THREAT_LEVEL(buf[18])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageV2Extension) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint16(buf[0:], m.MessageType)
+	buf[2] = m.TargetNetwork
+	buf[3] = m.TargetSystem
+	buf[4] = m.TargetComponent
+	copy(buf[5:254], m.Payload[:])
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageV2Extension) UnmarshalPayload(buf []byte) {
+	m.MessageType = binary.LittleEndian.Uint16(buf[0:])
+	m.TargetNetwork = buf[2]
+	m.TargetSystem = buf[3]
+	m.TargetComponent = buf[4]
+	copy(m.Payload[:], buf[5:254])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageMemoryVect) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint16(buf[0:], m.Address)
+	buf[2] = m.Ver
+	buf[3] = m.Type
+	for i, v := range m.Value {
+		buf[4+i] = uint8(v)
+	}
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageMemoryVect) UnmarshalPayload(buf []byte) {
+	m.Address = binary.LittleEndian.Uint16(buf[0:])
+	m.Ver = buf[2]
+	m.Type = buf[3]
+	for i := range m.Value {
+		m.Value[i] = int8(buf[4+i])
+	}
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageDebugVect) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.TimeUsec)
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.X))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.Y))
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.Z))
+	copy(buf[20:30], m.Name)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageDebugVect) UnmarshalPayload(buf []byte) {
+	m.TimeUsec = binary.LittleEndian.Uint64(buf[0:])
+	m.X = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.Y = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.Z = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	m.Name = decodeString(buf[20:30])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageNamedValueFloat) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], m.TimeBootMs)
+	binary.LittleEndian.PutUint32(buf[4:], math.Float32bits(m.Value))
+	copy(buf[8:18], m.Name)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageNamedValueFloat) UnmarshalPayload(buf []byte) {
+	m.TimeBootMs = binary.LittleEndian.Uint32(buf[0:])
+	m.Value = math.Float32frombits(binary.LittleEndian.Uint32(buf[4:]))
+	m.Name = decodeString(buf[8:18])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageNamedValueInt) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], m.TimeBootMs)
+	binary.LittleEndian.PutUint32(buf[4:], uint32(m.Value))
+	copy(buf[8:18], m.Name)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageNamedValueInt) UnmarshalPayload(buf []byte) {
+	m.TimeBootMs = binary.LittleEndian.Uint32(buf[0:])
+	m.Value = int32(binary.LittleEndian.Uint32(buf[4:]))
+	m.Name = decodeString(buf[8:18])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageStatustext) MarshalPayload(buf []byte) {
+	buf[0] = uint8(m.Severity)
+	copy(buf[1:51], m.Text)
+	if len(buf) < 54 {
+		return
+	}
+	binary.LittleEndian.PutUint16(buf[51:], m.Id)
+	buf[53] = m.ChunkSeq
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageStatustext) UnmarshalPayload(buf []byte) {
+	m.Severity = MAV_SEVERITY(buf[0])
+	m.Text = decodeString(buf[1:51])
+	if len(buf) < 54 {
+		return
+	}
+	m.Id = binary.LittleEndian.Uint16(buf[51:])
+	m.ChunkSeq = buf[53]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageDebug) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], m.TimeBootMs)
+	binary.LittleEndian.PutUint32(buf[4:], math.Float32bits(m.Value))
+	buf[8] = m.Ind
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageDebug) UnmarshalPayload(buf []byte) {
+	m.TimeBootMs = binary.LittleEndian.Uint32(buf[0:])
+	m.Value = math.Float32frombits(binary.LittleEndian.Uint32(buf[4:]))
+	m.Ind = buf[8]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageSetupSigning) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.InitialTimestamp)
+	buf[8] = m.TargetSystem
+	buf[9] = m.TargetComponent
+	copy(buf[10:42], m.SecretKey[:])
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageSetupSigning) UnmarshalPayload(buf []byte) {
+	m.InitialTimestamp = binary.LittleEndian.Uint64(buf[0:])
+	m.TargetSystem = buf[8]
+	m.TargetComponent = buf[9]
+	copy(m.SecretKey[:], buf[10:42])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageButtonChange) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], m.TimeBootMs)
+	binary.LittleEndian.PutUint32(buf[4:], m.LastChangeMs)
+	buf[8] = m.State
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageButtonChange) UnmarshalPayload(buf []byte) {
+	m.TimeBootMs = binary.LittleEndian.Uint32(buf[0:])
+	m.LastChangeMs = binary.LittleEndian.Uint32(buf[4:])
+	m.State = buf[8]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessagePlayTune) MarshalPayload(buf []byte) {
+	buf[0] = m.TargetSystem
+	buf[1] = m.TargetComponent
+	copy(buf[2:32], m.Tune)
+	if len(buf) < 232 {
+		return
+	}
+	copy(buf[32:232], m.Tune2)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessagePlayTune) UnmarshalPayload(buf []byte) {
+	m.TargetSystem = buf[0]
+	m.TargetComponent = buf[1]
+	m.Tune = decodeString(buf[2:32])
+	if len(buf) < 232 {
+		return
+	}
+	m.Tune2 = decodeString(buf[32:232])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageCameraInformation) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], m.TimeBootMs)
+	binary.LittleEndian.PutUint32(buf[4:], m.FirmwareVersion)
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.FocalLength))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.SensorSizeH))
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.SensorSizeV))
+	binary.LittleEndian.PutUint32(buf[20:], uint32(m.Flags))
+	binary.LittleEndian.PutUint16(buf[24:], m.ResolutionH)
+	binary.LittleEndian.PutUint16(buf[26:], m.ResolutionV)
+	binary.LittleEndian.PutUint16(buf[28:], m.CamDefinitionVersion)
+	copy(buf[30:62], m.VendorName[:])
+	copy(buf[62:94], m.ModelName[:])
+	buf[94] = m.LensId
+	copy(buf[95:235], m.CamDefinitionUri)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageCameraInformation) UnmarshalPayload(buf []byte) {
+	m.TimeBootMs = binary.LittleEndian.Uint32(buf[0:])
+	m.FirmwareVersion = binary.LittleEndian.Uint32(buf[4:])
+	m.FocalLength = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.SensorSizeH = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.SensorSizeV = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	m.Flags = CAMERA_CAP_FLAGS(binary.LittleEndian.Uint32(buf[20:]))
+	m.ResolutionH = binary.LittleEndian.Uint16(buf[24:])
+	m.ResolutionV = binary.LittleEndian.Uint16(buf[26:])
+	m.CamDefinitionVersion = binary.LittleEndian.Uint16(buf[28:])
+	copy(m.VendorName[:], buf[30:62])
+	copy(m.ModelName[:], buf[62:94])
+	m.LensId = buf[94]
+	m.CamDefinitionUri = decodeString(buf[95:235])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageCameraSettings) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], m.TimeBootMs)
+	buf[4] = uint8(m.ModeId)
+	if len(buf) < 13 {
+		return
+	}
+	binary.LittleEndian.PutUint32(buf[5:], math.Float32bits(m.Zoomlevel))
+	binary.LittleEndian.PutUint32(buf[9:], math.Float32bits(m.Focuslevel))
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageCameraSettings) UnmarshalPayload(buf []byte) {
+	m.TimeBootMs = binary.LittleEndian.Uint32(buf[0:])
+	m.ModeId = CAMERA_MODE(buf[4])
+	if len(buf) < 13 {
+		return
+	}
+	m.Zoomlevel = math.Float32frombits(binary.LittleEndian.Uint32(buf[5:]))
+	m.Focuslevel = math.Float32frombits(binary.LittleEndian.Uint32(buf[9:]))
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageStorageInformation) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], m.TimeBootMs)
+	binary.LittleEndian.PutUint32(buf[4:], math.Float32bits(m.TotalCapacity))
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.UsedCapacity))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.AvailableCapacity))
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.ReadSpeed))
+	binary.LittleEndian.PutUint32(buf[20:], math.Float32bits(m.WriteSpeed))
+	buf[24] = m.StorageId
+	buf[25] = m.StorageCount
+	buf[26] = uint8(m.Status)
+	if len(buf) < 60 {
+		return
+	}
+	buf[27] = uint8(m.Type)
+	copy(buf[28:60], m.Name)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageStorageInformation) UnmarshalPayload(buf []byte) {
+	m.TimeBootMs = binary.LittleEndian.Uint32(buf[0:])
+	m.TotalCapacity = math.Float32frombits(binary.LittleEndian.Uint32(buf[4:]))
+	m.UsedCapacity = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.AvailableCapacity = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.ReadSpeed = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	m.WriteSpeed = math.Float32frombits(binary.LittleEndian.Uint32(buf[20:]))
+	m.StorageId = buf[24]
+	m.StorageCount = buf[25]
+	m.Status = STORAGE_STATUS(buf[26])
+	if len(buf) < 60 {
+		return
+	}
+	m.Type = STORAGE_TYPE(buf[27])
+	m.Name = decodeString(buf[28:60])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageCameraCaptureStatus) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], m.TimeBootMs)
+	binary.LittleEndian.PutUint32(buf[4:], math.Float32bits(m.ImageInterval))
+	binary.LittleEndian.PutUint32(buf[8:], m.RecordingTimeMs)
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.AvailableCapacity))
+	buf[16] = m.ImageStatus
+	buf[17] = m.VideoStatus
+	if len(buf) < 22 {
+		return
+	}
+	binary.LittleEndian.PutUint32(buf[18:], uint32(m.ImageCount))
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageCameraCaptureStatus) UnmarshalPayload(buf []byte) {
+	m.TimeBootMs = binary.LittleEndian.Uint32(buf[0:])
+	m.ImageInterval = math.Float32frombits(binary.LittleEndian.Uint32(buf[4:]))
+	m.RecordingTimeMs = binary.LittleEndian.Uint32(buf[8:])
+	m.AvailableCapacity = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.ImageStatus = buf[16]
+	m.VideoStatus = buf[17]
+	if len(buf) < 22 {
+		return
+	}
+	m.ImageCount = int32(binary.LittleEndian.Uint32(buf[18:]))
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageCameraImageCaptured) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.TimeUtc)
+	binary.LittleEndian.PutUint32(buf[8:], m.TimeBootMs)
+	binary.LittleEndian.PutUint32(buf[12:], uint32(m.Lat))
+	binary.LittleEndian.PutUint32(buf[16:], uint32(m.Lon))
+	binary.LittleEndian.PutUint32(buf[20:], uint32(m.Alt))
+	binary.LittleEndian.PutUint32(buf[24:], uint32(m.RelativeAlt))
+	for i, v := range m.Q {
+		binary.LittleEndian.PutUint32(buf[28+i*4:], math.Float32bits(v))
+	}
+	binary.LittleEndian.PutUint32(buf[44:], uint32(m.ImageIndex))
+	buf[48] = m.CameraId
+	buf[49] = uint8(m.CaptureResult)
+	copy(buf[50:255], m.FileUrl)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageCameraImageCaptured) UnmarshalPayload(buf []byte) {
+	m.TimeUtc = binary.LittleEndian.Uint64(buf[0:])
+	m.TimeBootMs = binary.LittleEndian.Uint32(buf[8:])
+	m.Lat = int32(binary.LittleEndian.Uint32(buf[12:]))
+	m.Lon = int32(binary.LittleEndian.Uint32(buf[16:]))
+	m.Alt = int32(binary.LittleEndian.Uint32(buf[20:]))
+	m.RelativeAlt = int32(binary.LittleEndian.Uint32(buf[24:]))
+	for i := range m.Q {
+		m.Q[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[28+i*4:]))
+	}
+	m.ImageIndex = int32(binary.LittleEndian.Uint32(buf[44:]))
+	m.CameraId = buf[48]
+	m.CaptureResult = int8(buf[49])
+	m.FileUrl = decodeString(buf[50:255])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageFlightInformation) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.ArmingTimeUtc)
+	binary.LittleEndian.PutUint64(buf[8:], m.TakeoffTimeUtc)
+	binary.LittleEndian.PutUint64(buf[16:], m.FlightUuid)
+	binary.LittleEndian.PutUint32(buf[24:], m.TimeBootMs)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageFlightInformation) UnmarshalPayload(buf []byte) {
+	m.ArmingTimeUtc = binary.LittleEndian.Uint64(buf[0:])
+	m.TakeoffTimeUtc = binary.LittleEndian.Uint64(buf[8:])
+	m.FlightUuid = binary.LittleEndian.Uint64(buf[16:])
+	m.TimeBootMs = binary.LittleEndian.Uint32(buf[24:])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageMountOrientation) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], m.TimeBootMs)
+	binary.LittleEndian.PutUint32(buf[4:], math.Float32bits(m.Roll))
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.Pitch))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.Yaw))
+	if len(buf) < 20 {
+		return
+	}
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.YawAbsolute))
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageMountOrientation) UnmarshalPayload(buf []byte) {
+	m.TimeBootMs = binary.LittleEndian.Uint32(buf[0:])
+	m.Roll = math.Float32frombits(binary.LittleEndian.Uint32(buf[4:]))
+	m.Pitch = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.Yaw = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	if len(buf) < 20 {
+		return
+	}
+	m.YawAbsolute = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageLoggingData) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint16(buf[0:], m.Sequence)
+	buf[2] = m.TargetSystem
+	buf[3] = m.TargetComponent
+	buf[4] = m.Length
+	buf[5] = m.FirstMessageOffset
+	copy(buf[6:255], m.Data[:])
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageLoggingData) UnmarshalPayload(buf []byte) {
+	m.Sequence = binary.LittleEndian.Uint16(buf[0:])
+	m.TargetSystem = buf[2]
+	m.TargetComponent = buf[3]
+	m.Length = buf[4]
+	m.FirstMessageOffset = buf[5]
+	copy(m.Data[:], buf[6:255])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageLoggingDataAcked) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint16(buf[0:], m.Sequence)
+	buf[2] = m.TargetSystem
+	buf[3] = m.TargetComponent
+	buf[4] = m.Length
+	buf[5] = m.FirstMessageOffset
+	copy(buf[6:255], m.Data[:])
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageLoggingDataAcked) UnmarshalPayload(buf []byte) {
+	m.Sequence = binary.LittleEndian.Uint16(buf[0:])
+	m.TargetSystem = buf[2]
+	m.TargetComponent = buf[3]
+	m.Length = buf[4]
+	m.FirstMessageOffset = buf[5]
+	copy(m.Data[:], buf[6:255])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageLoggingAck) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint16(buf[0:], m.Sequence)
+	buf[2] = m.TargetSystem
+	buf[3] = m.TargetComponent
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageLoggingAck) UnmarshalPayload(buf []byte) {
+	m.Sequence = binary.LittleEndian.Uint16(buf[0:])
+	m.TargetSystem = buf[2]
+	m.TargetComponent = buf[3]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageVideoStreamInformation) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], math.Float32bits(m.Framerate))
+	binary.LittleEndian.PutUint32(buf[4:], m.Bitrate)
+	binary.LittleEndian.PutUint16(buf[8:], uint16(m.Flags))
+	binary.LittleEndian.PutUint16(buf[10:], m.ResolutionH)
+	binary.LittleEndian.PutUint16(buf[12:], m.ResolutionV)
+	binary.LittleEndian.PutUint16(buf[14:], m.Rotation)
+	binary.LittleEndian.PutUint16(buf[16:], m.Hfov)
+	buf[18] = m.StreamId
+	buf[19] = m.Count
+	buf[20] = uint8(m.Type)
+	copy(buf[21:53], m.Name)
+	copy(buf[53:213], m.Uri)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageVideoStreamInformation) UnmarshalPayload(buf []byte) {
+	m.Framerate = math.Float32frombits(binary.LittleEndian.Uint32(buf[0:]))
+	m.Bitrate = binary.LittleEndian.Uint32(buf[4:])
+	m.Flags = VIDEO_STREAM_STATUS_FLAGS(binary.LittleEndian.Uint16(buf[8:]))
+	m.ResolutionH = binary.LittleEndian.Uint16(buf[10:])
+	m.ResolutionV = binary.LittleEndian.Uint16(buf[12:])
+	m.Rotation = binary.LittleEndian.Uint16(buf[14:])
+	m.Hfov = binary.LittleEndian.Uint16(buf[16:])
+	m.StreamId = buf[18]
+	m.Count = buf[19]
+	m.Type = VIDEO_STREAM_TYPE(buf[20])
+	m.Name = decodeString(buf[21:53])
+	m.Uri = decodeString(buf[53:213])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageVideoStreamStatus) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], math.Float32bits(m.Framerate))
+	binary.LittleEndian.PutUint32(buf[4:], m.Bitrate)
+	binary.LittleEndian.PutUint16(buf[8:], uint16(m.Flags))
+	binary.LittleEndian.PutUint16(buf[10:], m.ResolutionH)
+	binary.LittleEndian.PutUint16(buf[12:], m.ResolutionV)
+	binary.LittleEndian.PutUint16(buf[14:], m.Rotation)
+	binary.LittleEndian.PutUint16(buf[16:], m.Hfov)
+	buf[18] = m.StreamId
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageVideoStreamStatus) UnmarshalPayload(buf []byte) {
+	m.Framerate = math.Float32frombits(binary.LittleEndian.Uint32(buf[0:]))
+	m.Bitrate = binary.LittleEndian.Uint32(buf[4:])
+	m.Flags = VIDEO_STREAM_STATUS_FLAGS(binary.LittleEndian.Uint16(buf[8:]))
+	m.ResolutionH = binary.LittleEndian.Uint16(buf[10:])
+	m.ResolutionV = binary.LittleEndian.Uint16(buf[12:])
+	m.Rotation = binary.LittleEndian.Uint16(buf[14:])
+	m.Hfov = binary.LittleEndian.Uint16(buf[16:])
+	m.StreamId = buf[18]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageCameraFovStatus) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], m.TimeBootMs)
+	binary.LittleEndian.PutUint32(buf[4:], uint32(m.LatCamera))
+	binary.LittleEndian.PutUint32(buf[8:], uint32(m.LonCamera))
+	binary.LittleEndian.PutUint32(buf[12:], uint32(m.AltCamera))
+	binary.LittleEndian.PutUint32(buf[16:], uint32(m.LatImage))
+	binary.LittleEndian.PutUint32(buf[20:], uint32(m.LonImage))
+	binary.LittleEndian.PutUint32(buf[24:], uint32(m.AltImage))
+	for i, v := range m.Q {
+		binary.LittleEndian.PutUint32(buf[28+i*4:], math.Float32bits(v))
+	}
+	binary.LittleEndian.PutUint32(buf[44:], math.Float32bits(m.Hfov))
+	binary.LittleEndian.PutUint32(buf[48:], math.Float32bits(m.Vfov))
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageCameraFovStatus) UnmarshalPayload(buf []byte) {
+	m.TimeBootMs = binary.LittleEndian.Uint32(buf[0:])
+	m.LatCamera = int32(binary.LittleEndian.Uint32(buf[4:]))
+	m.LonCamera = int32(binary.LittleEndian.Uint32(buf[8:]))
+	m.AltCamera = int32(binary.LittleEndian.Uint32(buf[12:]))
+	m.LatImage = int32(binary.LittleEndian.Uint32(buf[16:]))
+	m.LonImage = int32(binary.LittleEndian.Uint32(buf[20:]))
+	m.AltImage = int32(binary.LittleEndian.Uint32(buf[24:]))
+	for i := range m.Q {
+		m.Q[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[28+i*4:]))
+	}
+	m.Hfov = math.Float32frombits(binary.LittleEndian.Uint32(buf[44:]))
+	m.Vfov = math.Float32frombits(binary.LittleEndian.Uint32(buf[48:]))
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageCameraTrackingImageStatus) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], math.Float32bits(m.PointX))
+	binary.LittleEndian.PutUint32(buf[4:], math.Float32bits(m.PointY))
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.Radius))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.RecTopX))
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.RecTopY))
+	binary.LittleEndian.PutUint32(buf[20:], math.Float32bits(m.RecBottomX))
+	binary.LittleEndian.PutUint32(buf[24:], math.Float32bits(m.RecBottomY))
+	buf[28] = uint8(m.TrackingStatus)
+	buf[29] = uint8(m.TrackingMode)
+	buf[30] = uint8(m.TargetData)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageCameraTrackingImageStatus) UnmarshalPayload(buf []byte) {
+	m.PointX = math.Float32frombits(binary.LittleEndian.Uint32(buf[0:]))
+	m.PointY = math.Float32frombits(binary.LittleEndian.Uint32(buf[4:]))
+	m.Radius = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.RecTopX = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.RecTopY = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	m.RecBottomX = math.Float32frombits(binary.LittleEndian.Uint32(buf[20:]))
+	m.RecBottomY = math.Float32frombits(binary.LittleEndian.Uint32(buf[24:]))
+	m.TrackingStatus = CAMERA_TRACKING_STATUS_FLAGS(buf[28])
+	m.TrackingMode = CAMERA_TRACKING_MODE(buf[29])
+	m.TargetData = CAMERA_TRACKING_TARGET_DATA(buf[30])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageCameraTrackingGeoStatus) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], uint32(m.Lat))
+	binary.LittleEndian.PutUint32(buf[4:], uint32(m.Lon))
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.Alt))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.HAcc))
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.VAcc))
+	binary.LittleEndian.PutUint32(buf[20:], math.Float32bits(m.VelN))
+	binary.LittleEndian.PutUint32(buf[24:], math.Float32bits(m.VelE))
+	binary.LittleEndian.PutUint32(buf[28:], math.Float32bits(m.VelD))
+	binary.LittleEndian.PutUint32(buf[32:], math.Float32bits(m.VelAcc))
+	binary.LittleEndian.PutUint32(buf[36:], math.Float32bits(m.Dist))
+	binary.LittleEndian.PutUint32(buf[40:], math.Float32bits(m.Hdg))
+	binary.LittleEndian.PutUint32(buf[44:], math.Float32bits(m.HdgAcc))
+	buf[48] = uint8(m.TrackingStatus)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageCameraTrackingGeoStatus) UnmarshalPayload(buf []byte) {
+	m.Lat = int32(binary.LittleEndian.Uint32(buf[0:]))
+	m.Lon = int32(binary.LittleEndian.Uint32(buf[4:]))
+	m.Alt = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.HAcc = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.VAcc = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	m.VelN = math.Float32frombits(binary.LittleEndian.Uint32(buf[20:]))
+	m.VelE = math.Float32frombits(binary.LittleEndian.Uint32(buf[24:]))
+	m.VelD = math.Float32frombits(binary.LittleEndian.Uint32(buf[28:]))
+	m.VelAcc = math.Float32frombits(binary.LittleEndian.Uint32(buf[32:]))
+	m.Dist = math.Float32frombits(binary.LittleEndian.Uint32(buf[36:]))
+	m.Hdg = math.Float32frombits(binary.LittleEndian.Uint32(buf[40:]))
+	m.HdgAcc = math.Float32frombits(binary.LittleEndian.Uint32(buf[44:]))
+	m.TrackingStatus = CAMERA_TRACKING_STATUS_FLAGS(buf[48])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageGimbalManagerInformation) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], m.TimeBootMs)
+	binary.LittleEndian.PutUint32(buf[4:], uint32(m.CapFlags))
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.RollMin))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.RollMax))
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.PitchMin))
+	binary.LittleEndian.PutUint32(buf[20:], math.Float32bits(m.PitchMax))
+	binary.LittleEndian.PutUint32(buf[24:], math.Float32bits(m.YawMin))
+	binary.LittleEndian.PutUint32(buf[28:], math.Float32bits(m.YawMax))
+	buf[32] = m.GimbalDeviceId
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageGimbalManagerInformation) UnmarshalPayload(buf []byte) {
+	m.TimeBootMs = binary.LittleEndian.Uint32(buf[0:])
+	m.CapFlags = GIMBAL_MANAGER_CAP_FLAGS(binary.LittleEndian.Uint32(buf[4:]))
+	m.RollMin = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.RollMax = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.PitchMin = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	m.PitchMax = math.Float32frombits(binary.LittleEndian.Uint32(buf[20:]))
+	m.YawMin = math.Float32frombits(binary.LittleEndian.Uint32(buf[24:]))
+	m.YawMax = math.Float32frombits(binary.LittleEndian.Uint32(buf[28:]))
+	m.GimbalDeviceId = buf[32]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageGimbalManagerStatus) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], m.TimeBootMs)
+	binary.LittleEndian.PutUint32(buf[4:], uint32(m.Flags))
+	buf[8] = m.GimbalDeviceId
+	buf[9] = m.PrimaryControlSysid
+	buf[10] = m.PrimaryControlCompid
+	buf[11] = m.SecondaryControlSysid
+	buf[12] = m.SecondaryControlCompid
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageGimbalManagerStatus) UnmarshalPayload(buf []byte) {
+	m.TimeBootMs = binary.LittleEndian.Uint32(buf[0:])
+	m.Flags = GIMBAL_MANAGER_FLAGS(binary.LittleEndian.Uint32(buf[4:]))
+	m.GimbalDeviceId = buf[8]
+	m.PrimaryControlSysid = buf[9]
+	m.PrimaryControlCompid = buf[10]
+	m.SecondaryControlSysid = buf[11]
+	m.SecondaryControlCompid = buf[12]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageGimbalManagerSetAttitude) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], uint32(m.Flags))
+	for i, v := range m.Q {
+		binary.LittleEndian.PutUint32(buf[4+i*4:], math.Float32bits(v))
+	}
+	binary.LittleEndian.PutUint32(buf[20:], math.Float32bits(m.AngularVelocityX))
+	binary.LittleEndian.PutUint32(buf[24:], math.Float32bits(m.AngularVelocityY))
+	binary.LittleEndian.PutUint32(buf[28:], math.Float32bits(m.AngularVelocityZ))
+	buf[32] = m.TargetSystem
+	buf[33] = m.TargetComponent
+	buf[34] = m.GimbalDeviceId
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageGimbalManagerSetAttitude) UnmarshalPayload(buf []byte) {
+	m.Flags = GIMBAL_MANAGER_FLAGS(binary.LittleEndian.Uint32(buf[0:]))
+	for i := range m.Q {
+		m.Q[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[4+i*4:]))
+	}
+	m.AngularVelocityX = math.Float32frombits(binary.LittleEndian.Uint32(buf[20:]))
+	m.AngularVelocityY = math.Float32frombits(binary.LittleEndian.Uint32(buf[24:]))
+	m.AngularVelocityZ = math.Float32frombits(binary.LittleEndian.Uint32(buf[28:]))
+	m.TargetSystem = buf[32]
+	m.TargetComponent = buf[33]
+	m.GimbalDeviceId = buf[34]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageGimbalDeviceInformation) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.Uid)
+	binary.LittleEndian.PutUint32(buf[8:], m.TimeBootMs)
+	binary.LittleEndian.PutUint32(buf[12:], m.FirmwareVersion)
+	binary.LittleEndian.PutUint32(buf[16:], m.HardwareVersion)
+	binary.LittleEndian.PutUint32(buf[20:], math.Float32bits(m.RollMin))
+	binary.LittleEndian.PutUint32(buf[24:], math.Float32bits(m.RollMax))
+	binary.LittleEndian.PutUint32(buf[28:], math.Float32bits(m.PitchMin))
+	binary.LittleEndian.PutUint32(buf[32:], math.Float32bits(m.PitchMax))
+	binary.LittleEndian.PutUint32(buf[36:], math.Float32bits(m.YawMin))
+	binary.LittleEndian.PutUint32(buf[40:], math.Float32bits(m.YawMax))
+	binary.LittleEndian.PutUint16(buf[44:], uint16(m.CapFlags))
+	binary.LittleEndian.PutUint16(buf[46:], m.CustomCapFlags)
+	copy(buf[48:80], m.VendorName)
+	copy(buf[80:112], m.ModelName)
+	copy(buf[112:144], m.CustomName)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageGimbalDeviceInformation) UnmarshalPayload(buf []byte) {
+	m.Uid = binary.LittleEndian.Uint64(buf[0:])
+	m.TimeBootMs = binary.LittleEndian.Uint32(buf[8:])
+	m.FirmwareVersion = binary.LittleEndian.Uint32(buf[12:])
+	m.HardwareVersion = binary.LittleEndian.Uint32(buf[16:])
+	m.RollMin = math.Float32frombits(binary.LittleEndian.Uint32(buf[20:]))
+	m.RollMax = math.Float32frombits(binary.LittleEndian.Uint32(buf[24:]))
+	m.PitchMin = math.Float32frombits(binary.LittleEndian.Uint32(buf[28:]))
+	m.PitchMax = math.Float32frombits(binary.LittleEndian.Uint32(buf[32:]))
+	m.YawMin = math.Float32frombits(binary.LittleEndian.Uint32(buf[36:]))
+	m.YawMax = math.Float32frombits(binary.LittleEndian.Uint32(buf[40:]))
+	m.CapFlags = GIMBAL_DEVICE_CAP_FLAGS(binary.LittleEndian.Uint16(buf[44:]))
+	m.CustomCapFlags = binary.LittleEndian.Uint16(buf[46:])
+	m.VendorName = decodeString(buf[48:80])
+	m.ModelName = decodeString(buf[80:112])
+	m.CustomName = decodeString(buf[112:144])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageGimbalDeviceSetAttitude) MarshalPayload(buf []byte) {
+	for i, v := range m.Q {
+		binary.LittleEndian.PutUint32(buf[0+i*4:], math.Float32bits(v))
+	}
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.AngularVelocityX))
+	binary.LittleEndian.PutUint32(buf[20:], math.Float32bits(m.AngularVelocityY))
+	binary.LittleEndian.PutUint32(buf[24:], math.Float32bits(m.AngularVelocityZ))
+	binary.LittleEndian.PutUint16(buf[28:], uint16(m.Flags))
+	buf[30] = m.TargetSystem
+	buf[31] = m.TargetComponent
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageGimbalDeviceSetAttitude) UnmarshalPayload(buf []byte) {
+	for i := range m.Q {
+		m.Q[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[0+i*4:]))
+	}
+	m.AngularVelocityX = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	m.AngularVelocityY = math.Float32frombits(binary.LittleEndian.Uint32(buf[20:]))
+	m.AngularVelocityZ = math.Float32frombits(binary.LittleEndian.Uint32(buf[24:]))
+	m.Flags = GIMBAL_DEVICE_FLAGS(binary.LittleEndian.Uint16(buf[28:]))
+	m.TargetSystem = buf[30]
+	m.TargetComponent = buf[31]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageGimbalDeviceAttitudeStatus) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], m.TimeBootMs)
+	for i, v := range m.Q {
+		binary.LittleEndian.PutUint32(buf[4+i*4:], math.Float32bits(v))
+	}
+	binary.LittleEndian.PutUint32(buf[20:], math.Float32bits(m.AngularVelocityX))
+	binary.LittleEndian.PutUint32(buf[24:], math.Float32bits(m.AngularVelocityY))
+	binary.LittleEndian.PutUint32(buf[28:], math.Float32bits(m.AngularVelocityZ))
+	binary.LittleEndian.PutUint32(buf[32:], uint32(m.FailureFlags))
+	binary.LittleEndian.PutUint16(buf[36:], uint16(m.Flags))
+	buf[38] = m.TargetSystem
+	buf[39] = m.TargetComponent
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageGimbalDeviceAttitudeStatus) UnmarshalPayload(buf []byte) {
+	m.TimeBootMs = binary.LittleEndian.Uint32(buf[0:])
+	for i := range m.Q {
+		m.Q[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[4+i*4:]))
+	}
+	m.AngularVelocityX = math.Float32frombits(binary.LittleEndian.Uint32(buf[20:]))
+	m.AngularVelocityY = math.Float32frombits(binary.LittleEndian.Uint32(buf[24:]))
+	m.AngularVelocityZ = math.Float32frombits(binary.LittleEndian.Uint32(buf[28:]))
+	m.FailureFlags = GIMBAL_DEVICE_ERROR_FLAGS(binary.LittleEndian.Uint32(buf[32:]))
+	m.Flags = GIMBAL_DEVICE_FLAGS(binary.LittleEndian.Uint16(buf[36:]))
+	m.TargetSystem = buf[38]
+	m.TargetComponent = buf[39]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageAutopilotStateForGimbalDevice) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.TimeBootUs)
+	for i, v := range m.Q {
+		binary.LittleEndian.PutUint32(buf[8+i*4:], math.Float32bits(v))
+	}
+	binary.LittleEndian.PutUint32(buf[24:], m.QEstimatedDelayUs)
+	binary.LittleEndian.PutUint32(buf[28:], math.Float32bits(m.Vx))
+	binary.LittleEndian.PutUint32(buf[32:], math.Float32bits(m.Vy))
+	binary.LittleEndian.PutUint32(buf[36:], math.Float32bits(m.Vz))
+	binary.LittleEndian.PutUint32(buf[40:], m.VEstimatedDelayUs)
+	binary.LittleEndian.PutUint32(buf[44:], math.Float32bits(m.FeedForwardAngularVelocityZ))
+	binary.LittleEndian.PutUint16(buf[48:], uint16(m.EstimatorStatus))
+	buf[50] = m.TargetSystem
+	buf[51] = m.TargetComponent
+	buf[52] = uint8(m.LandedState)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageAutopilotStateForGimbalDevice) UnmarshalPayload(buf []byte) {
+	m.TimeBootUs = binary.LittleEndian.Uint64(buf[0:])
+	for i := range m.Q {
+		m.Q[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[8+i*4:]))
+	}
+	m.QEstimatedDelayUs = binary.LittleEndian.Uint32(buf[24:])
+	m.Vx = math.Float32frombits(binary.LittleEndian.Uint32(buf[28:]))
+	m.Vy = math.Float32frombits(binary.LittleEndian.Uint32(buf[32:]))
+	m.Vz = math.Float32frombits(binary.LittleEndian.Uint32(buf[36:]))
+	m.VEstimatedDelayUs = binary.LittleEndian.Uint32(buf[40:])
+	m.FeedForwardAngularVelocityZ = math.Float32frombits(binary.LittleEndian.Uint32(buf[44:]))
+	m.EstimatorStatus = ESTIMATOR_STATUS_FLAGS(binary.LittleEndian.Uint16(buf[48:]))
+	m.TargetSystem = buf[50]
+	m.TargetComponent = buf[51]
+	m.LandedState = MAV_LANDED_STATE(buf[52])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageGimbalManagerSetPitchyaw) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], uint32(m.Flags))
+	binary.LittleEndian.PutUint32(buf[4:], math.Float32bits(m.Pitch))
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.Yaw))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.PitchRate))
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.YawRate))
+	buf[20] = m.TargetSystem
+	buf[21] = m.TargetComponent
+	buf[22] = m.GimbalDeviceId
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageGimbalManagerSetPitchyaw) UnmarshalPayload(buf []byte) {
+	m.Flags = GIMBAL_MANAGER_FLAGS(binary.LittleEndian.Uint32(buf[0:]))
+	m.Pitch = math.Float32frombits(binary.LittleEndian.Uint32(buf[4:]))
+	m.Yaw = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.PitchRate = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.YawRate = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	m.TargetSystem = buf[20]
+	m.TargetComponent = buf[21]
+	m.GimbalDeviceId = buf[22]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageGimbalManagerSetManualControl) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], uint32(m.Flags))
+	binary.LittleEndian.PutUint32(buf[4:], math.Float32bits(m.Pitch))
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.Yaw))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.PitchRate))
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.YawRate))
+	buf[20] = m.TargetSystem
+	buf[21] = m.TargetComponent
+	buf[22] = m.GimbalDeviceId
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageGimbalManagerSetManualControl) UnmarshalPayload(buf []byte) {
+	m.Flags = GIMBAL_MANAGER_FLAGS(binary.LittleEndian.Uint32(buf[0:]))
+	m.Pitch = math.Float32frombits(binary.LittleEndian.Uint32(buf[4:]))
+	m.Yaw = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.PitchRate = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.YawRate = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	m.TargetSystem = buf[20]
+	m.TargetComponent = buf[21]
+	m.GimbalDeviceId = buf[22]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageEscInfo) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.TimeUsec)
+	for i, v := range m.ErrorCount {
+		binary.LittleEndian.PutUint32(buf[8+i*4:], v)
+	}
+	binary.LittleEndian.PutUint16(buf[24:], m.Counter)
+	for i, v := range m.FailureFlags {
+		binary.LittleEndian.PutUint16(buf[26+i*2:], uint16(v))
+	}
+	buf[34] = m.Index
+	buf[35] = m.Count
+	buf[36] = uint8(m.ConnectionType)
+	buf[37] = m.Info
+	copy(buf[38:42], m.Temperature[:])
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageEscInfo) UnmarshalPayload(buf []byte) {
+	m.TimeUsec = binary.LittleEndian.Uint64(buf[0:])
+	for i := range m.ErrorCount {
+		m.ErrorCount[i] = binary.LittleEndian.Uint32(buf[8+i*4:])
+	}
+	m.Counter = binary.LittleEndian.Uint16(buf[24:])
+	for i := range m.FailureFlags {
+		m.FailureFlags[i] = ESC_FAILURE_FLAGS(binary.LittleEndian.Uint16(buf[26+i*2:]))
+	}
+	m.Index = buf[34]
+	m.Count = buf[35]
+	m.ConnectionType = ESC_CONNECTION_TYPE(buf[36])
+	m.Info = buf[37]
+	copy(m.Temperature[:], buf[38:42])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageEscStatus) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.TimeUsec)
+	for i, v := range m.Rpm {
+		binary.LittleEndian.PutUint32(buf[8+i*4:], uint32(v))
+	}
+	for i, v := range m.Voltage {
+		binary.LittleEndian.PutUint32(buf[24+i*4:], math.Float32bits(v))
+	}
+	for i, v := range m.Current {
+		binary.LittleEndian.PutUint32(buf[40+i*4:], math.Float32bits(v))
+	}
+	buf[56] = m.Index
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageEscStatus) UnmarshalPayload(buf []byte) {
+	m.TimeUsec = binary.LittleEndian.Uint64(buf[0:])
+	for i := range m.Rpm {
+		m.Rpm[i] = int32(binary.LittleEndian.Uint32(buf[8+i*4:]))
+	}
+	for i := range m.Voltage {
+		m.Voltage[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[24+i*4:]))
+	}
+	for i := range m.Current {
+		m.Current[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[40+i*4:]))
+	}
+	m.Index = buf[56]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageWifiConfigAp) MarshalPayload(buf []byte) {
+	copy(buf[0:32], m.Ssid)
+	copy(buf[32:96], m.Password)
+	if len(buf) < 98 {
+		return
+	}
+	buf[96] = uint8(int8(m.Mode))
+	buf[97] = uint8(int8(m.Response))
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageWifiConfigAp) UnmarshalPayload(buf []byte) {
+	m.Ssid = decodeString(buf[0:32])
+	m.Password = decodeString(buf[32:96])
+	if len(buf) < 98 {
+		return
+	}
+	m.Mode = WIFI_CONFIG_AP_MODE(buf[96])
+	m.Response = WIFI_CONFIG_AP_RESPONSE(buf[97])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageAisVessel) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], m.Mmsi)
+	binary.LittleEndian.PutUint32(buf[4:], uint32(m.Lat))
+	binary.LittleEndian.PutUint32(buf[8:], uint32(m.Lon))
+	binary.LittleEndian.PutUint16(buf[12:], m.Cog)
+	binary.LittleEndian.PutUint16(buf[14:], m.Heading)
+	binary.LittleEndian.PutUint16(buf[16:], m.Velocity)
+	binary.LittleEndian.PutUint16(buf[18:], m.DimensionBow)
+	binary.LittleEndian.PutUint16(buf[20:], m.DimensionStern)
+	binary.LittleEndian.PutUint16(buf[22:], m.Tslc)
+	binary.LittleEndian.PutUint16(buf[24:], uint16(m.Flags))
+	buf[26] = uint8(m.TurnRate)
+	buf[27] = uint8(m.NavigationalStatus)
+	buf[28] = uint8(m.Type)
+	buf[29] = m.DimensionPort
+	buf[30] = m.DimensionStarboard
+	copy(buf[31:38], m.Callsign)
+	copy(buf[38:58], m.Name)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageAisVessel) UnmarshalPayload(buf []byte) {
+	m.Mmsi = binary.LittleEndian.Uint32(buf[0:])
+	m.Lat = int32(binary.LittleEndian.Uint32(buf[4:]))
+	m.Lon = int32(binary.LittleEndian.Uint32(buf[8:]))
+	m.Cog = binary.LittleEndian.Uint16(buf[12:])
+	m.Heading = binary.LittleEndian.Uint16(buf[14:])
+	m.Velocity = binary.LittleEndian.Uint16(buf[16:])
+	m.DimensionBow = binary.LittleEndian.Uint16(buf[18:])
+	m.DimensionStern = binary.LittleEndian.Uint16(buf[20:])
+	m.Tslc = binary.LittleEndian.Uint16(buf[22:])
+	m.Flags = AIS_FLAGS(binary.LittleEndian.Uint16(buf[24:]))
+	m.TurnRate = int8(buf[26])
+	m.NavigationalStatus = AIS_NAV_STATUS(buf[27])
+	m.Type = AIS_TYPE(buf[28])
+	m.DimensionPort = buf[29]
+	m.DimensionStarboard = buf[30]
+	m.Callsign = decodeString(buf[31:38])
+	m.Name = decodeString(buf[38:58])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageUavcanNodeStatus) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.TimeUsec)
+	binary.LittleEndian.PutUint32(buf[8:], m.UptimeSec)
+	binary.LittleEndian.PutUint16(buf[12:], m.VendorSpecificStatusCode)
+	buf[14] = uint8(m.Health)
+	buf[15] = uint8(m.Mode)
+	buf[16] = m.SubMode
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageUavcanNodeStatus) UnmarshalPayload(buf []byte) {
+	m.TimeUsec = binary.LittleEndian.Uint64(buf[0:])
+	m.UptimeSec = binary.LittleEndian.Uint32(buf[8:])
+	m.VendorSpecificStatusCode = binary.LittleEndian.Uint16(buf[12:])
+	m.Health = UAVCAN_NODE_HEALTH(buf[14])
+	m.Mode = UAVCAN_NODE_MODE(buf[15])
+	m.SubMode = buf[16]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageUavcanNodeInfo) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.TimeUsec)
+	binary.LittleEndian.PutUint32(buf[8:], m.UptimeSec)
+	binary.LittleEndian.PutUint32(buf[12:], m.SwVcsCommit)
+	copy(buf[16:96], m.Name)
+	buf[96] = m.HwVersionMajor
+	buf[97] = m.HwVersionMinor
+	copy(buf[98:114], m.HwUniqueId[:])
+	buf[114] = m.SwVersionMajor
+	buf[115] = m.SwVersionMinor
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageUavcanNodeInfo) UnmarshalPayload(buf []byte) {
+	m.TimeUsec = binary.LittleEndian.Uint64(buf[0:])
+	m.UptimeSec = binary.LittleEndian.Uint32(buf[8:])
+	m.SwVcsCommit = binary.LittleEndian.Uint32(buf[12:])
+	m.Name = decodeString(buf[16:96])
+	m.HwVersionMajor = buf[96]
+	m.HwVersionMinor = buf[97]
+	copy(m.HwUniqueId[:], buf[98:114])
+	m.SwVersionMajor = buf[114]
+	m.SwVersionMinor = buf[115]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageParamExtRequestRead) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint16(buf[0:], uint16(m.ParamIndex))
+	buf[2] = m.TargetSystem
+	buf[3] = m.TargetComponent
+	copy(buf[4:20], m.ParamId)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageParamExtRequestRead) UnmarshalPayload(buf []byte) {
+	m.ParamIndex = int16(binary.LittleEndian.Uint16(buf[0:]))
+	m.TargetSystem = buf[2]
+	m.TargetComponent = buf[3]
+	m.ParamId = decodeString(buf[4:20])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageParamExtRequestList) MarshalPayload(buf []byte) {
+	buf[0] = m.TargetSystem
+	buf[1] = m.TargetComponent
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageParamExtRequestList) UnmarshalPayload(buf []byte) {
+	m.TargetSystem = buf[0]
+	m.TargetComponent = buf[1]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageParamExtValue) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint16(buf[0:], m.ParamCount)
+	binary.LittleEndian.PutUint16(buf[2:], m.ParamIndex)
+	copy(buf[4:20], m.ParamId)
+	copy(buf[20:148], m.ParamValue)
+	buf[148] = uint8(m.ParamType)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageParamExtValue) UnmarshalPayload(buf []byte) {
+	m.ParamCount = binary.LittleEndian.Uint16(buf[0:])
+	m.ParamIndex = binary.LittleEndian.Uint16(buf[2:])
+	m.ParamId = decodeString(buf[4:20])
+	m.ParamValue = decodeString(buf[20:148])
+	m.ParamType = MAV_PARAM_EXT_TYPE(buf[148])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageParamExtSet) MarshalPayload(buf []byte) {
+	buf[0] = m.TargetSystem
+	buf[1] = m.TargetComponent
+	copy(buf[2:18], m.ParamId)
+	copy(buf[18:146], m.ParamValue)
+	buf[146] = uint8(m.ParamType)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageParamExtSet) UnmarshalPayload(buf []byte) {
+	m.TargetSystem = buf[0]
+	m.TargetComponent = buf[1]
+	m.ParamId = decodeString(buf[2:18])
+	m.ParamValue = decodeString(buf[18:146])
+	m.ParamType = MAV_PARAM_EXT_TYPE(buf[146])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageParamExtAck) MarshalPayload(buf []byte) {
+	copy(buf[0:16], m.ParamId)
+	copy(buf[16:144], m.ParamValue)
+	buf[144] = uint8(m.ParamType)
+	buf[145] = uint8(m.ParamResult)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageParamExtAck) UnmarshalPayload(buf []byte) {
+	m.ParamId = decodeString(buf[0:16])
+	m.ParamValue = decodeString(buf[16:144])
+	m.ParamType = MAV_PARAM_EXT_TYPE(buf[144])
+	m.ParamResult = PARAM_ACK(buf[145])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageObstacleDistance) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.TimeUsec)
+	for i, v := range m.Distances {
+		binary.LittleEndian.PutUint16(buf[8+i*2:], v)
+	}
+	binary.LittleEndian.PutUint16(buf[152:], m.MinDistance)
+	binary.LittleEndian.PutUint16(buf[154:], m.MaxDistance)
+	buf[156] = uint8(m.SensorType)
+	buf[157] = m.Increment
+	if len(buf) < 167 {
+		return
+	}
+	binary.LittleEndian.PutUint32(buf[158:], math.Float32bits(m.IncrementF))
+	binary.LittleEndian.PutUint32(buf[162:], math.Float32bits(m.AngleOffset))
+	buf[166] = uint8(m.Frame)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageObstacleDistance) UnmarshalPayload(buf []byte) {
+	m.TimeUsec = binary.LittleEndian.Uint64(buf[0:])
+	for i := range m.Distances {
+		m.Distances[i] = binary.LittleEndian.Uint16(buf[8+i*2:])
+	}
+	m.MinDistance = binary.LittleEndian.Uint16(buf[152:])
+	m.MaxDistance = binary.LittleEndian.Uint16(buf[154:])
+	m.SensorType = MAV_DISTANCE_SENSOR(buf[156])
+	m.Increment = buf[157]
+	if len(buf) < 167 {
+		return
+	}
+	m.IncrementF = math.Float32frombits(binary.LittleEndian.Uint32(buf[158:]))
+	m.AngleOffset = math.Float32frombits(binary.LittleEndian.Uint32(buf[162:]))
+	m.Frame = MAV_FRAME(buf[166])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageOdometry) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.TimeUsec)
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.X))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.Y))
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.Z))
+	for i, v := range m.Q {
+		binary.LittleEndian.PutUint32(buf[20+i*4:], math.Float32bits(v))
+	}
+	binary.LittleEndian.PutUint32(buf[36:], math.Float32bits(m.Vx))
+	binary.LittleEndian.PutUint32(buf[40:], math.Float32bits(m.Vy))
+	binary.LittleEndian.PutUint32(buf[44:], math.Float32bits(m.Vz))
+	binary.LittleEndian.PutUint32(buf[48:], math.Float32bits(m.Rollspeed))
+	binary.LittleEndian.PutUint32(buf[52:], math.Float32bits(m.Pitchspeed))
+	binary.LittleEndian.PutUint32(buf[56:], math.Float32bits(m.Yawspeed))
+	for i, v := range m.PoseCovariance {
+		binary.LittleEndian.PutUint32(buf[60+i*4:], math.Float32bits(v))
+	}
+	for i, v := range m.VelocityCovariance {
+		binary.LittleEndian.PutUint32(buf[144+i*4:], math.Float32bits(v))
+	}
+	buf[228] = uint8(m.FrameId)
+	buf[229] = uint8(m.ChildFrameId)
+	if len(buf) < 232 {
+		return
+	}
+	buf[230] = m.ResetCounter
+	buf[231] = uint8(m.EstimatorType)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageOdometry) UnmarshalPayload(buf []byte) {
+	m.TimeUsec = binary.LittleEndian.Uint64(buf[0:])
+	m.X = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.Y = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.Z = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	for i := range m.Q {
+		m.Q[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[20+i*4:]))
+	}
+	m.Vx = math.Float32frombits(binary.LittleEndian.Uint32(buf[36:]))
+	m.Vy = math.Float32frombits(binary.LittleEndian.Uint32(buf[40:]))
+	m.Vz = math.Float32frombits(binary.LittleEndian.Uint32(buf[44:]))
+	m.Rollspeed = math.Float32frombits(binary.LittleEndian.Uint32(buf[48:]))
+	m.Pitchspeed = math.Float32frombits(binary.LittleEndian.Uint32(buf[52:]))
+	m.Yawspeed = math.Float32frombits(binary.LittleEndian.Uint32(buf[56:]))
+	for i := range m.PoseCovariance {
+		m.PoseCovariance[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[60+i*4:]))
+	}
+	for i := range m.VelocityCovariance {
+		m.VelocityCovariance[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[144+i*4:]))
+	}
+	m.FrameId = MAV_FRAME(buf[228])
+	m.ChildFrameId = MAV_FRAME(buf[229])
+	if len(buf) < 232 {
+		return
+	}
+	m.ResetCounter = buf[230]
+	m.EstimatorType = MAV_ESTIMATOR_TYPE(buf[231])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageTrajectoryRepresentationWaypoints) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.TimeUsec)
+	for i, v := range m.PosX {
+		binary.LittleEndian.PutUint32(buf[8+i*4:], math.Float32bits(v))
+	}
+	for i, v := range m.PosY {
+		binary.LittleEndian.PutUint32(buf[28+i*4:], math.Float32bits(v))
+	}
+	for i, v := range m.PosZ {
+		binary.LittleEndian.PutUint32(buf[48+i*4:], math.Float32bits(v))
+	}
+	for i, v := range m.VelX {
+		binary.LittleEndian.PutUint32(buf[68+i*4:], math.Float32bits(v))
+	}
+	for i, v := range m.VelY {
+		binary.LittleEndian.PutUint32(buf[88+i*4:], math.Float32bits(v))
+	}
+	for i, v := range m.VelZ {
+		binary.LittleEndian.PutUint32(buf[108+i*4:], math.Float32bits(v))
+	}
+	for i, v := range m.AccX {
+		binary.LittleEndian.PutUint32(buf[128+i*4:], math.Float32bits(v))
+	}
+	for i, v := range m.AccY {
+		binary.LittleEndian.PutUint32(buf[148+i*4:], math.Float32bits(v))
+	}
+	for i, v := range m.AccZ {
+		binary.LittleEndian.PutUint32(buf[168+i*4:], math.Float32bits(v))
+	}
+	for i, v := range m.PosYaw {
+		binary.LittleEndian.PutUint32(buf[188+i*4:], math.Float32bits(v))
+	}
+	for i, v := range m.VelYaw {
+		binary.LittleEndian.PutUint32(buf[208+i*4:], math.Float32bits(v))
+	}
+	for i, v := range m.Command {
+		binary.LittleEndian.PutUint16(buf[228+i*2:], uint16(v))
+	}
+	buf[238] = m.ValidPoints
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageTrajectoryRepresentationWaypoints) UnmarshalPayload(buf []byte) {
+	m.TimeUsec = binary.LittleEndian.Uint64(buf[0:])
+	for i := range m.PosX {
+		m.PosX[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[8+i*4:]))
+	}
+	for i := range m.PosY {
+		m.PosY[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[28+i*4:]))
+	}
+	for i := range m.PosZ {
+		m.PosZ[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[48+i*4:]))
+	}
+	for i := range m.VelX {
+		m.VelX[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[68+i*4:]))
+	}
+	for i := range m.VelY {
+		m.VelY[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[88+i*4:]))
+	}
+	for i := range m.VelZ {
+		m.VelZ[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[108+i*4:]))
+	}
+	for i := range m.AccX {
+		m.AccX[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[128+i*4:]))
+	}
+	for i := range m.AccY {
+		m.AccY[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[148+i*4:]))
+	}
+	for i := range m.AccZ {
+		m.AccZ[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[168+i*4:]))
+	}
+	for i := range m.PosYaw {
+		m.PosYaw[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[188+i*4:]))
+	}
+	for i := range m.VelYaw {
+		m.VelYaw[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[208+i*4:]))
+	}
+	for i := range m.Command {
+		m.Command[i] = MAV_CMD(binary.LittleEndian.Uint16(buf[228+i*2:]))
+	}
+	m.ValidPoints = buf[238]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageTrajectoryRepresentationBezier) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.TimeUsec)
+	for i, v := range m.PosX {
+		binary.LittleEndian.PutUint32(buf[8+i*4:], math.Float32bits(v))
+	}
+	for i, v := range m.PosY {
+		binary.LittleEndian.PutUint32(buf[28+i*4:], math.Float32bits(v))
+	}
+	for i, v := range m.PosZ {
+		binary.LittleEndian.PutUint32(buf[48+i*4:], math.Float32bits(v))
+	}
+	for i, v := range m.Delta {
+		binary.LittleEndian.PutUint32(buf[68+i*4:], math.Float32bits(v))
+	}
+	for i, v := range m.PosYaw {
+		binary.LittleEndian.PutUint32(buf[88+i*4:], math.Float32bits(v))
+	}
+	buf[108] = m.ValidPoints
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageTrajectoryRepresentationBezier) UnmarshalPayload(buf []byte) {
+	m.TimeUsec = binary.LittleEndian.Uint64(buf[0:])
+	for i := range m.PosX {
+		m.PosX[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[8+i*4:]))
+	}
+	for i := range m.PosY {
+		m.PosY[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[28+i*4:]))
+	}
+	for i := range m.PosZ {
+		m.PosZ[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[48+i*4:]))
+	}
+	for i := range m.Delta {
+		m.Delta[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[68+i*4:]))
+	}
+	for i := range m.PosYaw {
+		m.PosYaw[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[88+i*4:]))
+	}
+	m.ValidPoints = buf[108]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageCellularStatus) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint16(buf[0:], m.Mcc)
+	binary.LittleEndian.PutUint16(buf[2:], m.Mnc)
+	binary.LittleEndian.PutUint16(buf[4:], m.Lac)
+	buf[6] = uint8(m.Status)
+	buf[7] = uint8(m.FailureReason)
+	buf[8] = uint8(m.Type)
+	buf[9] = m.Quality
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageCellularStatus) UnmarshalPayload(buf []byte) {
+	m.Mcc = binary.LittleEndian.Uint16(buf[0:])
+	m.Mnc = binary.LittleEndian.Uint16(buf[2:])
+	m.Lac = binary.LittleEndian.Uint16(buf[4:])
+	m.Status = CELLULAR_STATUS_FLAG(buf[6])
+	m.FailureReason = CELLULAR_NETWORK_FAILED_REASON(buf[7])
+	m.Type = CELLULAR_NETWORK_RADIO_TYPE(buf[8])
+	m.Quality = buf[9]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageIsbdLinkStatus) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.Timestamp)
+	binary.LittleEndian.PutUint64(buf[8:], m.LastHeartbeat)
+	binary.LittleEndian.PutUint16(buf[16:], m.FailedSessions)
+	binary.LittleEndian.PutUint16(buf[18:], m.SuccessfulSessions)
+	buf[20] = m.SignalQuality
+	buf[21] = m.RingPending
+	buf[22] = m.TxSessionPending
+	buf[23] = m.RxSessionPending
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageIsbdLinkStatus) UnmarshalPayload(buf []byte) {
+	m.Timestamp = binary.LittleEndian.Uint64(buf[0:])
+	m.LastHeartbeat = binary.LittleEndian.Uint64(buf[8:])
+	m.FailedSessions = binary.LittleEndian.Uint16(buf[16:])
+	m.SuccessfulSessions = binary.LittleEndian.Uint16(buf[18:])
+	m.SignalQuality = buf[20]
+	m.RingPending = buf[21]
+	m.TxSessionPending = buf[22]
+	m.RxSessionPending = buf[23]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageCellularConfig) MarshalPayload(buf []byte) {
+	buf[0] = m.EnableLte
+	buf[1] = m.EnablePin
+	copy(buf[2:18], m.Pin)
+	copy(buf[18:34], m.NewPin)
+	copy(buf[34:66], m.Apn)
+	copy(buf[66:82], m.Puk)
+	buf[82] = m.Roaming
+	buf[83] = uint8(m.Response)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageCellularConfig) UnmarshalPayload(buf []byte) {
+	m.EnableLte = buf[0]
+	m.EnablePin = buf[1]
+	m.Pin = decodeString(buf[2:18])
+	m.NewPin = decodeString(buf[18:34])
+	m.Apn = decodeString(buf[34:66])
+	m.Puk = decodeString(buf[66:82])
+	m.Roaming = buf[82]
+	m.Response = CELLULAR_CONFIG_RESPONSE(buf[83])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageRawRpm) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], math.Float32bits(m.Frequency))
+	buf[4] = m.Index
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageRawRpm) UnmarshalPayload(buf []byte) {
+	m.Frequency = math.Float32frombits(binary.LittleEndian.Uint32(buf[0:]))
+	m.Index = buf[4]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageUtmGlobalPosition) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.Time)
+	binary.LittleEndian.PutUint32(buf[8:], uint32(m.Lat))
+	binary.LittleEndian.PutUint32(buf[12:], uint32(m.Lon))
+	binary.LittleEndian.PutUint32(buf[16:], uint32(m.Alt))
+	binary.LittleEndian.PutUint32(buf[20:], uint32(m.RelativeAlt))
+	binary.LittleEndian.PutUint32(buf[24:], uint32(m.NextLat))
+	binary.LittleEndian.PutUint32(buf[28:], uint32(m.NextLon))
+	binary.LittleEndian.PutUint32(buf[32:], uint32(m.NextAlt))
+	binary.LittleEndian.PutUint16(buf[36:], uint16(m.Vx))
+	binary.LittleEndian.PutUint16(buf[38:], uint16(m.Vy))
+	binary.LittleEndian.PutUint16(buf[40:], uint16(m.Vz))
+	binary.LittleEndian.PutUint16(buf[42:], m.HAcc)
+	binary.LittleEndian.PutUint16(buf[44:], m.VAcc)
+	binary.LittleEndian.PutUint16(buf[46:], m.VelAcc)
+	binary.LittleEndian.PutUint16(buf[48:], m.UpdateRate)
+	copy(buf[50:68], m.UasId[:])
+	buf[68] = uint8(m.FlightState)
+	buf[69] = uint8(m.Flags)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageUtmGlobalPosition) UnmarshalPayload(buf []byte) {
+	m.Time = binary.LittleEndian.Uint64(buf[0:])
+	m.Lat = int32(binary.LittleEndian.Uint32(buf[8:]))
+	m.Lon = int32(binary.LittleEndian.Uint32(buf[12:]))
+	m.Alt = int32(binary.LittleEndian.Uint32(buf[16:]))
+	m.RelativeAlt = int32(binary.LittleEndian.Uint32(buf[20:]))
+	m.NextLat = int32(binary.LittleEndian.Uint32(buf[24:]))
+	m.NextLon = int32(binary.LittleEndian.Uint32(buf[28:]))
+	m.NextAlt = int32(binary.LittleEndian.Uint32(buf[32:]))
+	m.Vx = int16(binary.LittleEndian.Uint16(buf[36:]))
+	m.Vy = int16(binary.LittleEndian.Uint16(buf[38:]))
+	m.Vz = int16(binary.LittleEndian.Uint16(buf[40:]))
+	m.HAcc = binary.LittleEndian.Uint16(buf[42:])
+	m.VAcc = binary.LittleEndian.Uint16(buf[44:])
+	m.VelAcc = binary.LittleEndian.Uint16(buf[46:])
+	m.UpdateRate = binary.LittleEndian.Uint16(buf[48:])
+	copy(m.UasId[:], buf[50:68])
+	m.FlightState = UTM_FLIGHT_STATE(buf[68])
+	m.Flags = UTM_DATA_AVAIL_FLAGS(buf[69])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageDebugFloatArray) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.TimeUsec)
+	binary.LittleEndian.PutUint16(buf[8:], m.ArrayId)
+	copy(buf[10:20], m.Name)
+	if len(buf) < 252 {
+		return
+	}
+	for i, v := range m.Data {
+		binary.LittleEndian.PutUint32(buf[20+i*4:], math.Float32bits(v))
+	}
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageDebugFloatArray) UnmarshalPayload(buf []byte) {
+	m.TimeUsec = binary.LittleEndian.Uint64(buf[0:])
+	m.ArrayId = binary.LittleEndian.Uint16(buf[8:])
+	m.Name = decodeString(buf[10:20])
+	if len(buf) < 252 {
+		return
+	}
+	for i := range m.Data {
+		m.Data[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[20+i*4:]))
+	}
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageOrbitExecutionStatus) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.TimeUsec)
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.Radius))
+	binary.LittleEndian.PutUint32(buf[12:], uint32(m.X))
+	binary.LittleEndian.PutUint32(buf[16:], uint32(m.Y))
+	binary.LittleEndian.PutUint32(buf[20:], math.Float32bits(m.Z))
+	buf[24] = uint8(m.Frame)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageOrbitExecutionStatus) UnmarshalPayload(buf []byte) {
+	m.TimeUsec = binary.LittleEndian.Uint64(buf[0:])
+	m.Radius = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.X = int32(binary.LittleEndian.Uint32(buf[12:]))
+	m.Y = int32(binary.LittleEndian.Uint32(buf[16:]))
+	m.Z = math.Float32frombits(binary.LittleEndian.Uint32(buf[20:]))
+	m.Frame = MAV_FRAME(buf[24])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageSmartBatteryInfo) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], uint32(m.CapacityFullSpecification))
+	binary.LittleEndian.PutUint32(buf[4:], uint32(m.CapacityFull))
+	binary.LittleEndian.PutUint16(buf[8:], m.CycleCount)
+	binary.LittleEndian.PutUint16(buf[10:], m.Weight)
+	binary.LittleEndian.PutUint16(buf[12:], m.DischargeMinimumVoltage)
+	binary.LittleEndian.PutUint16(buf[14:], m.ChargingMinimumVoltage)
+	binary.LittleEndian.PutUint16(buf[16:], m.RestingMinimumVoltage)
+	buf[18] = m.Id
+	buf[19] = uint8(m.BatteryFunction)
+	buf[20] = uint8(m.Type)
+	copy(buf[21:37], m.SerialNumber)
+	copy(buf[37:87], m.DeviceName)
+	if len(buf) < 109 {
+		return
+	}
+	binary.LittleEndian.PutUint16(buf[87:], m.ChargingMaximumVoltage)
+	buf[89] = m.CellsInSeries
+	binary.LittleEndian.PutUint32(buf[90:], m.DischargeMaximumCurrent)
+	binary.LittleEndian.PutUint32(buf[94:], m.DischargeMaximumBurstCurrent)
+	copy(buf[98:109], m.ManufactureDate)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageSmartBatteryInfo) UnmarshalPayload(buf []byte) {
+	m.CapacityFullSpecification = int32(binary.LittleEndian.Uint32(buf[0:]))
+	m.CapacityFull = int32(binary.LittleEndian.Uint32(buf[4:]))
+	m.CycleCount = binary.LittleEndian.Uint16(buf[8:])
+	m.Weight = binary.LittleEndian.Uint16(buf[10:])
+	m.DischargeMinimumVoltage = binary.LittleEndian.Uint16(buf[12:])
+	m.ChargingMinimumVoltage = binary.LittleEndian.Uint16(buf[14:])
+	m.RestingMinimumVoltage = binary.LittleEndian.Uint16(buf[16:])
+	m.Id = buf[18]
+	m.BatteryFunction = MAV_BATTERY_FUNCTION(buf[19])
+	m.Type = MAV_BATTERY_TYPE(buf[20])
+	m.SerialNumber = decodeString(buf[21:37])
+	m.DeviceName = decodeString(buf[37:87])
+	if len(buf) < 109 {
+		return
+	}
+	m.ChargingMaximumVoltage = binary.LittleEndian.Uint16(buf[87:])
+	m.CellsInSeries = buf[89]
+	m.DischargeMaximumCurrent = binary.LittleEndian.Uint32(buf[90:])
+	m.DischargeMaximumBurstCurrent = binary.LittleEndian.Uint32(buf[94:])
+	m.ManufactureDate = decodeString(buf[98:109])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageGeneratorStatus) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], uint64(m.Status))
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.BatteryCurrent))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.LoadCurrent))
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.PowerGenerated))
+	binary.LittleEndian.PutUint32(buf[20:], math.Float32bits(m.BusVoltage))
+	binary.LittleEndian.PutUint32(buf[24:], math.Float32bits(m.BatCurrentSetpoint))
+	binary.LittleEndian.PutUint32(buf[28:], m.Runtime)
+	binary.LittleEndian.PutUint32(buf[32:], uint32(m.TimeUntilMaintenance))
+	binary.LittleEndian.PutUint16(buf[36:], m.GeneratorSpeed)
+	binary.LittleEndian.PutUint16(buf[38:], uint16(m.RectifierTemperature))
+	binary.LittleEndian.PutUint16(buf[40:], uint16(m.GeneratorTemperature))
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageGeneratorStatus) UnmarshalPayload(buf []byte) {
+	m.Status = MAV_GENERATOR_STATUS_FLAG(binary.LittleEndian.Uint64(buf[0:]))
+	m.BatteryCurrent = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.LoadCurrent = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.PowerGenerated = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	m.BusVoltage = math.Float32frombits(binary.LittleEndian.Uint32(buf[20:]))
+	m.BatCurrentSetpoint = math.Float32frombits(binary.LittleEndian.Uint32(buf[24:]))
+	m.Runtime = binary.LittleEndian.Uint32(buf[28:])
+	m.TimeUntilMaintenance = int32(binary.LittleEndian.Uint32(buf[32:]))
+	m.GeneratorSpeed = binary.LittleEndian.Uint16(buf[36:])
+	m.RectifierTemperature = int16(binary.LittleEndian.Uint16(buf[38:]))
+	m.GeneratorTemperature = int16(binary.LittleEndian.Uint16(buf[40:]))
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageActuatorOutputStatus) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.TimeUsec)
+	binary.LittleEndian.PutUint32(buf[8:], m.Active)
+	for i, v := range m.Actuator {
+		binary.LittleEndian.PutUint32(buf[12+i*4:], math.Float32bits(v))
+	}
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageActuatorOutputStatus) UnmarshalPayload(buf []byte) {
+	m.TimeUsec = binary.LittleEndian.Uint64(buf[0:])
+	m.Active = binary.LittleEndian.Uint32(buf[8:])
+	for i := range m.Actuator {
+		m.Actuator[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[12+i*4:]))
+	}
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageTimeEstimateToTarget) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], uint32(m.SafeReturn))
+	binary.LittleEndian.PutUint32(buf[4:], uint32(m.Land))
+	binary.LittleEndian.PutUint32(buf[8:], uint32(m.MissionNextItem))
+	binary.LittleEndian.PutUint32(buf[12:], uint32(m.MissionEnd))
+	binary.LittleEndian.PutUint32(buf[16:], uint32(m.CommandedAction))
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageTimeEstimateToTarget) UnmarshalPayload(buf []byte) {
+	m.SafeReturn = int32(binary.LittleEndian.Uint32(buf[0:]))
+	m.Land = int32(binary.LittleEndian.Uint32(buf[4:]))
+	m.MissionNextItem = int32(binary.LittleEndian.Uint32(buf[8:]))
+	m.MissionEnd = int32(binary.LittleEndian.Uint32(buf[12:]))
+	m.CommandedAction = int32(binary.LittleEndian.Uint32(buf[16:]))
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageTunnel) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint16(buf[0:], uint16(m.PayloadType))
+	buf[2] = m.TargetSystem
+	buf[3] = m.TargetComponent
+	buf[4] = m.PayloadLength
+	copy(buf[5:133], m.Payload[:])
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageTunnel) UnmarshalPayload(buf []byte) {
+	m.PayloadType = MAV_TUNNEL_PAYLOAD_TYPE(binary.LittleEndian.Uint16(buf[0:]))
+	m.TargetSystem = buf[2]
+	m.TargetComponent = buf[3]
+	m.PayloadLength = buf[4]
+	copy(m.Payload[:], buf[5:133])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageOnboardComputerStatus) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.TimeUsec)
+	binary.LittleEndian.PutUint32(buf[8:], m.Uptime)
+	binary.LittleEndian.PutUint32(buf[12:], m.RamUsage)
+	binary.LittleEndian.PutUint32(buf[16:], m.RamTotal)
+	for i, v := range m.StorageType {
+		binary.LittleEndian.PutUint32(buf[20+i*4:], v)
+	}
+	for i, v := range m.StorageUsage {
+		binary.LittleEndian.PutUint32(buf[36+i*4:], v)
+	}
+	for i, v := range m.StorageTotal {
+		binary.LittleEndian.PutUint32(buf[52+i*4:], v)
+	}
+	for i, v := range m.LinkType {
+		binary.LittleEndian.PutUint32(buf[68+i*4:], v)
+	}
+	for i, v := range m.LinkTxRate {
+		binary.LittleEndian.PutUint32(buf[92+i*4:], v)
+	}
+	for i, v := range m.LinkRxRate {
+		binary.LittleEndian.PutUint32(buf[116+i*4:], v)
+	}
+	for i, v := range m.LinkTxMax {
+		binary.LittleEndian.PutUint32(buf[140+i*4:], v)
+	}
+	for i, v := range m.LinkRxMax {
+		binary.LittleEndian.PutUint32(buf[164+i*4:], v)
+	}
+	for i, v := range m.FanSpeed {
+		binary.LittleEndian.PutUint16(buf[188+i*2:], uint16(v))
+	}
+	buf[196] = m.Type
+	copy(buf[197:205], m.CpuCores[:])
+	copy(buf[205:215], m.CpuCombined[:])
+	copy(buf[215:219], m.GpuCores[:])
+	copy(buf[219:229], m.GpuCombined[:])
+	buf[229] = uint8(m.TemperatureBoard)
+	for i, v := range m.TemperatureCore {
+		buf[230+i] = uint8(v)
+	}
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageOnboardComputerStatus) UnmarshalPayload(buf []byte) {
+	m.TimeUsec = binary.LittleEndian.Uint64(buf[0:])
+	m.Uptime = binary.LittleEndian.Uint32(buf[8:])
+	m.RamUsage = binary.LittleEndian.Uint32(buf[12:])
+	m.RamTotal = binary.LittleEndian.Uint32(buf[16:])
+	for i := range m.StorageType {
+		m.StorageType[i] = binary.LittleEndian.Uint32(buf[20+i*4:])
+	}
+	for i := range m.StorageUsage {
+		m.StorageUsage[i] = binary.LittleEndian.Uint32(buf[36+i*4:])
+	}
+	for i := range m.StorageTotal {
+		m.StorageTotal[i] = binary.LittleEndian.Uint32(buf[52+i*4:])
+	}
+	for i := range m.LinkType {
+		m.LinkType[i] = binary.LittleEndian.Uint32(buf[68+i*4:])
+	}
+	for i := range m.LinkTxRate {
+		m.LinkTxRate[i] = binary.LittleEndian.Uint32(buf[92+i*4:])
+	}
+	for i := range m.LinkRxRate {
+		m.LinkRxRate[i] = binary.LittleEndian.Uint32(buf[116+i*4:])
+	}
+	for i := range m.LinkTxMax {
+		m.LinkTxMax[i] = binary.LittleEndian.Uint32(buf[140+i*4:])
+	}
+	for i := range m.LinkRxMax {
+		m.LinkRxMax[i] = binary.LittleEndian.Uint32(buf[164+i*4:])
+	}
+	for i := range m.FanSpeed {
+		m.FanSpeed[i] = int16(binary.LittleEndian.Uint16(buf[188+i*2:]))
+	}
+	m.Type = buf[196]
+	copy(m.CpuCores[:], buf[197:205])
+	copy(m.CpuCombined[:], buf[205:215])
+	copy(m.GpuCores[:], buf[215:219])
+	copy(m.GpuCombined[:], buf[219:229])
+	m.TemperatureBoard = int8(buf[229])
+	for i := range m.TemperatureCore {
+		m.TemperatureCore[i] = int8(buf[230+i])
+	}
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageComponentInformation) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], m.TimeBootMs)
+	binary.LittleEndian.PutUint32(buf[4:], m.GeneralMetadataFileCrc)
+	binary.LittleEndian.PutUint32(buf[8:], m.PeripheralsMetadataFileCrc)
+	copy(buf[12:112], m.GeneralMetadataUri)
+	copy(buf[112:212], m.PeripheralsMetadataUri)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageComponentInformation) UnmarshalPayload(buf []byte) {
+	m.TimeBootMs = binary.LittleEndian.Uint32(buf[0:])
+	m.GeneralMetadataFileCrc = binary.LittleEndian.Uint32(buf[4:])
+	m.PeripheralsMetadataFileCrc = binary.LittleEndian.Uint32(buf[8:])
+	m.GeneralMetadataUri = decodeString(buf[12:112])
+	m.PeripheralsMetadataUri = decodeString(buf[112:212])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessagePlayTuneV2) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], uint32(m.Format))
+	buf[4] = m.TargetSystem
+	buf[5] = m.TargetComponent
+	copy(buf[6:254], m.Tune)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessagePlayTuneV2) UnmarshalPayload(buf []byte) {
+	m.Format = TUNE_FORMAT(binary.LittleEndian.Uint32(buf[0:]))
+	m.TargetSystem = buf[4]
+	m.TargetComponent = buf[5]
+	m.Tune = decodeString(buf[6:254])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageSupportedTunes) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], uint32(m.Format))
+	buf[4] = m.TargetSystem
+	buf[5] = m.TargetComponent
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageSupportedTunes) UnmarshalPayload(buf []byte) {
+	m.Format = TUNE_FORMAT(binary.LittleEndian.Uint32(buf[0:]))
+	m.TargetSystem = buf[4]
+	m.TargetComponent = buf[5]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageEvent) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], m.Id)
+	binary.LittleEndian.PutUint32(buf[4:], m.EventTimeBootMs)
+	binary.LittleEndian.PutUint16(buf[8:], m.Sequence)
+	buf[10] = m.DestinationComponent
+	buf[11] = m.DestinationSystem
+	buf[12] = m.LogLevels
+	copy(buf[13:53], m.Arguments[:])
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageEvent) UnmarshalPayload(buf []byte) {
+	m.Id = binary.LittleEndian.Uint32(buf[0:])
+	m.EventTimeBootMs = binary.LittleEndian.Uint32(buf[4:])
+	m.Sequence = binary.LittleEndian.Uint16(buf[8:])
+	m.DestinationComponent = buf[10]
+	m.DestinationSystem = buf[11]
+	m.LogLevels = buf[12]
+	copy(m.Arguments[:], buf[13:53])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageCurrentEventSequence) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint16(buf[0:], m.Sequence)
+	buf[2] = uint8(m.Flags)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageCurrentEventSequence) UnmarshalPayload(buf []byte) {
+	m.Sequence = binary.LittleEndian.Uint16(buf[0:])
+	m.Flags = MAV_EVENT_CURRENT_SEQUENCE_FLAGS(buf[2])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageRequestEvent) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint16(buf[0:], m.FirstSequence)
+	binary.LittleEndian.PutUint16(buf[2:], m.LastSequence)
+	buf[4] = m.TargetSystem
+	buf[5] = m.TargetComponent
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageRequestEvent) UnmarshalPayload(buf []byte) {
+	m.FirstSequence = binary.LittleEndian.Uint16(buf[0:])
+	m.LastSequence = binary.LittleEndian.Uint16(buf[2:])
+	m.TargetSystem = buf[4]
+	m.TargetComponent = buf[5]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageResponseEventError) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint16(buf[0:], m.Sequence)
+	binary.LittleEndian.PutUint16(buf[2:], m.SequenceOldestAvailable)
+	buf[4] = m.TargetSystem
+	buf[5] = m.TargetComponent
+	buf[6] = uint8(m.Reason)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageResponseEventError) UnmarshalPayload(buf []byte) {
+	m.Sequence = binary.LittleEndian.Uint16(buf[0:])
+	m.SequenceOldestAvailable = binary.LittleEndian.Uint16(buf[2:])
+	m.TargetSystem = buf[4]
+	m.TargetComponent = buf[5]
+	m.Reason = MAV_EVENT_ERROR_REASON(buf[6])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageWheelDistance) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.TimeUsec)
+	for i, v := range m.Distance {
+		binary.LittleEndian.PutUint64(buf[8+i*8:], math.Float64bits(v))
+	}
+	buf[136] = m.Count
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageWheelDistance) UnmarshalPayload(buf []byte) {
+	m.TimeUsec = binary.LittleEndian.Uint64(buf[0:])
+	for i := range m.Distance {
+		m.Distance[i] = math.Float64frombits(binary.LittleEndian.Uint64(buf[8+i*8:]))
+	}
+	m.Count = buf[136]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageWinchStatus) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.TimeUsec)
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.LineLength))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.Speed))
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.Tension))
+	binary.LittleEndian.PutUint32(buf[20:], math.Float32bits(m.Voltage))
+	binary.LittleEndian.PutUint32(buf[24:], math.Float32bits(m.Current))
+	binary.LittleEndian.PutUint32(buf[28:], uint32(m.Status))
+	binary.LittleEndian.PutUint16(buf[32:], uint16(m.Temperature))
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageWinchStatus) UnmarshalPayload(buf []byte) {
+	m.TimeUsec = binary.LittleEndian.Uint64(buf[0:])
+	m.LineLength = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.Speed = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.Tension = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	m.Voltage = math.Float32frombits(binary.LittleEndian.Uint32(buf[20:]))
+	m.Current = math.Float32frombits(binary.LittleEndian.Uint32(buf[24:]))
+	m.Status = MAV_WINCH_STATUS_FLAG(binary.LittleEndian.Uint32(buf[28:]))
+	m.Temperature = int16(binary.LittleEndian.Uint16(buf[32:]))
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageOpenDroneIdBasicId) MarshalPayload(buf []byte) {
+	buf[0] = m.TargetSystem
+	buf[1] = m.TargetComponent
+	copy(buf[2:22], m.IdOrMac[:])
+	buf[22] = uint8(m.IdType)
+	buf[23] = uint8(m.UaType)
+	copy(buf[24:44], m.UasId[:])
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageOpenDroneIdBasicId) UnmarshalPayload(buf []byte) {
+	m.TargetSystem = buf[0]
+	m.TargetComponent = buf[1]
+	copy(m.IdOrMac[:], buf[2:22])
+	m.IdType = MAV_ODID_ID_TYPE(buf[22])
+	m.UaType = MAV_ODID_UA_TYPE(buf[23])
+	copy(m.UasId[:], buf[24:44])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageOpenDroneIdLocation) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], uint32(m.Latitude))
+	binary.LittleEndian.PutUint32(buf[4:], uint32(m.Longitude))
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.AltitudeBarometric))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.AltitudeGeodetic))
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.Height))
+	binary.LittleEndian.PutUint32(buf[20:], math.Float32bits(m.Timestamp))
+	binary.LittleEndian.PutUint16(buf[24:], m.Direction)
+	binary.LittleEndian.PutUint16(buf[26:], m.SpeedHorizontal)
+	binary.LittleEndian.PutUint16(buf[28:], uint16(m.SpeedVertical))
+	buf[30] = m.TargetSystem
+	buf[31] = m.TargetComponent
+	copy(buf[32:52], m.IdOrMac[:])
+	buf[52] = uint8(m.Status)
+	buf[53] = uint8(m.HeightReference)
+	buf[54] = uint8(m.HorizontalAccuracy)
+	buf[55] = uint8(m.VerticalAccuracy)
+	buf[56] = uint8(m.BarometerAccuracy)
+	buf[57] = uint8(m.SpeedAccuracy)
+	buf[58] = uint8(m.TimestampAccuracy)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageOpenDroneIdLocation) UnmarshalPayload(buf []byte) {
+	m.Latitude = int32(binary.LittleEndian.Uint32(buf[0:]))
+	m.Longitude = int32(binary.LittleEndian.Uint32(buf[4:]))
+	m.AltitudeBarometric = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.AltitudeGeodetic = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.Height = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	m.Timestamp = math.Float32frombits(binary.LittleEndian.Uint32(buf[20:]))
+	m.Direction = binary.LittleEndian.Uint16(buf[24:])
+	m.SpeedHorizontal = binary.LittleEndian.Uint16(buf[26:])
+	m.SpeedVertical = int16(binary.LittleEndian.Uint16(buf[28:]))
+	m.TargetSystem = buf[30]
+	m.TargetComponent = buf[31]
+	copy(m.IdOrMac[:], buf[32:52])
+	m.Status = MAV_ODID_STATUS(buf[52])
+	m.HeightReference = MAV_ODID_HEIGHT_REF(buf[53])
+	m.HorizontalAccuracy = MAV_ODID_HOR_ACC(buf[54])
+	m.VerticalAccuracy = MAV_ODID_VER_ACC(buf[55])
+	m.BarometerAccuracy = MAV_ODID_VER_ACC(buf[56])
+	m.SpeedAccuracy = MAV_ODID_SPEED_ACC(buf[57])
+	m.TimestampAccuracy = MAV_ODID_TIME_ACC(buf[58])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageOpenDroneIdAuthentication) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], m.Timestamp)
+	buf[4] = m.TargetSystem
+	buf[5] = m.TargetComponent
+	copy(buf[6:26], m.IdOrMac[:])
+	buf[26] = uint8(m.AuthenticationType)
+	buf[27] = m.DataPage
+	buf[28] = m.PageCount
+	buf[29] = m.Length
+	copy(buf[30:53], m.AuthenticationData[:])
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageOpenDroneIdAuthentication) UnmarshalPayload(buf []byte) {
+	m.Timestamp = binary.LittleEndian.Uint32(buf[0:])
+	m.TargetSystem = buf[4]
+	m.TargetComponent = buf[5]
+	copy(m.IdOrMac[:], buf[6:26])
+	m.AuthenticationType = MAV_ODID_AUTH_TYPE(buf[26])
+	m.DataPage = buf[27]
+	m.PageCount = buf[28]
+	m.Length = buf[29]
+	copy(m.AuthenticationData[:], buf[30:53])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageOpenDroneIdSelfId) MarshalPayload(buf []byte) {
+	buf[0] = m.TargetSystem
+	buf[1] = m.TargetComponent
+	copy(buf[2:22], m.IdOrMac[:])
+	buf[22] = uint8(m.DescriptionType)
+	copy(buf[23:46], m.Description)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageOpenDroneIdSelfId) UnmarshalPayload(buf []byte) {
+	m.TargetSystem = buf[0]
+	m.TargetComponent = buf[1]
+	copy(m.IdOrMac[:], buf[2:22])
+	m.DescriptionType = MAV_ODID_DESC_TYPE(buf[22])
+	m.Description = decodeString(buf[23:46])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageOpenDroneIdSystem) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], uint32(m.OperatorLatitude))
+	binary.LittleEndian.PutUint32(buf[4:], uint32(m.OperatorLongitude))
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.AreaCeiling))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.AreaFloor))
+	binary.LittleEndian.PutUint16(buf[16:], m.AreaCount)
+	binary.LittleEndian.PutUint16(buf[18:], m.AreaRadius)
+	buf[20] = m.TargetSystem
+	buf[21] = m.TargetComponent
+	copy(buf[22:42], m.IdOrMac[:])
+	buf[42] = uint8(m.OperatorLocationType)
+	buf[43] = uint8(m.ClassificationType)
+	buf[44] = uint8(m.CategoryEu)
+	buf[45] = uint8(m.ClassEu)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageOpenDroneIdSystem) UnmarshalPayload(buf []byte) {
+	m.OperatorLatitude = int32(binary.LittleEndian.Uint32(buf[0:]))
+	m.OperatorLongitude = int32(binary.LittleEndian.Uint32(buf[4:]))
+	m.AreaCeiling = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.AreaFloor = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.AreaCount = binary.LittleEndian.Uint16(buf[16:])
+	m.AreaRadius = binary.LittleEndian.Uint16(buf[18:])
+	m.TargetSystem = buf[20]
+	m.TargetComponent = buf[21]
+	copy(m.IdOrMac[:], buf[22:42])
+	m.OperatorLocationType = MAV_ODID_OPERATOR_LOCATION_TYPE(buf[42])
+	m.ClassificationType = MAV_ODID_CLASSIFICATION_TYPE(buf[43])
+	m.CategoryEu = MAV_ODID_CATEGORY_EU(buf[44])
+	m.ClassEu = MAV_ODID_CLASS_EU(buf[45])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageOpenDroneIdOperatorId) MarshalPayload(buf []byte) {
+	buf[0] = m.TargetSystem
+	buf[1] = m.TargetComponent
+	copy(buf[2:22], m.IdOrMac[:])
+	buf[22] = uint8(m.OperatorIdType)
+	copy(buf[23:43], m.OperatorId)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageOpenDroneIdOperatorId) UnmarshalPayload(buf []byte) {
+	m.TargetSystem = buf[0]
+	m.TargetComponent = buf[1]
+	copy(m.IdOrMac[:], buf[2:22])
+	m.OperatorIdType = MAV_ODID_OPERATOR_ID_TYPE(buf[22])
+	m.OperatorId = decodeString(buf[23:43])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageOpenDroneIdMessagePack) MarshalPayload(buf []byte) {
+	buf[0] = m.TargetSystem
+	buf[1] = m.TargetComponent
+	buf[2] = m.SingleMessageSize
+	buf[3] = m.MsgPackSize
+	copy(buf[4:254], m.Messages[:])
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageOpenDroneIdMessagePack) UnmarshalPayload(buf []byte) {
+	m.TargetSystem = buf[0]
+	m.TargetComponent = buf[1]
+	m.SingleMessageSize = buf[2]
+	m.MsgPackSize = buf[3]
+	copy(m.Messages[:], buf[4:254])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageCommandIntStamped) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.VehicleTimestamp)
+	binary.LittleEndian.PutUint32(buf[8:], m.UtcTime)
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.Param1))
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.Param2))
+	binary.LittleEndian.PutUint32(buf[20:], math.Float32bits(m.Param3))
+	binary.LittleEndian.PutUint32(buf[24:], math.Float32bits(m.Param4))
+	binary.LittleEndian.PutUint32(buf[28:], uint32(m.X))
+	binary.LittleEndian.PutUint32(buf[32:], uint32(m.Y))
+	binary.LittleEndian.PutUint32(buf[36:], math.Float32bits(m.Z))
+	binary.LittleEndian.PutUint16(buf[40:], uint16(m.Command))
+	buf[42] = m.TargetSystem
+	buf[43] = m.TargetComponent
+	buf[44] = uint8(m.Frame)
+	buf[45] = m.Current
+	buf[46] = m.Autocontinue
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageCommandIntStamped) UnmarshalPayload(buf []byte) {
+	m.VehicleTimestamp = binary.LittleEndian.Uint64(buf[0:])
+	m.UtcTime = binary.LittleEndian.Uint32(buf[8:])
+	m.Param1 = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.Param2 = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	m.Param3 = math.Float32frombits(binary.LittleEndian.Uint32(buf[20:]))
+	m.Param4 = math.Float32frombits(binary.LittleEndian.Uint32(buf[24:]))
+	m.X = int32(binary.LittleEndian.Uint32(buf[28:]))
+	m.Y = int32(binary.LittleEndian.Uint32(buf[32:]))
+	m.Z = math.Float32frombits(binary.LittleEndian.Uint32(buf[36:]))
+	m.Command = MAV_CMD(binary.LittleEndian.Uint16(buf[40:]))
+	m.TargetSystem = buf[42]
+	m.TargetComponent = buf[43]
+	m.Frame = MAV_FRAME(buf[44])
+	m.Current = buf[45]
+	m.Autocontinue = buf[46]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageCommandLongStamped) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.VehicleTimestamp)
+	binary.LittleEndian.PutUint32(buf[8:], m.UtcTime)
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.Param1))
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.Param2))
+	binary.LittleEndian.PutUint32(buf[20:], math.Float32bits(m.Param3))
+	binary.LittleEndian.PutUint32(buf[24:], math.Float32bits(m.Param4))
+	binary.LittleEndian.PutUint32(buf[28:], math.Float32bits(m.Param5))
+	binary.LittleEndian.PutUint32(buf[32:], math.Float32bits(m.Param6))
+	binary.LittleEndian.PutUint32(buf[36:], math.Float32bits(m.Param7))
+	binary.LittleEndian.PutUint16(buf[40:], uint16(m.Command))
+	buf[42] = m.TargetSystem
+	buf[43] = m.TargetComponent
+	buf[44] = m.Confirmation
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageCommandLongStamped) UnmarshalPayload(buf []byte) {
+	m.VehicleTimestamp = binary.LittleEndian.Uint64(buf[0:])
+	m.UtcTime = binary.LittleEndian.Uint32(buf[8:])
+	m.Param1 = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.Param2 = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	m.Param3 = math.Float32frombits(binary.LittleEndian.Uint32(buf[20:]))
+	m.Param4 = math.Float32frombits(binary.LittleEndian.Uint32(buf[24:]))
+	m.Param5 = math.Float32frombits(binary.LittleEndian.Uint32(buf[28:]))
+	m.Param6 = math.Float32frombits(binary.LittleEndian.Uint32(buf[32:]))
+	m.Param7 = math.Float32frombits(binary.LittleEndian.Uint32(buf[36:]))
+	m.Command = MAV_CMD(binary.LittleEndian.Uint16(buf[40:]))
+	m.TargetSystem = buf[42]
+	m.TargetComponent = buf[43]
+	m.Confirmation = buf[44]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageSensPower) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], math.Float32bits(m.Adc121VspbVolt))
+	binary.LittleEndian.PutUint32(buf[4:], math.Float32bits(m.Adc121CspbAmp))
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.Adc121Cs1Amp))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.Adc121Cs2Amp))
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageSensPower) UnmarshalPayload(buf []byte) {
+	m.Adc121VspbVolt = math.Float32frombits(binary.LittleEndian.Uint32(buf[0:]))
+	m.Adc121CspbAmp = math.Float32frombits(binary.LittleEndian.Uint32(buf[4:]))
+	m.Adc121Cs1Amp = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.Adc121Cs2Amp = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageSensMppt) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.MpptTimestamp)
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.Mppt1Volt))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.Mppt1Amp))
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.Mppt2Volt))
+	binary.LittleEndian.PutUint32(buf[20:], math.Float32bits(m.Mppt2Amp))
+	binary.LittleEndian.PutUint32(buf[24:], math.Float32bits(m.Mppt3Volt))
+	binary.LittleEndian.PutUint32(buf[28:], math.Float32bits(m.Mppt3Amp))
+	binary.LittleEndian.PutUint16(buf[32:], m.Mppt1Pwm)
+	binary.LittleEndian.PutUint16(buf[34:], m.Mppt2Pwm)
+	binary.LittleEndian.PutUint16(buf[36:], m.Mppt3Pwm)
+	buf[38] = m.Mppt1Status
+	buf[39] = m.Mppt2Status
+	buf[40] = m.Mppt3Status
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageSensMppt) UnmarshalPayload(buf []byte) {
+	m.MpptTimestamp = binary.LittleEndian.Uint64(buf[0:])
+	m.Mppt1Volt = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.Mppt1Amp = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.Mppt2Volt = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	m.Mppt2Amp = math.Float32frombits(binary.LittleEndian.Uint32(buf[20:]))
+	m.Mppt3Volt = math.Float32frombits(binary.LittleEndian.Uint32(buf[24:]))
+	m.Mppt3Amp = math.Float32frombits(binary.LittleEndian.Uint32(buf[28:]))
+	m.Mppt1Pwm = binary.LittleEndian.Uint16(buf[32:])
+	m.Mppt2Pwm = binary.LittleEndian.Uint16(buf[34:])
+	m.Mppt3Pwm = binary.LittleEndian.Uint16(buf[36:])
+	m.Mppt1Status = buf[38]
+	m.Mppt2Status = buf[39]
+	m.Mppt3Status = buf[40]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageAslctrlData) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.Timestamp)
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.H))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.Href))
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.HrefT))
+	binary.LittleEndian.PutUint32(buf[20:], math.Float32bits(m.Pitchangle))
+	binary.LittleEndian.PutUint32(buf[24:], math.Float32bits(m.Pitchangleref))
+	binary.LittleEndian.PutUint32(buf[28:], math.Float32bits(m.Q))
+	binary.LittleEndian.PutUint32(buf[32:], math.Float32bits(m.Qref))
+	binary.LittleEndian.PutUint32(buf[36:], math.Float32bits(m.Uelev))
+	binary.LittleEndian.PutUint32(buf[40:], math.Float32bits(m.Uthrot))
+	binary.LittleEndian.PutUint32(buf[44:], math.Float32bits(m.Uthrot2))
+	binary.LittleEndian.PutUint32(buf[48:], math.Float32bits(m.Nz))
+	binary.LittleEndian.PutUint32(buf[52:], math.Float32bits(m.Airspeedref))
+	binary.LittleEndian.PutUint32(buf[56:], math.Float32bits(m.Yawangle))
+	binary.LittleEndian.PutUint32(buf[60:], math.Float32bits(m.Yawangleref))
+	binary.LittleEndian.PutUint32(buf[64:], math.Float32bits(m.Rollangle))
+	binary.LittleEndian.PutUint32(buf[68:], math.Float32bits(m.Rollangleref))
+	binary.LittleEndian.PutUint32(buf[72:], math.Float32bits(m.P))
+	binary.LittleEndian.PutUint32(buf[76:], math.Float32bits(m.Pref))
+	binary.LittleEndian.PutUint32(buf[80:], math.Float32bits(m.R))
+	binary.LittleEndian.PutUint32(buf[84:], math.Float32bits(m.Rref))
+	binary.LittleEndian.PutUint32(buf[88:], math.Float32bits(m.Uail))
+	binary.LittleEndian.PutUint32(buf[92:], math.Float32bits(m.Urud))
+	buf[96] = m.AslctrlMode
+	buf[97] = m.Spoilersengaged
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageAslctrlData) UnmarshalPayload(buf []byte) {
+	m.Timestamp = binary.LittleEndian.Uint64(buf[0:])
+	m.H = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.Href = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.HrefT = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	m.Pitchangle = math.Float32frombits(binary.LittleEndian.Uint32(buf[20:]))
+	m.Pitchangleref = math.Float32frombits(binary.LittleEndian.Uint32(buf[24:]))
+	m.Q = math.Float32frombits(binary.LittleEndian.Uint32(buf[28:]))
+	m.Qref = math.Float32frombits(binary.LittleEndian.Uint32(buf[32:]))
+	m.Uelev = math.Float32frombits(binary.LittleEndian.Uint32(buf[36:]))
+	m.Uthrot = math.Float32frombits(binary.LittleEndian.Uint32(buf[40:]))
+	m.Uthrot2 = math.Float32frombits(binary.LittleEndian.Uint32(buf[44:]))
+	m.Nz = math.Float32frombits(binary.LittleEndian.Uint32(buf[48:]))
+	m.Airspeedref = math.Float32frombits(binary.LittleEndian.Uint32(buf[52:]))
+	m.Yawangle = math.Float32frombits(binary.LittleEndian.Uint32(buf[56:]))
+	m.Yawangleref = math.Float32frombits(binary.LittleEndian.Uint32(buf[60:]))
+	m.Rollangle = math.Float32frombits(binary.LittleEndian.Uint32(buf[64:]))
+	m.Rollangleref = math.Float32frombits(binary.LittleEndian.Uint32(buf[68:]))
+	m.P = math.Float32frombits(binary.LittleEndian.Uint32(buf[72:]))
+	m.Pref = math.Float32frombits(binary.LittleEndian.Uint32(buf[76:]))
+	m.R = math.Float32frombits(binary.LittleEndian.Uint32(buf[80:]))
+	m.Rref = math.Float32frombits(binary.LittleEndian.Uint32(buf[84:]))
+	m.Uail = math.Float32frombits(binary.LittleEndian.Uint32(buf[88:]))
+	m.Urud = math.Float32frombits(binary.LittleEndian.Uint32(buf[92:]))
+	m.AslctrlMode = buf[96]
+	m.Spoilersengaged = buf[97]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageAslctrlDebug) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], m.I32_1)
+	binary.LittleEndian.PutUint32(buf[4:], math.Float32bits(m.F_1))
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.F_2))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.F_3))
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.F_4))
+	binary.LittleEndian.PutUint32(buf[20:], math.Float32bits(m.F_5))
+	binary.LittleEndian.PutUint32(buf[24:], math.Float32bits(m.F_6))
+	binary.LittleEndian.PutUint32(buf[28:], math.Float32bits(m.F_7))
+	binary.LittleEndian.PutUint32(buf[32:], math.Float32bits(m.F_8))
+	buf[36] = m.I8_1
+	buf[37] = m.I8_2
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageAslctrlDebug) UnmarshalPayload(buf []byte) {
+	m.I32_1 = binary.LittleEndian.Uint32(buf[0:])
+	m.F_1 = math.Float32frombits(binary.LittleEndian.Uint32(buf[4:]))
+	m.F_2 = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.F_3 = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.F_4 = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	m.F_5 = math.Float32frombits(binary.LittleEndian.Uint32(buf[20:]))
+	m.F_6 = math.Float32frombits(binary.LittleEndian.Uint32(buf[24:]))
+	m.F_7 = math.Float32frombits(binary.LittleEndian.Uint32(buf[28:]))
+	m.F_8 = math.Float32frombits(binary.LittleEndian.Uint32(buf[32:]))
+	m.I8_1 = buf[36]
+	m.I8_2 = buf[37]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageAsluavStatus) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], math.Float32bits(m.MotorRpm))
+	buf[4] = m.LedStatus
+	buf[5] = m.SatcomStatus
+	copy(buf[6:14], m.ServoStatus[:])
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageAsluavStatus) UnmarshalPayload(buf []byte) {
+	m.MotorRpm = math.Float32frombits(binary.LittleEndian.Uint32(buf[0:]))
+	m.LedStatus = buf[4]
+	m.SatcomStatus = buf[5]
+	copy(m.ServoStatus[:], buf[6:14])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageEkfExt) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.Timestamp)
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.Windspeed))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.Winddir))
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.Windz))
+	binary.LittleEndian.PutUint32(buf[20:], math.Float32bits(m.Airspeed))
+	binary.LittleEndian.PutUint32(buf[24:], math.Float32bits(m.Beta))
+	binary.LittleEndian.PutUint32(buf[28:], math.Float32bits(m.Alpha))
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageEkfExt) UnmarshalPayload(buf []byte) {
+	m.Timestamp = binary.LittleEndian.Uint64(buf[0:])
+	m.Windspeed = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.Winddir = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.Windz = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	m.Airspeed = math.Float32frombits(binary.LittleEndian.Uint32(buf[20:]))
+	m.Beta = math.Float32frombits(binary.LittleEndian.Uint32(buf[24:]))
+	m.Alpha = math.Float32frombits(binary.LittleEndian.Uint32(buf[28:]))
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageAslObctrl) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.Timestamp)
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.Uelev))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.Uthrot))
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.Uthrot2))
+	binary.LittleEndian.PutUint32(buf[20:], math.Float32bits(m.Uaill))
+	binary.LittleEndian.PutUint32(buf[24:], math.Float32bits(m.Uailr))
+	binary.LittleEndian.PutUint32(buf[28:], math.Float32bits(m.Urud))
+	buf[32] = m.ObctrlStatus
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageAslObctrl) UnmarshalPayload(buf []byte) {
+	m.Timestamp = binary.LittleEndian.Uint64(buf[0:])
+	m.Uelev = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.Uthrot = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.Uthrot2 = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	m.Uaill = math.Float32frombits(binary.LittleEndian.Uint32(buf[20:]))
+	m.Uailr = math.Float32frombits(binary.LittleEndian.Uint32(buf[24:]))
+	m.Urud = math.Float32frombits(binary.LittleEndian.Uint32(buf[28:]))
+	m.ObctrlStatus = buf[32]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageSensAtmos) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.Timestamp)
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.Tempambient))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.Humidity))
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageSensAtmos) UnmarshalPayload(buf []byte) {
+	m.Timestamp = binary.LittleEndian.Uint64(buf[0:])
+	m.Tempambient = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.Humidity = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageSensBatmon) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.BatmonTimestamp)
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.Temperature))
+	binary.LittleEndian.PutUint32(buf[12:], m.Safetystatus)
+	binary.LittleEndian.PutUint32(buf[16:], m.Operationstatus)
+	binary.LittleEndian.PutUint16(buf[20:], m.Voltage)
+	binary.LittleEndian.PutUint16(buf[22:], uint16(m.Current))
+	binary.LittleEndian.PutUint16(buf[24:], m.Batterystatus)
+	binary.LittleEndian.PutUint16(buf[26:], m.Serialnumber)
+	binary.LittleEndian.PutUint16(buf[28:], m.Cellvoltage1)
+	binary.LittleEndian.PutUint16(buf[30:], m.Cellvoltage2)
+	binary.LittleEndian.PutUint16(buf[32:], m.Cellvoltage3)
+	binary.LittleEndian.PutUint16(buf[34:], m.Cellvoltage4)
+	binary.LittleEndian.PutUint16(buf[36:], m.Cellvoltage5)
+	binary.LittleEndian.PutUint16(buf[38:], m.Cellvoltage6)
+	buf[40] = m.Soc
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageSensBatmon) UnmarshalPayload(buf []byte) {
+	m.BatmonTimestamp = binary.LittleEndian.Uint64(buf[0:])
+	m.Temperature = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.Safetystatus = binary.LittleEndian.Uint32(buf[12:])
+	m.Operationstatus = binary.LittleEndian.Uint32(buf[16:])
+	m.Voltage = binary.LittleEndian.Uint16(buf[20:])
+	m.Current = int16(binary.LittleEndian.Uint16(buf[22:]))
+	m.Batterystatus = binary.LittleEndian.Uint16(buf[24:])
+	m.Serialnumber = binary.LittleEndian.Uint16(buf[26:])
+	m.Cellvoltage1 = binary.LittleEndian.Uint16(buf[28:])
+	m.Cellvoltage2 = binary.LittleEndian.Uint16(buf[30:])
+	m.Cellvoltage3 = binary.LittleEndian.Uint16(buf[32:])
+	m.Cellvoltage4 = binary.LittleEndian.Uint16(buf[34:])
+	m.Cellvoltage5 = binary.LittleEndian.Uint16(buf[36:])
+	m.Cellvoltage6 = binary.LittleEndian.Uint16(buf[38:])
+	m.Soc = buf[40]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageFwSoaringData) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.Timestamp)
+	binary.LittleEndian.PutUint64(buf[8:], m.Timestampmodechanged)
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.Xw))
+	binary.LittleEndian.PutUint32(buf[20:], math.Float32bits(m.Xr))
+	binary.LittleEndian.PutUint32(buf[24:], math.Float32bits(m.Xlat))
+	binary.LittleEndian.PutUint32(buf[28:], math.Float32bits(m.Xlon))
+	binary.LittleEndian.PutUint32(buf[32:], math.Float32bits(m.Varw))
+	binary.LittleEndian.PutUint32(buf[36:], math.Float32bits(m.Varr))
+	binary.LittleEndian.PutUint32(buf[40:], math.Float32bits(m.Varlat))
+	binary.LittleEndian.PutUint32(buf[44:], math.Float32bits(m.Varlon))
+	binary.LittleEndian.PutUint32(buf[48:], math.Float32bits(m.Loiterradius))
+	binary.LittleEndian.PutUint32(buf[52:], math.Float32bits(m.Loiterdirection))
+	binary.LittleEndian.PutUint32(buf[56:], math.Float32bits(m.Disttosoarpoint))
+	binary.LittleEndian.PutUint32(buf[60:], math.Float32bits(m.Vsinkexp))
+	binary.LittleEndian.PutUint32(buf[64:], math.Float32bits(m.Z1Localupdraftspeed))
+	binary.LittleEndian.PutUint32(buf[68:], math.Float32bits(m.Z2Deltaroll))
+	binary.LittleEndian.PutUint32(buf[72:], math.Float32bits(m.Z1Exp))
+	binary.LittleEndian.PutUint32(buf[76:], math.Float32bits(m.Z2Exp))
+	binary.LittleEndian.PutUint32(buf[80:], math.Float32bits(m.Thermalgsnorth))
+	binary.LittleEndian.PutUint32(buf[84:], math.Float32bits(m.Thermalgseast))
+	binary.LittleEndian.PutUint32(buf[88:], math.Float32bits(m.TseDot))
+	binary.LittleEndian.PutUint32(buf[92:], math.Float32bits(m.Debugvar1))
+	binary.LittleEndian.PutUint32(buf[96:], math.Float32bits(m.Debugvar2))
+	buf[100] = m.Controlmode
+	buf[101] = m.Valid
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageFwSoaringData) UnmarshalPayload(buf []byte) {
+	m.Timestamp = binary.LittleEndian.Uint64(buf[0:])
+	m.Timestampmodechanged = binary.LittleEndian.Uint64(buf[8:])
+	m.Xw = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	m.Xr = math.Float32frombits(binary.LittleEndian.Uint32(buf[20:]))
+	m.Xlat = math.Float32frombits(binary.LittleEndian.Uint32(buf[24:]))
+	m.Xlon = math.Float32frombits(binary.LittleEndian.Uint32(buf[28:]))
+	m.Varw = math.Float32frombits(binary.LittleEndian.Uint32(buf[32:]))
+	m.Varr = math.Float32frombits(binary.LittleEndian.Uint32(buf[36:]))
+	m.Varlat = math.Float32frombits(binary.LittleEndian.Uint32(buf[40:]))
+	m.Varlon = math.Float32frombits(binary.LittleEndian.Uint32(buf[44:]))
+	m.Loiterradius = math.Float32frombits(binary.LittleEndian.Uint32(buf[48:]))
+	m.Loiterdirection = math.Float32frombits(binary.LittleEndian.Uint32(buf[52:]))
+	m.Disttosoarpoint = math.Float32frombits(binary.LittleEndian.Uint32(buf[56:]))
+	m.Vsinkexp = math.Float32frombits(binary.LittleEndian.Uint32(buf[60:]))
+	m.Z1Localupdraftspeed = math.Float32frombits(binary.LittleEndian.Uint32(buf[64:]))
+	m.Z2Deltaroll = math.Float32frombits(binary.LittleEndian.Uint32(buf[68:]))
+	m.Z1Exp = math.Float32frombits(binary.LittleEndian.Uint32(buf[72:]))
+	m.Z2Exp = math.Float32frombits(binary.LittleEndian.Uint32(buf[76:]))
+	m.Thermalgsnorth = math.Float32frombits(binary.LittleEndian.Uint32(buf[80:]))
+	m.Thermalgseast = math.Float32frombits(binary.LittleEndian.Uint32(buf[84:]))
+	m.TseDot = math.Float32frombits(binary.LittleEndian.Uint32(buf[88:]))
+	m.Debugvar1 = math.Float32frombits(binary.LittleEndian.Uint32(buf[92:]))
+	m.Debugvar2 = math.Float32frombits(binary.LittleEndian.Uint32(buf[96:]))
+	m.Controlmode = buf[100]
+	m.Valid = buf[101]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageSensorpodStatus) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.Timestamp)
+	binary.LittleEndian.PutUint16(buf[8:], m.FreeSpace)
+	buf[10] = m.VisensorRate_1
+	buf[11] = m.VisensorRate_2
+	buf[12] = m.VisensorRate_3
+	buf[13] = m.VisensorRate_4
+	buf[14] = m.RecordingNodesCount
+	buf[15] = m.CpuTemp
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageSensorpodStatus) UnmarshalPayload(buf []byte) {
+	m.Timestamp = binary.LittleEndian.Uint64(buf[0:])
+	m.FreeSpace = binary.LittleEndian.Uint16(buf[8:])
+	m.VisensorRate_1 = buf[10]
+	m.VisensorRate_2 = buf[11]
+	m.VisensorRate_3 = buf[12]
+	m.VisensorRate_4 = buf[13]
+	m.RecordingNodesCount = buf[14]
+	m.CpuTemp = buf[15]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageSensPowerBoard) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.Timestamp)
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.PwrBrdSystemVolt))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.PwrBrdServoVolt))
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.PwrBrdDigitalVolt))
+	binary.LittleEndian.PutUint32(buf[20:], math.Float32bits(m.PwrBrdMotLAmp))
+	binary.LittleEndian.PutUint32(buf[24:], math.Float32bits(m.PwrBrdMotRAmp))
+	binary.LittleEndian.PutUint32(buf[28:], math.Float32bits(m.PwrBrdAnalogAmp))
+	binary.LittleEndian.PutUint32(buf[32:], math.Float32bits(m.PwrBrdDigitalAmp))
+	binary.LittleEndian.PutUint32(buf[36:], math.Float32bits(m.PwrBrdExtAmp))
+	binary.LittleEndian.PutUint32(buf[40:], math.Float32bits(m.PwrBrdAuxAmp))
+	buf[44] = m.PwrBrdStatus
+	buf[45] = m.PwrBrdLedStatus
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageSensPowerBoard) UnmarshalPayload(buf []byte) {
+	m.Timestamp = binary.LittleEndian.Uint64(buf[0:])
+	m.PwrBrdSystemVolt = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.PwrBrdServoVolt = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.PwrBrdDigitalVolt = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	m.PwrBrdMotLAmp = math.Float32frombits(binary.LittleEndian.Uint32(buf[20:]))
+	m.PwrBrdMotRAmp = math.Float32frombits(binary.LittleEndian.Uint32(buf[24:]))
+	m.PwrBrdAnalogAmp = math.Float32frombits(binary.LittleEndian.Uint32(buf[28:]))
+	m.PwrBrdDigitalAmp = math.Float32frombits(binary.LittleEndian.Uint32(buf[32:]))
+	m.PwrBrdExtAmp = math.Float32frombits(binary.LittleEndian.Uint32(buf[36:]))
+	m.PwrBrdAuxAmp = math.Float32frombits(binary.LittleEndian.Uint32(buf[40:]))
+	m.PwrBrdStatus = buf[44]
+	m.PwrBrdLedStatus = buf[45]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageGsmLinkStatus) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.Timestamp)
+	buf[8] = uint8(m.GsmModemType)
+	buf[9] = uint8(m.GsmLinkType)
+	buf[10] = m.Rssi
+	buf[11] = m.RsrpRscp
+	buf[12] = m.SinrEcio
+	buf[13] = m.Rsrq
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageGsmLinkStatus) UnmarshalPayload(buf []byte) {
+	m.Timestamp = binary.LittleEndian.Uint64(buf[0:])
+	m.GsmModemType = GSM_MODEM_TYPE(buf[8])
+	m.GsmLinkType = GSM_LINK_TYPE(buf[9])
+	m.Rssi = buf[10]
+	m.RsrpRscp = buf[11]
+	m.SinrEcio = buf[12]
+	m.Rsrq = buf[13]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageSatcomLinkStatus) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.Timestamp)
+	binary.LittleEndian.PutUint64(buf[8:], m.LastHeartbeat)
+	binary.LittleEndian.PutUint16(buf[16:], m.FailedSessions)
+	binary.LittleEndian.PutUint16(buf[18:], m.SuccessfulSessions)
+	buf[20] = m.SignalQuality
+	buf[21] = m.RingPending
+	buf[22] = m.TxSessionPending
+	buf[23] = m.RxSessionPending
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageSatcomLinkStatus) UnmarshalPayload(buf []byte) {
+	m.Timestamp = binary.LittleEndian.Uint64(buf[0:])
+	m.LastHeartbeat = binary.LittleEndian.Uint64(buf[8:])
+	m.FailedSessions = binary.LittleEndian.Uint16(buf[16:])
+	m.SuccessfulSessions = binary.LittleEndian.Uint16(buf[18:])
+	m.SignalQuality = buf[20]
+	m.RingPending = buf[21]
+	m.TxSessionPending = buf[22]
+	m.RxSessionPending = buf[23]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageSensorAirflowAngles) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.Timestamp)
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.Angleofattack))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.Sideslip))
+	buf[16] = m.AngleofattackValid
+	buf[17] = m.SideslipValid
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageSensorAirflowAngles) UnmarshalPayload(buf []byte) {
+	m.Timestamp = binary.LittleEndian.Uint64(buf[0:])
+	m.Angleofattack = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.Sideslip = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.AngleofattackValid = buf[16]
+	m.SideslipValid = buf[17]
+}
+
+func decodeString(buf []byte) string {
+	end := 0
+	for end < len(buf) && buf[end] != 0 {
+		end++
+	}
+	return string(buf[:end])
+}
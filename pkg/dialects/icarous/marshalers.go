@@ -0,0 +1,59 @@
+//nolint:golint,misspell,govet
+package icarous
+
+// Code generated by dialect-marshalers. DO NOT EDIT.
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageIcarousHeartbeat) MarshalPayload(buf []byte) {
+	buf[0] = uint8(m.Status)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageIcarousHeartbeat) UnmarshalPayload(buf []byte) {
+	m.Status = ICAROUS_FMS_STATE(buf[0])
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageIcarousKinematicBands) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], math.Float32bits(m.Min1))
+	binary.LittleEndian.PutUint32(buf[4:], math.Float32bits(m.Max1))
+	binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(m.Min2))
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(m.Max2))
+	binary.LittleEndian.PutUint32(buf[16:], math.Float32bits(m.Min3))
+	binary.LittleEndian.PutUint32(buf[20:], math.Float32bits(m.Max3))
+	binary.LittleEndian.PutUint32(buf[24:], math.Float32bits(m.Min4))
+	binary.LittleEndian.PutUint32(buf[28:], math.Float32bits(m.Max4))
+	binary.LittleEndian.PutUint32(buf[32:], math.Float32bits(m.Min5))
+	binary.LittleEndian.PutUint32(buf[36:], math.Float32bits(m.Max5))
+	buf[40] = uint8(m.Numbands)
+	buf[41] = uint8(m.Type1)
+	buf[42] = uint8(m.Type2)
+	buf[43] = uint8(m.Type3)
+	buf[44] = uint8(m.Type4)
+	buf[45] = uint8(m.Type5)
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageIcarousKinematicBands) UnmarshalPayload(buf []byte) {
+	m.Min1 = math.Float32frombits(binary.LittleEndian.Uint32(buf[0:]))
+	m.Max1 = math.Float32frombits(binary.LittleEndian.Uint32(buf[4:]))
+	m.Min2 = math.Float32frombits(binary.LittleEndian.Uint32(buf[8:]))
+	m.Max2 = math.Float32frombits(binary.LittleEndian.Uint32(buf[12:]))
+	m.Min3 = math.Float32frombits(binary.LittleEndian.Uint32(buf[16:]))
+	m.Max3 = math.Float32frombits(binary.LittleEndian.Uint32(buf[20:]))
+	m.Min4 = math.Float32frombits(binary.LittleEndian.Uint32(buf[24:]))
+	m.Max4 = math.Float32frombits(binary.LittleEndian.Uint32(buf[28:]))
+	m.Min5 = math.Float32frombits(binary.LittleEndian.Uint32(buf[32:]))
+	m.Max5 = math.Float32frombits(binary.LittleEndian.Uint32(buf[36:]))
+	m.Numbands = int8(buf[40])
+	m.Type1 = ICAROUS_TRACK_BAND_TYPES(buf[41])
+	m.Type2 = ICAROUS_TRACK_BAND_TYPES(buf[42])
+	m.Type3 = ICAROUS_TRACK_BAND_TYPES(buf[43])
+	m.Type4 = ICAROUS_TRACK_BAND_TYPES(buf[44])
+	m.Type5 = ICAROUS_TRACK_BAND_TYPES(buf[45])
+}
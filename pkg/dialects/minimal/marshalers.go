@@ -0,0 +1,46 @@
+//nolint:golint,misspell,govet
+package minimal
+
+// Code generated by dialect-marshalers. DO NOT EDIT.
+
+import (
+	"encoding/binary"
+)
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageHeartbeat) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[0:], m.CustomMode)
+	buf[4] = uint8(m.Type)
+	buf[5] = uint8(m.Autopilot)
+	buf[6] = uint8(m.BaseMode)
+	buf[7] = uint8(m.SystemStatus)
+	buf[8] = m.MavlinkVersion
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageHeartbeat) UnmarshalPayload(buf []byte) {
+	m.CustomMode = binary.LittleEndian.Uint32(buf[0:])
+	m.Type = MAV_TYPE(buf[4])
+	m.Autopilot = MAV_AUTOPILOT(buf[5])
+	m.BaseMode = MAV_MODE_FLAG(buf[6])
+	m.SystemStatus = MAV_STATE(buf[7])
+	m.MavlinkVersion = buf[8]
+}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageProtocolVersion) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint16(buf[0:], m.Version)
+	binary.LittleEndian.PutUint16(buf[2:], m.MinVersion)
+	binary.LittleEndian.PutUint16(buf[4:], m.MaxVersion)
+	copy(buf[6:14], m.SpecVersionHash[:])
+	copy(buf[14:22], m.LibraryVersionHash[:])
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageProtocolVersion) UnmarshalPayload(buf []byte) {
+	m.Version = binary.LittleEndian.Uint16(buf[0:])
+	m.MinVersion = binary.LittleEndian.Uint16(buf[2:])
+	m.MaxVersion = binary.LittleEndian.Uint16(buf[4:])
+	copy(m.SpecVersionHash[:], buf[6:14])
+	copy(m.LibraryVersionHash[:], buf[14:22])
+}
@@ -0,0 +1,105 @@
+//nolint:golint,misspell,govet
+package test
+
+// Code generated by dialect-marshalers. DO NOT EDIT.
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *MessageTestTypes) MarshalPayload(buf []byte) {
+	binary.LittleEndian.PutUint64(buf[0:], m.U64)
+	binary.LittleEndian.PutUint64(buf[8:], uint64(m.S64))
+	binary.LittleEndian.PutUint64(buf[16:], math.Float64bits(m.D))
+	for i, v := range m.U64Array {
+		binary.LittleEndian.PutUint64(buf[24+i*8:], v)
+	}
+	for i, v := range m.S64Array {
+		binary.LittleEndian.PutUint64(buf[48+i*8:], uint64(v))
+	}
+	for i, v := range m.DArray {
+		binary.LittleEndian.PutUint64(buf[72+i*8:], math.Float64bits(v))
+	}
+	binary.LittleEndian.PutUint32(buf[96:], m.U32)
+	binary.LittleEndian.PutUint32(buf[100:], uint32(m.S32))
+	binary.LittleEndian.PutUint32(buf[104:], math.Float32bits(m.F))
+	for i, v := range m.U32Array {
+		binary.LittleEndian.PutUint32(buf[108+i*4:], v)
+	}
+	for i, v := range m.S32Array {
+		binary.LittleEndian.PutUint32(buf[120+i*4:], uint32(v))
+	}
+	for i, v := range m.FArray {
+		binary.LittleEndian.PutUint32(buf[132+i*4:], math.Float32bits(v))
+	}
+	binary.LittleEndian.PutUint16(buf[144:], m.U16)
+	binary.LittleEndian.PutUint16(buf[146:], uint16(m.S16))
+	for i, v := range m.U16Array {
+		binary.LittleEndian.PutUint16(buf[148+i*2:], v)
+	}
+	for i, v := range m.S16Array {
+		binary.LittleEndian.PutUint16(buf[154+i*2:], uint16(v))
+	}
+	copy(buf[160:161], m.C)
+	copy(buf[161:171], m.S)
+	buf[171] = m.U8
+	buf[172] = uint8(m.S8)
+	copy(buf[173:176], m.U8Array[:])
+	for i, v := range m.S8Array {
+		buf[176+i] = uint8(v)
+	}
+}
+
+// UnmarshalPayload implements the msg.Marshaler interface.
+func (m *MessageTestTypes) UnmarshalPayload(buf []byte) {
+	m.U64 = binary.LittleEndian.Uint64(buf[0:])
+	m.S64 = int64(binary.LittleEndian.Uint64(buf[8:]))
+	m.D = math.Float64frombits(binary.LittleEndian.Uint64(buf[16:]))
+	for i := range m.U64Array {
+		m.U64Array[i] = binary.LittleEndian.Uint64(buf[24+i*8:])
+	}
+	for i := range m.S64Array {
+		m.S64Array[i] = int64(binary.LittleEndian.Uint64(buf[48+i*8:]))
+	}
+	for i := range m.DArray {
+		m.DArray[i] = math.Float64frombits(binary.LittleEndian.Uint64(buf[72+i*8:]))
+	}
+	m.U32 = binary.LittleEndian.Uint32(buf[96:])
+	m.S32 = int32(binary.LittleEndian.Uint32(buf[100:]))
+	m.F = math.Float32frombits(binary.LittleEndian.Uint32(buf[104:]))
+	for i := range m.U32Array {
+		m.U32Array[i] = binary.LittleEndian.Uint32(buf[108+i*4:])
+	}
+	for i := range m.S32Array {
+		m.S32Array[i] = int32(binary.LittleEndian.Uint32(buf[120+i*4:]))
+	}
+	for i := range m.FArray {
+		m.FArray[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[132+i*4:]))
+	}
+	m.U16 = binary.LittleEndian.Uint16(buf[144:])
+	m.S16 = int16(binary.LittleEndian.Uint16(buf[146:]))
+	for i := range m.U16Array {
+		m.U16Array[i] = binary.LittleEndian.Uint16(buf[148+i*2:])
+	}
+	for i := range m.S16Array {
+		m.S16Array[i] = int16(binary.LittleEndian.Uint16(buf[154+i*2:]))
+	}
+	m.C = decodeString(buf[160:161])
+	m.S = decodeString(buf[161:171])
+	m.U8 = buf[171]
+	m.S8 = int8(buf[172])
+	copy(m.U8Array[:], buf[173:176])
+	for i := range m.S8Array {
+		m.S8Array[i] = int8(buf[176+i])
+	}
+}
+
+func decodeString(buf []byte) string {
+	end := 0
+	for end < len(buf) && buf[end] != 0 {
+		end++
+	}
+	return string(buf[:end])
+}
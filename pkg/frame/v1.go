@@ -126,16 +126,16 @@ func (f *V1Frame) Encode(buf []byte, msgEncoded []byte) ([]byte, error) {
 // GenChecksum implements the Frame interface.
 func (f *V1Frame) GenChecksum(crcExtra byte) uint16 {
 	msg := f.GetMessage().(*msg.MessageRaw)
-	h := x25.New()
 
-	h.Write([]byte{byte(len(msg.Content))})
-	h.Write([]byte{f.SequenceID})
-	h.Write([]byte{f.SystemID})
-	h.Write([]byte{f.ComponentID})
-	h.Write([]byte{byte(msg.ID)})
-	h.Write(msg.Content)
-
-	h.Write([]byte{crcExtra})
+	header := [5]byte{
+		byte(len(msg.Content)),
+		f.SequenceID,
+		f.SystemID,
+		f.ComponentID,
+		byte(msg.ID),
+	}
 
-	return h.Sum16()
+	crc := x25.Update(x25.Init, header[:])
+	crc = x25.Update(crc, msg.Content)
+	return x25.Update(crc, []byte{crcExtra})
 }
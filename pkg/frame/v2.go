@@ -70,6 +70,12 @@ type V2Frame struct {
 	SignatureLinkID     byte
 	SignatureTimestamp  uint64
 	Signature           *V2Signature
+
+	// the length of the message payload, as read from the wire.
+	// It is filled when decoding and ignored when encoding.
+	// Payloads of V2 frames can be shorter than their message, since trailing
+	// zero bytes are truncated.
+	PayloadLength byte
 }
 
 // Clone implements the Frame interface.
@@ -85,6 +91,7 @@ func (f *V2Frame) Clone() Frame {
 		SignatureLinkID:     f.SignatureLinkID,
 		SignatureTimestamp:  f.SignatureTimestamp,
 		Signature:           f.Signature,
+		PayloadLength:       f.PayloadLength,
 	}
 }
 
@@ -113,6 +120,12 @@ func (f *V2Frame) IsSigned() bool {
 	return (f.IncompatibilityFlag & V2FlagSigned) != 0
 }
 
+// HasUnknownIncompatibilityFlags checks whether the frame contains
+// incompatibility flags that are not understood.
+func (f *V2Frame) HasUnknownIncompatibilityFlags() bool {
+	return (f.IncompatibilityFlag &^ V2FlagSigned) != 0
+}
+
 // Decode implements the Frame interface.
 func (f *V2Frame) Decode(br *bufio.Reader) error {
 	// header
@@ -122,6 +135,7 @@ func (f *V2Frame) Decode(br *bufio.Reader) error {
 	}
 	br.Discard(9)
 	msgLen := buf[0]
+	f.PayloadLength = msgLen
 	f.IncompatibilityFlag = buf[1]
 	f.CompatibilityFlag = buf[2]
 	f.SequenceID = buf[3]
@@ -130,7 +144,7 @@ func (f *V2Frame) Decode(br *bufio.Reader) error {
 	msgID := uint24Decode(buf[6:])
 
 	// discard frame if incompatibility flag is not understood, as in recommendations
-	if f.IncompatibilityFlag != 0 && f.IncompatibilityFlag != V2FlagSigned {
+	if f.HasUnknownIncompatibilityFlags() {
 		return fmt.Errorf("unknown incompatibility flag (%d)", f.IncompatibilityFlag)
 	}
 
@@ -212,21 +226,19 @@ func (f *V2Frame) Encode(buf []byte, msgEncoded []byte) ([]byte, error) {
 // GenChecksum implements the Frame interface.
 func (f *V2Frame) GenChecksum(crcExtra byte) uint16 {
 	msg := f.GetMessage().(*msg.MessageRaw)
-	h := x25.New()
-
-	buf := make([]byte, 3)
-	h.Write([]byte{byte(len(msg.Content))})
-	h.Write([]byte{f.IncompatibilityFlag})
-	h.Write([]byte{f.CompatibilityFlag})
-	h.Write([]byte{f.SequenceID})
-	h.Write([]byte{f.SystemID})
-	h.Write([]byte{f.ComponentID})
-	h.Write(uint24Encode(buf, msg.ID))
-	h.Write(msg.Content)
-
-	h.Write([]byte{crcExtra})
 
-	return h.Sum16()
+	var header [9]byte
+	header[0] = byte(len(msg.Content))
+	header[1] = f.IncompatibilityFlag
+	header[2] = f.CompatibilityFlag
+	header[3] = f.SequenceID
+	header[4] = f.SystemID
+	header[5] = f.ComponentID
+	uint24Encode(header[6:], msg.ID)
+
+	crc := x25.Update(x25.Init, header[:])
+	crc = x25.Update(crc, msg.Content)
+	return x25.Update(crc, []byte{crcExtra})
 }
 
 // GenSignature generates a signature with the given key.
@@ -0,0 +1,12 @@
+module github.com/aler9/gomavlib/pkg/grpcbridge
+
+go 1.14
+
+require (
+	github.com/aler9/gomavlib v0.0.0
+	github.com/stretchr/testify v1.7.0
+	google.golang.org/grpc v1.43.0
+	google.golang.org/protobuf v1.27.1
+)
+
+replace github.com/aler9/gomavlib => ../../
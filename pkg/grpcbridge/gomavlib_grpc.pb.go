@@ -0,0 +1,169 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package grpcbridge
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// MavlinkClient is the client API for Mavlink service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type MavlinkClient interface {
+	// Frames streams frames received by the node.
+	Frames(ctx context.Context, in *FramesRequest, opts ...grpc.CallOption) (Mavlink_FramesClient, error)
+	// Send writes a message to channels of the node.
+	Send(ctx context.Context, in *SendRequest, opts ...grpc.CallOption) (*SendResponse, error)
+}
+
+type mavlinkClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMavlinkClient(cc grpc.ClientConnInterface) MavlinkClient {
+	return &mavlinkClient{cc}
+}
+
+func (c *mavlinkClient) Frames(ctx context.Context, in *FramesRequest, opts ...grpc.CallOption) (Mavlink_FramesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Mavlink_ServiceDesc.Streams[0], "/gomavlib.Mavlink/Frames", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &mavlinkFramesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Mavlink_FramesClient interface {
+	Recv() (*Frame, error)
+	grpc.ClientStream
+}
+
+type mavlinkFramesClient struct {
+	grpc.ClientStream
+}
+
+func (x *mavlinkFramesClient) Recv() (*Frame, error) {
+	m := new(Frame)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *mavlinkClient) Send(ctx context.Context, in *SendRequest, opts ...grpc.CallOption) (*SendResponse, error) {
+	out := new(SendResponse)
+	err := c.cc.Invoke(ctx, "/gomavlib.Mavlink/Send", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MavlinkServer is the server API for Mavlink service.
+// All implementations must embed UnimplementedMavlinkServer
+// for forward compatibility
+type MavlinkServer interface {
+	// Frames streams frames received by the node.
+	Frames(*FramesRequest, Mavlink_FramesServer) error
+	// Send writes a message to channels of the node.
+	Send(context.Context, *SendRequest) (*SendResponse, error)
+	mustEmbedUnimplementedMavlinkServer()
+}
+
+// UnimplementedMavlinkServer must be embedded to have forward compatible implementations.
+type UnimplementedMavlinkServer struct {
+}
+
+func (UnimplementedMavlinkServer) Frames(*FramesRequest, Mavlink_FramesServer) error {
+	return status.Errorf(codes.Unimplemented, "method Frames not implemented")
+}
+func (UnimplementedMavlinkServer) Send(context.Context, *SendRequest) (*SendResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Send not implemented")
+}
+func (UnimplementedMavlinkServer) mustEmbedUnimplementedMavlinkServer() {}
+
+// UnsafeMavlinkServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MavlinkServer will
+// result in compilation errors.
+type UnsafeMavlinkServer interface {
+	mustEmbedUnimplementedMavlinkServer()
+}
+
+func RegisterMavlinkServer(s grpc.ServiceRegistrar, srv MavlinkServer) {
+	s.RegisterService(&Mavlink_ServiceDesc, srv)
+}
+
+func _Mavlink_Frames_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FramesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MavlinkServer).Frames(m, &mavlinkFramesServer{stream})
+}
+
+type Mavlink_FramesServer interface {
+	Send(*Frame) error
+	grpc.ServerStream
+}
+
+type mavlinkFramesServer struct {
+	grpc.ServerStream
+}
+
+func (x *mavlinkFramesServer) Send(m *Frame) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Mavlink_Send_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MavlinkServer).Send(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gomavlib.Mavlink/Send",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MavlinkServer).Send(ctx, req.(*SendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Mavlink_ServiceDesc is the grpc.ServiceDesc for Mavlink service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Mavlink_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gomavlib.Mavlink",
+	HandlerType: (*MavlinkServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Send",
+			Handler:    _Mavlink_Send_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Frames",
+			Handler:       _Mavlink_Frames_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "gomavlib.proto",
+}
@@ -0,0 +1,260 @@
+// Package grpcbridge exposes a Node through the gRPC service defined in
+// api/gomavlib.proto, such that non-Go processes and remote services can use
+// it as a Mavlink gateway.
+//
+// The package is a separate Go module, in order not to add gRPC to the
+// dependencies of the library. The client is the generated MavlinkClient:
+//
+//	conn, err := grpc.Dial("localhost:5800", grpc.WithInsecure())
+//	client := grpcbridge.NewMavlinkClient(conn)
+//	stream, err := client.Frames(ctx, &grpcbridge.FramesRequest{})
+package grpcbridge
+
+//go:generate protoc --proto_path=../../api --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative gomavlib.proto
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/aler9/gomavlib"
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/frame"
+	"github.com/aler9/gomavlib/pkg/msg"
+	"github.com/aler9/gomavlib/pkg/rest"
+)
+
+const (
+	streamQueueSize = 64
+)
+
+type stream struct {
+	req *FramesRequest
+	ch  chan *Frame
+}
+
+func (s *stream) matches(fr *Frame) bool {
+	if s.req.SystemId != 0 && s.req.SystemId != fr.SystemId {
+		return false
+	}
+
+	if s.req.ComponentId != 0 && s.req.ComponentId != fr.ComponentId {
+		return false
+	}
+
+	if len(s.req.MessageIds) != 0 {
+		for _, id := range s.req.MessageIds {
+			if id == fr.MessageId {
+				return true
+			}
+		}
+		return false
+	}
+
+	return true
+}
+
+// ServerConf allows to configure a Server.
+type ServerConf struct {
+	// the node.
+	Node *gomavlib.Node
+	// the dialect, used to encode and decode messages.
+	// It must be the same dialect used by the node.
+	Dialect *dialect.Dialect
+}
+
+// Server implements the Mavlink gRPC service on top of a Node.
+// It can be registered into a grpc.Server with Register().
+//
+// Received frames must be passed to the server with OnEventFrame().
+type Server struct {
+	UnimplementedMavlinkServer
+
+	conf ServerConf
+	de   *dialect.DecEncoder
+
+	mutex   sync.Mutex
+	streams map[*stream]struct{}
+}
+
+// NewServer allocates a Server. See ServerConf for the options.
+func NewServer(conf ServerConf) (*Server, error) {
+	if conf.Node == nil {
+		return nil, fmt.Errorf("node not provided")
+	}
+
+	s := &Server{
+		conf:    conf,
+		streams: make(map[*stream]struct{}),
+	}
+
+	if conf.Dialect != nil {
+		var err error
+		s.de, err = dialect.NewDecEncoder(conf.Dialect)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// Register registers the server into a grpc.Server.
+func (s *Server) Register(gs *grpc.Server) {
+	RegisterMavlinkServer(gs, s)
+}
+
+// OnEventFrame forwards a received frame to connected streams.
+// It must be called for every *gomavlib.EventFrame received from the node.
+func (s *Server) OnEventFrame(evt *gomavlib.EventFrame) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if len(s.streams) == 0 {
+		return
+	}
+
+	fr := s.encodeFrame(evt)
+
+	for st := range s.streams {
+		if !st.matches(fr) {
+			continue
+		}
+
+		// drop the frame if the client is too slow
+		select {
+		case st.ch <- fr:
+		default:
+		}
+	}
+}
+
+func (s *Server) encodeFrame(evt *gomavlib.EventFrame) *Frame {
+	m := evt.Message()
+
+	fr := &Frame{
+		Time:        time.Now().UnixNano(),
+		SystemId:    uint32(evt.SystemID()),
+		ComponentId: uint32(evt.ComponentID()),
+		Sequence:    uint32(frameSequenceID(evt.Frame)),
+		MessageId:   m.GetID(),
+	}
+
+	if evt.Channel != nil {
+		fr.Channel = evt.Channel.Label()
+	}
+
+	if raw, ok := m.(*msg.MessageRaw); ok {
+		fr.Payload = raw.Content
+		return fr
+	}
+
+	fr.MessageName = msg.Name(m)
+
+	if s.de != nil {
+		if mde, ok := s.de.MessageDEs[m.GetID()]; ok {
+			// errors are not possible since the message is in the dialect
+			fr.Payload, _ = mde.Encode(m, true)
+		}
+	}
+
+	if byts, err := rest.MarshalMessage(m); err == nil {
+		fr.Json = string(byts)
+	}
+
+	return fr
+}
+
+func frameSequenceID(fr frame.Frame) byte {
+	switch ff := fr.(type) {
+	case *frame.V1Frame:
+		return ff.SequenceID
+
+	case *frame.V2Frame:
+		return ff.SequenceID
+	}
+	return 0
+}
+
+// Frames implements MavlinkServer.
+func (s *Server) Frames(req *FramesRequest, srv Mavlink_FramesServer) error {
+	st := &stream{
+		req: req,
+		ch:  make(chan *Frame, streamQueueSize),
+	}
+
+	s.mutex.Lock()
+	s.streams[st] = struct{}{}
+	s.mutex.Unlock()
+
+	defer func() {
+		s.mutex.Lock()
+		delete(s.streams, st)
+		s.mutex.Unlock()
+	}()
+
+	// the stream is registered before headers are sent, therefore clients can
+	// wait for headers in order to know when frames start being streamed.
+	err := srv.SendHeader(metadata.MD{})
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case fr := <-st.ch:
+			err := srv.Send(fr)
+			if err != nil {
+				return err
+			}
+
+		case <-srv.Context().Done():
+			return nil
+		}
+	}
+}
+
+func (s *Server) decodeMessage(req *SendRequest) (msg.Message, error) {
+	if req.Json != "" {
+		return rest.UnmarshalMessage(s.conf.Dialect, []byte(req.Json))
+	}
+
+	if s.de == nil {
+		return nil, fmt.Errorf("dialect not provided")
+	}
+
+	mde, ok := s.de.MessageDEs[req.MessageId]
+	if !ok {
+		return nil, fmt.Errorf("message %d is not in the dialect", req.MessageId)
+	}
+
+	return mde.Decode(req.Payload, true)
+}
+
+// Send implements MavlinkServer.
+func (s *Server) Send(ctx context.Context, req *SendRequest) (*SendResponse, error) {
+	m, err := s.decodeMessage(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if req.Channel == "" {
+		s.conf.Node.WriteMessageAll(m)
+		return &SendResponse{}, nil
+	}
+
+	for _, ch := range s.conf.Node.Channels() {
+		if ch.Label() == req.Channel {
+			s.conf.Node.WriteMessageTo(ch, m)
+			return &SendResponse{}, nil
+		}
+	}
+
+	return nil, status.Errorf(codes.NotFound, "channel '%s' not found", req.Channel)
+}
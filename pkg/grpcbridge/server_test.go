@@ -0,0 +1,218 @@
+package grpcbridge
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/aler9/gomavlib"
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+	"github.com/aler9/gomavlib/pkg/frame"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+type testRWC struct {
+	written chan []byte
+	done    chan struct{}
+}
+
+func (t *testRWC) Read(p []byte) (int, error) {
+	<-t.done
+	return 0, errTerminated
+}
+
+func (t *testRWC) Write(p []byte) (int, error) {
+	cpy := make([]byte, len(p))
+	copy(cpy, p)
+	select {
+	case t.written <- cpy:
+	case <-t.done:
+	}
+	return len(p), nil
+}
+
+func (t *testRWC) Close() error {
+	close(t.done)
+	return nil
+}
+
+type testError struct{}
+
+func (testError) Error() string { return "terminated" }
+
+var errTerminated = testError{}
+
+type testEnv struct {
+	node   *gomavlib.Node
+	rwc    *testRWC
+	server *Server
+	gs     *grpc.Server
+	conn   *grpc.ClientConn
+	client MavlinkClient
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	env := &testEnv{
+		rwc: &testRWC{
+			written: make(chan []byte, 10),
+			done:    make(chan struct{}),
+		},
+	}
+
+	var err error
+	env.node, err = gomavlib.NewNode(gomavlib.NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  gomavlib.V2,
+		OutSystemID: 10,
+		Endpoints: []gomavlib.EndpointConf{
+			gomavlib.EndpointCustom{ReadWriteCloser: env.rwc},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+
+	go func() {
+		for range env.node.Events() {
+		}
+	}()
+
+	env.server, err = NewServer(ServerConf{
+		Node:    env.node,
+		Dialect: common.Dialect,
+	})
+	require.NoError(t, err)
+
+	ln := bufconn.Listen(1024 * 1024)
+	env.gs = grpc.NewServer()
+	env.server.Register(env.gs)
+	go env.gs.Serve(ln) //nolint:errcheck
+
+	env.conn, err = grpc.Dial("bufconn",
+		grpc.WithInsecure(),
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return ln.Dial()
+		}))
+	require.NoError(t, err)
+
+	env.client = NewMavlinkClient(env.conn)
+
+	return env
+}
+
+func (env *testEnv) close() {
+	env.conn.Close()
+	env.gs.Stop()
+	env.node.Close()
+}
+
+func testEventFrame(m msg.Message) *gomavlib.EventFrame {
+	return &gomavlib.EventFrame{
+		Frame: &frame.V2Frame{
+			SequenceID:  3,
+			SystemID:    1,
+			ComponentID: 2,
+			Message:     m,
+		},
+	}
+}
+
+func TestServerFrames(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := env.client.Frames(ctx, &FramesRequest{
+		MessageIds: []uint32{0},
+	})
+	require.NoError(t, err)
+
+	// the stream is registered before headers are sent
+	_, err = stream.Header()
+	require.NoError(t, err)
+
+	// filtered out
+	env.server.OnEventFrame(testEventFrame(&common.MessageSysStatus{}))
+
+	env.server.OnEventFrame(testEventFrame(&common.MessageHeartbeat{
+		Type:      common.MAV_TYPE_QUADROTOR,
+		Autopilot: common.MAV_AUTOPILOT_PX4,
+	}))
+
+	fr, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, uint32(1), fr.SystemId)
+	require.Equal(t, uint32(2), fr.ComponentId)
+	require.Equal(t, uint32(3), fr.Sequence)
+	require.Equal(t, uint32(0), fr.MessageId)
+	require.Equal(t, "HEARTBEAT", fr.MessageName)
+	require.Equal(t, []byte{0, 0, 0, 0, 2, 12}, fr.Payload) // trailing zeros are truncated
+	require.Contains(t, fr.Json, `"mavtype":"MAV_TYPE_QUADROTOR"`)
+}
+
+func TestServerSend(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	for _, ca := range []struct {
+		name string
+		req  *SendRequest
+	}{
+		{
+			"json",
+			&SendRequest{
+				Json: `{"type": "HEARTBEAT", "mavtype": "MAV_TYPE_GCS", "custom_mode": 5}`,
+			},
+		},
+		{
+			"payload",
+			&SendRequest{
+				MessageId: 0,
+				Payload:   []byte{5, 0, 0, 0, 6},
+			},
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			_, err := env.client.Send(ctx, ca.req)
+			require.NoError(t, err)
+
+			select {
+			case byts := <-env.rwc.written:
+				require.Equal(t, byte(frame.V2MagicByte), byts[0])
+				require.Equal(t, byte(10), byts[5]) // system id
+				require.Equal(t, byte(0), byts[7])  // message id
+				require.Equal(t, byte(5), byts[10]) // custom mode
+				require.Equal(t, byte(6), byts[14]) // type
+			case <-time.After(2 * time.Second):
+				t.Fatal("message not written")
+			}
+		})
+	}
+
+	_, err := env.client.Send(ctx, &SendRequest{
+		Json: `{"type": "HEARTBEAT", "wrong_field": 1}`,
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = env.client.Send(ctx, &SendRequest{
+		MessageId: 1234567,
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = env.client.Send(ctx, &SendRequest{
+		Channel: "not-existing",
+		Json:    `{"type": "HEARTBEAT"}`,
+	})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
@@ -0,0 +1,106 @@
+package influxdb
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+var (
+	measurementEscaper = strings.NewReplacer(",", "\\,", " ", "\\ ")
+	tagEscaper         = strings.NewReplacer(",", "\\,", "=", "\\=", " ", "\\ ")
+	stringEscaper      = strings.NewReplacer("\\", "\\\\", "\"", "\\\"")
+)
+
+// appendPoint appends a message to buf, in the line protocol format.
+// The measurement is the message name, tags are the system id and component
+// id, fields are the message fields. Arrays are split into multiple fields,
+// named name_0, name_1, etc.
+func appendPoint(buf []byte, m msg.Message, systemID byte, componentID byte, t time.Time) []byte {
+	start := len(buf)
+	buf = append(buf, measurementEscaper.Replace(msg.Name(m))...)
+	buf = append(buf, ",system_id="...)
+	buf = strconv.AppendUint(buf, uint64(systemID), 10)
+	buf = append(buf, ",component_id="...)
+	buf = strconv.AppendUint(buf, uint64(componentID), 10)
+	buf = append(buf, ' ')
+
+	rv := reflect.ValueOf(m).Elem()
+	rt := rv.Type()
+	first := true
+
+	for i := 0; i < rt.NumField(); i++ {
+		name := tagEscaper.Replace(msg.FieldName(rt.Field(i)))
+		v := rv.Field(i)
+
+		if v.Kind() == reflect.Array {
+			for j := 0; j < v.Len(); j++ {
+				buf, first = appendField(buf, first, name+"_"+strconv.FormatInt(int64(j), 10), v.Index(j))
+			}
+		} else {
+			buf, first = appendField(buf, first, name, v)
+		}
+	}
+
+	// points without fields are not allowed
+	if first {
+		return buf[:start]
+	}
+
+	buf = append(buf, ' ')
+	buf = strconv.AppendInt(buf, t.UnixNano(), 10)
+	buf = append(buf, '\n')
+	return buf
+}
+
+func appendField(buf []byte, first bool, name string, v reflect.Value) ([]byte, bool) {
+	start := len(buf)
+	if !first {
+		buf = append(buf, ',')
+	}
+	buf = append(buf, name...)
+	buf = append(buf, '=')
+
+	switch v.Kind() {
+	case reflect.Float32:
+		f := v.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return buf[:start], first
+		}
+		buf = strconv.AppendFloat(buf, f, 'g', -1, 32)
+
+	case reflect.Float64:
+		f := v.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return buf[:start], first
+		}
+		buf = strconv.AppendFloat(buf, f, 'g', -1, 64)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		buf = strconv.AppendInt(buf, v.Int(), 10)
+		buf = append(buf, 'i')
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u := v.Uint()
+		if u > math.MaxInt64 {
+			buf = strconv.AppendFloat(buf, float64(u), 'g', -1, 64)
+		} else {
+			buf = strconv.AppendInt(buf, int64(u), 10)
+			buf = append(buf, 'i')
+		}
+
+	case reflect.String:
+		buf = append(buf, '"')
+		buf = append(buf, stringEscaper.Replace(v.String())...)
+		buf = append(buf, '"')
+
+	default:
+		return buf[:start], first
+	}
+
+	return buf, false
+}
@@ -0,0 +1,220 @@
+// Package influxdb implements a sink that writes decoded messages into
+// InfluxDB, by using the line protocol.
+package influxdb
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"sync/atomic"
+	"time"
+
+	"github.com/aler9/gomavlib"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+type point struct {
+	m           msg.Message
+	systemID    byte
+	componentID byte
+	t           time.Time
+}
+
+// SinkConf allows to configure a Sink.
+type SinkConf struct {
+	// the URL of the write endpoint, including the query parameters, for instance
+	// http://localhost:8086/api/v2/write?org=myorg&bucket=mybucket (InfluxDB 2.x) or
+	// http://localhost:8086/write?db=mydb (InfluxDB 1.x).
+	URL string
+
+	// (optional) the token used for authentication.
+	Token string
+
+	// (optional) the messages that are written. If nil, all decoded
+	// messages are written.
+	Messages []msg.Message
+
+	// (optional) the maximum number of points of a batch.
+	// It defaults to 1000.
+	BatchSize int
+
+	// (optional) the maximum time between the reception of a message and its
+	// writing. It defaults to 1 second.
+	FlushPeriod time.Duration
+
+	// (optional) the maximum number of points waiting to be written.
+	// Points received when the queue is full are dropped.
+	// It defaults to 10000.
+	QueueSize int
+
+	// (optional) the HTTP client. It defaults to a client with a 10 seconds timeout.
+	HTTPClient *http.Client
+
+	// (optional) a function that is called when a batch cannot be written.
+	OnError func(error)
+}
+
+// Sink writes decoded messages into InfluxDB, in batches.
+// Each message is converted into a point whose measurement is the message
+// name, whose tags are the system id and component id and whose fields are
+// the message fields.
+type Sink struct {
+	conf     SinkConf
+	messages map[reflect.Type]struct{}
+	dropped  uint64
+
+	// in
+	queue     chan point
+	terminate chan struct{}
+
+	// out
+	done chan struct{}
+}
+
+// NewSink allocates a Sink. See SinkConf for the options.
+func NewSink(conf SinkConf) (*Sink, error) {
+	if conf.URL == "" {
+		return nil, fmt.Errorf("URL not provided")
+	}
+	if conf.BatchSize == 0 {
+		conf.BatchSize = 1000
+	}
+	if conf.FlushPeriod == 0 {
+		conf.FlushPeriod = 1 * time.Second
+	}
+	if conf.QueueSize == 0 {
+		conf.QueueSize = 10000
+	}
+	if conf.HTTPClient == nil {
+		conf.HTTPClient = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	s := &Sink{
+		conf:      conf,
+		queue:     make(chan point, conf.QueueSize),
+		terminate: make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+
+	if conf.Messages != nil {
+		s.messages = make(map[reflect.Type]struct{})
+		for _, m := range conf.Messages {
+			s.messages[reflect.TypeOf(m)] = struct{}{}
+		}
+	}
+
+	go s.run()
+
+	return s, nil
+}
+
+// Close writes pending points and closes the Sink.
+func (s *Sink) Close() {
+	close(s.terminate)
+	<-s.done
+}
+
+// Dropped returns the number of points that have been dropped since the
+// queue was full.
+func (s *Sink) Dropped() uint64 {
+	return atomic.LoadUint64(&s.dropped)
+}
+
+// OnEventFrame queues a received frame for writing.
+// It must be called for every *gomavlib.EventFrame received from the node.
+// It never blocks.
+func (s *Sink) OnEventFrame(evt *gomavlib.EventFrame) {
+	m := evt.Message()
+
+	if _, ok := m.(*msg.MessageRaw); ok {
+		return
+	}
+
+	if s.messages != nil {
+		if _, ok := s.messages[reflect.TypeOf(m)]; !ok {
+			return
+		}
+	}
+
+	select {
+	case s.queue <- point{m, evt.SystemID(), evt.ComponentID(), time.Now()}:
+	default:
+		atomic.AddUint64(&s.dropped, 1)
+	}
+}
+
+func (s *Sink) run() {
+	defer close(s.done)
+
+	var buf []byte
+	count := 0
+
+	flush := func() {
+		if count == 0 {
+			return
+		}
+
+		err := s.write(buf)
+		if err != nil && s.conf.OnError != nil {
+			s.conf.OnError(err)
+		}
+
+		buf = buf[:0]
+		count = 0
+	}
+
+	add := func(p point) {
+		buf = appendPoint(buf, p.m, p.systemID, p.componentID, p.t)
+		count++
+		if count >= s.conf.BatchSize {
+			flush()
+		}
+	}
+
+	ticker := time.NewTicker(s.conf.FlushPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case p := <-s.queue:
+			add(p)
+
+		case <-ticker.C:
+			flush()
+
+		case <-s.terminate:
+			for len(s.queue) > 0 {
+				add(<-s.queue)
+			}
+			flush()
+			return
+		}
+	}
+}
+
+func (s *Sink) write(buf []byte) error {
+	req, err := http.NewRequest(http.MethodPost, s.conf.URL, bytes.NewReader(buf))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	if s.conf.Token != "" {
+		req.Header.Set("Authorization", "Token "+s.conf.Token)
+	}
+
+	res, err := s.conf.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		body, _ := ioutil.ReadAll(res.Body)
+		return fmt.Errorf("bad status code: %d (%s)", res.StatusCode, bytes.TrimSpace(body))
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+package influxdb
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib"
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+	"github.com/aler9/gomavlib/pkg/frame"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+func TestAppendPoint(t *testing.T) {
+	buf := appendPoint(nil, &common.MessageParamValue{
+		ParamId:    "PARAM 1",
+		ParamValue: 1.5,
+		ParamType:  common.MAV_PARAM_TYPE_REAL32,
+		ParamCount: 10,
+		ParamIndex: 2,
+	}, 1, 2, time.Unix(1, 5))
+	require.Equal(t, "PARAM_VALUE,system_id=1,component_id=2 "+
+		"param_id=\"PARAM 1\",param_value=1.5,param_type=9i,param_count=10i,param_index=2i 1000000005\n",
+		string(buf))
+
+	buf = appendPoint(nil, &common.MessageAttitudeQuaternionCov{
+		TimeUsec: 3,
+		Q:        [4]float32{1, 2, 3, 4},
+	}, 1, 1, time.Unix(0, 0))
+	require.True(t, strings.HasPrefix(string(buf), "ATTITUDE_QUATERNION_COV,system_id=1,component_id=1 "+
+		"time_usec=3i,q_0=1,q_1=2,q_2=3,q_3=4,"))
+}
+
+func TestSink(t *testing.T) {
+	var mutex sync.Mutex
+	var bodies []string
+	var token string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		byts, _ := ioutil.ReadAll(r.Body)
+		mutex.Lock()
+		bodies = append(bodies, string(byts))
+		token = r.Header.Get("Authorization")
+		mutex.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	s, err := NewSink(SinkConf{
+		URL:       srv.URL + "/api/v2/write?org=o&bucket=b",
+		Token:     "mytoken",
+		Messages:  []msg.Message{&common.MessageHeartbeat{}},
+		BatchSize: 2,
+	})
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		s.OnEventFrame(&gomavlib.EventFrame{
+			Frame: &frame.V2Frame{
+				SystemID:    1,
+				ComponentID: 1,
+				Message:     &common.MessageHeartbeat{CustomMode: uint32(i)},
+			},
+		})
+		s.OnEventFrame(&gomavlib.EventFrame{
+			Frame: &frame.V2Frame{
+				SystemID:    1,
+				ComponentID: 1,
+				Message:     &common.MessageSystemTime{},
+			},
+		})
+	}
+
+	s.Close()
+
+	require.Equal(t, "Token mytoken", token)
+	require.Len(t, bodies, 2)
+	require.Equal(t, 2, strings.Count(bodies[0], "\n"))
+	require.Equal(t, 1, strings.Count(bodies[1], "\n"))
+	require.True(t, strings.Contains(bodies[1], "custom_mode=2i"))
+	require.Equal(t, uint64(0), s.Dropped())
+}
+
+func TestSinkError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	errs := make(chan error, 1)
+
+	s, err := NewSink(SinkConf{
+		URL: srv.URL + "/write?db=mydb",
+		OnError: func(err error) {
+			errs <- err
+		},
+	})
+	require.NoError(t, err)
+
+	s.OnEventFrame(&gomavlib.EventFrame{
+		Frame: &frame.V2Frame{
+			SystemID:    1,
+			ComponentID: 1,
+			Message:     &common.MessageHeartbeat{},
+		},
+	})
+
+	s.Close()
+
+	require.EqualError(t, <-errs, "bad status code: 401 ()")
+}
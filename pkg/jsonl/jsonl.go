@@ -0,0 +1,139 @@
+// Package jsonl implements a sink that writes decoded messages in the JSON
+// Lines format, that can be processed by jq or ingested by log collectors.
+package jsonl
+
+import (
+	"encoding"
+	"encoding/json"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/aler9/gomavlib"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+var textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+// Writer writes decoded messages into a io.Writer, one JSON object per line:
+//
+//	{"timestamp":"2021-01-02T03:04:05.5Z","system_id":1,"component_id":1,"message":"HEARTBEAT","fields":{"type":"MAV_TYPE_QUADROTOR",...}}
+//
+// Fields are written in the order of the message definition. Enums are
+// written by name, while values that do not correspond to any name, like
+// bitmasks, are written in numeric form. NaN and infinite values, that are
+// not supported by JSON, are written as null.
+// It can be used by multiple routines in parallel.
+type Writer struct {
+	w     io.Writer
+	mutex sync.Mutex
+	buf   []byte
+}
+
+// NewWriter allocates a Writer.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{
+		w: w,
+	}
+}
+
+// OnEventFrame writes a received frame, by using the current time as timestamp.
+// Messages that have not been decoded are skipped.
+func (w *Writer) OnEventFrame(evt *gomavlib.EventFrame) error {
+	return w.Write(time.Now(), evt.SystemID(), evt.ComponentID(), evt.Message())
+}
+
+// Write writes a message. Each message is written with a single call to the
+// underlying io.Writer.
+// Messages that have not been decoded are skipped.
+func (w *Writer) Write(t time.Time, systemID byte, componentID byte, m msg.Message) error {
+	if _, ok := m.(*msg.MessageRaw); ok {
+		return nil
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	buf, err := appendMessage(w.buf[:0], t, systemID, componentID, m)
+	if err != nil {
+		return err
+	}
+	w.buf = buf
+
+	_, err = w.w.Write(buf)
+	return err
+}
+
+// appendMessage appends the JSON line of a message to a buffer.
+func appendMessage(buf []byte, t time.Time, systemID byte, componentID byte, m msg.Message) ([]byte, error) {
+	buf = append(buf, `{"timestamp":"`...)
+	buf = t.UTC().AppendFormat(buf, time.RFC3339Nano)
+	buf = append(buf, `","system_id":`...)
+	buf = strconv.AppendUint(buf, uint64(systemID), 10)
+	buf = append(buf, `,"component_id":`...)
+	buf = strconv.AppendUint(buf, uint64(componentID), 10)
+	buf = append(buf, `,"message":`...)
+	buf = strconv.AppendQuote(buf, msg.Name(m))
+	buf = append(buf, `,"fields":{`...)
+
+	rv := reflect.ValueOf(m).Elem()
+	rt := rv.Type()
+
+	for i := 0; i < rt.NumField(); i++ {
+		if i != 0 {
+			buf = append(buf, ',')
+		}
+
+		buf = strconv.AppendQuote(buf, msg.FieldName(rt.Field(i)))
+		buf = append(buf, ':')
+
+		byts, err := json.Marshal(fieldEncode(rv.Field(i)))
+		if err != nil {
+			return nil, err
+		}
+		buf = append(buf, byts...)
+	}
+
+	buf = append(buf, "}}\n"...)
+	return buf, nil
+}
+
+func isFloat(t reflect.Type) bool {
+	return t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64
+}
+
+// fieldEncode converts a field into a JSON-compatible value.
+func fieldEncode(v reflect.Value) interface{} {
+	switch {
+	case isFloat(v.Type()):
+		f := v.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil
+		}
+
+	case v.Type().Implements(textMarshalerType):
+		if byts, err := v.Interface().(encoding.TextMarshaler).MarshalText(); err == nil {
+			return string(byts)
+		}
+
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return v.Int()
+		default:
+			return v.Uint()
+		}
+
+	case v.Kind() == reflect.Array && (v.Type().Elem().Implements(textMarshalerType) ||
+		isFloat(v.Type().Elem())):
+		ret := make([]interface{}, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			ret[i] = fieldEncode(v.Index(i))
+		}
+		return ret
+	}
+
+	return v.Interface()
+}
@@ -0,0 +1,56 @@
+package jsonl
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+func TestWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+
+	err := w.Write(time.Unix(1, 500000000), 1, 2, &common.MessageParamValue{
+		ParamId:    "PARAM 1",
+		ParamValue: 1.5,
+		ParamType:  common.MAV_PARAM_TYPE_REAL32,
+		ParamCount: 10,
+		ParamIndex: 2,
+	})
+	require.NoError(t, err)
+
+	err = w.Write(time.Unix(2, 0), 1, 2, &msg.MessageRaw{ID: 1})
+	require.NoError(t, err)
+
+	err = w.Write(time.Unix(3, 0), 1, 2, &common.MessageAttitudeQuaternion{
+		TimeBootMs:  10,
+		Q1:          float32(math.NaN()),
+		ReprOffsetQ: [4]float32{0.5, 0, 0, 0},
+	})
+	require.NoError(t, err)
+
+	lines := bytes.Split(bytes.TrimSuffix(buf.Bytes(), []byte("\n")), []byte("\n"))
+	require.Equal(t, 2, len(lines))
+
+	require.Equal(t, `{"timestamp":"1970-01-01T00:00:01.5Z","system_id":1,"component_id":2,`+
+		`"message":"PARAM_VALUE","fields":{"param_id":"PARAM 1","param_value":1.5,`+
+		`"param_type":"MAV_PARAM_TYPE_REAL32","param_count":10,"param_index":2}}`,
+		string(lines[0]))
+
+	var dec map[string]interface{}
+	err = json.Unmarshal(lines[1], &dec)
+	require.NoError(t, err)
+	require.Equal(t, "ATTITUDE_QUATERNION", dec["message"])
+
+	fields := dec["fields"].(map[string]interface{})
+	require.Equal(t, float64(10), fields["time_boot_ms"])
+	require.Equal(t, nil, fields["q1"])
+	require.Equal(t, []interface{}{0.5, float64(0), float64(0), float64(0)}, fields["repr_offset_q"])
+}
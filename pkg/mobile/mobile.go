@@ -0,0 +1,239 @@
+// Package mobile exposes a Node through an API that can be used from Android
+// and iOS applications through gomobile:
+//
+//	gomobile bind github.com/aler9/gomavlib/pkg/mobile
+//
+// The API uses only types supported by gomobile: channels, slices of
+// structs and interfaces are replaced by callbacks and strings, and messages
+// are exchanged in JSON format.
+package mobile
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/aler9/gomavlib"
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/dialects/ardupilotmega"
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+	"github.com/aler9/gomavlib/pkg/dialects/minimal"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+var dialects = map[string]*dialect.Dialect{
+	"minimal":       minimal.Dialect,
+	"common":        common.Dialect,
+	"ardupilotmega": ardupilotmega.Dialect,
+}
+
+// Frame is a received frame.
+type Frame struct {
+	// the channel from which the frame was received
+	Channel string
+	// the system id of the sender
+	SystemID int
+	// the component id of the sender
+	ComponentID int
+	// the message id
+	MessageID int
+	// the message name, for instance HEARTBEAT
+	MessageName string
+	// the message in JSON format, with fields named as in the Go dialect.
+	// It is empty if the message contains values that can't be represented
+	// in JSON (NaN or infinite floats).
+	MessageJSON string
+}
+
+// Handler receives events from a Node.
+// Its methods are called by a single routine, in the order in which events
+// occur, and must not call Node.Close().
+type Handler interface {
+	// called when a channel is opened.
+	OnChannelOpen(channel string)
+	// called when a channel is closed.
+	OnChannelClose(channel string)
+	// called when a frame is received.
+	OnFrame(frame *Frame)
+}
+
+// NodeConf allows to configure a Node.
+// Endpoints are added with the Add methods.
+type NodeConf struct {
+	// the dialect, that is one of minimal, common or ardupilotmega.
+	// It defaults to common.
+	Dialect string
+	// the system id, added to every outgoing frame.
+	OutSystemID int
+	// (optional) the component id, added to every outgoing frame.
+	// It defaults to 1.
+	OutComponentID int
+	// (optional) whether to encode messages with Mavlink 1.0 instead of 2.0.
+	OutVersion1 bool
+	// (optional) disables the periodic sending of heartbeats.
+	HeartbeatDisable bool
+
+	endpoints []gomavlib.EndpointConf
+}
+
+// NewNodeConf allocates a NodeConf.
+func NewNodeConf() *NodeConf {
+	return &NodeConf{}
+}
+
+// AddSerial adds a serial endpoint, with address in format name:baudrate.
+func (c *NodeConf) AddSerial(address string) {
+	c.endpoints = append(c.endpoints, gomavlib.EndpointSerial{Address: address})
+}
+
+// AddUDPServer adds a UDP server endpoint, for instance 0.0.0.0:14550.
+func (c *NodeConf) AddUDPServer(address string) {
+	c.endpoints = append(c.endpoints, gomavlib.EndpointUDPServer{Address: address})
+}
+
+// AddUDPClient adds a UDP client endpoint, for instance 1.2.3.4:14550.
+func (c *NodeConf) AddUDPClient(address string) {
+	c.endpoints = append(c.endpoints, gomavlib.EndpointUDPClient{Address: address})
+}
+
+// AddUDPBroadcast adds a UDP broadcast endpoint.
+func (c *NodeConf) AddUDPBroadcast(broadcastAddress string, localAddress string) {
+	c.endpoints = append(c.endpoints, gomavlib.EndpointUDPBroadcast{
+		BroadcastAddress: broadcastAddress,
+		LocalAddress:     localAddress,
+	})
+}
+
+// AddTCPServer adds a TCP server endpoint, for instance 0.0.0.0:5760.
+func (c *NodeConf) AddTCPServer(address string) {
+	c.endpoints = append(c.endpoints, gomavlib.EndpointTCPServer{Address: address})
+}
+
+// AddTCPClient adds a TCP client endpoint, for instance 1.2.3.4:5760.
+func (c *NodeConf) AddTCPClient(address string) {
+	c.endpoints = append(c.endpoints, gomavlib.EndpointTCPClient{Address: address})
+}
+
+// Node is a wrapper around gomavlib.Node.
+type Node struct {
+	n        *gomavlib.Node
+	messages map[string]msg.Message
+	done     chan struct{}
+}
+
+// NewNode allocates a Node, that sends events to the given handler.
+func NewNode(conf *NodeConf, handler Handler) (*Node, error) {
+	if handler == nil {
+		return nil, fmt.Errorf("handler not provided")
+	}
+
+	if conf.Dialect == "" {
+		conf.Dialect = "common"
+	}
+	d, ok := dialects[conf.Dialect]
+	if !ok {
+		return nil, fmt.Errorf("unsupported dialect: %s", conf.Dialect)
+	}
+
+	if conf.OutSystemID < 1 || conf.OutSystemID > 255 {
+		return nil, fmt.Errorf("OutSystemID must be between 1 and 255")
+	}
+	if conf.OutComponentID < 0 || conf.OutComponentID > 255 {
+		return nil, fmt.Errorf("OutComponentID must be between 0 and 255")
+	}
+
+	outVersion := gomavlib.V2
+	if conf.OutVersion1 {
+		outVersion = gomavlib.V1
+	}
+
+	n, err := gomavlib.NewNode(gomavlib.NodeConf{
+		Endpoints:        conf.endpoints,
+		Dialect:          d,
+		OutVersion:       outVersion,
+		OutSystemID:      byte(conf.OutSystemID),
+		OutComponentID:   byte(conf.OutComponentID),
+		HeartbeatDisable: conf.HeartbeatDisable,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make(map[string]msg.Message)
+	for _, m := range d.Messages {
+		messages[msg.Name(m)] = m
+	}
+
+	mn := &Node{
+		n:        n,
+		messages: messages,
+		done:     make(chan struct{}),
+	}
+
+	go mn.run(handler)
+
+	return mn, nil
+}
+
+// Close closes the node.
+func (mn *Node) Close() {
+	mn.n.Close()
+	<-mn.done
+}
+
+func (mn *Node) run(handler Handler) {
+	defer close(mn.done)
+
+	for evt := range mn.n.Events() {
+		switch ee := evt.(type) {
+		case *gomavlib.EventChannelOpen:
+			handler.OnChannelOpen(ee.Channel.String())
+
+		case *gomavlib.EventChannelClose:
+			handler.OnChannelClose(ee.Channel.String())
+
+		case *gomavlib.EventFrame:
+			handler.OnFrame(newFrame(ee))
+		}
+	}
+}
+
+func newFrame(evt *gomavlib.EventFrame) *Frame {
+	m := evt.Message()
+
+	fr := &Frame{
+		Channel:     evt.Channel.String(),
+		SystemID:    int(evt.SystemID()),
+		ComponentID: int(evt.ComponentID()),
+		MessageID:   int(m.GetID()),
+		MessageName: msg.Name(m),
+	}
+
+	if _, ok := m.(*msg.MessageRaw); !ok {
+		byts, err := json.Marshal(m)
+		if err == nil {
+			fr.MessageJSON = string(byts)
+		}
+	}
+
+	return fr
+}
+
+// WriteMessageAll writes a message to all channels.
+// The message is identified by its name, for instance HEARTBEAT, and its
+// content is in JSON format, with fields named as in the Go dialect.
+func (mn *Node) WriteMessageAll(name string, messageJSON string) error {
+	tpl, ok := mn.messages[name]
+	if !ok {
+		return fmt.Errorf("message %s is not in the dialect", name)
+	}
+
+	m := reflect.New(reflect.TypeOf(tpl).Elem()).Interface().(msg.Message)
+	err := json.Unmarshal([]byte(messageJSON), m)
+	if err != nil {
+		return err
+	}
+
+	mn.n.WriteMessageAll(m)
+	return nil
+}
@@ -0,0 +1,111 @@
+package mobile
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib"
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+)
+
+type testHandler struct {
+	open   chan string
+	frames chan *Frame
+}
+
+func (h *testHandler) OnChannelOpen(channel string) {
+	h.open <- channel
+}
+
+func (h *testHandler) OnChannelClose(channel string) {
+}
+
+func (h *testHandler) OnFrame(frame *Frame) {
+	h.frames <- frame
+}
+
+func TestNode(t *testing.T) {
+	conf := NewNodeConf()
+	conf.OutSystemID = 10
+	conf.HeartbeatDisable = true
+	conf.AddUDPServer("127.0.0.1:5605")
+
+	h := &testHandler{
+		open:   make(chan string, 10),
+		frames: make(chan *Frame, 10),
+	}
+
+	mn, err := NewNode(conf, h)
+	require.NoError(t, err)
+	defer mn.Close()
+
+	n, err := gomavlib.NewNode(gomavlib.NodeConf{
+		Dialect:          common.Dialect,
+		OutVersion:       gomavlib.V2,
+		OutSystemID:      11,
+		Endpoints:        []gomavlib.EndpointConf{gomavlib.EndpointUDPClient{Address: "127.0.0.1:5605"}},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer n.Close()
+
+	<-n.Events() // EventChannelOpen
+
+	n.WriteMessageAll(&common.MessageParamValue{
+		ParamId:    "test",
+		ParamValue: 123,
+		ParamType:  common.MAV_PARAM_TYPE_UINT32,
+		ParamCount: 1,
+		ParamIndex: 2,
+	})
+
+	select {
+	case <-h.open:
+	case <-time.After(2 * time.Second):
+		t.Fatal("timeout")
+	}
+
+	var fr *Frame
+	select {
+	case fr = <-h.frames:
+	case <-time.After(2 * time.Second):
+		t.Fatal("timeout")
+	}
+	require.Equal(t, 11, fr.SystemID)
+	require.Equal(t, 1, fr.ComponentID)
+	require.Equal(t, 22, fr.MessageID)
+	require.Equal(t, "PARAM_VALUE", fr.MessageName)
+	require.Equal(t, `{"ParamId":"test","ParamValue":123,"ParamType":"MAV_PARAM_TYPE_UINT32",`+
+		`"ParamCount":1,"ParamIndex":2}`, fr.MessageJSON)
+
+	err = mn.WriteMessageAll("PARAM_VALUE", fr.MessageJSON)
+	require.NoError(t, err)
+
+	for evt := range n.Events() {
+		if ee, ok := evt.(*gomavlib.EventFrame); ok {
+			require.Equal(t, &common.MessageParamValue{
+				ParamId:    "test",
+				ParamValue: 123,
+				ParamType:  common.MAV_PARAM_TYPE_UINT32,
+				ParamCount: 1,
+				ParamIndex: 2,
+			}, ee.Message())
+			break
+		}
+	}
+}
+
+func TestNodeErrors(t *testing.T) {
+	conf := NewNodeConf()
+	conf.Dialect = "unknown"
+	conf.OutSystemID = 10
+	_, err := NewNode(conf, &testHandler{})
+	require.EqualError(t, err, "unsupported dialect: unknown")
+
+	conf = NewNodeConf()
+	conf.AddUDPServer("127.0.0.1:5606")
+	_, err = NewNode(conf, &testHandler{})
+	require.EqualError(t, err, "OutSystemID must be between 1 and 255")
+}
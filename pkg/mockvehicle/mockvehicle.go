@@ -0,0 +1,361 @@
+// Package mockvehicle provides a minimal emulated vehicle, that allows to test
+// ground control software without a SITL instance.
+//
+// The vehicle sends heartbeats and basic telemetry (SYS_STATUS, ATTITUDE,
+// GLOBAL_POSITION_INT and HOME_POSITION), serves parameters and plans with
+// the parameter and mission protocols and acknowledges commands. Arming,
+// disarming and mode changes are reflected into heartbeats.
+package mockvehicle
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aler9/gomavlib"
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+)
+
+// CommandFunc is the callback invoked when a command is received.
+// It returns the result that is sent back with COMMAND_ACK.
+type CommandFunc func(command int, params [7]float32) gomavlib.CommandResult
+
+// Conf allows to configure a Vehicle.
+type Conf struct {
+	// the endpoints through which the vehicle communicates.
+	Endpoints []gomavlib.EndpointConf
+
+	// (optional) the system id of the vehicle. It defaults to 1.
+	SystemID byte
+	// (optional) the component id of the autopilot. It defaults to 1.
+	ComponentID byte
+	// (optional) the vehicle type (MAV_TYPE). It defaults to MAV_TYPE_QUADROTOR.
+	Type int
+	// (optional) the autopilot type (MAV_AUTOPILOT). It defaults to
+	// MAV_AUTOPILOT_ARDUPILOTMEGA. With MAV_AUTOPILOT_PX4, parameters are
+	// encoded bytewise.
+	Autopilot int
+
+	// (optional) the parameters of the vehicle. If nil, a few Ardupilot-like
+	// parameters are provided.
+	Params []*gomavlib.Param
+	// (optional) the mission stored by the vehicle.
+	Mission []*gomavlib.MissionItem
+
+	// (optional) the position of the vehicle, that is also its home position.
+	// Latitude and longitude are in degrees, altitude is in meters above mean
+	// sea level.
+	Latitude  float64
+	Longitude float64
+	Altitude  float32
+
+	// (optional) the period between telemetry messages. It defaults to 200
+	// milliseconds.
+	TelemetryPeriod time.Duration
+
+	// (optional) callback invoked when a command is received.
+	// If nil, all commands are accepted.
+	OnCommand CommandFunc
+}
+
+// Vehicle is a minimal emulated vehicle.
+type Vehicle struct {
+	conf          Conf
+	node          *gomavlib.Node
+	paramServer   *gomavlib.ParamServer
+	missionServer *gomavlib.MissionServer
+	start         time.Time
+
+	mutex      sync.Mutex
+	armed      bool
+	customMode uint32
+	commands   []int
+
+	// in
+	terminate chan struct{}
+
+	// out
+	done chan struct{}
+}
+
+// New allocates a Vehicle. See Conf for the options.
+func New(conf Conf) (*Vehicle, error) {
+	if len(conf.Endpoints) == 0 {
+		return nil, fmt.Errorf("Endpoints not provided")
+	}
+	if conf.SystemID == 0 {
+		conf.SystemID = 1
+	}
+	if conf.ComponentID == 0 {
+		conf.ComponentID = 1
+	}
+	if conf.Type == 0 {
+		conf.Type = int(common.MAV_TYPE_QUADROTOR)
+	}
+	if conf.Autopilot == 0 {
+		conf.Autopilot = int(common.MAV_AUTOPILOT_ARDUPILOTMEGA)
+	}
+	if conf.Params == nil {
+		conf.Params = []*gomavlib.Param{
+			{Name: "SYSID_THISMAV", Type: gomavlib.ParamTypeInt16, Value: float64(conf.SystemID)},
+			{Name: "FRAME_CLASS", Type: gomavlib.ParamTypeInt8, Value: 1},
+			{Name: "WPNAV_SPEED", Type: gomavlib.ParamTypeReal32, Value: 500},
+			{Name: "RTL_ALT", Type: gomavlib.ParamTypeInt32, Value: 1500},
+		}
+	}
+	if conf.TelemetryPeriod == 0 {
+		conf.TelemetryPeriod = 200 * time.Millisecond
+	}
+
+	v := &Vehicle{
+		conf:      conf,
+		start:     time.Now(),
+		terminate: make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+
+	var err error
+	v.node, err = gomavlib.NewNode(gomavlib.NodeConf{
+		Endpoints:              conf.Endpoints,
+		Dialect:                common.Dialect,
+		OutVersion:             gomavlib.V2,
+		OutSystemID:            conf.SystemID,
+		OutComponentID:         conf.ComponentID,
+		HeartbeatPeriod:        1 * time.Second,
+		HeartbeatSystemType:    conf.Type,
+		HeartbeatAutopilotType: conf.Autopilot,
+		HeartbeatBaseMode:      int(common.MAV_MODE_FLAG_CUSTOM_MODE_ENABLED),
+		HeartbeatSystemStatus:  int(common.MAV_STATE_STANDBY),
+		SysStatusProvider:      v.sysStatus,
+		SysStatusPeriod:        conf.TelemetryPeriod,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	v.paramServer, err = gomavlib.NewParamServer(gomavlib.ParamServerConf{
+		Node:             v.node,
+		BytewiseEncoding: conf.Autopilot == int(common.MAV_AUTOPILOT_PX4),
+	})
+	if err != nil {
+		v.node.Close()
+		return nil, err
+	}
+
+	for _, p := range conf.Params {
+		err := v.paramServer.Register(p.Name, p.Type, p.Value, nil)
+		if err != nil {
+			v.paramServer.Close()
+			v.node.Close()
+			return nil, err
+		}
+	}
+
+	v.missionServer, err = gomavlib.NewMissionServer(gomavlib.MissionServerConf{
+		Node: v.node,
+	})
+	if err != nil {
+		v.paramServer.Close()
+		v.node.Close()
+		return nil, err
+	}
+
+	v.missionServer.Set(gomavlib.MissionTypeMission, conf.Mission)
+
+	go v.run()
+
+	return v, nil
+}
+
+// Close closes the Vehicle.
+func (v *Vehicle) Close() {
+	close(v.terminate)
+	v.missionServer.Close()
+	v.paramServer.Close()
+	v.node.Close()
+	<-v.done
+}
+
+// Node returns the node of the vehicle, that can be used to send additional
+// messages.
+func (v *Vehicle) Node() *gomavlib.Node {
+	return v.node
+}
+
+// Params returns the parameter server of the vehicle.
+func (v *Vehicle) Params() *gomavlib.ParamServer {
+	return v.paramServer
+}
+
+// Missions returns the mission server of the vehicle.
+func (v *Vehicle) Missions() *gomavlib.MissionServer {
+	return v.missionServer
+}
+
+// Armed returns whether the vehicle is armed.
+func (v *Vehicle) Armed() bool {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	return v.armed
+}
+
+// Commands returns the ids (MAV_CMD) of the received commands, in order of
+// reception.
+func (v *Vehicle) Commands() []int {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	return append([]int(nil), v.commands...)
+}
+
+func (v *Vehicle) run() {
+	defer close(v.done)
+
+	telemetryDone := make(chan struct{})
+	go func() {
+		defer close(telemetryDone)
+		v.runTelemetry()
+	}()
+
+	for evt := range v.node.Events() {
+		if frm, ok := evt.(*gomavlib.EventFrame); ok {
+			v.onEventFrame(frm)
+		}
+	}
+
+	<-telemetryDone
+}
+
+func (v *Vehicle) runTelemetry() {
+	ticker := time.NewTicker(v.conf.TelemetryPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			v.writeTelemetry()
+
+		case <-v.terminate:
+			return
+		}
+	}
+}
+
+func (v *Vehicle) timeBootMs() uint32 {
+	return uint32(time.Since(v.start) / time.Millisecond)
+}
+
+func (v *Vehicle) writeTelemetry() {
+	lat := int32(v.conf.Latitude * 1e7)
+	lon := int32(v.conf.Longitude * 1e7)
+	alt := int32(v.conf.Altitude * 1000)
+
+	v.node.WriteMessageAll(&common.MessageAttitude{
+		TimeBootMs: v.timeBootMs(),
+	})
+
+	v.node.WriteMessageAll(&common.MessageGlobalPositionInt{
+		TimeBootMs: v.timeBootMs(),
+		Lat:        lat,
+		Lon:        lon,
+		Alt:        alt,
+		Hdg:        0xFFFF,
+	})
+
+	v.node.WriteMessageAll(&common.MessageHomePosition{
+		Latitude:  lat,
+		Longitude: lon,
+		Altitude:  alt,
+		Q:         [4]float32{1, 0, 0, 0},
+	})
+}
+
+func (v *Vehicle) sysStatus() *gomavlib.SysStatus {
+	sensors := uint32(common.MAV_SYS_STATUS_SENSOR_3D_GYRO |
+		common.MAV_SYS_STATUS_SENSOR_3D_ACCEL |
+		common.MAV_SYS_STATUS_SENSOR_3D_MAG |
+		common.MAV_SYS_STATUS_SENSOR_ABSOLUTE_PRESSURE |
+		common.MAV_SYS_STATUS_SENSOR_GPS)
+
+	return &gomavlib.SysStatus{
+		SensorsPresent:   sensors,
+		SensorsEnabled:   sensors,
+		SensorsHealth:    sensors,
+		Load:             10,
+		BatteryVoltage:   12.6,
+		BatteryCurrent:   -1,
+		BatteryRemaining: 100,
+	}
+}
+
+func (v *Vehicle) onEventFrame(frm *gomavlib.EventFrame) {
+	var command int
+	var params [7]float32
+	var targetSystem, targetComponent uint8
+
+	switch m := frm.Message().(type) {
+	case *common.MessageCommandLong:
+		command = int(m.Command)
+		params = [7]float32{m.Param1, m.Param2, m.Param3, m.Param4, m.Param5, m.Param6, m.Param7}
+		targetSystem, targetComponent = m.TargetSystem, m.TargetComponent
+
+	case *common.MessageCommandInt:
+		command = int(m.Command)
+		params = [7]float32{m.Param1, m.Param2, m.Param3, m.Param4, float32(m.X), float32(m.Y), m.Z}
+		targetSystem, targetComponent = m.TargetSystem, m.TargetComponent
+
+	default:
+		return
+	}
+
+	if targetSystem != v.conf.SystemID ||
+		(targetComponent != v.conf.ComponentID && targetComponent != 0) {
+		return
+	}
+
+	res := gomavlib.CommandResultAccepted
+	if v.conf.OnCommand != nil {
+		res = v.conf.OnCommand(command, params)
+	}
+
+	if res == gomavlib.CommandResultAccepted {
+		v.applyCommand(command, params)
+	}
+
+	v.mutex.Lock()
+	v.commands = append(v.commands, command)
+	v.mutex.Unlock()
+
+	v.node.WriteMessageTo(frm.Channel, &common.MessageCommandAck{
+		Command:         common.MAV_CMD(command),
+		Result:          common.MAV_RESULT(res),
+		TargetSystem:    frm.SystemID(),
+		TargetComponent: frm.ComponentID(),
+	})
+}
+
+// applyCommand reflects accepted commands into the state of the vehicle.
+func (v *Vehicle) applyCommand(command int, params [7]float32) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	switch common.MAV_CMD(command) {
+	case common.MAV_CMD_COMPONENT_ARM_DISARM:
+		v.armed = params[0] == 1
+
+	case common.MAV_CMD_DO_SET_MODE:
+		v.customMode = uint32(params[1])
+
+	default:
+		return
+	}
+
+	baseMode := int(common.MAV_MODE_FLAG_CUSTOM_MODE_ENABLED)
+	state := int(common.MAV_STATE_STANDBY)
+	if v.armed {
+		baseMode |= int(common.MAV_MODE_FLAG_SAFETY_ARMED)
+		state = int(common.MAV_STATE_ACTIVE)
+	}
+
+	v.node.SetMode(baseMode, v.customMode)
+	v.node.SetSystemStatus(state)
+}
@@ -0,0 +1,160 @@
+package mockvehicle
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib"
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+)
+
+func TestVehicle(t *testing.T) {
+	c1, c2 := net.Pipe()
+
+	v, err := New(Conf{
+		Endpoints: []gomavlib.EndpointConf{
+			gomavlib.EndpointCustom{ReadWriteCloser: c1},
+		},
+		Mission: []*gomavlib.MissionItem{{
+			Frame:        int(common.MAV_FRAME_GLOBAL_RELATIVE_ALT_INT),
+			Command:      int(common.MAV_CMD_NAV_TAKEOFF),
+			Autocontinue: true,
+			Z:            10,
+		}},
+		Latitude:        45,
+		Longitude:       9,
+		Altitude:        100,
+		TelemetryPeriod: 50 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	defer v.Close()
+
+	gcs, err := gomavlib.NewNode(gomavlib.NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  gomavlib.V2,
+		OutSystemID: 255,
+		Endpoints: []gomavlib.EndpointConf{
+			gomavlib.EndpointCustom{ReadWriteCloser: c2},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer gcs.Close()
+
+	positionReceived := make(chan *common.MessageGlobalPositionInt, 1)
+	armedReceived := make(chan struct{})
+
+	go func() {
+		armed := false
+		for evt := range gcs.Events() {
+			frm, ok := evt.(*gomavlib.EventFrame)
+			if !ok {
+				continue
+			}
+
+			switch m := frm.Message().(type) {
+			case *common.MessageGlobalPositionInt:
+				select {
+				case positionReceived <- m:
+				default:
+				}
+
+			case *common.MessageHeartbeat:
+				if !armed && m.BaseMode&common.MAV_MODE_FLAG_SAFETY_ARMED != 0 {
+					armed = true
+					close(armedReceived)
+				}
+			}
+		}
+	}()
+
+	target := gomavlib.Target{SystemID: 1, ComponentID: 1}
+
+	pos := <-positionReceived
+	require.Equal(t, int32(450000000), pos.Lat)
+	require.Equal(t, int32(100000), pos.Alt)
+
+	pc, err := gomavlib.NewParamClient(gomavlib.ParamClientConf{
+		Node:   gcs,
+		Target: target,
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	params, err := pc.List(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 4, len(params))
+	require.Equal(t, "SYSID_THISMAV", params[0].Name)
+	require.Equal(t, float64(1), params[0].Value)
+
+	mc, err := gomavlib.NewMissionClient(gomavlib.MissionClientConf{
+		Node:   gcs,
+		Target: target,
+	})
+	require.NoError(t, err)
+
+	items, err := mc.Download(ctx, gomavlib.MissionTypeMission)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(items))
+	require.Equal(t, int(common.MAV_CMD_NAV_TAKEOFF), items[0].Command)
+
+	res, err := gcs.SendCommand(ctx, target, int(common.MAV_CMD_COMPONENT_ARM_DISARM), 1)
+	require.NoError(t, err)
+	require.Equal(t, gomavlib.CommandResultAccepted, res)
+	require.Equal(t, true, v.Armed())
+	require.Equal(t, []int{int(common.MAV_CMD_COMPONENT_ARM_DISARM)}, v.Commands())
+
+	<-armedReceived
+}
+
+func TestVehicleCommandRejected(t *testing.T) {
+	c1, c2 := net.Pipe()
+
+	v, err := New(Conf{
+		Endpoints: []gomavlib.EndpointConf{
+			gomavlib.EndpointCustom{ReadWriteCloser: c1},
+		},
+		OnCommand: func(command int, params [7]float32) gomavlib.CommandResult {
+			return gomavlib.CommandResultDenied
+		},
+	})
+	require.NoError(t, err)
+	defer v.Close()
+
+	gcs, err := gomavlib.NewNode(gomavlib.NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  gomavlib.V2,
+		OutSystemID: 255,
+		Endpoints: []gomavlib.EndpointConf{
+			gomavlib.EndpointCustom{ReadWriteCloser: c2},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer gcs.Close()
+
+	go func() {
+		for range gcs.Events() {
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := gcs.SendCommand(ctx, gomavlib.Target{SystemID: 1, ComponentID: 1},
+		int(common.MAV_CMD_COMPONENT_ARM_DISARM), 1)
+	require.NoError(t, err)
+	require.Equal(t, gomavlib.CommandResultDenied, res)
+	require.Equal(t, false, v.Armed())
+}
+
+func TestVehicleErrors(t *testing.T) {
+	_, err := New(Conf{})
+	require.EqualError(t, err, "Endpoints not provided")
+}
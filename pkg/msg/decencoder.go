@@ -1,12 +1,10 @@
 package msg
 
 import (
-	"bytes"
 	"encoding/binary"
 	"fmt"
 	"math"
 	"reflect"
-	"regexp"
 	"sort"
 	"strconv"
 	"strings"
@@ -73,14 +71,12 @@ var fieldTypeSizes = map[fieldType]byte{
 }
 
 func fieldGoToDef(in string) string {
-	re := regexp.MustCompile("([A-Z])")
-	in = re.ReplaceAllString(in, "_${1}")
+	in = reUpper.ReplaceAllString(in, "_${1}")
 	return strings.ToLower(in[1:])
 }
 
 func msgGoToDef(in string) string {
-	re := regexp.MustCompile("([A-Z])")
-	in = re.ReplaceAllString(in, "_${1}")
+	in = reUpper.ReplaceAllString(in, "_${1}")
 	return strings.ToUpper(in[1:])
 }
 
@@ -100,12 +96,18 @@ type DecEncoder struct {
 	sizeExtended byte
 	elemType     reflect.Type
 	crcExtra     byte
+	marshaler    bool
 }
 
 // NewDecEncoder allocates a DecEncoder.
 func NewDecEncoder(msg Message) (*DecEncoder, error) {
+	if t := reflect.TypeOf(msg); t == nil || t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("message must be a pointer to a struct")
+	}
+
 	mde := &DecEncoder{}
 	mde.elemType = reflect.TypeOf(msg).Elem()
+	_, mde.marshaler = msg.(Marshaler)
 
 	mde.fields = make([]*decEncoderField, mde.elemType.NumField())
 
@@ -199,14 +201,9 @@ func NewDecEncoder(msg Message) (*DecEncoder, error) {
 		}
 
 		mde.fields[i] = &decEncoderField{
-			isEnum: isEnum,
-			ftype:  dialectType,
-			name: func() string {
-				if mavname := field.Tag.Get("mavname"); mavname != "" {
-					return mavname
-				}
-				return fieldGoToDef(field.Name)
-			}(),
+			isEnum:      isEnum,
+			ftype:       dialectType,
+			name:        FieldName(field),
 			arrayLength: arrayLength,
 			index:       i,
 			isExtension: isExtension,
@@ -254,6 +251,10 @@ func NewDecEncoder(msg Message) (*DecEncoder, error) {
 		return byte((sum & 0xFF) ^ (sum >> 8))
 	}()
 
+	if p, ok := msg.(CRCExtraProvider); ok {
+		mde.crcExtra = p.CRCExtra()
+	}
+
 	return mde, nil
 }
 
@@ -262,6 +263,16 @@ func (mde *DecEncoder) CRCExtra() byte {
 	return mde.crcExtra
 }
 
+// SizeNormal returns the size of the encoded message, without extensions.
+func (mde *DecEncoder) SizeNormal() int {
+	return int(mde.sizeNormal)
+}
+
+// SizeExtended returns the size of the encoded message, including extensions.
+func (mde *DecEncoder) SizeExtended() int {
+	return int(mde.sizeExtended)
+}
+
 // Decode decodes a Message.
 func (mde *DecEncoder) Decode(buf []byte, isV2 bool) (Message, error) {
 	msg := reflect.New(mde.elemType)
@@ -270,8 +281,11 @@ func (mde *DecEncoder) Decode(buf []byte, isV2 bool) (Message, error) {
 		// in V2 buffer length can be > message or < message
 		// in this latter case it must be filled with zeros to support empty-byte de-truncation
 		// and extension fields
+		// a new buffer is allocated since the original one may be shared.
 		if len(buf) < int(mde.sizeExtended) {
-			buf = append(buf, bytes.Repeat([]byte{0x00}, int(mde.sizeExtended)-len(buf))...)
+			padded := make([]byte, mde.sizeExtended)
+			copy(padded, buf)
+			buf = padded
 		}
 	} else {
 		// in V1 buffer must fit message perfectly
@@ -280,6 +294,11 @@ func (mde *DecEncoder) Decode(buf []byte, isV2 bool) (Message, error) {
 		}
 	}
 
+	if mde.marshaler {
+		msg.Interface().(Marshaler).UnmarshalPayload(buf)
+		return msg.Interface().(Message), nil
+	}
+
 	// decode field by field
 	for _, f := range mde.fields {
 		// skip extensions in V1 frames
@@ -308,36 +327,53 @@ func (mde *DecEncoder) Decode(buf []byte, isV2 bool) (Message, error) {
 
 // Encode encodes a message.
 func (mde *DecEncoder) Encode(msg Message, isV2 bool) ([]byte, error) {
-	var buf []byte
+	return mde.EncodeTo(nil, msg, isV2)
+}
 
+// EncodeTo encodes a message into buf, in order to avoid allocations.
+// A new buffer is allocated only if the capacity of buf is not enough.
+// It returns the encoded message, that may point to buf.
+func (mde *DecEncoder) EncodeTo(buf []byte, msg Message, isV2 bool) ([]byte, error) {
+	size := int(mde.sizeNormal)
 	if isV2 {
-		buf = make([]byte, mde.sizeExtended)
+		size = int(mde.sizeExtended)
+	}
+
+	if cap(buf) < size {
+		buf = make([]byte, size)
 	} else {
-		buf = make([]byte, mde.sizeNormal)
+		buf = buf[:size]
+		for i := range buf {
+			buf[i] = 0
+		}
 	}
 
 	start := buf
 
-	// encode field by field
-	for _, f := range mde.fields {
-		// skip extensions in V1 frames
-		if !isV2 && f.isExtension {
-			continue
-		}
+	if mde.marshaler {
+		msg.(Marshaler).MarshalPayload(buf)
+	} else {
+		// encode field by field
+		for _, f := range mde.fields {
+			// skip extensions in V1 frames
+			if !isV2 && f.isExtension {
+				continue
+			}
 
-		target := reflect.ValueOf(msg).Elem().Field(f.index)
+			target := reflect.ValueOf(msg).Elem().Field(f.index)
 
-		switch target.Kind() {
-		case reflect.Array:
-			length := target.Len()
-			for i := 0; i < length; i++ {
-				n := valueEncode(buf, target.Index(i), f)
+			switch target.Kind() {
+			case reflect.Array:
+				length := target.Len()
+				for i := 0; i < length; i++ {
+					n := valueEncode(buf, target.Index(i), f)
+					buf = buf[n:]
+				}
+
+			default:
+				n := valueEncode(buf, target, f)
 				buf = buf[n:]
 			}
-
-		default:
-			n := valueEncode(buf, target, f)
-			buf = buf[n:]
 		}
 	}
 
@@ -2,6 +2,7 @@ package msg
 
 import (
 	"bytes"
+	"reflect"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -178,6 +179,45 @@ func TestCRC(t *testing.T) {
 	}
 }
 
+type MessageExperimental struct {
+	Value uint32
+}
+
+func (*MessageExperimental) GetID() uint32 {
+	return 50000
+}
+
+func (*MessageExperimental) CRCExtra() byte {
+	return 123
+}
+
+func TestCRCExtraProvider(t *testing.T) {
+	mp, err := NewDecEncoder(&MessageExperimental{})
+	require.NoError(t, err)
+	require.Equal(t, byte(123), mp.CRCExtra())
+
+	byt, err := mp.Encode(&MessageExperimental{Value: 5}, true)
+	require.NoError(t, err)
+
+	m, err := mp.Decode(byt, true)
+	require.NoError(t, err)
+	require.Equal(t, &MessageExperimental{Value: 5}, m)
+}
+
+type messageNotPointer struct{}
+
+func (messageNotPointer) GetID() uint32 {
+	return 1
+}
+
+func TestNewDecEncoderErrors(t *testing.T) {
+	_, err := NewDecEncoder(messageNotPointer{})
+	require.EqualError(t, err, "message must be a pointer to a struct")
+
+	_, err = NewDecEncoder(nil)
+	require.EqualError(t, err, "message must be a pointer to a struct")
+}
+
 var casesMsgs = []struct {
 	name   string
 	isV2   bool
@@ -369,3 +409,73 @@ func TestEncode(t *testing.T) {
 		})
 	}
 }
+
+func TestEncodeTo(t *testing.T) {
+	for _, c := range casesMsgs {
+		t.Run(c.name, func(t *testing.T) {
+			mp, err := NewDecEncoder(c.parsed)
+			require.NoError(t, err)
+
+			// a dirty buffer must be cleared before encoding
+			buf := make([]byte, 512)
+			for i := range buf {
+				buf[i] = 0xFF
+			}
+
+			byt, err := mp.EncodeTo(buf, c.parsed, c.isV2)
+			require.NoError(t, err)
+			require.Equal(t, c.raw, byt)
+		})
+	}
+}
+
+type customMsg struct{}
+
+func (*customMsg) GetID() uint32 {
+	return 1000
+}
+
+func TestName(t *testing.T) {
+	require.Equal(t, "HEARTBEAT", Name(&MessageHeartbeat{}))
+	require.Equal(t, "SYS_STATUS", Name(&MessageSysStatus{}))
+	require.Equal(t, "UNKNOWN_35", Name(&MessageRaw{ID: 35}))
+	require.Equal(t, "CUSTOM_MSG", Name(&customMsg{}))
+}
+
+type MessageDescribed struct {
+	Altitude float32 `mavunits:"m"`
+	Flags    uint16  `mavdisplay:"bitmask"`
+}
+
+func (*MessageDescribed) GetID() uint32 {
+	return 1001
+}
+
+func (*MessageDescribed) MessageDescription() string {
+	return "A described message."
+}
+
+func (*MessageDescribed) FieldDescription(name string) string {
+	switch name {
+	case "Altitude":
+		return "Altitude."
+	}
+	return ""
+}
+
+func TestMetadata(t *testing.T) {
+	m := &MessageDescribed{}
+	rt := reflect.TypeOf(m).Elem()
+
+	require.Equal(t, "A described message.", Description(m))
+	require.Equal(t, "Altitude.", FieldDescription(m, rt.Field(0)))
+	require.Equal(t, "", FieldDescription(m, rt.Field(1)))
+	require.Equal(t, "m", FieldUnits(rt.Field(0)))
+	require.Equal(t, "", FieldUnits(rt.Field(1)))
+	require.Equal(t, "bitmask", FieldDisplay(rt.Field(1)))
+
+	// messages without descriptions
+	require.Equal(t, "", Description(&MessageHeartbeat{}))
+	require.Equal(t, "", FieldDescription(&MessageHeartbeat{},
+		reflect.TypeOf(MessageHeartbeat{}).Field(0)))
+}
@@ -0,0 +1,12 @@
+package msg
+
+// NewReflectDecEncoder allocates a DecEncoder that ignores the Marshaler
+// interface, in order to compare generated methods with reflection.
+func NewReflectDecEncoder(m Message) (*DecEncoder, error) {
+	mde, err := NewDecEncoder(m)
+	if err != nil {
+		return nil, err
+	}
+	mde.marshaler = false
+	return mde, nil
+}
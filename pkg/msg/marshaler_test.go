@@ -0,0 +1,109 @@
+package msg_test
+
+import (
+	"math/rand"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialects/ardupilotmega"
+	"github.com/aler9/gomavlib/pkg/dialects/pythonarraytest"
+	"github.com/aler9/gomavlib/pkg/dialects/test"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+func randomValue(r *rand.Rand, v reflect.Value, f reflect.StructField) {
+	switch v.Kind() {
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			randomValue(r, v.Index(i), f)
+		}
+
+	case reflect.String:
+		l := 1
+		if tag := f.Tag.Get("mavlen"); tag != "" {
+			l, _ = strconv.Atoi(tag)
+		}
+		buf := make([]byte, r.Intn(l+1))
+		for i := range buf {
+			buf[i] = byte('a' + r.Intn(26))
+		}
+		v.SetString(string(buf))
+
+	case reflect.Int:
+		// enum
+		switch f.Tag.Get("mavenum") {
+		case "uint8", "int8":
+			v.SetInt(int64(r.Intn(1 << 8)))
+		case "uint16":
+			v.SetInt(int64(r.Intn(1 << 16)))
+		case "uint64":
+			v.SetInt(r.Int63())
+		default:
+			v.SetInt(int64(r.Uint32()))
+		}
+
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(int64(r.Uint64()))
+
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(r.Uint64())
+
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(r.NormFloat64())
+	}
+}
+
+func randomMessage(r *rand.Rand, tpl msg.Message) msg.Message {
+	v := reflect.New(reflect.TypeOf(tpl).Elem())
+	for i := 0; i < v.Elem().NumField(); i++ {
+		randomValue(r, v.Elem().Field(i), v.Elem().Type().Field(i))
+	}
+	return v.Interface().(msg.Message)
+}
+
+func TestMarshalerReflectionEquivalence(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	var msgs []msg.Message
+	msgs = append(msgs, ardupilotmega.Dialect.Messages...)
+	msgs = append(msgs, pythonarraytest.Dialect.Messages...)
+	msgs = append(msgs, test.Dialect.Messages...)
+
+	for _, tpl := range msgs {
+		t.Run(msg.Name(tpl), func(t *testing.T) {
+			_, ok := tpl.(msg.Marshaler)
+			require.True(t, ok)
+
+			gen, err := msg.NewDecEncoder(tpl)
+			require.NoError(t, err)
+
+			ref, err := msg.NewReflectDecEncoder(tpl)
+			require.NoError(t, err)
+
+			for i := 0; i < 10; i++ {
+				m := randomMessage(r, tpl)
+
+				for _, isV2 := range []bool{false, true} {
+					enc1, err := gen.Encode(m, isV2)
+					require.NoError(t, err)
+					enc2, err := ref.Encode(m, isV2)
+					require.NoError(t, err)
+					require.Equal(t, enc2, enc1)
+
+					dec1, err := gen.Decode(enc1, isV2)
+					require.NoError(t, err)
+					dec2, err := ref.Decode(enc1, isV2)
+					require.NoError(t, err)
+					require.Equal(t, dec2, dec1)
+
+					if isV2 {
+						require.Equal(t, m, dec1)
+					}
+				}
+			}
+		})
+	}
+}
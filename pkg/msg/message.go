@@ -2,12 +2,52 @@
 // decode messages.
 package msg
 
+import (
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
 // Message is the interface that must be implemented by all Mavlink messages.
 // Furthermore, any message must be labeled "MessageNameOfMessage".
 type Message interface {
 	GetID() uint32
 }
 
+// CRCExtraProvider is implemented by messages that specify their CRC extra,
+// instead of letting DecEncoder compute it from their fields. It allows to
+// define messages in Go, without XML definitions, whose fields do not match
+// exactly the definition used by other Mavlink implementations, or whose CRC
+// extra must be pinned.
+type CRCExtraProvider interface {
+	CRCExtra() byte
+}
+
+// Marshaler is implemented by messages that are able to encode and decode
+// their payload without reflection. Messages generated by dialect-import
+// implement it, and DecEncoder uses it when available.
+type Marshaler interface {
+	// MarshalPayload encodes the message into buf, that is filled with zeros.
+	// Extensions are encoded only if buf is big enough to contain them.
+	MarshalPayload(buf []byte)
+
+	// UnmarshalPayload decodes the message from buf.
+	// Extensions are decoded only if buf is big enough to contain them.
+	UnmarshalPayload(buf []byte)
+}
+
+// DescriptionProvider is implemented by messages that provide the
+// descriptions of the message and of its fields, that are taken from their
+// XML definition. Dialects generated by dialect-import implement it.
+type DescriptionProvider interface {
+	// MessageDescription returns the description of the message.
+	MessageDescription() string
+
+	// FieldDescription returns the description of a field, given its Go name.
+	FieldDescription(name string) string
+}
+
 // MessageRaw is a special struct that contains an unencoded message.
 // It is used:
 //
@@ -24,3 +64,57 @@ type MessageRaw struct {
 func (m *MessageRaw) GetID() uint32 {
 	return m.ID
 }
+
+var reUpper = regexp.MustCompile("([A-Z])")
+
+// Name returns the Mavlink name of a message, for instance
+// MessageCommandLong -> COMMAND_LONG. Raw messages are named after their ID,
+// for instance UNKNOWN_123.
+func Name(m Message) string {
+	if raw, ok := m.(*MessageRaw); ok {
+		return "UNKNOWN_" + strconv.FormatUint(uint64(raw.ID), 10)
+	}
+
+	name := strings.TrimPrefix(reflect.TypeOf(m).Elem().Name(), "Message")
+	name = reUpper.ReplaceAllString(name, "_${1}")
+	return strings.ToUpper(strings.TrimPrefix(name, "_"))
+}
+
+// FieldName returns the Mavlink name of a message field, for instance
+// TargetSystem -> target_system.
+func FieldName(f reflect.StructField) string {
+	if mavname := f.Tag.Get("mavname"); mavname != "" {
+		return mavname
+	}
+	return fieldGoToDef(f.Name)
+}
+
+// FieldUnits returns the units of a message field, for instance m/s or degE7.
+// It returns an empty string if units are not defined.
+func FieldUnits(f reflect.StructField) string {
+	return f.Tag.Get("mavunits")
+}
+
+// FieldDisplay returns the display hint of a message field, for instance
+// bitmask. It returns an empty string if the hint is not defined.
+func FieldDisplay(f reflect.StructField) string {
+	return f.Tag.Get("mavdisplay")
+}
+
+// Description returns the description of a message. It returns an empty
+// string if the message does not implement DescriptionProvider.
+func Description(m Message) string {
+	if p, ok := m.(DescriptionProvider); ok {
+		return p.MessageDescription()
+	}
+	return ""
+}
+
+// FieldDescription returns the description of a message field. It returns an
+// empty string if the message does not implement DescriptionProvider.
+func FieldDescription(m Message, f reflect.StructField) string {
+	if p, ok := m.(DescriptionProvider); ok {
+		return p.FieldDescription(f.Name)
+	}
+	return ""
+}
@@ -0,0 +1,328 @@
+// Package ntrip implements a NTRIP client, that receives RTCM3 corrections
+// from a NTRIP caster and forwards them to a writer, usually a
+// gomavlib.RTCMInjector:
+//
+//	inj, _ := gomavlib.NewRTCMInjector(gomavlib.RTCMInjectorConf{Node: node})
+//	cl, _ := ntrip.NewClient(ntrip.ClientConf{
+//		Address:    "caster.example.com:2101",
+//		Mountpoint: "MOUNT",
+//		Writer:     inj,
+//	})
+package ntrip
+
+import (
+	"bufio"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http/httputil"
+	"net/textproto"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Position is the position of the rover, that is sent to the caster with
+// GGA sentences.
+type Position struct {
+	// degrees
+	Latitude float64
+	// degrees
+	Longitude float64
+	// meters above mean sea level
+	Altitude float64
+	// number of satellites in use. If zero, 12 is sent.
+	Satellites int
+}
+
+// ClientConf allows to configure a Client.
+type ClientConf struct {
+	// the address of the caster, in format host:port.
+	Address string
+	// the mountpoint.
+	Mountpoint string
+	// the writer to which corrections are written, for instance a
+	// gomavlib.RTCMInjector.
+	Writer io.Writer
+
+	// (optional) the credentials.
+	User string
+	Pass string
+
+	// (optional) a function that returns the position of the rover, that is
+	// periodically sent to the caster. It is required by mountpoints that
+	// generate corrections for the rover position (VRS). It can return nil if
+	// the position is not known yet.
+	PositionProvider func() *Position
+	// (optional) the period between positions. It defaults to 10 seconds.
+	PositionPeriod time.Duration
+
+	// (optional) the maximum time without receiving data, after which the
+	// connection is closed. It defaults to 30 seconds.
+	ReadTimeout time.Duration
+	// (optional) the time waited before connecting again after an error.
+	// It defaults to 2 seconds.
+	RetryPeriod time.Duration
+	// (optional) a function that is called when the connection fails.
+	OnError func(error)
+}
+
+// Client connects to a NTRIP caster and writes the received corrections into
+// a writer. It connects again in case of errors.
+type Client struct {
+	conf ClientConf
+
+	mutex sync.Mutex
+	conn  net.Conn
+
+	// in
+	terminate chan struct{}
+
+	// out
+	done chan struct{}
+}
+
+// NewClient allocates a Client. See ClientConf for the options.
+func NewClient(conf ClientConf) (*Client, error) {
+	if conf.Address == "" {
+		return nil, fmt.Errorf("Address not provided")
+	}
+	if conf.Mountpoint == "" {
+		return nil, fmt.Errorf("Mountpoint not provided")
+	}
+	if conf.Writer == nil {
+		return nil, fmt.Errorf("Writer not provided")
+	}
+	if conf.PositionPeriod == 0 {
+		conf.PositionPeriod = 10 * time.Second
+	}
+	if conf.ReadTimeout == 0 {
+		conf.ReadTimeout = 30 * time.Second
+	}
+	if conf.RetryPeriod == 0 {
+		conf.RetryPeriod = 2 * time.Second
+	}
+
+	c := &Client{
+		conf:      conf,
+		terminate: make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+
+	go c.run()
+
+	return c, nil
+}
+
+// Close closes the client.
+func (c *Client) Close() {
+	close(c.terminate)
+
+	c.mutex.Lock()
+	if c.conn != nil {
+		c.conn.Close()
+	}
+	c.mutex.Unlock()
+
+	<-c.done
+}
+
+func (c *Client) run() {
+	defer close(c.done)
+
+	for {
+		err := c.runSession()
+
+		select {
+		case <-c.terminate:
+			return
+		default:
+		}
+
+		if c.conf.OnError != nil {
+			c.conf.OnError(err)
+		}
+
+		select {
+		case <-time.After(c.conf.RetryPeriod):
+		case <-c.terminate:
+			return
+		}
+	}
+}
+
+func (c *Client) runSession() error {
+	conn, err := net.DialTimeout("tcp", c.conf.Address, c.conf.ReadTimeout)
+	if err != nil {
+		return err
+	}
+
+	c.mutex.Lock()
+	select {
+	case <-c.terminate:
+		c.mutex.Unlock()
+		conn.Close()
+		return fmt.Errorf("terminated")
+	default:
+	}
+	c.conn = conn
+	c.mutex.Unlock()
+
+	defer func() {
+		c.mutex.Lock()
+		c.conn = nil
+		c.mutex.Unlock()
+		conn.Close()
+	}()
+
+	conn.SetWriteDeadline(time.Now().Add(c.conf.ReadTimeout)) //nolint:errcheck
+	_, err = conn.Write(c.request())
+	if err != nil {
+		return err
+	}
+
+	conn.SetReadDeadline(time.Now().Add(c.conf.ReadTimeout)) //nolint:errcheck
+	br := bufio.NewReader(conn)
+	body, err := readResponse(br)
+	if err != nil {
+		return err
+	}
+
+	positionDone := make(chan struct{})
+	positionTerminate := make(chan struct{})
+	go c.runPosition(conn, positionTerminate, positionDone)
+	defer func() {
+		close(positionTerminate)
+		<-positionDone
+	}()
+
+	buf := make([]byte, 1024)
+	for {
+		conn.SetReadDeadline(time.Now().Add(c.conf.ReadTimeout)) //nolint:errcheck
+		n, err := body.Read(buf)
+		if n > 0 {
+			_, werr := c.conf.Writer.Write(buf[:n])
+			if werr != nil {
+				return werr
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (c *Client) request() []byte {
+	var b strings.Builder
+	b.WriteString("GET /" + c.conf.Mountpoint + " HTTP/1.0\r\n")
+	b.WriteString("Host: " + c.conf.Address + "\r\n")
+	b.WriteString("User-Agent: NTRIP gomavlib\r\n")
+	if c.conf.User != "" {
+		b.WriteString("Authorization: Basic " +
+			base64.StdEncoding.EncodeToString([]byte(c.conf.User+":"+c.conf.Pass)) + "\r\n")
+	}
+	b.WriteString("\r\n")
+	return []byte(b.String())
+}
+
+// readResponse reads the response of the caster and returns a reader of the
+// corrections.
+func readResponse(br *bufio.Reader) (io.Reader, error) {
+	line, err := br.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	line = strings.TrimRight(line, "\r\n")
+
+	switch {
+	// NTRIP 1.0, data follows immediately
+	case line == "ICY 200 OK":
+		return br, nil
+
+	case strings.HasPrefix(line, "SOURCETABLE "):
+		return nil, fmt.Errorf("mountpoint not found")
+
+	case strings.HasPrefix(line, "HTTP/1.") && len(strings.Fields(line)) >= 2 &&
+		strings.Fields(line)[1] == "200":
+		header, err := textproto.NewReader(br).ReadMIMEHeader()
+		if err != nil {
+			return nil, err
+		}
+
+		if strings.EqualFold(header.Get("Content-Type"), "gnss/sourcetable") {
+			return nil, fmt.Errorf("mountpoint not found")
+		}
+
+		if strings.EqualFold(header.Get("Transfer-Encoding"), "chunked") {
+			return httputil.NewChunkedReader(br), nil
+		}
+		return br, nil
+	}
+
+	return nil, fmt.Errorf("bad response: %s", line)
+}
+
+func (c *Client) runPosition(conn net.Conn, terminate chan struct{}, done chan struct{}) {
+	defer close(done)
+
+	if c.conf.PositionProvider == nil {
+		return
+	}
+
+	ticker := time.NewTicker(c.conf.PositionPeriod)
+	defer ticker.Stop()
+
+	for {
+		if pos := c.conf.PositionProvider(); pos != nil {
+			conn.SetWriteDeadline(time.Now().Add(c.conf.ReadTimeout)) //nolint:errcheck
+			_, err := conn.Write([]byte(formatGGA(time.Now().UTC(), pos)))
+			if err != nil {
+				return
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-terminate:
+			return
+		}
+	}
+}
+
+// formatGGA formats a position as a NMEA GGA sentence.
+func formatGGA(t time.Time, pos *Position) string {
+	coord := func(v float64, degDigits int, pos string, neg string) string {
+		hemi := pos
+		if v < 0 {
+			hemi = neg
+			v = -v
+		}
+		deg := math.Floor(v)
+		min := math.Round((v-deg)*60*1e5) / 1e5
+		if min >= 60 {
+			deg++
+			min = 0
+		}
+		return fmt.Sprintf("%0*d%08.5f,%s", degDigits, int(deg), min, hemi)
+	}
+
+	sats := pos.Satellites
+	if sats == 0 {
+		sats = 12
+	}
+
+	body := fmt.Sprintf("GPGGA,%02d%02d%02d.%02d,%s,%s,1,%02d,1.0,%.1f,M,0.0,M,,",
+		t.Hour(), t.Minute(), t.Second(), t.Nanosecond()/10000000,
+		coord(pos.Latitude, 2, "N", "S"),
+		coord(pos.Longitude, 3, "E", "W"),
+		sats, pos.Altitude)
+
+	var sum byte
+	for i := 0; i < len(body); i++ {
+		sum ^= body[i]
+	}
+
+	return fmt.Sprintf("$%s*%02X\r\n", body, sum)
+}
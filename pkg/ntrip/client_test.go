@@ -0,0 +1,154 @@
+package ntrip
+
+import (
+	"bufio"
+	"bytes"
+	"net"
+	"net/http/httputil"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testWriter struct {
+	mutex sync.Mutex
+	buf   bytes.Buffer
+	recv  chan struct{}
+}
+
+func (w *testWriter) Write(p []byte) (int, error) {
+	w.mutex.Lock()
+	w.buf.Write(p)
+	w.mutex.Unlock()
+	select {
+	case w.recv <- struct{}{}:
+	default:
+	}
+	return len(p), nil
+}
+
+func (w *testWriter) bytes() []byte {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return append([]byte(nil), w.buf.Bytes()...)
+}
+
+func TestFormatGGA(t *testing.T) {
+	require.Equal(t, "$GPGGA,123456.70,4530.00000,N,00715.00000,W,1,12,1.0,100.0,M,0.0,M,,*4C\r\n",
+		formatGGA(time.Date(2020, 1, 1, 12, 34, 56, 700000000, time.UTC), &Position{
+			Latitude:  45.5,
+			Longitude: -7.25,
+			Altitude:  100,
+		}))
+}
+
+func TestClient(t *testing.T) {
+	for _, ca := range []string{"v1", "v2 chunked"} {
+		t.Run(ca, func(t *testing.T) {
+			l, err := net.Listen("tcp", "127.0.0.1:0")
+			require.NoError(t, err)
+			defer l.Close()
+
+			serverDone := make(chan struct{})
+			go func() {
+				defer close(serverDone)
+
+				conn, err := l.Accept()
+				require.NoError(t, err)
+				defer conn.Close()
+
+				br := bufio.NewReader(conn)
+
+				line, err := br.ReadString('\n')
+				require.NoError(t, err)
+				require.Equal(t, "GET /MOUNT HTTP/1.0\r\n", line)
+
+				var auth string
+				for {
+					line, err = br.ReadString('\n')
+					require.NoError(t, err)
+					if line == "\r\n" {
+						break
+					}
+					if strings.HasPrefix(line, "Authorization: ") {
+						auth = line
+					}
+				}
+				require.Equal(t, "Authorization: Basic dXNlcjpwYXNz\r\n", auth)
+
+				if ca == "v1" {
+					conn.Write([]byte("ICY 200 OK\r\n")) //nolint:errcheck
+					conn.Write([]byte{1, 2, 3})          //nolint:errcheck
+				} else {
+					conn.Write([]byte("HTTP/1.1 200 OK\r\n" + //nolint:errcheck
+						"Content-Type: gnss/data\r\n" +
+						"Transfer-Encoding: chunked\r\n\r\n"))
+					cw := httputil.NewChunkedWriter(conn)
+					cw.Write([]byte{1, 2, 3}) //nolint:errcheck
+				}
+
+				// position
+				line, err = br.ReadString('\n')
+				require.NoError(t, err)
+				require.True(t, strings.HasPrefix(line, "$GPGGA,"))
+			}()
+
+			w := &testWriter{recv: make(chan struct{}, 1)}
+
+			c, err := NewClient(ClientConf{
+				Address:    l.Addr().String(),
+				Mountpoint: "MOUNT",
+				Writer:     w,
+				User:       "user",
+				Pass:       "pass",
+				PositionProvider: func() *Position {
+					return &Position{Latitude: 45, Longitude: 7}
+				},
+			})
+			require.NoError(t, err)
+			defer c.Close()
+
+			<-serverDone
+
+			for !bytes.Contains(w.bytes(), []byte{1, 2, 3}) {
+				select {
+				case <-w.recv:
+				case <-time.After(2 * time.Second):
+					t.Fatal("timeout")
+				}
+			}
+		})
+	}
+}
+
+func TestClientSourceTable(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.Write([]byte("SOURCETABLE 200 OK\r\n\r\n")) //nolint:errcheck
+		time.Sleep(500 * time.Millisecond)
+	}()
+
+	errs := make(chan error, 10)
+
+	c, err := NewClient(ClientConf{
+		Address:    l.Addr().String(),
+		Mountpoint: "MOUNT",
+		Writer:     &testWriter{},
+		OnError:    func(err error) { errs <- err },
+	})
+	require.NoError(t, err)
+	defer c.Close()
+
+	require.EqualError(t, <-errs, "mountpoint not found")
+}
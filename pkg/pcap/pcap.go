@@ -0,0 +1,231 @@
+// Package pcap implements a writer of packet captures (pcap) that contain
+// Mavlink frames, that can be opened with Wireshark and its Mavlink dissector,
+// and a reader and a player of captures, that allow to replay traffic.
+package pcap
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/frame"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+const (
+	bufferSize = 512
+
+	magicMicroseconds = 0xa1b2c3d4
+	snapLen           = 65535
+
+	ipv4HeaderSize = 20
+	udpHeaderSize  = 8
+
+	// the port on which the Mavlink dissector of Wireshark is registered
+	defaultPort = 14550
+)
+
+// LinkType is the link-layer type of a capture.
+type LinkType uint32
+
+// link-layer types.
+const (
+	// frames are encapsulated into IPv4 and UDP headers, addressed to
+	// 127.0.0.1:14550, in order to be decoded by the Mavlink dissector
+	// without additional configuration.
+	LinkTypeIPv4 LinkType = 228
+
+	// frames are written without encapsulation, with the first user-defined
+	// link-layer type (DLT_USER0). The Mavlink dissector must be associated
+	// to this type in the preferences of Wireshark.
+	LinkTypeUser0 LinkType = 147
+)
+
+// Writer writes Mavlink frames into a capture.
+// It can be used by multiple routines in parallel.
+type Writer struct {
+	w         io.Writer
+	dialectDE *dialect.DecEncoder
+	linkType  LinkType
+
+	mutex sync.Mutex
+	buf   []byte
+}
+
+// NewWriter allocates a Writer and writes the header of the capture.
+// If the dialect is not nil, it is used to encode frames with decoded
+// messages; otherwise only frames with a MessageRaw can be written.
+func NewWriter(w io.Writer, d *dialect.Dialect, linkType LinkType) (*Writer, error) {
+	switch linkType {
+	case LinkTypeIPv4, LinkTypeUser0:
+	default:
+		return nil, fmt.Errorf("unsupported link type (%d)", linkType)
+	}
+
+	var dialectDE *dialect.DecEncoder
+	if d != nil {
+		var err error
+		dialectDE, err = dialect.NewDecEncoder(d)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	header := make([]byte, 24)
+	binary.LittleEndian.PutUint32(header[0:], magicMicroseconds)
+	binary.LittleEndian.PutUint16(header[4:], 2)
+	binary.LittleEndian.PutUint16(header[6:], 4)
+	binary.LittleEndian.PutUint32(header[16:], snapLen)
+	binary.LittleEndian.PutUint32(header[20:], uint32(linkType))
+
+	_, err := w.Write(header)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Writer{
+		w:         w,
+		dialectDE: dialectDE,
+		linkType:  linkType,
+		buf:       make([]byte, 16+ipv4HeaderSize+udpHeaderSize+bufferSize),
+	}, nil
+}
+
+// WriteFrame writes a frame, that has been received or sent at the given time.
+// Frames with a decoded message are encoded again, preserving the payload
+// length of V2 frames, in order to keep checksums valid.
+func (w *Writer) WriteFrame(t time.Time, fr frame.Frame) error {
+	content, err := w.encodeMessage(fr)
+	if err != nil {
+		return err
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	offset := 16
+	if w.linkType == LinkTypeIPv4 {
+		offset += ipv4HeaderSize + udpHeaderSize
+	}
+
+	byt, err := fr.Encode(w.buf[offset:], content)
+	if err != nil {
+		return err
+	}
+
+	return w.writePacket(t, len(byt))
+}
+
+// WriteBytes writes a frame that is already encoded.
+func (w *Writer) WriteBytes(t time.Time, byt []byte) error {
+	if len(byt) > bufferSize {
+		return fmt.Errorf("frame is too big")
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	offset := 16
+	if w.linkType == LinkTypeIPv4 {
+		offset += ipv4HeaderSize + udpHeaderSize
+	}
+
+	copy(w.buf[offset:], byt)
+
+	return w.writePacket(t, len(byt))
+}
+
+// writePacket writes a packet whose frame is already in the buffer.
+// It must be called with the mutex locked.
+func (w *Writer) writePacket(t time.Time, frameLen int) error {
+	packetLen := frameLen
+	if w.linkType == LinkTypeIPv4 {
+		packetLen += ipv4HeaderSize + udpHeaderSize
+		encodeIPv4UDP(w.buf[16:], frameLen)
+	}
+
+	usec := t.UnixNano() / int64(time.Microsecond)
+	binary.LittleEndian.PutUint32(w.buf[0:], uint32(usec/1000000))
+	binary.LittleEndian.PutUint32(w.buf[4:], uint32(usec%1000000))
+	binary.LittleEndian.PutUint32(w.buf[8:], uint32(packetLen))
+	binary.LittleEndian.PutUint32(w.buf[12:], uint32(packetLen))
+
+	_, err := w.w.Write(w.buf[:16+packetLen])
+	return err
+}
+
+// encodeMessage returns the encoded message of a frame.
+func (w *Writer) encodeMessage(fr frame.Frame) ([]byte, error) {
+	m := fr.GetMessage()
+	if m == nil {
+		return nil, fmt.Errorf("message is nil")
+	}
+
+	if raw, ok := m.(*msg.MessageRaw); ok {
+		return raw.Content, nil
+	}
+
+	if w.dialectDE == nil {
+		return nil, fmt.Errorf("message cannot be encoded since dialect is nil")
+	}
+
+	mp, ok := w.dialectDE.MessageDEs[m.GetID()]
+	if !ok {
+		return nil, fmt.Errorf("message cannot be encoded since it is not in the dialect")
+	}
+
+	ff, isV2 := fr.(*frame.V2Frame)
+	if !isV2 || ff.PayloadLength == 0 {
+		return mp.Encode(m, isV2)
+	}
+
+	buf := make([]byte, 255)
+	_, err := mp.EncodeTo(buf, m, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// the payload length of a received frame may differ from the one of a
+	// standard encoding, since the sender may not truncate the payload or
+	// may not know all extension fields. Frames that have not been received
+	// do not have a payload length and are encoded in the standard way.
+	return buf[:ff.PayloadLength], nil
+}
+
+// encodeIPv4UDP fills the IPv4 and UDP headers of a packet.
+func encodeIPv4UDP(buf []byte, payloadLen int) {
+	totalLen := ipv4HeaderSize + udpHeaderSize + payloadLen
+
+	ip := buf[:ipv4HeaderSize]
+	ip[0] = 0x45 // version 4, header length 20
+	ip[1] = 0
+	binary.BigEndian.PutUint16(ip[2:], uint16(totalLen))
+	binary.BigEndian.PutUint16(ip[4:], 0)
+	binary.BigEndian.PutUint16(ip[6:], 0x4000) // don't fragment
+	ip[8] = 64                                 // TTL
+	ip[9] = 17                                 // UDP
+	binary.BigEndian.PutUint16(ip[10:], 0)
+	copy(ip[12:], []byte{127, 0, 0, 1})
+	copy(ip[16:], []byte{127, 0, 0, 1})
+	binary.BigEndian.PutUint16(ip[10:], ipv4Checksum(ip))
+
+	udp := buf[ipv4HeaderSize : ipv4HeaderSize+udpHeaderSize]
+	binary.BigEndian.PutUint16(udp[0:], defaultPort)
+	binary.BigEndian.PutUint16(udp[2:], defaultPort)
+	binary.BigEndian.PutUint16(udp[4:], uint16(udpHeaderSize+payloadLen))
+	binary.BigEndian.PutUint16(udp[6:], 0) // checksum is optional in IPv4
+}
+
+func ipv4Checksum(header []byte) uint16 {
+	var sum uint32
+	for i := 0; i < len(header); i += 2 {
+		sum += uint32(binary.BigEndian.Uint16(header[i:]))
+	}
+	for sum > 0xFFFF {
+		sum = (sum & 0xFFFF) + (sum >> 16)
+	}
+	return ^uint16(sum)
+}
@@ -0,0 +1,104 @@
+package pcap
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+	"github.com/aler9/gomavlib/pkg/frame"
+	"github.com/aler9/gomavlib/pkg/transceiver"
+)
+
+// testFrame returns a received frame with a decoded message, and its bytes.
+func testFrame(t *testing.T) (frame.Frame, []byte) {
+	dialectDE, err := dialect.NewDecEncoder(common.Dialect)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	w, err := transceiver.NewWriter(transceiver.WriterConf{
+		Writer:               &buf,
+		DialectDE:            dialectDE,
+		OutVersion:           transceiver.V2,
+		OutSystemID:          1,
+		OutTruncationDisable: true,
+	})
+	require.NoError(t, err)
+
+	err = w.WriteMessage(&common.MessageParamValue{ParamId: "test", ParamValue: 1})
+	require.NoError(t, err)
+	byts := append([]byte(nil), buf.Bytes()...)
+
+	r, err := transceiver.NewReader(transceiver.ReaderConf{
+		Reader:    &buf,
+		DialectDE: dialectDE,
+	})
+	require.NoError(t, err)
+
+	fr, err := r.Read()
+	require.NoError(t, err)
+	_, ok := fr.GetMessage().(*common.MessageParamValue)
+	require.Equal(t, true, ok)
+
+	return fr, byts
+}
+
+func TestWriterUser0(t *testing.T) {
+	fr, byts := testFrame(t)
+
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, common.Dialect, LinkTypeUser0)
+	require.NoError(t, err)
+
+	ts := time.Date(2021, 1, 2, 3, 4, 5, 6000, time.UTC)
+	err = w.WriteFrame(ts, fr)
+	require.NoError(t, err)
+
+	out := buf.Bytes()
+	require.Equal(t, uint32(magicMicroseconds), binary.LittleEndian.Uint32(out[0:]))
+	require.Equal(t, uint32(LinkTypeUser0), binary.LittleEndian.Uint32(out[20:]))
+
+	rec := out[24:]
+	require.Equal(t, uint32(ts.Unix()), binary.LittleEndian.Uint32(rec[0:]))
+	require.Equal(t, uint32(6), binary.LittleEndian.Uint32(rec[4:]))
+	require.Equal(t, uint32(len(byts)), binary.LittleEndian.Uint32(rec[8:]))
+
+	// the frame is encoded again with its original payload length
+	require.Equal(t, byts, rec[16:])
+}
+
+func TestWriterIPv4(t *testing.T) {
+	fr, byts := testFrame(t)
+
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, nil, LinkTypeIPv4)
+	require.NoError(t, err)
+
+	// frames with a decoded message cannot be encoded without a dialect
+	err = w.WriteFrame(time.Now(), fr)
+	require.EqualError(t, err, "message cannot be encoded since dialect is nil")
+
+	err = w.WriteBytes(time.Now(), byts)
+	require.NoError(t, err)
+
+	rec := buf.Bytes()[24:]
+	packetLen := int(binary.LittleEndian.Uint32(rec[8:]))
+	require.Equal(t, ipv4HeaderSize+udpHeaderSize+len(byts), packetLen)
+
+	ip := rec[16 : 16+ipv4HeaderSize]
+	require.Equal(t, uint16(0), ipv4Checksum(ip))
+	require.Equal(t, byte(17), ip[9])
+
+	udp := rec[16+ipv4HeaderSize:]
+	require.Equal(t, uint16(defaultPort), binary.BigEndian.Uint16(udp[2:]))
+	require.Equal(t, byts, udp[udpHeaderSize:])
+}
+
+func TestWriterErrors(t *testing.T) {
+	_, err := NewWriter(bytes.NewBuffer(nil), nil, 1)
+	require.EqualError(t, err, "unsupported link type (1)")
+}
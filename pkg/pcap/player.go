@@ -0,0 +1,210 @@
+package pcap
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// playerClock is the time source of a Player.
+type playerClock interface {
+	Now() time.Time
+	NewTimer(d time.Duration) (<-chan time.Time, func())
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) NewTimer(d time.Duration) (<-chan time.Time, func()) {
+	t := time.NewTimer(d)
+	return t.C, func() { t.Stop() }
+}
+
+// Player plays back a capture, by emitting the Mavlink data of its packets
+// with their original timing. It implements io.ReadWriteCloser and can be
+// used as a node endpoint, in order to test routers against captured traffic:
+//
+//	player, _ := pcap.NewPlayer(f)
+//	node, _ := gomavlib.NewNode(gomavlib.NodeConf{
+//		Endpoints: []gomavlib.EndpointConf{
+//			gomavlib.EndpointCustom{ReadWriteCloser: player},
+//		},
+//		...
+//	})
+//
+// The capture is read sequentially, therefore it does not need to fit into
+// memory. When the capture is over, Read() blocks until Close() is called;
+// Done() can be used to detect the end of the playback.
+// Frames written to the player are discarded.
+type Player struct {
+	r     *Reader
+	clock playerClock
+
+	mutex     sync.Mutex
+	speed     float64
+	next      *Packet
+	refWall   time.Time
+	refLog    time.Time
+	pending   []byte
+	err       error
+	changed   chan struct{}
+	closeOnce sync.Once
+	done      chan struct{}
+	terminate chan struct{}
+}
+
+// NewPlayer allocates a Player. The first packet is read immediately and is
+// used as the start of the playback.
+func NewPlayer(r io.Reader) (*Player, error) {
+	return newPlayer(r, realClock{})
+}
+
+func newPlayer(r io.Reader, clock playerClock) (*Player, error) {
+	rd, err := NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	first, err := rd.Read()
+	if err != nil {
+		if err == io.EOF {
+			return nil, fmt.Errorf("capture does not contain Mavlink data")
+		}
+		return nil, err
+	}
+
+	return &Player{
+		r:         rd,
+		clock:     clock,
+		speed:     1,
+		next:      first,
+		refWall:   clock.Now(),
+		refLog:    first.Time,
+		changed:   make(chan struct{}),
+		done:      make(chan struct{}),
+		terminate: make(chan struct{}),
+	}, nil
+}
+
+// Close implements io.Closer.
+func (p *Player) Close() error {
+	p.closeOnce.Do(func() {
+		close(p.terminate)
+	})
+	return nil
+}
+
+// Write implements io.Writer. Written data is discarded.
+func (p *Player) Write(buf []byte) (int, error) {
+	return len(buf), nil
+}
+
+// Read implements io.Reader.
+func (p *Player) Read(buf []byte) (int, error) {
+	for {
+		p.mutex.Lock()
+
+		if len(p.pending) > 0 {
+			n := copy(buf, p.pending)
+			p.pending = p.pending[n:]
+			p.mutex.Unlock()
+			return n, nil
+		}
+
+		changed := p.changed
+		var timerC <-chan time.Time
+		stopTimer := func() {}
+
+		if p.next != nil {
+			wait := time.Duration(float64(p.next.Time.Sub(p.refLog))/p.speed) - p.clock.Now().Sub(p.refWall)
+
+			if wait <= 0 {
+				data := p.next.Data
+				p.readNext()
+
+				n := copy(buf, data)
+				p.pending = data[n:]
+				p.mutex.Unlock()
+				return n, nil
+			}
+
+			timerC, stopTimer = p.clock.NewTimer(wait)
+		}
+
+		p.mutex.Unlock()
+
+		// wait for the next packet, for a change of the speed or for
+		// termination.
+		select {
+		case <-timerC:
+		case <-changed:
+		case <-p.terminate:
+			stopTimer()
+			return 0, io.EOF
+		}
+
+		stopTimer()
+	}
+}
+
+// readNext reads the packet that follows the current one.
+// It must be called with the mutex locked.
+func (p *Player) readNext() {
+	next, err := p.r.Read()
+	if err != nil {
+		p.next = nil
+		if err != io.EOF {
+			p.err = err
+		}
+		close(p.done)
+		return
+	}
+
+	// packets of captures that merge multiple interfaces are not always
+	// ordered by time; they are emitted immediately.
+	p.next = next
+}
+
+// Done returns a channel that is closed when all the packets of the capture
+// have been emitted, or when the capture cannot be read anymore.
+func (p *Player) Done() <-chan struct{} {
+	return p.done
+}
+
+// Err returns the error that stopped the playback before the end of the
+// capture, if any. It must be called after Done() is closed.
+func (p *Player) Err() error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.err
+}
+
+// Speed returns the playback speed.
+func (p *Player) Speed() float64 {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.speed
+}
+
+// SetSpeed sets the playback speed, as a multiplier of the original
+// speed (for instance 0.5 or 100). It defaults to 1.
+func (p *Player) SetSpeed(speed float64) error {
+	if speed <= 0 {
+		return fmt.Errorf("speed must be greater than zero")
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	now := p.clock.Now()
+	p.refLog = p.refLog.Add(time.Duration(float64(now.Sub(p.refWall)) * p.speed))
+	p.refWall = now
+	p.speed = speed
+	close(p.changed)
+	p.changed = make(chan struct{})
+	return nil
+}
@@ -0,0 +1,164 @@
+package pcap
+
+import (
+	"bytes"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testClock is a playerClock whose time is moved forward manually.
+type testClock struct {
+	mutex   sync.Mutex
+	now     time.Time
+	timers  map[*testTimer]struct{}
+	created chan time.Duration
+}
+
+type testTimer struct {
+	deadline time.Time
+	c        chan time.Time
+}
+
+func newTestClock() *testClock {
+	return &testClock{
+		now:     time.Unix(1000, 0),
+		timers:  make(map[*testTimer]struct{}),
+		created: make(chan time.Duration, 16),
+	}
+}
+
+func (c *testClock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.now
+}
+
+func (c *testClock) NewTimer(d time.Duration) (<-chan time.Time, func()) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	t := &testTimer{
+		deadline: c.now.Add(d),
+		c:        make(chan time.Time, 1),
+	}
+	c.timers[t] = struct{}{}
+	c.created <- d
+
+	return t.c, func() {
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+		delete(c.timers, t)
+	}
+}
+
+// Advance moves the time forward and fires expired timers.
+func (c *testClock) Advance(d time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.now = c.now.Add(d)
+	for t := range c.timers {
+		if !t.deadline.After(c.now) {
+			t.c <- c.now
+			delete(c.timers, t)
+		}
+	}
+}
+
+// newTestPlayer returns a player of a capture with 3 packets, one per second.
+// The payload of each packet is its index.
+func newTestPlayer(t *testing.T) (*Player, *testClock) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, nil, LinkTypeIPv4)
+	require.NoError(t, err)
+
+	start := time.Unix(1600000000, 0)
+	for i := 0; i < 3; i++ {
+		err = w.WriteBytes(start.Add(time.Duration(i)*time.Second), []byte{byte(i)})
+		require.NoError(t, err)
+	}
+
+	clock := newTestClock()
+	p, err := newPlayer(&buf, clock)
+	require.NoError(t, err)
+	return p, clock
+}
+
+func readPacket(t *testing.T, p *Player) byte {
+	buf := make([]byte, 512)
+	n, err := p.Read(buf)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	return buf[0]
+}
+
+func readPacketAsync(t *testing.T, p *Player) chan byte {
+	done := make(chan byte, 1)
+	go func() {
+		done <- readPacket(t, p)
+	}()
+	return done
+}
+
+func TestPlayerTiming(t *testing.T) {
+	p, clock := newTestPlayer(t)
+	defer p.Close()
+
+	require.Equal(t, byte(0), readPacket(t, p))
+
+	done := readPacketAsync(t, p)
+	require.Equal(t, 1*time.Second, <-clock.created)
+	clock.Advance(1 * time.Second)
+	require.Equal(t, byte(1), <-done)
+
+	require.Error(t, p.SetSpeed(0))
+	require.NoError(t, p.SetSpeed(10))
+	require.Equal(t, float64(10), p.Speed())
+
+	done = readPacketAsync(t, p)
+	require.Equal(t, 100*time.Millisecond, <-clock.created)
+	clock.Advance(100 * time.Millisecond)
+	require.Equal(t, byte(2), <-done)
+
+	<-p.Done()
+	require.NoError(t, p.Err())
+}
+
+func TestPlayerEnd(t *testing.T) {
+	p, clock := newTestPlayer(t)
+
+	for i := 0; i < 3; i++ {
+		clock.Advance(1 * time.Second)
+		require.Equal(t, byte(i), readPacket(t, p))
+	}
+
+	// at the end of the capture, Read() blocks until Close()
+	done := make(chan error)
+	go func() {
+		buf := make([]byte, 512)
+		_, err := p.Read(buf)
+		done <- err
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("should not happen")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	p.Close()
+	require.Equal(t, io.EOF, <-done)
+}
+
+func TestPlayerEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	_, err := NewWriter(&buf, nil, LinkTypeIPv4)
+	require.NoError(t, err)
+
+	_, err = NewPlayer(&buf)
+	require.EqualError(t, err, "capture does not contain Mavlink data")
+}
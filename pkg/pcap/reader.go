@@ -0,0 +1,416 @@
+package pcap
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"time"
+)
+
+const (
+	magicNanoseconds = 0xa1b23c4d
+
+	pcapngBlockSHB       = 0x0A0D0D0A
+	pcapngBlockIDB       = 0x00000001
+	pcapngBlockSPB       = 0x00000003
+	pcapngBlockEPB       = 0x00000006
+	pcapngByteOrderMagic = 0x1A2B3C4D
+	pcapngOptionTSResol  = 9
+
+	// maximum size of a packet or of a pcapng block
+	maxPacketSize = 256 * 1024
+)
+
+// link-layer types that can be read, in addition to the ones that can be
+// written.
+const (
+	linkTypeNull     = 0
+	linkTypeEthernet = 1
+	linkTypeRaw      = 101
+	linkTypeLinuxSLL = 113
+)
+
+// Packet is a packet of a capture that contains Mavlink data.
+type Packet struct {
+	// the time at which the packet was captured
+	Time time.Time
+	// the Mavlink data, that is the UDP or TCP payload of the packet or, with
+	// LinkTypeUser0, the whole packet.
+	Data []byte
+}
+
+type pcapngInterface struct {
+	linkType LinkType
+	tsUnit   time.Duration
+}
+
+// Reader reads packets that contain Mavlink data from a capture, in pcap or
+// pcapng format. Supported link-layer types are Ethernet, Linux cooked
+// capture, BSD loopback, raw IPv4 and IPv6 and LinkTypeUser0; packets of other
+// protocols, like ARP or ICMP, are skipped.
+type Reader struct {
+	br *bufio.Reader
+
+	// pcap
+	order    binary.ByteOrder
+	tsUnit   time.Duration
+	linkType LinkType
+
+	// pcapng
+	isNG       bool
+	interfaces []pcapngInterface
+}
+
+// NewReader allocates a Reader and reads the header of the capture.
+func NewReader(r io.Reader) (*Reader, error) {
+	rd := &Reader{
+		br: bufio.NewReader(r),
+	}
+
+	buf, err := rd.br.Peek(4)
+	if err != nil {
+		return nil, err
+	}
+
+	if binary.LittleEndian.Uint32(buf) == pcapngBlockSHB {
+		rd.isNG = true
+		return rd, nil
+	}
+
+	header := make([]byte, 24)
+	_, err = io.ReadFull(rd.br, header)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case binary.LittleEndian.Uint32(header) == magicMicroseconds:
+		rd.order, rd.tsUnit = binary.LittleEndian, time.Microsecond
+	case binary.BigEndian.Uint32(header) == magicMicroseconds:
+		rd.order, rd.tsUnit = binary.BigEndian, time.Microsecond
+	case binary.LittleEndian.Uint32(header) == magicNanoseconds:
+		rd.order, rd.tsUnit = binary.LittleEndian, time.Nanosecond
+	case binary.BigEndian.Uint32(header) == magicNanoseconds:
+		rd.order, rd.tsUnit = binary.BigEndian, time.Nanosecond
+	default:
+		return nil, fmt.Errorf("invalid magic number")
+	}
+
+	rd.linkType = LinkType(rd.order.Uint32(header[20:]) & 0xFFFF)
+
+	return rd, nil
+}
+
+// Read reads the next packet that contains Mavlink data. It returns io.EOF at
+// the end of the capture.
+func (r *Reader) Read() (*Packet, error) {
+	for {
+		var t time.Time
+		var linkType LinkType
+		var data []byte
+		var err error
+
+		if r.isNG {
+			t, linkType, data, err = r.readNG()
+		} else {
+			t, linkType, data, err = r.readPcap()
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// blocks that do not contain packets
+		if data == nil {
+			continue
+		}
+
+		payload := extractPayload(linkType, data)
+		if len(payload) == 0 {
+			continue
+		}
+
+		return &Packet{
+			Time: t,
+			Data: payload,
+		}, nil
+	}
+}
+
+func (r *Reader) readPcap() (time.Time, LinkType, []byte, error) {
+	header := make([]byte, 16)
+	_, err := io.ReadFull(r.br, header)
+	if err != nil {
+		return time.Time{}, 0, nil, err
+	}
+
+	sec := r.order.Uint32(header[0:])
+	frac := r.order.Uint32(header[4:])
+	capLen := r.order.Uint32(header[8:])
+
+	if capLen > maxPacketSize {
+		return time.Time{}, 0, nil, fmt.Errorf("packet is too big (%d)", capLen)
+	}
+
+	data := make([]byte, capLen)
+	_, err = io.ReadFull(r.br, data)
+	if err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return time.Time{}, 0, nil, err
+	}
+
+	t := time.Unix(int64(sec), int64(frac)*int64(r.tsUnit))
+	return t, r.linkType, data, nil
+}
+
+func (r *Reader) readNG() (time.Time, LinkType, []byte, error) {
+	header := make([]byte, 8)
+	_, err := io.ReadFull(r.br, header)
+	if err != nil {
+		return time.Time{}, 0, nil, err
+	}
+
+	// the byte order is defined by the section header block
+	if binary.LittleEndian.Uint32(header) == pcapngBlockSHB {
+		bom, err := r.br.Peek(4)
+		if err != nil {
+			return time.Time{}, 0, nil, io.ErrUnexpectedEOF
+		}
+
+		switch {
+		case binary.LittleEndian.Uint32(bom) == pcapngByteOrderMagic:
+			r.order = binary.LittleEndian
+		case binary.BigEndian.Uint32(bom) == pcapngByteOrderMagic:
+			r.order = binary.BigEndian
+		default:
+			return time.Time{}, 0, nil, fmt.Errorf("invalid byte order magic")
+		}
+
+		// interfaces are defined per section
+		r.interfaces = nil
+	}
+
+	if r.order == nil {
+		return time.Time{}, 0, nil, fmt.Errorf("section header block is missing")
+	}
+
+	blockType := r.order.Uint32(header[0:])
+	blockLen := r.order.Uint32(header[4:])
+
+	if blockLen < 12 || blockLen%4 != 0 || blockLen > maxPacketSize {
+		return time.Time{}, 0, nil, fmt.Errorf("invalid block length (%d)", blockLen)
+	}
+
+	body := make([]byte, blockLen-8)
+	_, err = io.ReadFull(r.br, body)
+	if err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return time.Time{}, 0, nil, err
+	}
+
+	// remove the trailing block length
+	body = body[:len(body)-4]
+
+	switch blockType {
+	case pcapngBlockIDB:
+		if len(body) < 8 {
+			return time.Time{}, 0, nil, fmt.Errorf("invalid interface description block")
+		}
+
+		intf := pcapngInterface{
+			linkType: LinkType(r.order.Uint16(body[0:])),
+			tsUnit:   time.Microsecond,
+		}
+
+		// parse options, in order to find the timestamp resolution
+		opts := body[8:]
+		for len(opts) >= 4 {
+			code := r.order.Uint16(opts[0:])
+			l := int(r.order.Uint16(opts[2:]))
+			if code == 0 || 4+l > len(opts) {
+				break
+			}
+
+			if code == pcapngOptionTSResol && l >= 1 {
+				intf.tsUnit = tsResolToUnit(opts[4])
+			}
+
+			opts = opts[4+((l+3)&^3):]
+		}
+
+		r.interfaces = append(r.interfaces, intf)
+
+	case pcapngBlockEPB:
+		if len(body) < 20 {
+			return time.Time{}, 0, nil, fmt.Errorf("invalid enhanced packet block")
+		}
+
+		id := r.order.Uint32(body[0:])
+		if int(id) >= len(r.interfaces) {
+			return time.Time{}, 0, nil, fmt.Errorf("invalid interface id (%d)", id)
+		}
+		intf := r.interfaces[id]
+
+		ts := uint64(r.order.Uint32(body[4:]))<<32 | uint64(r.order.Uint32(body[8:]))
+		capLen := r.order.Uint32(body[12:])
+		if int(capLen) > len(body)-20 {
+			return time.Time{}, 0, nil, fmt.Errorf("invalid packet length (%d)", capLen)
+		}
+
+		return tsToTime(ts, intf.tsUnit), intf.linkType, body[20 : 20+capLen], nil
+
+	case pcapngBlockSPB:
+		if len(body) < 4 || len(r.interfaces) == 0 {
+			return time.Time{}, 0, nil, fmt.Errorf("invalid simple packet block")
+		}
+
+		// simple packets do not have a timestamp
+		origLen := int(r.order.Uint32(body[0:]))
+		data := body[4:]
+		if origLen < len(data) {
+			data = data[:origLen]
+		}
+
+		return time.Time{}, r.interfaces[0].linkType, data, nil
+	}
+
+	// other blocks are skipped
+	return time.Time{}, 0, nil, nil
+}
+
+// tsResolToUnit converts the if_tsresol option of pcapng into a duration.
+func tsResolToUnit(v byte) time.Duration {
+	// powers of two are not supported, since they are rarely used
+	if v&0x80 != 0 {
+		return time.Microsecond
+	}
+
+	unit := time.Second
+	for i := byte(0); i < v && unit > 1; i++ {
+		unit /= 10
+	}
+	return unit
+}
+
+func tsToTime(ts uint64, unit time.Duration) time.Time {
+	perSecond := uint64(time.Second / unit)
+	return time.Unix(int64(ts/perSecond), int64(ts%perSecond)*int64(unit))
+}
+
+// extractPayload returns the Mavlink data of a packet.
+func extractPayload(linkType LinkType, data []byte) []byte {
+	switch linkType {
+	case LinkTypeUser0:
+		return data
+
+	case LinkTypeIPv4, linkTypeRaw:
+		return extractIPPayload(data)
+
+	case linkTypeEthernet:
+		if len(data) < 14 {
+			return nil
+		}
+		etherType := binary.BigEndian.Uint16(data[12:])
+		data = data[14:]
+
+		// VLAN tag
+		if etherType == 0x8100 && len(data) >= 4 {
+			etherType = binary.BigEndian.Uint16(data[2:])
+			data = data[4:]
+		}
+
+		if etherType != 0x0800 && etherType != 0x86DD {
+			return nil
+		}
+		return extractIPPayload(data)
+
+	case linkTypeLinuxSLL:
+		if len(data) < 16 {
+			return nil
+		}
+		etherType := binary.BigEndian.Uint16(data[14:])
+		if etherType != 0x0800 && etherType != 0x86DD {
+			return nil
+		}
+		return extractIPPayload(data[16:])
+
+	case linkTypeNull:
+		// the address family is in host byte order, therefore it is not
+		// checked; the IP version is checked instead.
+		if len(data) < 4 {
+			return nil
+		}
+		return extractIPPayload(data[4:])
+	}
+
+	return nil
+}
+
+// extractIPPayload returns the UDP or TCP payload of an IPv4 or IPv6 packet.
+func extractIPPayload(data []byte) []byte {
+	if len(data) < 1 {
+		return nil
+	}
+
+	var proto byte
+
+	switch data[0] >> 4 {
+	case 4:
+		if len(data) < ipv4HeaderSize {
+			return nil
+		}
+		headerLen := int(data[0]&0x0F) * 4
+		totalLen := int(binary.BigEndian.Uint16(data[2:]))
+		if headerLen < ipv4HeaderSize || totalLen < headerLen || totalLen > len(data) {
+			return nil
+		}
+
+		// fragments are not supported
+		if binary.BigEndian.Uint16(data[6:])&0x3FFF != 0 {
+			return nil
+		}
+
+		proto = data[9]
+		data = data[headerLen:totalLen]
+
+	case 6:
+		if len(data) < 40 {
+			return nil
+		}
+		payloadLen := int(binary.BigEndian.Uint16(data[4:]))
+		if 40+payloadLen > len(data) {
+			return nil
+		}
+
+		// extension headers are not supported
+		proto = data[6]
+		data = data[40 : 40+payloadLen]
+
+	default:
+		return nil
+	}
+
+	switch proto {
+	case 17: // UDP
+		if len(data) < udpHeaderSize {
+			return nil
+		}
+		return data[udpHeaderSize:]
+
+	case 6: // TCP
+		if len(data) < 20 {
+			return nil
+		}
+		headerLen := int(data[12]>>4) * 4
+		if headerLen < 20 || headerLen > len(data) {
+			return nil
+		}
+		return data[headerLen:]
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package pcap
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReaderRoundTrip(t *testing.T) {
+	_, byts := testFrame(t)
+
+	for _, linkType := range []LinkType{LinkTypeIPv4, LinkTypeUser0} {
+		var buf bytes.Buffer
+		w, err := NewWriter(&buf, nil, linkType)
+		require.NoError(t, err)
+
+		ts := time.Date(2021, 1, 2, 3, 4, 5, 6000, time.UTC)
+		for i := 0; i < 2; i++ {
+			err = w.WriteBytes(ts.Add(time.Duration(i)*time.Second), byts)
+			require.NoError(t, err)
+		}
+
+		r, err := NewReader(&buf)
+		require.NoError(t, err)
+
+		for i := 0; i < 2; i++ {
+			pkt, err := r.Read()
+			require.NoError(t, err)
+			require.Equal(t, true, pkt.Time.Equal(ts.Add(time.Duration(i)*time.Second)))
+			require.Equal(t, byts, pkt.Data)
+		}
+
+		_, err = r.Read()
+		require.Equal(t, io.EOF, err)
+	}
+}
+
+// ethernetPacket encapsulates a payload into Ethernet, IPv4 and UDP headers.
+func ethernetPacket(payload []byte) []byte {
+	pkt := make([]byte, 14+ipv4HeaderSize+udpHeaderSize+len(payload))
+	binary.BigEndian.PutUint16(pkt[12:], 0x0800)
+	encodeIPv4UDP(pkt[14:], len(payload))
+	copy(pkt[14+ipv4HeaderSize+udpHeaderSize:], payload)
+	return pkt
+}
+
+// pcapngBlock encodes a little-endian pcapng block.
+func pcapngBlock(typ uint32, body []byte) []byte {
+	for len(body)%4 != 0 {
+		body = append(body, 0)
+	}
+
+	blk := make([]byte, 12+len(body))
+	binary.LittleEndian.PutUint32(blk[0:], typ)
+	binary.LittleEndian.PutUint32(blk[4:], uint32(len(blk)))
+	copy(blk[8:], body)
+	binary.LittleEndian.PutUint32(blk[8+len(body):], uint32(len(blk)))
+	return blk
+}
+
+func TestReaderPcapng(t *testing.T) {
+	_, byts := testFrame(t)
+
+	var buf bytes.Buffer
+
+	shb := make([]byte, 16)
+	binary.LittleEndian.PutUint32(shb[0:], pcapngByteOrderMagic)
+	binary.LittleEndian.PutUint16(shb[4:], 1)
+	binary.LittleEndian.PutUint64(shb[8:], 0xFFFFFFFFFFFFFFFF)
+	buf.Write(pcapngBlock(pcapngBlockSHB, shb))
+
+	// Ethernet interface with nanosecond resolution
+	idb := make([]byte, 8, 20)
+	binary.LittleEndian.PutUint16(idb[0:], linkTypeEthernet)
+	idb = append(idb, pcapngOptionTSResol, 0, 1, 0, 9, 0, 0, 0, 0, 0, 0, 0)
+	buf.Write(pcapngBlock(pcapngBlockIDB, idb))
+
+	// unknown block
+	buf.Write(pcapngBlock(0x0BAD, []byte{1, 2, 3, 4}))
+
+	ts := time.Date(2021, 1, 2, 3, 4, 5, 6, time.UTC)
+
+	for _, pkt := range [][]byte{
+		// ARP packet, that is skipped
+		append(make([]byte, 12), 0x08, 0x06, 0, 0),
+		ethernetPacket(byts),
+	} {
+		epb := make([]byte, 20+len(pkt))
+		nsec := uint64(ts.UnixNano())
+		binary.LittleEndian.PutUint32(epb[4:], uint32(nsec>>32))
+		binary.LittleEndian.PutUint32(epb[8:], uint32(nsec))
+		binary.LittleEndian.PutUint32(epb[12:], uint32(len(pkt)))
+		binary.LittleEndian.PutUint32(epb[16:], uint32(len(pkt)))
+		copy(epb[20:], pkt)
+		buf.Write(pcapngBlock(pcapngBlockEPB, epb))
+	}
+
+	r, err := NewReader(&buf)
+	require.NoError(t, err)
+
+	pkt, err := r.Read()
+	require.NoError(t, err)
+	require.Equal(t, true, pkt.Time.Equal(ts))
+	require.Equal(t, byts, pkt.Data)
+
+	_, err = r.Read()
+	require.Equal(t, io.EOF, err)
+}
+
+func TestReaderErrors(t *testing.T) {
+	_, err := NewReader(bytes.NewReader(make([]byte, 24)))
+	require.EqualError(t, err, "invalid magic number")
+
+	_, err = NewReader(bytes.NewReader(nil))
+	require.Equal(t, io.EOF, err)
+}
@@ -0,0 +1,201 @@
+// Package promtext exposes statistics of a Node in the Prometheus text
+// exposition format.
+//
+// The package does not depend on the Prometheus client library, therefore
+// it does not implement prometheus.Collector and metrics can't be added to
+// a prometheus.Registry; they are meant to be scraped from a dedicated
+// endpoint.
+//
+// Counters are cumulative; rates (frames per second, bytes per second) are
+// meant to be computed by the Prometheus server, for instance with
+//
+//	rate(mavlink_channel_frames_received_total[1m])
+package promtext
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/aler9/gomavlib"
+)
+
+const contentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Exporter exposes node and channel statistics as Prometheus metrics.
+// It implements http.Handler and can be registered into a HTTP server:
+//
+//	http.Handle("/metrics", promtext.NewExporter(node))
+type Exporter struct {
+	node      *gomavlib.Node
+	namespace string
+}
+
+// NewExporter allocates an Exporter.
+func NewExporter(node *gomavlib.Node) *Exporter {
+	return &Exporter{
+		node:      node,
+		namespace: "mavlink",
+	}
+}
+
+// SetNamespace sets the prefix of metric names. It defaults to "mavlink".
+func (e *Exporter) SetNamespace(namespace string) {
+	e.namespace = namespace
+}
+
+type metric struct {
+	name   string
+	help   string
+	typ    string
+	values []sample
+}
+
+type sample struct {
+	labels string
+	value  float64
+}
+
+func labels(kv ...string) string {
+	var parts []string
+	for i := 0; i < len(kv); i += 2 {
+		parts = append(parts, kv[i]+"=\""+escapeLabel(kv[i+1])+"\"")
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+func escapeLabel(v string) string {
+	v = strings.ReplaceAll(v, "\\", "\\\\")
+	v = strings.ReplaceAll(v, "\"", "\\\"")
+	v = strings.ReplaceAll(v, "\n", "\\n")
+	return v
+}
+
+func (e *Exporter) metrics() []*metric {
+	newMetric := func(name string, typ string, help string) *metric {
+		return &metric{
+			name: e.namespace + "_" + name,
+			help: help,
+			typ:  typ,
+		}
+	}
+
+	channels := newMetric("channels", "gauge", "Number of open channels.")
+	framesIn := newMetric("channel_frames_received_total", "counter", "Frames received by channel.")
+	framesOut := newMetric("channel_frames_sent_total", "counter", "Frames sent by channel.")
+	bytesIn := newMetric("channel_bytes_received_total", "counter", "Bytes received by channel.")
+	bytesOut := newMetric("channel_bytes_sent_total", "counter", "Bytes sent by channel.")
+	parseErrors := newMetric("channel_parse_errors_total", "counter", "Frames that could not be parsed, by channel.")
+	sigErrors := newMetric("channel_signature_errors_total", "counter",
+		"Frames discarded because of an invalid signature, by channel.")
+	crcErrors := newMetric("channel_checksum_errors_total", "counter",
+		"Frames discarded because of a wrong checksum, by channel.")
+	unknownMessages := newMetric("channel_unknown_messages_total", "counter",
+		"Frames discarded because their message is not in the dialect, by channel.")
+	writeDrops := newMetric("channel_write_drops_total", "counter",
+		"Writes discarded because the channel could not keep up, by channel.")
+	rtt := newMetric("channel_rtt_seconds", "gauge",
+		"Round-trip time measured with TIMESYNC, by channel. It is zero if unknown.")
+	sysFrames := newMetric("system_frames_received_total", "counter", "Frames received by remote system.")
+	sysLost := newMetric("system_frames_lost_total", "counter", "Frames lost by remote system.")
+	sysLoss := newMetric("system_loss_ratio", "gauge", "Ratio of lost frames with respect to the expected ones, by remote system.")
+
+	chStats := e.node.ChannelStats()
+	sort.Slice(chStats, func(i, j int) bool {
+		return chStats[i].Channel.String() < chStats[j].Channel.String()
+	})
+
+	channels.values = append(channels.values, sample{"", float64(len(chStats))})
+
+	for _, s := range chStats {
+		l := labels("channel", s.Channel.String())
+		framesIn.values = append(framesIn.values, sample{l, float64(s.FramesIn)})
+		framesOut.values = append(framesOut.values, sample{l, float64(s.FramesOut)})
+		bytesIn.values = append(bytesIn.values, sample{l, float64(s.BytesIn)})
+		bytesOut.values = append(bytesOut.values, sample{l, float64(s.BytesOut)})
+		parseErrors.values = append(parseErrors.values, sample{l, float64(s.ParseErrors)})
+		sigErrors.values = append(sigErrors.values, sample{l, float64(s.SignatureErrors)})
+		crcErrors.values = append(crcErrors.values, sample{l, float64(s.ChecksumErrors)})
+		unknownMessages.values = append(unknownMessages.values, sample{l, float64(s.UnknownMessages)})
+		writeDrops.values = append(writeDrops.values, sample{l, float64(s.WriteDrops)})
+		rtt.values = append(rtt.values, sample{l, s.RTT.Seconds()})
+	}
+
+	sysStats := e.node.SystemStats()
+	sort.Slice(sysStats, func(i, j int) bool {
+		a, b := sysStats[i], sysStats[j]
+		if a.Channel.String() != b.Channel.String() {
+			return a.Channel.String() < b.Channel.String()
+		}
+		if a.SystemID != b.SystemID {
+			return a.SystemID < b.SystemID
+		}
+		return a.ComponentID < b.ComponentID
+	})
+
+	for _, s := range sysStats {
+		l := labels("channel", s.Channel.String(),
+			"system_id", fmt.Sprintf("%d", s.SystemID),
+			"component_id", fmt.Sprintf("%d", s.ComponentID))
+		sysFrames.values = append(sysFrames.values, sample{l, float64(s.FramesReceived)})
+		sysLost.values = append(sysLost.values, sample{l, float64(s.FramesLost)})
+		sysLoss.values = append(sysLoss.values, sample{l, s.LossPercentage() / 100})
+	}
+
+	return []*metric{
+		channels,
+		framesIn,
+		framesOut,
+		bytesIn,
+		bytesOut,
+		parseErrors,
+		sigErrors,
+		crcErrors,
+		unknownMessages,
+		writeDrops,
+		rtt,
+		sysFrames,
+		sysLost,
+		sysLoss,
+	}
+}
+
+// Write writes the current value of metrics into w.
+func (e *Exporter) Write(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	for _, m := range e.metrics() {
+		fmt.Fprintf(bw, "# HELP %s %s\n", m.name, m.help)
+		fmt.Fprintf(bw, "# TYPE %s %s\n", m.name, m.typ)
+		for _, s := range m.values {
+			fmt.Fprintf(bw, "%s%s %v\n", m.name, s.labels, s.value)
+		}
+	}
+
+	return bw.Flush()
+}
+
+// ServeHTTP implements http.Handler.
+func (e *Exporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	// metrics are rendered before writing the response, in order to be able
+	// to report errors.
+	var buf bytes.Buffer
+	err := e.Write(&buf)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// errors of the client connection can't be reported to the client
+	w.Header().Set("Content-Type", contentType)
+	w.Write(buf.Bytes()) //nolint:errcheck
+}
@@ -0,0 +1,88 @@
+package promtext
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib"
+)
+
+// testRWC returns an invalid byte, then blocks until closed.
+type testRWC struct {
+	sent bool
+	done chan struct{}
+}
+
+func (t *testRWC) Read(p []byte) (int, error) {
+	if !t.sent {
+		t.sent = true
+		p[0] = 0x01
+		return 1, nil
+	}
+	<-t.done
+	return 0, io.EOF
+}
+
+func (t *testRWC) Write(p []byte) (int, error) {
+	return len(p), nil
+}
+
+func (t *testRWC) Close() error {
+	close(t.done)
+	return nil
+}
+
+func TestExporter(t *testing.T) {
+	node, err := gomavlib.NewNode(gomavlib.NodeConf{
+		OutVersion:  gomavlib.V2,
+		OutSystemID: 10,
+		Endpoints: []gomavlib.EndpointConf{
+			gomavlib.EndpointCustom{ReadWriteCloser: &testRWC{done: make(chan struct{})}},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node.Close()
+
+	for evt := range node.Events() {
+		if _, ok := evt.(*gomavlib.EventParseError); ok {
+			break
+		}
+	}
+
+	e := NewExporter(node)
+	e.SetNamespace("test")
+
+	srv := httptest.NewServer(e)
+	defer srv.Close()
+
+	res, err := http.Get(srv.URL)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	require.Equal(t, contentType, res.Header.Get("Content-Type"))
+
+	byts, err := ioutil.ReadAll(res.Body)
+	require.NoError(t, err)
+	body := string(byts)
+
+	require.True(t, strings.Contains(body, "# TYPE test_channels gauge\ntest_channels 1\n"))
+	require.True(t, strings.Contains(body, "# TYPE test_channel_parse_errors_total counter\n"+
+		"test_channel_parse_errors_total{channel=\"custom\"} 1\n"))
+	require.True(t, strings.Contains(body, "test_channel_bytes_received_total{channel=\"custom\"} 1\n"))
+	require.True(t, strings.Contains(body, "test_channel_signature_errors_total{channel=\"custom\"} 0\n"))
+	require.True(t, strings.Contains(body, "test_channel_write_drops_total{channel=\"custom\"} 0\n"))
+	require.True(t, strings.Contains(body, "test_channel_rtt_seconds{channel=\"custom\"} 0\n"))
+	require.True(t, strings.Contains(body, "test_channel_checksum_errors_total{channel=\"custom\"} 0\n"))
+}
+
+func TestEscapeLabel(t *testing.T) {
+	require.Equal(t, `a\\b\"c\nd`, escapeLabel("a\\b\"c\nd"))
+}
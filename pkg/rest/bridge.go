@@ -0,0 +1,316 @@
+// Package rest exposes a Node over HTTP, with an API compatible with
+// mavlink2rest.
+//
+// Available routes are:
+//
+//	GET  /mavlink             latest messages of every system and component
+//	GET  /mavlink/vehicles/1  latest messages of a system (also components/N, messages/NAME)
+//	POST /mavlink             send a message, in the form {"header": {...}, "message": {"type": "NAME", ...}}
+//	GET  /stream              server-sent events containing every received message
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aler9/gomavlib"
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/frame"
+)
+
+const (
+	streamQueueSize = 64
+)
+
+type messageStatusTime struct {
+	FirstUpdate time.Time `json:"first_update"`
+	LastUpdate  time.Time `json:"last_update"`
+	Counter     uint64    `json:"counter"`
+	Frequency   float64   `json:"frequency"`
+}
+
+type messageStatus struct {
+	Time messageStatusTime `json:"time"`
+}
+
+type messageEntry struct {
+	Message map[string]interface{} `json:"message"`
+	Status  messageStatus          `json:"status"`
+}
+
+type header struct {
+	SystemID    byte `json:"system_id"`
+	ComponentID byte `json:"component_id"`
+	Sequence    byte `json:"sequence"`
+}
+
+type streamEntry struct {
+	Header  header                 `json:"header"`
+	Message map[string]interface{} `json:"message"`
+}
+
+type postRequest struct {
+	Header  header          `json:"header"`
+	Message json.RawMessage `json:"message"`
+}
+
+// BridgeConf allows to configure a Bridge.
+type BridgeConf struct {
+	// the node.
+	Node *gomavlib.Node
+	// the dialect, used to decode messages sent through POST requests.
+	// It must be the same dialect used by the node.
+	Dialect *dialect.Dialect
+}
+
+// Bridge exposes a Node over HTTP.
+// It implements http.Handler.
+//
+// Received frames must be passed to the bridge with OnEventFrame().
+type Bridge struct {
+	conf    BridgeConf
+	decoder *messageDecoder
+
+	mutex    sync.Mutex
+	messages map[byte]map[byte]map[string]*messageEntry
+	streams  map[chan []byte]struct{}
+}
+
+// NewBridge allocates a Bridge. See BridgeConf for the options.
+func NewBridge(conf BridgeConf) (*Bridge, error) {
+	if conf.Node == nil {
+		return nil, fmt.Errorf("node not provided")
+	}
+
+	return &Bridge{
+		conf:     conf,
+		decoder:  newMessageDecoder(conf.Dialect),
+		messages: make(map[byte]map[byte]map[string]*messageEntry),
+		streams:  make(map[chan []byte]struct{}),
+	}, nil
+}
+
+// OnEventFrame stores a received frame and forwards it to connected streams.
+// It must be called for every *gomavlib.EventFrame received from the node.
+func (b *Bridge) OnEventFrame(evt *gomavlib.EventFrame) {
+	enc := messageEncode(evt.Message())
+	name := enc["type"].(string)
+	now := time.Now()
+
+	byts, err := json.Marshal(streamEntry{
+		Header: header{
+			SystemID:    evt.SystemID(),
+			ComponentID: evt.ComponentID(),
+			Sequence:    frameSequenceID(evt.Frame),
+		},
+		Message: enc,
+	})
+	if err != nil {
+		// the message can't be represented in JSON
+		return
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	components, ok := b.messages[evt.SystemID()]
+	if !ok {
+		components = make(map[byte]map[string]*messageEntry)
+		b.messages[evt.SystemID()] = components
+	}
+
+	messages, ok := components[evt.ComponentID()]
+	if !ok {
+		messages = make(map[string]*messageEntry)
+		components[evt.ComponentID()] = messages
+	}
+
+	entry, ok := messages[name]
+	if !ok {
+		entry = &messageEntry{}
+		entry.Status.Time.FirstUpdate = now
+		messages[name] = entry
+	}
+
+	entry.Message = enc
+	entry.Status.Time.LastUpdate = now
+	entry.Status.Time.Counter++
+	if elapsed := now.Sub(entry.Status.Time.FirstUpdate); elapsed > 0 {
+		entry.Status.Time.Frequency = float64(entry.Status.Time.Counter-1) / elapsed.Seconds()
+	}
+
+	for ch := range b.streams {
+		// drop the message if the client is too slow
+		select {
+		case ch <- byts:
+		default:
+		}
+	}
+}
+
+func frameSequenceID(fr frame.Frame) byte {
+	switch ff := fr.(type) {
+	case *frame.V1Frame:
+		return ff.SequenceID
+
+	case *frame.V2Frame:
+		return ff.SequenceID
+	}
+	return 0
+}
+
+// tree returns the stored messages in the form
+// {"vehicles": {"1": {"components": {"1": {"messages": {"NAME": ...}}}}}}.
+func (b *Bridge) tree() map[string]interface{} {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	vehicles := make(map[string]interface{})
+	for sysID, components := range b.messages {
+		comps := make(map[string]interface{})
+		for compID, messages := range components {
+			msgs := make(map[string]interface{})
+			for name, entry := range messages {
+				cpy := *entry
+				msgs[name] = cpy
+			}
+			comps[strconv.FormatUint(uint64(compID), 10)] = map[string]interface{}{
+				"messages": msgs,
+			}
+		}
+		vehicles[strconv.FormatUint(uint64(sysID), 10)] = map[string]interface{}{
+			"components": comps,
+		}
+	}
+
+	return map[string]interface{}{
+		"vehicles": vehicles,
+	}
+}
+
+// ServeHTTP implements http.Handler.
+func (b *Bridge) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.URL.Path == "/stream":
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		b.serveStream(w, r)
+
+	case r.URL.Path == "/mavlink" && r.Method == http.MethodPost:
+		b.servePost(w, r)
+
+	case r.URL.Path == "/mavlink" || strings.HasPrefix(r.URL.Path, "/mavlink/"):
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		b.serveGet(w, r)
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (b *Bridge) serveGet(w http.ResponseWriter, r *http.Request) {
+	var cur interface{} = b.tree()
+
+	for _, part := range strings.Split(strings.TrimPrefix(r.URL.Path, "/mavlink"), "/") {
+		if part == "" {
+			continue
+		}
+
+		m, ok := cur.(map[string]interface{})
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		cur, ok = m[part]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+	}
+
+	writeJSON(w, http.StatusOK, cur)
+}
+
+func (b *Bridge) servePost(w http.ResponseWriter, r *http.Request) {
+	var req postRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+
+	m, err := b.decoder.decode(req.Message)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+
+	// the header is ignored, since the node fills system id, component id
+	// and sequence id of outgoing frames.
+	b.conf.Node.WriteMessageAll(m)
+
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+func (b *Bridge) serveStream(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	ch := make(chan []byte, streamQueueSize)
+
+	b.mutex.Lock()
+	b.streams[ch] = struct{}{}
+	b.mutex.Unlock()
+
+	defer func() {
+		b.mutex.Lock()
+		delete(b.streams, ch)
+		b.mutex.Unlock()
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case byts := <-ch:
+			_, err := fmt.Fprintf(w, "data: %s\n\n", byts)
+			if err != nil {
+				return
+			}
+			flusher.Flush()
+
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	byts, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// errors of the client connection can't be reported to the client
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(append(byts, '\n')) //nolint:errcheck
+}
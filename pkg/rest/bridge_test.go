@@ -0,0 +1,241 @@
+package rest
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib"
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+	"github.com/aler9/gomavlib/pkg/frame"
+)
+
+type testRWC struct {
+	written chan []byte
+	done    chan struct{}
+}
+
+func (t *testRWC) Read(p []byte) (int, error) {
+	<-t.done
+	return 0, errTerminated
+}
+
+func (t *testRWC) Write(p []byte) (int, error) {
+	cpy := make([]byte, len(p))
+	copy(cpy, p)
+	select {
+	case t.written <- cpy:
+	case <-t.done:
+	}
+	return len(p), nil
+}
+
+func (t *testRWC) Close() error {
+	close(t.done)
+	return nil
+}
+
+type testError struct{}
+
+func (testError) Error() string { return "terminated" }
+
+var errTerminated = testError{}
+
+func newTestBridge(t *testing.T) (*gomavlib.Node, *testRWC, *Bridge) {
+	rwc := &testRWC{
+		written: make(chan []byte, 10),
+		done:    make(chan struct{}),
+	}
+
+	node, err := gomavlib.NewNode(gomavlib.NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  gomavlib.V2,
+		OutSystemID: 10,
+		Endpoints: []gomavlib.EndpointConf{
+			gomavlib.EndpointCustom{ReadWriteCloser: rwc},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+
+	go func() {
+		for range node.Events() {
+		}
+	}()
+
+	b, err := NewBridge(BridgeConf{
+		Node:    node,
+		Dialect: common.Dialect,
+	})
+	require.NoError(t, err)
+
+	return node, rwc, b
+}
+
+func testEventFrame() *gomavlib.EventFrame {
+	return &gomavlib.EventFrame{
+		Frame: &frame.V2Frame{
+			SequenceID:  3,
+			SystemID:    1,
+			ComponentID: 2,
+			Message: &common.MessageHeartbeat{
+				Type:      common.MAV_TYPE_QUADROTOR,
+				Autopilot: common.MAV_AUTOPILOT_PX4,
+			},
+		},
+	}
+}
+
+func TestBridgeGet(t *testing.T) {
+	node, _, b := newTestBridge(t)
+	defer node.Close()
+
+	b.OnEventFrame(testEventFrame())
+	b.OnEventFrame(testEventFrame())
+
+	srv := httptest.NewServer(b)
+	defer srv.Close()
+
+	res, err := http.Get(srv.URL + "/mavlink/vehicles/1/components/2/messages/HEARTBEAT")
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var entry struct {
+		Message map[string]interface{} `json:"message"`
+		Status  struct {
+			Time struct {
+				Counter int `json:"counter"`
+			} `json:"time"`
+		} `json:"status"`
+	}
+	err = json.NewDecoder(res.Body).Decode(&entry)
+	require.NoError(t, err)
+	require.Equal(t, "HEARTBEAT", entry.Message["type"])
+	require.Equal(t, "MAV_TYPE_QUADROTOR", entry.Message["mavtype"])
+	require.Equal(t, "MAV_AUTOPILOT_PX4", entry.Message["autopilot"])
+	require.Equal(t, 2, entry.Status.Time.Counter)
+
+	res2, err := http.Get(srv.URL + "/mavlink/vehicles/5")
+	require.NoError(t, err)
+	defer res2.Body.Close()
+	require.Equal(t, http.StatusNotFound, res2.StatusCode)
+}
+
+func TestBridgePost(t *testing.T) {
+	node, rwc, b := newTestBridge(t)
+	defer node.Close()
+
+	srv := httptest.NewServer(b)
+	defer srv.Close()
+
+	res, err := http.Post(srv.URL+"/mavlink", "application/json", bytes.NewReader([]byte(
+		`{"header": {"system_id": 255, "component_id": 0, "sequence": 0},`+
+			`"message": {"type": "HEARTBEAT", "mavtype": "MAV_TYPE_GCS", "custom_mode": 5}}`)))
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	select {
+	case byts := <-rwc.written:
+		require.Equal(t, byte(frame.V2MagicByte), byts[0])
+		require.Equal(t, byte(10), byts[5]) // system id
+		require.Equal(t, byte(0), byts[7])  // message id
+	case <-time.After(2 * time.Second):
+		t.Fatal("message not written")
+	}
+
+	res2, err := http.Post(srv.URL+"/mavlink", "application/json", bytes.NewReader([]byte(
+		`{"message": {"type": "HEARTBEAT", "wrong_field": 1}}`)))
+	require.NoError(t, err)
+	defer res2.Body.Close()
+	require.Equal(t, http.StatusBadRequest, res2.StatusCode)
+}
+
+func TestBridgeStream(t *testing.T) {
+	node, _, b := newTestBridge(t)
+	defer node.Close()
+
+	srv := httptest.NewServer(b)
+	defer srv.Close()
+
+	res, err := http.Get(srv.URL + "/stream")
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, "text/event-stream", res.Header.Get("Content-Type"))
+
+	// the stream is registered before the response headers are sent
+	b.OnEventFrame(testEventFrame())
+
+	line, err := bufio.NewReader(res.Body).ReadString('\n')
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(line, "data: "))
+
+	var entry streamEntry
+	err = json.Unmarshal([]byte(line[len("data: "):]), &entry)
+	require.NoError(t, err)
+	require.Equal(t, header{SystemID: 1, ComponentID: 2, Sequence: 3}, entry.Header)
+	require.Equal(t, "HEARTBEAT", entry.Message["type"])
+}
+
+func TestMessageEncodeNaN(t *testing.T) {
+	enc := messageEncode(&common.MessageAttitudeTarget{
+		BodyRollRate: float32(math.NaN()),
+		Thrust:       float32(math.Inf(1)),
+		Q:            [4]float32{1, float32(math.NaN()), 0, 0},
+	})
+
+	byts, err := json.Marshal(enc)
+	require.NoError(t, err)
+
+	var dec map[string]interface{}
+	err = json.Unmarshal(byts, &dec)
+	require.NoError(t, err)
+	require.Nil(t, dec["body_roll_rate"])
+	require.Nil(t, dec["thrust"])
+	require.Equal(t, []interface{}{float64(1), nil, float64(0), float64(0)}, dec["q"])
+}
+
+func TestMessageDecode(t *testing.T) {
+	md := newMessageDecoder(common.Dialect)
+
+	m, err := md.decode([]byte(`{"type": "COMMAND_LONG", "target_system": 1, "command": "MAV_CMD_COMPONENT_ARM_DISARM", "param1": 1}`))
+	require.NoError(t, err)
+	require.Equal(t, &common.MessageCommandLong{
+		TargetSystem: 1,
+		Command:      common.MAV_CMD_COMPONENT_ARM_DISARM,
+		Param1:       1,
+	}, m)
+
+	m, err = md.decode([]byte(`{"type": "HEARTBEAT", "mavtype": 2, "base_mode": 129}`))
+	require.NoError(t, err)
+	require.Equal(t, &common.MessageHeartbeat{
+		Type:     common.MAV_TYPE_QUADROTOR,
+		BaseMode: common.MAV_MODE_FLAG_SAFETY_ARMED | common.MAV_MODE_FLAG_CUSTOM_MODE_ENABLED,
+	}, m)
+
+	_, err = md.decode([]byte(`{"type": "NOT_EXISTING"}`))
+	require.Error(t, err)
+}
+
+func TestMarshalMessage(t *testing.T) {
+	m := &common.MessageHeartbeat{
+		Type:      common.MAV_TYPE_QUADROTOR,
+		Autopilot: common.MAV_AUTOPILOT_PX4,
+	}
+
+	byts, err := MarshalMessage(m)
+	require.NoError(t, err)
+
+	dec, err := UnmarshalMessage(common.Dialect, byts)
+	require.NoError(t, err)
+	require.Equal(t, m, dec)
+}
@@ -0,0 +1,211 @@
+package rest
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+// fieldName returns the Mavlink name of a field.
+// Like in mavlink2rest, the "type" field is renamed into "mavtype", since
+// "type" contains the message name.
+func fieldName(f reflect.StructField) string {
+	name := msg.FieldName(f)
+	if name == "type" {
+		return "mavtype"
+	}
+	return name
+}
+
+// messageEncode converts a message into a JSON-compatible map, in which the
+// message name is stored into the "type" key.
+func messageEncode(m msg.Message) map[string]interface{} {
+	if raw, ok := m.(*msg.MessageRaw); ok {
+		return map[string]interface{}{
+			"type":    "UNKNOWN",
+			"id":      raw.ID,
+			"payload": raw.Content,
+		}
+	}
+
+	rv := reflect.ValueOf(m).Elem()
+	rt := rv.Type()
+
+	ret := make(map[string]interface{}, rt.NumField()+1)
+	ret["type"] = msg.Name(m)
+	for i := 0; i < rt.NumField(); i++ {
+		ret[fieldName(rt.Field(i))] = fieldEncode(rv.Field(i))
+	}
+	return ret
+}
+
+var textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+func isFloat(t reflect.Type) bool {
+	return t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64
+}
+
+// fieldEncode converts enums into their name. Values that do not correspond
+// to any name, like bitmasks, are kept numeric. NaN and infinite values,
+// that are not supported by JSON, are converted into null.
+func fieldEncode(v reflect.Value) interface{} {
+	switch {
+	case isFloat(v.Type()):
+		f := v.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil
+		}
+
+	case v.Type().Implements(textMarshalerType):
+		if byts, err := v.Interface().(encoding.TextMarshaler).MarshalText(); err == nil {
+			return string(byts)
+		}
+
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return v.Int()
+		default:
+			return v.Uint()
+		}
+
+	case v.Kind() == reflect.Array && (v.Type().Elem().Implements(textMarshalerType) ||
+		isFloat(v.Type().Elem())):
+		ret := make([]interface{}, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			ret[i] = fieldEncode(v.Index(i))
+		}
+		return ret
+	}
+
+	return v.Interface()
+}
+
+// MarshalMessage encodes a message in JSON, in the format used by
+// mavlink2rest, in which the message name is stored into the "type" field.
+func MarshalMessage(m msg.Message) ([]byte, error) {
+	return json.Marshal(messageEncode(m))
+}
+
+// UnmarshalMessage decodes a message of a dialect from JSON, in the format
+// used by mavlink2rest.
+func UnmarshalMessage(d *dialect.Dialect, byts []byte) (msg.Message, error) {
+	return newMessageDecoder(d).decode(byts)
+}
+
+// messageDecoder decodes messages of a dialect from their JSON representation.
+type messageDecoder struct {
+	types map[string]reflect.Type
+}
+
+func newMessageDecoder(d *dialect.Dialect) *messageDecoder {
+	md := &messageDecoder{
+		types: make(map[string]reflect.Type),
+	}
+
+	if d != nil {
+		for _, m := range d.Messages {
+			md.types[msg.Name(m)] = reflect.TypeOf(m).Elem()
+		}
+	}
+
+	return md
+}
+
+func (md *messageDecoder) decode(byts []byte) (msg.Message, error) {
+	var fields map[string]json.RawMessage
+	err := json.Unmarshal(byts, &fields)
+	if err != nil {
+		return nil, err
+	}
+
+	var name string
+	if raw, ok := fields["type"]; ok {
+		err := json.Unmarshal(raw, &name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid type: %s", err)
+		}
+	}
+
+	typ, ok := md.types[name]
+	if !ok {
+		return nil, fmt.Errorf("message '%s' is not in the dialect", name)
+	}
+
+	rv := reflect.New(typ)
+	names := make(map[string]int, typ.NumField())
+	for i := 0; i < typ.NumField(); i++ {
+		names[fieldName(typ.Field(i))] = i
+	}
+
+	for key, raw := range fields {
+		if key == "type" {
+			continue
+		}
+
+		i, ok := names[key]
+		if !ok {
+			return nil, fmt.Errorf("message '%s' has no field '%s'", name, key)
+		}
+
+		err := fieldDecode(raw, rv.Elem().Field(i))
+		if err != nil {
+			return nil, fmt.Errorf("invalid field '%s': %s", key, err)
+		}
+	}
+
+	return rv.Interface().(msg.Message), nil
+}
+
+// fieldDecode decodes a field, accepting enums in both their name and
+// numeric form.
+func fieldDecode(raw json.RawMessage, v reflect.Value) error {
+	switch {
+	case v.Type().Implements(textMarshalerType):
+		var num json.Number
+		if json.Unmarshal(raw, &num) != nil {
+			return json.Unmarshal(raw, v.Addr().Interface())
+		}
+
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			i, err := num.Int64()
+			if err != nil {
+				return err
+			}
+			v.SetInt(i)
+
+		default:
+			u, err := strconv.ParseUint(num.String(), 10, 64)
+			if err != nil {
+				return err
+			}
+			v.SetUint(u)
+		}
+		return nil
+
+	case v.Kind() == reflect.Array && v.Type().Elem().Implements(textMarshalerType):
+		var elems []json.RawMessage
+		err := json.Unmarshal(raw, &elems)
+		if err != nil {
+			return err
+		}
+		if len(elems) > v.Len() {
+			return fmt.Errorf("too many elements")
+		}
+		for i, elem := range elems {
+			err := fieldDecode(elem, v.Index(i))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return json.Unmarshal(raw, v.Addr().Interface())
+}
@@ -0,0 +1,318 @@
+// Package sqlitelog implements a logger that stores decoded messages into a
+// SQLite database, that can be used as a lightweight blackbox.
+//
+// The package does not depend on any SQLite driver. The database must be
+// opened by the caller with a driver of choice, for instance:
+//
+//	import _ "github.com/mattn/go-sqlite3"
+//
+//	db, _ := sql.Open("sqlite3", "blackbox.db")
+//	logger, _ := sqlitelog.NewLogger(sqlitelog.LoggerConf{DB: db})
+package sqlitelog
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+	"time"
+
+	"github.com/aler9/gomavlib"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+type row struct {
+	m           msg.Message
+	systemID    byte
+	componentID byte
+	t           time.Time
+}
+
+// LoggerConf allows to configure a Logger.
+type LoggerConf struct {
+	// the database, opened with a SQLite driver.
+	DB *sql.DB
+
+	// (optional) the messages that are stored. If nil, all decoded
+	// messages are stored.
+	Messages []msg.Message
+
+	// (optional) the maximum number of rows that are inserted within a
+	// single transaction. It defaults to 500.
+	BatchSize int
+
+	// (optional) the maximum time between the reception of a message and its
+	// storage. It defaults to 1 second.
+	FlushPeriod time.Duration
+
+	// (optional) the maximum number of rows waiting to be stored.
+	// Rows received when the queue is full are dropped.
+	// It defaults to 10000.
+	QueueSize int
+
+	// (optional) rows older than MaxAge are deleted.
+	MaxAge time.Duration
+
+	// (optional) the maximum number of rows of each table. Oldest rows are
+	// deleted when the limit is exceeded.
+	MaxRows int
+
+	// (optional) a function that is called when a batch cannot be stored.
+	OnError func(error)
+}
+
+// Logger stores decoded messages into a SQLite database, in batches.
+// Each message type is stored into a table named after the message
+// (for instance ATTITUDE), that contains a timestamp column (nanoseconds since
+// the Unix epoch), the system id, the component id and a column for each
+// message field. Arrays are split into multiple columns, named name_0, name_1,
+// etc. Enums are stored in numeric form.
+// Tables are created when needed; existing tables are reused.
+type Logger struct {
+	conf     LoggerConf
+	messages map[reflect.Type]struct{}
+	tables   map[reflect.Type]*table
+	dropped  uint64
+
+	// in
+	queue     chan row
+	terminate chan struct{}
+
+	// out
+	done chan struct{}
+}
+
+// NewLogger allocates a Logger. See LoggerConf for the options.
+func NewLogger(conf LoggerConf) (*Logger, error) {
+	if conf.DB == nil {
+		return nil, fmt.Errorf("DB not provided")
+	}
+	if conf.MaxAge < 0 {
+		return nil, fmt.Errorf("MaxAge must be positive")
+	}
+	if conf.MaxRows < 0 {
+		return nil, fmt.Errorf("MaxRows must be positive")
+	}
+	if conf.BatchSize == 0 {
+		conf.BatchSize = 500
+	}
+	if conf.FlushPeriod == 0 {
+		conf.FlushPeriod = 1 * time.Second
+	}
+	if conf.QueueSize == 0 {
+		conf.QueueSize = 10000
+	}
+
+	l := &Logger{
+		conf:      conf,
+		tables:    make(map[reflect.Type]*table),
+		queue:     make(chan row, conf.QueueSize),
+		terminate: make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+
+	if conf.Messages != nil {
+		l.messages = make(map[reflect.Type]struct{})
+		for _, m := range conf.Messages {
+			l.messages[reflect.TypeOf(m)] = struct{}{}
+		}
+	}
+
+	go l.run()
+
+	return l, nil
+}
+
+// Close stores pending rows and closes the Logger.
+// The database is not closed.
+func (l *Logger) Close() {
+	close(l.terminate)
+	<-l.done
+}
+
+// Dropped returns the number of rows that have been dropped since the
+// queue was full.
+func (l *Logger) Dropped() uint64 {
+	return atomic.LoadUint64(&l.dropped)
+}
+
+// OnEventFrame queues a received frame for storage.
+// It must be called for every *gomavlib.EventFrame received from the node.
+// It never blocks.
+func (l *Logger) OnEventFrame(evt *gomavlib.EventFrame) {
+	l.Write(time.Now(), evt.SystemID(), evt.ComponentID(), evt.Message())
+}
+
+// Write queues a message for storage.
+// Messages that have not been decoded are skipped. It never blocks.
+func (l *Logger) Write(t time.Time, systemID byte, componentID byte, m msg.Message) {
+	if _, ok := m.(*msg.MessageRaw); ok {
+		return
+	}
+
+	if l.messages != nil {
+		if _, ok := l.messages[reflect.TypeOf(m)]; !ok {
+			return
+		}
+	}
+
+	select {
+	case l.queue <- row{m, systemID, componentID, t}:
+	default:
+		atomic.AddUint64(&l.dropped, 1)
+	}
+}
+
+func (l *Logger) run() {
+	defer close(l.done)
+
+	var batch []row
+
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+
+		err := l.store(batch)
+		if err != nil && l.conf.OnError != nil {
+			l.conf.OnError(err)
+		}
+
+		batch = batch[:0]
+	}
+
+	add := func(r row) {
+		batch = append(batch, r)
+		if len(batch) >= l.conf.BatchSize {
+			flush()
+		}
+	}
+
+	ticker := time.NewTicker(l.conf.FlushPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case r := <-l.queue:
+			add(r)
+
+		case <-ticker.C:
+			flush()
+
+		case <-l.terminate:
+			for len(l.queue) > 0 {
+				add(<-l.queue)
+			}
+			flush()
+			return
+		}
+	}
+}
+
+// store inserts a batch of rows within a transaction, then applies the
+// retention limits to the tables that have been written.
+func (l *Logger) store(batch []row) error {
+	tx, err := l.conf.DB.Begin()
+	if err != nil {
+		return err
+	}
+
+	stmts := make(map[*table]*sql.Stmt)
+
+	err = func() error {
+		defer func() {
+			for _, stmt := range stmts {
+				stmt.Close()
+			}
+		}()
+
+		for _, r := range batch {
+			t, err := l.table(tx, r.m)
+			if err != nil {
+				return err
+			}
+
+			stmt, ok := stmts[t]
+			if !ok {
+				stmt, err = tx.Prepare(t.insert)
+				if err != nil {
+					return err
+				}
+				stmts[t] = stmt
+			}
+
+			_, err = stmt.Exec(t.values(r)...)
+			if err != nil {
+				return err
+			}
+		}
+
+		for t := range stmts {
+			err := l.applyRetention(tx, t)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}()
+	if err == nil {
+		err = tx.Commit()
+	} else {
+		tx.Rollback()
+	}
+
+	if err != nil {
+		// tables created within the transaction do not exist anymore
+		l.tables = make(map[reflect.Type]*table)
+		return err
+	}
+
+	return nil
+}
+
+// table returns the table of a message, and creates it if needed.
+func (l *Logger) table(tx *sql.Tx, m msg.Message) (*table, error) {
+	rt := reflect.TypeOf(m)
+
+	if t, ok := l.tables[rt]; ok {
+		return t, nil
+	}
+
+	t := newTable(m)
+
+	for _, query := range t.create {
+		_, err := tx.Exec(query)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	l.tables[rt] = t
+	return t, nil
+}
+
+// applyRetention deletes the rows that exceed the retention limits.
+func (l *Logger) applyRetention(tx *sql.Tx, t *table) error {
+	if l.conf.MaxAge > 0 {
+		_, err := tx.Exec("DELETE FROM "+quoteIdentifier(t.name)+" WHERE "+
+			quoteIdentifier("timestamp")+" < ?",
+			time.Now().Add(-l.conf.MaxAge).UnixNano())
+		if err != nil {
+			return err
+		}
+	}
+
+	if l.conf.MaxRows > 0 {
+		// rows are inserted in chronological order, therefore the oldest rows
+		// are the ones with the lowest rowid.
+		_, err := tx.Exec("DELETE FROM "+quoteIdentifier(t.name)+
+			" WHERE rowid <= (SELECT MAX(rowid) FROM "+quoteIdentifier(t.name)+") - ?",
+			int64(l.conf.MaxRows))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,223 @@
+package sqlitelog
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib"
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+	"github.com/aler9/gomavlib/pkg/frame"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+// testDriver is a database/sql driver that records executed queries.
+type testDriver struct {
+	mutex   sync.Mutex
+	queries []string
+	args    [][]driver.Value
+	fail    bool
+}
+
+func (d *testDriver) Open(name string) (driver.Conn, error) {
+	return &testConn{d}, nil
+}
+
+func (d *testDriver) record(query string, args []driver.Value) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.fail && strings.HasPrefix(query, "INSERT") {
+		return fmt.Errorf("disk full")
+	}
+
+	d.queries = append(d.queries, query)
+	d.args = append(d.args, args)
+	return nil
+}
+
+type testConn struct {
+	d *testDriver
+}
+
+func (c *testConn) Prepare(query string) (driver.Stmt, error) {
+	return &testStmt{c.d, query}, nil
+}
+
+func (c *testConn) Close() error {
+	return nil
+}
+
+func (c *testConn) Begin() (driver.Tx, error) {
+	return c, c.d.record("BEGIN", nil)
+}
+
+func (c *testConn) Commit() error {
+	return c.d.record("COMMIT", nil)
+}
+
+func (c *testConn) Rollback() error {
+	return c.d.record("ROLLBACK", nil)
+}
+
+type testStmt struct {
+	d     *testDriver
+	query string
+}
+
+func (s *testStmt) Close() error {
+	return nil
+}
+
+func (s *testStmt) NumInput() int {
+	return -1
+}
+
+func (s *testStmt) Exec(args []driver.Value) (driver.Result, error) {
+	err := s.d.record(s.query, args)
+	if err != nil {
+		return nil, err
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (s *testStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return nil, fmt.Errorf("not supported")
+}
+
+var testDriverCount = 0
+
+func openTestDB(t *testing.T, d *testDriver) *sql.DB {
+	// drivers cannot be unregistered, therefore each one has a unique name
+	name := fmt.Sprintf("sqlitelogtest%d", testDriverCount)
+	testDriverCount++
+	sql.Register(name, d)
+
+	db, err := sql.Open(name, "")
+	require.NoError(t, err)
+	return db
+}
+
+func TestTable(t *testing.T) {
+	tab := newTable(&common.MessageAttitudeQuaternion{})
+	require.Equal(t, "ATTITUDE_QUATERNION", tab.name)
+	require.Equal(t, []string{
+		`CREATE TABLE IF NOT EXISTS "ATTITUDE_QUATERNION" ("timestamp" INTEGER NOT NULL, ` +
+			`"system_id" INTEGER NOT NULL, "component_id" INTEGER NOT NULL, "time_boot_ms" INTEGER, ` +
+			`"q1" REAL, "q2" REAL, "q3" REAL, "q4" REAL, "rollspeed" REAL, "pitchspeed" REAL, ` +
+			`"yawspeed" REAL, "repr_offset_q_0" REAL, "repr_offset_q_1" REAL, ` +
+			`"repr_offset_q_2" REAL, "repr_offset_q_3" REAL)`,
+		`CREATE INDEX IF NOT EXISTS "ATTITUDE_QUATERNION_timestamp" ON "ATTITUDE_QUATERNION" ("timestamp")`,
+	}, tab.create)
+
+	tab = newTable(&common.MessageParamValue{})
+	require.Equal(t, `INSERT INTO "PARAM_VALUE" ("timestamp", "system_id", "component_id", `+
+		`"param_id", "param_value", "param_type", "param_count", "param_index") `+
+		`VALUES (?, ?, ?, ?, ?, ?, ?, ?)`, tab.insert)
+
+	require.Equal(t, []interface{}{
+		int64(1000000005), int64(1), int64(2), "PARAM 1", float64(1.5), int64(9), int64(10), int64(2),
+	}, tab.values(row{
+		m: &common.MessageParamValue{
+			ParamId:    "PARAM 1",
+			ParamValue: 1.5,
+			ParamType:  common.MAV_PARAM_TYPE_REAL32,
+			ParamCount: 10,
+			ParamIndex: 2,
+		},
+		systemID:    1,
+		componentID: 2,
+		t:           time.Unix(1, 5),
+	}))
+}
+
+func TestLogger(t *testing.T) {
+	d := &testDriver{}
+	db := openTestDB(t, d)
+	defer db.Close()
+
+	l, err := NewLogger(LoggerConf{
+		DB:        db,
+		Messages:  []msg.Message{&common.MessageHeartbeat{}},
+		BatchSize: 2,
+		MaxAge:    1 * time.Hour,
+		MaxRows:   100,
+	})
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		l.OnEventFrame(&gomavlib.EventFrame{
+			Frame: &frame.V2Frame{
+				SystemID:    1,
+				ComponentID: 1,
+				Message:     &common.MessageHeartbeat{CustomMode: uint32(i)},
+			},
+		})
+		l.OnEventFrame(&gomavlib.EventFrame{
+			Frame: &frame.V2Frame{
+				SystemID:    1,
+				ComponentID: 1,
+				Message:     &common.MessageSystemTime{},
+			},
+		})
+	}
+
+	l.Close()
+
+	var kinds []string
+	for _, q := range d.queries {
+		kinds = append(kinds, strings.SplitN(q, " ", 2)[0])
+	}
+	require.Equal(t, []string{
+		"BEGIN", "CREATE", "CREATE", "INSERT", "INSERT", "DELETE", "DELETE", "COMMIT",
+		"BEGIN", "INSERT", "DELETE", "DELETE", "COMMIT",
+	}, kinds)
+
+	require.Equal(t, int64(2), d.args[9][6])
+	require.Equal(t, []driver.Value{int64(100)}, d.args[11])
+	require.Equal(t, uint64(0), l.Dropped())
+}
+
+func TestLoggerError(t *testing.T) {
+	d := &testDriver{fail: true}
+	db := openTestDB(t, d)
+	defer db.Close()
+
+	errs := make(chan error, 2)
+
+	l, err := NewLogger(LoggerConf{
+		DB:        db,
+		BatchSize: 1,
+		OnError: func(err error) {
+			errs <- err
+		},
+	})
+	require.NoError(t, err)
+
+	l.Write(time.Now(), 1, 1, &common.MessageHeartbeat{})
+	l.Write(time.Now(), 1, 1, &common.MessageHeartbeat{})
+	l.Close()
+
+	require.EqualError(t, <-errs, "disk full")
+	require.EqualError(t, <-errs, "disk full")
+
+	// the table is created again after a rollback
+	var creates int
+	for _, q := range d.queries {
+		if strings.HasPrefix(q, "CREATE TABLE") {
+			creates++
+		}
+	}
+	require.Equal(t, 2, creates)
+}
+
+func TestLoggerErrors(t *testing.T) {
+	_, err := NewLogger(LoggerConf{})
+	require.EqualError(t, err, "DB not provided")
+}
@@ -0,0 +1,121 @@
+package sqlitelog
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+// table is the table of a message type.
+type table struct {
+	name    string
+	columns []string
+	create  []string
+	insert  string
+}
+
+// quoteIdentifier quotes a table or column name.
+func quoteIdentifier(name string) string {
+	return `"` + strings.Replace(name, `"`, `""`, -1) + `"`
+}
+
+// columnType returns the SQLite type of a field.
+func columnType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return "REAL"
+
+	case reflect.String:
+		return "TEXT"
+	}
+
+	return "INTEGER"
+}
+
+// newTable returns the table of a message. The table is named after the
+// message and contains a timestamp column (nanoseconds since the Unix epoch),
+// the system id, the component id and a column for each message field.
+// Arrays are split into multiple columns, named name_0, name_1, etc.
+func newTable(m msg.Message) *table {
+	t := &table{
+		name:    msg.Name(m),
+		columns: []string{"timestamp", "system_id", "component_id"},
+	}
+
+	types := []string{"INTEGER NOT NULL", "INTEGER NOT NULL", "INTEGER NOT NULL"}
+
+	rt := reflect.TypeOf(m).Elem()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		name := msg.FieldName(field)
+
+		if field.Type.Kind() == reflect.Array {
+			for j := 0; j < field.Type.Len(); j++ {
+				t.columns = append(t.columns, name+"_"+strconv.FormatInt(int64(j), 10))
+				types = append(types, columnType(field.Type.Elem()))
+			}
+		} else {
+			t.columns = append(t.columns, name)
+			types = append(types, columnType(field.Type))
+		}
+	}
+
+	defs := make([]string, len(t.columns))
+	quoted := make([]string, len(t.columns))
+	for i, c := range t.columns {
+		quoted[i] = quoteIdentifier(c)
+		defs[i] = quoted[i] + " " + types[i]
+	}
+
+	t.create = []string{
+		"CREATE TABLE IF NOT EXISTS " + quoteIdentifier(t.name) + " (" + strings.Join(defs, ", ") + ")",
+		"CREATE INDEX IF NOT EXISTS " + quoteIdentifier(t.name+"_timestamp") +
+			" ON " + quoteIdentifier(t.name) + " (" + quoteIdentifier("timestamp") + ")",
+	}
+
+	t.insert = "INSERT INTO " + quoteIdentifier(t.name) + " (" + strings.Join(quoted, ", ") +
+		") VALUES (?" + strings.Repeat(", ?", len(t.columns)-1) + ")"
+
+	return t
+}
+
+// values returns the values of a row. Enums are stored in numeric form.
+func (t *table) values(r row) []interface{} {
+	ret := make([]interface{}, 0, len(t.columns))
+	ret = append(ret, r.t.UnixNano(), int64(r.systemID), int64(r.componentID))
+
+	rv := reflect.ValueOf(r.m).Elem()
+	for i := 0; i < rv.NumField(); i++ {
+		v := rv.Field(i)
+		if v.Kind() == reflect.Array {
+			for j := 0; j < v.Len(); j++ {
+				ret = append(ret, columnValue(v.Index(j)))
+			}
+		} else {
+			ret = append(ret, columnValue(v))
+		}
+	}
+
+	return ret
+}
+
+// columnValue converts a field into a value supported by database/sql.
+func columnValue(v reflect.Value) interface{} {
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(v.Uint())
+
+	case reflect.String:
+		return v.String()
+	}
+
+	return v.Interface()
+}
@@ -0,0 +1,325 @@
+package tlog
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// playerClock is the time source of a Player.
+type playerClock interface {
+	Now() time.Time
+	NewTimer(d time.Duration) (<-chan time.Time, func())
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) NewTimer(d time.Duration) (<-chan time.Time, func()) {
+	t := time.NewTimer(d)
+	return t.C, func() { t.Stop() }
+}
+
+type playerEntry struct {
+	time   time.Time
+	offset int64
+	size   int
+}
+
+// Player plays back a tlog, by emitting its frames with their original
+// timing. It implements io.ReadWriteCloser and can be used as a node endpoint:
+//
+//	player, _ := tlog.NewPlayer(f)
+//	node, _ := gomavlib.NewNode(gomavlib.NodeConf{
+//		Endpoints: []gomavlib.EndpointConf{
+//			gomavlib.EndpointCustom{ReadWriteCloser: player},
+//		},
+//		...
+//	})
+//
+// Playback can be controlled at runtime with SetSpeed(), Pause(), Resume()
+// and Seek(). Frames written to the player are discarded.
+type Player struct {
+	rs      io.ReadSeeker
+	entries []playerEntry
+	start   time.Time
+	end     time.Time
+	clock   playerClock
+
+	mutex     sync.Mutex
+	speed     float64
+	paused    bool
+	pos       int
+	refWall   time.Time
+	refLog    time.Time
+	pending   []byte
+	changed   chan struct{}
+	closeOnce sync.Once
+	terminate chan struct{}
+}
+
+// NewPlayer allocates a Player. The tlog is indexed in advance, in order to
+// allow seeking.
+func NewPlayer(rs io.ReadSeeker) (*Player, error) {
+	return newPlayer(rs, realClock{})
+}
+
+func newPlayer(rs io.ReadSeeker, clock playerClock) (*Player, error) {
+	r, err := NewReader(rs, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []playerEntry
+	for {
+		offset := r.offset()
+
+		e, err := r.Read()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+
+		entries = append(entries, playerEntry{
+			time:   e.Time,
+			offset: offset + 8,
+			size:   int(r.offset() - offset - 8),
+		})
+	}
+
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("tlog is empty")
+	}
+
+	start, end := entries[0].time, entries[0].time
+	for _, e := range entries[1:] {
+		if e.time.Before(start) {
+			start = e.time
+		}
+		if e.time.After(end) {
+			end = e.time
+		}
+	}
+
+	p := &Player{
+		rs:        rs,
+		entries:   entries,
+		start:     start,
+		end:       end,
+		clock:     clock,
+		speed:     1,
+		refWall:   clock.Now(),
+		refLog:    entries[0].time,
+		changed:   make(chan struct{}),
+		terminate: make(chan struct{}),
+	}
+
+	return p, nil
+}
+
+// Close implements io.Closer.
+func (p *Player) Close() error {
+	p.closeOnce.Do(func() {
+		close(p.terminate)
+	})
+	return nil
+}
+
+// Write implements io.Writer. Written data is discarded.
+func (p *Player) Write(buf []byte) (int, error) {
+	return len(buf), nil
+}
+
+// Read implements io.Reader.
+func (p *Player) Read(buf []byte) (int, error) {
+	for {
+		p.mutex.Lock()
+
+		if len(p.pending) > 0 {
+			n := copy(buf, p.pending)
+			p.pending = p.pending[n:]
+			p.mutex.Unlock()
+			return n, nil
+		}
+
+		changed := p.changed
+		var timerC <-chan time.Time
+		stopTimer := func() {}
+
+		if !p.paused && p.pos < len(p.entries) {
+			e := p.entries[p.pos]
+			wait := time.Duration(float64(e.time.Sub(p.refLog))/p.speed) - p.clock.Now().Sub(p.refWall)
+
+			if wait <= 0 {
+				frame := make([]byte, e.size)
+				_, err := p.rs.Seek(e.offset, io.SeekStart)
+				if err == nil {
+					_, err = io.ReadFull(p.rs, frame)
+				}
+				if err != nil {
+					p.mutex.Unlock()
+					return 0, err
+				}
+
+				p.pos++
+				n := copy(buf, frame)
+				p.pending = frame[n:]
+				p.mutex.Unlock()
+				return n, nil
+			}
+
+			timerC, stopTimer = p.clock.NewTimer(wait)
+		}
+
+		p.mutex.Unlock()
+
+		// wait for the next frame, for a change of the playback state
+		// (pause, resume, seek, speed) or for termination.
+		select {
+		case <-timerC:
+		case <-changed:
+		case <-p.terminate:
+			stopTimer()
+			return 0, io.EOF
+		}
+
+		stopTimer()
+	}
+}
+
+// position returns the current position in the log.
+// It must be called with the mutex locked.
+func (p *Player) position() time.Time {
+	if p.paused {
+		return p.refLog
+	}
+	return p.refLog.Add(time.Duration(float64(p.clock.Now().Sub(p.refWall)) * p.speed))
+}
+
+// setPosition moves the position of the playback.
+// It must be called with the mutex locked.
+func (p *Player) setPosition(t time.Time) {
+	p.refWall = p.clock.Now()
+	p.refLog = t
+	close(p.changed)
+	p.changed = make(chan struct{})
+}
+
+// Start returns the time of the oldest entry.
+func (p *Player) Start() time.Time {
+	return p.start
+}
+
+// Duration returns the duration of the log.
+func (p *Player) Duration() time.Duration {
+	return p.end.Sub(p.start)
+}
+
+// Position returns the current position of the playback.
+func (p *Player) Position() time.Time {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	pos := p.position()
+	if pos.After(p.end) {
+		return p.end
+	}
+	return pos
+}
+
+// Speed returns the playback speed.
+func (p *Player) Speed() float64 {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.speed
+}
+
+// SetSpeed sets the playback speed, as a multiplier of the original
+// speed (for instance 0.5 or 100). It defaults to 1.
+func (p *Player) SetSpeed(speed float64) error {
+	if speed <= 0 {
+		return fmt.Errorf("speed must be greater than zero")
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.setPosition(p.position())
+	p.speed = speed
+	return nil
+}
+
+// Paused returns whether the playback is paused.
+func (p *Player) Paused() bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.paused
+}
+
+// Pause pauses the playback.
+func (p *Player) Pause() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.paused {
+		return
+	}
+
+	p.setPosition(p.position())
+	p.paused = true
+}
+
+// Resume resumes the playback.
+func (p *Player) Resume() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if !p.paused {
+		return
+	}
+
+	p.paused = false
+	p.setPosition(p.refLog)
+}
+
+// Seek moves the playback to the first entry whose time is equal or
+// greater than t.
+func (p *Player) Seek(t time.Time) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if t.Before(p.start) {
+		t = p.start
+	}
+
+	// entries are scanned linearly, since logs that merge multiple sources
+	// are not always ordered by time.
+	pos := len(p.entries)
+	for i, e := range p.entries {
+		if !e.time.Before(t) {
+			pos = i
+			break
+		}
+	}
+
+	// a frame that is partially read is completed, in order not to break
+	// the stream.
+	p.pos = pos
+	p.setPosition(t)
+}
+
+// SeekPercentage moves the playback to a percentage (0-100) of the log duration.
+func (p *Player) SeekPercentage(percentage float64) error {
+	if percentage < 0 || percentage > 100 {
+		return fmt.Errorf("percentage must be between 0 and 100")
+	}
+
+	p.Seek(p.Start().Add(time.Duration(float64(p.Duration()) * percentage / 100)))
+	return nil
+}
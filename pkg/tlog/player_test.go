@@ -0,0 +1,216 @@
+package tlog
+
+import (
+	"bytes"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/frame"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+// testClock is a playerClock whose time is moved forward manually.
+type testClock struct {
+	mutex   sync.Mutex
+	now     time.Time
+	timers  map[*testTimer]struct{}
+	created chan time.Duration
+}
+
+type testTimer struct {
+	deadline time.Time
+	c        chan time.Time
+}
+
+func newTestClock() *testClock {
+	return &testClock{
+		now:     time.Unix(1000, 0),
+		timers:  make(map[*testTimer]struct{}),
+		created: make(chan time.Duration, 16),
+	}
+}
+
+func (c *testClock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.now
+}
+
+func (c *testClock) NewTimer(d time.Duration) (<-chan time.Time, func()) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	t := &testTimer{
+		deadline: c.now.Add(d),
+		c:        make(chan time.Time, 1),
+	}
+	c.timers[t] = struct{}{}
+	c.created <- d
+
+	return t.c, func() {
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+		delete(c.timers, t)
+	}
+}
+
+// Advance moves the time forward and fires expired timers.
+func (c *testClock) Advance(d time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.now = c.now.Add(d)
+	for t := range c.timers {
+		if !t.deadline.After(c.now) {
+			t.c <- c.now
+			delete(c.timers, t)
+		}
+	}
+}
+
+func newTestPlayer(t *testing.T) (*Player, *testClock) {
+	var buf bytes.Buffer
+	start := time.Unix(1600000000, 0)
+	for i := 0; i < 5; i++ {
+		writeTestEntry(t, &buf, start.Add(time.Duration(i)*time.Second), &frame.V2Frame{
+			SequenceID:  byte(i),
+			SystemID:    1,
+			ComponentID: 1,
+			Message:     &msg.MessageRaw{ID: 1, Content: []byte{1, 2, 3}},
+		})
+	}
+
+	clock := newTestClock()
+	p, err := newPlayer(bytes.NewReader(buf.Bytes()), clock)
+	require.NoError(t, err)
+	return p, clock
+}
+
+// readSequenceID reads a frame and returns its sequence id.
+func readSequenceID(t *testing.T, p *Player) byte {
+	buf := make([]byte, 512)
+	n, err := p.Read(buf)
+	require.NoError(t, err)
+	require.Equal(t, 15, n)
+	return buf[4]
+}
+
+// readSequenceIDAsync reads a frame in a separate routine.
+func readSequenceIDAsync(t *testing.T, p *Player) chan byte {
+	done := make(chan byte, 1)
+	go func() {
+		done <- readSequenceID(t, p)
+	}()
+	return done
+}
+
+func TestPlayerSpeed(t *testing.T) {
+	p, clock := newTestPlayer(t)
+	defer p.Close()
+
+	require.Equal(t, 4*time.Second, p.Duration())
+	require.Error(t, p.SetSpeed(0))
+	require.NoError(t, p.SetSpeed(100))
+	require.Equal(t, float64(100), p.Speed())
+
+	require.Equal(t, byte(0), readSequenceID(t, p))
+
+	for i := 1; i < 5; i++ {
+		done := readSequenceIDAsync(t, p)
+		require.Equal(t, 10*time.Millisecond, <-clock.created)
+		clock.Advance(10 * time.Millisecond)
+		require.Equal(t, byte(i), <-done)
+	}
+}
+
+func TestPlayerSeek(t *testing.T) {
+	p, clock := newTestPlayer(t)
+	defer p.Close()
+
+	require.Equal(t, byte(0), readSequenceID(t, p))
+
+	require.Error(t, p.SeekPercentage(101))
+	require.NoError(t, p.SeekPercentage(75))
+	require.Equal(t, 3*time.Second, p.Position().Sub(p.Start()))
+	require.Equal(t, byte(3), readSequenceID(t, p))
+
+	p.Seek(p.Start().Add(1500 * time.Millisecond))
+	require.NoError(t, p.SetSpeed(10))
+
+	done := readSequenceIDAsync(t, p)
+	require.Equal(t, 50*time.Millisecond, <-clock.created)
+	clock.Advance(50 * time.Millisecond)
+	require.Equal(t, byte(2), <-done)
+}
+
+func TestPlayerSeekUnordered(t *testing.T) {
+	var buf bytes.Buffer
+	start := time.Unix(1600000000, 0)
+	for i, sec := range []int{0, 2, 1, 3} {
+		writeTestEntry(t, &buf, start.Add(time.Duration(sec)*time.Second), &frame.V2Frame{
+			SequenceID:  byte(i),
+			SystemID:    1,
+			ComponentID: 1,
+			Message:     &msg.MessageRaw{ID: 1, Content: []byte{1, 2, 3}},
+		})
+	}
+
+	clock := newTestClock()
+	p, err := newPlayer(bytes.NewReader(buf.Bytes()), clock)
+	require.NoError(t, err)
+	defer p.Close()
+
+	require.Equal(t, 3*time.Second, p.Duration())
+
+	p.Seek(start.Add(1500 * time.Millisecond))
+
+	done := readSequenceIDAsync(t, p)
+	require.Equal(t, 500*time.Millisecond, <-clock.created)
+	clock.Advance(500 * time.Millisecond)
+	require.Equal(t, byte(1), <-done)
+}
+
+func TestPlayerPause(t *testing.T) {
+	p, clock := newTestPlayer(t)
+	defer p.Close()
+
+	require.NoError(t, p.SetSpeed(10))
+	require.Equal(t, byte(0), readSequenceID(t, p))
+
+	p.Pause()
+	require.True(t, p.Paused())
+
+	done := readSequenceIDAsync(t, p)
+
+	// time does not flow while paused
+	clock.Advance(10 * time.Second)
+	require.Equal(t, p.Start(), p.Position())
+
+	p.Resume()
+	require.False(t, p.Paused())
+
+	require.Equal(t, 100*time.Millisecond, <-clock.created)
+	clock.Advance(100 * time.Millisecond)
+	require.Equal(t, byte(1), <-done)
+}
+
+func TestPlayerClose(t *testing.T) {
+	p, clock := newTestPlayer(t)
+
+	require.Equal(t, byte(0), readSequenceID(t, p))
+
+	done := make(chan error)
+	go func() {
+		buf := make([]byte, 512)
+		_, err := p.Read(buf)
+		done <- err
+	}()
+
+	<-clock.created
+	p.Close()
+	require.Equal(t, io.EOF, <-done)
+}
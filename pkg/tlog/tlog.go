@@ -0,0 +1,186 @@
+// Package tlog implements a reader and a player of telemetry logs (tlog),
+// the format used by ground stations to record Mavlink traffic.
+//
+// A tlog is a sequence of entries, each made of a timestamp, expressed in
+// microseconds since the Unix epoch and encoded as a 64-bit big-endian
+// integer, followed by a Mavlink frame.
+package tlog
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/frame"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+const (
+	bufferSize = 512
+
+	// timestamp and signed V2 frame with the maximum payload
+	maxEntrySize = 8 + 10 + 255 + 2 + 13
+)
+
+// Entry is an entry of a tlog.
+type Entry struct {
+	// the time at which the frame was recorded
+	Time time.Time
+	// the frame
+	Frame frame.Frame
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// Reader reads entries from a tlog.
+type Reader struct {
+	cr        *countingReader
+	br        *bufio.Reader
+	dialectDE *dialect.DecEncoder
+
+	// entries are decoded from a peeked buffer, in order to be able to
+	// skip forward in case of corruption.
+	entryR  *bytes.Reader
+	entryBR *bufio.Reader
+	resync  bool
+}
+
+// NewReader allocates a Reader.
+// If the dialect is not nil, messages are decoded and checksums are validated.
+func NewReader(r io.Reader, d *dialect.Dialect) (*Reader, error) {
+	var dialectDE *dialect.DecEncoder
+	if d != nil {
+		var err error
+		dialectDE, err = dialect.NewDecEncoder(d)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	cr := &countingReader{r: r}
+	entryR := bytes.NewReader(nil)
+
+	return &Reader{
+		cr:        cr,
+		br:        bufio.NewReaderSize(cr, bufferSize),
+		dialectDE: dialectDE,
+		entryR:    entryR,
+		entryBR:   bufio.NewReaderSize(entryR, bufferSize),
+	}, nil
+}
+
+// offset returns the position of the next entry.
+func (r *Reader) offset() int64 {
+	return r.cr.n - int64(r.br.Buffered())
+}
+
+// Read reads an entry. It returns io.EOF at the end of the log.
+// In case an entry is corrupted, an error is returned and the next call
+// skips data until a valid entry is found.
+func (r *Reader) Read() (*Entry, error) {
+	for {
+		buf, peekErr := r.br.Peek(maxEntrySize)
+		if len(buf) == 0 {
+			return nil, peekErr
+		}
+
+		e, n, err := r.decodeEntry(buf)
+		if err == nil {
+			r.br.Discard(n)
+			r.resync = false
+			return e, nil
+		}
+
+		// the error has already been reported: skip a byte and try again
+		if r.resync {
+			r.br.Discard(1)
+			continue
+		}
+
+		// the log ends with an incomplete entry
+		if err == io.ErrUnexpectedEOF && peekErr != nil {
+			r.br.Discard(len(buf))
+			if peekErr != io.EOF {
+				return nil, peekErr
+			}
+			return nil, io.ErrUnexpectedEOF
+		}
+
+		r.br.Discard(1)
+		r.resync = true
+		return nil, err
+	}
+}
+
+// decodeEntry decodes an entry from buf and returns its size.
+func (r *Reader) decodeEntry(buf []byte) (*Entry, int, error) {
+	if len(buf) < 9 {
+		return nil, 0, io.ErrUnexpectedEOF
+	}
+	usec := binary.BigEndian.Uint64(buf)
+
+	var f frame.Frame
+	switch buf[8] {
+	case frame.V1MagicByte:
+		f = &frame.V1Frame{}
+
+	case frame.V2MagicByte:
+		f = &frame.V2Frame{}
+
+	default:
+		return nil, 0, fmt.Errorf("invalid magic byte: %x", buf[8])
+	}
+
+	r.entryR.Reset(buf[9:])
+	r.entryBR.Reset(r.entryR)
+
+	err := f.Decode(r.entryBR)
+	if err != nil {
+		if err == io.EOF {
+			return nil, 0, io.ErrUnexpectedEOF
+		}
+		return nil, 0, err
+	}
+
+	n := len(buf) - r.entryR.Len() - r.entryBR.Buffered()
+
+	if r.dialectDE != nil {
+		if mde, ok := r.dialectDE.MessageDEs[f.GetMessage().GetID()]; ok {
+			if sum := f.GenChecksum(mde.CRCExtra()); sum != f.GetChecksum() {
+				return nil, 0, fmt.Errorf("wrong checksum (expected %.4x, got %.4x, id=%d)",
+					sum, f.GetChecksum(), f.GetMessage().GetID())
+			}
+
+			_, isV2 := f.(*frame.V2Frame)
+			m, err := mde.Decode(f.GetMessage().(*msg.MessageRaw).Content, isV2)
+			if err != nil {
+				return nil, 0, err
+			}
+
+			switch ff := f.(type) {
+			case *frame.V1Frame:
+				ff.Message = m
+			case *frame.V2Frame:
+				ff.Message = m
+			}
+		}
+	}
+
+	return &Entry{
+		Time:  time.Unix(0, int64(usec)*int64(time.Microsecond)),
+		Frame: f,
+	}, n, nil
+}
@@ -0,0 +1,132 @@
+package tlog
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+	"github.com/aler9/gomavlib/pkg/frame"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+// writeTestEntry appends an entry to a tlog. The frame must contain a raw
+// message.
+func writeTestEntry(t *testing.T, buf *bytes.Buffer, tim time.Time, f frame.Frame) {
+	var ts [8]byte
+	binary.BigEndian.PutUint64(ts[:], uint64(tim.UnixNano()/int64(time.Microsecond)))
+	buf.Write(ts[:])
+
+	byts, err := f.Encode(make([]byte, 512), f.GetMessage().(*msg.MessageRaw).Content)
+	require.NoError(t, err)
+	buf.Write(byts)
+}
+
+func testHeartbeatFrame(t *testing.T) (*frame.V2Frame, *common.MessageHeartbeat) {
+	hb := &common.MessageHeartbeat{
+		Type:       common.MAV_TYPE_QUADROTOR,
+		CustomMode: 4,
+	}
+
+	mde, err := msg.NewDecEncoder(hb)
+	require.NoError(t, err)
+
+	content, err := mde.Encode(hb, true)
+	require.NoError(t, err)
+
+	f := &frame.V2Frame{
+		SequenceID:  1,
+		SystemID:    2,
+		ComponentID: 3,
+		Message:     &msg.MessageRaw{ID: 0, Content: content},
+	}
+	f.Checksum = f.GenChecksum(mde.CRCExtra())
+	return f, hb
+}
+
+func TestRead(t *testing.T) {
+	f, hb := testHeartbeatFrame(t)
+	content := f.Message.(*msg.MessageRaw).Content
+
+	var buf bytes.Buffer
+	t1 := time.Unix(1600000000, 123456000)
+	writeTestEntry(t, &buf, t1, f)
+	writeTestEntry(t, &buf, t1.Add(time.Second), f)
+
+	require.Equal(t, []byte{0x00, 0x05, 0xaf, 0x31, 0x07, 0xa5, 0xe2, 0x40}, buf.Bytes()[:8])
+
+	r, err := NewReader(bytes.NewReader(buf.Bytes()), common.Dialect)
+	require.NoError(t, err)
+
+	e, err := r.Read()
+	require.NoError(t, err)
+	require.True(t, e.Time.Equal(t1))
+	require.Equal(t, &frame.V2Frame{
+		SequenceID:    1,
+		SystemID:      2,
+		ComponentID:   3,
+		Message:       hb,
+		Checksum:      f.Checksum,
+		PayloadLength: byte(len(content)),
+	}, e.Frame)
+
+	e, err = r.Read()
+	require.NoError(t, err)
+	require.True(t, e.Time.Equal(t1.Add(time.Second)))
+
+	_, err = r.Read()
+	require.Equal(t, io.EOF, err)
+
+	// without dialect, messages are not decoded
+	r, err = NewReader(bytes.NewReader(buf.Bytes()), nil)
+	require.NoError(t, err)
+
+	e, err = r.Read()
+	require.NoError(t, err)
+	require.Equal(t, &msg.MessageRaw{ID: 0, Content: content}, e.Frame.GetMessage())
+
+	// truncated log
+	r, err = NewReader(bytes.NewReader(buf.Bytes()[:12]), nil)
+	require.NoError(t, err)
+
+	_, err = r.Read()
+	require.Equal(t, io.ErrUnexpectedEOF, err)
+
+	_, err = r.Read()
+	require.Equal(t, io.EOF, err)
+}
+
+func TestReadResync(t *testing.T) {
+	f, _ := testHeartbeatFrame(t)
+
+	var buf bytes.Buffer
+	t1 := time.Unix(1600000000, 0)
+	writeTestEntry(t, &buf, t1, f)
+	writeTestEntry(t, &buf, t1.Add(time.Second), f)
+	writeTestEntry(t, &buf, t1.Add(2*time.Second), f)
+
+	// corrupt the checksum of the first entry and the magic byte of the
+	// second one
+	entrySize := buf.Len() / 3
+	byts := buf.Bytes()
+	byts[entrySize-1]++
+	byts[entrySize+8] = 0x00
+
+	r, err := NewReader(bytes.NewReader(byts), common.Dialect)
+	require.NoError(t, err)
+
+	_, err = r.Read()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "wrong checksum")
+
+	e, err := r.Read()
+	require.NoError(t, err)
+	require.True(t, e.Time.Equal(t1.Add(2*time.Second)))
+
+	_, err = r.Read()
+	require.Equal(t, io.EOF, err)
+}
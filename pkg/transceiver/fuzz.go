@@ -0,0 +1,62 @@
+//go:build gofuzz
+// +build gofuzz
+
+package transceiver
+
+import (
+	"bytes"
+	"io/ioutil"
+
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+	"github.com/aler9/gomavlib/pkg/frame"
+)
+
+var fuzzDialectDE = func() *dialect.DecEncoder {
+	de, err := dialect.NewDecEncoder(common.Dialect)
+	if err != nil {
+		panic(err)
+	}
+	return de
+}()
+
+// Fuzz is the entry point of go-fuzz. The corpus is in testdata/fuzz.
+//
+//	go-fuzz-build github.com/aler9/gomavlib/pkg/transceiver
+//	go-fuzz -bin=transceiver-fuzz.zip -workdir=pkg/transceiver/testdata/fuzz
+func Fuzz(data []byte) int {
+	ret := 0
+
+	for _, inKey := range []*frame.V2Key{nil, frame.NewV2Key([]byte("fuzz"))} {
+		tr, err := New(Conf{
+			Reader:      bytes.NewReader(data),
+			Writer:      ioutil.Discard,
+			DialectDE:   fuzzDialectDE,
+			InKey:       inKey,
+			OutVersion:  V2,
+			OutSystemID: 1,
+		})
+		if err != nil {
+			panic(err)
+		}
+
+		for {
+			fr, err := tr.Read()
+			if err != nil {
+				if _, ok := err.(*Error); ok {
+					continue
+				}
+				break
+			}
+
+			// frames that have been read must be encodable again
+			err = tr.WriteFrame(fr)
+			if err != nil {
+				panic(err)
+			}
+			ret = 1
+		}
+	}
+
+	return ret
+}
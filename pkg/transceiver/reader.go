@@ -0,0 +1,420 @@
+package transceiver
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sync/atomic"
+	"time"
+
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/frame"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+// ReaderConf configures a Reader.
+type ReaderConf struct {
+	// the reader from which frames will be read.
+	Reader io.Reader
+
+	// (optional) the dialect which contains the messages that will be encoded and decoded.
+	// If not provided, messages are decoded in the MessageRaw struct.
+	DialectDE *dialect.DecEncoder
+
+	// (optional) the crc-extra of messages that are not in the dialect,
+	// indexed by message id. It allows to validate the checksum of frames
+	// that are returned with a MessageRaw, and to compute the checksum of
+	// MessageRaw frames that are written or modified, without the need of a
+	// full dialect.
+	CRCExtras map[uint32]byte
+
+	// (optional) the secret key used to validate incoming frames.
+	// Non-signed frames are discarded. This feature requires v2 frames.
+	InKey *frame.V2Key
+
+	// (optional) the maximum age of the signature timestamp of a frame with
+	// respect to the most recent frame of the same stream (link id, system id,
+	// component id), in order to reject replayed frames while tolerating
+	// reordering. It defaults to 10 seconds. Set it to a value lower than the
+	// timestamp resolution (10 microseconds) in order to reject any frame
+	// older than the most recent one.
+	InSignatureWindow time.Duration
+
+	// (optional) discards frames whose message is not in the dialect and not
+	// in CRCExtras, instead of returning them with a MessageRaw. Read()
+	// returns an Error of kind ErrUnknownMessage.
+	DiscardUnknown bool
+
+	// (optional) accepts V2 frames with incompatibility flags that are not
+	// understood, instead of returning an Error of kind
+	// ErrUnknownIncompatibilityFlag. The layout of these frames is assumed to
+	// be the standard one, and their message is not decoded.
+	AcceptUnknownIncompatibilityFlags bool
+
+	// (optional) disables the decoding of messages inside Read(), that returns
+	// frames with a MessageRaw. Messages can then be decoded with DecodeMessage().
+	DecodeDisable bool
+
+	// (optional) whether frames returned by Read() are taken from a pool.
+	// Frames can be given back to the pool with ReleaseFrame().
+	FramePool bool
+}
+
+// Reader is a low-level Mavlink frame decoder that works with a io.Reader.
+// It validates checksums and signatures and decodes messages, without the
+// need of a Node.
+type Reader struct {
+	conf                 ReaderConf
+	readBuffer           *bufio.Reader
+	curReadSignatureTime uint64
+	readSignatureStreams map[signatureStream]uint64
+	v2Received           uint32 // atomic
+}
+
+// signatureStream identifies a stream of signed frames.
+type signatureStream struct {
+	linkID      byte
+	systemID    byte
+	componentID byte
+}
+
+// NewReader allocates a Reader. See ReaderConf for the options.
+func NewReader(conf ReaderConf) (*Reader, error) {
+	if conf.Reader == nil {
+		return nil, fmt.Errorf("Reader not provided")
+	}
+	if conf.InSignatureWindow == 0 {
+		conf.InSignatureWindow = 10 * time.Second
+	}
+
+	return &Reader{
+		conf:                 conf,
+		readBuffer:           bufio.NewReaderSize(conf.Reader, bufferSize),
+		readSignatureStreams: make(map[signatureStream]uint64),
+	}, nil
+}
+
+// decodeV1Frame decodes a V1 frame, whose magic byte has already been read,
+// and returns its length. The frame is not consumed.
+// Unlike Frame.Decode(), the message content is not copied and points to the
+// read buffer, therefore it must be decoded or copied before reading again.
+func decodeV1Frame(br *bufio.Reader, f *frame.V1Frame) (int, error) {
+	// header
+	buf, err := br.Peek(5)
+	if err != nil {
+		return 0, newError(ErrShortFrame, err.Error())
+	}
+	msgLen := int(buf[0])
+
+	// the whole frame fits into the read buffer
+	frameLen := 5 + msgLen + 2
+	buf, err = br.Peek(frameLen)
+	if err != nil {
+		return 0, newError(ErrShortFrame, err.Error())
+	}
+
+	f.SequenceID = buf[1]
+	f.SystemID = buf[2]
+	f.ComponentID = buf[3]
+	f.Checksum = binary.LittleEndian.Uint16(buf[5+msgLen:])
+
+	raw := &msg.MessageRaw{ID: uint32(buf[4])}
+	if msgLen > 0 {
+		raw.Content = buf[5 : 5+msgLen : 5+msgLen]
+	}
+	f.Message = raw
+
+	return frameLen, nil
+}
+
+// decodeV2Frame decodes a V2 frame, whose magic byte has already been read,
+// and returns its length. The frame is not consumed.
+// Unlike Frame.Decode(), the message content is not copied and points to the
+// read buffer, therefore it must be decoded or copied before reading again.
+func decodeV2Frame(br *bufio.Reader, f *frame.V2Frame, acceptUnknownFlags bool) (int, error) {
+	// header
+	buf, err := br.Peek(9)
+	if err != nil {
+		return 0, newError(ErrShortFrame, err.Error())
+	}
+	msgLen := int(buf[0])
+
+	f.PayloadLength = buf[0]
+	f.IncompatibilityFlag = buf[1]
+	f.CompatibilityFlag = buf[2]
+	f.SequenceID = buf[3]
+	f.SystemID = buf[4]
+	f.ComponentID = buf[5]
+	msgID := uint32(buf[6]) | uint32(buf[7])<<8 | uint32(buf[8])<<16
+
+	// discard frame if incompatibility flag is not understood, as in recommendations
+	if !acceptUnknownFlags && f.HasUnknownIncompatibilityFlags() {
+		return 0, newError(ErrUnknownIncompatibilityFlag,
+			"unknown incompatibility flag (%d)", f.IncompatibilityFlag)
+	}
+
+	frameLen := 9 + msgLen + 2
+	if f.IsSigned() {
+		frameLen += 13
+	}
+
+	// the whole frame fits into the read buffer
+	buf, err = br.Peek(frameLen)
+	if err != nil {
+		return 0, newError(ErrShortFrame, err.Error())
+	}
+
+	raw := &msg.MessageRaw{ID: msgID}
+	if msgLen > 0 {
+		raw.Content = buf[9 : 9+msgLen : 9+msgLen]
+	}
+	f.Message = raw
+	buf = buf[9+msgLen:]
+
+	f.Checksum = binary.LittleEndian.Uint16(buf)
+
+	if f.IsSigned() {
+		buf = buf[2:]
+		f.SignatureLinkID = buf[0]
+		f.SignatureTimestamp = uint64(buf[1]) | uint64(buf[2])<<8 | uint64(buf[3])<<16 |
+			uint64(buf[4])<<24 | uint64(buf[5])<<32 | uint64(buf[6])<<40
+		f.Signature = new(frame.V2Signature)
+		copy(f.Signature[:], buf[7:])
+	}
+
+	return frameLen, nil
+}
+
+// skipGarbage discards buffered bytes until the next magic byte, in order
+// to return a single error for each sequence of invalid bytes.
+// It returns the discarded bytes.
+func (p *Reader) skipGarbage() []byte {
+	var ret []byte
+	for p.readBuffer.Buffered() > 0 {
+		buf, _ := p.readBuffer.Peek(1)
+		if buf[0] == frame.V1MagicByte || buf[0] == frame.V2MagicByte {
+			break
+		}
+		ret = append(ret, buf[0])
+		p.readBuffer.Discard(1)
+	}
+	return ret
+}
+
+// Read reads a Frame from the reader.
+// It must not be called by multiple routines in parallel.
+// Messages are decoded directly from the read buffer, without intermediate
+// copies. The returned frame does not reference the read buffer and is owned
+// by the caller.
+// Invalid frames are not consumed, except for their magic byte: parsing
+// restarts from the following byte, in order to recover frames that are
+// preceded by garbage. Memory usage is bounded by the size of the read buffer.
+func (p *Reader) Read() (frame.Frame, error) {
+	magicByte, err := p.readBuffer.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+
+	var f frame.Frame
+	var frameLen int
+	switch magicByte {
+	case frame.V1MagicByte:
+		var ff *frame.V1Frame
+		if p.conf.FramePool {
+			ff = v1FramePool.Get().(*frame.V1Frame)
+		} else {
+			ff = &frame.V1Frame{}
+		}
+		f = ff
+		frameLen, err = decodeV1Frame(p.readBuffer, ff)
+
+	case frame.V2MagicByte:
+		var ff *frame.V2Frame
+		if p.conf.FramePool {
+			ff = v2FramePool.Get().(*frame.V2Frame)
+		} else {
+			ff = &frame.V2Frame{}
+		}
+		f = ff
+		frameLen, err = decodeV2Frame(p.readBuffer, ff, p.conf.AcceptUnknownIncompatibilityFlags)
+
+	default:
+		garbage := p.skipGarbage()
+		err := newError(ErrInvalidMagicByte, "invalid magic byte: %x", magicByte)
+		err.Bytes = append([]byte{magicByte}, garbage...)
+		return nil, err
+	}
+	if err != nil {
+		e := err.(*Error)
+		buf, _ := p.readBuffer.Peek(p.readBuffer.Buffered())
+		e.Bytes = append([]byte{magicByte}, buf...)
+		return nil, e
+	}
+
+	// the frame is still in the read buffer until the next read
+	frameBuf, _ := p.readBuffer.Peek(frameLen)
+
+	// validate checksum before consuming the frame
+	var mp *msg.DecEncoder
+	if p.conf.DialectDE != nil {
+		mp = p.conf.DialectDE.MessageDEs[f.GetMessage().GetID()]
+	}
+
+	if mp == nil {
+		if crcExtra, ok := p.conf.CRCExtras[f.GetMessage().GetID()]; ok {
+			err := checkChecksumExtra(crcExtra, f)
+			if err != nil {
+				return nil, withFrame(err, f, magicByte, frameBuf)
+			}
+		} else if p.conf.DiscardUnknown && (p.conf.DialectDE != nil || p.conf.CRCExtras != nil) {
+			err := newError(ErrUnknownMessage,
+				"message is not in the dialect (id=%d)", f.GetMessage().GetID())
+			return nil, withFrame(err, f, magicByte, frameBuf)
+		}
+	} else if p.conf.DecodeDisable {
+		mp = nil
+	} else {
+		err := checkChecksum(mp, f)
+		if err != nil {
+			return nil, withFrame(err, f, magicByte, frameBuf)
+		}
+	}
+
+	// the message of frames with unknown incompatibility flags is not decoded
+	if ff, ok := f.(*frame.V2Frame); ok && ff.HasUnknownIncompatibilityFlags() {
+		mp = nil
+	}
+
+	// the message content is still valid after the frame is discarded,
+	// since the read buffer is filled only by following reads.
+	p.readBuffer.Discard(frameLen)
+
+	if p.conf.InKey != nil {
+		err := p.checkSignature(f)
+		if err != nil {
+			return nil, withFrame(err, f, magicByte, frameBuf)
+		}
+	}
+
+	// decode message if in dialect
+	if mp != nil {
+		err := decodeMessage(mp, f)
+		if err != nil {
+			return nil, withFrame(err, f, magicByte, frameBuf)
+		}
+	}
+
+	// messages that have not been decoded point to the read buffer,
+	// therefore their content is copied.
+	if raw, ok := f.GetMessage().(*msg.MessageRaw); ok && raw.Content != nil {
+		raw.Content = append([]byte(nil), raw.Content...)
+	}
+
+	if _, ok := f.(*frame.V2Frame); ok && atomic.LoadUint32(&p.v2Received) == 0 {
+		atomic.StoreUint32(&p.v2Received, 1)
+	}
+
+	return f, nil
+}
+
+func (p *Reader) checkSignature(f frame.Frame) *Error {
+	ff, ok := f.(*frame.V2Frame)
+	if !ok {
+		return newError(ErrBadSignature, "signature required but packet is not v2")
+	}
+
+	if !ff.IsSigned() {
+		return newError(ErrBadSignature, "signature required but packet is not signed")
+	}
+
+	if sig := ff.GenSignature(p.conf.InKey); *sig != *ff.Signature {
+		return newError(ErrBadSignature, "wrong signature")
+	}
+
+	stream := signatureStream{ff.SignatureLinkID, ff.SystemID, ff.ComponentID}
+	last, ok := p.readSignatureStreams[stream]
+
+	if ok {
+		// in UDP, packet order is not guaranteed. Therefore, we accept frames
+		// with a timestamp within a window with respect to the most recent
+		// frame of the same stream.
+		window := uint64(p.conf.InSignatureWindow / (10 * time.Microsecond))
+		if ff.SignatureTimestamp+window < last {
+			return newError(ErrBadSignature, "signature timestamp is too old")
+		}
+	} else {
+		if ff.SignatureTimestamp+newSignatureStreamMaxAge < p.curReadSignatureTime {
+			return newError(ErrBadSignature, "signature timestamp is too old")
+		}
+
+		if len(p.readSignatureStreams) >= maxSignatureStreams {
+			return newError(ErrBadSignature, "too many signature streams")
+		}
+	}
+
+	if !ok || ff.SignatureTimestamp > last {
+		p.readSignatureStreams[stream] = ff.SignatureTimestamp
+	}
+
+	if ff.SignatureTimestamp > p.curReadSignatureTime {
+		p.curReadSignatureTime = ff.SignatureTimestamp
+	}
+
+	return nil
+}
+
+// DecodeMessage validates the checksum of a frame and decodes its message,
+// if the message is in the dialect and the frame does not have unknown
+// incompatibility flags. The frame must contain a MessageRaw.
+// It can be used to decode frames read by a Reader or a Transceiver without a
+// dialect, and can be called by multiple routines in parallel.
+func DecodeMessage(dialectDE *dialect.DecEncoder, f frame.Frame) error {
+	mp, ok := dialectDE.MessageDEs[f.GetMessage().GetID()]
+	if !ok {
+		return nil
+	}
+
+	err := checkChecksum(mp, f)
+	if err == nil {
+		if ff, ok := f.(*frame.V2Frame); !ok || !ff.HasUnknownIncompatibilityFlags() {
+			err = decodeMessage(mp, f)
+		}
+	}
+	if err != nil {
+		err.Bytes = encodeFrame(f)
+		err.Frame = f
+		return err
+	}
+
+	return nil
+}
+
+func checkChecksum(mp *msg.DecEncoder, f frame.Frame) *Error {
+	return checkChecksumExtra(mp.CRCExtra(), f)
+}
+
+func checkChecksumExtra(crcExtra byte, f frame.Frame) *Error {
+	if sum := f.GenChecksum(crcExtra); sum != f.GetChecksum() {
+		return newError(ErrBadChecksum, "wrong checksum (expected %.4x, got %.4x, id=%d)",
+			sum, f.GetChecksum(), f.GetMessage().GetID())
+	}
+	return nil
+}
+
+func decodeMessage(mp *msg.DecEncoder, f frame.Frame) *Error {
+	_, isV2 := f.(*frame.V2Frame)
+	msg, err := mp.Decode(f.GetMessage().(*msg.MessageRaw).Content, isV2)
+	if err != nil {
+		return newError(ErrInvalidMessage, err.Error())
+	}
+
+	switch ff := f.(type) {
+	case *frame.V1Frame:
+		ff.Message = msg
+	case *frame.V2Frame:
+		ff.Message = msg
+	}
+
+	return nil
+}
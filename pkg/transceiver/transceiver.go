@@ -1,10 +1,12 @@
-// Package transceiver implements a Mavlink transceiver.
+// Package transceiver implements a Mavlink transceiver, and a frame reader
+// and a frame writer that can be used separately.
 package transceiver
 
 import (
-	"bufio"
+	"errors"
 	"fmt"
 	"io"
+	"sync"
 	"time"
 
 	"github.com/aler9/gomavlib/pkg/dialect"
@@ -14,13 +16,86 @@ import (
 
 const (
 	bufferSize = 512 // frames cannot go beyond len(header) + 255 + len(check) + len(sig)
+
+	// maximum number of signature streams tracked by a Transceiver
+	maxSignatureStreams = 256
+
+	// maximum age of the first frame of a signature stream with respect to
+	// the most recent frame, in 10 microsecond units (1 minute).
+	newSignatureStreamMaxAge = 60 * 100000
+)
+
+// bufferPool contains buffers used to encode messages and frames, shared
+// between transceivers in order to reduce allocations.
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		buf := make([]byte, bufferSize)
+		return &buf
+	},
+}
+
+// frame pools contain frames returned by Read() when FramePool is enabled.
+var (
+	v1FramePool = sync.Pool{
+		New: func() interface{} {
+			return &frame.V1Frame{}
+		},
+	}
+	v2FramePool = sync.Pool{
+		New: func() interface{} {
+			return &frame.V2Frame{}
+		},
+	}
 )
 
+// ReleaseFrame gives back a frame returned by Read() to the frame pool.
+// It must be used only when FramePool is enabled, and the frame must not be
+// used afterwards.
+func ReleaseFrame(f frame.Frame) {
+	switch ff := f.(type) {
+	case *frame.V1Frame:
+		*ff = frame.V1Frame{}
+		v1FramePool.Put(ff)
+
+	case *frame.V2Frame:
+		*ff = frame.V2Frame{}
+		v2FramePool.Put(ff)
+	}
+}
+
 // 1st January 2015 GMT
 var signatureReferenceDate = time.Date(2015, 0o1, 0o1, 0, 0, 0, 0, time.UTC)
 
+// Kinds of parsing errors, wrapped by Error. They can be checked with errors.Is().
+var (
+	// bytes that do not begin a frame.
+	ErrInvalidMagicByte = errors.New("invalid magic byte")
+	// the stream ended before the end of a frame.
+	ErrShortFrame = errors.New("short frame")
+	// a V2 frame has an incompatibility flag that is not understood.
+	ErrUnknownIncompatibilityFlag = errors.New("unknown incompatibility flag")
+	// the checksum of a frame is wrong.
+	ErrBadChecksum = errors.New("wrong checksum")
+	// a signature is missing, wrong or too old.
+	ErrBadSignature = errors.New("bad signature")
+	// the message is not in the dialect. See Conf.DiscardUnknown.
+	ErrUnknownMessage = errors.New("unknown message")
+	// the message content cannot be decoded.
+	ErrInvalidMessage = errors.New("invalid message")
+)
+
 // Error is the error returned in case of non-fatal parsing errors.
 type Error struct {
+	// the kind of error, that is one of the Err* variables.
+	Kind error
+	// the offending bytes, that is the discarded frame or the bytes that
+	// could not be parsed.
+	Bytes []byte
+	// the discarded frame, with a MessageRaw, if its header could be decoded.
+	// It is nil in case of ErrInvalidMagicByte, ErrShortFrame and
+	// ErrUnknownIncompatibilityFlag.
+	Frame frame.Frame
+
 	str string
 }
 
@@ -28,10 +103,43 @@ func (e *Error) Error() string {
 	return e.str
 }
 
-func newError(format string, args ...interface{}) *Error {
+// Unwrap returns the kind of error.
+func (e *Error) Unwrap() error {
+	return e.Kind
+}
+
+// IsSignature returns whether the frame has been discarded because of a
+// missing or invalid signature.
+func (e *Error) IsSignature() bool {
+	return e.Kind == ErrBadSignature
+}
+
+func newError(kind error, format string, args ...interface{}) *Error {
 	return &Error{
-		str: fmt.Sprintf(format, args...),
+		Kind: kind,
+		str:  fmt.Sprintf(format, args...),
+	}
+}
+
+// withFrame attaches a frame and its bytes to an error.
+// The frame content, that points to the read buffer, is copied.
+func withFrame(err *Error, f frame.Frame, magicByte byte, buf []byte) *Error {
+	err.Bytes = append([]byte{magicByte}, buf...)
+	if raw, ok := f.GetMessage().(*msg.MessageRaw); ok && raw.Content != nil {
+		raw.Content = append([]byte(nil), raw.Content...)
 	}
+	err.Frame = f
+	return err
+}
+
+// encodeFrame returns the bytes of a frame that contains a MessageRaw.
+func encodeFrame(f frame.Frame) []byte {
+	raw, ok := f.GetMessage().(*msg.MessageRaw)
+	if !ok {
+		return nil
+	}
+	buf, _ := f.Encode(make([]byte, bufferSize), raw.Content)
+	return buf
 }
 
 // Conf configures a Transceiver.
@@ -44,10 +152,42 @@ type Conf struct {
 	// (optional) the dialect which contains the messages that will be encoded and decoded.
 	// If not provided, messages are decoded in the MessageRaw struct.
 	DialectDE *dialect.DecEncoder
+	// (optional) the crc-extra of messages that are not in the dialect,
+	// indexed by message id. It allows to validate the checksum of frames
+	// that are returned with a MessageRaw, and to compute the checksum of
+	// MessageRaw frames that are written or modified, without the need of a
+	// full dialect.
+	CRCExtras map[uint32]byte
 
 	// (optional) the secret key used to validate incoming frames.
 	// Non-signed frames are discarded. This feature requires v2 frames.
 	InKey *frame.V2Key
+	// (optional) the maximum age of the signature timestamp of a frame with
+	// respect to the most recent frame of the same stream (link id, system id,
+	// component id), in order to reject replayed frames while tolerating
+	// reordering. It defaults to 10 seconds. Set it to a value lower than the
+	// timestamp resolution (10 microseconds) in order to reject any frame
+	// older than the most recent one.
+	InSignatureWindow time.Duration
+
+	// (optional) discards frames whose message is not in the dialect and not
+	// in CRCExtras, instead of returning them with a MessageRaw. Read()
+	// returns an Error of kind ErrUnknownMessage.
+	DiscardUnknown bool
+
+	// (optional) accepts V2 frames with incompatibility flags that are not
+	// understood, instead of returning an Error of kind
+	// ErrUnknownIncompatibilityFlag. The layout of these frames is assumed to
+	// be the standard one, and their message is not decoded.
+	AcceptUnknownIncompatibilityFlags bool
+
+	// (optional) disables the decoding of messages inside Read(), that returns
+	// frames with a MessageRaw. Messages can then be decoded with DecodeMessage().
+	DecodeDisable bool
+
+	// (optional) whether frames returned by Read() are taken from a pool.
+	// Frames can be given back to the pool with ReleaseFrame().
+	FramePool bool
 
 	// Mavlink version used to encode messages. See Version
 	// for the available options.
@@ -63,15 +203,50 @@ type Conf struct {
 	// (optional) the secret key used to sign outgoing frames.
 	// This feature requires v2 frames.
 	OutKey *frame.V2Key
+	// (optional) the compatibility flags added to messages written with
+	// WriteMessage(). It applies to v2 frames only.
+	OutCompatibilityFlag byte
+	// (optional) disables the empty-byte truncation of messages written with
+	// WriteMessage(). It applies to v2 frames only.
+	OutTruncationDisable bool
+	// (optional) disables the encoding of extension fields of messages written
+	// with WriteMessage(), in order to target receivers that do not know them.
+	// It applies to v2 frames only.
+	OutExtensionsDisable bool
+	// (optional) a function that is called with every frame written with
+	// WriteMessage() or WriteFrame(), before it is encoded. It returns the
+	// frame to write, that is the same frame or a modified copy, or nil in
+	// order to discard the frame. The frame must not be modified in place.
+	// The checksum and the signature of frames written with WriteMessage() are
+	// computed after the function returns; the checksum of frames written with
+	// WriteFrame() is computed again only if the function returns a copy.
+	OutFrameHook func(frame.Frame) frame.Frame
+	// (optional) signs again V2 frames written with WriteFrame() with OutKey,
+	// or removes their signature if OutKey is nil, in order to route frames
+	// between links that use different keys. When a signature is added or
+	// removed, the checksum is computed again, and this requires the message
+	// to be in the dialect or in CRCExtras.
+	OutResign bool
+	// (optional) replaces the sequence id of frames written with WriteFrame()
+	// with the sequence id of the transceiver, that is shared with
+	// WriteMessage(), in order to route frames of multiple sources to a single
+	// link without confusing the loss detection of receivers. The frame passed
+	// to WriteFrame() is not modified. Since the checksum is computed again,
+	// only frames whose message is in the dialect or in CRCExtras are
+	// re-sequenced; signed frames are re-sequenced only when OutResign is
+	// enabled.
+	OutResequence bool
+
+	// (optional) a function that returns the current time, that is used to
+	// fill signature timestamps. It defaults to time.Now.
+	TimeNow func() time.Time
 }
 
-// Transceiver is a low-level Mavlink encoder and decoder that works with a Reader and a Writer.
+// Transceiver is a low-level Mavlink encoder and decoder that works with a
+// io.Reader and a io.Writer. It combines a Reader and a Writer.
 type Transceiver struct {
-	conf                 Conf
-	readBuffer           *bufio.Reader
-	writeBuffer          []byte
-	curWriteSequenceID   byte
-	curReadSignatureTime uint64
+	r *Reader
+	w *Writer
 }
 
 // New allocates a Transceiver, a low level frame encoder and decoder.
@@ -80,234 +255,66 @@ func New(conf Conf) (*Transceiver, error) {
 	if conf.Reader == nil {
 		return nil, fmt.Errorf("Reader not provided")
 	}
-	if conf.Writer == nil {
-		return nil, fmt.Errorf("Writer not provided")
-	}
-
-	if conf.OutVersion == 0 {
-		return nil, fmt.Errorf("OutVersion not provided")
-	}
-	if conf.OutSystemID < 1 {
-		return nil, fmt.Errorf("SystemID must be >= 1")
-	}
-	if conf.OutComponentID < 1 {
-		conf.OutComponentID = 1
-	}
-	if conf.OutKey != nil && conf.OutVersion != V2 {
-		return nil, fmt.Errorf("OutKey requires V2 frames")
-	}
-
-	return &Transceiver{
-		conf:        conf,
-		readBuffer:  bufio.NewReaderSize(conf.Reader, bufferSize),
-		writeBuffer: make([]byte, 0, bufferSize),
-	}, nil
-}
 
-// Read reads a Frame from the reader.
-// It must not be called by multiple routines in parallel.
-func (p *Transceiver) Read() (frame.Frame, error) {
-	magicByte, err := p.readBuffer.ReadByte()
+	r, err := NewReader(ReaderConf{
+		Reader:                            conf.Reader,
+		DialectDE:                         conf.DialectDE,
+		CRCExtras:                         conf.CRCExtras,
+		InKey:                             conf.InKey,
+		InSignatureWindow:                 conf.InSignatureWindow,
+		DiscardUnknown:                    conf.DiscardUnknown,
+		AcceptUnknownIncompatibilityFlags: conf.AcceptUnknownIncompatibilityFlags,
+		DecodeDisable:                     conf.DecodeDisable,
+		FramePool:                         conf.FramePool,
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	f, err := func() (frame.Frame, error) {
-		switch magicByte {
-		case frame.V1MagicByte:
-			return &frame.V1Frame{}, nil
-
-		case frame.V2MagicByte:
-			return &frame.V2Frame{}, nil
-		}
-
-		return nil, newError("invalid magic byte: %x", magicByte)
-	}()
+	w, err := newWriter(WriterConf{
+		Writer:               conf.Writer,
+		DialectDE:            conf.DialectDE,
+		CRCExtras:            conf.CRCExtras,
+		OutVersion:           conf.OutVersion,
+		OutSystemID:          conf.OutSystemID,
+		OutComponentID:       conf.OutComponentID,
+		OutSignatureLinkID:   conf.OutSignatureLinkID,
+		OutKey:               conf.OutKey,
+		OutCompatibilityFlag: conf.OutCompatibilityFlag,
+		OutTruncationDisable: conf.OutTruncationDisable,
+		OutExtensionsDisable: conf.OutExtensionsDisable,
+		OutFrameHook:         conf.OutFrameHook,
+		OutResign:            conf.OutResign,
+		OutResequence:        conf.OutResequence,
+		TimeNow:              conf.TimeNow,
+	}, &r.v2Received)
 	if err != nil {
 		return nil, err
 	}
 
-	err = f.Decode(p.readBuffer)
-	if err != nil {
-		return nil, newError(err.Error())
-	}
-
-	if p.conf.InKey != nil {
-		ff, ok := f.(*frame.V2Frame)
-		if !ok {
-			return nil, newError("signature required but packet is not v2")
-		}
-
-		if sig := ff.GenSignature(p.conf.InKey); *sig != *ff.Signature {
-			return nil, newError("wrong signature")
-		}
-
-		// in UDP, packet order is not guaranteed. Therefore, we accept frames
-		// with a timestamp within 10 seconds with respect to the previous frame.
-		if p.curReadSignatureTime > 0 &&
-			ff.SignatureTimestamp < (p.curReadSignatureTime-(10*100000)) {
-			return nil, newError("signature timestamp is too old")
-		}
-
-		if ff.SignatureTimestamp > p.curReadSignatureTime {
-			p.curReadSignatureTime = ff.SignatureTimestamp
-		}
-	}
-
-	// decode message if in dialect and validate checksum
-	if p.conf.DialectDE != nil {
-		if mp, ok := p.conf.DialectDE.MessageDEs[f.GetMessage().GetID()]; ok {
-			if sum := f.GenChecksum(p.conf.DialectDE.MessageDEs[f.GetMessage().GetID()].CRCExtra()); sum != f.GetChecksum() {
-				return nil, newError("wrong checksum (expected %.4x, got %.4x, id=%d)",
-					sum, f.GetChecksum(), f.GetMessage().GetID())
-			}
-
-			_, isV2 := f.(*frame.V2Frame)
-			msg, err := mp.Decode(f.GetMessage().(*msg.MessageRaw).Content, isV2)
-			if err != nil {
-				return nil, newError(err.Error())
-			}
-
-			switch ff := f.(type) {
-			case *frame.V1Frame:
-				ff.Message = msg
-			case *frame.V2Frame:
-				ff.Message = msg
-			}
-		}
-	}
-
-	return f, nil
+	return &Transceiver{
+		r: r,
+		w: w,
+	}, nil
 }
 
-// WriteMessage writes a Message into the writer.
-// It must not be called by multiple routines in parallel.
-func (p *Transceiver) WriteMessage(m msg.Message) error {
-	var fr frame.Frame
-	if p.conf.OutVersion == V1 {
-		fr = &frame.V1Frame{Message: m}
-	} else {
-		fr = &frame.V2Frame{Message: m}
-	}
-	return p.writeFrameAndFill(fr)
+// Read reads a Frame from the reader. See Reader.Read.
+func (p *Transceiver) Read() (frame.Frame, error) {
+	return p.r.Read()
 }
 
-func (p *Transceiver) writeFrameAndFill(fr frame.Frame) error {
-	if fr.GetMessage() == nil {
-		return fmt.Errorf("message is nil")
-	}
-
-	// do not touch the original frame, but work with a separate object
-	// in such way that the frame can be encoded by other parsers in parallel
-	safeFrame := fr.Clone()
-
-	// fill SequenceID, SystemID, ComponentID
-	switch ff := safeFrame.(type) {
-	case *frame.V1Frame:
-		ff.SequenceID = p.curWriteSequenceID
-		ff.SystemID = p.conf.OutSystemID
-		ff.ComponentID = p.conf.OutComponentID
-	case *frame.V2Frame:
-		ff.SequenceID = p.curWriteSequenceID
-		ff.SystemID = p.conf.OutSystemID
-		ff.ComponentID = p.conf.OutComponentID
-	}
-	p.curWriteSequenceID++
-
-	// fill CompatibilityFlag, IncompatibilityFlag if v2
-	if ff, ok := safeFrame.(*frame.V2Frame); ok {
-		ff.CompatibilityFlag = 0
-		ff.IncompatibilityFlag = 0
-
-		if p.conf.OutKey != nil {
-			ff.IncompatibilityFlag |= frame.V2FlagSigned
-		}
-	}
-
-	// encode message if it is not already encoded
-	if _, ok := safeFrame.GetMessage().(*msg.MessageRaw); !ok {
-		if p.conf.DialectDE == nil {
-			return fmt.Errorf("message cannot be encoded since dialect is nil")
-		}
-
-		mp, ok := p.conf.DialectDE.MessageDEs[safeFrame.GetMessage().GetID()]
-		if !ok {
-			return fmt.Errorf("message cannot be encoded since it is not in the dialect")
-		}
-
-		_, isV2 := safeFrame.(*frame.V2Frame)
-		byt, err := mp.Encode(safeFrame.GetMessage(), isV2)
-		if err != nil {
-			return err
-		}
-
-		msgRaw := &msg.MessageRaw{safeFrame.GetMessage().GetID(), byt} //nolint:govet
-		switch ff := safeFrame.(type) {
-		case *frame.V1Frame:
-			ff.Message = msgRaw
-		case *frame.V2Frame:
-			ff.Message = msgRaw
-		}
-
-		// fill checksum
-		switch ff := safeFrame.(type) {
-		case *frame.V1Frame:
-			ff.Checksum = ff.GenChecksum(p.conf.DialectDE.MessageDEs[ff.GetMessage().GetID()].CRCExtra())
-		case *frame.V2Frame:
-			ff.Checksum = ff.GenChecksum(p.conf.DialectDE.MessageDEs[ff.GetMessage().GetID()].CRCExtra())
-		}
-	}
-
-	// fill SignatureLinkID, SignatureTimestamp, Signature if v2
-	if ff, ok := safeFrame.(*frame.V2Frame); ok && p.conf.OutKey != nil {
-		ff.SignatureLinkID = p.conf.OutSignatureLinkID
-		// Timestamp in 10 microsecond units since 1st January 2015 GMT time
-		ff.SignatureTimestamp = uint64(time.Since(signatureReferenceDate)) / 10000
-		ff.Signature = ff.GenSignature(p.conf.OutKey)
-	}
+// OutVersion returns the version currently used to encode messages.
+// With VAuto, it is V1 until a V2 frame is read, then V2.
+func (p *Transceiver) OutVersion() Version {
+	return p.w.OutVersion()
+}
 
-	return p.WriteFrame(safeFrame)
+// WriteMessage writes a Message into the writer. See Writer.WriteMessage.
+func (p *Transceiver) WriteMessage(m msg.Message) error {
+	return p.w.WriteMessage(m)
 }
 
-// WriteFrame writes a Frame into the writer.
-// It must not be called by multiple routines in parallel.
-// This function is intended only for routing pre-existing frames to other nodes,
-// since all frame fields must be filled manually.
+// WriteFrame writes a Frame into the writer. See Writer.WriteFrame.
 func (p *Transceiver) WriteFrame(fr frame.Frame) error {
-	m := fr.GetMessage()
-	if m == nil {
-		return fmt.Errorf("message is nil")
-	}
-
-	// encode message if it is not already encoded
-	if _, ok := m.(*msg.MessageRaw); !ok {
-		if p.conf.DialectDE == nil {
-			return fmt.Errorf("message cannot be encoded since dialect is nil")
-		}
-
-		mp, ok := p.conf.DialectDE.MessageDEs[m.GetID()]
-		if !ok {
-			return fmt.Errorf("message cannot be encoded since it is not in the dialect")
-		}
-
-		_, isV2 := fr.(*frame.V2Frame)
-		byt, err := mp.Encode(m, isV2)
-		if err != nil {
-			return err
-		}
-
-		// do not touch frame.Message
-		// in such way that the frame can be encoded by other parsers in parallel
-		m = &msg.MessageRaw{m.GetID(), byt} //nolint:govet
-	}
-
-	buf, err := fr.Encode(p.writeBuffer, m.(*msg.MessageRaw).Content)
-	if err != nil {
-		return err
-	}
-
-	// do not check n, since io.Writer is not allowed to return n < len(buf)
-	// without throwing an error
-	_, err = p.conf.Writer.Write(buf)
-	return err
+	return p.w.WriteFrame(fr)
 }
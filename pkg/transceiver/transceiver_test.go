@@ -3,6 +3,10 @@ package transceiver
 import (
 	"bytes"
 	"encoding/binary"
+	"errors"
+	"io"
+	"io/ioutil"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -180,7 +184,8 @@ var casesTransceiver = []struct {
 				0x0607,
 				[]byte("\x10\x10\x10\x10\x10"),
 			},
-			Checksum: 0x0349,
+			Checksum:      0x0349,
+			PayloadLength: 5,
 		},
 		[]byte("\xFD\x05\x00\x00\x8F\x01\x02\x07\x06\x00\x10\x10\x10\x10\x10\x49\x03"),
 	},
@@ -198,7 +203,8 @@ var casesTransceiver = []struct {
 				'\x10',
 				binary.LittleEndian.Uint32([]byte("\x10\x10\x10\x10")),
 			},
-			Checksum: 0x0349,
+			Checksum:      0x0349,
+			PayloadLength: 5,
 		},
 		[]byte("\xFD\x05\x00\x00\x8F\x01\x02\x07\x06\x00\x10\x10\x10\x10\x10\x49\x03"),
 	},
@@ -224,6 +230,7 @@ var casesTransceiver = []struct {
 			SignatureLinkID:    1,
 			SignatureTimestamp: 2,
 			Signature:          &frame.V2Signature{0x0e, 0x47, 0x04, 0x0c, 0xef, 0x9b},
+			PayloadLength:      9,
 		},
 		[]byte("\xFD\x09\x01\x00\x00\x00\x00\x00\x00\x00\x04\x00\x00\x00\x01\x02\x03\x05\x03\xd9\xd1\x01\x02\x00\x00\x00\x00\x00\x0e\x47\x04\x0c\xef\x9b"),
 	},
@@ -252,6 +259,7 @@ var casesTransceiver = []struct {
 			SignatureLinkID:    3,
 			SignatureTimestamp: 4,
 			Signature:          &frame.V2Signature{0xa8, 0x88, 0x9, 0x39, 0xb2, 0x60},
+			PayloadLength:      34,
 		},
 		[]byte("\xFD\x22\x01\x00\x00\x00\x00\x64\x00\x00\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa0\x40\x00\x00\xc0\x40\x00\x00\x00\x41\x03\x00\x04\x00\x02\x07\x00\x00\x00\x00\x00\x00\x80\x3f\x77\xfb\x03\x04\x00\x00\x00\x00\x00\xa8\x88\x09\x39\xb2\x60"),
 	},
@@ -402,3 +410,935 @@ func TestTransceiverWriteFrameIsConst(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, f, original)
 }
+
+func TestTransceiverReadDoesNotAlias(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := New(Conf{
+		Reader:      bytes.NewBuffer(nil),
+		Writer:      &buf,
+		OutVersion:  V2,
+		OutSystemID: 1,
+	})
+	require.NoError(t, err)
+
+	// write enough frames to force the read buffer to be refilled
+	for i := byte(0); i < 100; i++ {
+		err = w.WriteFrame(&frame.V2Frame{
+			SystemID:    1,
+			ComponentID: 1,
+			Message:     &msg.MessageRaw{ID: 1, Content: []byte{i, i, i}},
+		})
+		require.NoError(t, err)
+	}
+
+	r, err := New(Conf{
+		Reader:      &buf,
+		Writer:      bytes.NewBuffer(nil),
+		OutVersion:  V2,
+		OutSystemID: 1,
+	})
+	require.NoError(t, err)
+
+	var frames []frame.Frame
+	for i := 0; i < 100; i++ {
+		f, err := r.Read()
+		require.NoError(t, err)
+		frames = append(frames, f)
+	}
+
+	for i, f := range frames {
+		require.Equal(t, &msg.MessageRaw{ID: 1, Content: []byte{byte(i), byte(i), byte(i)}}, f.GetMessage())
+	}
+}
+
+func TestTransceiverReadResync(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := New(Conf{
+		Reader:      bytes.NewBuffer(nil),
+		Writer:      &buf,
+		DialectDE:   testDialectDE,
+		OutVersion:  V2,
+		OutSystemID: 1,
+	})
+	require.NoError(t, err)
+
+	hb := &MessageHeartbeat{
+		Type:           1,
+		Autopilot:      2,
+		BaseMode:       3,
+		CustomMode:     6,
+		SystemStatus:   4,
+		MavlinkVersion: 5,
+	}
+	err = w.WriteMessage(hb)
+	require.NoError(t, err)
+
+	// garbage, then a frame header that claims a length which includes
+	// the following valid frame
+	var in []byte
+	in = append(in, 0x01, 0x02, 0x03)
+	in = append(in, 0xFD, 0x09, 0x00, 0x00, 0x00, 0x01, 0x01, 0x00, 0x00, 0x00)
+	in = append(in, buf.Bytes()...)
+
+	r, err := New(Conf{
+		Reader:      bytes.NewReader(in),
+		Writer:      bytes.NewBuffer(nil),
+		DialectDE:   testDialectDE,
+		OutVersion:  V2,
+		OutSystemID: 1,
+	})
+	require.NoError(t, err)
+
+	// a single error is returned for consecutive garbage
+	_, err = r.Read()
+	require.EqualError(t, err, "invalid magic byte: 1")
+	require.True(t, errors.Is(err, ErrInvalidMagicByte))
+	require.Equal(t, []byte{0x01, 0x02, 0x03}, err.(*Error).Bytes)
+
+	_, err = r.Read()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "wrong checksum")
+	require.True(t, errors.Is(err, ErrBadChecksum))
+	require.Equal(t, in[3:3+9+10+2], err.(*Error).Bytes)
+
+	_, err = r.Read()
+	require.EqualError(t, err, "invalid magic byte: 9")
+	require.True(t, errors.Is(err, ErrInvalidMagicByte))
+
+	f, err := r.Read()
+	require.NoError(t, err)
+	require.Equal(t, hb, f.GetMessage())
+}
+
+func TestTransceiverReadUnsignedWithKey(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := New(Conf{
+		Reader:      bytes.NewBuffer(nil),
+		Writer:      &buf,
+		OutVersion:  V2,
+		OutSystemID: 1,
+	})
+	require.NoError(t, err)
+
+	err = w.WriteMessage(&msg.MessageRaw{ID: 1, Content: []byte{1}})
+	require.NoError(t, err)
+
+	r, err := New(Conf{
+		Reader:      &buf,
+		Writer:      bytes.NewBuffer(nil),
+		InKey:       frame.NewV2Key([]byte("key")),
+		OutVersion:  V2,
+		OutSystemID: 1,
+	})
+	require.NoError(t, err)
+
+	_, err = r.Read()
+	require.EqualError(t, err, "signature required but packet is not signed")
+	require.True(t, errors.Is(err, ErrBadSignature))
+	require.True(t, err.(*Error).IsSignature())
+	require.Equal(t, &msg.MessageRaw{ID: 1, Content: []byte{1}}, err.(*Error).Frame.GetMessage())
+}
+
+func TestTransceiverReadErrorKinds(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := New(Conf{
+		Reader:      bytes.NewBuffer(nil),
+		Writer:      &buf,
+		OutVersion:  V2,
+		OutSystemID: 1,
+	})
+	require.NoError(t, err)
+
+	// message not in the dialect
+	err = w.WriteMessage(&msg.MessageRaw{ID: 200, Content: []byte{1}})
+	require.NoError(t, err)
+	unknown := append([]byte(nil), buf.Bytes()...)
+	buf.Reset()
+
+	// heartbeat with a checksum computed without CRC extra
+	err = w.WriteMessage(&msg.MessageRaw{ID: 0, Content: []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}})
+	require.NoError(t, err)
+	badChecksum := append([]byte(nil), buf.Bytes()...)
+	buf.Reset()
+
+	for _, ca := range []struct {
+		name  string
+		in    []byte
+		kind  error
+		bytes []byte
+	}{
+		{"unknown message", unknown, ErrUnknownMessage, unknown},
+		{"short frame", unknown[:5], ErrShortFrame, unknown[:5]},
+		{
+			"incompatibility flag",
+			[]byte{0xFD, 0x01, 0x04, 0x00, 0x00, 0x01, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+			ErrUnknownIncompatibilityFlag,
+			nil,
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			r, err := New(Conf{
+				Reader:         bytes.NewReader(ca.in),
+				Writer:         bytes.NewBuffer(nil),
+				DialectDE:      testDialectDE,
+				DiscardUnknown: true,
+				OutVersion:     V2,
+				OutSystemID:    1,
+			})
+			require.NoError(t, err)
+
+			_, err = r.Read()
+			require.True(t, errors.Is(err, ca.kind))
+			if ca.bytes != nil {
+				require.Equal(t, ca.bytes, err.(*Error).Bytes)
+			}
+		})
+	}
+
+	// checksum errors are detected also by DecodeMessage
+	r, err := New(Conf{
+		Reader:        bytes.NewReader(badChecksum),
+		Writer:        bytes.NewBuffer(nil),
+		DialectDE:     testDialectDE,
+		DecodeDisable: true,
+		OutVersion:    V2,
+		OutSystemID:   1,
+	})
+	require.NoError(t, err)
+
+	f, err := r.Read()
+	require.NoError(t, err)
+
+	err = DecodeMessage(testDialectDE, f)
+	require.True(t, errors.Is(err, ErrBadChecksum))
+	require.Equal(t, badChecksum, err.(*Error).Bytes)
+}
+
+// TestTransceiverFuzzCorpus checks that the go-fuzz corpus is parsed without
+// panics and that each read consumes some input.
+func TestTransceiverFuzzCorpus(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "fuzz", "corpus", "*"))
+	require.NoError(t, err)
+	require.NotEqual(t, 0, len(files))
+
+	for _, fpath := range files {
+		t.Run(filepath.Base(fpath), func(t *testing.T) {
+			data, err := ioutil.ReadFile(fpath)
+			require.NoError(t, err)
+
+			for _, inKey := range []*frame.V2Key{nil, frame.NewV2Key([]byte("fuzz"))} {
+				r, err := New(Conf{
+					Reader:      bytes.NewReader(data),
+					Writer:      bytes.NewBuffer(nil),
+					DialectDE:   testDialectDE,
+					InKey:       inKey,
+					OutVersion:  V2,
+					OutSystemID: 1,
+				})
+				require.NoError(t, err)
+
+				reads := 0
+				for {
+					_, err := r.Read()
+					if _, ok := err.(*Error); err != nil && !ok {
+						break
+					}
+					reads++
+					require.True(t, reads <= len(data))
+				}
+			}
+		})
+	}
+}
+
+// repeatReader returns the same content indefinitely.
+type repeatReader struct {
+	content []byte
+	pos     int
+}
+
+func (r *repeatReader) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		c := copy(p[n:], r.content[r.pos:])
+		n += c
+		r.pos = (r.pos + c) % len(r.content)
+	}
+	return n, nil
+}
+
+func BenchmarkTransceiverRead(b *testing.B) {
+	var buf bytes.Buffer
+	w, err := New(Conf{
+		Reader:      bytes.NewBuffer(nil),
+		Writer:      &buf,
+		DialectDE:   testDialectDE,
+		OutVersion:  V2,
+		OutSystemID: 1,
+	})
+	require.NoError(b, err)
+
+	err = w.WriteMessage(&MessageOpticalFlow{
+		TimeUsec:       3,
+		FlowCompMX:     1,
+		GroundDistance: 2,
+		FlowRateX:      3,
+	})
+	require.NoError(b, err)
+
+	r, err := New(Conf{
+		Reader:      &repeatReader{content: buf.Bytes()},
+		Writer:      bytes.NewBuffer(nil),
+		DialectDE:   testDialectDE,
+		OutVersion:  V2,
+		OutSystemID: 1,
+	})
+	require.NoError(b, err)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, err := r.Read()
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestTransceiverOutVersionAuto(t *testing.T) {
+	var in bytes.Buffer
+	for _, ver := range []Version{V1, V2} {
+		peer, err := New(Conf{
+			Reader:      bytes.NewReader(nil),
+			Writer:      &in,
+			DialectDE:   testDialectDE,
+			OutVersion:  ver,
+			OutSystemID: 2,
+		})
+		require.NoError(t, err)
+
+		err = peer.WriteMessage(&MessageHeartbeat{})
+		require.NoError(t, err)
+	}
+
+	var out bytes.Buffer
+	transceiver, err := New(Conf{
+		Reader:      &in,
+		Writer:      &out,
+		DialectDE:   testDialectDE,
+		OutVersion:  VAuto,
+		OutSystemID: 1,
+	})
+	require.NoError(t, err)
+	require.Equal(t, V1, transceiver.OutVersion())
+
+	f, err := transceiver.Read()
+	require.NoError(t, err)
+	require.IsType(t, &frame.V1Frame{}, f)
+	require.Equal(t, V1, transceiver.OutVersion())
+
+	err = transceiver.WriteMessage(&MessageHeartbeat{})
+	require.NoError(t, err)
+	require.Equal(t, byte(frame.V1MagicByte), out.Bytes()[0])
+	out.Reset()
+
+	f, err = transceiver.Read()
+	require.NoError(t, err)
+	require.IsType(t, &frame.V2Frame{}, f)
+	require.Equal(t, V2, transceiver.OutVersion())
+
+	err = transceiver.WriteMessage(&MessageHeartbeat{})
+	require.NoError(t, err)
+	require.Equal(t, byte(frame.V2MagicByte), out.Bytes()[0])
+}
+
+func TestTransceiverWriteMessageEncoding(t *testing.T) {
+	for _, ca := range []struct {
+		name              string
+		truncationDisable bool
+		extensionsDisable bool
+		payloadLength     byte
+		flowRateX         float32
+	}{
+		{"default", false, false, 30, 1},
+		{"truncation disabled", true, false, 34, 1},
+		{"extensions disabled", false, true, 1, 0},
+		{"truncation and extensions disabled", true, true, 26, 0},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			transceiver, err := New(Conf{
+				Reader:               bytes.NewReader(nil),
+				Writer:               &buf,
+				DialectDE:            testDialectDE,
+				OutVersion:           V2,
+				OutSystemID:          1,
+				OutTruncationDisable: ca.truncationDisable,
+				OutExtensionsDisable: ca.extensionsDisable,
+			})
+			require.NoError(t, err)
+
+			err = transceiver.WriteMessage(&MessageOpticalFlow{
+				TimeUsec:  1,
+				FlowRateX: 1,
+			})
+			require.NoError(t, err)
+
+			reader, err := New(Conf{
+				Reader:      &buf,
+				Writer:      bytes.NewBuffer(nil),
+				DialectDE:   testDialectDE,
+				OutVersion:  V2,
+				OutSystemID: 2,
+			})
+			require.NoError(t, err)
+
+			f, err := reader.Read()
+			require.NoError(t, err)
+			require.Equal(t, ca.payloadLength, f.(*frame.V2Frame).PayloadLength)
+			require.Equal(t, &MessageOpticalFlow{
+				TimeUsec:  1,
+				FlowRateX: ca.flowRateX,
+			}, f.GetMessage())
+		})
+	}
+}
+
+func TestTransceiverReadSignatureWindow(t *testing.T) {
+	key := frame.NewV2Key(bytes.Repeat([]byte("\x4F"), 32))
+
+	encode := func(linkID byte, ts uint64) []byte {
+		f := &frame.V2Frame{
+			IncompatibilityFlag: frame.V2FlagSigned,
+			SystemID:            1,
+			ComponentID:         1,
+			Message:             &msg.MessageRaw{ID: 1, Content: []byte{1}},
+			SignatureLinkID:     linkID,
+			SignatureTimestamp:  ts,
+		}
+		f.Checksum = f.GenChecksum(0)
+		f.Signature = f.GenSignature(key)
+		buf, err := f.Encode(make([]byte, 512), f.Message.(*msg.MessageRaw).Content)
+		require.NoError(t, err)
+		return buf
+	}
+
+	t.Run("default", func(t *testing.T) {
+		var buf bytes.Buffer
+		r, err := New(Conf{
+			Reader:      &buf,
+			Writer:      bytes.NewBuffer(nil),
+			InKey:       key,
+			OutVersion:  V2,
+			OutSystemID: 2,
+		})
+		require.NoError(t, err)
+
+		for _, ca := range []struct {
+			linkID byte
+			ts     uint64
+			err    string
+		}{
+			{1, 10000000, ""},
+			// reordered frame, within window
+			{1, 10000000 - 5*100000, ""},
+			// replayed frame, out of window
+			{1, 10000000 - 11*100000, "signature timestamp is too old"},
+			// new stream
+			{2, 10000000 - 30*100000, ""},
+			// new stream, too old with respect to other streams
+			{3, 10000000 - 61*100000, "signature timestamp is too old"},
+		} {
+			buf.Write(encode(ca.linkID, ca.ts))
+			_, err := r.Read()
+			if ca.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, ca.err)
+				require.True(t, errors.Is(err, ErrBadSignature))
+			}
+		}
+	})
+
+	t.Run("strict", func(t *testing.T) {
+		var buf bytes.Buffer
+		r, err := New(Conf{
+			Reader:            &buf,
+			Writer:            bytes.NewBuffer(nil),
+			InKey:             key,
+			InSignatureWindow: 1,
+			OutVersion:        V2,
+			OutSystemID:       2,
+		})
+		require.NoError(t, err)
+
+		buf.Write(encode(1, 1000))
+		_, err = r.Read()
+		require.NoError(t, err)
+
+		buf.Write(encode(1, 999))
+		_, err = r.Read()
+		require.EqualError(t, err, "signature timestamp is too old")
+
+		buf.Write(encode(1, 1001))
+		_, err = r.Read()
+		require.NoError(t, err)
+	})
+}
+
+func TestTransceiverOutFrameHook(t *testing.T) {
+	var buf bytes.Buffer
+	transceiver, err := New(Conf{
+		Reader:      bytes.NewReader(nil),
+		Writer:      &buf,
+		DialectDE:   testDialectDE,
+		OutVersion:  V2,
+		OutSystemID: 1,
+		OutFrameHook: func(fr frame.Frame) frame.Frame {
+			// drop messages with an odd TimeUsec
+			if fr.GetMessage().(*MessageOpticalFlow).TimeUsec%2 == 1 {
+				return nil
+			}
+
+			// remap the component id
+			fr = fr.Clone()
+			fr.(*frame.V2Frame).ComponentID = 5
+			return fr
+		},
+	})
+	require.NoError(t, err)
+
+	for i := uint64(1); i <= 4; i++ {
+		err = transceiver.WriteMessage(&MessageOpticalFlow{TimeUsec: i})
+		require.NoError(t, err)
+	}
+
+	err = transceiver.WriteFrame(&frame.V2Frame{
+		SystemID:    3,
+		ComponentID: 1,
+		Message:     &MessageOpticalFlow{TimeUsec: 6},
+		Checksum:    0xFFFF,
+	})
+	require.NoError(t, err)
+
+	reader, err := New(Conf{
+		Reader:      &buf,
+		Writer:      bytes.NewBuffer(nil),
+		DialectDE:   testDialectDE,
+		OutVersion:  V2,
+		OutSystemID: 2,
+	})
+	require.NoError(t, err)
+
+	for _, exp := range []struct {
+		systemID   byte
+		sequenceID byte
+		timeUsec   uint64
+	}{
+		{1, 0, 2},
+		{1, 1, 4},
+		{3, 0, 6},
+	} {
+		f, err := reader.Read()
+		require.NoError(t, err)
+		ff := f.(*frame.V2Frame)
+		require.Equal(t, exp.systemID, ff.SystemID)
+		require.Equal(t, byte(5), ff.ComponentID)
+		require.Equal(t, exp.sequenceID, ff.SequenceID)
+		require.Equal(t, exp.timeUsec, ff.Message.(*MessageOpticalFlow).TimeUsec)
+	}
+}
+
+func TestTransceiverOutResign(t *testing.T) {
+	keyA := frame.NewV2Key(bytes.Repeat([]byte("\x4F"), 32))
+	keyB := frame.NewV2Key(bytes.Repeat([]byte("\x5A"), 32))
+
+	// readFrom reads a frame written by a writer with the given key
+	readFrom := func(buf *bytes.Buffer, key *frame.V2Key) *frame.V2Frame {
+		reader, err := New(Conf{
+			Reader:      buf,
+			Writer:      bytes.NewBuffer(nil),
+			DialectDE:   testDialectDE,
+			InKey:       key,
+			OutVersion:  V2,
+			OutSystemID: 2,
+		})
+		require.NoError(t, err)
+
+		f, err := reader.Read()
+		require.NoError(t, err)
+		return f.(*frame.V2Frame)
+	}
+
+	// route writes a frame with a transceiver that signs with the given key
+	route := func(f frame.Frame, key *frame.V2Key) *bytes.Buffer {
+		var buf bytes.Buffer
+		transceiver, err := New(Conf{
+			Reader:      bytes.NewReader(nil),
+			Writer:      &buf,
+			DialectDE:   testDialectDE,
+			OutVersion:  V2,
+			OutSystemID: 3,
+			OutKey:      key,
+			OutResign:   true,
+		})
+		require.NoError(t, err)
+
+		err = transceiver.WriteFrame(f)
+		require.NoError(t, err)
+		return &buf
+	}
+
+	var buf bytes.Buffer
+	transceiver, err := New(Conf{
+		Reader:      bytes.NewReader(nil),
+		Writer:      &buf,
+		DialectDE:   testDialectDE,
+		OutVersion:  V2,
+		OutSystemID: 1,
+		OutKey:      keyA,
+	})
+	require.NoError(t, err)
+
+	err = transceiver.WriteMessage(&MessageOpticalFlow{TimeUsec: 1})
+	require.NoError(t, err)
+
+	signedA := readFrom(&buf, keyA)
+	require.Equal(t, true, signedA.IsSigned())
+
+	// a signed frame is signed again with another key
+	signedB := readFrom(route(signedA, keyB), keyB)
+	require.Equal(t, true, signedB.IsSigned())
+	require.Equal(t, byte(1), signedB.SystemID)
+	require.Equal(t, &MessageOpticalFlow{TimeUsec: 1}, signedB.Message)
+
+	// a signed frame is routed to a link without key
+	unsigned := readFrom(route(signedA, nil), nil)
+	require.Equal(t, false, unsigned.IsSigned())
+	require.Equal(t, &MessageOpticalFlow{TimeUsec: 1}, unsigned.Message)
+
+	// an unsigned frame is routed to a link with a key
+	signedB = readFrom(route(unsigned, keyB), keyB)
+	require.Equal(t, true, signedB.IsSigned())
+	require.Equal(t, &MessageOpticalFlow{TimeUsec: 1}, signedB.Message)
+
+	// the original frames are not modified
+	require.Equal(t, true, signedA.IsSigned())
+	require.Equal(t, false, unsigned.IsSigned())
+}
+
+func TestTransceiverOutResequence(t *testing.T) {
+	var buf bytes.Buffer
+	transceiver, err := New(Conf{
+		Reader:        bytes.NewReader(nil),
+		Writer:        &buf,
+		DialectDE:     testDialectDE,
+		OutVersion:    V2,
+		OutSystemID:   1,
+		OutResequence: true,
+	})
+	require.NoError(t, err)
+
+	err = transceiver.WriteMessage(&MessageOpticalFlow{TimeUsec: 1})
+	require.NoError(t, err)
+
+	routed := &frame.V2Frame{
+		SequenceID: 50,
+		SystemID:   3,
+		Message:    &MessageOpticalFlow{TimeUsec: 2},
+	}
+	err = transceiver.WriteFrame(routed)
+	require.NoError(t, err)
+	require.Equal(t, byte(50), routed.SequenceID)
+
+	// messages that are not in the dialect keep their sequence id
+	err = transceiver.WriteFrame(&frame.V2Frame{
+		SequenceID: 60,
+		SystemID:   3,
+		Message:    &msg.MessageRaw{ID: 1234, Content: []byte{1}},
+		Checksum:   0x1234,
+	})
+	require.NoError(t, err)
+
+	err = transceiver.WriteMessage(&MessageOpticalFlow{TimeUsec: 3})
+	require.NoError(t, err)
+
+	reader, err := New(Conf{
+		Reader:      &buf,
+		Writer:      bytes.NewBuffer(nil),
+		DialectDE:   testDialectDE,
+		OutVersion:  V2,
+		OutSystemID: 2,
+	})
+	require.NoError(t, err)
+
+	for _, exp := range []struct {
+		systemID   byte
+		sequenceID byte
+	}{
+		{1, 0},
+		{3, 1},
+		{3, 60},
+		{1, 2},
+	} {
+		f, err := reader.Read()
+		require.NoError(t, err)
+		require.Equal(t, exp.systemID, f.GetSystemID())
+		require.Equal(t, exp.sequenceID, f.(*frame.V2Frame).SequenceID)
+	}
+}
+
+func TestTransceiverCRCExtras(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := New(Conf{
+		Reader:      bytes.NewReader(nil),
+		Writer:      &buf,
+		DialectDE:   testDialectDE,
+		OutVersion:  V2,
+		OutSystemID: 1,
+	})
+	require.NoError(t, err)
+
+	err = w.WriteMessage(&MessageOpticalFlow{TimeUsec: 1})
+	require.NoError(t, err)
+	valid := append([]byte(nil), buf.Bytes()...)
+	buf.Reset()
+
+	err = w.WriteMessage(&msg.MessageRaw{ID: 200, Content: []byte{1}})
+	require.NoError(t, err)
+	unknown := append([]byte(nil), buf.Bytes()...)
+	buf.Reset()
+
+	corrupted := append([]byte(nil), valid...)
+	corrupted[10]++
+
+	crcExtras := map[uint32]byte{
+		100: testDialectDE.MessageDEs[100].CRCExtra(),
+	}
+
+	newReader := func(byts []byte) *Transceiver {
+		r, err := New(Conf{
+			Reader:         bytes.NewReader(byts),
+			Writer:         bytes.NewBuffer(nil),
+			CRCExtras:      crcExtras,
+			DiscardUnknown: true,
+			OutVersion:     V2,
+			OutSystemID:    2,
+		})
+		require.NoError(t, err)
+		return r
+	}
+
+	// frames are validated but not decoded
+	f, err := newReader(valid).Read()
+	require.NoError(t, err)
+	require.Equal(t, uint32(100), f.GetMessage().GetID())
+	_, ok := f.GetMessage().(*msg.MessageRaw)
+	require.Equal(t, true, ok)
+
+	_, err = newReader(corrupted).Read()
+	require.True(t, errors.Is(err, ErrBadChecksum))
+
+	_, err = newReader(unknown).Read()
+	require.True(t, errors.Is(err, ErrUnknownMessage))
+
+	// frames are re-sequenced without a dialect
+	router, err := New(Conf{
+		Reader:        bytes.NewReader(nil),
+		Writer:        &buf,
+		CRCExtras:     crcExtras,
+		OutVersion:    V2,
+		OutSystemID:   2,
+		OutResequence: true,
+	})
+	require.NoError(t, err)
+
+	ff := f.(*frame.V2Frame)
+	ff.SequenceID = 50
+	err = router.WriteFrame(ff)
+	require.NoError(t, err)
+
+	r, err := New(Conf{
+		Reader:      &buf,
+		Writer:      bytes.NewBuffer(nil),
+		DialectDE:   testDialectDE,
+		OutVersion:  V2,
+		OutSystemID: 3,
+	})
+	require.NoError(t, err)
+
+	f, err = r.Read()
+	require.NoError(t, err)
+	require.Equal(t, byte(0), f.(*frame.V2Frame).SequenceID)
+	require.Equal(t, &MessageOpticalFlow{TimeUsec: 1}, f.GetMessage())
+}
+
+func TestReaderWriter(t *testing.T) {
+	key := frame.NewV2Key([]byte("key"))
+
+	var buf bytes.Buffer
+	w, err := NewWriter(WriterConf{
+		Writer:      &buf,
+		DialectDE:   testDialectDE,
+		OutVersion:  V2,
+		OutSystemID: 1,
+		OutKey:      key,
+	})
+	require.NoError(t, err)
+
+	err = w.WriteMessage(&MessageOpticalFlow{TimeUsec: 1})
+	require.NoError(t, err)
+
+	r, err := NewReader(ReaderConf{
+		Reader:    &buf,
+		DialectDE: testDialectDE,
+		InKey:     key,
+	})
+	require.NoError(t, err)
+
+	f, err := r.Read()
+	require.NoError(t, err)
+	require.Equal(t, true, f.(*frame.V2Frame).IsSigned())
+	require.Equal(t, byte(1), f.GetSystemID())
+	require.Equal(t, &MessageOpticalFlow{TimeUsec: 1}, f.GetMessage())
+
+	_, err = NewReader(ReaderConf{})
+	require.EqualError(t, err, "Reader not provided")
+
+	_, err = NewWriter(WriterConf{
+		Writer:      &buf,
+		OutVersion:  VAuto,
+		OutSystemID: 1,
+	})
+	require.EqualError(t, err, "VAuto requires a Transceiver")
+}
+
+func TestWriterTimeNow(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(WriterConf{
+		Writer:      &buf,
+		DialectDE:   testDialectDE,
+		OutVersion:  V2,
+		OutSystemID: 1,
+		OutKey:      frame.NewV2Key([]byte("key")),
+		TimeNow: func() time.Time {
+			return time.Date(2015, 1, 1, 0, 0, 1, 0, time.UTC)
+		},
+	})
+	require.NoError(t, err)
+
+	err = w.WriteMessage(&MessageOpticalFlow{TimeUsec: 1})
+	require.NoError(t, err)
+
+	r, err := NewReader(ReaderConf{
+		Reader:    &buf,
+		DialectDE: testDialectDE,
+	})
+	require.NoError(t, err)
+
+	f, err := r.Read()
+	require.NoError(t, err)
+	require.Equal(t, uint64(100000), f.(*frame.V2Frame).SignatureTimestamp)
+}
+
+func TestTranscoder(t *testing.T) {
+	var in bytes.Buffer
+	w, err := NewWriter(WriterConf{
+		Writer:      &in,
+		DialectDE:   testDialectDE,
+		OutVersion:  V1,
+		OutSystemID: 3,
+	})
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		err = w.WriteMessage(&MessageOpticalFlow{TimeUsec: uint64(i)})
+		require.NoError(t, err)
+	}
+
+	// garbage is skipped
+	in.Write([]byte{1, 2, 3})
+
+	key := frame.NewV2Key([]byte("key"))
+
+	var out bytes.Buffer
+	tc, err := NewTranscoder(TranscoderConf{
+		Reader:     &in,
+		Writer:     &out,
+		DialectDE:  testDialectDE,
+		OutVersion: V2,
+		OutResign:  true,
+		OutKey:     key,
+	})
+	require.NoError(t, err)
+
+	err = tc.Run()
+	require.NoError(t, err)
+
+	r, err := NewReader(ReaderConf{
+		Reader:    &out,
+		DialectDE: testDialectDE,
+		InKey:     key,
+	})
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		f, err := r.Read()
+		require.NoError(t, err)
+		ff, ok := f.(*frame.V2Frame)
+		require.Equal(t, true, ok)
+		require.Equal(t, true, ff.IsSigned())
+		require.Equal(t, byte(3), ff.SystemID)
+		require.Equal(t, byte(i), ff.SequenceID)
+		require.Equal(t, &MessageOpticalFlow{TimeUsec: uint64(i)}, ff.Message)
+	}
+
+	_, err = r.Read()
+	require.Equal(t, io.EOF, err)
+}
+
+func TestTranscoderToV1(t *testing.T) {
+	var in bytes.Buffer
+	w, err := NewWriter(WriterConf{
+		Writer:      &in,
+		DialectDE:   testDialectDE,
+		OutVersion:  V2,
+		OutSystemID: 3,
+	})
+	require.NoError(t, err)
+
+	err = w.WriteMessage(&MessageOpticalFlow{TimeUsec: 1})
+	require.NoError(t, err)
+
+	// messages that are not in the dialect cannot be converted
+	err = w.WriteMessage(&msg.MessageRaw{ID: 200, Content: []byte{1}})
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	tc, err := NewTranscoder(TranscoderConf{
+		Reader:     &in,
+		Writer:     &out,
+		DialectDE:  testDialectDE,
+		OutVersion: V1,
+	})
+	require.NoError(t, err)
+
+	err = tc.Run()
+	require.NoError(t, err)
+
+	r, err := NewReader(ReaderConf{
+		Reader:    &out,
+		DialectDE: testDialectDE,
+	})
+	require.NoError(t, err)
+
+	f, err := r.Read()
+	require.NoError(t, err)
+	_, ok := f.(*frame.V1Frame)
+	require.Equal(t, true, ok)
+	require.Equal(t, &MessageOpticalFlow{TimeUsec: 1}, f.GetMessage())
+
+	_, err = r.Read()
+	require.Equal(t, io.EOF, err)
+}
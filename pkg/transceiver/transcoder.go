@@ -0,0 +1,180 @@
+package transceiver
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/frame"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+// TranscoderConf configures a Transcoder.
+type TranscoderConf struct {
+	// the reader from which frames will be read.
+	Reader io.Reader
+	// the writer to which frames will be written.
+	Writer io.Writer
+
+	// (optional) the dialect which contains the messages that will be decoded
+	// and encoded. It is needed to change the version of frames.
+	DialectDE *dialect.DecEncoder
+	// (optional) the crc-extra of messages that are not in the dialect,
+	// indexed by message id. See ReaderConf.
+	CRCExtras map[uint32]byte
+
+	// (optional) the secret key used to validate incoming frames.
+	// Non-signed frames are discarded.
+	InKey *frame.V2Key
+
+	// (optional) the version of outgoing frames. If not provided, frames keep
+	// their version. V1 frames can always be converted into V2 frames, as long
+	// as their message is in the dialect or in CRCExtras; V2 frames can be
+	// converted into V1 frames only if their message is in the dialect and
+	// has an id lower than 256. Frames that cannot be converted are discarded.
+	OutVersion Version
+	// (optional) signs again V2 frames with OutKey, or removes their signature
+	// if OutKey is nil. See WriterConf.OutResign.
+	OutResign bool
+	// (optional) the secret key used to sign outgoing frames when OutResign
+	// is enabled.
+	OutKey *frame.V2Key
+	// (optional) the value to insert into the signature link id.
+	OutSignatureLinkID byte
+	// (optional) replaces the sequence id of frames with the sequence id of
+	// the transcoder. See WriterConf.OutResequence.
+	OutResequence bool
+}
+
+// Transcoder reads frames from a io.Reader and writes them to a io.Writer,
+// optionally changing their version, their signature and their sequence id.
+// It can be used as a building block for bridges that do not need a Node.
+type Transcoder struct {
+	conf TranscoderConf
+	r    *Reader
+	w    *Writer
+}
+
+// NewTranscoder allocates a Transcoder. See TranscoderConf for the options.
+func NewTranscoder(conf TranscoderConf) (*Transcoder, error) {
+	if conf.OutVersion == VAuto {
+		return nil, fmt.Errorf("VAuto is not supported")
+	}
+	if conf.OutKey != nil && conf.OutVersion == V1 {
+		return nil, fmt.Errorf("OutKey requires V2 frames")
+	}
+
+	r, err := NewReader(ReaderConf{
+		Reader:    conf.Reader,
+		DialectDE: conf.DialectDE,
+		CRCExtras: conf.CRCExtras,
+		InKey:     conf.InKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Transcoder{
+		conf: conf,
+		r:    r,
+	}
+
+	var hook func(frame.Frame) frame.Frame
+	if conf.OutVersion != 0 {
+		hook = t.convert
+	}
+
+	// the system id and the component id of the writer are not used,
+	// since frames are written with WriteFrame().
+	t.w, err = NewWriter(WriterConf{
+		Writer:             conf.Writer,
+		DialectDE:          conf.DialectDE,
+		CRCExtras:          conf.CRCExtras,
+		OutVersion:         V2,
+		OutSystemID:        1,
+		OutSignatureLinkID: conf.OutSignatureLinkID,
+		OutKey:             conf.OutKey,
+		OutFrameHook:       hook,
+		OutResign:          conf.OutResign,
+		OutResequence:      conf.OutResequence,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// convert converts a frame into the output version.
+func (t *Transcoder) convert(fr frame.Frame) frame.Frame {
+	switch ff := fr.(type) {
+	case *frame.V1Frame:
+		if t.conf.OutVersion != V2 {
+			return fr
+		}
+
+		// the payload of V1 frames is a valid V2 payload
+		if _, ok := t.w.crcExtra(ff.Message.GetID()); !ok {
+			return nil
+		}
+
+		return &frame.V2Frame{
+			SequenceID:  ff.SequenceID,
+			SystemID:    ff.SystemID,
+			ComponentID: ff.ComponentID,
+			Message:     ff.Message,
+		}
+
+	case *frame.V2Frame:
+		if t.conf.OutVersion != V1 {
+			return fr
+		}
+
+		// V2 payloads are truncated and can contain extensions, therefore
+		// the message must be encoded again.
+		if _, ok := ff.Message.(*msg.MessageRaw); ok || ff.Message.GetID() > 0xFF {
+			return nil
+		}
+
+		return &frame.V1Frame{
+			SequenceID:  ff.SequenceID,
+			SystemID:    ff.SystemID,
+			ComponentID: ff.ComponentID,
+			Message:     ff.Message,
+		}
+	}
+
+	return fr
+}
+
+// Transcode reads a frame and writes it. It returns an Error in case of
+// non-fatal parsing errors, like Reader.Read.
+// It must not be called by multiple routines in parallel.
+func (t *Transcoder) Transcode() error {
+	fr, err := t.r.Read()
+	if err != nil {
+		return err
+	}
+
+	return t.w.WriteFrame(fr)
+}
+
+// Run transcodes frames until the reader returns io.EOF or a fatal error.
+// Frames that cannot be parsed are skipped.
+func (t *Transcoder) Run() error {
+	for {
+		err := t.Transcode()
+		if err != nil {
+			var perr *Error
+			if errors.As(err, &perr) {
+				continue
+			}
+
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+	}
+}
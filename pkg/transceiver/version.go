@@ -9,12 +9,19 @@ const (
 
 	// V2 is Mavlink 2.0
 	V2 Version = 2
+
+	// VAuto is Mavlink 1.0 until a Mavlink 2.0 frame is received, then
+	// Mavlink 2.0, as recommended by the Mavlink specification.
+	VAuto Version = 3
 )
 
 // String implements fmt.Stringer.
 func (v Version) String() string {
-	if v == V1 {
+	switch v {
+	case V1:
 		return "V1"
+	case VAuto:
+		return "auto"
 	}
 	return "V2"
 }
@@ -0,0 +1,476 @@
+package transceiver
+
+import (
+	"fmt"
+	"io"
+	"sync/atomic"
+	"time"
+
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/frame"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+// WriterConf configures a Writer.
+type WriterConf struct {
+	// the writer to which frames will be written.
+	Writer io.Writer
+
+	// (optional) the dialect which contains the messages that will be encoded and decoded.
+	// If not provided, messages are decoded in the MessageRaw struct.
+	DialectDE *dialect.DecEncoder
+
+	// (optional) the crc-extra of messages that are not in the dialect,
+	// indexed by message id. It allows to validate the checksum of frames
+	// that are returned with a MessageRaw, and to compute the checksum of
+	// MessageRaw frames that are written or modified, without the need of a
+	// full dialect.
+	CRCExtras map[uint32]byte
+
+	// Mavlink version used to encode messages. See Version
+	// for the available options. VAuto is available only with Transceiver,
+	// since it depends on received frames.
+	OutVersion Version
+
+	// the system id, added to every outgoing frame and used to identify this
+	// node in the network.
+	OutSystemID byte
+
+	// (optional) the component id, added to every outgoing frame, defaults to 1.
+	OutComponentID byte
+
+	// (optional) the value to insert into the signature link id.
+	// This feature requires v2 frames.
+	OutSignatureLinkID byte
+
+	// (optional) the secret key used to sign outgoing frames.
+	// This feature requires v2 frames.
+	OutKey *frame.V2Key
+
+	// (optional) the compatibility flags added to messages written with
+	// WriteMessage(). It applies to v2 frames only.
+	OutCompatibilityFlag byte
+
+	// (optional) disables the empty-byte truncation of messages written with
+	// WriteMessage(). It applies to v2 frames only.
+	OutTruncationDisable bool
+
+	// (optional) disables the encoding of extension fields of messages written
+	// with WriteMessage(), in order to target receivers that do not know them.
+	// It applies to v2 frames only.
+	OutExtensionsDisable bool
+
+	// (optional) a function that is called with every frame written with
+	// WriteMessage() or WriteFrame(), before it is encoded. It returns the
+	// frame to write, that is the same frame or a modified copy, or nil in
+	// order to discard the frame. The frame must not be modified in place.
+	// The checksum and the signature of frames written with WriteMessage() are
+	// computed after the function returns; the checksum of frames written with
+	// WriteFrame() is computed again only if the function returns a copy.
+	OutFrameHook func(frame.Frame) frame.Frame
+
+	// (optional) signs again V2 frames written with WriteFrame() with OutKey,
+	// or removes their signature if OutKey is nil, in order to route frames
+	// between links that use different keys. When a signature is added or
+	// removed, the checksum is computed again, and this requires the message
+	// to be in the dialect or in CRCExtras.
+	OutResign bool
+
+	// (optional) replaces the sequence id of frames written with WriteFrame()
+	// with the sequence id of the writer, that is shared with
+	// WriteMessage(), in order to route frames of multiple sources to a single
+	// link without confusing the loss detection of receivers. The frame passed
+	// to WriteFrame() is not modified. Since the checksum is computed again,
+	// only frames whose message is in the dialect or in CRCExtras are
+	// re-sequenced; signed frames are re-sequenced only when OutResign is
+	// enabled.
+	OutResequence bool
+
+	// (optional) a function that returns the current time, that is used to
+	// fill signature timestamps. It defaults to time.Now.
+	TimeNow func() time.Time
+}
+
+// Writer is a low-level Mavlink frame encoder that works with a io.Writer.
+// It fills, encodes and signs frames, without the need of a Node.
+type Writer struct {
+	conf               WriterConf
+	v2Received         *uint32 // atomic, provided by the Reader of a Transceiver
+	curWriteSequenceID byte
+}
+
+// NewWriter allocates a Writer. See WriterConf for the options.
+func NewWriter(conf WriterConf) (*Writer, error) {
+	if conf.OutVersion == VAuto {
+		return nil, fmt.Errorf("VAuto requires a Transceiver")
+	}
+	return newWriter(conf, nil)
+}
+
+func newWriter(conf WriterConf, v2Received *uint32) (*Writer, error) {
+	if conf.Writer == nil {
+		return nil, fmt.Errorf("Writer not provided")
+	}
+	if conf.OutVersion == 0 {
+		return nil, fmt.Errorf("OutVersion not provided")
+	}
+	if conf.OutSystemID < 1 {
+		return nil, fmt.Errorf("SystemID must be >= 1")
+	}
+	if conf.OutComponentID < 1 {
+		conf.OutComponentID = 1
+	}
+	if conf.OutKey != nil && conf.OutVersion != V2 {
+		return nil, fmt.Errorf("OutKey requires V2 frames")
+	}
+	if conf.TimeNow == nil {
+		conf.TimeNow = time.Now
+	}
+
+	return &Writer{
+		conf:       conf,
+		v2Received: v2Received,
+	}, nil
+}
+
+// OutVersion returns the version currently used to encode messages.
+// With VAuto, it is V1 until a V2 frame is read, then V2.
+func (p *Writer) OutVersion() Version {
+	if p.conf.OutVersion == VAuto {
+		if atomic.LoadUint32(p.v2Received) == 1 {
+			return V2
+		}
+		return V1
+	}
+	return p.conf.OutVersion
+}
+
+// WriteMessage writes a Message into the writer.
+// It must not be called by multiple routines in parallel.
+func (p *Writer) WriteMessage(m msg.Message) error {
+	var fr frame.Frame
+	if p.OutVersion() == V1 {
+		fr = &frame.V1Frame{Message: m}
+	} else {
+		fr = &frame.V2Frame{Message: m}
+	}
+	return p.writeFrameAndFill(fr)
+}
+
+func (p *Writer) writeFrameAndFill(fr frame.Frame) error {
+	if fr.GetMessage() == nil {
+		return fmt.Errorf("message is nil")
+	}
+
+	// do not touch the original frame, but work with a separate object
+	// in such way that the frame can be encoded by other parsers in parallel
+	safeFrame := fr.Clone()
+
+	// fill SequenceID, SystemID, ComponentID
+	switch ff := safeFrame.(type) {
+	case *frame.V1Frame:
+		ff.SequenceID = p.curWriteSequenceID
+		ff.SystemID = p.conf.OutSystemID
+		ff.ComponentID = p.conf.OutComponentID
+	case *frame.V2Frame:
+		ff.SequenceID = p.curWriteSequenceID
+		ff.SystemID = p.conf.OutSystemID
+		ff.ComponentID = p.conf.OutComponentID
+	}
+
+	// fill CompatibilityFlag, IncompatibilityFlag if v2
+	if ff, ok := safeFrame.(*frame.V2Frame); ok {
+		ff.CompatibilityFlag = p.conf.OutCompatibilityFlag
+		ff.IncompatibilityFlag = 0
+
+		if p.conf.OutKey != nil {
+			ff.IncompatibilityFlag |= frame.V2FlagSigned
+		}
+	}
+
+	if p.conf.OutFrameHook != nil {
+		safeFrame = p.conf.OutFrameHook(safeFrame)
+		if safeFrame == nil {
+			// discarded frames do not consume a sequence id
+			return nil
+		}
+		if safeFrame.GetMessage() == nil {
+			return fmt.Errorf("message is nil")
+		}
+	}
+	p.curWriteSequenceID++
+
+	// encode message if it is not already encoded
+	if _, ok := safeFrame.GetMessage().(*msg.MessageRaw); !ok {
+		if p.conf.DialectDE == nil {
+			return fmt.Errorf("message cannot be encoded since dialect is nil")
+		}
+
+		mp, ok := p.conf.DialectDE.MessageDEs[safeFrame.GetMessage().GetID()]
+		if !ok {
+			return fmt.Errorf("message cannot be encoded since it is not in the dialect")
+		}
+
+		bufp := bufferPool.Get().(*[]byte)
+
+		_, isV2 := safeFrame.(*frame.V2Frame)
+		byt, err := p.encodeMessage(mp, *bufp, safeFrame.GetMessage(), isV2)
+		if err != nil {
+			bufferPool.Put(bufp)
+			return err
+		}
+
+		msgRaw := &msg.MessageRaw{safeFrame.GetMessage().GetID(), byt} //nolint:govet
+
+		// the encoded message points to pooled storage, therefore it is
+		// detached before the buffer is returned to the pool.
+		defer func() {
+			msgRaw.Content = nil
+			bufferPool.Put(bufp)
+		}()
+		switch ff := safeFrame.(type) {
+		case *frame.V1Frame:
+			ff.Message = msgRaw
+		case *frame.V2Frame:
+			ff.Message = msgRaw
+		}
+
+		// fill checksum
+		switch ff := safeFrame.(type) {
+		case *frame.V1Frame:
+			ff.Checksum = ff.GenChecksum(p.conf.DialectDE.MessageDEs[ff.GetMessage().GetID()].CRCExtra())
+		case *frame.V2Frame:
+			ff.Checksum = ff.GenChecksum(p.conf.DialectDE.MessageDEs[ff.GetMessage().GetID()].CRCExtra())
+		}
+	} else if crcExtra, ok := p.crcExtra(safeFrame.GetMessage().GetID()); ok {
+		// fill checksum of already encoded messages
+		switch ff := safeFrame.(type) {
+		case *frame.V1Frame:
+			ff.Checksum = ff.GenChecksum(crcExtra)
+		case *frame.V2Frame:
+			ff.Checksum = ff.GenChecksum(crcExtra)
+		}
+	}
+
+	// fill SignatureLinkID, SignatureTimestamp, Signature if v2
+	if ff, ok := safeFrame.(*frame.V2Frame); ok && p.conf.OutKey != nil {
+		ff.SignatureLinkID = p.conf.OutSignatureLinkID
+		ff.SignatureTimestamp = p.signatureTimestamp()
+		ff.Signature = ff.GenSignature(p.conf.OutKey)
+	}
+
+	return p.writeFrame(safeFrame, false, false)
+}
+
+// signatureTimestamp returns the current time in 10 microsecond units since
+// 1st January 2015 GMT time.
+func (p *Writer) signatureTimestamp() uint64 {
+	return uint64(p.conf.TimeNow().Sub(signatureReferenceDate)) / 10000
+}
+
+// encodeMessage encodes a message, applying OutTruncationDisable and
+// OutExtensionsDisable.
+func (p *Writer) encodeMessage(mp *msg.DecEncoder, buf []byte, m msg.Message, isV2 bool) ([]byte, error) {
+	byt, err := mp.EncodeTo(buf, m, isV2)
+	if err != nil || !isV2 || (!p.conf.OutTruncationDisable && !p.conf.OutExtensionsDisable) {
+		return byt, err
+	}
+
+	// bytes removed by truncation are zeros and are still in the buffer.
+	byt = byt[:mp.SizeExtended()]
+
+	if p.conf.OutExtensionsDisable {
+		byt = byt[:mp.SizeNormal()]
+	}
+
+	if !p.conf.OutTruncationDisable {
+		end := len(byt)
+		for end > 1 && byt[end-1] == 0x00 {
+			end--
+		}
+		byt = byt[:end]
+	}
+
+	return byt, nil
+}
+
+// WriteFrame writes a Frame into the writer.
+// It must not be called by multiple routines in parallel.
+// This function is intended only for routing pre-existing frames to other nodes,
+// since all frame fields must be filled manually.
+func (p *Writer) WriteFrame(fr frame.Frame) error {
+	fillChecksum := false
+
+	if p.conf.OutFrameHook != nil {
+		out := p.conf.OutFrameHook(fr)
+		if out == nil {
+			return nil
+		}
+
+		// the frame has been modified, therefore its checksum is not valid anymore
+		if out != fr {
+			fr = out
+			fillChecksum = true
+		}
+	}
+
+	sign := false
+
+	if ff, ok := fr.(*frame.V2Frame); ok && p.conf.OutResign {
+		sign = p.conf.OutKey != nil
+
+		if sign || ff.IsSigned() {
+			wasSigned := ff.IsSigned()
+			ff = ff.Clone().(*frame.V2Frame)
+
+			if sign {
+				ff.IncompatibilityFlag |= frame.V2FlagSigned
+				ff.SignatureLinkID = p.conf.OutSignatureLinkID
+				ff.SignatureTimestamp = p.signatureTimestamp()
+			} else {
+				ff.IncompatibilityFlag &^= frame.V2FlagSigned
+				ff.SignatureLinkID = 0
+				ff.SignatureTimestamp = 0
+				ff.Signature = nil
+			}
+
+			// the incompatibility flag is covered by the checksum
+			if sign != wasSigned {
+				fillChecksum = true
+			}
+
+			fr = ff
+		}
+	}
+
+	if p.conf.OutResequence && p.canResequence(fr) {
+		fr = fr.Clone()
+		switch ff := fr.(type) {
+		case *frame.V1Frame:
+			ff.SequenceID = p.curWriteSequenceID
+		case *frame.V2Frame:
+			ff.SequenceID = p.curWriteSequenceID
+		}
+		p.curWriteSequenceID++
+
+		// the sequence id is covered by the checksum
+		fillChecksum = true
+	}
+
+	return p.writeFrame(fr, fillChecksum, sign)
+}
+
+// canResequence checks whether the sequence id of a frame can be replaced.
+func (p *Writer) canResequence(fr frame.Frame) bool {
+	if fr.GetMessage() == nil {
+		return false
+	}
+
+	if _, ok := p.crcExtra(fr.GetMessage().GetID()); !ok {
+		return false
+	}
+
+	// the signature covers the sequence id
+	if ff, ok := fr.(*frame.V2Frame); ok && ff.IsSigned() && !p.conf.OutResign {
+		return false
+	}
+
+	return true
+}
+
+// crcExtra returns the crc-extra of a message, that is taken from the dialect
+// or from CRCExtras.
+func (p *Writer) crcExtra(id uint32) (byte, bool) {
+	if p.conf.DialectDE != nil {
+		if mp, ok := p.conf.DialectDE.MessageDEs[id]; ok {
+			return mp.CRCExtra(), true
+		}
+	}
+
+	crcExtra, ok := p.conf.CRCExtras[id]
+	return crcExtra, ok
+}
+
+func (p *Writer) writeFrame(fr frame.Frame, fillChecksum bool, sign bool) error {
+	m := fr.GetMessage()
+	if m == nil {
+		return fmt.Errorf("message is nil")
+	}
+
+	// encode message if it is not already encoded
+	if _, ok := m.(*msg.MessageRaw); !ok {
+		if p.conf.DialectDE == nil {
+			return fmt.Errorf("message cannot be encoded since dialect is nil")
+		}
+
+		mp, ok := p.conf.DialectDE.MessageDEs[m.GetID()]
+		if !ok {
+			return fmt.Errorf("message cannot be encoded since it is not in the dialect")
+		}
+
+		bufp := bufferPool.Get().(*[]byte)
+
+		_, isV2 := fr.(*frame.V2Frame)
+		byt, err := mp.EncodeTo(*bufp, m, isV2)
+		if err != nil {
+			bufferPool.Put(bufp)
+			return err
+		}
+
+		// do not touch frame.Message
+		// in such way that the frame can be encoded by other parsers in parallel
+		msgRaw := &msg.MessageRaw{m.GetID(), byt} //nolint:govet
+		m = msgRaw
+
+		// the encoded message points to pooled storage, therefore it is
+		// detached before the buffer is returned to the pool.
+		defer func() {
+			msgRaw.Content = nil
+			bufferPool.Put(bufp)
+		}()
+	}
+
+	if fillChecksum || sign {
+		// work on a copy that contains the encoded message
+		fr = fr.Clone()
+		switch ff := fr.(type) {
+		case *frame.V1Frame:
+			ff.Message = m
+		case *frame.V2Frame:
+			ff.Message = m
+		}
+	}
+
+	if fillChecksum {
+		crcExtra, ok := p.crcExtra(m.GetID())
+		if !ok {
+			return fmt.Errorf("checksum cannot be computed since message is not in the dialect")
+		}
+
+		switch ff := fr.(type) {
+		case *frame.V1Frame:
+			ff.Checksum = ff.GenChecksum(crcExtra)
+		case *frame.V2Frame:
+			ff.Checksum = ff.GenChecksum(crcExtra)
+		}
+	}
+
+	// the signature covers the checksum, therefore it is computed afterwards
+	if sign {
+		ff := fr.(*frame.V2Frame)
+		ff.Signature = ff.GenSignature(p.conf.OutKey)
+	}
+
+	bufp := bufferPool.Get().(*[]byte)
+	defer bufferPool.Put(bufp)
+
+	buf, err := fr.Encode(*bufp, m.(*msg.MessageRaw).Content)
+	if err != nil {
+		return err
+	}
+
+	// do not check n, since io.Writer is not allowed to return n < len(buf)
+	// without throwing an error
+	_, err = p.conf.Writer.Write(buf)
+	return err
+}
@@ -10,6 +10,15 @@ import (
 // MTU is ~1500
 const bufferSize = 2048
 
+// bufferPool contains buffers used to read packets, shared between
+// listeners in order to reduce allocations.
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		buf := make([]byte, bufferSize)
+		return &buf
+	},
+}
+
 // implements net.Error
 type udpNetError struct {
 	str       string
@@ -47,7 +56,7 @@ type conn struct {
 	writeDeadline time.Time
 
 	// in
-	read chan []byte
+	read chan *[]byte
 }
 
 func newConn(listener *Listener, index connIndex, addr *net.UDPAddr) *conn {
@@ -55,7 +64,7 @@ func newConn(listener *Listener, index connIndex, addr *net.UDPAddr) *conn {
 		listener: listener,
 		index:    index,
 		addr:     addr,
-		read:     make(chan []byte),
+		read:     make(chan *[]byte),
 	}
 }
 
@@ -95,7 +104,7 @@ func (c *conn) Close() error {
 
 // Read implements the net.Conn interface.
 func (c *conn) Read(byt []byte) (int, error) {
-	var buf []byte
+	var bufp *[]byte
 	var ok bool
 
 	if !c.readDeadline.IsZero() {
@@ -105,19 +114,19 @@ func (c *conn) Read(byt []byte) (int, error) {
 		select {
 		case <-readTimer.C:
 			return 0, errTimeout
-		case buf, ok = <-c.read:
+		case bufp, ok = <-c.read:
 		}
 	} else {
-		buf, ok = <-c.read
+		bufp, ok = <-c.read
 	}
 
 	if !ok {
 		return 0, errTerminated
 	}
 
-	copy(byt, buf)
-	c.listener.readDone <- struct{}{}
-	return len(buf), nil
+	n := copy(byt, *bufp)
+	bufferPool.Put(bufp)
+	return n, nil
 }
 
 // Write implements the net.Conn interface.
@@ -161,8 +170,7 @@ type Listener struct {
 	writeMutex sync.Mutex
 	closed     bool
 
-	accept   chan net.Conn
-	readDone chan struct{}
+	accept chan net.Conn
 }
 
 // New allocates a Listener.
@@ -173,10 +181,9 @@ func New(network, address string) (net.Listener, error) {
 	}
 
 	l := &Listener{
-		pc:       pc,
-		conns:    make(map[connIndex]*conn),
-		accept:   make(chan net.Conn),
-		readDone: make(chan struct{}),
+		pc:     pc,
+		conns:  make(map[connIndex]*conn),
+		accept: make(chan net.Conn),
 	}
 
 	go l.reader()
@@ -212,13 +219,15 @@ func (l *Listener) Addr() net.Addr {
 }
 
 func (l *Listener) reader() {
-	buf := make([]byte, bufferSize)
-
 	for {
+		bufp := bufferPool.Get().(*[]byte)
+		*bufp = (*bufp)[:bufferSize]
+
 		// read WITHOUT deadline. Long periods without packets are normal since
 		// we're not directly connected to someone.
-		n, addr, err := l.pc.ReadFrom(buf)
+		n, addr, err := l.pc.ReadFrom(*bufp)
 		if err != nil {
+			bufferPool.Put(bufp)
 			break
 		}
 
@@ -236,6 +245,7 @@ func (l *Listener) reader() {
 
 			if !preExisting && l.closed {
 				// listener is closed, ignore new connection
+				bufferPool.Put(bufp)
 			} else {
 				if !preExisting {
 					conn = newConn(l, connIndex, uaddr)
@@ -243,11 +253,10 @@ func (l *Listener) reader() {
 					l.accept <- conn
 				}
 
-				// route buffer to connection
-				conn.read <- buf[:n]
-
-				// wait copy since buffer is shared
-				<-l.readDone
+				// route buffer to connection, that returns it to the pool
+				// once it has been copied
+				*bufp = (*bufp)[:n]
+				conn.read <- bufp
 			}
 		}()
 	}
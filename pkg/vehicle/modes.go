@@ -0,0 +1,133 @@
+package vehicle
+
+// mode is a flight mode. Ardupilot modes are identified by the custom mode
+// only; PX4 modes are identified by a main mode and a sub mode, that are
+// encoded into the custom mode of heartbeats.
+type mode struct {
+	name    string
+	main    uint32
+	sub     uint32
+	landing bool
+	rtl     bool
+}
+
+// https://github.com/ArduPilot/ardupilot/blob/master/ArduCopter/mode.h
+var modesArduCopter = []mode{
+	{name: "STABILIZE", main: 0},
+	{name: "ACRO", main: 1},
+	{name: "ALT_HOLD", main: 2},
+	{name: "AUTO", main: 3},
+	{name: "GUIDED", main: 4},
+	{name: "LOITER", main: 5},
+	{name: "RTL", main: 6, rtl: true},
+	{name: "CIRCLE", main: 7},
+	{name: "LAND", main: 9, landing: true},
+	{name: "DRIFT", main: 11},
+	{name: "SPORT", main: 13},
+	{name: "FLIP", main: 14},
+	{name: "AUTOTUNE", main: 15},
+	{name: "POSHOLD", main: 16},
+	{name: "BRAKE", main: 17},
+	{name: "THROW", main: 18},
+	{name: "AVOID_ADSB", main: 19},
+	{name: "GUIDED_NOGPS", main: 20},
+	{name: "SMART_RTL", main: 21},
+}
+
+// https://github.com/ArduPilot/ardupilot/blob/master/ArduPlane/mode.h
+var modesArduPlane = []mode{
+	{name: "MANUAL", main: 0},
+	{name: "CIRCLE", main: 1},
+	{name: "STABILIZE", main: 2},
+	{name: "TRAINING", main: 3},
+	{name: "ACRO", main: 4},
+	{name: "FBWA", main: 5},
+	{name: "FBWB", main: 6},
+	{name: "CRUISE", main: 7},
+	{name: "AUTOTUNE", main: 8},
+	{name: "AUTO", main: 10},
+	{name: "RTL", main: 11, rtl: true},
+	{name: "LOITER", main: 12},
+	{name: "TAKEOFF", main: 13},
+	{name: "GUIDED", main: 15},
+	{name: "QSTABILIZE", main: 17},
+	{name: "QHOVER", main: 18},
+	{name: "QLOITER", main: 19},
+	{name: "QLAND", main: 20, landing: true},
+	{name: "QRTL", main: 21},
+}
+
+// https://github.com/ArduPilot/ardupilot/blob/master/Rover/mode.h
+var modesArduRover = []mode{
+	{name: "MANUAL", main: 0},
+	{name: "ACRO", main: 1},
+	{name: "STEERING", main: 3},
+	{name: "HOLD", main: 4},
+	{name: "LOITER", main: 5},
+	{name: "FOLLOW", main: 6},
+	{name: "SIMPLE", main: 7},
+	{name: "AUTO", main: 10},
+	{name: "RTL", main: 11, rtl: true},
+	{name: "SMART_RTL", main: 12},
+	{name: "GUIDED", main: 15},
+}
+
+// https://github.com/PX4/PX4-Autopilot/blob/main/src/modules/commander/px4_custom_mode.h
+var modesPX4 = []mode{
+	{name: "MANUAL", main: 1},
+	{name: "ALTCTL", main: 2},
+	{name: "POSCTL", main: 3},
+	{name: "AUTO.TAKEOFF", main: 4, sub: 2},
+	{name: "AUTO.LOITER", main: 4, sub: 3},
+	{name: "AUTO.MISSION", main: 4, sub: 4},
+	{name: "AUTO.RTL", main: 4, sub: 5, rtl: true},
+	{name: "AUTO.LAND", main: 4, sub: 6, landing: true},
+	{name: "AUTO.FOLLOW_TARGET", main: 4, sub: 8},
+	{name: "AUTO.PRECLAND", main: 4, sub: 9},
+	{name: "ACRO", main: 5},
+	{name: "OFFBOARD", main: 6},
+	{name: "STABILIZED", main: 7},
+}
+
+func flavorModes(f Flavor) []mode {
+	switch f {
+	case FlavorArduCopter:
+		return modesArduCopter
+
+	case FlavorArduPlane:
+		return modesArduPlane
+
+	case FlavorArduRover:
+		return modesArduRover
+
+	case FlavorPX4:
+		return modesPX4
+	}
+	return nil
+}
+
+func findMode(f Flavor, match func(m *mode) bool) *mode {
+	modes := flavorModes(f)
+	for i := range modes {
+		if match(&modes[i]) {
+			return &modes[i]
+		}
+	}
+	return nil
+}
+
+// modeFromHeartbeat finds the mode advertised by a heartbeat.
+func modeFromHeartbeat(f Flavor, customMode uint32) *mode {
+	if f == FlavorPX4 {
+		mainMode := (customMode >> 16) & 0xFF
+		subMode := (customMode >> 24) & 0xFF
+		if m := findMode(f, func(m *mode) bool { return m.main == mainMode && m.sub == subMode }); m != nil {
+			return m
+		}
+
+		// sub modes are not always filled
+		return findMode(f, func(m *mode) bool { return m.main == mainMode && m.sub == 0 })
+	}
+
+	return findMode(f, func(m *mode) bool { return m.main == customMode })
+}
@@ -0,0 +1,368 @@
+// Package vehicle provides a high-level API to control a vehicle, that
+// allows to arm, take off, land, move and change flight mode, and supports
+// Ardupilot (Copter, Plane, Rover) and PX4 flight stacks.
+//
+// The state of the vehicle is tracked from received frames, that must be
+// passed to the Vehicle with OnEventFrame:
+//
+//	for evt := range node.Events() {
+//		if frm, ok := evt.(*gomavlib.EventFrame); ok {
+//			v.OnEventFrame(frm)
+//		}
+//	}
+//
+// Since commands are acknowledged through the same events, methods that
+// send commands must not be called by the routine that reads events.
+package vehicle
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"sync"
+
+	"github.com/aler9/gomavlib"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+// Flavor is the flight stack of a vehicle.
+type Flavor int
+
+// flavors.
+const (
+	FlavorUnknown Flavor = iota
+	FlavorArduCopter
+	FlavorArduPlane
+	FlavorArduRover
+	FlavorPX4
+)
+
+// String implements fmt.Stringer.
+func (f Flavor) String() string {
+	switch f {
+	case FlavorArduCopter:
+		return "ArduCopter"
+
+	case FlavorArduPlane:
+		return "ArduPlane"
+
+	case FlavorArduRover:
+		return "ArduRover"
+
+	case FlavorPX4:
+		return "PX4"
+	}
+	return "unknown"
+}
+
+// flavorFromHeartbeat detects the flight stack from the MAV_AUTOPILOT and
+// MAV_TYPE fields of a heartbeat.
+func flavorFromHeartbeat(autopilot int, typ int) Flavor {
+	switch autopilot {
+	case 3: // MAV_AUTOPILOT_ARDUPILOTMEGA
+		switch typ {
+		case 2, 3, 4, 13, 14, 15, 29: // multicopters and helicopters
+			return FlavorArduCopter
+
+		case 1, 19, 20, 21, 22, 23, 24, 25: // fixed wings and VTOLs
+			return FlavorArduPlane
+
+		case 10, 11: // ground rovers and boats
+			return FlavorArduRover
+		}
+
+	case 12: // MAV_AUTOPILOT_PX4
+		return FlavorPX4
+	}
+	return FlavorUnknown
+}
+
+// Conf allows to configure a Vehicle.
+type Conf struct {
+	// the node used to communicate.
+	// Its dialect must contain the COMMAND_* messages.
+	Node *gomavlib.Node
+	// the autopilot of the vehicle.
+	Target gomavlib.Target
+
+	// (optional) the flight stack of the vehicle.
+	// By default, it is detected from heartbeats.
+	Flavor Flavor
+}
+
+// State is the state of a vehicle.
+type State struct {
+	// the flight stack
+	Flavor Flavor
+	// whether the vehicle is armed
+	Armed bool
+	// the name of the current flight mode, or an empty string if unknown
+	Mode string
+
+	// whether the position of the vehicle has been received
+	PositionKnown bool
+	// latitude, in degrees
+	Latitude float64
+	// longitude, in degrees
+	Longitude float64
+	// altitude above mean sea level, in meters
+	Altitude float32
+	// altitude above home, in meters
+	RelativeAltitude float32
+
+	// whether the home position has been received
+	HomeKnown bool
+	// altitude of the home position above mean sea level, in meters
+	HomeAltitude float32
+}
+
+// Vehicle allows to control a vehicle.
+type Vehicle struct {
+	conf Conf
+
+	mutex sync.Mutex
+	state State
+}
+
+// New allocates a Vehicle. See Conf for the options.
+func New(conf Conf) (*Vehicle, error) {
+	if conf.Node == nil {
+		return nil, fmt.Errorf("Node not provided")
+	}
+
+	return &Vehicle{
+		conf: conf,
+		state: State{
+			Flavor: conf.Flavor,
+		},
+	}, nil
+}
+
+func (v *Vehicle) matches(evt *gomavlib.EventFrame) bool {
+	t := v.conf.Target
+	return (t.Channel == nil || t.Channel == evt.Channel) &&
+		t.SystemID == evt.SystemID() &&
+		t.ComponentID == evt.ComponentID()
+}
+
+func messageGetInt(m msg.Message, name string) int64 {
+	f := reflect.ValueOf(m).Elem().FieldByName(name)
+	switch f.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(f.Uint())
+	}
+	return f.Int()
+}
+
+// OnEventFrame updates the state of the vehicle.
+// It must be called for every *gomavlib.EventFrame received from the node.
+func (v *Vehicle) OnEventFrame(evt *gomavlib.EventFrame) {
+	if !v.matches(evt) {
+		return
+	}
+
+	m := evt.Message()
+	if _, ok := m.(*msg.MessageRaw); ok {
+		return
+	}
+
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	switch m.GetID() {
+	case 0: // HEARTBEAT
+		if v.conf.Flavor == FlavorUnknown {
+			v.state.Flavor = flavorFromHeartbeat(int(messageGetInt(m, "Autopilot")),
+				int(messageGetInt(m, "Type")))
+		}
+
+		v.state.Armed = (messageGetInt(m, "BaseMode") & 128) != 0 // MAV_MODE_FLAG_SAFETY_ARMED
+
+		v.state.Mode = ""
+		if md := modeFromHeartbeat(v.state.Flavor, uint32(messageGetInt(m, "CustomMode"))); md != nil {
+			v.state.Mode = md.name
+		}
+
+	case 33: // GLOBAL_POSITION_INT
+		v.state.PositionKnown = true
+		v.state.Latitude = float64(messageGetInt(m, "Lat")) / 1e7
+		v.state.Longitude = float64(messageGetInt(m, "Lon")) / 1e7
+		v.state.Altitude = float32(messageGetInt(m, "Alt")) / 1000
+		v.state.RelativeAltitude = float32(messageGetInt(m, "RelativeAlt")) / 1000
+
+	case 242: // HOME_POSITION
+		v.state.HomeKnown = true
+		v.state.HomeAltitude = float32(messageGetInt(m, "Altitude")) / 1000
+	}
+}
+
+// State returns the state of the vehicle.
+func (v *Vehicle) State() State {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	return v.state
+}
+
+// Modes returns the names of the flight modes supported by the vehicle.
+func (v *Vehicle) Modes() []string {
+	modes := flavorModes(v.State().Flavor)
+
+	ret := make([]string, len(modes))
+	for i, m := range modes {
+		ret[i] = m.name
+	}
+	return ret
+}
+
+func (v *Vehicle) flavor() (Flavor, error) {
+	f := v.State().Flavor
+	if f == FlavorUnknown {
+		return 0, fmt.Errorf("flight stack of the vehicle is unknown")
+	}
+	return f, nil
+}
+
+func (v *Vehicle) command(ctx context.Context, command int, params ...float32) error {
+	res, err := v.conf.Node.SendCommand(ctx, v.conf.Target, command, params...)
+	if err != nil {
+		return err
+	}
+	if res != gomavlib.CommandResultAccepted {
+		return fmt.Errorf("command %d: %s", command, res)
+	}
+	return nil
+}
+
+func (v *Vehicle) commandInt(ctx context.Context, command int, p gomavlib.CommandIntParams) error {
+	res, err := v.conf.Node.SendCommandInt(ctx, v.conf.Target, command, p)
+	if err != nil {
+		return err
+	}
+	if res != gomavlib.CommandResultAccepted {
+		return fmt.Errorf("command %d: %s", command, res)
+	}
+	return nil
+}
+
+// Arm arms the vehicle.
+func (v *Vehicle) Arm(ctx context.Context) error {
+	return v.command(ctx, 400, 1) // MAV_CMD_COMPONENT_ARM_DISARM
+}
+
+// Disarm disarms the vehicle.
+func (v *Vehicle) Disarm(ctx context.Context) error {
+	return v.command(ctx, 400, 0) // MAV_CMD_COMPONENT_ARM_DISARM
+}
+
+func (v *Vehicle) setMode(ctx context.Context, m *mode) error {
+	// MAV_CMD_DO_SET_MODE, MAV_MODE_FLAG_CUSTOM_MODE_ENABLED
+	return v.command(ctx, 176, 1, float32(m.main), float32(m.sub))
+}
+
+// SetMode changes the flight mode of the vehicle. The mode is one of the
+// names returned by Modes.
+func (v *Vehicle) SetMode(ctx context.Context, name string) error {
+	f, err := v.flavor()
+	if err != nil {
+		return err
+	}
+
+	m := findMode(f, func(m *mode) bool { return m.name == name })
+	if m == nil {
+		return fmt.Errorf("mode %s is not supported by %s", name, f)
+	}
+
+	return v.setMode(ctx, m)
+}
+
+// Takeoff takes off to the given altitude above home, in meters.
+// The vehicle must be armed. It is supported by ArduCopter and PX4;
+// PX4 requires the home position.
+func (v *Vehicle) Takeoff(ctx context.Context, altitude float32) error {
+	f, err := v.flavor()
+	if err != nil {
+		return err
+	}
+
+	nan := float32(math.NaN())
+
+	switch f {
+	case FlavorArduCopter:
+		err := v.SetMode(ctx, "GUIDED")
+		if err != nil {
+			return err
+		}
+
+		return v.command(ctx, 22, 0, 0, 0, 0, 0, 0, altitude) // MAV_CMD_NAV_TAKEOFF
+
+	case FlavorPX4:
+		// PX4 expects an altitude above mean sea level
+		state := v.State()
+		if !state.HomeKnown {
+			return fmt.Errorf("home position is unknown")
+		}
+
+		return v.command(ctx, 22, nan, 0, 0, nan, nan, nan, state.HomeAltitude+altitude)
+	}
+
+	return fmt.Errorf("takeoff is not supported by %s", f)
+}
+
+// Land lands the vehicle at its current position.
+func (v *Vehicle) Land(ctx context.Context) error {
+	f, err := v.flavor()
+	if err != nil {
+		return err
+	}
+
+	m := findMode(f, func(m *mode) bool { return m.landing })
+	if m == nil {
+		return fmt.Errorf("landing is not supported by %s", f)
+	}
+
+	return v.setMode(ctx, m)
+}
+
+// ReturnToLaunch brings the vehicle back to its home position.
+func (v *Vehicle) ReturnToLaunch(ctx context.Context) error {
+	f, err := v.flavor()
+	if err != nil {
+		return err
+	}
+
+	return v.setMode(ctx, findMode(f, func(m *mode) bool { return m.rtl }))
+}
+
+// Goto moves the vehicle to the given position, expressed in degrees, and
+// altitude above home, in meters. PX4 requires the home position.
+func (v *Vehicle) Goto(ctx context.Context, latitude float64, longitude float64, altitude float32) error {
+	f, err := v.flavor()
+	if err != nil {
+		return err
+	}
+
+	p := gomavlib.CommandIntParams{
+		Param1: -1, // default speed
+		Param2: 1,  // MAV_DO_REPOSITION_FLAGS_CHANGE_MODE
+		Param4: float32(math.NaN()),
+		X:      int32(math.Round(latitude * 1e7)),
+		Y:      int32(math.Round(longitude * 1e7)),
+		Z:      altitude,
+	}
+
+	if f == FlavorPX4 {
+		// PX4 expects an altitude above mean sea level
+		state := v.State()
+		if !state.HomeKnown {
+			return fmt.Errorf("home position is unknown")
+		}
+
+		p.Frame = 0 // MAV_FRAME_GLOBAL
+		p.Z = state.HomeAltitude + altitude
+	} else {
+		p.Frame = 3 // MAV_FRAME_GLOBAL_RELATIVE_ALT
+	}
+
+	return v.commandInt(ctx, 192, p) // MAV_CMD_DO_REPOSITION
+}
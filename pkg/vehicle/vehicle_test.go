@@ -0,0 +1,212 @@
+package vehicle
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib"
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+)
+
+type testVehicle struct {
+	gcs      *gomavlib.Node
+	node     *gomavlib.Node
+	commands chan interface{}
+}
+
+func newTestVehicle(t *testing.T, heartbeat *common.MessageHeartbeat) (*testVehicle, *Vehicle) {
+	c1, c2 := net.Pipe()
+
+	gcs, err := gomavlib.NewNode(gomavlib.NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  gomavlib.V2,
+		OutSystemID: 255,
+		Endpoints: []gomavlib.EndpointConf{
+			gomavlib.EndpointCustom{ReadWriteCloser: c1},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+
+	node, err := gomavlib.NewNode(gomavlib.NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  gomavlib.V2,
+		OutSystemID: 1,
+		Endpoints: []gomavlib.EndpointConf{
+			gomavlib.EndpointCustom{ReadWriteCloser: c2},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+
+	tv := &testVehicle{
+		gcs:      gcs,
+		node:     node,
+		commands: make(chan interface{}, 10),
+	}
+
+	v, err := New(Conf{
+		Node: gcs,
+		Target: gomavlib.Target{
+			SystemID:    1,
+			ComponentID: 1,
+		},
+	})
+	require.NoError(t, err)
+
+	stateReceived := make(chan struct{})
+
+	go func() {
+		for evt := range gcs.Events() {
+			if frm, ok := evt.(*gomavlib.EventFrame); ok {
+				v.OnEventFrame(frm)
+				if frm.Message().GetID() == 242 {
+					close(stateReceived)
+				}
+			}
+		}
+	}()
+
+	// a vehicle that accepts every command
+	go func() {
+		for evt := range node.Events() {
+			frm, ok := evt.(*gomavlib.EventFrame)
+			if !ok {
+				continue
+			}
+
+			switch m := frm.Message().(type) {
+			case *common.MessageCommandLong:
+				tv.commands <- m
+				node.WriteMessageAll(&common.MessageCommandAck{Command: m.Command})
+
+			case *common.MessageCommandInt:
+				tv.commands <- m
+				node.WriteMessageAll(&common.MessageCommandAck{Command: m.Command})
+			}
+		}
+	}()
+
+	node.WriteMessageAll(heartbeat)
+	node.WriteMessageAll(&common.MessageGlobalPositionInt{
+		Lat:         450000000,
+		Lon:         90000000,
+		Alt:         110000,
+		RelativeAlt: 10000,
+	})
+	node.WriteMessageAll(&common.MessageHomePosition{
+		Altitude: 100000,
+	})
+	<-stateReceived
+
+	return tv, v
+}
+
+func (tv *testVehicle) close() {
+	tv.gcs.Close()
+	tv.node.Close()
+}
+
+func TestVehicleArduCopter(t *testing.T) {
+	tv, v := newTestVehicle(t, &common.MessageHeartbeat{
+		Type:       common.MAV_TYPE_QUADROTOR,
+		Autopilot:  common.MAV_AUTOPILOT_ARDUPILOTMEGA,
+		BaseMode:   common.MAV_MODE_FLAG_SAFETY_ARMED | common.MAV_MODE_FLAG_CUSTOM_MODE_ENABLED,
+		CustomMode: 5,
+	})
+	defer tv.close()
+
+	require.Equal(t, State{
+		Flavor:           FlavorArduCopter,
+		Armed:            true,
+		Mode:             "LOITER",
+		PositionKnown:    true,
+		Latitude:         45,
+		Longitude:        9,
+		Altitude:         110,
+		RelativeAltitude: 10,
+		HomeKnown:        true,
+		HomeAltitude:     100,
+	}, v.State())
+	require.Contains(t, v.Modes(), "GUIDED")
+
+	err := v.Arm(context.Background())
+	require.NoError(t, err)
+	m := (<-tv.commands).(*common.MessageCommandLong)
+	require.Equal(t, common.MAV_CMD_COMPONENT_ARM_DISARM, m.Command)
+	require.Equal(t, float32(1), m.Param1)
+
+	err = v.Takeoff(context.Background(), 20)
+	require.NoError(t, err)
+	m = (<-tv.commands).(*common.MessageCommandLong)
+	require.Equal(t, common.MAV_CMD_DO_SET_MODE, m.Command)
+	require.Equal(t, float32(4), m.Param2)
+	m = (<-tv.commands).(*common.MessageCommandLong)
+	require.Equal(t, common.MAV_CMD_NAV_TAKEOFF, m.Command)
+	require.Equal(t, float32(20), m.Param7)
+
+	err = v.Goto(context.Background(), 45.1, 9.2, 30)
+	require.NoError(t, err)
+	mi := (<-tv.commands).(*common.MessageCommandInt)
+	require.Equal(t, common.MAV_CMD_DO_REPOSITION, mi.Command)
+	require.Equal(t, common.MAV_FRAME_GLOBAL_RELATIVE_ALT, mi.Frame)
+	require.Equal(t, int32(451000000), mi.X)
+	require.Equal(t, int32(92000000), mi.Y)
+	require.Equal(t, float32(30), mi.Z)
+
+	err = v.Land(context.Background())
+	require.NoError(t, err)
+	m = (<-tv.commands).(*common.MessageCommandLong)
+	require.Equal(t, common.MAV_CMD_DO_SET_MODE, m.Command)
+	require.Equal(t, float32(9), m.Param2)
+
+	err = v.SetMode(context.Background(), "AUTO.MISSION")
+	require.EqualError(t, err, "mode AUTO.MISSION is not supported by ArduCopter")
+}
+
+func TestVehiclePX4(t *testing.T) {
+	tv, v := newTestVehicle(t, &common.MessageHeartbeat{
+		Type:       common.MAV_TYPE_QUADROTOR,
+		Autopilot:  common.MAV_AUTOPILOT_PX4,
+		BaseMode:   common.MAV_MODE_FLAG_CUSTOM_MODE_ENABLED,
+		CustomMode: 4<<16 | 4<<24,
+	})
+	defer tv.close()
+
+	state := v.State()
+	require.Equal(t, FlavorPX4, state.Flavor)
+	require.Equal(t, false, state.Armed)
+	require.Equal(t, "AUTO.MISSION", state.Mode)
+
+	err := v.Takeoff(context.Background(), 20)
+	require.NoError(t, err)
+	m := (<-tv.commands).(*common.MessageCommandLong)
+	require.Equal(t, common.MAV_CMD_NAV_TAKEOFF, m.Command)
+	require.Equal(t, float32(120), m.Param7)
+
+	err = v.Goto(context.Background(), 45.1, 9.2, 30)
+	require.NoError(t, err)
+	mi := (<-tv.commands).(*common.MessageCommandInt)
+	require.Equal(t, common.MAV_FRAME_GLOBAL, mi.Frame)
+	require.Equal(t, float32(130), mi.Z)
+
+	err = v.ReturnToLaunch(context.Background())
+	require.NoError(t, err)
+	m = (<-tv.commands).(*common.MessageCommandLong)
+	require.Equal(t, common.MAV_CMD_DO_SET_MODE, m.Command)
+	require.Equal(t, []float32{1, 4, 5}, []float32{m.Param1, m.Param2, m.Param3})
+}
+
+func TestVehicleUnknownFlavor(t *testing.T) {
+	tv, v := newTestVehicle(t, &common.MessageHeartbeat{
+		Type:      common.MAV_TYPE_QUADROTOR,
+		Autopilot: common.MAV_AUTOPILOT_GENERIC,
+	})
+	defer tv.close()
+
+	err := v.Land(context.Background())
+	require.EqualError(t, err, "flight stack of the vehicle is unknown")
+}
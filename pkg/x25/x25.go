@@ -1,6 +1,33 @@
-// Package x25 implements the X25 hash.
+// Package x25 implements the X25 hash (CRC-16/MCRF4XX).
 package x25
 
+// Init is the initial value of the checksum.
+const Init = 0xFFFF
+
+var table = func() [256]uint16 {
+	var t [256]uint16
+	for i := range t {
+		tmp := uint16(i)
+		tmp ^= (tmp << 4)
+		tmp &= 0xFF
+		t[i] = (tmp << 8) ^ (tmp << 3) ^ (tmp >> 4)
+	}
+	return t
+}()
+
+// Update returns the result of adding the bytes in p to crc.
+func Update(crc uint16, p []byte) uint16 {
+	for _, b := range p {
+		crc = (crc >> 8) ^ table[byte(crc)^b]
+	}
+	return crc
+}
+
+// Checksum returns the checksum of p.
+func Checksum(p []byte) uint16 {
+	return Update(Init, p)
+}
+
 // X25 is the hash used to compute Frame checksums.
 type X25 struct {
 	crc uint16
@@ -15,7 +42,7 @@ func New() *X25 {
 
 // Reset resets the Hash to its initial state.
 func (x *X25) Reset() {
-	x.crc = 0xFFFF
+	x.crc = Init
 }
 
 // Size returns the number of bytes Sum will return.
@@ -33,16 +60,11 @@ func (x *X25) BlockSize() int {
 
 // Write adds more data to the running hash.
 func (x *X25) Write(p []byte) (int, error) {
-	for _, b := range p {
-		tmp := uint16(b) ^ (x.crc & 0xFF)
-		tmp ^= (tmp << 4)
-		tmp &= 0xFF
-		x.crc = (x.crc >> 8) ^ (tmp << 8) ^ (tmp << 3) ^ (tmp >> 4)
-	}
+	x.crc = Update(x.crc, p)
 	return len(p), nil
 }
 
-// Sum16 returns the curren thash.
+// Sum16 returns the current hash.
 func (x *X25) Sum16() uint16 {
 	return x.crc
 }
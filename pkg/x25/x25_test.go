@@ -25,5 +25,38 @@ func TestX25(t *testing.T) {
 		h.Write(in)
 		out := h.Sum16()
 		require.Equal(t, outs[i], out)
+
+		require.Equal(t, outs[i], Checksum(in))
+	}
+}
+
+func referenceUpdate(crc uint16, p []byte) uint16 {
+	for _, b := range p {
+		tmp := uint16(b) ^ (crc & 0xFF)
+		tmp ^= (tmp << 4)
+		tmp &= 0xFF
+		crc = (crc >> 8) ^ (tmp << 8) ^ (tmp << 3) ^ (tmp >> 4)
+	}
+	return crc
+}
+
+func TestUpdate(t *testing.T) {
+	buf := make([]byte, 256)
+	for i := range buf {
+		buf[i] = byte(i * 7)
+	}
+
+	crc := uint16(Init)
+	for i := 0; i < len(buf); i += 16 {
+		crc = Update(crc, buf[i:i+16])
+	}
+	require.Equal(t, referenceUpdate(Init, buf), crc)
+}
+
+func BenchmarkChecksum(b *testing.B) {
+	buf := make([]byte, 280)
+	b.SetBytes(int64(len(buf)))
+	for i := 0; i < b.N; i++ {
+		Checksum(buf)
 	}
 }
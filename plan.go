@@ -0,0 +1,348 @@
+package gomavlib
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+)
+
+// commands used by fence and rally plans (MAV_CMD).
+const (
+	planCmdFenceVertexInclusion = 5001
+	planCmdFenceVertexExclusion = 5002
+	planCmdFenceCircleInclusion = 5003
+	planCmdFenceCircleExclusion = 5004
+	planCmdRallyPoint           = 5100
+)
+
+// frames used by fence and rally plans (MAV_FRAME).
+const (
+	planFrameGlobal            = 0
+	planFrameGlobalRelativeAlt = 3
+)
+
+// Plan is a set of mission, fence and rally items, that can be imported from
+// and exported to QGroundControl .plan files, and transferred with a
+// MissionClient or a MissionServer.
+type Plan struct {
+	// the mission items (MissionTypeMission)
+	Mission []*MissionItem
+	// the fence items (MissionTypeFence)
+	Fence []*MissionItem
+	// the rally points (MissionTypeRally)
+	Rally []*MissionItem
+
+	// the planned home position, in degrees and meters above mean sea level
+	HomeLatitude  float64
+	HomeLongitude float64
+	HomeAltitude  float64
+}
+
+type qgcPlanSimpleItem struct {
+	AutoContinue bool        `json:"autoContinue"`
+	Command      int         `json:"command"`
+	DoJumpID     int         `json:"doJumpId"`
+	Frame        int         `json:"frame"`
+	Params       [7]*float64 `json:"params"`
+	Type         string      `json:"type"`
+}
+
+type qgcPlanItem struct {
+	qgcPlanSimpleItem
+	ComplexItemType          string `json:"complexItemType,omitempty"`
+	TransectStyleComplexItem *struct {
+		Items []qgcPlanItem `json:"Items"`
+	} `json:"TransectStyleComplexItem,omitempty"`
+	StructureScanItems []qgcPlanItem `json:"Items,omitempty"`
+}
+
+type qgcPlanPolygon struct {
+	Inclusion bool         `json:"inclusion"`
+	Polygon   [][2]float64 `json:"polygon"`
+	Version   int          `json:"version"`
+}
+
+type qgcPlanCircle struct {
+	Circle struct {
+		Center [2]float64 `json:"center"`
+		Radius float64    `json:"radius"`
+	} `json:"circle"`
+	Inclusion bool `json:"inclusion"`
+	Version   int  `json:"version"`
+}
+
+type qgcPlan struct {
+	FileType      string `json:"fileType"`
+	GroundStation string `json:"groundStation"`
+	Version       int    `json:"version"`
+	Mission       struct {
+		Items               []qgcPlanItem `json:"items"`
+		PlannedHomePosition [3]float64    `json:"plannedHomePosition"`
+		Version             int           `json:"version"`
+	} `json:"mission"`
+	GeoFence struct {
+		Circles      []qgcPlanCircle  `json:"circles"`
+		Polygons     []qgcPlanPolygon `json:"polygons"`
+		BreachReturn *[3]float64      `json:"breachReturn,omitempty"`
+		Version      int              `json:"version"`
+	} `json:"geoFence"`
+	RallyPoints struct {
+		Points  [][3]float64 `json:"points"`
+		Version int          `json:"version"`
+	} `json:"rallyPoints"`
+}
+
+// planIsGlobalFrame checks whether the coordinates of a frame are latitude
+// and longitude.
+func planIsGlobalFrame(frame int) bool {
+	switch frame {
+	case 0, 3, 5, 6, 10, 11:
+		return true
+	}
+	return false
+}
+
+func planDegToInt(v float64) int32 {
+	return int32(math.Round(v * 1e7))
+}
+
+func planIntToDeg(v int32) float64 {
+	return float64(v) / 1e7
+}
+
+func planParam(v *float64) float32 {
+	if v == nil {
+		return float32(math.NaN())
+	}
+	return float32(*v)
+}
+
+func planParamPtr(v float64) *float64 {
+	if math.IsNaN(v) {
+		return nil
+	}
+	return &v
+}
+
+func planDecodeItem(it *qgcPlanSimpleItem) *MissionItem {
+	item := &MissionItem{
+		Frame:        it.Frame,
+		Command:      it.Command,
+		Autocontinue: it.AutoContinue,
+		Param1:       planParam(it.Params[0]),
+		Param2:       planParam(it.Params[1]),
+		Param3:       planParam(it.Params[2]),
+		Param4:       planParam(it.Params[3]),
+		Z:            planParam(it.Params[6]),
+	}
+
+	var x, y float64
+	if it.Params[4] != nil {
+		x = *it.Params[4]
+	}
+	if it.Params[5] != nil {
+		y = *it.Params[5]
+	}
+
+	if planIsGlobalFrame(it.Frame) {
+		item.X = planDegToInt(x)
+		item.Y = planDegToInt(y)
+	} else {
+		item.X = int32(x)
+		item.Y = int32(y)
+	}
+
+	return item
+}
+
+func planDecodeItems(items []qgcPlanItem) ([]*MissionItem, error) {
+	var ret []*MissionItem
+
+	for i := range items {
+		it := &items[i]
+
+		switch it.Type {
+		case "SimpleItem":
+			ret = append(ret, planDecodeItem(&it.qgcPlanSimpleItem))
+
+		case "ComplexItem":
+			var sub []qgcPlanItem
+			switch {
+			case it.TransectStyleComplexItem != nil:
+				sub = it.TransectStyleComplexItem.Items
+			case it.StructureScanItems != nil:
+				sub = it.StructureScanItems
+			default:
+				return nil, fmt.Errorf("unsupported complex item: %s", it.ComplexItemType)
+			}
+
+			subItems, err := planDecodeItems(sub)
+			if err != nil {
+				return nil, err
+			}
+			ret = append(ret, subItems...)
+
+		default:
+			return nil, fmt.Errorf("unsupported item type: %s", it.Type)
+		}
+	}
+
+	return ret, nil
+}
+
+// PlanDecode reads a QGroundControl .plan file.
+// Complex items, like surveys, are expanded into the simple items they
+// consist of.
+func PlanDecode(r io.Reader) (*Plan, error) {
+	var in qgcPlan
+	err := json.NewDecoder(r).Decode(&in)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.FileType != "Plan" {
+		return nil, fmt.Errorf("file is not a plan")
+	}
+
+	p := &Plan{
+		HomeLatitude:  in.Mission.PlannedHomePosition[0],
+		HomeLongitude: in.Mission.PlannedHomePosition[1],
+		HomeAltitude:  in.Mission.PlannedHomePosition[2],
+	}
+
+	p.Mission, err = planDecodeItems(in.Mission.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	var fence Fence
+	for _, poly := range in.GeoFence.Polygons {
+		fp := FencePolygon{Inclusion: poly.Inclusion}
+		for _, v := range poly.Polygon {
+			fp.Vertices = append(fp.Vertices, FencePoint{v[0], v[1]})
+		}
+		fence.Polygons = append(fence.Polygons, fp)
+	}
+	for _, c := range in.GeoFence.Circles {
+		fence.Circles = append(fence.Circles, FenceCircle{
+			Inclusion: c.Inclusion,
+			Center:    FencePoint{c.Circle.Center[0], c.Circle.Center[1]},
+			Radius:    float32(c.Circle.Radius),
+		})
+	}
+	if r := in.GeoFence.BreachReturn; r != nil {
+		fence.ReturnPoint = &FencePoint{r[0], r[1]}
+	}
+
+	p.Fence, err = FenceEncode(&fence)
+	if err != nil {
+		return nil, err
+	}
+
+	var rally []RallyPoint
+	for _, pt := range in.RallyPoints.Points {
+		rally = append(rally, RallyPoint{
+			Latitude:  pt[0],
+			Longitude: pt[1],
+			Altitude:  float32(pt[2]),
+		})
+	}
+	p.Rally = RallyEncode(rally)
+
+	return p, nil
+}
+
+func planEncodeItem(item *MissionItem, jumpID int) qgcPlanItem {
+	var x, y float64
+	if planIsGlobalFrame(item.Frame) {
+		x = planIntToDeg(item.X)
+		y = planIntToDeg(item.Y)
+	} else {
+		x = float64(item.X)
+		y = float64(item.Y)
+	}
+
+	return qgcPlanItem{
+		qgcPlanSimpleItem: qgcPlanSimpleItem{
+			AutoContinue: item.Autocontinue,
+			Command:      item.Command,
+			DoJumpID:     jumpID,
+			Frame:        item.Frame,
+			Params: [7]*float64{
+				planParamPtr(float64(item.Param1)),
+				planParamPtr(float64(item.Param2)),
+				planParamPtr(float64(item.Param3)),
+				planParamPtr(float64(item.Param4)),
+				planParamPtr(x),
+				planParamPtr(y),
+				planParamPtr(float64(item.Z)),
+			},
+			Type: "SimpleItem",
+		},
+	}
+}
+
+// PlanEncode writes a QGroundControl .plan file.
+func PlanEncode(w io.Writer, p *Plan) error {
+	var out qgcPlan
+	out.FileType = "Plan"
+	out.GroundStation = "gomavlib"
+	out.Version = 1
+	out.Mission.Version = 2
+	out.Mission.Items = []qgcPlanItem{}
+	out.Mission.PlannedHomePosition = [3]float64{p.HomeLatitude, p.HomeLongitude, p.HomeAltitude}
+	out.GeoFence.Version = 2
+	out.GeoFence.Circles = []qgcPlanCircle{}
+	out.GeoFence.Polygons = []qgcPlanPolygon{}
+	out.RallyPoints.Version = 2
+	out.RallyPoints.Points = [][3]float64{}
+
+	for i, item := range p.Mission {
+		out.Mission.Items = append(out.Mission.Items, planEncodeItem(item, i+1))
+	}
+
+	fence, err := FenceDecode(p.Fence)
+	if err != nil {
+		return err
+	}
+
+	for _, fp := range fence.Polygons {
+		poly := qgcPlanPolygon{
+			Inclusion: fp.Inclusion,
+			Version:   1,
+		}
+		for _, v := range fp.Vertices {
+			poly.Polygon = append(poly.Polygon, [2]float64{v.Latitude, v.Longitude})
+		}
+		out.GeoFence.Polygons = append(out.GeoFence.Polygons, poly)
+	}
+
+	for _, fc := range fence.Circles {
+		var c qgcPlanCircle
+		c.Circle.Center = [2]float64{fc.Center.Latitude, fc.Center.Longitude}
+		c.Circle.Radius = float64(fc.Radius)
+		c.Inclusion = fc.Inclusion
+		c.Version = 1
+		out.GeoFence.Circles = append(out.GeoFence.Circles, c)
+	}
+
+	if r := fence.ReturnPoint; r != nil {
+		out.GeoFence.BreachReturn = &[3]float64{r.Latitude, r.Longitude, 0}
+	}
+
+	rally, err := RallyDecode(p.Rally)
+	if err != nil {
+		return err
+	}
+
+	// .plan files do not store the altitude frame of rally points
+	for _, pt := range rally {
+		out.RallyPoints.Points = append(out.RallyPoints.Points,
+			[3]float64{pt.Latitude, pt.Longitude, float64(pt.Altitude)})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	return enc.Encode(out)
+}
@@ -0,0 +1,207 @@
+package gomavlib
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testPlanFile = `{
+    "fileType": "Plan",
+    "geoFence": {
+        "circles": [
+            {
+                "circle": {
+                    "center": [47.3977, 8.5456],
+                    "radius": 50
+                },
+                "inclusion": false,
+                "version": 1
+            }
+        ],
+        "polygons": [
+            {
+                "inclusion": true,
+                "polygon": [
+                    [47.39, 8.54],
+                    [47.40, 8.54],
+                    [47.40, 8.55]
+                ],
+                "version": 1
+            }
+        ],
+        "version": 2
+    },
+    "groundStation": "QGroundControl",
+    "mission": {
+        "cruiseSpeed": 15,
+        "firmwareType": 12,
+        "hoverSpeed": 5,
+        "items": [
+            {
+                "AMSLAltAboveTerrain": null,
+                "Altitude": 50,
+                "AltitudeMode": 1,
+                "autoContinue": true,
+                "command": 22,
+                "doJumpId": 1,
+                "frame": 3,
+                "params": [15, 0, 0, null, 47.3977419, 8.5455938, 50],
+                "type": "SimpleItem"
+            },
+            {
+                "TransectStyleComplexItem": {
+                    "Items": [
+                        {
+                            "autoContinue": true,
+                            "command": 16,
+                            "doJumpId": 2,
+                            "frame": 3,
+                            "params": [0, 0, 0, null, 47.398, 8.546, 50],
+                            "type": "SimpleItem"
+                        },
+                        {
+                            "autoContinue": true,
+                            "command": 16,
+                            "doJumpId": 3,
+                            "frame": 3,
+                            "params": [0, 0, 0, null, 47.399, 8.546, 50],
+                            "type": "SimpleItem"
+                        }
+                    ]
+                },
+                "complexItemType": "survey",
+                "type": "ComplexItem",
+                "version": 5
+            },
+            {
+                "autoContinue": true,
+                "command": 20,
+                "doJumpId": 4,
+                "frame": 2,
+                "params": [0, 0, 0, 0, 0, 0, 0],
+                "type": "SimpleItem"
+            }
+        ],
+        "plannedHomePosition": [47.3977419, 8.5455938, 488.1],
+        "vehicleType": 2,
+        "version": 2
+    },
+    "rallyPoints": {
+        "points": [
+            [47.3980, 8.5460, 30]
+        ],
+        "version": 2
+    },
+    "version": 1
+}`
+
+// planClearNaN replaces unset parameters with zero, since NaN != NaN.
+func planClearNaN(items []*MissionItem) {
+	for _, item := range items {
+		for _, v := range []*float32{&item.Param1, &item.Param2, &item.Param3, &item.Param4, &item.Z} {
+			if math.IsNaN(float64(*v)) {
+				*v = 0
+			}
+		}
+	}
+}
+
+func TestPlanDecode(t *testing.T) {
+	p, err := PlanDecode(strings.NewReader(testPlanFile))
+	require.NoError(t, err)
+
+	require.Equal(t, 47.3977419, p.HomeLatitude)
+	require.Equal(t, 8.5455938, p.HomeLongitude)
+	require.Equal(t, 488.1, p.HomeAltitude)
+
+	require.Equal(t, 4, len(p.Mission))
+	require.True(t, math.IsNaN(float64(p.Mission[0].Param4)))
+	planClearNaN(p.Mission)
+	require.Equal(t, &MissionItem{
+		Frame:        3,
+		Command:      22,
+		Autocontinue: true,
+		Param1:       15,
+		X:            473977419,
+		Y:            85455938,
+		Z:            50,
+	}, p.Mission[0])
+	require.Equal(t, int32(473980000), p.Mission[1].X)
+	require.Equal(t, int32(473990000), p.Mission[2].X)
+	require.Equal(t, 20, p.Mission[3].Command)
+
+	require.Equal(t, []*MissionItem{
+		{Frame: 0, Command: 5001, Autocontinue: true, Param1: 3, X: 473900000, Y: 85400000},
+		{Frame: 0, Command: 5001, Autocontinue: true, Param1: 3, X: 474000000, Y: 85400000},
+		{Frame: 0, Command: 5001, Autocontinue: true, Param1: 3, X: 474000000, Y: 85500000},
+		{Frame: 0, Command: 5004, Autocontinue: true, Param1: 50, X: 473977000, Y: 85456000},
+	}, p.Fence)
+
+	require.Equal(t, []*MissionItem{
+		{Frame: 3, Command: 5100, Autocontinue: true, X: 473980000, Y: 85460000, Z: 30},
+	}, p.Rally)
+}
+
+func TestPlanDecodeErrors(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		file string
+		err  string
+	}{
+		{
+			"invalid json",
+			`{`,
+			"unexpected EOF",
+		},
+		{
+			"not a plan",
+			`{"fileType": "GeoFence"}`,
+			"file is not a plan",
+		},
+		{
+			"unsupported complex item",
+			`{"fileType": "Plan", "mission": {"items": [{"type": "ComplexItem", "complexItemType": "unknown"}]}}`,
+			"unsupported complex item: unknown",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			_, err := PlanDecode(strings.NewReader(ca.file))
+			require.EqualError(t, err, ca.err)
+		})
+	}
+}
+
+func TestPlanEncodeDecode(t *testing.T) {
+	p, err := PlanDecode(strings.NewReader(testPlanFile))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	err = PlanEncode(&buf, p)
+	require.NoError(t, err)
+
+	p2, err := PlanDecode(&buf)
+	require.NoError(t, err)
+
+	planClearNaN(p.Mission)
+	planClearNaN(p2.Mission)
+	require.Equal(t, p, p2)
+}
+
+func TestPlanEncodeErrors(t *testing.T) {
+	err := PlanEncode(&bytes.Buffer{}, &Plan{
+		Fence: []*MissionItem{
+			{Command: 5001, Param1: 3},
+			{Command: 5001, Param1: 3},
+		},
+	})
+	require.EqualError(t, err, "fence item 0: invalid vertex count (3)")
+
+	err = PlanEncode(&bytes.Buffer{}, &Plan{
+		Rally: []*MissionItem{{Command: 16}},
+	})
+	require.EqualError(t, err, "rally item 0: unsupported command (16)")
+}
@@ -0,0 +1,36 @@
+package gomavlib
+
+import (
+	"github.com/aler9/gomavlib/pkg/frame"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+// priorityWrite is a message that is written before the messages and frames
+// queued with normal priority.
+type priorityWrite struct {
+	what interface{}
+}
+
+// isHighPriority checks whether a message or frame is in
+// NodeConf.HighPriorityMessages.
+func (n *Node) isHighPriority(what interface{}) bool {
+	if len(n.highPriority) == 0 {
+		return false
+	}
+
+	var m msg.Message
+	switch wh := what.(type) {
+	case msg.Message:
+		m = wh
+
+	case frame.Frame:
+		m = wh.GetMessage()
+	}
+
+	if m == nil {
+		return false
+	}
+
+	_, ok := n.highPriority[m.GetID()]
+	return ok
+}
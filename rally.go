@@ -0,0 +1,81 @@
+package gomavlib
+
+import (
+	"context"
+	"fmt"
+)
+
+// RallyPoint is an alternative landing or return point, that is transferred
+// with the mission protocol in a plan of type MissionTypeRally.
+type RallyPoint struct {
+	// latitude, in degrees
+	Latitude float64
+	// longitude, in degrees
+	Longitude float64
+	// altitude, in meters
+	Altitude float32
+	// whether the altitude is above mean sea level (true) or above home (false)
+	AMSL bool
+}
+
+// RallyEncode converts rally points into the items of a rally plan.
+func RallyEncode(points []RallyPoint) []*MissionItem {
+	var items []*MissionItem
+
+	for _, pt := range points {
+		frame := planFrameGlobalRelativeAlt
+		if pt.AMSL {
+			frame = planFrameGlobal
+		}
+
+		items = append(items, &MissionItem{
+			Frame:        frame,
+			Command:      planCmdRallyPoint,
+			Autocontinue: true,
+			X:            planDegToInt(pt.Latitude),
+			Y:            planDegToInt(pt.Longitude),
+			Z:            pt.Altitude,
+		})
+	}
+
+	return items
+}
+
+// RallyDecode converts the items of a rally plan into rally points.
+func RallyDecode(items []*MissionItem) ([]RallyPoint, error) {
+	var points []RallyPoint
+
+	for i, item := range items {
+		if item.Command != planCmdRallyPoint {
+			return nil, fmt.Errorf("rally item %d: unsupported command (%d)", i, item.Command)
+		}
+
+		if item.Frame != planFrameGlobal && item.Frame != planFrameGlobalRelativeAlt {
+			return nil, fmt.Errorf("rally item %d: unsupported frame (%d)", i, item.Frame)
+		}
+
+		points = append(points, RallyPoint{
+			Latitude:  planIntToDeg(item.X),
+			Longitude: planIntToDeg(item.Y),
+			Altitude:  item.Z,
+			AMSL:      item.Frame == planFrameGlobal,
+		})
+	}
+
+	return points, nil
+}
+
+// UploadRally uploads rally points, replacing the existing ones.
+func (c *MissionClient) UploadRally(ctx context.Context, points []RallyPoint) error {
+	return c.Upload(ctx, MissionTypeRally, RallyEncode(points))
+}
+
+// DownloadRally downloads the rally points.
+func (c *MissionClient) DownloadRally(ctx context.Context) ([]RallyPoint, error) {
+	items, err := c.Download(ctx, MissionTypeRally)
+	if err != nil {
+		return nil, err
+	}
+
+	return RallyDecode(items)
+}
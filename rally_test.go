@@ -0,0 +1,87 @@
+package gomavlib
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+)
+
+var testRallyPoints = []RallyPoint{
+	{Latitude: 45.1, Longitude: 9.1, Altitude: 30},
+	{Latitude: 45.2, Longitude: 9.2, Altitude: 250, AMSL: true},
+}
+
+func TestRallyEncodeDecode(t *testing.T) {
+	items := RallyEncode(testRallyPoints)
+	require.Equal(t, []*MissionItem{
+		{
+			Frame:        planFrameGlobalRelativeAlt,
+			Command:      planCmdRallyPoint,
+			Autocontinue: true,
+			X:            451000000,
+			Y:            91000000,
+			Z:            30,
+		},
+		{
+			Frame:        planFrameGlobal,
+			Command:      planCmdRallyPoint,
+			Autocontinue: true,
+			X:            452000000,
+			Y:            92000000,
+			Z:            250,
+		},
+	}, items)
+
+	points, err := RallyDecode(items)
+	require.NoError(t, err)
+	require.Equal(t, testRallyPoints, points)
+}
+
+func TestRallyDecodeErrors(t *testing.T) {
+	_, err := RallyDecode([]*MissionItem{{Command: 16}})
+	require.EqualError(t, err, "rally item 0: unsupported command (16)")
+
+	_, err = RallyDecode([]*MissionItem{{Command: planCmdRallyPoint, Frame: 1}})
+	require.EqualError(t, err, "rally item 0: unsupported frame (1)")
+}
+
+func TestMissionClientRally(t *testing.T) {
+	node1, node2 := newTestNodePair(t, common.Dialect)
+	defer node1.Close()
+	defer node2.Close()
+
+	go func() {
+		for range node1.Events() {
+		}
+	}()
+	go func() {
+		for range node2.Events() {
+		}
+	}()
+
+	s, err := NewMissionServer(MissionServerConf{
+		Node: node2,
+	})
+	require.NoError(t, err)
+	defer s.Close()
+
+	c, err := NewMissionClient(MissionClientConf{
+		Node: node1,
+		Target: Target{
+			SystemID:    11,
+			ComponentID: 1,
+		},
+	})
+	require.NoError(t, err)
+
+	err = c.UploadRally(context.Background(), testRallyPoints)
+	require.NoError(t, err)
+	require.Equal(t, RallyEncode(testRallyPoints), s.Get(MissionTypeRally))
+
+	points, err := c.DownloadRally(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, testRallyPoints, points)
+}
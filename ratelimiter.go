@@ -0,0 +1,85 @@
+package gomavlib
+
+import (
+	"io"
+	"time"
+)
+
+// rateLimiter is a token bucket that limits the amount of bytes and frames
+// that can be written in a second.
+// Byte tokens are allowed to become negative, in order to support frames that
+// are bigger than the per-second byte budget.
+type rateLimiter struct {
+	bytesPerSecond  int
+	framesPerSecond int
+	byteTokens      float64
+	frameTokens     float64
+	last            time.Time
+}
+
+func newRateLimiter(bytesPerSecond int, framesPerSecond int, now time.Time) *rateLimiter {
+	return &rateLimiter{
+		bytesPerSecond:  bytesPerSecond,
+		framesPerSecond: framesPerSecond,
+		byteTokens:      float64(bytesPerSecond),
+		frameTokens:     float64(framesPerSecond),
+		last:            now,
+	}
+}
+
+func (l *rateLimiter) refill(now time.Time) {
+	elapsed := now.Sub(l.last).Seconds()
+	l.last = now
+
+	if l.bytesPerSecond > 0 {
+		l.byteTokens += elapsed * float64(l.bytesPerSecond)
+		if l.byteTokens > float64(l.bytesPerSecond) {
+			l.byteTokens = float64(l.bytesPerSecond)
+		}
+	}
+
+	if l.framesPerSecond > 0 {
+		l.frameTokens += elapsed * float64(l.framesPerSecond)
+		if l.frameTokens > float64(l.framesPerSecond) {
+			l.frameTokens = float64(l.framesPerSecond)
+		}
+	}
+}
+
+// allow checks whether a frame can be written, and consumes a frame token in
+// case it can. It must be called before the frame is encoded, in order not to
+// waste sequence numbers with discarded frames.
+func (l *rateLimiter) allow(now time.Time) bool {
+	l.refill(now)
+
+	if l.bytesPerSecond > 0 && l.byteTokens <= 0 {
+		return false
+	}
+	if l.framesPerSecond > 0 && l.frameTokens < 1 {
+		return false
+	}
+
+	if l.framesPerSecond > 0 {
+		l.frameTokens--
+	}
+	return true
+}
+
+// consume consumes the byte tokens of a frame that has been written.
+func (l *rateLimiter) consume(size int) {
+	if l.bytesPerSecond > 0 {
+		l.byteTokens -= float64(size)
+	}
+}
+
+// rateLimitedWriter is a writer that reports written bytes to a rate limiter.
+type rateLimitedWriter struct {
+	w io.Writer
+	l *rateLimiter
+}
+
+func (w *rateLimitedWriter) Write(buf []byte) (int, error) {
+	n, err := w.w.Write(buf)
+	w.l.consume(n)
+	return n, err
+}
@@ -0,0 +1,46 @@
+package gomavlib
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiterFrames(t *testing.T) {
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := newRateLimiter(0, 2, now)
+
+	require.True(t, l.allow(now))
+	require.True(t, l.allow(now))
+	require.False(t, l.allow(now))
+
+	now = now.Add(250 * time.Millisecond)
+	require.False(t, l.allow(now))
+
+	now = now.Add(250 * time.Millisecond)
+	require.True(t, l.allow(now))
+	require.False(t, l.allow(now))
+
+	// tokens do not exceed the per-second budget
+	now = now.Add(10 * time.Second)
+	require.True(t, l.allow(now))
+	require.True(t, l.allow(now))
+	require.False(t, l.allow(now))
+}
+
+func TestRateLimiterBytes(t *testing.T) {
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := newRateLimiter(100, 0, now)
+
+	// a frame bigger than the budget is allowed, then tokens become negative
+	require.True(t, l.allow(now))
+	l.consume(150)
+	require.False(t, l.allow(now))
+
+	now = now.Add(500 * time.Millisecond)
+	require.False(t, l.allow(now))
+
+	now = now.Add(10 * time.Millisecond)
+	require.True(t, l.allow(now))
+}
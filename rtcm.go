@@ -0,0 +1,227 @@
+package gomavlib
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+const (
+	rtcmPreamble      = 0xD3
+	rtcmFragmentSize  = 180
+	rtcmMaxFragments  = 4
+	rtcmDefaultRate   = 4096
+	rtcmSequenceCount = 32
+)
+
+var rtcmCRC24QTable = func() [256]uint32 {
+	var t [256]uint32
+	for i := range t {
+		crc := uint32(i) << 16
+		for j := 0; j < 8; j++ {
+			crc <<= 1
+			if crc&0x1000000 != 0 {
+				crc ^= 0x1864CFB
+			}
+		}
+		t[i] = crc & 0xFFFFFF
+	}
+	return t
+}()
+
+// rtcmCRC24Q computes the CRC-24Q checksum used by RTCM3 frames.
+func rtcmCRC24Q(buf []byte) uint32 {
+	crc := uint32(0)
+	for _, b := range buf {
+		crc = ((crc << 8) & 0xFFFFFF) ^ rtcmCRC24QTable[byte(crc>>16)^b]
+	}
+	return crc
+}
+
+// RTCMInjectorConf allows to configure a RTCMInjector.
+type RTCMInjectorConf struct {
+	// the node used to communicate.
+	// Its dialect must contain the GPS_RTCM_DATA message.
+	Node *Node
+	// (optional) the channel to which corrections are written.
+	// If nil, corrections are written to all channels.
+	Channel *Channel
+
+	// (optional) the maximum number of bytes of corrections that are sent
+	// per second. It defaults to 4096.
+	MaxRate int
+}
+
+// RTCMInjector is a io.WriteCloser that receives a RTCM3 byte stream, for
+// instance from a NTRIP caster or a base station, and sends each RTCM3
+// message to autopilots with one or more GPS_RTCM_DATA messages, setting
+// fragment flags and sequence ids.
+// Bytes that do not belong to valid RTCM3 messages are discarded, as well as
+// messages longer than 720 bytes, that cannot be fragmented.
+type RTCMInjector struct {
+	conf           RTCMInjectorConf
+	msgGpsRtcmData msg.Message
+	mutex          sync.Mutex
+	buf            []byte
+	sequenceID     int
+	nextWrite      time.Time
+	terminate      chan struct{}
+	closeOnce      sync.Once
+}
+
+// NewRTCMInjector allocates a RTCMInjector. See RTCMInjectorConf for the options.
+func NewRTCMInjector(conf RTCMInjectorConf) (*RTCMInjector, error) {
+	if conf.Node == nil {
+		return nil, fmt.Errorf("Node not provided")
+	}
+	if conf.MaxRate == 0 {
+		conf.MaxRate = rtcmDefaultRate
+	}
+
+	msgGpsRtcmData := dialectMessage(conf.Node.conf.Dialect, 233, 35)
+	if msgGpsRtcmData == nil {
+		return nil, fmt.Errorf("dialect does not contain GPS_RTCM_DATA")
+	}
+
+	return &RTCMInjector{
+		conf:           conf,
+		msgGpsRtcmData: msgGpsRtcmData,
+		terminate:      make(chan struct{}),
+	}, nil
+}
+
+// Close closes the injector. Pending writes are interrupted.
+func (r *RTCMInjector) Close() error {
+	r.closeOnce.Do(func() {
+		close(r.terminate)
+	})
+	return nil
+}
+
+// Write implements io.Writer. Complete RTCM3 messages are sent immediately;
+// incomplete ones are kept until the remaining bytes are written.
+// It blocks in order to respect MaxRate.
+func (r *RTCMInjector) Write(p []byte) (int, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.buf = append(r.buf, p...)
+
+	for {
+		frame, ok := r.nextFrame()
+		if !ok {
+			break
+		}
+
+		err := r.inject(frame)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return len(p), nil
+}
+
+// nextFrame extracts the next valid RTCM3 frame from the buffer.
+func (r *RTCMInjector) nextFrame() ([]byte, bool) {
+	for {
+		// discard bytes until the preamble
+		i := 0
+		for i < len(r.buf) && r.buf[i] != rtcmPreamble {
+			i++
+		}
+		r.buf = r.buf[i:]
+
+		if len(r.buf) < 3 {
+			return nil, false
+		}
+
+		frameLen := 3 + (int(r.buf[1]&0x03)<<8 | int(r.buf[2])) + 3
+		if len(r.buf) < frameLen {
+			return nil, false
+		}
+
+		frame := r.buf[:frameLen]
+		crc := uint32(frame[frameLen-3])<<16 | uint32(frame[frameLen-2])<<8 | uint32(frame[frameLen-1])
+
+		// the preamble belongs to garbage; restart from the following byte
+		if r.buf[1]&0xFC != 0 || rtcmCRC24Q(frame[:frameLen-3]) != crc {
+			r.buf = r.buf[1:]
+			continue
+		}
+
+		r.buf = r.buf[frameLen:]
+		return frame, true
+	}
+}
+
+// inject sends a RTCM3 frame with one or more GPS_RTCM_DATA messages.
+func (r *RTCMInjector) inject(frame []byte) error {
+	if len(frame) > rtcmFragmentSize*rtcmMaxFragments {
+		return nil
+	}
+
+	// a frame whose length is a multiple of the fragment size is followed by
+	// an empty fragment, in order to signal its end.
+	count := 1
+	if len(frame) > rtcmFragmentSize {
+		count = len(frame)/rtcmFragmentSize + 1
+		if count > rtcmMaxFragments {
+			count = rtcmMaxFragments
+		}
+	}
+
+	for i := 0; i < count; i++ {
+		chunk := frame[i*rtcmFragmentSize:]
+		if len(chunk) > rtcmFragmentSize {
+			chunk = chunk[:rtcmFragmentSize]
+		}
+
+		err := r.wait(len(chunk))
+		if err != nil {
+			return err
+		}
+
+		flags := r.sequenceID << 3
+		if count > 1 {
+			flags |= 0x01 | i<<1
+		}
+
+		m := newMessage(r.msgGpsRtcmData)
+		messageSet(m, "Flags", flags)
+		messageSet(m, "Len", len(chunk))
+		reflect.Copy(messageGet(m, "Data"), reflect.ValueOf(chunk))
+
+		if r.conf.Channel != nil {
+			r.conf.Node.WriteMessageTo(r.conf.Channel, m)
+		} else {
+			r.conf.Node.WriteMessageAll(m)
+		}
+	}
+
+	r.sequenceID = (r.sequenceID + 1) % rtcmSequenceCount
+	return nil
+}
+
+// wait waits until n bytes can be sent without exceeding MaxRate.
+func (r *RTCMInjector) wait(n int) error {
+	now := r.conf.Node.conf.Clock.Now()
+	if r.nextWrite.After(now) {
+		timerC, stopTimer := r.conf.Node.conf.Clock.NewTimer(r.nextWrite.Sub(now))
+		defer stopTimer()
+
+		select {
+		case <-timerC:
+		case <-r.terminate:
+			return fmt.Errorf("terminated")
+		}
+	} else {
+		r.nextWrite = now
+	}
+
+	r.nextWrite = r.nextWrite.Add(time.Duration(n) * time.Second / time.Duration(r.conf.MaxRate))
+	return nil
+}
@@ -0,0 +1,91 @@
+package gomavlib
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+)
+
+func rtcmFrame(payload []byte) []byte {
+	buf := []byte{rtcmPreamble, byte(len(payload) >> 8), byte(len(payload))}
+	buf = append(buf, payload...)
+	crc := rtcmCRC24Q(buf)
+	return append(buf, byte(crc>>16), byte(crc>>8), byte(crc))
+}
+
+func TestRTCMCRC24Q(t *testing.T) {
+	require.Equal(t, uint32(0xCDE703), rtcmCRC24Q([]byte("123456789")))
+}
+
+func TestRTCMInjector(t *testing.T) {
+	node1, node2 := newTestNodePair(t, common.Dialect)
+	defer node1.Close()
+	defer node2.Close()
+
+	go func() {
+		for range node1.Events() {
+		}
+	}()
+
+	inj, err := NewRTCMInjector(RTCMInjectorConf{
+		Node:    node1,
+		MaxRate: 10000,
+	})
+	require.NoError(t, err)
+	defer inj.Close()
+
+	short := rtcmFrame(make([]byte, 20))
+	long := make([]byte, 400)
+	for i := range long {
+		long[i] = byte(i)
+	}
+	long = rtcmFrame(long)
+	exact := rtcmFrame(make([]byte, 360-6))
+
+	var in []byte
+	in = append(in, 0x01, 0xD3, 0x02)
+	in = append(in, short...)
+	in = append(in, long...)
+	in = append(in, exact...)
+
+	// the stream is written in two parts
+	start := time.Now()
+	go func() {
+		inj.Write(in[:100]) //nolint:errcheck
+		inj.Write(in[100:]) //nolint:errcheck
+	}()
+
+	var msgs []*common.MessageGpsRtcmData
+	for evt := range node2.Events() {
+		if fr, ok := evt.(*EventFrame); ok {
+			msgs = append(msgs, fr.Message().(*common.MessageGpsRtcmData))
+			if len(msgs) == 1+3+3 {
+				break
+			}
+		}
+	}
+
+	// about 800 bytes at 10000 bytes per second
+	require.True(t, time.Since(start) >= 60*time.Millisecond)
+
+	require.Equal(t, uint8(0<<3), msgs[0].Flags)
+	require.Equal(t, short, msgs[0].Data[:msgs[0].Len])
+
+	var reassembled []byte
+	for i, m := range msgs[1:4] {
+		require.Equal(t, uint8(1<<3|i<<1|1), m.Flags)
+		reassembled = append(reassembled, m.Data[:m.Len]...)
+	}
+	require.Equal(t, long, reassembled)
+
+	// a message whose length is a multiple of the fragment size is
+	// terminated by an empty fragment
+	for i, m := range msgs[4:7] {
+		require.Equal(t, uint8(2<<3|i<<1|1), m.Flags)
+	}
+	require.Equal(t, uint8(180), msgs[5].Len)
+	require.Equal(t, uint8(0), msgs[6].Len)
+}
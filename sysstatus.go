@@ -0,0 +1,80 @@
+package gomavlib
+
+import (
+	"math"
+
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+// SysStatus is the general status of a system, that is periodically sent
+// with SYS_STATUS messages. See NodeConf.SysStatusProvider.
+type SysStatus struct {
+	// bitmask of MAV_SYS_STATUS_SENSOR, sensors that are present
+	SensorsPresent uint32
+	// bitmask of MAV_SYS_STATUS_SENSOR, sensors that are enabled
+	SensorsEnabled uint32
+	// bitmask of MAV_SYS_STATUS_SENSOR, sensors that are healthy
+	SensorsHealth uint32
+	// percent, the load of the main loop
+	Load float64
+	// volts, -1 if not provided
+	BatteryVoltage float64
+	// amperes, -1 if not provided
+	BatteryCurrent float64
+	// percent, -1 if not provided
+	BatteryRemaining int
+	// percent, communication drop rate
+	DropRateComm float64
+	// communication errors
+	ErrorsComm int
+	// autopilot-specific errors
+	ErrorsCount [4]int
+
+	// (optional) the extended state, that is sent with EXTENDED_SYS_STATE
+	// messages.
+	Extended *ExtendedSysState
+}
+
+// ExtendedSysState is the extended state of a system, that is sent with
+// EXTENDED_SYS_STATE messages.
+type ExtendedSysState struct {
+	// MAV_VTOL_STATE
+	VtolState int
+	// MAV_LANDED_STATE
+	LandedState int
+}
+
+func sysStatusEncode(tpl msg.Message, s *SysStatus) msg.Message {
+	voltage := float64(math.MaxUint16)
+	if s.BatteryVoltage >= 0 {
+		voltage = highLatencyClamp(s.BatteryVoltage*1000, 0, math.MaxUint16-1)
+	}
+
+	current := float64(-1)
+	if s.BatteryCurrent >= 0 {
+		current = highLatencyClamp(s.BatteryCurrent*100, 0, math.MaxInt16)
+	}
+
+	m := newMessage(tpl)
+	messageSet(m, "OnboardControlSensorsPresent", s.SensorsPresent)
+	messageSet(m, "OnboardControlSensorsEnabled", s.SensorsEnabled)
+	messageSet(m, "OnboardControlSensorsHealth", s.SensorsHealth)
+	messageSet(m, "Load", highLatencyClamp(s.Load*10, 0, 1000))
+	messageSet(m, "VoltageBattery", voltage)
+	messageSet(m, "CurrentBattery", current)
+	messageSet(m, "BatteryRemaining", highLatencyClamp(float64(s.BatteryRemaining), -1, 100))
+	messageSet(m, "DropRateComm", highLatencyClamp(s.DropRateComm*100, 0, 10000))
+	messageSet(m, "ErrorsComm", highLatencyClamp(float64(s.ErrorsComm), 0, math.MaxUint16))
+	messageSet(m, "ErrorsCount1", highLatencyClamp(float64(s.ErrorsCount[0]), 0, math.MaxUint16))
+	messageSet(m, "ErrorsCount2", highLatencyClamp(float64(s.ErrorsCount[1]), 0, math.MaxUint16))
+	messageSet(m, "ErrorsCount3", highLatencyClamp(float64(s.ErrorsCount[2]), 0, math.MaxUint16))
+	messageSet(m, "ErrorsCount4", highLatencyClamp(float64(s.ErrorsCount[3]), 0, math.MaxUint16))
+	return m
+}
+
+func extendedSysStateEncode(tpl msg.Message, s *ExtendedSysState) msg.Message {
+	m := newMessage(tpl)
+	messageSet(m, "VtolState", s.VtolState)
+	messageSet(m, "LandedState", s.LandedState)
+	return m
+}
@@ -0,0 +1,101 @@
+package gomavlib
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+)
+
+func TestNodeSysStatus(t *testing.T) {
+	l1 := newTestPipe()
+	l2 := newTestPipe()
+
+	vehicle, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 10,
+		Endpoints: []EndpointConf{
+			EndpointCustom{ReadWriteCloser: &testEndpoint{l1, l2}},
+		},
+		HeartbeatDisable: true,
+		SysStatusProvider: func() *SysStatus {
+			return &SysStatus{
+				SensorsPresent:   uint32(common.MAV_SYS_STATUS_SENSOR_3D_GYRO | common.MAV_SYS_STATUS_SENSOR_GPS),
+				SensorsEnabled:   uint32(common.MAV_SYS_STATUS_SENSOR_3D_GYRO | common.MAV_SYS_STATUS_SENSOR_GPS),
+				SensorsHealth:    uint32(common.MAV_SYS_STATUS_SENSOR_3D_GYRO),
+				Load:             35.5,
+				BatteryVoltage:   12.6,
+				BatteryCurrent:   -1,
+				BatteryRemaining: 80,
+				DropRateComm:     1.25,
+				ErrorsComm:       3,
+				ErrorsCount:      [4]int{1, 2, 3, 4},
+				Extended: &ExtendedSysState{
+					VtolState:   int(common.MAV_VTOL_STATE_MC),
+					LandedState: int(common.MAV_LANDED_STATE_IN_AIR),
+				},
+			}
+		},
+		SysStatusPeriod: 50 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	defer vehicle.Close()
+
+	gcs, err := NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 11,
+		Endpoints: []EndpointConf{
+			EndpointCustom{ReadWriteCloser: &testEndpoint{l2, l1}},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer gcs.Close()
+
+	go func() {
+		for range vehicle.Events() {
+		}
+	}()
+
+	var sysStatus *common.MessageSysStatus
+	var extendedSysState *common.MessageExtendedSysState
+
+	for evt := range gcs.Events() {
+		if fr, ok := evt.(*EventFrame); ok {
+			switch m := fr.Message().(type) {
+			case *common.MessageSysStatus:
+				sysStatus = m
+			case *common.MessageExtendedSysState:
+				extendedSysState = m
+			}
+			if sysStatus != nil && extendedSysState != nil {
+				break
+			}
+		}
+	}
+
+	require.Equal(t, &common.MessageSysStatus{
+		OnboardControlSensorsPresent: common.MAV_SYS_STATUS_SENSOR_3D_GYRO | common.MAV_SYS_STATUS_SENSOR_GPS,
+		OnboardControlSensorsEnabled: common.MAV_SYS_STATUS_SENSOR_3D_GYRO | common.MAV_SYS_STATUS_SENSOR_GPS,
+		OnboardControlSensorsHealth:  common.MAV_SYS_STATUS_SENSOR_3D_GYRO,
+		Load:                         355,
+		VoltageBattery:               12600,
+		CurrentBattery:               -1,
+		BatteryRemaining:             80,
+		DropRateComm:                 125,
+		ErrorsComm:                   3,
+		ErrorsCount1:                 1,
+		ErrorsCount2:                 2,
+		ErrorsCount3:                 3,
+		ErrorsCount4:                 4,
+	}, sysStatus)
+
+	require.Equal(t, &common.MessageExtendedSysState{
+		VtolState:   common.MAV_VTOL_STATE_MC,
+		LandedState: common.MAV_LANDED_STATE_IN_AIR,
+	}, extendedSysState)
+}
@@ -0,0 +1,28 @@
+package gomavlib
+
+// Target is a remote component, identified by its system id and component
+// id, that is reachable through a channel.
+type Target struct {
+	// (optional) the channel used to communicate with the component.
+	// If nil, messages are written to all channels and responses are
+	// accepted from any channel.
+	Channel *Channel
+	// the system id of the component
+	SystemID byte
+	// the component id of the component
+	ComponentID byte
+}
+
+func (t Target) matches(evt *EventFrame) bool {
+	return (t.Channel == nil || t.Channel == evt.Channel) &&
+		t.SystemID == evt.SystemID() &&
+		t.ComponentID == evt.ComponentID()
+}
+
+func (n *Node) writeMessageToTarget(t Target, m interface{}) {
+	if t.Channel != nil {
+		n.writeTo(t.Channel, m)
+	} else {
+		n.writeAll(m)
+	}
+}
@@ -0,0 +1,185 @@
+package gomavlib
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+const (
+	terrainEarthRadius = 6378100
+
+	// grid spacing reported by TERRAIN_REPORT before any TERRAIN_REQUEST
+	// has been received, equal to the Ardupilot default.
+	terrainDefaultSpacing = 100
+)
+
+// TerrainSource is a source of elevation data.
+type TerrainSource interface {
+	// Elevation returns the elevation of a point above mean sea level, in meters,
+	// and whether it is available.
+	Elevation(lat float64, lon float64) (float64, bool)
+}
+
+// TerrainSourceFunc is a function that implements TerrainSource.
+type TerrainSourceFunc func(lat float64, lon float64) (float64, bool)
+
+// Elevation implements TerrainSource.
+func (f TerrainSourceFunc) Elevation(lat float64, lon float64) (float64, bool) {
+	return f(lat, lon)
+}
+
+// TerrainServerConf allows to configure a TerrainServer.
+type TerrainServerConf struct {
+	// the node used to communicate.
+	// Its dialect must contain the TERRAIN_* messages.
+	Node *Node
+	// the source of elevation data.
+	Source TerrainSource
+}
+
+// TerrainServer implements the server side of the terrain protocol, that
+// allows to feed vehicles with terrain data. TERRAIN_REQUEST messages are
+// answered with TERRAIN_DATA, TERRAIN_CHECK messages with TERRAIN_REPORT.
+type TerrainServer struct {
+	conf             TerrainServerConf
+	msgTerrainData   msg.Message
+	msgTerrainReport msg.Message
+
+	// spacing of the last requested grid
+	spacing int64
+
+	frameService
+}
+
+// NewTerrainServer allocates a TerrainServer. See TerrainServerConf for the options.
+func NewTerrainServer(conf TerrainServerConf) (*TerrainServer, error) {
+	if conf.Node == nil {
+		return nil, fmt.Errorf("Node not provided")
+	}
+	if conf.Source == nil {
+		return nil, fmt.Errorf("Source not provided")
+	}
+
+	d := conf.Node.conf.Dialect
+	if dialectMessage(d, 133, 6) == nil ||
+		dialectMessage(d, 134, 229) == nil ||
+		dialectMessage(d, 135, 203) == nil ||
+		dialectMessage(d, 136, 1) == nil {
+		return nil, fmt.Errorf("dialect does not contain the terrain protocol messages")
+	}
+
+	s := &TerrainServer{
+		conf:             conf,
+		msgTerrainData:   dialectMessage(d, 134, 229),
+		msgTerrainReport: dialectMessage(d, 136, 1),
+		spacing:          terrainDefaultSpacing,
+	}
+
+	s.start(conf.Node, func(evt *EventFrame) bool {
+		id := evt.Message().GetID()
+		return id == 133 || id == 135
+	}, 64, s.run)
+
+	return s, nil
+}
+
+// Close stops the server.
+func (s *TerrainServer) Close() {
+	s.close()
+}
+
+func (s *TerrainServer) run() {
+	for {
+		select {
+		case evt := <-s.fw.frames:
+			if evt.Message().GetID() == 133 {
+				s.onRequest(evt)
+			} else {
+				s.onCheck(evt)
+			}
+
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// terrainOffset moves a point by the given distances, in meters.
+func terrainOffset(lat float64, lon float64, north float64, east float64) (float64, float64) {
+	lat2 := lat + (north/terrainEarthRadius)*180/math.Pi
+	lon2 := lon + (east/(terrainEarthRadius*math.Cos(lat*math.Pi/180)))*180/math.Pi
+	return lat2, lon2
+}
+
+func (s *TerrainServer) onRequest(evt *EventFrame) {
+	m := evt.Message()
+	lat := float64(messageGetInt(m, "Lat")) / 1e7
+	lon := float64(messageGetInt(m, "Lon")) / 1e7
+	spacing := float64(messageGetInt(m, "GridSpacing"))
+	mask := uint64(messageGetInt(m, "Mask"))
+
+	if spacing > 0 {
+		s.spacing = int64(spacing)
+	}
+
+	// the requested area is a 8x7 array of 4x4 grids; each bit of the mask
+	// corresponds to a grid.
+	for bit := 0; bit < 56; bit++ {
+		if (mask & (1 << bit)) == 0 {
+			continue
+		}
+
+		res, ok := s.grid(lat, lon, spacing, bit)
+		if !ok {
+			continue
+		}
+
+		messageSet(res, "Lat", messageGetInt(m, "Lat"))
+		messageSet(res, "Lon", messageGetInt(m, "Lon"))
+		messageSet(res, "GridSpacing", messageGetInt(m, "GridSpacing"))
+		s.conf.Node.WriteMessageTo(evt.Channel, res)
+	}
+}
+
+func (s *TerrainServer) onCheck(evt *EventFrame) {
+	m := evt.Message()
+	lat := float64(messageGetInt(m, "Lat")) / 1e7
+	lon := float64(messageGetInt(m, "Lon")) / 1e7
+
+	res := newMessage(s.msgTerrainReport)
+	messageSet(res, "Lat", messageGetInt(m, "Lat"))
+	messageSet(res, "Lon", messageGetInt(m, "Lon"))
+
+	// the spacing is zero when terrain is not available
+	if alt, ok := s.conf.Source.Elevation(lat, lon); ok {
+		messageSet(res, "Spacing", s.spacing)
+		messageSet(res, "TerrainHeight", alt)
+	}
+
+	s.conf.Node.WriteMessageTo(evt.Channel, res)
+}
+
+func (s *TerrainServer) grid(lat float64, lon float64, spacing float64, bit int) (msg.Message, bool) {
+	gridLat, gridLon := terrainOffset(lat, lon,
+		spacing*4*float64(bit/8), spacing*4*float64(bit%8))
+
+	res := newMessage(s.msgTerrainData)
+	messageSet(res, "Gridbit", bit)
+	data := messageGet(res, "Data")
+
+	for i := 0; i < 16; i++ {
+		pLat, pLon := terrainOffset(gridLat, gridLon,
+			spacing*float64(i/4), spacing*float64(i%4))
+
+		alt, ok := s.conf.Source.Elevation(pLat, pLon)
+		if !ok {
+			return nil, false
+		}
+
+		data.Index(i).SetInt(int64(math.Max(math.MinInt16, math.Min(math.MaxInt16, math.Round(alt)))))
+	}
+
+	return res, true
+}
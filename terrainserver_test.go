@@ -0,0 +1,97 @@
+package gomavlib
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+)
+
+func TestTerrainServer(t *testing.T) {
+	node1, node2 := newTestNodePair(t, common.Dialect)
+	defer node1.Close()
+	defer node2.Close()
+
+	go func() {
+		for range node2.Events() {
+		}
+	}()
+
+	// the elevation is equal to the distance from the origin,
+	// in meters, toward north.
+	s, err := NewTerrainServer(TerrainServerConf{
+		Node: node2,
+		Source: TerrainSourceFunc(func(lat float64, lon float64) (float64, bool) {
+			if lat < 45 {
+				return 0, false
+			}
+			return (lat - 45) * math.Pi / 180 * terrainEarthRadius, true
+		}),
+	})
+	require.NoError(t, err)
+	defer s.Close()
+
+	node1.WriteMessageAll(&common.MessageTerrainRequest{
+		Lat:         450000000,
+		Lon:         70000000,
+		GridSpacing: 100,
+		Mask:        1<<0 | 1<<9,
+	})
+
+	var received []*common.MessageTerrainData
+	for evt := range node1.Events() {
+		if fr, ok := evt.(*EventFrame); ok {
+			if m, ok := fr.Message().(*common.MessageTerrainData); ok {
+				received = append(received, m)
+				if len(received) == 2 {
+					break
+				}
+			}
+		}
+	}
+
+	require.Equal(t, &common.MessageTerrainData{
+		Lat:         450000000,
+		Lon:         70000000,
+		GridSpacing: 100,
+		Gridbit:     0,
+		Data: [16]int16{
+			0, 0, 0, 0,
+			100, 100, 100, 100,
+			200, 200, 200, 200,
+			300, 300, 300, 300,
+		},
+	}, received[0])
+
+	require.Equal(t, uint8(9), received[1].Gridbit)
+	require.Equal(t, int16(400), received[1].Data[0])
+	require.Equal(t, int16(700), received[1].Data[15])
+
+	check := func(lat int32) *common.MessageTerrainReport {
+		node1.WriteMessageAll(&common.MessageTerrainCheck{
+			Lat: lat,
+			Lon: 70000000,
+		})
+
+		for evt := range node1.Events() {
+			if fr, ok := evt.(*EventFrame); ok {
+				if m, ok := fr.Message().(*common.MessageTerrainReport); ok {
+					return m
+				}
+			}
+		}
+		return nil
+	}
+
+	res := check(450000000 + int32(math.Round(1000.0/terrainEarthRadius*180/math.Pi*1e7)))
+	require.Equal(t, uint16(100), res.Spacing)
+	require.InDelta(t, 1000, res.TerrainHeight, 0.1)
+
+	res = check(440000000)
+	require.Equal(t, &common.MessageTerrainReport{
+		Lat: 440000000,
+		Lon: 70000000,
+	}, res)
+}
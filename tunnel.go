@@ -0,0 +1,164 @@
+package gomavlib
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+const (
+	tunnelPayloadSize = 128
+)
+
+// TunnelConf allows to configure a Tunnel.
+type TunnelConf struct {
+	// the node used to communicate.
+	// Its dialect must contain the TUNNEL message.
+	Node *Node
+	// the other end of the tunnel.
+	Target Target
+
+	// (optional) the type of the payload (MAV_TUNNEL_PAYLOAD_TYPE).
+	// If zero, the type is adopted from the first message received from the
+	// target, and writes are blocked until then.
+	PayloadType int
+}
+
+// Tunnel is a io.ReadWriteCloser that transports a byte stream to and from
+// a remote component through TUNNEL messages.
+// Since messages can be lost, the stream is not reliable.
+type Tunnel struct {
+	conf        TunnelConf
+	msgTunnel   msg.Message
+	mutex       sync.Mutex
+	payloadType int
+	typeKnown   chan struct{}
+	readBuf     []byte
+	closeOnce   sync.Once
+	frameService
+}
+
+// NewTunnel allocates a Tunnel. See TunnelConf for the options.
+func NewTunnel(conf TunnelConf) (*Tunnel, error) {
+	if conf.Node == nil {
+		return nil, fmt.Errorf("Node not provided")
+	}
+
+	msgTunnel := dialectMessage(conf.Node.conf.Dialect, 385, 147)
+	if msgTunnel == nil {
+		return nil, fmt.Errorf("dialect does not contain TUNNEL")
+	}
+
+	t := &Tunnel{
+		conf:        conf,
+		msgTunnel:   msgTunnel,
+		payloadType: conf.PayloadType,
+		typeKnown:   make(chan struct{}),
+	}
+
+	if conf.PayloadType != 0 {
+		close(t.typeKnown)
+	}
+
+	t.start(conf.Node, t.isTunnel, 1024, nil)
+
+	return t, nil
+}
+
+// Close closes the tunnel.
+func (t *Tunnel) Close() error {
+	t.closeOnce.Do(func() {
+		t.close()
+	})
+	return nil
+}
+
+// PayloadType returns the type of the payload, that is zero until it is
+// either configured or adopted from the target.
+func (t *Tunnel) PayloadType() int {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return t.payloadType
+}
+
+func (t *Tunnel) isTunnel(evt *EventFrame) bool {
+	m := evt.Message()
+	if m.GetID() != 385 || !t.conf.Target.matches(evt) {
+		return false
+	}
+
+	sysID := byte(messageGetInt(m, "TargetSystem"))
+	compID := byte(messageGetInt(m, "TargetComponent"))
+	if sysID != t.conf.Node.conf.OutSystemID ||
+		(compID != t.conf.Node.conf.OutComponentID && compID != 0) {
+		return false
+	}
+
+	typ := int(messageGetInt(m, "PayloadType"))
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.payloadType == 0 && typ != 0 {
+		t.payloadType = typ
+		close(t.typeKnown)
+	}
+	return typ == t.payloadType
+}
+
+// Read implements io.Reader.
+func (t *Tunnel) Read(p []byte) (int, error) {
+	for len(t.readBuf) == 0 {
+		select {
+		case evt := <-t.fw.frames:
+			m := evt.Message()
+			t.readBuf = messageGetBytes(m, "Payload", int(messageGetInt(m, "PayloadLength")))
+
+		case <-t.ctx.Done():
+			return 0, io.EOF
+		}
+	}
+
+	n := copy(p, t.readBuf)
+	t.readBuf = t.readBuf[n:]
+	return n, nil
+}
+
+// Write implements io.Writer. Data is split into multiple messages.
+// If the type of the payload is not known yet, it waits until it is adopted
+// from the target.
+func (t *Tunnel) Write(p []byte) (int, error) {
+	select {
+	case <-t.typeKnown:
+	case <-t.ctx.Done():
+		return 0, fmt.Errorf("terminated")
+	}
+
+	select {
+	case <-t.ctx.Done():
+		return 0, fmt.Errorf("terminated")
+	default:
+	}
+
+	typ := t.PayloadType()
+
+	for i := 0; i < len(p); i += tunnelPayloadSize {
+		chunk := p[i:]
+		if len(chunk) > tunnelPayloadSize {
+			chunk = chunk[:tunnelPayloadSize]
+		}
+
+		m := newMessage(t.msgTunnel)
+		messageSet(m, "TargetSystem", t.conf.Target.SystemID)
+		messageSet(m, "TargetComponent", t.conf.Target.ComponentID)
+		messageSet(m, "PayloadType", typ)
+		messageSet(m, "PayloadLength", len(chunk))
+		reflect.Copy(messageGet(m, "Payload"), reflect.ValueOf(chunk))
+		t.conf.Node.writeMessageToTarget(t.conf.Target, m)
+	}
+
+	return len(p), nil
+}
@@ -0,0 +1,79 @@
+package gomavlib
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+)
+
+func TestTunnel(t *testing.T) {
+	node1, node2 := newTestNodePair(t, common.Dialect)
+	defer node1.Close()
+	defer node2.Close()
+
+	go func() {
+		for range node1.Events() {
+		}
+	}()
+	go func() {
+		for range node2.Events() {
+		}
+	}()
+
+	t1, err := NewTunnel(TunnelConf{
+		Node:        node1,
+		Target:      Target{SystemID: 11, ComponentID: 1},
+		PayloadType: 32800,
+	})
+	require.NoError(t, err)
+	defer t1.Close()
+
+	t2, err := NewTunnel(TunnelConf{
+		Node:   node2,
+		Target: Target{SystemID: 10, ComponentID: 1},
+	})
+	require.NoError(t, err)
+	defer t2.Close()
+
+	// writes are blocked until the payload type is adopted
+	writeDone := make(chan error)
+	go func() {
+		_, err := t2.Write([]byte("reply"))
+		writeDone <- err
+	}()
+
+	select {
+	case <-writeDone:
+		t.Fatal("write did not wait for the payload type")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	data := make([]byte, 300)
+	for i := range data {
+		data[i] = byte(i)
+	}
+
+	_, err = t1.Write(data)
+	require.NoError(t, err)
+
+	buf := make([]byte, 300)
+	_, err = io.ReadFull(t2, buf)
+	require.NoError(t, err)
+	require.Equal(t, data, buf)
+	require.Equal(t, 32800, t2.PayloadType())
+
+	require.NoError(t, <-writeDone)
+
+	buf = make([]byte, 5)
+	_, err = io.ReadFull(t1, buf)
+	require.NoError(t, err)
+	require.Equal(t, []byte("reply"), buf)
+
+	t2.Close()
+	_, err = t2.Read(buf)
+	require.Equal(t, io.EOF, err)
+}
@@ -11,7 +11,8 @@ var errorTerminated = fmt.Errorf("terminated")
 
 // netTimedConn forces a net.Conn to use timeouts
 type netTimedConn struct {
-	conn net.Conn
+	conn        net.Conn
+	readTimeout time.Duration
 }
 
 func (c *netTimedConn) Close() error {
@@ -19,13 +20,22 @@ func (c *netTimedConn) Close() error {
 }
 
 func (c *netTimedConn) Read(buf []byte) (int, error) {
-	err := c.conn.SetReadDeadline(time.Now().Add(netReadTimeout))
+	readTimeout := c.readTimeout
+	if readTimeout == 0 {
+		readTimeout = netReadTimeout
+	}
+
+	err := c.conn.SetReadDeadline(time.Now().Add(readTimeout))
 	if err != nil {
 		return 0, err
 	}
 	return c.conn.Read(buf)
 }
 
+func (c *netTimedConn) RemoteAddr() net.Addr {
+	return c.conn.RemoteAddr()
+}
+
 func (c *netTimedConn) Write(buf []byte) (int, error) {
 	err := c.conn.SetWriteDeadline(time.Now().Add(netWriteTimeout))
 	if err != nil {
@@ -9,12 +9,20 @@ const (
 
 	// V2 is Mavlink 2.0
 	V2 Version = 2
+
+	// VAuto is Mavlink 1.0 on each channel until a Mavlink 2.0 frame is
+	// received from it, then Mavlink 2.0, as recommended by the Mavlink
+	// specification.
+	VAuto Version = 3
 )
 
 // String implements fmt.Stringer.
 func (v Version) String() string {
-	if v == V1 {
+	switch v {
+	case V1:
 		return "V1"
+	case VAuto:
+		return "auto"
 	}
 	return "V2"
 }